@@ -83,6 +83,10 @@ func handleAnalyticsQuery(uc usecases.Usecases) func(c *gin.Context) {
 			results, err = uc.RuleCoOccurenceMatrix(c.Request.Context(), filters)
 		case "screening_hits":
 			results, err = uc.ScreeningHits(c.Request.Context(), filters)
+		case "rule_precision":
+			results, err = uc.RulePrecision(c.Request.Context(), filters)
+		case "rule_pair_precision":
+			results, err = uc.RulePairPrecision(c.Request.Context(), filters)
 
 		// The following endpoint use Postgres, for now, instead of DuckDB.
 
@@ -70,6 +70,7 @@ func handleClient360SearchObjects(uc usecases.Usecases) func(c *gin.Context) {
 			Table: payload.Table,
 			Terms: payload.Terms,
 			Page:  uint64(page),
+			AsOf:  payload.AsOf,
 		}
 
 		uc := usecasesWithCreds(ctx, uc)
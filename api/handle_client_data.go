@@ -23,8 +23,19 @@ func handleGetIngestedObject(uc usecases.Usecases) func(c *gin.Context) {
 		objectType := c.Param("object_type")
 		objectId := c.Param("object_id")
 
+		var params dto.GetClientObjectParams
+		if err := c.ShouldBindQuery(&params); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
 		usecase := usecasesWithCreds(ctx, uc).NewIngestedDataReaderUsecase()
-		objects, err := usecase.GetIngestedObject(ctx, organizationID, nil, objectType, objectId, "object_id")
+		var objects []models.ClientObjectDetail
+		if params.AsOf != nil {
+			objects, err = usecase.GetIngestedObjectAsOf(ctx, organizationID, objectType, objectId, *params.AsOf)
+		} else {
+			objects, err = usecase.GetIngestedObject(ctx, organizationID, nil, objectType, objectId, "object_id")
+		}
 		if presentError(ctx, c, err) {
 			return
 		}
@@ -43,6 +54,32 @@ func handleGetIngestedObject(uc usecases.Usecases) func(c *gin.Context) {
 	}
 }
 
+func handleGetIngestedObjectHistory(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationID, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		objectType := c.Param("object_type")
+		objectId := c.Param("object_id")
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestedDataReaderUsecase()
+		versions, err := usecase.GetIngestedObjectHistory(ctx, organizationID, objectType, objectId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		if len(versions) == 0 {
+			c.JSON(http.StatusNotFound, nil)
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(versions, dto.AdaptClientObjectVersionDto))
+	}
+}
+
 func handleReadClientDataAsList(uc usecases.Usecases) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
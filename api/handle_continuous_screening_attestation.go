@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleListContinuousScreeningAttestationReports(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var paginationAndSortingDto dto.PaginationAndSorting
+		if err := c.ShouldBind(&paginationAndSortingDto); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+		paginationAndSorting := models.WithPaginationDefaults(
+			dto.AdaptPaginationAndSorting(paginationAndSortingDto),
+			continuousScreeningPaginationDefaults,
+		)
+
+		uc := usecasesWithCreds(ctx, uc).NewContinuousScreeningAttestationReportUsecase()
+		reports, err := uc.ListAttestationReports(ctx, organizationId, paginationAndSorting)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.Paginated[dto.ContinuousScreeningAttestationReportDto]{
+			Items:       pure_utils.Map(reports.Items, dto.AdaptContinuousScreeningAttestationReportDto),
+			HasNextPage: reports.HasNextPage,
+		})
+	}
+}
+
+func handleDownloadContinuousScreeningAttestationReport(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		reportId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		format, ok := models.ContinuousScreeningAttestationReportFormatFrom(
+			c.DefaultQuery("format", string(models.ContinuousScreeningAttestationReportFormatCsv)))
+		if !ok {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "format must be either csv or json"))
+			return
+		}
+
+		uc := usecasesWithCreds(ctx, uc).NewContinuousScreeningAttestationReportUsecase()
+		url, err := uc.GetAttestationReportDownloadUrl(ctx, organizationId, reportId, format)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"url": url})
+	}
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleListContinuousScreeningCoverageAnalyses(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var paginationAndSortingDto dto.PaginationAndSorting
+		if err := c.ShouldBind(&paginationAndSortingDto); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+		paginationAndSorting := models.WithPaginationDefaults(
+			dto.AdaptPaginationAndSorting(paginationAndSortingDto),
+			continuousScreeningPaginationDefaults,
+		)
+
+		uc := usecasesWithCreds(ctx, uc).NewContinuousScreeningCoverageAnalysisUsecase()
+		analyses, err := uc.ListCoverageAnalyses(ctx, organizationId, paginationAndSorting)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.Paginated[dto.ContinuousScreeningCoverageAnalysisDto]{
+			Items:       pure_utils.Map(analyses.Items, dto.AdaptContinuousScreeningCoverageAnalysisDto),
+			HasNextPage: analyses.HasNextPage,
+		})
+	}
+}
+
+func handleRequestContinuousScreeningCoverageAnalysis(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		uc := usecasesWithCreds(ctx, uc).NewContinuousScreeningCoverageAnalysisUsecase()
+		if presentError(ctx, c, uc.RequestCoverageAnalysis(ctx, organizationId)) {
+			return
+		}
+
+		c.Status(http.StatusAccepted)
+	}
+}
+
+func handleListContinuousScreeningCoverageGaps(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		analysisId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		var filters models.ContinuousScreeningCoverageGapFilters
+		if gapTypeParam := c.Query("gap_type"); gapTypeParam != "" {
+			gapType, ok := models.ContinuousScreeningCoverageGapTypeFrom(gapTypeParam)
+			if !ok {
+				presentError(ctx, c, errors.Wrap(models.BadParameterError,
+					"gap_type must be either unmonitored or orphaned"))
+				return
+			}
+			filters.GapType = &gapType
+		}
+
+		var paginationAndSortingDto dto.PaginationAndSorting
+		if err := c.ShouldBind(&paginationAndSortingDto); err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{Message: err.Error()})
+			return
+		}
+		paginationAndSorting := models.WithPaginationDefaults(
+			dto.AdaptPaginationAndSorting(paginationAndSortingDto),
+			continuousScreeningPaginationDefaults,
+		)
+
+		uc := usecasesWithCreds(ctx, uc).NewContinuousScreeningCoverageAnalysisUsecase()
+		gaps, err := uc.ListCoverageGaps(ctx, organizationId, analysisId, filters, paginationAndSorting)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.Paginated[dto.ContinuousScreeningCoverageGapDto]{
+			Items:       pure_utils.Map(gaps.Items, dto.AdaptContinuousScreeningCoverageGapDto),
+			HasNextPage: gaps.HasNextPage,
+		})
+	}
+}
+
+func handleBackfillContinuousScreeningCoverageAnalysis(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		analysisId, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		// The body is optional, for a one-click backfill
+		var input dto.ContinuousScreeningCoverageBackfillDto
+		if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		uc := usecasesWithCreds(ctx, uc).NewContinuousScreeningCoverageAnalysisUsecase()
+		result, err := uc.BackfillCoverageAnalysis(ctx, organizationId, analysisId,
+			models.ContinuousScreeningCoverageBackfill{
+				ConfigStableId: input.ConfigStableId,
+				ShouldScreen:   input.ShouldScreen,
+			})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ContinuousScreeningCoverageBackfillResultDto{
+			EnqueuedCount: result.EnqueuedCount,
+			SkippedCount:  result.SkippedCount,
+		})
+	}
+}
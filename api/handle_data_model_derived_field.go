@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+)
+
+func handleSetFieldDerivation(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var payload dto.SetFieldDerivationInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		expression, err := dto.AdaptASTNode(payload.Expression)
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDerivedFieldUsecase()
+		backfill, err := usecase.SetFieldDerivation(ctx, c.Param("fieldID"), expression)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptDerivedFieldBackfill(backfill))
+	}
+}
+
+func handleRemoveFieldDerivation(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		usecase := usecasesWithCreds(ctx, uc).NewDerivedFieldUsecase()
+		if err := usecase.RemoveFieldDerivation(ctx, c.Param("fieldID")); presentError(ctx, c, err) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleListDerivedFieldBackfills(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		usecase := usecasesWithCreds(ctx, uc).NewDerivedFieldUsecase()
+		backfills, err := usecase.ListDerivedFieldBackfills(ctx, c.Param("fieldID"))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(backfills, dto.AdaptDerivedFieldBackfill))
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleCreateFieldTypeMigration(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var payload dto.CreateFieldTypeMigrationInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		input, err := dto.AdaptCreateFieldTypeMigrationInput(c.Param("fieldID"), payload)
+		if presentError(ctx, c, err) {
+			return
+		}
+		dryRun := c.Query("perform") != "true"
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataModelFieldTypeUsecase()
+		report, err := usecase.CreateFieldTypeMigration(ctx, dryRun, input)
+		if errors.Is(err, models.ConflictError) && report.HasConflicts() {
+			c.JSON(http.StatusConflict, dto.AdaptFieldTypeMigrationReport(report))
+			return
+		}
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptFieldTypeMigrationReport(report))
+	}
+}
+
+func handleListFieldTypeMigrations(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataModelFieldTypeUsecase()
+		migrations, err := usecase.ListFieldTypeMigrations(ctx, c.Param("fieldID"))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(migrations, dto.AdaptFieldTypeMigration))
+	}
+}
+
+func handleGetFieldTypeMigration(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		migrationId, err := uuid.Parse(c.Param("migrationID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid migration id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataModelFieldTypeUsecase()
+		migration, failures, err := usecase.GetFieldTypeMigration(ctx, migrationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptFieldTypeMigrationWithFailures(migration, failures))
+	}
+}
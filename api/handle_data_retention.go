@@ -0,0 +1,149 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleCreateDataRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.CreateDataRetentionPolicyInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataRetentionUsecase()
+		policy, err := usecase.CreateDataRetentionPolicy(ctx, models.CreateDataRetentionPolicyInput{
+			OrganizationId:         organizationId,
+			Target:                 models.DataRetentionTarget(payload.Target),
+			TableId:                payload.TableId,
+			SupersededVersionsDays: payload.SupersededVersionsDays,
+			MaxAgeDays:             payload.MaxAgeDays,
+			Enabled:                payload.Enabled == nil || *payload.Enabled,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.AdaptDataRetentionPolicy(policy))
+	}
+}
+
+func handleListDataRetentionPolicies(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataRetentionUsecase()
+		policies, err := usecase.ListDataRetentionPolicies(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(policies, dto.AdaptDataRetentionPolicy))
+	}
+}
+
+func handleGetDataRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		policyId, err := uuid.Parse(c.Param("policyID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid retention policy id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataRetentionUsecase()
+		policy, err := usecase.GetDataRetentionPolicy(ctx, policyId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptDataRetentionPolicy(policy))
+	}
+}
+
+func handleUpdateDataRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		policyId, err := uuid.Parse(c.Param("policyID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid retention policy id"))
+			return
+		}
+
+		var payload dto.UpdateDataRetentionPolicyInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataRetentionUsecase()
+		policy, err := usecase.UpdateDataRetentionPolicy(ctx, models.UpdateDataRetentionPolicyInput{
+			Id:                     policyId,
+			SupersededVersionsDays: payload.SupersededVersionsDays,
+			MaxAgeDays:             payload.MaxAgeDays,
+			Enabled:                payload.Enabled,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptDataRetentionPolicy(policy))
+	}
+}
+
+func handleDeleteDataRetentionPolicy(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		policyId, err := uuid.Parse(c.Param("policyID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid retention policy id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataRetentionUsecase()
+		if presentError(ctx, c, usecase.DeleteDataRetentionPolicy(ctx, policyId)) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleListDataRetentionRuns(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		policyId, err := uuid.Parse(c.Param("policyID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid retention policy id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataRetentionUsecase()
+		runs, err := usecase.ListDataRetentionRuns(ctx, policyId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(runs, dto.AdaptDataRetentionRun))
+	}
+}
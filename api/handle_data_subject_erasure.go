@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleCreateDataSubjectErasure(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.CreateDataSubjectErasureInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		dryRun := c.Query("perform") != "true"
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataSubjectErasureUsecase()
+		report, err := usecase.CreateDataSubjectErasure(ctx, dryRun, models.CreateDataSubjectErasureInput{
+			OrganizationId: organizationId,
+			ObjectType:     payload.ObjectType,
+			ObjectId:       payload.ObjectId,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		status := http.StatusOK
+		if report.Erasure != nil {
+			status = http.StatusCreated
+		}
+		c.JSON(status, dto.AdaptDataSubjectErasureReport(report))
+	}
+}
+
+func handleListDataSubjectErasures(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataSubjectErasureUsecase()
+		erasures, err := usecase.ListDataSubjectErasures(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(erasures, dto.AdaptDataSubjectErasure))
+	}
+}
+
+func handleGetDataSubjectErasure(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		erasureId, err := uuid.Parse(c.Param("erasureID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid erasure id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewDataSubjectErasureUsecase()
+		erasure, err := usecase.GetDataSubjectErasure(ctx, erasureId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptDataSubjectErasure(erasure))
+	}
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"regexp"
 
@@ -205,6 +206,7 @@ func handleUpdateDataModelField(uc usecases.Usecases) func(c *gin.Context) {
 			Alias:        input.Alias,
 			SemanticType: fieldSemanticType,
 			Metadata:     input.Metadata,
+			Constraints:  dto.AdaptFieldConstraintsUpdate(input.Constraints),
 		})
 		if presentError(ctx, c, err) {
 			return
@@ -267,23 +269,13 @@ func handleGetOpenAPI(uc usecases.Usecases) func(c *gin.Context) {
 			return
 		}
 
-		organizationID, err := utils.OrganizationIdFromRequest(c.Request)
-		if presentError(ctx, c, err) {
-			return
-		}
-
 		openapi, err := pubapi.GetOpenApiForVersion(c.Param("version"))
 		if presentError(ctx, c, err) {
 			return
 		}
 
-		usecase := usecasesWithCreds(ctx, uc).NewDataModelUseCase()
-		dataModel, err := usecase.GetDataModel(ctx, organizationID, models.DataModelReadOptions{
-			IncludeEnums:              false,
-			IncludeNavigationOptions:  false,
-			IncludeUnicityConstraints: false,
-		}, false)
-		if presentError(ctx, c, err) {
+		dataModel, ok := dataModelForSchemaExport(c, uc)
+		if !ok {
 			return
 		}
 
@@ -296,6 +288,67 @@ func handleGetOpenAPI(uc usecases.Usecases) func(c *gin.Context) {
 	}
 }
 
+func handleGetDataModelJSONSchema(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		dataModel, ok := dataModelForSchemaExport(c, uc)
+		if !ok {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.JSONSchemaFromDataModel(dataModel))
+	}
+}
+
+func handleGetTableJSONSchema(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		dataModel, ok := dataModelForSchemaExport(c, uc)
+		if !ok {
+			return
+		}
+		table, found := dataModel.Tables[c.Param("tableName")]
+		if !found {
+			presentError(ctx, c, errors.Wrapf(models.NotFoundError,
+				"table %s not found in data model", c.Param("tableName")))
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.JSONSchemaFromTable(dataModel, table))
+	}
+}
+
+// dataModelForSchemaExport reads the data model of the organization for the schemas generated from
+// it, enum values included. The schema hash of the data model and the hash of its enum values are
+// their ETag, and nothing more is returned if the client already has the same schemas.
+func dataModelForSchemaExport(c *gin.Context, uc usecases.Usecases) (models.DataModel, bool) {
+	ctx := c.Request.Context()
+
+	organizationID, err := utils.OrganizationIdFromRequest(c.Request)
+	if presentError(ctx, c, err) {
+		return models.DataModel{}, false
+	}
+
+	usecase := usecasesWithCreds(ctx, uc).NewDataModelUseCase()
+	dataModel, err := usecase.GetDataModel(ctx, organizationID, models.DataModelReadOptions{
+		IncludeEnums:              true,
+		IncludeNavigationOptions:  false,
+		IncludeUnicityConstraints: false,
+	}, false)
+	if presentError(ctx, c, err) {
+		return models.DataModel{}, false
+	}
+
+	etag := fmt.Sprintf("%q", dataModel.SchemaHash()+"."+dataModel.EnumValuesHash()[:12])
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return models.DataModel{}, false
+	}
+
+	return dataModel, true
+}
+
 func handleCreateNavigationOption(uc usecases.Usecases) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
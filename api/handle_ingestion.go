@@ -1,7 +1,6 @@
 package api
 
 import (
-	"encoding/csv"
 	"fmt"
 	"net/http"
 
@@ -36,14 +35,14 @@ func handlePostCsvIngestion(uc usecases.Usecases) func(c *gin.Context) {
 			return
 		}
 
-		file, _, err := c.Request.FormFile("file")
+		file, fileHeader, err := c.Request.FormFile("file")
 		if err != nil {
 			http.Error(c.Writer, err.Error(), http.StatusBadRequest)
 			return
 		}
 		defer file.Close()
 
-		fileReader := csv.NewReader(pure_utils.NewReaderWithoutBom(file))
+		fileFormat := models.IngestionFileFormatOfUpload(fileHeader.Header.Get("Content-Type"), fileHeader.Filename)
 		objectType := c.Param("object_type")
 
 		ingestionOptions := models.IngestionOptions{
@@ -59,8 +58,8 @@ func handlePostCsvIngestion(uc usecases.Usecases) func(c *gin.Context) {
 		}
 
 		ingestionUseCase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
-		uploadLog, err := ingestionUseCase.ValidateAndUploadIngestionCsv(ctx,
-			organizationId, userId, objectType, fileReader, ingestionOptions)
+		uploadLog, err := ingestionUseCase.ValidateAndUploadIngestionFile(ctx,
+			organizationId, userId, objectType, fileFormat, file, ingestionOptions)
 
 		if presentError(ctx, c, err) {
 			return
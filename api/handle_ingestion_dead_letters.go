@@ -0,0 +1,142 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var ingestionDeadLetterPaginationDefaults = models.PaginationDefaults{
+	Limit:  25,
+	SortBy: models.SortingFieldCreatedAt,
+	Order:  models.SortingOrderDesc,
+}
+
+func handleListIngestionDeadLetters(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var filters dto.IngestionDeadLetterFilters
+		if err := c.ShouldBindQuery(&filters); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+		var paginationAndSortingDto dto.PaginationAndSorting
+		if err := c.ShouldBindQuery(&paginationAndSortingDto); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+		paginationAndSorting := models.WithPaginationDefaults(
+			dto.AdaptPaginationAndSorting(paginationAndSortingDto),
+			ingestionDeadLetterPaginationDefaults,
+		)
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
+		deadLetters, err := usecase.ListIngestionDeadLetters(ctx, organizationId,
+			dto.AdaptIngestionDeadLetterFilters(filters), paginationAndSorting)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.Paginated[dto.IngestionDeadLetter]{
+			Items:       pure_utils.Map(deadLetters.Items, dto.AdaptIngestionDeadLetter),
+			HasNextPage: deadLetters.HasNextPage,
+		})
+	}
+}
+
+func handleGetIngestionDeadLetter(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		deadLetterId, err := uuid.Parse(c.Param("deadLetterID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid dead letter id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
+		deadLetter, err := usecase.GetIngestionDeadLetter(ctx, deadLetterId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptIngestionDeadLetterWithPayload(deadLetter))
+	}
+}
+
+func handleReplayIngestionDeadLetter(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		deadLetterId, err := uuid.Parse(c.Param("deadLetterID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid dead letter id"))
+			return
+		}
+
+		// The body is optional, the stored payload is replayed as is without one
+		var payload dto.ReplayIngestionDeadLetterInput
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&payload); err != nil {
+				presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+				return
+			}
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
+		deadLetter, err := usecase.ReplayIngestionDeadLetter(ctx, deadLetterId, payload.Payload)
+		var validationErrors models.IngestionValidationErrors
+		if errors.As(err, &validationErrors) {
+			c.JSON(http.StatusBadRequest, dto.APIErrorResponse{
+				Message:   "the payload was rejected again",
+				Details:   validationErrors,
+				ErrorCode: dto.SchemaMismatchError,
+			})
+			return
+		}
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptIngestionDeadLetterWithPayload(deadLetter))
+	}
+}
+
+func handleReplayIngestionDeadLetters(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.ReplayIngestionDeadLettersInput
+		if c.Request.ContentLength != 0 {
+			if err := c.ShouldBindJSON(&payload); err != nil {
+				presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+				return
+			}
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionUseCase()
+		report, err := usecase.ReplayIngestionDeadLetters(ctx, organizationId, models.IngestionDeadLetterFilters{
+			ObjectType:  payload.ObjectType,
+			UploadLogId: payload.UploadLogId,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptIngestionDeadLetterReplayReport(report))
+	}
+}
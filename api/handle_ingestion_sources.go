@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleCreateIngestionSource(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.CreateIngestionSourceInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionSourceUsecase()
+		source, err := usecase.CreateIngestionSource(ctx, models.CreateIngestionSourceInput{
+			OrganizationId: organizationId,
+			Name:           payload.Name,
+			BucketUrl:      payload.BucketUrl,
+			Prefix:         payload.Prefix,
+			FileMappings:   pure_utils.Map(payload.FileMappings, dto.IngestionSourceFileMapping.ToModel),
+			Enabled:        payload.Enabled == nil || *payload.Enabled,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.AdaptIngestionSource(source))
+	}
+}
+
+func handleListIngestionSources(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionSourceUsecase()
+		sources, err := usecase.ListIngestionSources(ctx, organizationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(sources, dto.AdaptIngestionSource))
+	}
+}
+
+func handleGetIngestionSource(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		sourceId, err := uuid.Parse(c.Param("sourceID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid ingestion source id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionSourceUsecase()
+		source, err := usecase.GetIngestionSource(ctx, sourceId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptIngestionSource(source))
+	}
+}
+
+func handleUpdateIngestionSource(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		sourceId, err := uuid.Parse(c.Param("sourceID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid ingestion source id"))
+			return
+		}
+
+		var payload dto.UpdateIngestionSourceInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionSourceUsecase()
+		source, err := usecase.UpdateIngestionSource(ctx, models.UpdateIngestionSourceInput{
+			Id:           sourceId,
+			Name:         payload.Name,
+			BucketUrl:    payload.BucketUrl,
+			Prefix:       payload.Prefix,
+			FileMappings: pure_utils.Map(payload.FileMappings, dto.IngestionSourceFileMapping.ToModel),
+			Enabled:      payload.Enabled,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptIngestionSource(source))
+	}
+}
+
+func handleDeleteIngestionSource(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		sourceId, err := uuid.Parse(c.Param("sourceID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid ingestion source id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionSourceUsecase()
+		if presentError(ctx, c, usecase.DeleteIngestionSource(ctx, sourceId)) {
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func handleListIngestionSourceFiles(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		sourceId, err := uuid.Parse(c.Param("sourceID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid ingestion source id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewIngestionSourceUsecase()
+		files, err := usecase.ListIngestionSourceFiles(ctx, sourceId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(files, dto.AdaptIngestionSourceFile))
+	}
+}
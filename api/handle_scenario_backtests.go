@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleCreateScenarioBacktest(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.CreateScenarioBacktestInput
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioBacktestUsecase()
+		backtest, err := usecase.CreateScenarioBacktest(ctx, models.CreateScenarioBacktestInput{
+			OrganizationId:      organizationId,
+			ScenarioId:          payload.ScenarioId,
+			ScenarioIterationId: payload.TestIterationId,
+			WindowStart:         payload.WindowStart,
+			WindowEnd:           payload.WindowEnd,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.AdaptScenarioBacktest(backtest))
+	}
+}
+
+func handleListScenarioBacktests(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var scenarioId *uuid.UUID
+		if param := c.Query("scenario_id"); param != "" {
+			id, err := uuid.Parse(param)
+			if err != nil {
+				presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid scenario id"))
+				return
+			}
+			scenarioId = &id
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioBacktestUsecase()
+		backtests, err := usecase.ListScenarioBacktests(ctx, organizationId, scenarioId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(backtests, dto.AdaptScenarioBacktest))
+	}
+}
+
+func handleGetScenarioBacktest(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		backtestId, err := uuid.Parse(c.Param("backtestID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid backtest id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioBacktestUsecase()
+		backtest, err := usecase.GetScenarioBacktest(ctx, backtestId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptScenarioBacktest(backtest))
+	}
+}
+
+func handleCancelScenarioBacktest(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		backtestId, err := uuid.Parse(c.Param("backtestID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid backtest id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioBacktestUsecase()
+		backtest, err := usecase.CancelScenarioBacktest(ctx, backtestId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptScenarioBacktest(backtest))
+	}
+}
+
+func handleGetScenarioBacktestOutcomes(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		backtestId, err := uuid.Parse(c.Param("backtestID"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid backtest id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioBacktestUsecase()
+		counts, err := usecase.GetScenarioBacktestOutcomes(ctx, backtestId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(counts, dto.AdaptScenarioBacktestOutcomeCount))
+	}
+}
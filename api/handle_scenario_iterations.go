@@ -342,6 +342,26 @@ func handleValidateScenarioIteration(uc usecases.Usecases) func(c *gin.Context)
 	}
 }
 
+func handleDiffScenarioIterations(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var query struct {
+			BaseIterationId string `form:"base_iteration_id" binding:"required"`
+		}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioIterationUsecase()
+		diff, err := usecase.DiffScenarioIterations(ctx, query.BaseIterationId, c.Param("iteration_id"))
+		if presentError(ctx, c, err) {
+			return
+		}
+		c.JSON(http.StatusOK, dto.AdaptScenarioIterationDiff(diff))
+	}
+}
+
 func handleCommitScenarioIterationVersion(uc usecases.Usecases) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleListScenarioPublicationRequests(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var filters models.ListScenarioPublicationRequestsFilters
+		if scenarioId := c.Query("scenario_id"); scenarioId != "" {
+			filters.ScenarioId = &scenarioId
+		}
+		if status := c.Query("status"); status != "" {
+			filters.Status = utils.Ptr(models.ScenarioPublicationRequestStatus(status))
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationRequestUsecase()
+		requests, err := usecase.ListScenarioPublicationRequests(ctx, organizationId, filters)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, pure_utils.Map(requests, dto.AdaptScenarioPublicationRequest))
+	}
+}
+
+func handleCreateScenarioPublicationRequest(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.CreateScenarioPublicationRequestBody
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationRequestUsecase()
+		request, err := usecase.CreateScenarioPublicationRequest(ctx,
+			dto.AdaptCreateScenarioPublicationRequestBody(organizationId, payload))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.AdaptScenarioPublicationRequest(request))
+	}
+}
+
+func handleGetScenarioPublicationRequest(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		requestId, err := uuid.Parse(c.Param("request_id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid publication request id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationRequestUsecase()
+		request, err := usecase.GetScenarioPublicationRequest(ctx, requestId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptScenarioPublicationRequest(request))
+	}
+}
+
+func handleReviewScenarioPublicationRequest(uc usecases.Usecases, approved bool) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		requestId, err := uuid.Parse(c.Param("request_id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid publication request id"))
+			return
+		}
+
+		var payload dto.ReviewScenarioPublicationRequestBody
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationRequestUsecase()
+		request, err := usecase.ReviewScenarioPublicationRequest(ctx, models.ReviewScenarioPublicationRequestInput{
+			Id:       requestId,
+			Approved: approved,
+			Comment:  payload.Comment,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptScenarioPublicationRequest(request))
+	}
+}
+
+func handleCancelScenarioPublicationRequest(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		requestId, err := uuid.Parse(c.Param("request_id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid publication request id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationRequestUsecase()
+		request, err := usecase.CancelScenarioPublicationRequest(ctx, requestId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptScenarioPublicationRequest(request))
+	}
+}
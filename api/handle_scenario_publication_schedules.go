@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func handleCreateScenarioPublicationSchedule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		organizationId, err := utils.OrganizationIdFromRequest(c.Request)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		var payload dto.CreateScenarioPublicationScheduleBody
+		if err := c.ShouldBindJSON(&payload); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationScheduleUsecase()
+		schedule, err := usecase.CreateScenarioPublicationSchedule(ctx,
+			dto.AdaptCreateScenarioPublicationScheduleBody(organizationId, payload))
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.AdaptScenarioPublicationSchedule(schedule))
+	}
+}
+
+func handleCancelScenarioPublicationSchedule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		scheduleId, err := uuid.Parse(c.Param("schedule_id"))
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "invalid publication schedule id"))
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationScheduleUsecase()
+		schedule, err := usecase.CancelScenarioPublicationSchedule(ctx, scheduleId)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.AdaptScenarioPublicationSchedule(schedule))
+	}
+}
@@ -66,6 +66,40 @@ func handleCreateScenarioPublication(uc usecases.Usecases) func(c *gin.Context)
 	}
 }
 
+func handleGetPublicationDiff(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var data struct {
+			ScenarioIterationId string `form:"scenario_iteration_id" binding:"required"`
+		}
+		if err := c.ShouldBindQuery(&data); err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationUsecase()
+		diff, err := usecase.GetPublicationDiff(ctx, data.ScenarioIterationId)
+		if presentError(ctx, c, err) {
+			return
+		}
+		c.JSON(http.StatusOK, dto.AdaptScenarioIterationDiff(diff))
+	}
+}
+
+func handleGetScenarioCanaryStats(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		scenarioID := c.Param("scenario_id")
+
+		usecase := usecasesWithCreds(ctx, uc).NewScenarioPublicationUsecase()
+		stats, err := usecase.GetScenarioCanaryStats(ctx, scenarioID)
+		if presentError(ctx, c, err) {
+			return
+		}
+		c.JSON(http.StatusOK, dto.AdaptScenarioCanaryStats(stats))
+	}
+}
+
 func handleGetScenarioPublication(uc usecases.Usecases) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
@@ -5,6 +5,7 @@ import (
 
 	"github.com/checkmarble/marble-backend/dto"
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/usecases"
 	"github.com/checkmarble/marble-backend/utils"
 
@@ -33,7 +34,11 @@ func handleSnoozesOfDecision(uc usecases.Usecases) func(c *gin.Context) {
 		if presentError(ctx, c, err) {
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"snoozes": dto.AdaptSnoozesOfDecision(snoozes)})
+		snoozesDto, err := dto.AdaptSnoozesOfDecision(snoozes)
+		if presentError(ctx, c, err) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"snoozes": snoozesDto})
 	}
 }
 
@@ -59,21 +64,33 @@ func handleSnoozeDecision(uc usecases.Usecases) func(c *gin.Context) {
 		if presentError(ctx, c, c.BindJSON(&input)) {
 			return
 		}
+		keyField, unlessCondition, err := dto.AdaptSnoozeConditionInput(input.KeyField,
+			input.UnlessConditionAstExpression)
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
 
 		ruleSnoozeUsecase := usecasesWithCreds(ctx, uc).NewRuleSnoozeUsecase()
 		snoozes, err := ruleSnoozeUsecase.SnoozeDecision(ctx, models.SnoozeDecisionInput{
-			Comment:        input.Comment,
-			DecisionId:     decisionId,
-			Duration:       input.Duration,
-			OrganizationId: organizationId,
-			RuleId:         input.RuleId,
-			UserId:         &userId,
+			Comment:         input.Comment,
+			DecisionId:      decisionId,
+			Duration:        input.Duration,
+			OrganizationId:  organizationId,
+			RuleId:          input.RuleId,
+			UserId:          &userId,
+			KeyField:        keyField,
+			UnlessCondition: unlessCondition,
 		})
 		if presentError(ctx, c, err) {
 			return
 		}
+		snoozesDto, err := dto.AdaptSnoozesOfDecision(snoozes)
+		if presentError(ctx, c, err) {
+			return
+		}
 
-		c.JSON(http.StatusCreated, gin.H{"snoozes": dto.AdaptSnoozesOfDecision(snoozes)})
+		c.JSON(http.StatusCreated, gin.H{"snoozes": snoozesDto})
 	}
 }
 
@@ -119,7 +136,106 @@ func handleGetSnoozesById(uc usecases.Usecases) func(c *gin.Context) {
 		if presentError(ctx, c, err) {
 			return
 		}
+		snoozeDto, err := dto.AdaptRuleSnoose(snooze)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"snooze": snoozeDto})
+	}
+}
+
+func handleSnoozesOfRule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		ruleId := c.Param("rule_id")
+		if _, err := uuid.Parse(ruleId); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "rule_id must be a valid uuid"))
+			return
+		}
+
+		ruleSnoozeUsecase := usecasesWithCreds(ctx, uc).NewRuleSnoozeUsecase()
+		snoozes, err := ruleSnoozeUsecase.ActiveSnoozesOfRule(ctx, ruleId)
+		if presentError(ctx, c, err) {
+			return
+		}
+		snoozesDto, err := pure_utils.MapErr(snoozes, dto.AdaptRuleSnoose)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"snoozes": snoozesDto})
+	}
+}
+
+func handleSnoozeRule(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		creds, _ := utils.CredentialsFromCtx(ctx)
+		userId := creds.ActorIdentity.UserId
+
+		ruleId := c.Param("rule_id")
+		if _, err := uuid.Parse(ruleId); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "rule_id must be a valid uuid"))
+			return
+		}
+
+		var input dto.SnoozeRuleInput
+		if presentError(ctx, c, c.BindJSON(&input)) {
+			return
+		}
+		keyField, unlessCondition, err := dto.AdaptSnoozeConditionInput(input.KeyField,
+			input.UnlessConditionAstExpression)
+		if err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, err.Error()))
+			return
+		}
+
+		ruleSnoozeUsecase := usecasesWithCreds(ctx, uc).NewRuleSnoozeUsecase()
+		snoozes, err := ruleSnoozeUsecase.SnoozeRule(ctx, models.SnoozeRuleInput{
+			RuleId:          ruleId,
+			Duration:        input.Duration,
+			KeyField:        keyField,
+			Values:          input.Values,
+			UnlessCondition: unlessCondition,
+			UserId:          &userId,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
+		snoozesDto, err := pure_utils.MapErr(snoozes, dto.AdaptRuleSnoose)
+		if presentError(ctx, c, err) {
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{"snoozes": snoozesDto})
+	}
+}
+
+func handleEndRuleSnoozes(uc usecases.Usecases) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		ruleId := c.Param("rule_id")
+		if _, err := uuid.Parse(ruleId); err != nil {
+			presentError(ctx, c, errors.Wrap(models.BadParameterError, "rule_id must be a valid uuid"))
+			return
+		}
+
+		var input dto.EndRuleSnoozesInput
+		if presentError(ctx, c, c.BindJSON(&input)) {
+			return
+		}
+
+		ruleSnoozeUsecase := usecasesWithCreds(ctx, uc).NewRuleSnoozeUsecase()
+		err := ruleSnoozeUsecase.EndRuleSnoozes(ctx, models.EndRuleSnoozesInput{
+			RuleId:    ruleId,
+			SnoozeIds: input.SnoozeIds,
+			All:       input.All,
+		})
+		if presentError(ctx, c, err) {
+			return
+		}
 
-		c.JSON(http.StatusOK, gin.H{"snooze": dto.AdaptRuleSnoose(snooze)})
+		c.Status(http.StatusNoContent)
 	}
 }
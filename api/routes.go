@@ -118,7 +118,22 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.POST("/ingestion/:object_type/batch", timeoutMiddleware(conf.BatchTimeout), handlePostCsvIngestion(uc))
 	router.GET("/ingestion/:object_type/upload-logs", tom, handleListUploadLogs(uc))
 
+	// Rejected ingestion payloads
+	router.GET("/ingestion-dead-letters", tom, handleListIngestionDeadLetters(uc))
+	router.POST("/ingestion-dead-letters/replay", timeoutMiddleware(conf.BatchTimeout), handleReplayIngestionDeadLetters(uc))
+	router.GET("/ingestion-dead-letters/:deadLetterID", tom, handleGetIngestionDeadLetter(uc))
+	router.POST("/ingestion-dead-letters/:deadLetterID/replay", tom, handleReplayIngestionDeadLetter(uc))
+
+	// Blob storage prefixes watched for files to ingest
+	router.POST("/ingestion-sources", tom, handleCreateIngestionSource(uc))
+	router.GET("/ingestion-sources", tom, handleListIngestionSources(uc))
+	router.GET("/ingestion-sources/:sourceID", tom, handleGetIngestionSource(uc))
+	router.PUT("/ingestion-sources/:sourceID", tom, handleUpdateIngestionSource(uc))
+	router.DELETE("/ingestion-sources/:sourceID", tom, handleDeleteIngestionSource(uc))
+	router.GET("/ingestion-sources/:sourceID/files", tom, handleListIngestionSourceFiles(uc))
+
 	router.GET("/client_data/:object_type/:object_id", tom, handleGetIngestedObject(uc))
+	router.GET("/client_data/:object_type/:object_id/history", tom, handleGetIngestedObjectHistory(uc))
 	router.GET("/client_data/:object_type/:object_id/annotations", tom, handleListEntityAnnotations(uc))
 	router.GET("/client_data/:object_type/:object_id/cases", tom, handleEntityRelatedCases(uc))
 	router.GET("/client_data/annotations/:id", tom, handleGetEntityAnnotation(uc))
@@ -145,6 +160,7 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 		handleAiDescriptionAST(uc),
 	)
 	router.GET("/scenarios/:scenario_id/rules/latest", tom, listLatestScenarioRules(uc))
+	router.GET("/scenarios/:scenario_id/canary-stats", tom, handleGetScenarioCanaryStats(uc))
 
 	router.POST("/scenarios/:scenario_id/generate-ast",
 		timeoutMiddleware(conf.BatchTimeout), handleGenerateRule(uc))
@@ -160,6 +176,7 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.PATCH("/scenario-iterations/:iteration_id/screening/:config_id", tom, handleUpdateScreeningCheckConfig(uc))
 	router.DELETE("/scenario-iterations/:iteration_id/screening/:config_id", tom, handleDeleteScreeningConfig(uc))
 	router.POST("/scenario-iterations/:iteration_id/validate", tom, handleValidateScenarioIteration(uc))
+	router.GET("/scenario-iterations/:iteration_id/diff", tom, handleDiffScenarioIterations(uc))
 	router.POST("/scenario-iterations/:iteration_id/commit",
 		tom,
 		handleCommitScenarioIterationVersion(uc))
@@ -172,6 +189,9 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.GET("/scenario-iteration-rules/:rule_id", tom, handleGetRule(uc))
 	router.PATCH("/scenario-iteration-rules/:rule_id", tom, handleUpdateRule(uc))
 	router.DELETE("/scenario-iteration-rules/:rule_id", tom, handleDeleteRule(uc))
+	router.GET("/scenario-iteration-rules/:rule_id/snoozes", tom, handleSnoozesOfRule(uc))
+	router.POST("/scenario-iteration-rules/:rule_id/snoozes", tom, handleSnoozeRule(uc))
+	router.POST("/scenario-iteration-rules/:rule_id/snoozes/end", tom, handleEndRuleSnoozes(uc))
 
 	router.GET("/screenings/freshness", tom, handleScreeningDatasetFreshness(uc))
 	router.GET("/screenings/datasets", tom, handleScreeningDatasetCatalog(uc))
@@ -220,6 +240,18 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 		handleListContinuousScreeningUpdateJobs(uc))
 	router.GET("/continuous-screenings/client-data-indexing", tom,
 		handleListContinuousScreeningClientDataIndexing(uc))
+	router.GET("/continuous-screenings/attestation-reports", tom,
+		handleListContinuousScreeningAttestationReports(uc))
+	router.GET("/continuous-screenings/attestation-reports/:id/download", tom,
+		handleDownloadContinuousScreeningAttestationReport(uc))
+	router.GET("/continuous-screenings/coverage-analyses", tom,
+		handleListContinuousScreeningCoverageAnalyses(uc))
+	router.POST("/continuous-screenings/coverage-analyses", tom,
+		handleRequestContinuousScreeningCoverageAnalysis(uc))
+	router.GET("/continuous-screenings/coverage-analyses/:id/gaps", tom,
+		handleListContinuousScreeningCoverageGaps(uc))
+	router.POST("/continuous-screenings/coverage-analyses/:id/backfill", tom,
+		handleBackfillContinuousScreeningCoverageAnalysis(uc))
 	router.GET("/continuous-screenings", tom, handleListContinuousScreeningsForOrg(uc))
 	router.PATCH("/continuous-screenings/:id/dismiss", tom,
 		handleDismissContinuousScreening(uc))
@@ -231,8 +263,23 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.GET("/scenario-publications/preparation", tom,
 		handleGetPublicationPreparationStatus(uc))
 	router.POST("/scenario-publications/preparation", tom, handleStartPublicationPreparation(uc))
+	router.GET("/scenario-publications/diff", tom, handleGetPublicationDiff(uc))
 	router.GET("/scenario-publications/:publication_id", tom, handleGetScenarioPublication(uc))
 
+	router.GET("/scenario-publication-requests", tom, handleListScenarioPublicationRequests(uc))
+	router.POST("/scenario-publication-requests", tom, handleCreateScenarioPublicationRequest(uc))
+	router.GET("/scenario-publication-requests/:request_id", tom, handleGetScenarioPublicationRequest(uc))
+	router.POST("/scenario-publication-requests/:request_id/approve", tom,
+		handleReviewScenarioPublicationRequest(uc, true))
+	router.POST("/scenario-publication-requests/:request_id/reject", tom,
+		handleReviewScenarioPublicationRequest(uc, false))
+	router.POST("/scenario-publication-requests/:request_id/cancel", tom,
+		handleCancelScenarioPublicationRequest(uc))
+
+	router.POST("/scenario-publication-schedules", tom, handleCreateScenarioPublicationSchedule(uc))
+	router.POST("/scenario-publication-schedules/:schedule_id/cancel", tom,
+		handleCancelScenarioPublicationSchedule(uc))
+
 	router.POST("/scenario-testrun", tom, handleCreateScenarioTestRun(uc))
 	router.GET("/scenario-testrun", tom, handleListScenarioTestRun(uc))
 	router.GET("/scenario-testruns/:test_run_id/decision_data_by_score",
@@ -244,6 +291,12 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.GET("/scenario-testruns/:test_run_id", tom, handleGetScenarioTestRun(uc))
 	router.POST("/scenario-testruns/:test_run_id/cancel", tom, handleCancelScenarioTestRun(uc))
 
+	router.POST("/scenario-backtests", tom, handleCreateScenarioBacktest(uc))
+	router.GET("/scenario-backtests", tom, handleListScenarioBacktests(uc))
+	router.GET("/scenario-backtests/:backtestID", tom, handleGetScenarioBacktest(uc))
+	router.POST("/scenario-backtests/:backtestID/cancel", tom, handleCancelScenarioBacktest(uc))
+	router.GET("/scenario-backtests/:backtestID/outcomes", tom, handleGetScenarioBacktestOutcomes(uc))
+
 	router.GET("/scheduled-executions", tom, handleListScheduledExecution(uc))
 	router.GET("/scheduled-executions/:execution_id", tom, handleGetScheduledExecution(uc))
 
@@ -351,6 +404,8 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.PATCH("/data-model/fields/:fieldID", tom, handleUpdateDataModelField(uc))
 	router.DELETE("/data-model", tom, handleDeleteDataModel(uc))
 	router.GET("/data-model/openapi/:version", tom, handleGetOpenAPI(uc))
+	router.GET("/data-model/json-schema", tom, handleGetDataModelJSONSchema(uc))
+	router.GET("/data-model/json-schema/:tableName", tom, handleGetTableJSONSchema(uc))
 	router.POST("/data-model/pivots", tom, handleCreateDataModelPivot(uc))
 	router.GET("/data-model/pivots", tom, handleListDataModelPivots(uc))
 	router.POST("/data-model/tables/:tableID/navigation_options", tom, handleCreateNavigationOption(uc))
@@ -365,6 +420,29 @@ func addRoutes(r *gin.Engine, conf Configuration, uc usecases.Usecases, auth uti
 	router.DELETE("/data-model/links/:linkID", tom, handleDeleteDataModelLink(uc))
 	router.DELETE("/data-model/pivots/:pivotID", tom, handleDeleteDataModelPivot(uc))
 
+	// Data model field type migrations
+	router.POST("/data-model/fields/:fieldID/type-migrations", tom, handleCreateFieldTypeMigration(uc))
+	router.GET("/data-model/fields/:fieldID/type-migrations", tom, handleListFieldTypeMigrations(uc))
+	router.GET("/data-model/type-migrations/:migrationID", tom, handleGetFieldTypeMigration(uc))
+
+	// Data model derived fields
+	router.PUT("/data-model/fields/:fieldID/derivation", tom, handleSetFieldDerivation(uc))
+	router.DELETE("/data-model/fields/:fieldID/derivation", tom, handleRemoveFieldDerivation(uc))
+	router.GET("/data-model/fields/:fieldID/derivation/backfills", tom, handleListDerivedFieldBackfills(uc))
+
+	// Erasure of the personal data of a data subject
+	router.POST("/data-subject-erasures", tom, handleCreateDataSubjectErasure(uc))
+	router.GET("/data-subject-erasures", tom, handleListDataSubjectErasures(uc))
+	router.GET("/data-subject-erasures/:erasureID", tom, handleGetDataSubjectErasure(uc))
+
+	// Retention of the ingested data and of the decisions
+	router.POST("/data-retention-policies", tom, handleCreateDataRetentionPolicy(uc))
+	router.GET("/data-retention-policies", tom, handleListDataRetentionPolicies(uc))
+	router.GET("/data-retention-policies/:policyID", tom, handleGetDataRetentionPolicy(uc))
+	router.PUT("/data-retention-policies/:policyID", tom, handleUpdateDataRetentionPolicy(uc))
+	router.DELETE("/data-retention-policies/:policyID", tom, handleDeleteDataRetentionPolicy(uc))
+	router.GET("/data-retention-policies/:policyID/runs", tom, handleListDataRetentionRuns(uc))
+
 	router.GET("/licenses", tom, handleListLicenses(uc))
 	router.POST("/licenses", tom, handleCreateLicense(uc))
 	router.PATCH("/licenses/:license_id", tom, handleUpdateLicense(uc))
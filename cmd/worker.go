@@ -257,6 +257,9 @@ func RunTaskQueue(apiVersion string, only, onlyArgs string) error {
 	// Webhook cleanup (30 day retention)
 	maps.Copy(nonOrgQueues, usecases.QueueWebhookCleanup())
 	globalPeriodics = append(globalPeriodics, worker_jobs.NewWebhookCleanupPeriodicJob())
+	// Ingestion dead letter cleanup (30 day retention)
+	maps.Copy(nonOrgQueues, usecases.QueueIngestionDeadLetterCleanup())
+	globalPeriodics = append(globalPeriodics, worker_jobs.NewIngestionDeadLetterCleanupPeriodicJob())
 	// Async decision execution cleanup (30 day retention)
 	maps.Copy(nonOrgQueues, usecases.QueueAsyncDecisionCleanup())
 	globalPeriodics = append(globalPeriodics, worker_jobs.NewAsyncDecisionExecutionCleanupPeriodicJob())
@@ -350,6 +353,7 @@ func RunTaskQueue(apiVersion string, only, onlyArgs string) error {
 		usecases.WithLicense(license),
 		usecases.WithAllowInsecureWebhookURLs(utils.GetEnv("ENV", "production") == "development"),
 		usecases.WithWebhookIPWhitelist(os.Getenv("WEBHOOK_IP_WHITELIST")),
+		usecases.WithIngestionSourceBucketUrls(os.Getenv("INGESTION_SOURCE_BUCKET_URLS")),
 		usecases.WithOpensanctions(openSanctionsConfig.IsSet()),
 		usecases.WithApiVersion(apiVersion),
 		usecases.WithMetricsCollectionConfig(metricCollectionConfig),
@@ -393,6 +397,15 @@ func RunTaskQueue(apiVersion string, only, onlyArgs string) error {
 	river.AddWorker(workers, adminUc.NewContinuousScreeningApplyDeltaFileWorker())
 	river.AddWorker(workers, adminUc.NewContinuousScreeningScanDatasetUpdatesWorker())
 	river.AddWorker(workers, adminUc.NewCsvIngestionWorker())
+	river.AddWorker(workers, adminUc.NewFieldTypeMigrationWorker())
+	river.AddWorker(workers, adminUc.NewDerivedFieldBackfillWorker())
+	river.AddWorker(workers, adminUc.NewDataSubjectErasureWorker())
+	river.AddWorker(workers, adminUc.NewDataRetentionWorker())
+	river.AddWorker(workers, adminUc.NewIngestionSourcePollWorker())
+	river.AddWorker(workers, adminUc.NewScenarioBacktestWorker())
+	river.AddWorker(workers, adminUc.NewScenarioPublicationRequestWorker())
+	river.AddWorker(workers, adminUc.NewScenarioPublicationScheduleWorker())
+	river.AddWorker(workers, adminUc.NewScreeningWhitelistExpiryWorker())
 	river.AddWorker(workers, adminUc.NewAsyncUploadWorker())
 	river.AddWorker(workers, adminUc.NewScheduledExecutionWorker())
 	river.AddWorker(workers, adminUc.NewBatchExecutionCoordinatorWorker())
@@ -413,12 +426,15 @@ func RunTaskQueue(apiVersion string, only, onlyArgs string) error {
 		river.AddWorker(workers, uc.NewSendBillingEventWorker())
 	}
 	river.AddWorker(workers, uc.NewContinuousScreeningCreateFullDatasetWorker())
+	river.AddWorker(workers, uc.NewContinuousScreeningAttestationReportWorker())
+	river.AddWorker(workers, uc.NewContinuousScreeningCoverageAnalysisWorker())
 	river.AddWorker(workers, adminUc.NewScheduledScenarioWorker())
 
 	// New webhook delivery system
 	river.AddWorker(workers, adminUc.NewWebhookDispatchWorker())
 	river.AddWorker(workers, adminUc.NewWebhookDeliveryWorker())
 	river.AddWorker(workers, adminUc.NewWebhookCleanupWorker())
+	river.AddWorker(workers, adminUc.NewIngestionDeadLetterCleanupWorker())
 
 	river.AddWorker(workers, adminUc.NewScoreComputationWorker())
 	river.AddWorker(workers, adminUc.NewTriggeredScoreComputationWorker())
@@ -644,6 +660,12 @@ func singleJobRun(ctx context.Context, uc usecases.UsecasesWithCreds, apiVersion
 	case "continuous_screening_create_full_dataset":
 		return uc.NewContinuousScreeningCreateFullDatasetWorker().Work(ctx,
 			singleJobCreate[models.ContinuousScreeningCreateFullDatasetArgs](ctx, jobArgs))
+	case "continuous_screening_attestation_report":
+		return uc.NewContinuousScreeningAttestationReportWorker().Work(ctx,
+			singleJobCreate[models.ContinuousScreeningAttestationReportArgs](ctx, jobArgs))
+	case "continuous_screening_coverage_analysis":
+		return uc.NewContinuousScreeningCoverageAnalysisWorker().Work(ctx,
+			singleJobCreate[models.ContinuousScreeningCoverageAnalysisArgs](ctx, jobArgs))
 	case "scheduled_scenario":
 		return uc.NewScheduledScenarioWorker().Work(ctx,
 			singleJobCreate[models.ScheduledScenarioArgs](ctx, jobArgs))
@@ -653,6 +675,33 @@ func singleJobRun(ctx context.Context, uc usecases.UsecasesWithCreds, apiVersion
 	case "csv_ingestion":
 		return uc.NewCsvIngestionWorker().Work(ctx,
 			singleJobCreate[models.CsvIngestionArgs](ctx, jobArgs))
+	case "field_type_migration":
+		return uc.NewFieldTypeMigrationWorker().Work(ctx,
+			singleJobCreate[models.FieldTypeMigrationArgs](ctx, jobArgs))
+	case "derived_field_backfill":
+		return uc.NewDerivedFieldBackfillWorker().Work(ctx,
+			singleJobCreate[models.DerivedFieldBackfillArgs](ctx, jobArgs))
+	case "data_subject_erasure":
+		return uc.NewDataSubjectErasureWorker().Work(ctx,
+			singleJobCreate[models.DataSubjectErasureArgs](ctx, jobArgs))
+	case "data_retention":
+		return uc.NewDataRetentionWorker().Work(ctx,
+			singleJobCreate[models.DataRetentionArgs](ctx, jobArgs))
+	case "ingestion_source_poll":
+		return uc.NewIngestionSourcePollWorker().Work(ctx,
+			singleJobCreate[models.IngestionSourcePollArgs](ctx, jobArgs))
+	case "scenario_backtest":
+		return uc.NewScenarioBacktestWorker().Work(ctx,
+			singleJobCreate[models.ScenarioBacktestArgs](ctx, jobArgs))
+	case "scenario_publication_request":
+		return uc.NewScenarioPublicationRequestWorker().Work(ctx,
+			singleJobCreate[models.ScenarioPublicationRequestArgs](ctx, jobArgs))
+	case "scenario_publication_schedule":
+		return uc.NewScenarioPublicationScheduleWorker().Work(ctx,
+			singleJobCreate[models.ScenarioPublicationScheduleArgs](ctx, jobArgs))
+	case "screening_whitelist_expiry":
+		return uc.NewScreeningWhitelistExpiryWorker().Work(ctx,
+			singleJobCreate[models.ScreeningWhitelistExpiryArgs](ctx, jobArgs))
 	case "webhook_dispatch":
 		return uc.NewWebhookDispatchWorker().Work(ctx,
 			singleJobCreate[models.WebhookDispatchJobArgs](ctx, jobArgs))
@@ -662,6 +711,9 @@ func singleJobRun(ctx context.Context, uc usecases.UsecasesWithCreds, apiVersion
 	case "webhook_cleanup":
 		return uc.NewWebhookCleanupWorker().Work(ctx,
 			singleJobCreate[models.WebhookCleanupJobArgs](ctx, jobArgs))
+	case "ingestion_dead_letter_cleanup":
+		return uc.NewIngestionDeadLetterCleanupWorker().Work(ctx,
+			singleJobCreate[models.IngestionDeadLetterCleanupArgs](ctx, jobArgs))
 	case "triggered_score_computation":
 		return uc.NewTriggeredScoreComputationWorker().Work(ctx,
 			singleJobCreate[models.TriggeredScoreComputationArgs](ctx, jobArgs))
@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/checkmarble/marble-backend/models"
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+)
 
 type Client360Table struct {
 	Id           string `json:"id"`
@@ -23,6 +27,7 @@ func AdaptClient360Table(table models.Client360Table) Client360Table {
 }
 
 type Client360SearchInput struct {
-	Table string `json:"table"`
-	Terms string `json:"terms"`
+	Table string     `json:"table"`
+	Terms string     `json:"terms"`
+	AsOf  *time.Time `json:"as_of"`
 }
@@ -55,21 +55,6 @@ func (c ClientObjectDetail) MarshalJSON() ([]byte, error) {
 	if c.RelatedObjects == nil {
 		c.RelatedObjects = make([]RelatedObject, 0)
 	}
-	if c.Data == nil {
-		c.Data = make(map[string]any)
-	}
-
-	for k, v := range c.Data {
-		switch v := v.(type) {
-		case *geom.Point:
-			c.Data[k] = fmt.Sprintf("%f,%f", v.Y(), v.X())
-		}
-
-		if strings.Contains(k, `"`) {
-			c.Data[strings.ReplaceAll(k, `"`, "")] = c.Data[k]
-			delete(c.Data, k)
-		}
-	}
 
 	return json.Marshal(struct {
 		Metadata       ClientObjectMetadata     `json:"metadata"`
@@ -78,12 +63,30 @@ func (c ClientObjectDetail) MarshalJSON() ([]byte, error) {
 		Annotations    GroupedEntityAnnotations `json:"annotations,omitzero"`
 	}{
 		Metadata:       c.Metadata,
-		Data:           c.Data,
+		Data:           adaptClientObjectData(c.Data),
 		RelatedObjects: c.RelatedObjects,
 		Annotations:    c.Annotations,
 	})
 }
 
+// adaptClientObjectData presents the fields of an ingested object: geographic points as "lat,lng"
+// strings, and field names without the quotes of their SQL identifier.
+func adaptClientObjectData(data map[string]any) map[string]any {
+	out := make(map[string]any, len(data))
+	for k, v := range data {
+		out[strings.ReplaceAll(k, `"`, "")] = adaptClientObjectValue(v)
+	}
+	return out
+}
+
+func adaptClientObjectValue(v any) any {
+	switch v := v.(type) {
+	case *geom.Point:
+		return fmt.Sprintf("%f,%f", v.Y(), v.X())
+	}
+	return v
+}
+
 type RelatedObject struct {
 	LinkName string             `json:"link_name"`
 	Detail   ClientObjectDetail `json:"related_object_detail"` //nolint:tagliatelle
@@ -275,3 +278,35 @@ func AdaptGroupedEntityAnnotations(a models.GroupedEntityAnnotations) (GroupedEn
 
 	return out, nil
 }
+
+type ClientObjectVersion struct {
+	ValidFrom  time.Time                 `json:"valid_from"`
+	ValidUntil *time.Time                `json:"valid_until"`
+	Data       map[string]any            `json:"data"`
+	Changes    []ClientObjectFieldChange `json:"changes"`
+}
+
+type ClientObjectFieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+func AdaptClientObjectVersionDto(v models.ClientObjectVersion) ClientObjectVersion {
+	return ClientObjectVersion{
+		ValidFrom:  v.ValidFrom,
+		ValidUntil: v.ValidUntil,
+		Data:       adaptClientObjectData(v.Data),
+		Changes: pure_utils.Map(v.Changes, func(c models.ClientObjectFieldChange) ClientObjectFieldChange {
+			return ClientObjectFieldChange{
+				Field:  strings.ReplaceAll(c.Field, `"`, ""),
+				Before: adaptClientObjectValue(c.Before),
+				After:  adaptClientObjectValue(c.After),
+			}
+		}),
+	}
+}
+
+type GetClientObjectParams struct {
+	AsOf *time.Time `form:"as_of"`
+}
@@ -233,3 +233,75 @@ func AdaptContinuousScreeningMatchDto(m models.ContinuousScreeningMatch) Continu
 		UpdatedAt:             m.UpdatedAt,
 	}
 }
+
+type ContinuousScreeningAttestationReportDto struct {
+	Id                 uuid.UUID `json:"id"`
+	ObjectCount        int       `json:"object_count"`
+	FlaggedObjectCount int       `json:"flagged_object_count"`
+	OpenMatchCount     int       `json:"open_match_count"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func AdaptContinuousScreeningAttestationReportDto(
+	r models.ContinuousScreeningAttestationReport,
+) ContinuousScreeningAttestationReportDto {
+	return ContinuousScreeningAttestationReportDto{
+		Id:                 r.Id,
+		ObjectCount:        r.ObjectCount,
+		FlaggedObjectCount: r.FlaggedObjectCount,
+		OpenMatchCount:     r.OpenMatchCount,
+		CreatedAt:          r.CreatedAt,
+	}
+}
+
+type ContinuousScreeningCoverageAnalysisDto struct {
+	Id               uuid.UUID  `json:"id"`
+	ObjectTypes      []string   `json:"object_types"`
+	UnmonitoredCount int        `json:"unmonitored_count"`
+	OrphanedCount    int        `json:"orphaned_count"`
+	Truncated        bool       `json:"truncated"`
+	BackfilledAt     *time.Time `json:"backfilled_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+func AdaptContinuousScreeningCoverageAnalysisDto(
+	a models.ContinuousScreeningCoverageAnalysis,
+) ContinuousScreeningCoverageAnalysisDto {
+	return ContinuousScreeningCoverageAnalysisDto{
+		Id:               a.Id,
+		ObjectTypes:      a.ObjectTypes,
+		UnmonitoredCount: a.UnmonitoredCount,
+		OrphanedCount:    a.OrphanedCount,
+		Truncated:        a.Truncated,
+		BackfilledAt:     a.BackfilledAt,
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+type ContinuousScreeningCoverageGapDto struct {
+	Id             uuid.UUID  `json:"id"`
+	GapType        string     `json:"gap_type"`
+	ObjectType     string     `json:"object_type"`
+	ObjectId       string     `json:"object_id"`
+	ConfigStableId *uuid.UUID `json:"config_stable_id"`
+}
+
+func AdaptContinuousScreeningCoverageGapDto(g models.ContinuousScreeningCoverageGap) ContinuousScreeningCoverageGapDto {
+	return ContinuousScreeningCoverageGapDto{
+		Id:             g.Id,
+		GapType:        string(g.GapType),
+		ObjectType:     g.ObjectType,
+		ObjectId:       g.ObjectId,
+		ConfigStableId: g.ConfigStableId,
+	}
+}
+
+type ContinuousScreeningCoverageBackfillDto struct {
+	ConfigStableId *uuid.UUID `json:"config_stable_id"`
+	ShouldScreen   bool       `json:"should_screen"`
+}
+
+type ContinuousScreeningCoverageBackfillResultDto struct {
+	EnqueuedCount int `json:"enqueued_count"`
+	SkippedCount  int `json:"skipped_count"`
+}
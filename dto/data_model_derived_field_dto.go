@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+)
+
+type SetFieldDerivationInput struct {
+	Expression NodeDto `json:"expression" binding:"required"`
+}
+
+type DerivedFieldBackfill struct {
+	Id            uuid.UUID  `json:"id"`
+	TableId       string     `json:"table_id"`
+	FieldId       string     `json:"field_id"`
+	TableName     string     `json:"table_name"`
+	FieldName     string     `json:"field_name"`
+	Status        string     `json:"status"`
+	RowsProcessed int64      `json:"rows_processed"`
+	Error         *string    `json:"error"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	CompletedAt   *time.Time `json:"completed_at"`
+}
+
+func AdaptDerivedFieldBackfill(b models.DerivedFieldBackfill) DerivedFieldBackfill {
+	return DerivedFieldBackfill{
+		Id:            b.Id,
+		TableId:       b.TableId,
+		FieldId:       b.FieldId,
+		TableName:     b.TableName,
+		FieldName:     b.FieldName,
+		Status:        string(b.Status),
+		RowsProcessed: b.RowsProcessed,
+		Error:         b.Error,
+		CreatedAt:     b.CreatedAt,
+		UpdatedAt:     b.UpdatedAt,
+		CompletedAt:   b.CompletedAt,
+	}
+}
@@ -25,19 +25,74 @@ type LinkToSingle struct {
 }
 
 type Field struct {
-	ID                string          `json:"id"`
-	DataType          string          `json:"data_type"`
-	Description       string          `json:"description"`
-	Alias             string          `json:"alias"`
-	SemanticType      string          `json:"semantic_type,omitempty"`
-	IsEnum            bool            `json:"is_enum"`
-	Name              string          `json:"name"`
-	Nullable          bool            `json:"nullable"`
-	TableId           string          `json:"table_id"`
-	Values            []any           `json:"values,omitempty"`
-	UnicityConstraint string          `json:"unicity_constraint"`
-	FTMProperty       *string         `json:"ftm_property,omitempty"`
-	Metadata          json.RawMessage `json:"metadata,omitempty"`
+	ID                string            `json:"id"`
+	DataType          string            `json:"data_type"`
+	Description       string            `json:"description"`
+	Alias             string            `json:"alias"`
+	SemanticType      string            `json:"semantic_type,omitempty"`
+	IsEnum            bool              `json:"is_enum"`
+	Name              string            `json:"name"`
+	Nullable          bool              `json:"nullable"`
+	TableId           string            `json:"table_id"`
+	Values            []any             `json:"values,omitempty"`
+	UnicityConstraint string            `json:"unicity_constraint"`
+	FTMProperty       *string           `json:"ftm_property,omitempty"`
+	Metadata          json.RawMessage   `json:"metadata,omitempty"`
+	Constraints       *FieldConstraints `json:"constraints,omitempty"`
+	DerivedExpression *NodeDto          `json:"derived_expression,omitempty"`
+}
+
+type FieldConstraints struct {
+	Min           *float64 `json:"min,omitempty"`
+	Max           *float64 `json:"max,omitempty"`
+	MinLength     *int     `json:"min_length,omitempty"`
+	MaxLength     *int     `json:"max_length,omitempty"`
+	Pattern       *string  `json:"pattern,omitempty"`
+	Format        *string  `json:"format,omitempty"`
+	AllowedValues []any    `json:"allowed_values,omitempty"`
+}
+
+func AdaptFieldConstraintsDto(constraints *models.FieldConstraints) *FieldConstraints {
+	if constraints == nil {
+		return nil
+	}
+	return &FieldConstraints{
+		Min:           constraints.Min,
+		Max:           constraints.Max,
+		MinLength:     constraints.MinLength,
+		MaxLength:     constraints.MaxLength,
+		Pattern:       constraints.Pattern,
+		Format:        (*string)(constraints.Format),
+		AllowedValues: constraints.AllowedValues,
+	}
+}
+
+// AdaptFieldConstraints returns nil for empty constraints, so that they are not stored.
+func AdaptFieldConstraints(constraints *FieldConstraints) *models.FieldConstraints {
+	if constraints == nil {
+		return nil
+	}
+	result := models.FieldConstraints{
+		Min:           constraints.Min,
+		Max:           constraints.Max,
+		MinLength:     constraints.MinLength,
+		MaxLength:     constraints.MaxLength,
+		Pattern:       constraints.Pattern,
+		Format:        (*models.FieldConstraintFormat)(constraints.Format),
+		AllowedValues: constraints.AllowedValues,
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return &result
+}
+
+// Setting the constraints of a field to null or to an empty object removes them.
+func AdaptFieldConstraintsUpdate(constraints pure_utils.Null[FieldConstraints]) pure_utils.Null[models.FieldConstraints] {
+	if !constraints.Set {
+		return pure_utils.Null[models.FieldConstraints]{}
+	}
+	return pure_utils.NullFromPtr(AdaptFieldConstraints(constraints.Ptr()))
 }
 
 type NavigationOption struct {
@@ -99,6 +154,13 @@ func adaptDataModelField(field models.Field) Field {
 	if field.FTMProperty != nil {
 		ftmProperty = utils.Ptr(field.FTMProperty.String())
 	}
+	// The expression was validated when it was set, it always has a representation
+	var derivedExpression *NodeDto
+	if field.DerivedExpression != nil {
+		if node, err := AdaptNodeDto(*field.DerivedExpression); err == nil {
+			derivedExpression = &node
+		}
+	}
 	return Field{
 		ID:                field.ID,
 		DataType:          field.DataType.String(),
@@ -113,6 +175,8 @@ func adaptDataModelField(field models.Field) Field {
 		UnicityConstraint: field.UnicityConstraint.String(),
 		FTMProperty:       ftmProperty,
 		Metadata:          field.Metadata,
+		Constraints:       AdaptFieldConstraintsDto(field.Constraints),
+		DerivedExpression: derivedExpression,
 	}
 }
 
@@ -313,6 +377,7 @@ func (input UpdateTableInput) AdaptToUpdateTableCompositeInput() (models.UpdateT
 				IsNullable:  data.IsNullable,
 				Alias:       data.Alias,
 				Metadata:    data.Metadata,
+				Constraints: AdaptFieldConstraintsUpdate(data.Constraints),
 			}
 			if data.FTMProperty.Set {
 				if data.FTMProperty.Valid {
@@ -430,15 +495,16 @@ type DeleteOperationData struct {
 }
 
 type ModFieldOperationData struct {
-	ID           string                  `json:"id"`
-	Description  *string                 `json:"description"`
-	IsEnum       *bool                   `json:"is_enum"`
-	IsUnique     *bool                   `json:"is_unique"`
-	IsNullable   *bool                   `json:"is_nullable"`
-	FTMProperty  pure_utils.Null[string] `json:"ftm_property"`
-	Alias        *string                 `json:"alias"`
-	SemanticType pure_utils.Null[string] `json:"semantic_type"`
-	Metadata     *json.RawMessage        `json:"metadata"`
+	ID           string                            `json:"id"`
+	Description  *string                           `json:"description"`
+	IsEnum       *bool                             `json:"is_enum"`
+	IsUnique     *bool                             `json:"is_unique"`
+	IsNullable   *bool                             `json:"is_nullable"`
+	FTMProperty  pure_utils.Null[string]           `json:"ftm_property"`
+	Alias        *string                           `json:"alias"`
+	SemanticType pure_utils.Null[string]           `json:"semantic_type"`
+	Metadata     *json.RawMessage                  `json:"metadata"`
+	Constraints  pure_utils.Null[FieldConstraints] `json:"constraints"`
 }
 
 type ModLinkOperationData struct {
@@ -475,27 +541,29 @@ func (input CreateLinkInput) AdaptToModel(organizationID uuid.UUID) (models.Data
 }
 
 type UpdateFieldInput struct {
-	Description  *string                 `json:"description"`
-	IsEnum       *bool                   `json:"is_enum"`
-	IsUnique     *bool                   `json:"is_unique"`
-	IsNullable   *bool                   `json:"is_nullable"`
-	FTMProperty  pure_utils.Null[string] `json:"ftm_property"`
-	Alias        *string                 `json:"alias"`
-	SemanticType pure_utils.Null[string] `json:"semantic_type"`
-	Metadata     *json.RawMessage        `json:"metadata"`
+	Description  *string                           `json:"description"`
+	IsEnum       *bool                             `json:"is_enum"`
+	IsUnique     *bool                             `json:"is_unique"`
+	IsNullable   *bool                             `json:"is_nullable"`
+	FTMProperty  pure_utils.Null[string]           `json:"ftm_property"`
+	Alias        *string                           `json:"alias"`
+	SemanticType pure_utils.Null[string]           `json:"semantic_type"`
+	Metadata     *json.RawMessage                  `json:"metadata"`
+	Constraints  pure_utils.Null[FieldConstraints] `json:"constraints"`
 }
 
 type CreateFieldInput struct {
-	Name         string          `json:"name"`
-	Description  string          `json:"description"`
-	Type         string          `json:"type"`
-	Alias        string          `json:"alias"`
-	SemanticType *string         `json:"semantic_type"`
-	Nullable     bool            `json:"nullable"`
-	IsEnum       bool            `json:"is_enum"`
-	IsUnique     bool            `json:"is_unique"`
-	FTMProperty  *string         `json:"ftm_property"`
-	Metadata     json.RawMessage `json:"metadata"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	Type         string            `json:"type"`
+	Alias        string            `json:"alias"`
+	SemanticType *string           `json:"semantic_type"`
+	Nullable     bool              `json:"nullable"`
+	IsEnum       bool              `json:"is_enum"`
+	IsUnique     bool              `json:"is_unique"`
+	FTMProperty  *string           `json:"ftm_property"`
+	Metadata     json.RawMessage   `json:"metadata"`
+	Constraints  *FieldConstraints `json:"constraints"`
 }
 
 func AdaptCreateFieldInputToModel(input CreateFieldInput) (models.CreateFieldInput, error) {
@@ -535,6 +603,7 @@ func AdaptCreateFieldInputToModel(input CreateFieldInput) (models.CreateFieldInp
 		IsUnique:     input.IsUnique,
 		FTMProperty:  ftmProperty,
 		Metadata:     input.Metadata,
+		Constraints:  AdaptFieldConstraints(input.Constraints),
 	}, nil
 }
 
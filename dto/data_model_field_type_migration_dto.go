@@ -0,0 +1,148 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type CreateFieldTypeMigrationInput struct {
+	DataType        string  `json:"data_type" binding:"required"`
+	TimestampFormat *string `json:"timestamp_format"`
+	NullOnFailure   bool    `json:"null_on_failure"`
+}
+
+func AdaptCreateFieldTypeMigrationInput(fieldId string, input CreateFieldTypeMigrationInput) (
+	models.CreateFieldTypeMigrationInput, error,
+) {
+	dataType := models.DataTypeFrom(input.DataType)
+	if dataType == models.UnknownDataType {
+		return models.CreateFieldTypeMigrationInput{},
+			errors.Wrapf(models.BadParameterError, "invalid data type %q", input.DataType)
+	}
+
+	return models.CreateFieldTypeMigrationInput{
+		FieldId:         fieldId,
+		DataType:        dataType,
+		TimestampFormat: input.TimestampFormat,
+		NullOnFailure:   input.NullOnFailure,
+	}, nil
+}
+
+type FieldTypeMigration struct {
+	Id              uuid.UUID                   `json:"id"`
+	TableId         string                      `json:"table_id"`
+	FieldId         string                      `json:"field_id"`
+	TableName       string                      `json:"table_name"`
+	FieldName       string                      `json:"field_name"`
+	SourceDataType  string                      `json:"source_data_type"`
+	TargetDataType  string                      `json:"target_data_type"`
+	TimestampFormat *string                     `json:"timestamp_format"`
+	NullOnFailure   bool                        `json:"null_on_failure"`
+	Status          string                      `json:"status"`
+	RowsProcessed   int64                       `json:"rows_processed"`
+	RowsFailed      int64                       `json:"rows_failed"`
+	Error           *string                     `json:"error"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+	CompletedAt     *time.Time                  `json:"completed_at"`
+	Failures        []FieldTypeMigrationFailure `json:"failures,omitempty"`
+}
+
+type FieldTypeMigrationFailure struct {
+	ObjectId string `json:"object_id"`
+	Value    string `json:"value"`
+	Error    string `json:"error"`
+}
+
+func AdaptFieldTypeMigration(m models.FieldTypeMigration) FieldTypeMigration {
+	return FieldTypeMigration{
+		Id:              m.Id,
+		TableId:         m.TableId,
+		FieldId:         m.FieldId,
+		TableName:       m.TableName,
+		FieldName:       m.FieldName,
+		SourceDataType:  m.SourceDataType.String(),
+		TargetDataType:  m.TargetDataType.String(),
+		TimestampFormat: m.TimestampFormat,
+		NullOnFailure:   m.NullOnFailure,
+		Status:          string(m.Status),
+		RowsProcessed:   m.RowsProcessed,
+		RowsFailed:      m.RowsFailed,
+		Error:           m.Error,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+		CompletedAt:     m.CompletedAt,
+	}
+}
+
+func AdaptFieldTypeMigrationWithFailures(m models.FieldTypeMigration, failures []models.FieldTypeMigrationFailure) FieldTypeMigration {
+	out := AdaptFieldTypeMigration(m)
+	out.Failures = pure_utils.Map(failures, func(f models.FieldTypeMigrationFailure) FieldTypeMigrationFailure {
+		return FieldTypeMigrationFailure{
+			ObjectId: f.ObjectId,
+			Value:    f.Value,
+			Error:    f.Error,
+		}
+	})
+	return out
+}
+
+type FieldTypeMigrationReport struct {
+	Migration *FieldTypeMigration         `json:"migration"`
+	Conflicts FieldTypeMigrationConflicts `json:"conflicts"`
+}
+
+type FieldTypeMigrationConflicts struct {
+	ContinuousScreening  bool                                              `json:"continuous_screening"`
+	PrimaryOrderingField bool                                              `json:"primary_ordering_field"`
+	Links                []string                                          `json:"links"`
+	Pivots               []string                                          `json:"pivots"`
+	AnalyticsSettings    int                                               `json:"analytics_settings"`
+	NavigationOptions    int                                               `json:"navigation_options"`
+	ScenarioIterations   map[string]*DataModelDeleteFieldConflictIteration `json:"scenario_iterations"`
+	Workflows            []DataModelDeleteFieldRef                         `json:"workflows"`
+}
+
+func AdaptFieldTypeMigrationReport(m models.FieldTypeMigrationReport) FieldTypeMigrationReport {
+	ref := func(id string) DataModelDeleteFieldRef {
+		label := id
+		if l, ok := m.References[id]; ok {
+			label = l
+		}
+		return DataModelDeleteFieldRef{Id: id, Label: label}
+	}
+
+	r := FieldTypeMigrationReport{
+		Conflicts: FieldTypeMigrationConflicts{
+			ContinuousScreening:  m.Conflicts.ContinuousScreening,
+			PrimaryOrderingField: m.Conflicts.PrimaryOrderingField,
+			Links:                m.Conflicts.Links.Slice(),
+			Pivots:               m.Conflicts.Pivots.Slice(),
+			AnalyticsSettings:    m.Conflicts.AnalyticsSettings,
+			NavigationOptions:    m.Conflicts.NavigationOptions,
+			ScenarioIterations:   map[string]*DataModelDeleteFieldConflictIteration{},
+			Workflows:            pure_utils.Map(m.Conflicts.Workflows.Slice(), ref),
+		},
+	}
+	if m.Migration != nil {
+		r.Migration = utils.Ptr(AdaptFieldTypeMigration(*m.Migration))
+	}
+
+	for iterationId, conflicts := range m.Conflicts.ScenarioIterations {
+		r.Conflicts.ScenarioIterations[iterationId] = &DataModelDeleteFieldConflictIteration{
+			Name:             conflicts.Name,
+			ScenarioId:       conflicts.ScenarioId,
+			Draft:            conflicts.Draft,
+			TriggerCondition: conflicts.TriggerCondition,
+			Rules:            pure_utils.Map(conflicts.Rules.Slice(), ref),
+			Screenings:       pure_utils.Map(conflicts.Screening.Slice(), ref),
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+)
+
+type CreateDataRetentionPolicyInput struct {
+	Target                 string     `json:"target" binding:"required"`
+	TableId                *uuid.UUID `json:"table_id"`
+	SupersededVersionsDays *int       `json:"superseded_versions_days"`
+	MaxAgeDays             *int       `json:"max_age_days"`
+	Enabled                *bool      `json:"enabled"`
+}
+
+type UpdateDataRetentionPolicyInput struct {
+	SupersededVersionsDays *int `json:"superseded_versions_days"`
+	MaxAgeDays             *int `json:"max_age_days"`
+	Enabled                bool `json:"enabled"`
+}
+
+type DataRetentionPolicy struct {
+	Id                     uuid.UUID  `json:"id"`
+	Target                 string     `json:"target"`
+	TableId                *uuid.UUID `json:"table_id"`
+	SupersededVersionsDays *int       `json:"superseded_versions_days"`
+	MaxAgeDays             *int       `json:"max_age_days"`
+	Enabled                bool       `json:"enabled"`
+	CreatedAt              time.Time  `json:"created_at"`
+	UpdatedAt              time.Time  `json:"updated_at"`
+}
+
+func AdaptDataRetentionPolicy(m models.DataRetentionPolicy) DataRetentionPolicy {
+	return DataRetentionPolicy{
+		Id:                     m.Id,
+		Target:                 string(m.Target),
+		TableId:                m.TableId,
+		SupersededVersionsDays: m.SupersededVersionsDays,
+		MaxAgeDays:             m.MaxAgeDays,
+		Enabled:                m.Enabled,
+		CreatedAt:              m.CreatedAt,
+		UpdatedAt:              m.UpdatedAt,
+	}
+}
+
+type DataRetentionRun struct {
+	Id                    uuid.UUID  `json:"id"`
+	PolicyId              uuid.UUID  `json:"policy_id"`
+	SupersededRowsDeleted int64      `json:"superseded_rows_deleted"`
+	ExpiredRowsDeleted    int64      `json:"expired_rows_deleted"`
+	DecisionsDeleted      int64      `json:"decisions_deleted"`
+	Reclaimed             int64      `json:"reclaimed"`
+	SkippedReason         *string    `json:"skipped_reason"`
+	Error                 *string    `json:"error"`
+	StartedAt             time.Time  `json:"started_at"`
+	FinishedAt            *time.Time `json:"finished_at"`
+}
+
+func AdaptDataRetentionRun(m models.DataRetentionRun) DataRetentionRun {
+	return DataRetentionRun{
+		Id:                    m.Id,
+		PolicyId:              m.PolicyId,
+		SupersededRowsDeleted: m.SupersededRowsDeleted,
+		ExpiredRowsDeleted:    m.ExpiredRowsDeleted,
+		DecisionsDeleted:      m.DecisionsDeleted,
+		Reclaimed:             m.Reclaimed(),
+		SkippedReason:         m.SkippedReason,
+		Error:                 m.Error,
+		StartedAt:             m.StartedAt,
+		FinishedAt:            m.FinishedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+type CreateDataSubjectErasureInput struct {
+	ObjectType string `json:"object_type" binding:"required"`
+	ObjectId   string `json:"object_id" binding:"required"`
+}
+
+type DataSubjectErasure struct {
+	Id          uuid.UUID                `json:"id"`
+	ObjectType  string                   `json:"object_type"`
+	ObjectId    *string                  `json:"object_id"`
+	Status      string                   `json:"status"`
+	Stage       string                   `json:"stage"`
+	Counts      DataSubjectErasureCounts `json:"counts"`
+	Error       *string                  `json:"error"`
+	CreatedBy   *string                  `json:"created_by"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+	CompletedAt *time.Time               `json:"completed_at"`
+}
+
+type DataSubjectErasureCounts struct {
+	Objects          int64 `json:"objects"`
+	IngestedRows     int64 `json:"ingested_rows"`
+	MonitoredObjects int64 `json:"monitored_objects"`
+	Decisions        int64 `json:"decisions"`
+	Screenings       int64 `json:"screenings"`
+	ScreeningMatches int64 `json:"screening_matches"`
+	// Continuous screenings of the objects, and the matches on them
+	ContinuousScreenings       int64 `json:"continuous_screenings"`
+	ContinuousScreeningMatches int64 `json:"continuous_screening_matches"`
+	Annotations                int64 `json:"annotations"`
+	Files                      int64 `json:"files"`
+	CaseComments               int64 `json:"case_comments"`
+}
+
+func AdaptDataSubjectErasure(m models.DataSubjectErasure) DataSubjectErasure {
+	return DataSubjectErasure{
+		Id:          m.Id,
+		ObjectType:  m.ObjectType,
+		ObjectId:    m.ObjectId,
+		Status:      string(m.Status),
+		Stage:       string(m.Stage),
+		Counts:      DataSubjectErasureCounts(m.Counts),
+		Error:       m.Error,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		CompletedAt: m.CompletedAt,
+	}
+}
+
+type DataSubjectErasureReport struct {
+	Erasure *DataSubjectErasure        `json:"erasure"`
+	Objects []DataSubjectErasureObject `json:"objects"`
+	Counts  DataSubjectErasureCounts   `json:"counts"`
+}
+
+type DataSubjectErasureObject struct {
+	ObjectType string `json:"object_type"`
+	ObjectId   string `json:"object_id"`
+	Depth      int    `json:"depth"`
+}
+
+func AdaptDataSubjectErasureReport(m models.DataSubjectErasureReport) DataSubjectErasureReport {
+	r := DataSubjectErasureReport{
+		Objects: pure_utils.Map(m.Targets.Objects, func(o models.DataSubjectErasureObject) DataSubjectErasureObject {
+			return DataSubjectErasureObject(o)
+		}),
+		Counts: DataSubjectErasureCounts(m.Counts),
+	}
+	if m.Erasure != nil {
+		r.Erasure = utils.Ptr(AdaptDataSubjectErasure(*m.Erasure))
+	}
+	return r
+}
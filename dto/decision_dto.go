@@ -72,6 +72,7 @@ type DecisionRule struct {
 	RuleId        string    `json:"rule_id"`
 	ScoreModifier int       `json:"score_modifier"`
 	ErrorCode     *int      `json:"error_code"`
+	RuleSnoozeId  *string   `json:"rule_snooze_id,omitempty"`
 
 	// RuleEvaluation is not returned by default, it only is for endpoints consumed by the frontend
 	RuleEvaluation *ast.NodeEvaluationDto `json:"rule_evaluation,omitempty"`
@@ -118,6 +119,7 @@ type Decision struct {
 	Scenario             DecisionScenario `json:"scenario"`
 	Score                int              `json:"score"`
 	ScheduledExecutionId *string          `json:"scheduled_execution_id"`
+	RolloutArm           string           `json:"rollout_arm,omitempty"`
 }
 
 type DecisionWithRules struct {
@@ -145,6 +147,7 @@ func NewDecisionDto(decision models.Decision, marbleAppUrl *url.URL) Decision {
 		},
 		Score:                decision.Score,
 		ScheduledExecutionId: decision.ScheduledExecutionId,
+		RolloutArm:           string(decision.RolloutArm),
 	}
 
 	if decision.Case != nil {
@@ -214,6 +217,7 @@ func NewDecisionRuleDto(rule models.RuleExecution, withRuleExecution bool) Decis
 		Result:        rule.Result,
 		RuleId:        rule.Rule.Id,
 		Error:         ErrorDtoFromError(rule.ExecutionError),
+		RuleSnoozeId:  rule.RuleSnoozeId,
 	}
 	if withRuleExecution {
 		out.RuleEvaluation = rule.Evaluation
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+)
+
+type IngestionDeadLetterFilters struct {
+	ObjectType  *string `form:"object_type"`
+	Status      *string `form:"status" binding:"omitempty,oneof=pending replayed"`
+	UploadLogId *string `form:"upload_log_id" binding:"omitempty,uuid"`
+}
+
+func AdaptIngestionDeadLetterFilters(filters IngestionDeadLetterFilters) models.IngestionDeadLetterFilters {
+	result := models.IngestionDeadLetterFilters{
+		ObjectType: filters.ObjectType,
+	}
+	if filters.Status != nil {
+		status := models.IngestionDeadLetterStatus(*filters.Status)
+		result.Status = &status
+	}
+	if filters.UploadLogId != nil {
+		uploadLogId := uuid.MustParse(*filters.UploadLogId)
+		result.UploadLogId = &uploadLogId
+	}
+	return result
+}
+
+type ReplayIngestionDeadLetterInput struct {
+	// Replaces the rejected payload before it is replayed, in the same format
+	Payload json.RawMessage `json:"payload"`
+}
+
+type ReplayIngestionDeadLettersInput struct {
+	ObjectType  *string    `json:"object_type"`
+	UploadLogId *uuid.UUID `json:"upload_log_id"`
+}
+
+type IngestionDeadLetter struct {
+	Id            uuid.UUID         `json:"id"`
+	ObjectType    string            `json:"object_type"`
+	ObjectId      *string           `json:"object_id"`
+	Source        string            `json:"source"`
+	PayloadFormat string            `json:"payload_format"`
+	IsPatch       bool              `json:"is_patch"`
+	Error         string            `json:"error"`
+	FieldErrors   map[string]string `json:"field_errors"`
+	UploadLogId   *uuid.UUID        `json:"upload_log_id"`
+	Status        string            `json:"status"`
+	ReplayCount   int               `json:"replay_count"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+	ReplayedAt    *time.Time        `json:"replayed_at"`
+}
+
+type IngestionDeadLetterWithPayload struct {
+	IngestionDeadLetter
+	Payload json.RawMessage `json:"payload"`
+}
+
+func AdaptIngestionDeadLetter(m models.IngestionDeadLetter) IngestionDeadLetter {
+	return IngestionDeadLetter{
+		Id:            m.Id,
+		ObjectType:    m.ObjectType,
+		ObjectId:      m.ObjectId,
+		Source:        string(m.Source),
+		PayloadFormat: string(m.PayloadFormat),
+		IsPatch:       m.IsPatch,
+		Error:         m.Error,
+		FieldErrors:   m.FieldErrors,
+		UploadLogId:   m.UploadLogId,
+		Status:        string(m.Status),
+		ReplayCount:   m.ReplayCount,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+		ReplayedAt:    m.ReplayedAt,
+	}
+}
+
+func AdaptIngestionDeadLetterWithPayload(m models.IngestionDeadLetter) IngestionDeadLetterWithPayload {
+	return IngestionDeadLetterWithPayload{
+		IngestionDeadLetter: AdaptIngestionDeadLetter(m),
+		Payload:             m.Payload,
+	}
+}
+
+type IngestionDeadLetterReplayReport struct {
+	Replayed int  `json:"replayed"`
+	Failed   int  `json:"failed"`
+	HasMore  bool `json:"has_more"`
+}
+
+func AdaptIngestionDeadLetterReplayReport(m models.IngestionDeadLetterReplayReport) IngestionDeadLetterReplayReport {
+	return IngestionDeadLetterReplayReport{
+		Replayed: m.Replayed,
+		Failed:   m.Failed,
+		HasMore:  m.HasMore,
+	}
+}
@@ -0,0 +1,101 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/google/uuid"
+)
+
+type IngestionSourceFileMapping struct {
+	Pattern    string `json:"pattern" binding:"required"`
+	TableName  string `json:"table_name" binding:"required"`
+	FileFormat string `json:"file_format"`
+}
+
+func (m IngestionSourceFileMapping) ToModel() models.IngestionSourceFileMapping {
+	return models.IngestionSourceFileMapping{
+		Pattern:    m.Pattern,
+		TableName:  m.TableName,
+		FileFormat: models.IngestionFileFormat(m.FileFormat),
+	}
+}
+
+func AdaptIngestionSourceFileMapping(m models.IngestionSourceFileMapping) IngestionSourceFileMapping {
+	return IngestionSourceFileMapping{
+		Pattern:    m.Pattern,
+		TableName:  m.TableName,
+		FileFormat: string(m.FileFormat),
+	}
+}
+
+type CreateIngestionSourceInput struct {
+	Name         string                       `json:"name" binding:"required"`
+	BucketUrl    string                       `json:"bucket_url" binding:"required"`
+	Prefix       string                       `json:"prefix"`
+	FileMappings []IngestionSourceFileMapping `json:"file_mappings" binding:"required,dive"`
+	Enabled      *bool                        `json:"enabled"`
+}
+
+type UpdateIngestionSourceInput struct {
+	Name         string                       `json:"name" binding:"required"`
+	BucketUrl    string                       `json:"bucket_url" binding:"required"`
+	Prefix       string                       `json:"prefix"`
+	FileMappings []IngestionSourceFileMapping `json:"file_mappings" binding:"required,dive"`
+	Enabled      bool                         `json:"enabled"`
+}
+
+type IngestionSource struct {
+	Id           uuid.UUID                    `json:"id"`
+	Name         string                       `json:"name"`
+	BucketUrl    string                       `json:"bucket_url"`
+	Prefix       string                       `json:"prefix"`
+	FileMappings []IngestionSourceFileMapping `json:"file_mappings"`
+	Enabled      bool                         `json:"enabled"`
+	ConfiguredBy uuid.UUID                    `json:"configured_by"`
+	LastPolledAt *time.Time                   `json:"last_polled_at"`
+	LastError    *string                      `json:"last_error"`
+	CreatedAt    time.Time                    `json:"created_at"`
+	UpdatedAt    time.Time                    `json:"updated_at"`
+}
+
+func AdaptIngestionSource(m models.IngestionSource) IngestionSource {
+	return IngestionSource{
+		Id:           m.Id,
+		Name:         m.Name,
+		BucketUrl:    m.BucketUrl,
+		Prefix:       m.Prefix,
+		FileMappings: pure_utils.Map(m.FileMappings, AdaptIngestionSourceFileMapping),
+		Enabled:      m.Enabled,
+		ConfiguredBy: m.ConfiguredBy,
+		LastPolledAt: m.LastPolledAt,
+		LastError:    m.LastError,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+type IngestionSourceFile struct {
+	Id          uuid.UUID  `json:"id"`
+	Key         string     `json:"key"`
+	ETag        string     `json:"etag"`
+	Size        int64      `json:"size"`
+	TableName   string     `json:"table_name"`
+	UploadLogId *uuid.UUID `json:"upload_log_id"`
+	Error       *string    `json:"error"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func AdaptIngestionSourceFile(m models.IngestionSourceFile) IngestionSourceFile {
+	return IngestionSourceFile{
+		Id:          m.Id,
+		Key:         m.Key,
+		ETag:        m.ETag,
+		Size:        m.Size,
+		TableName:   m.TableName,
+		UploadLogId: m.UploadLogId,
+		Error:       m.Error,
+		CreatedAt:   m.CreatedAt,
+	}
+}
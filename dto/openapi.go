@@ -2,17 +2,49 @@ package dto
 
 import (
 	"fmt"
+	"maps"
 	"slices"
+	"strings"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/utils"
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
+// Prefix of the components holding the schemas of the tables of the data model, which cannot
+// collide with the components of the base specification.
+const dataModelSchemaComponentPrefix = "DataModel."
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// OpenAPIFromDataModel completes the base specification with the ingestion endpoints of every
+// table and the shape of the trigger objects accepted by the decision endpoints. The version of the
+// specification carries the schema hash of the data model.
 func OpenAPIFromDataModel(dataModel models.DataModel, spec *openapi3.T) (*openapi3.T, error) {
-	for _, table := range dataModel.Tables {
+	hash := dataModel.SchemaHash()
+	if spec.Info != nil {
+		spec.Info.Version = fmt.Sprintf("%s+%s", spec.Info.Version, hash[:12])
+		if spec.Info.Extensions == nil {
+			spec.Info.Extensions = make(map[string]any)
+		}
+		spec.Info.Extensions["x-data-model-hash"] = hash
+	}
+	if spec.Components == nil {
+		spec.Components = &openapi3.Components{}
+	}
+	if spec.Components.Schemas == nil {
+		spec.Components.Schemas = make(openapi3.Schemas)
+	}
+
+	tableNames := slices.Sorted(maps.Keys(dataModel.Tables))
+	for _, tableName := range tableNames {
+		table := dataModel.Tables[tableName]
 		fields, required := objectFields(table)
 
+		spec.Components.Schemas[dataModelSchemaComponentPrefix+table.Name] = &openapi3.SchemaRef{
+			Value: tableSchema(table),
+		}
+
 		spec.Paths.Set(fmt.Sprintf("/ingest/%s", table.Name), &openapi3.PathItem{
 			Post:  ingestOp(spec, false, false, table, fields, required),
 			Patch: ingestOp(spec, false, true, table, fields, required),
@@ -24,9 +56,81 @@ func OpenAPIFromDataModel(dataModel models.DataModel, spec *openapi3.T) (*openap
 		})
 	}
 
+	setDecisionTriggerObjects(spec, tableNames)
+
 	return spec, nil
 }
 
+// setDecisionTriggerObjects replaces the free-form trigger objects of the decision endpoints with
+// the schemas of the tables, and restricts the trigger object types to the names of the tables.
+func setDecisionTriggerObjects(spec *openapi3.T, tableNames []string) {
+	if len(tableNames) == 0 {
+		return
+	}
+
+	tables := make([]any, 0, len(tableNames))
+	refs := make(openapi3.SchemaRefs, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		tables = append(tables, tableName)
+		refs = append(refs, openapi3.NewSchemaRef(
+			"#/components/schemas/"+dataModelSchemaComponentPrefix+tableName, nil))
+	}
+
+	for path, item := range spec.Paths.Map() {
+		if !strings.HasPrefix(path, "/decisions") || item.Post == nil ||
+			item.Post.RequestBody == nil || item.Post.RequestBody.Value == nil {
+			continue
+		}
+		media := item.Post.RequestBody.Value.Content.Get("application/json")
+		if media == nil || media.Schema == nil || media.Schema.Value == nil {
+			continue
+		}
+
+		properties := media.Schema.Value.Properties
+		if property, ok := properties["trigger_object"]; ok && property.Value != nil {
+			properties["trigger_object"] = &openapi3.SchemaRef{Value: &openapi3.Schema{
+				Description: property.Value.Description,
+				AnyOf:       refs,
+			}}
+		}
+		if property, ok := properties["trigger_objects"]; ok && property.Value != nil && property.Value.Items != nil {
+			property.Value.Items = &openapi3.SchemaRef{Value: &openapi3.Schema{AnyOf: refs}}
+		}
+		if property, ok := properties["trigger_object_type"]; ok && property.Value != nil {
+			property.Value.Enum = tables
+		}
+	}
+}
+
+// JSONSchemaFromTable returns a standalone JSON Schema of the objects of the table.
+func JSONSchemaFromTable(dataModel models.DataModel, table models.Table) *openapi3.Schema {
+	hash := dataModel.SchemaHash()
+
+	schema := tableSchema(table)
+	schema.SchemaDialect = jsonSchemaDialect
+	schema.SchemaID = fmt.Sprintf("urn:marble:data-model:%s:%s", hash, table.Name)
+	schema.Extensions["x-data-model-hash"] = hash
+	return schema
+}
+
+// JSONSchemaFromDataModel returns a JSON Schema holding the schemas of all the tables of the data
+// model as definitions.
+func JSONSchemaFromDataModel(dataModel models.DataModel) *openapi3.Schema {
+	hash := dataModel.SchemaHash()
+
+	defs := make(openapi3.Schemas, len(dataModel.Tables))
+	for _, table := range dataModel.Tables {
+		defs[table.Name] = &openapi3.SchemaRef{Value: tableSchema(table)}
+	}
+
+	return &openapi3.Schema{
+		SchemaDialect: jsonSchemaDialect,
+		SchemaID:      fmt.Sprintf("urn:marble:data-model:%s", hash),
+		Defs:          defs,
+		Extensions:    map[string]any{"x-data-model-hash": hash},
+	}
+}
+
 func ingestOp(spec *openapi3.T, isBatch, isPatch bool, table models.Table, fields openapi3.Schemas, required []string) *openapi3.Operation {
 	summary := fmt.Sprintf("Ingest an object into '%s'", table.Name)
 	if isBatch {
@@ -68,35 +172,118 @@ func objectFields(table models.Table) (openapi3.Schemas, []string) {
 	required := make([]string, 0, len(table.Fields))
 
 	for _, field := range table.Fields {
-		types := openapi3.Types{}
-		format := ""
-
-		if !field.Nullable {
+		if field.Archived {
+			continue
+		}
+		if !field.Nullable && !field.IsDerived() {
 			required = append(required, field.Name)
 		}
 
-		switch field.DataType {
-		case models.String:
-			types = openapi3.Types{openapi3.TypeString}
-		case models.Int:
-			types = openapi3.Types{openapi3.TypeInteger}
-		case models.Float:
-			types = openapi3.Types{openapi3.TypeNumber}
-		case models.Timestamp:
-			types = openapi3.Types{openapi3.TypeString}
-			format = "date-time"
+		fields[field.Name] = &openapi3.SchemaRef{Value: fieldSchema(table, field)}
+	}
+
+	return fields, required
+}
+
+// tableSchema is the schema of a complete object of the table, unknown fields excluded.
+func tableSchema(table models.Table) *openapi3.Schema {
+	fields, required := objectFields(table)
+	schema := objectSchema(false, fields, required)
+	schema.Title = table.Name
+	schema.Description = table.Description
+	schema.AdditionalProperties = openapi3.AdditionalProperties{Has: utils.Ptr(false)}
+	schema.Extensions = map[string]any{}
+	return &schema
+}
+
+func fieldSchema(table models.Table, field models.Field) *openapi3.Schema {
+	schema := &openapi3.Schema{
+		Description: field.Description,
+		// Derived fields are computed by Marble, the values sent for them are ignored
+		ReadOnly:   field.IsDerived(),
+		Extensions: map[string]any{"x-marble-data-type": field.DataType.String()},
+	}
+
+	types := openapi3.Types{}
+	switch field.DataType {
+	case models.Bool:
+		types = openapi3.Types{openapi3.TypeBoolean}
+	case models.String:
+		types = openapi3.Types{openapi3.TypeString}
+	case models.Int:
+		types = openapi3.Types{openapi3.TypeInteger}
+	case models.Float:
+		types = openapi3.Types{openapi3.TypeNumber}
+	case models.Timestamp:
+		types = openapi3.Types{openapi3.TypeString}
+		schema.Format = "date-time"
+	case models.IpAddress:
+		types = openapi3.Types{openapi3.TypeString}
+	case models.Coords:
+		types = openapi3.Types{openapi3.TypeString}
+		schema.Pattern = `^-?[0-9]+(\.[0-9]+)?,-?[0-9]+(\.[0-9]+)?$`
+	}
+	if field.Nullable {
+		types = append(types, openapi3.TypeNull)
+	}
+	schema.Type = &types
+
+	// Enum fields accept any value, the values seen so far are listed without restricting the field
+	if field.IsEnum {
+		schema.Extensions["x-marble-enum"] = true
+		if len(field.Values) > 0 {
+			schema.Extensions["x-marble-enum-values"] = models.SortedEnumValues(field.Values)
+		}
+	}
+
+	if c := field.Constraints; c != nil {
+		schema.Min = c.Min
+		schema.Max = c.Max
+		if c.MinLength != nil {
+			schema.MinLength = uint64(*c.MinLength)
+		}
+		if c.MaxLength != nil {
+			schema.MaxLength = utils.Ptr(uint64(*c.MaxLength))
+		}
+		if c.Pattern != nil {
+			schema.Pattern = *c.Pattern
 		}
+		if c.Format != nil {
+			schema.Extensions["x-marble-format"] = string(*c.Format)
+			if pattern, ok := fieldConstraintFormatPatterns[*c.Format]; ok {
+				schema.AllOf = openapi3.SchemaRefs{{Value: &openapi3.Schema{Pattern: pattern}}}
+			}
+		}
+		if len(c.AllowedValues) > 0 {
+			schema.Enum = slices.Clone(c.AllowedValues)
+			if field.Nullable {
+				schema.Enum = append(schema.Enum, nil)
+			}
+		}
+	}
 
-		fields[field.Name] = &openapi3.SchemaRef{
-			Value: &openapi3.Schema{
-				Description: field.Description,
-				Type:        &types,
-				Format:      format,
-			},
+	links := make([]map[string]string, 0)
+	for _, linkName := range slices.Sorted(maps.Keys(table.LinksToSingle)) {
+		link := table.LinksToSingle[linkName]
+		if link.ChildFieldName == field.Name {
+			links = append(links, map[string]string{
+				"name":         link.Name,
+				"parent_table": link.ParentTableName,
+				"parent_field": link.ParentFieldName,
+			})
 		}
 	}
+	if len(links) > 0 {
+		schema.Extensions["x-marble-links"] = links
+	}
 
-	return fields, required
+	return schema
+}
+
+// Patterns matching the shape of the well-known formats, which are checked more strictly by Marble
+var fieldConstraintFormatPatterns = map[models.FieldConstraintFormat]string{
+	models.FieldConstraintFormatIsoCountry:  "^[A-Z]{2}$",
+	models.FieldConstraintFormatIsoCurrency: "^[A-Z]{3}$",
 }
 
 func requestBody(isBatch, isPatch bool, fields openapi3.Schemas, required []string) *openapi3.Schema {
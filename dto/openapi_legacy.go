@@ -340,7 +340,11 @@ func decisionRuleSchema() ComponentsSchema {
 			},
 			"outcome": {
 				Type: utils.Ptr("string"),
-				Enum: []string{"hit", "no_hit", "error", "snoozed"},
+				Enum: []string{"hit", "no_hit", "error", "snoozed", "throttled"},
+			},
+			"rule_snooze_id": {
+				Type:        utils.Ptr("string"),
+				Description: utils.Ptr("Id of the snooze that applied to the rule, when the outcome is snoozed."),
 			},
 		},
 	}
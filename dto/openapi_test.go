@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openAPITestDataModel() models.DataModel {
+	return models.DataModel{
+		Tables: map[string]models.Table{
+			"transactions": {
+				Name: "transactions",
+				Fields: map[string]models.Field{
+					"object_id":  {Name: "object_id", DataType: models.String},
+					"updated_at": {Name: "updated_at", DataType: models.Timestamp},
+					"account_id": {Name: "account_id", DataType: models.String, Nullable: true},
+					"amount":     {Name: "amount", DataType: models.Float},
+					"legacy":     {Name: "legacy", DataType: models.Int, Archived: true},
+					"status": {
+						Name:     "status",
+						DataType: models.String,
+						IsEnum:   true,
+						Values:   []any{"refused", "accepted"},
+					},
+					"is_large": {
+						Name:              "is_large",
+						DataType:          models.Bool,
+						Nullable:          true,
+						DerivedExpression: &ast.Node{Constant: true},
+					},
+				},
+				LinksToSingle: map[string]models.LinkToSingle{
+					"account": {
+						Name:            "account",
+						ParentTableName: "accounts",
+						ParentFieldName: "object_id",
+						ChildFieldName:  "account_id",
+					},
+				},
+			},
+			"accounts": {
+				Name: "accounts",
+				Fields: map[string]models.Field{
+					"object_id":  {Name: "object_id", DataType: models.String},
+					"updated_at": {Name: "updated_at", DataType: models.Timestamp},
+				},
+			},
+		},
+	}
+}
+
+func TestJSONSchemaFromTable(t *testing.T) {
+	dataModel := openAPITestDataModel()
+	schema := JSONSchemaFromTable(dataModel, dataModel.Tables["transactions"])
+
+	assert.Equal(t, jsonSchemaDialect, schema.SchemaDialect)
+	assert.Equal(t, "urn:marble:data-model:"+dataModel.SchemaHash()+":transactions", schema.SchemaID)
+	assert.Equal(t, []string{"amount", "object_id", "status", "updated_at"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "legacy")
+
+	accountId := schema.Properties["account_id"].Value
+	assert.Equal(t, []string{openapi3.TypeString, openapi3.TypeNull}, accountId.Type.Slice())
+	assert.Equal(t, []map[string]string{{
+		"name":         "account",
+		"parent_table": "accounts",
+		"parent_field": "object_id",
+	}}, accountId.Extensions["x-marble-links"])
+
+	status := schema.Properties["status"].Value
+	assert.Equal(t, true, status.Extensions["x-marble-enum"])
+	assert.Equal(t, []any{"accepted", "refused"}, status.Extensions["x-marble-enum-values"])
+	assert.Empty(t, status.Enum, "enum fields accept values not seen so far")
+
+	assert.True(t, schema.Properties["is_large"].Value.ReadOnly)
+	assert.Equal(t, "date-time", schema.Properties["updated_at"].Value.Format)
+}
+
+func TestOpenAPIFromDataModel(t *testing.T) {
+	dataModel := openAPITestDataModel()
+	spec := &openapi3.T{
+		Info:  &openapi3.Info{Version: "1.0.0"},
+		Paths: openapi3.NewPaths(),
+	}
+	spec.Paths.Set("/decisions", &openapi3.PathItem{
+		Post: &openapi3.Operation{
+			RequestBody: &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchema(
+				openapi3.NewObjectSchema().
+					WithProperty("trigger_object_type", openapi3.NewStringSchema()).
+					WithPropertyRef("trigger_object", &openapi3.SchemaRef{
+						Value: &openapi3.Schema{Description: "The object"},
+					}),
+			)},
+		},
+	})
+
+	spec, err := OpenAPIFromDataModel(dataModel, spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.0+"+dataModel.SchemaHash()[:12], spec.Info.Version)
+	assert.Contains(t, spec.Components.Schemas, "DataModel.transactions")
+	assert.NotNil(t, spec.Paths.Value("/ingest/transactions"))
+	assert.NotNil(t, spec.Paths.Value("/ingest/accounts/batch"))
+
+	body := spec.Paths.Value("/decisions").Post.RequestBody.Value.Content.Get("application/json").Schema.Value
+	assert.Equal(t, []any{"accounts", "transactions"}, body.Properties["trigger_object_type"].Value.Enum)
+
+	triggerObject := body.Properties["trigger_object"].Value
+	assert.Equal(t, "The object", triggerObject.Description)
+	require.Len(t, triggerObject.AnyOf, 2)
+	assert.Equal(t, "#/components/schemas/DataModel.accounts", triggerObject.AnyOf[0].Ref)
+	assert.Equal(t, "#/components/schemas/DataModel.transactions", triggerObject.AnyOf[1].Ref)
+}
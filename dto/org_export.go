@@ -22,6 +22,8 @@ func AdaptImportOrgDto(org models.Organization) ImportOrg {
 			SanctionsThreshold:      utils.Ptr(org.OpenSanctionsConfig.MatchThreshold),
 			SanctionsLimit:          utils.Ptr(org.OpenSanctionsConfig.MatchLimit),
 			ScreeningProviders:      screeningProviders,
+			NameNormalization:       utils.Ptr(org.OpenSanctionsConfig.NameNormalization),
+			NameStoplist:            utils.Ptr(org.OpenSanctionsConfig.NameStoplist),
 		},
 	}
 }
@@ -12,16 +12,19 @@ import (
 )
 
 type APIOrganization struct {
-	Id                      string                                               `json:"id"`
-	Name                    string                                               `json:"name"`
-	DefaultScenarioTimezone *string                                              `json:"default_scenario_timezone"`
-	ScreeningProviders      map[models.ScreeningFeature]models.ScreeningProvider `json:"screening_providers"`
-	SanctionsThreshold      int                                                  `json:"sanctions_threshold"`
-	SanctionsLimit          int                                                  `json:"sanctions_limit"`
-	AutoAssignQueueLimit    int                                                  `json:"auto_assign_queue_limit"`
-	AllowedNetworks         []SubnetDto                                          `json:"allowed_networks"`
-	SentryReplayEnabled     bool                                                 `json:"sentry_replay_enabled"`
-	Environment             string                                               `json:"environment"`
+	Id                         string                                               `json:"id"`
+	Name                       string                                               `json:"name"`
+	DefaultScenarioTimezone    *string                                              `json:"default_scenario_timezone"`
+	ScreeningProviders         map[models.ScreeningFeature]models.ScreeningProvider `json:"screening_providers"`
+	SanctionsThreshold         int                                                  `json:"sanctions_threshold"`
+	SanctionsLimit             int                                                  `json:"sanctions_limit"`
+	NameNormalization          bool                                                 `json:"name_normalization"`
+	NameStoplist               []string                                             `json:"name_stoplist"`
+	AutoAssignQueueLimit       int                                                  `json:"auto_assign_queue_limit"`
+	AllowedNetworks            []SubnetDto                                          `json:"allowed_networks"`
+	SentryReplayEnabled        bool                                                 `json:"sentry_replay_enabled"`
+	RequirePublicationApproval bool                                                 `json:"require_publication_approval"`
+	Environment                string                                               `json:"environment"`
 }
 
 func AdaptOrganizationDto(org models.Organization) APIOrganization {
@@ -32,12 +35,15 @@ func AdaptOrganizationDto(org models.Organization) APIOrganization {
 		ScreeningProviders:      org.OpenSanctionsConfig.Providers,
 		SanctionsThreshold:      org.OpenSanctionsConfig.MatchThreshold,
 		SanctionsLimit:          org.OpenSanctionsConfig.MatchLimit,
+		NameNormalization:       org.OpenSanctionsConfig.NameNormalization,
+		NameStoplist:            org.OpenSanctionsConfig.NameStoplist,
 		AutoAssignQueueLimit:    org.AutoAssignQueueLimit,
 		AllowedNetworks: pure_utils.Map(org.WhitelistedSubnets, func(subnet net.IPNet) SubnetDto {
 			return SubnetDto{subnet}
 		}),
-		SentryReplayEnabled: org.SentryReplayEnabled,
-		Environment:         org.Environment.String(),
+		SentryReplayEnabled:        org.SentryReplayEnabled,
+		RequirePublicationApproval: org.RequirePublicationApproval,
+		Environment:                org.Environment.String(),
 	}
 }
 
@@ -64,25 +70,31 @@ func AdaptCreateOrganizationInput(dto CreateOrganizationBodyDto) (models.CreateO
 }
 
 type UpdateOrganizationBodyDto struct {
-	DefaultScenarioTimezone *string                             `json:"default_scenario_timezone,omitempty"`
-	SanctionsThreshold      *int                                `json:"sanctions_threshold,omitempty"`
-	SanctionsLimit          *int                                `json:"sanctions_limit,omitempty"`
-	ScreeningProviders      map[string]models.ScreeningProvider `json:"screening_providers,omitempty"`
-	AutoAssignQueueLimit    *int                                `json:"auto_assign_queue_limit,omitempty"`
-	SentryReplayEnabled     *bool                               `json:"sentry_replay_enabled"`
-	Environment             *string                             `json:"environment"`
+	DefaultScenarioTimezone    *string                             `json:"default_scenario_timezone,omitempty"`
+	SanctionsThreshold         *int                                `json:"sanctions_threshold,omitempty"`
+	SanctionsLimit             *int                                `json:"sanctions_limit,omitempty"`
+	ScreeningProviders         map[string]models.ScreeningProvider `json:"screening_providers,omitempty"`
+	NameNormalization          *bool                               `json:"name_normalization,omitempty"`
+	NameStoplist               *[]string                           `json:"name_stoplist,omitempty"`
+	AutoAssignQueueLimit       *int                                `json:"auto_assign_queue_limit,omitempty"`
+	SentryReplayEnabled        *bool                               `json:"sentry_replay_enabled"`
+	RequirePublicationApproval *bool                               `json:"require_publication_approval"`
+	Environment                *string                             `json:"environment"`
 }
 
 func AdaptUpdateOrganizationInput(dto UpdateOrganizationBodyDto) (models.UpdateOrganizationInput, error) {
 	out := models.UpdateOrganizationInput{
 		DefaultScenarioTimezone: dto.DefaultScenarioTimezone,
 		ScreeningConfig: models.OrganizationOpenSanctionsConfigUpdateInput{
-			Providers:      dto.ScreeningProviders,
-			MatchThreshold: dto.SanctionsThreshold,
-			MatchLimit:     dto.SanctionsLimit,
+			Providers:         dto.ScreeningProviders,
+			MatchThreshold:    dto.SanctionsThreshold,
+			MatchLimit:        dto.SanctionsLimit,
+			NameNormalization: dto.NameNormalization,
+			NameStoplist:      dto.NameStoplist,
 		},
-		AutoAssignQueueLimit: dto.AutoAssignQueueLimit,
-		SentryReplayEnabled:  dto.SentryReplayEnabled,
+		AutoAssignQueueLimit:       dto.AutoAssignQueueLimit,
+		SentryReplayEnabled:        dto.SentryReplayEnabled,
+		RequirePublicationApproval: dto.RequirePublicationApproval,
 	}
 
 	if dto.ScreeningProviders != nil {
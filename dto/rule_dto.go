@@ -20,6 +20,9 @@ type RuleDto struct {
 	ScoreModifier        int       `json:"score_modifier"`
 	CreatedAt            time.Time `json:"created_at"`
 	RuleGroup            string    `json:"rule_group"`
+	// Empty when the rule is not throttled
+	ThrottlePeriod string `json:"throttle_period"`
+	ThrottleScope  string `json:"throttle_scope"`
 }
 
 type RuleMetadataDto struct {
@@ -43,6 +46,9 @@ type CreateRuleInputBody struct {
 	FormulaAstExpression *NodeDto `json:"formula_ast_expression"`
 	ScoreModifier        int      `json:"score_modifier"`
 	RuleGroup            string   `json:"rule_group"`
+	ThrottlePeriod       string   `json:"throttle_period"`
+	// "pivot" (the default) or "trigger_object"
+	ThrottleScope string `json:"throttle_scope"`
 }
 
 type UpdateRuleBody struct {
@@ -52,6 +58,9 @@ type UpdateRuleBody struct {
 	FormulaAstExpression *NodeDto `json:"formula_ast_expression"`
 	ScoreModifier        *int     `json:"score_modifier,omitempty"`
 	RuleGroup            *string  `json:"rule_group"`
+	// An empty string removes the throttling of the rule
+	ThrottlePeriod *string `json:"throttle_period"`
+	ThrottleScope  *string `json:"throttle_scope"`
 }
 
 func AdaptRuleDto(rule models.Rule) (RuleDto, error) {
@@ -76,9 +85,31 @@ func AdaptRuleDto(rule models.Rule) (RuleDto, error) {
 		ScoreModifier:        rule.ScoreModifier,
 		CreatedAt:            rule.CreatedAt,
 		RuleGroup:            rule.RuleGroup,
+		ThrottlePeriod:       throttlePeriodDto(rule.ThrottlePeriod),
+		ThrottleScope:        string(rule.ThrottleScope),
 	}, nil
 }
 
+func throttlePeriodDto(period time.Duration) string {
+	if period <= 0 {
+		return ""
+	}
+	return period.String()
+}
+
+// ParseThrottlePeriod reads a throttle period written as a Go duration (e.g. "24h"). An empty string
+// means that the rule is not throttled.
+func ParseThrottlePeriod(period string) (time.Duration, error) {
+	if period == "" {
+		return 0, nil
+	}
+	parsed, err := time.ParseDuration(period)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid throttle_period %q: %w", period, models.BadParameterError)
+	}
+	return parsed, nil
+}
+
 func AdaptRuleMetadataDto(rule models.RuleMetadata) RuleMetadataDto {
 	return RuleMetadataDto{
 		Id:                  rule.Id,
@@ -106,6 +137,18 @@ func AdaptCreateRuleInput(body CreateRuleInputBody, organizationId uuid.UUID) (m
 		RuleGroup:            body.RuleGroup,
 	}
 
+	throttlePeriod, err := ParseThrottlePeriod(body.ThrottlePeriod)
+	if err != nil {
+		return models.CreateRuleInput{}, err
+	}
+	createRuleInput.ThrottlePeriod = throttlePeriod
+
+	throttleScope, err := models.RuleThrottleScopeFrom(body.ThrottleScope)
+	if err != nil {
+		return models.CreateRuleInput{}, err
+	}
+	createRuleInput.ThrottleScope = throttleScope
+
 	if body.FormulaAstExpression != nil {
 		node, err := AdaptASTNode(*body.FormulaAstExpression)
 		if err != nil {
@@ -129,6 +172,22 @@ func AdaptUpdateRule(ruleId string, body UpdateRuleBody) (models.UpdateRuleInput
 		RuleGroup:            body.RuleGroup,
 	}
 
+	if body.ThrottlePeriod != nil {
+		throttlePeriod, err := ParseThrottlePeriod(*body.ThrottlePeriod)
+		if err != nil {
+			return models.UpdateRuleInput{}, err
+		}
+		updateRuleInput.ThrottlePeriod = &throttlePeriod
+	}
+
+	if body.ThrottleScope != nil {
+		throttleScope, err := models.RuleThrottleScopeFrom(*body.ThrottleScope)
+		if err != nil {
+			return models.UpdateRuleInput{}, err
+		}
+		updateRuleInput.ThrottleScope = &throttleScope
+	}
+
 	if body.FormulaAstExpression != nil {
 		node, err := AdaptASTNode(*body.FormulaAstExpression)
 		if err != nil {
@@ -4,16 +4,20 @@ import (
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
 )
 
 type RuleSnooze struct {
 	Id                    string    `json:"id"`
 	PivotValue            string    `json:"pivot_value"`
+	KeyField              *string   `json:"key_field"`
 	StartsAt              time.Time `json:"starts_at"`
 	ExpiresAt             time.Time `json:"ends_at"` //nolint:tagliatelle
 	CreatedByUser         *string   `json:"created_by_user,omitempty"`
 	CreatedFromDecisionId *string   `json:"created_from_decision_id"`
 	CreatedFromRuleId     string    `json:"created_from_rule_id"`
+
+	UnlessConditionAstExpression *NodeDto `json:"unless_condition_ast_expression"`
 }
 
 type RuleSnoozeWithRuleId struct {
@@ -21,16 +25,51 @@ type RuleSnoozeWithRuleId struct {
 	RuleId string `json:"rule_id"`
 }
 
-func AdaptRuleSnoose(r models.RuleSnooze) RuleSnooze {
+func AdaptRuleSnoose(r models.RuleSnooze) (RuleSnooze, error) {
+	unlessCondition, err := adaptSnoozeCondition(r.UnlessCondition)
+	if err != nil {
+		return RuleSnooze{}, err
+	}
+
 	return RuleSnooze{
 		Id:                    r.Id,
 		PivotValue:            r.PivotValue,
+		KeyField:              r.KeyField,
 		StartsAt:              r.StartsAt,
 		ExpiresAt:             r.ExpiresAt,
 		CreatedByUser:         r.CreatedByUser,
 		CreatedFromDecisionId: r.CreatedFromDecisionId,
 		CreatedFromRuleId:     r.CreatedFromRuleId,
+
+		UnlessConditionAstExpression: unlessCondition,
+	}, nil
+}
+
+func adaptSnoozeCondition(condition *ast.Node) (*NodeDto, error) {
+	if condition == nil {
+		return nil, nil
+	}
+	nodeDto, err := AdaptNodeDto(*condition)
+	if err != nil {
+		return nil, err
+	}
+	return &nodeDto, nil
+}
+
+// AdaptSnoozeConditionInput reads the condition of a snooze, and an empty key field as no key field
+// (the snooze is then on the pivot value).
+func AdaptSnoozeConditionInput(keyField *string, condition *NodeDto) (*string, *ast.Node, error) {
+	if keyField != nil && *keyField == "" {
+		keyField = nil
+	}
+	if condition == nil {
+		return keyField, nil, nil
+	}
+	node, err := AdaptASTNode(*condition)
+	if err != nil {
+		return nil, nil, err
 	}
+	return keyField, &node, nil
 }
 
 type SnoozesOfDecision struct {
@@ -38,18 +77,25 @@ type SnoozesOfDecision struct {
 	RuleSnoozes []RuleSnoozeWithRuleId `json:"rule_snoozes"`
 }
 
-func AdaptSnoozesOfDecision(s models.SnoozesOfDecision) SnoozesOfDecision {
+func AdaptSnoozesOfDecision(s models.SnoozesOfDecision) (SnoozesOfDecision, error) {
 	snoozes := make([]RuleSnoozeWithRuleId, 0, len(s.RuleSnoozes))
 	for _, s := range s.RuleSnoozes {
+		unlessCondition, err := adaptSnoozeCondition(s.UnlessCondition)
+		if err != nil {
+			return SnoozesOfDecision{}, err
+		}
 		snoozes = append(snoozes, RuleSnoozeWithRuleId{
 			RuleSnooze: RuleSnooze{
 				Id:                    s.Id,
 				PivotValue:            s.PivotValue,
+				KeyField:              s.KeyField,
 				StartsAt:              s.StartsAt,
 				ExpiresAt:             s.ExpiresAt,
 				CreatedByUser:         s.CreatedByUser,
 				CreatedFromDecisionId: s.CreatedFromDecisionId,
 				CreatedFromRuleId:     s.CreatedFromRuleId,
+
+				UnlessConditionAstExpression: unlessCondition,
 			},
 			RuleId: s.RuleId,
 		})
@@ -58,7 +104,7 @@ func AdaptSnoozesOfDecision(s models.SnoozesOfDecision) SnoozesOfDecision {
 	return SnoozesOfDecision{
 		DecisionId:  s.DecisionId,
 		RuleSnoozes: snoozes,
-	}
+	}, nil
 }
 
 type SnoozesOfIteration struct {
@@ -92,4 +138,21 @@ type SnoozeDecisionInput struct {
 	RuleId   string `json:"rule_id"`
 	Duration string `json:"duration"`
 	Comment  string `json:"comment"`
+	// Snooze on this field of the trigger object rather than on the pivot value
+	KeyField *string `json:"key_field"`
+	// Boolean formula on the trigger object of the decisions, for which the snooze does not apply. It does
+	// not see the snoozed decision: a threshold relative to it (e.g. twice its amount) must be a constant.
+	UnlessConditionAstExpression *NodeDto `json:"unless_condition_ast_expression"`
+}
+
+type SnoozeRuleInput struct {
+	Duration                     string   `json:"duration"`
+	KeyField                     *string  `json:"key_field"`
+	Values                       []string `json:"values"`
+	UnlessConditionAstExpression *NodeDto `json:"unless_condition_ast_expression"`
+}
+
+type EndRuleSnoozesInput struct {
+	SnoozeIds []string `json:"snooze_ids"`
+	All       bool     `json:"all"`
 }
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+)
+
+type CreateScenarioBacktestInput struct {
+	ScenarioId      string    `json:"scenario_id" binding:"required"`
+	TestIterationId string    `json:"test_iteration_id" binding:"required"`
+	WindowStart     time.Time `json:"window_start" binding:"required"`
+	WindowEnd       time.Time `json:"window_end" binding:"required"`
+}
+
+type ScenarioBacktest struct {
+	Id                 uuid.UUID  `json:"id"`
+	ScenarioId         uuid.UUID  `json:"scenario_id"`
+	TestIterationId    uuid.UUID  `json:"test_iteration_id"`
+	TestRunId          uuid.UUID  `json:"test_run_id"`
+	WindowStart        time.Time  `json:"window_start"`
+	WindowEnd          time.Time  `json:"window_end"`
+	Status             string     `json:"status"`
+	ReplayedUntil      *time.Time `json:"replayed_until"`
+	DecisionsEvaluated int64      `json:"decisions_evaluated"`
+	DecisionsFailed    int64      `json:"decisions_failed"`
+	Error              *string    `json:"error"`
+	CreatedAt          time.Time  `json:"created_at"`
+	FinishedAt         *time.Time `json:"finished_at"`
+}
+
+func AdaptScenarioBacktest(b models.ScenarioBacktest) ScenarioBacktest {
+	return ScenarioBacktest{
+		Id:                 b.Id,
+		ScenarioId:         b.ScenarioId,
+		TestIterationId:    b.ScenarioIterationId,
+		TestRunId:          b.TestRunId,
+		WindowStart:        b.WindowStart,
+		WindowEnd:          b.WindowEnd,
+		Status:             string(b.Status),
+		ReplayedUntil:      b.CursorCreatedAt,
+		DecisionsEvaluated: b.DecisionsEvaluated,
+		DecisionsFailed:    b.DecisionsFailed,
+		Error:              b.Error,
+		CreatedAt:          b.CreatedAt,
+		FinishedAt:         b.FinishedAt,
+	}
+}
+
+type ScenarioBacktestOutcomeCount struct {
+	LiveOutcome     string `json:"live_outcome"`
+	BacktestOutcome string `json:"backtest_outcome"`
+	Total           int64  `json:"total"`
+}
+
+func AdaptScenarioBacktestOutcomeCount(c models.ScenarioBacktestOutcomeCount) ScenarioBacktestOutcomeCount {
+	return ScenarioBacktestOutcomeCount{
+		LiveOutcome:     c.LiveOutcome,
+		BacktestOutcome: c.BacktestOutcome,
+		Total:           c.Total,
+	}
+}
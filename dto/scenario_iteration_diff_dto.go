@@ -0,0 +1,120 @@
+package dto
+
+import (
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+type AstDiff struct {
+	Before string     `json:"before"`
+	After  string     `json:"after"`
+	Lines  []DiffLine `json:"lines"`
+}
+
+type RuleDiff struct {
+	StableRuleId string        `json:"stable_rule_id"`
+	Name         string        `json:"name"`
+	Status       string        `json:"status"`
+	BeforeRuleId *string       `json:"before_rule_id"`
+	AfterRuleId  *string       `json:"after_rule_id"`
+	Changes      []FieldChange `json:"changes"`
+	Formula      *AstDiff      `json:"formula"`
+}
+
+type ScreeningConfigDiff struct {
+	StableId                 string             `json:"stable_id"`
+	Name                     string             `json:"name"`
+	Status                   string             `json:"status"`
+	BeforeId                 *string            `json:"before_id"`
+	AfterId                  *string            `json:"after_id"`
+	Changes                  []FieldChange      `json:"changes"`
+	TriggerRule              *AstDiff           `json:"trigger_rule"`
+	CounterpartyIdExpression *AstDiff           `json:"counterparty_id_expression"`
+	Query                    map[string]AstDiff `json:"query"`
+}
+
+type ScenarioIterationDiff struct {
+	BaseIterationId   *string               `json:"base_iteration_id"`
+	BaseVersion       *int                  `json:"base_version"`
+	TargetIterationId string                `json:"target_iteration_id"`
+	TargetVersion     *int                  `json:"target_version"`
+	TriggerCondition  *AstDiff              `json:"trigger_condition"`
+	Changes           []FieldChange         `json:"changes"`
+	Rules             []RuleDiff            `json:"rules"`
+	ScreeningConfigs  []ScreeningConfigDiff `json:"screening_configs"`
+}
+
+func adaptFieldChange(c models.FieldChange) FieldChange {
+	return FieldChange{Field: c.Field, Before: c.Before, After: c.After}
+}
+
+func adaptAstDiff(d models.AstDiff) AstDiff {
+	return AstDiff{
+		Before: d.Before,
+		After:  d.After,
+		Lines: pure_utils.Map(d.Lines, func(l models.DiffLine) DiffLine {
+			return DiffLine{Op: string(l.Op), Text: l.Text}
+		}),
+	}
+}
+
+func adaptAstDiffPtr(d *models.AstDiff) *AstDiff {
+	if d == nil {
+		return nil
+	}
+	out := adaptAstDiff(*d)
+	return &out
+}
+
+func AdaptScenarioIterationDiff(d models.ScenarioIterationDiff) ScenarioIterationDiff {
+	out := ScenarioIterationDiff{
+		BaseVersion:       d.BaseVersion,
+		TargetIterationId: d.TargetIterationId,
+		TargetVersion:     d.TargetVersion,
+		TriggerCondition:  adaptAstDiffPtr(d.TriggerCondition),
+		Changes:           pure_utils.Map(d.Changes, adaptFieldChange),
+		Rules: pure_utils.Map(d.Rules, func(r models.RuleDiff) RuleDiff {
+			return RuleDiff{
+				StableRuleId: r.StableRuleId,
+				Name:         r.Name,
+				Status:       string(r.Status),
+				BeforeRuleId: r.BeforeRuleId,
+				AfterRuleId:  r.AfterRuleId,
+				Changes:      pure_utils.Map(r.Changes, adaptFieldChange),
+				Formula:      adaptAstDiffPtr(r.Formula),
+			}
+		}),
+		ScreeningConfigs: pure_utils.Map(d.ScreeningConfigs, func(s models.ScreeningConfigDiff) ScreeningConfigDiff {
+			query := make(map[string]AstDiff, len(s.Query))
+			for field, q := range s.Query {
+				query[field] = adaptAstDiff(q)
+			}
+			return ScreeningConfigDiff{
+				StableId:                 s.StableId,
+				Name:                     s.Name,
+				Status:                   string(s.Status),
+				BeforeId:                 s.BeforeId,
+				AfterId:                  s.AfterId,
+				Changes:                  pure_utils.Map(s.Changes, adaptFieldChange),
+				TriggerRule:              adaptAstDiffPtr(s.TriggerRule),
+				CounterpartyIdExpression: adaptAstDiffPtr(s.CounterpartyIdExpression),
+				Query:                    query,
+			}
+		}),
+	}
+	if d.BaseIterationId != "" {
+		out.BaseIterationId = &d.BaseIterationId
+	}
+	return out
+}
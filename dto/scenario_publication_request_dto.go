@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+)
+
+type CreateScenarioPublicationRequestBody struct {
+	ScenarioIterationId string `json:"scenario_iteration_id" binding:"required"`
+	PublicationAction   string `json:"publication_action" binding:"required"`
+	CanaryPercentage    int    `json:"canary_percentage"`
+	Comment             string `json:"comment"`
+}
+
+func AdaptCreateScenarioPublicationRequestBody(
+	organizationId uuid.UUID,
+	body CreateScenarioPublicationRequestBody,
+) models.CreateScenarioPublicationRequestInput {
+	return models.CreateScenarioPublicationRequestInput{
+		OrganizationId:      organizationId,
+		ScenarioIterationId: body.ScenarioIterationId,
+		PublicationAction:   models.PublicationActionFrom(body.PublicationAction),
+		CanaryPercentage:    body.CanaryPercentage,
+		Comment:             body.Comment,
+	}
+}
+
+type ReviewScenarioPublicationRequestBody struct {
+	Comment *string `json:"comment"`
+}
+
+type ScenarioPublicationRequest struct {
+	Id                  uuid.UUID             `json:"id"`
+	ScenarioId          string                `json:"scenario_id"`
+	ScenarioIterationId string                `json:"scenario_iteration_id"`
+	PublicationAction   string                `json:"publication_action"`
+	CanaryPercentage    int                   `json:"canary_percentage"`
+	Status              string                `json:"status"`
+	Comment             string                `json:"comment"`
+	Diff                ScenarioIterationDiff `json:"diff"`
+	RequestedBy         string                `json:"requested_by"`
+	ReviewedBy          *string               `json:"reviewed_by"`
+	ReviewComment       *string               `json:"review_comment"`
+	Error               *string               `json:"error"`
+	ScheduledAt         *time.Time            `json:"scheduled_at"`
+	CreatedAt           time.Time             `json:"created_at"`
+	UpdatedAt           time.Time             `json:"updated_at"`
+	ReviewedAt          *time.Time            `json:"reviewed_at"`
+}
+
+func AdaptScenarioPublicationRequest(r models.ScenarioPublicationRequest) ScenarioPublicationRequest {
+	out := ScenarioPublicationRequest{
+		Id:                  r.Id,
+		ScenarioId:          r.ScenarioId,
+		ScenarioIterationId: r.ScenarioIterationId,
+		PublicationAction:   r.PublicationAction.String(),
+		CanaryPercentage:    r.CanaryPercentage,
+		Status:              string(r.Status),
+		Comment:             r.Comment,
+		Diff:                AdaptScenarioIterationDiff(r.Diff),
+		RequestedBy:         string(r.RequestedBy),
+		ReviewComment:       r.ReviewComment,
+		Error:               r.Error,
+		ScheduledAt:         r.ScheduledAt,
+		CreatedAt:           r.CreatedAt,
+		UpdatedAt:           r.UpdatedAt,
+		ReviewedAt:          r.ReviewedAt,
+	}
+	if r.ReviewedBy != nil {
+		reviewedBy := string(*r.ReviewedBy)
+		out.ReviewedBy = &reviewedBy
+	}
+	return out
+}
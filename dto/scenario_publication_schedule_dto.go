@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+)
+
+type CreateScenarioPublicationScheduleBody struct {
+	ScenarioIterationId string    `json:"scenario_iteration_id" binding:"required"`
+	PublicationAction   string    `json:"publication_action" binding:"required"`
+	ScheduledAt         time.Time `json:"scheduled_at" binding:"required"`
+	Comment             string    `json:"comment"`
+}
+
+func AdaptCreateScenarioPublicationScheduleBody(
+	organizationId uuid.UUID,
+	body CreateScenarioPublicationScheduleBody,
+) models.CreateScenarioPublicationScheduleInput {
+	return models.CreateScenarioPublicationScheduleInput{
+		OrganizationId:      organizationId,
+		ScenarioIterationId: body.ScenarioIterationId,
+		PublicationAction:   models.PublicationActionFrom(body.PublicationAction),
+		ScheduledAt:         body.ScheduledAt,
+		Comment:             body.Comment,
+	}
+}
+
+type ScenarioPublicationSchedule struct {
+	Id                   uuid.UUID  `json:"id"`
+	ScenarioId           string     `json:"scenario_id"`
+	ScenarioIterationId  string     `json:"scenario_iteration_id"`
+	PublicationAction    string     `json:"publication_action"`
+	ScheduledAt          time.Time  `json:"scheduled_at"`
+	Status               string     `json:"status"`
+	Error                *string    `json:"error"`
+	CreatedBy            *string    `json:"created_by"`
+	PublicationRequestId *uuid.UUID `json:"publication_request_id"`
+	PreparationAlertedAt *time.Time `json:"preparation_alerted_at"`
+	ExecutedAt           *time.Time `json:"executed_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+func AdaptScenarioPublicationSchedule(s models.ScenarioPublicationSchedule) ScenarioPublicationSchedule {
+	out := ScenarioPublicationSchedule{
+		Id:                   s.Id,
+		ScenarioId:           s.ScenarioId,
+		ScenarioIterationId:  s.ScenarioIterationId,
+		PublicationAction:    s.PublicationAction.String(),
+		ScheduledAt:          s.ScheduledAt,
+		Status:               string(s.Status),
+		Error:                s.Error,
+		PublicationRequestId: s.PublicationRequestId,
+		PreparationAlertedAt: s.PreparationAlertedAt,
+		ExecutedAt:           s.ExecutedAt,
+		CreatedAt:            s.CreatedAt,
+		UpdatedAt:            s.UpdatedAt,
+	}
+	if s.CreatedBy != nil {
+		createdBy := string(*s.CreatedBy)
+		out.CreatedBy = &createdBy
+	}
+	return out
+}
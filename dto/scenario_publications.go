@@ -4,14 +4,19 @@ import (
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/google/uuid"
 )
 
 type ScenarioPublication struct {
-	Id                  string    `json:"id"`
-	ScenarioId          string    `json:"scenario_id"`
-	ScenarioIterationId string    `json:"scenario_iteration_id"`
-	PublicationAction   string    `json:"publication_action"`
-	CreatedAt           time.Time `json:"created_at"`
+	Id                  string     `json:"id"`
+	ScenarioId          string     `json:"scenario_id"`
+	ScenarioIterationId string     `json:"scenario_iteration_id"`
+	PublicationAction   string     `json:"publication_action"`
+	CreatedAt           time.Time  `json:"created_at"`
+	Status              string     `json:"status"`
+	ScheduledAt         *time.Time `json:"scheduled_at"`
+	Error               *string    `json:"error"`
 }
 
 func AdaptScenarioPublicationDto(sp models.ScenarioPublication) ScenarioPublication {
@@ -21,18 +26,23 @@ func AdaptScenarioPublicationDto(sp models.ScenarioPublication) ScenarioPublicat
 		ScenarioIterationId: sp.ScenarioIterationId,
 		PublicationAction:   sp.PublicationAction.String(),
 		CreatedAt:           sp.CreatedAt,
+		Status:              string(sp.Status),
+		ScheduledAt:         sp.ScheduledAt,
+		Error:               sp.Error,
 	}
 }
 
 type CreateScenarioPublicationBody struct {
 	ScenarioIterationId string `json:"scenario_iteration_id"`
 	PublicationAction   string `json:"publication_action"`
+	CanaryPercentage    int    `json:"canary_percentage"`
 }
 
 func AdaptCreateScenarioPublicationBody(dto CreateScenarioPublicationBody) models.PublishScenarioIterationInput {
 	out := models.PublishScenarioIterationInput{
 		ScenarioIterationId: dto.ScenarioIterationId,
 		PublicationAction:   models.PublicationActionFrom(dto.PublicationAction),
+		CanaryPercentage:    dto.CanaryPercentage,
 	}
 
 	return out
@@ -49,3 +59,48 @@ func AdaptPublicationPreparationStatus(status models.PublicationPreparationStatu
 		PreparationServiceStatus: string(status.PreparationServiceStatus),
 	}
 }
+
+type RolloutArmStats struct {
+	Arm                 string             `json:"arm"`
+	ScenarioIterationId *uuid.UUID         `json:"scenario_iteration_id"`
+	Decisions           int                `json:"decisions"`
+	Outcomes            map[string]int     `json:"outcomes"`
+	OutcomeRates        map[string]float64 `json:"outcome_rates"`
+}
+
+type ScenarioCanaryStats struct {
+	ScenarioId        string            `json:"scenario_id"`
+	LiveIterationId   *string           `json:"live_iteration_id"`
+	CanaryIterationId *string           `json:"canary_iteration_id"`
+	CanaryPercentage  int               `json:"canary_percentage"`
+	CanaryStartedAt   *time.Time        `json:"canary_started_at"`
+	Arms              []RolloutArmStats `json:"arms"`
+}
+
+func AdaptScenarioCanaryStats(stats models.ScenarioCanaryStats) ScenarioCanaryStats {
+	return ScenarioCanaryStats{
+		ScenarioId:        stats.ScenarioId,
+		LiveIterationId:   stats.LiveIterationId,
+		CanaryIterationId: stats.CanaryIterationId,
+		CanaryPercentage:  stats.CanaryPercentage,
+		CanaryStartedAt:   stats.CanaryStartedAt,
+		Arms: pure_utils.Map(stats.Arms, func(arm models.RolloutArmStats) RolloutArmStats {
+			out := RolloutArmStats{
+				Arm:          string(arm.Arm),
+				Decisions:    arm.Decisions,
+				Outcomes:     make(map[string]int, len(arm.Outcomes)),
+				OutcomeRates: make(map[string]float64, len(arm.OutcomeRates)),
+			}
+			if arm.ScenarioIterationId != uuid.Nil {
+				out.ScenarioIterationId = &arm.ScenarioIterationId
+			}
+			for outcome, count := range arm.Outcomes {
+				out.Outcomes[outcome.String()] = count
+			}
+			for outcome, rate := range arm.OutcomeRates {
+				out.OutcomeRates[outcome.String()] = rate
+			}
+			return out
+		}),
+	}
+}
@@ -16,6 +16,7 @@ type ScenarioTestRunResp struct {
 	EndDate         time.Time `json:"end_date"`
 	CreatorId       string    `json:"creator_id"`
 	Status          string    `json:"status"`
+	Kind            string    `json:"kind"`
 }
 
 func AdaptScenarioTestRunDto(s models.ScenarioTestRun) ScenarioTestRunResp {
@@ -27,6 +28,7 @@ func AdaptScenarioTestRunDto(s models.ScenarioTestRun) ScenarioTestRunResp {
 		RefIterationId:  s.ScenarioLiveIterationId,
 		ScenarioId:      s.ScenarioId,
 		TestIterationId: s.ScenarioIterationId,
+		Kind:            string(s.Kind),
 	}
 }
 
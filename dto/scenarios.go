@@ -9,28 +9,42 @@ import (
 
 // Read DTO
 type ScenarioDto struct {
-	Id                      string    `json:"id"`
-	CreatedAt               time.Time `json:"created_at"`
-	Description             string    `json:"description"`
-	LiveVersionID           *string   `json:"live_version_id,omitempty"`
-	Name                    string    `json:"name"`
-	OrganizationId          uuid.UUID `json:"organization_id"`
-	TriggerObjectType       string    `json:"trigger_object_type"`
-	Archived                bool      `json:"archived"`
-	DeduplicateBatchObjects bool      `json:"deduplicate_batch_objects"`
+	Id                            string     `json:"id"`
+	CreatedAt                     time.Time  `json:"created_at"`
+	Description                   string     `json:"description"`
+	LiveVersionID                 *string    `json:"live_version_id,omitempty"`
+	Name                          string     `json:"name"`
+	OrganizationId                uuid.UUID  `json:"organization_id"`
+	TriggerObjectType             string     `json:"trigger_object_type"`
+	Archived                      bool       `json:"archived"`
+	DeduplicateBatchObjects       bool       `json:"deduplicate_batch_objects"`
+	CanaryIterationId             *string    `json:"canary_iteration_id,omitempty"`
+	CanaryPercentage              int        `json:"canary_percentage"`
+	CanaryStartedAt               *time.Time `json:"canary_started_at,omitempty"`
+	EvaluateOnIngest              bool       `json:"evaluate_on_ingest"`
+	EvaluateOnIngestFields        []string   `json:"evaluate_on_ingest_fields"`
+	IncrementalScheduledExecution bool       `json:"incremental_scheduled_execution"`
+	FullRefreshIntervalDays       int        `json:"full_refresh_interval_days"`
 }
 
 func AdaptScenarioDto(scenario models.Scenario) ScenarioDto {
 	return ScenarioDto{
-		Id:                      scenario.Id,
-		CreatedAt:               scenario.CreatedAt,
-		Description:             scenario.Description,
-		LiveVersionID:           scenario.LiveVersionID,
-		Name:                    scenario.Name,
-		OrganizationId:          scenario.OrganizationId,
-		TriggerObjectType:       scenario.TriggerObjectType,
-		Archived:                scenario.Archived,
-		DeduplicateBatchObjects: scenario.DeduplicateBatchObjects,
+		Id:                            scenario.Id,
+		CreatedAt:                     scenario.CreatedAt,
+		Description:                   scenario.Description,
+		LiveVersionID:                 scenario.LiveVersionID,
+		Name:                          scenario.Name,
+		OrganizationId:                scenario.OrganizationId,
+		TriggerObjectType:             scenario.TriggerObjectType,
+		Archived:                      scenario.Archived,
+		DeduplicateBatchObjects:       scenario.DeduplicateBatchObjects,
+		CanaryIterationId:             scenario.CanaryIterationId,
+		CanaryPercentage:              scenario.CanaryPercentage,
+		CanaryStartedAt:               scenario.CanaryStartedAt,
+		EvaluateOnIngest:              scenario.EvaluateOnIngest,
+		EvaluateOnIngestFields:        scenario.EvaluateOnIngestFields,
+		IncrementalScheduledExecution: scenario.IncrementalScheduledExecution,
+		FullRefreshIntervalDays:       scenario.FullRefreshIntervalDays,
 	}
 }
 
@@ -54,19 +68,27 @@ func AdaptCreateScenarioInput(input CreateScenarioBody, organizationId uuid.UUID
 
 // Update scenario DTO
 type UpdateScenarioBody struct {
-	Description             *string `json:"description"`
-	Name                    *string `json:"name"`
-	Archived                *bool   `json:"archived"`
-	DeduplicateBatchObjects *bool   `json:"deduplicate_batch_objects"`
+	Description                   *string   `json:"description"`
+	Name                          *string   `json:"name"`
+	Archived                      *bool     `json:"archived"`
+	DeduplicateBatchObjects       *bool     `json:"deduplicate_batch_objects"`
+	EvaluateOnIngest              *bool     `json:"evaluate_on_ingest"`
+	EvaluateOnIngestFields        *[]string `json:"evaluate_on_ingest_fields"`
+	IncrementalScheduledExecution *bool     `json:"incremental_scheduled_execution"`
+	FullRefreshIntervalDays       *int      `json:"full_refresh_interval_days"`
 }
 
 func AdaptUpdateScenarioInput(scenarioId string, input UpdateScenarioBody) models.UpdateScenarioInput {
 	parsedInput := models.UpdateScenarioInput{
-		Id:                      scenarioId,
-		Description:             input.Description,
-		Name:                    input.Name,
-		Archived:                input.Archived,
-		DeduplicateBatchObjects: input.DeduplicateBatchObjects,
+		Id:                            scenarioId,
+		Description:                   input.Description,
+		Name:                          input.Name,
+		Archived:                      input.Archived,
+		DeduplicateBatchObjects:       input.DeduplicateBatchObjects,
+		EvaluateOnIngest:              input.EvaluateOnIngest,
+		EvaluateOnIngestFields:        input.EvaluateOnIngestFields,
+		IncrementalScheduledExecution: input.IncrementalScheduledExecution,
+		FullRefreshIntervalDays:       input.FullRefreshIntervalDays,
 	}
 
 	return parsedInput
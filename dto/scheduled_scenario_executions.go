@@ -40,6 +40,10 @@ type ScheduledExecutionDto struct {
 	// setting, which may have changed since. Exposed so a "why did this run create 0
 	// decisions" question can be answered from the execution alone.
 	DeduplicateObjects bool `json:"deduplicate_objects"`
+
+	// IncrementalSince is set when the run only evaluated the objects written after this time
+	// (see models.ScheduledExecution.IncrementalSince), and null for a full run.
+	IncrementalSince *time.Time `json:"incremental_since"`
 }
 
 func AdaptScheduledExecutionDto(ExecutionBatch models.ScheduledExecution) ScheduledExecutionDto {
@@ -58,6 +62,7 @@ func AdaptScheduledExecutionDto(ExecutionBatch models.ScheduledExecution) Schedu
 		Manual:                     ExecutionBatch.Manual,
 		ManifestRowsProcessed:      ExecutionBatch.ManifestRowsProcessed,
 		DeduplicateObjects:         ExecutionBatch.DeduplicateObjects,
+		IncrementalSince:           ExecutionBatch.IncrementalSince,
 	}
 }
 
@@ -151,9 +151,16 @@ func AdaptScreeningMatchDto(m models.ScreeningMatch) ScreeningMatchDto {
 }
 
 type ScreeningMatchUpdateDto struct {
-	Status    string  `json:"status"`
-	Comment   *string `json:"comment,omitempty"`
-	Whitelist bool    `json:"whitelist"`
+	Status           string                        `json:"status"`
+	Comment          *string                       `json:"comment,omitempty"`
+	Whitelist        bool                          `json:"whitelist"`
+	WhitelistOptions *ScreeningWhitelistOptionsDto `json:"whitelist_options,omitempty"`
+}
+
+type ScreeningWhitelistOptionsDto struct {
+	ScopeToScreeningConfig bool       `json:"scope_to_screening_config"`
+	ScopeToObject          bool       `json:"scope_to_object"`
+	ExpiresAt              *time.Time `json:"expires_at,omitempty"`
 }
 
 func AdaptScreeningMatchUpdateInputDto(matchId string, reviewerId models.UserId,
@@ -171,6 +178,14 @@ func AdaptScreeningMatchUpdateInputDto(matchId string, reviewerId models.UserId,
 		Whitelist:  dto.Whitelist,
 	}
 
+	if dto.WhitelistOptions != nil {
+		update.WhitelistOptions = models.ScreeningWhitelistOptions{
+			ScopeToScreeningConfig: dto.WhitelistOptions.ScopeToScreeningConfig,
+			ScopeToObject:          dto.WhitelistOptions.ScopeToObject,
+			ExpiresAt:              dto.WhitelistOptions.ExpiresAt,
+		}
+	}
+
 	if dto.Comment != nil {
 		update.Comment = &models.ScreeningMatchComment{
 			MatchId:     matchId,
@@ -11,13 +11,17 @@ var OpenSanctionsValidFieldsPerClass = map[string][]string{
 	"Person":       {"name", "birthDate", "nationality", "passportNumber", "address"},
 	"Organization": {"name", "country", "registrationNumber", "address"},
 	"Vehicle":      {"name", "registrationNumber"},
+	"Vessel":       {"name", "imoNumber", "mmsi", "registrationNumber"},
+	"CryptoWallet": {"publicKey"},
 }
 
 type RefineQueryDto struct {
-	Thing        *RefineQueryBase         `json:"Thing,omitempty" binding:"required_without_all=Person Organization Vehicle,excluded_with=Person Organization Vehicle"` //nolint:tagliatelle
-	Person       *RefineQueryPerson       `json:"Person,omitempty" binding:"required_without_all=Thing Organization Vehicle,excluded_with=Thing Organization Vehicle"`  //nolint:tagliatelle
-	Organization *RefineQueryOrganization `json:"Organization,omitempty" binding:"required_without_all=Thing Person Vehicle,excluded_with=Person Thing Vehicle"`        //nolint:tagliatelle
-	Vehicle      *RefineQueryVehicle      `json:"Vehicle,omitempty" binding:"required_without_all=Thing Person Organization,excluded_with=Thing Person Organization"`   //nolint:tagliatelle
+	Thing        *RefineQueryBase         `json:"Thing,omitempty" binding:"required_without_all=Person Organization Vehicle Vessel CryptoWallet,excluded_with=Person Organization Vehicle Vessel CryptoWallet"` //nolint:tagliatelle
+	Person       *RefineQueryPerson       `json:"Person,omitempty" binding:"required_without_all=Thing Organization Vehicle Vessel CryptoWallet,excluded_with=Thing Organization Vehicle Vessel CryptoWallet"`  //nolint:tagliatelle
+	Organization *RefineQueryOrganization `json:"Organization,omitempty" binding:"required_without_all=Thing Person Vehicle Vessel CryptoWallet,excluded_with=Person Thing Vehicle Vessel CryptoWallet"`        //nolint:tagliatelle
+	Vehicle      *RefineQueryVehicle      `json:"Vehicle,omitempty" binding:"required_without_all=Thing Person Organization Vessel CryptoWallet,excluded_with=Thing Person Organization Vessel CryptoWallet"`   //nolint:tagliatelle
+	Vessel       *RefineQueryVessel       `json:"Vessel,omitempty" binding:"required_without_all=Thing Person Organization Vehicle CryptoWallet,excluded_with=Thing Person Organization Vehicle CryptoWallet"`  //nolint:tagliatelle
+	CryptoWallet *RefineQueryCryptoWallet `json:"CryptoWallet,omitempty" binding:"required_without_all=Thing Person Organization Vehicle Vessel,excluded_with=Thing Person Organization Vehicle Vessel"`        //nolint:tagliatelle
 }
 
 func (dto RefineQueryDto) Type() string {
@@ -28,6 +32,10 @@ func (dto RefineQueryDto) Type() string {
 		return "Organization"
 	case dto.Vehicle != nil:
 		return "Vehicle"
+	case dto.Vessel != nil:
+		return "Vessel"
+	case dto.CryptoWallet != nil:
+		return "CryptoWallet"
 	}
 
 	return "Thing"
@@ -62,6 +70,20 @@ type RefineQueryVehicle struct {
 	RegistrationNumber string `json:"registrationNumber"` //nolint:tagliatelle
 }
 
+type RefineQueryVessel struct {
+	RefineQueryBase
+
+	ImoNumber          string `json:"imoNumber"` //nolint:tagliatelle
+	Mmsi               string `json:"mmsi"`
+	RegistrationNumber string `json:"registrationNumber"` //nolint:tagliatelle
+}
+
+type RefineQueryCryptoWallet struct {
+	RefineQueryBase
+
+	PublicKey string `json:"publicKey"` //nolint:tagliatelle
+}
+
 func AdaptRefineQueryDto(dto RefineQueryDto) models.OpenSanctionsFilter {
 	filter := models.OpenSanctionsFilter{}
 
@@ -88,6 +110,13 @@ func AdaptRefineQueryDto(dto RefineQueryDto) models.OpenSanctionsFilter {
 	case dto.Vehicle != nil:
 		assign("name", dto.Vehicle.Name)
 		assign("registrationNumber", dto.Vehicle.RegistrationNumber)
+	case dto.Vessel != nil:
+		assign("name", dto.Vessel.Name)
+		assign("imoNumber", dto.Vessel.ImoNumber)
+		assign("mmsi", dto.Vessel.Mmsi)
+		assign("registrationNumber", dto.Vessel.RegistrationNumber)
+	case dto.CryptoWallet != nil:
+		assign("publicKey", dto.CryptoWallet.PublicKey)
 	}
 
 	return filter
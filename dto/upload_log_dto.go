@@ -9,6 +9,7 @@ import (
 
 type UploadLogDto struct {
 	Status          string     `json:"status"`
+	FileFormat      string     `json:"file_format"`
 	StartedAt       time.Time  `json:"started_at"`
 	FinishedAt      *time.Time `json:"finished_at"`
 	LinesProcessed  int        `json:"lines_processed"`
@@ -19,6 +20,7 @@ type UploadLogDto struct {
 func AdaptUploadLogDto(log models.UploadLog) UploadLogDto {
 	return UploadLogDto{
 		Status:          string(log.UploadStatus),
+		FileFormat:      string(log.FileFormat),
 		StartedAt:       log.StartedAt,
 		FinishedAt:      log.FinishedAt,
 		LinesProcessed:  max(log.LinesProcessed, log.RowsIngested),
@@ -58,8 +58,8 @@ func (r *MockBlobRepository) GenerateSignedUrl(ctx context.Context, bucketUrl, k
 	return args.String(0), args.Error(1)
 }
 
-func (r *MockBlobRepository) GenerateWriteSignedUrl(ctx context.Context, bucketUrl, key string, expiry time.Duration, hostOverride string) (string, error) {
-	args := r.Called(ctx, bucketUrl, key, expiry, hostOverride)
+func (r *MockBlobRepository) GenerateWriteSignedUrl(ctx context.Context, bucketUrl, key, contentType string, expiry time.Duration, hostOverride string) (string, error) {
+	args := r.Called(ctx, bucketUrl, key, contentType, expiry, hostOverride)
 	return args.String(0), args.Error(1)
 }
 
@@ -337,12 +337,9 @@ func (m *ContinuousScreeningRepository) BatchCreateCaseEvents(
 func (m *ContinuousScreeningRepository) AddScreeningMatchWhitelist(
 	ctx context.Context,
 	exec repositories.Executor,
-	orgId uuid.UUID,
-	counterpartyId string,
-	entityId string,
-	reviewerId *models.UserId,
+	input models.ScreeningWhitelistCreateInput,
 ) error {
-	args := m.Called(ctx, exec, orgId, counterpartyId, entityId, reviewerId)
+	args := m.Called(ctx, exec, input)
 	return args.Error(0)
 }
 
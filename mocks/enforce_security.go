@@ -215,6 +215,11 @@ func (e *EnforceSecurity) CanIngest(orgId uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (e *EnforceSecurity) ReadIngestionDeadLetter(deadLetter models.IngestionDeadLetter) error {
+	args := e.Called(deadLetter)
+	return args.Error(0)
+}
+
 func (e *EnforceSecurity) ReadContinuousScreeningConfig(config models.ContinuousScreeningConfig) error {
 	args := e.Called(config)
 	return args.Error(0)
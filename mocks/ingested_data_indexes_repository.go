@@ -138,3 +138,11 @@ func (m *IngestedDataIndexesRepository) DeleteIndex(
 	args := m.Called(ctx, exec)
 	return args.Error(0)
 }
+
+func (m *IngestedDataIndexesRepository) CreateMissingHistoryIndexesAsync(
+	ctx context.Context,
+	exec repositories.Executor,
+) ([]string, error) {
+	args := m.Called(ctx, exec)
+	return args.Get(0).([]string), args.Error(1)
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -67,12 +68,19 @@ func (m *IngestedDataReader) QueryIngestedObjectByUniqueField(ctx context.Contex
 
 func (m *IngestedDataReader) QueryAggregatedValue(ctx context.Context, exec repositories.Executor,
 	tableName string, fieldName string, fieldType models.DataType, aggregator ast.Aggregator,
-	filters []models.FilterWithType, options map[string]any,
+	filters []models.FilterWithType, options map[string]any, asOf *time.Time,
 ) (any, error) {
-	args := m.Called(ctx, exec, tableName, fieldName, fieldType, aggregator, filters, options)
+	args := m.Called(ctx, exec, tableName, fieldName, fieldType, aggregator, filters, options, asOf)
 	return args.Get(0), args.Error(1)
 }
 
+func (m *IngestedDataReader) QueryIngestedObjectVersions(ctx context.Context,
+	exec repositories.Executor, table models.Table, objectId string,
+) ([]models.DataModelObject, error) {
+	args := m.Called(ctx, exec, table, objectId)
+	return args.Get(0).([]models.DataModelObject), args.Error(1)
+}
+
 func (m *IngestedDataReader) ListIngestedObjects(ctx context.Context, exec repositories.Executor,
 	table models.Table, params models.ExplorationOptions, cursorId *string, limit int, fieldsToRead ...string,
 ) ([]models.DataModelObject, error) {
@@ -95,8 +103,9 @@ func (m *IngestedDataReader) SearchObjects(
 	terms string,
 	pageSize uint64,
 	offset uint64,
+	asOf *time.Time,
 ) ([]models.DataModelObject, error) {
-	args := m.Called(ctx, exec, table, field, terms, pageSize, offset)
+	args := m.Called(ctx, exec, table, field, terms, pageSize, offset, asOf)
 
 	return args.Get(0).([]models.DataModelObject), args.Error(1)
 }
@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type IngestionDeadLetterRepository struct {
+	mock.Mock
+}
+
+func (r *IngestionDeadLetterRepository) CreateIngestionDeadLetters(ctx context.Context,
+	exec repositories.Executor, deadLetters []models.IngestionDeadLetter,
+) error {
+	args := r.Called(ctx, exec, deadLetters)
+	return args.Error(0)
+}
+
+func (r *IngestionDeadLetterRepository) GetIngestionDeadLetter(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID,
+) (models.IngestionDeadLetter, error) {
+	args := r.Called(ctx, exec, id)
+	return args.Get(0).(models.IngestionDeadLetter), args.Error(1)
+}
+
+func (r *IngestionDeadLetterRepository) ListIngestionDeadLetters(ctx context.Context,
+	exec repositories.Executor, organizationId uuid.UUID, filters models.IngestionDeadLetterFilters,
+	pagination models.PaginationAndSorting,
+) ([]models.IngestionDeadLetter, error) {
+	args := r.Called(ctx, exec, organizationId, filters, pagination)
+	return args.Get(0).([]models.IngestionDeadLetter), args.Error(1)
+}
+
+func (r *IngestionDeadLetterRepository) ListIngestionDeadLettersToReplay(ctx context.Context,
+	exec repositories.Executor, organizationId uuid.UUID, filters models.IngestionDeadLetterFilters, limit int,
+) ([]models.IngestionDeadLetter, error) {
+	args := r.Called(ctx, exec, organizationId, filters, limit)
+	return args.Get(0).([]models.IngestionDeadLetter), args.Error(1)
+}
+
+func (r *IngestionDeadLetterRepository) UpdateIngestionDeadLetterPayload(ctx context.Context,
+	exec repositories.Executor, deadLetter models.IngestionDeadLetter,
+) error {
+	args := r.Called(ctx, exec, deadLetter)
+	return args.Error(0)
+}
+
+func (r *IngestionDeadLetterRepository) RecordIngestionDeadLetterReplay(ctx context.Context,
+	exec repositories.Executor, deadLetter models.IngestionDeadLetter,
+) error {
+	args := r.Called(ctx, exec, deadLetter)
+	return args.Error(0)
+}
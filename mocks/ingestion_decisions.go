@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type IngestionScenarioRepository struct {
+	mock.Mock
+}
+
+func (r *IngestionScenarioRepository) ListScenariosEvaluatingOnIngest(ctx context.Context,
+	exec repositories.Executor, organizationId uuid.UUID, objectType string,
+) ([]models.Scenario, error) {
+	args := r.Called(ctx, exec, organizationId, objectType)
+	return args.Get(0).([]models.Scenario), args.Error(1)
+}
+
+type IngestionAsyncDecisionCreator struct {
+	mock.Mock
+}
+
+func (c *IngestionAsyncDecisionCreator) CreateIngestionAsyncDecisionExecutions(ctx context.Context,
+	orgId uuid.UUID, inputs []models.AsyncDecisionExecutionCreate,
+) error {
+	args := c.Called(ctx, orgId, inputs)
+	return args.Error(0)
+}
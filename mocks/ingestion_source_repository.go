@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type IngestionSourceRepository struct {
+	mock.Mock
+}
+
+func (r *IngestionSourceRepository) CreateIngestionSource(ctx context.Context,
+	exec repositories.Executor, source models.IngestionSource,
+) (models.IngestionSource, error) {
+	args := r.Called(ctx, exec, source)
+	return args.Get(0).(models.IngestionSource), args.Error(1)
+}
+
+func (r *IngestionSourceRepository) GetIngestionSource(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID,
+) (models.IngestionSource, error) {
+	args := r.Called(ctx, exec, id)
+	return args.Get(0).(models.IngestionSource), args.Error(1)
+}
+
+func (r *IngestionSourceRepository) ListIngestionSources(ctx context.Context,
+	exec repositories.Executor, orgId uuid.UUID,
+) ([]models.IngestionSource, error) {
+	args := r.Called(ctx, exec, orgId)
+	return args.Get(0).([]models.IngestionSource), args.Error(1)
+}
+
+func (r *IngestionSourceRepository) UpdateIngestionSource(ctx context.Context,
+	exec repositories.Executor, input models.UpdateIngestionSourceInput,
+) (models.IngestionSource, error) {
+	args := r.Called(ctx, exec, input)
+	return args.Get(0).(models.IngestionSource), args.Error(1)
+}
+
+func (r *IngestionSourceRepository) DeleteIngestionSource(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID,
+) error {
+	args := r.Called(ctx, exec, id)
+	return args.Error(0)
+}
+
+func (r *IngestionSourceRepository) RecordIngestionSourcePoll(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID, pollError *string,
+) error {
+	args := r.Called(ctx, exec, id, pollError)
+	return args.Error(0)
+}
+
+func (r *IngestionSourceRepository) ListIngestionSourceFileVersions(ctx context.Context,
+	exec repositories.Executor, sourceId uuid.UUID,
+) (map[string][]string, error) {
+	args := r.Called(ctx, exec, sourceId)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+
+func (r *IngestionSourceRepository) CreateIngestionSourceFile(ctx context.Context,
+	exec repositories.Executor, file models.IngestionSourceFile,
+) (bool, error) {
+	args := r.Called(ctx, exec, file)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *IngestionSourceRepository) ListIngestionSourceFiles(ctx context.Context,
+	exec repositories.Executor, sourceId uuid.UUID, limit int,
+) ([]models.IngestionSourceFile, error) {
+	args := r.Called(ctx, exec, sourceId, limit)
+	return args.Get(0).([]models.IngestionSourceFile), args.Error(1)
+}
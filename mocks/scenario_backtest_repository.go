@@ -0,0 +1,136 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type ScenarioBacktestRepository struct {
+	mock.Mock
+}
+
+func (r *ScenarioBacktestRepository) GetScenarioById(ctx context.Context, exec repositories.Executor,
+	scenarioId string, screeningProvider models.ScreeningProvider,
+) (models.Scenario, error) {
+	args := r.Called(ctx, exec, scenarioId, screeningProvider)
+	return args.Get(0).(models.Scenario), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) GetScenarioIteration(ctx context.Context, exec repositories.Executor,
+	scenarioIterationId string, useCache bool,
+) (models.ScenarioIteration, error) {
+	args := r.Called(ctx, exec, scenarioIterationId, useCache)
+	return args.Get(0).(models.ScenarioIteration), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) ListScreeningConfigs(ctx context.Context, exec repositories.Executor,
+	scenarioIterationId string, useCache bool,
+) ([]models.ScreeningConfig, error) {
+	args := r.Called(ctx, exec, scenarioIterationId, useCache)
+	return args.Get(0).([]models.ScreeningConfig), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) CreateTestRun(ctx context.Context, tx repositories.Transaction,
+	testrunId string, input models.ScenarioTestRunCreateDbInput,
+) error {
+	args := r.Called(ctx, tx, testrunId, input)
+	return args.Error(0)
+}
+
+func (r *ScenarioBacktestRepository) GetTestRunByID(ctx context.Context, exec repositories.Executor,
+	testrunID string,
+) (models.ScenarioTestRun, error) {
+	args := r.Called(ctx, exec, testrunID)
+	return args.Get(0).(models.ScenarioTestRun), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) UpdateTestRunStatus(ctx context.Context, exec repositories.Executor,
+	testRunId string, status models.TestrunStatus,
+) error {
+	args := r.Called(ctx, exec, testRunId, status)
+	return args.Error(0)
+}
+
+func (r *ScenarioBacktestRepository) StorePhantomDecision(ctx context.Context, exec repositories.Executor,
+	decision models.PhantomDecision, organizationId uuid.UUID, testRunId string,
+	newPhantomDecisionId string, scenarioVersion int,
+) error {
+	args := r.Called(ctx, exec, decision, organizationId, testRunId, newPhantomDecisionId, scenarioVersion)
+	return args.Error(0)
+}
+
+func (r *ScenarioBacktestRepository) SaveTestRunDecisionSummary(ctx context.Context, exec repositories.Executor,
+	testRunId string, stat models.DecisionsByVersionByOutcome, newWatermark time.Time,
+) error {
+	args := r.Called(ctx, exec, testRunId, stat, newWatermark)
+	return args.Error(0)
+}
+
+func (r *ScenarioBacktestRepository) SaveTestRunSummary(ctx context.Context, exec repositories.Executor,
+	testRunId string, stat models.RuleExecutionStat, newWatermark time.Time,
+) error {
+	args := r.Called(ctx, exec, testRunId, stat, newWatermark)
+	return args.Error(0)
+}
+
+func (r *ScenarioBacktestRepository) CreateScenarioBacktest(ctx context.Context, exec repositories.Executor,
+	backtest models.ScenarioBacktest,
+) (models.ScenarioBacktest, error) {
+	args := r.Called(ctx, exec, backtest)
+	return args.Get(0).(models.ScenarioBacktest), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) GetScenarioBacktest(ctx context.Context, exec repositories.Executor,
+	id uuid.UUID,
+) (models.ScenarioBacktest, error) {
+	args := r.Called(ctx, exec, id)
+	return args.Get(0).(models.ScenarioBacktest), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) ListScenarioBacktests(ctx context.Context, exec repositories.Executor,
+	orgId uuid.UUID, scenarioId *uuid.UUID,
+) ([]models.ScenarioBacktest, error) {
+	args := r.Called(ctx, exec, orgId, scenarioId)
+	return args.Get(0).([]models.ScenarioBacktest), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) UpdateScenarioBacktestProgress(ctx context.Context, exec repositories.Executor,
+	id uuid.UUID, cursorCreatedAt time.Time, cursorDecisionId uuid.UUID, evaluated, failed int64,
+) (bool, error) {
+	args := r.Called(ctx, exec, id, cursorCreatedAt, cursorDecisionId, evaluated, failed)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) CompleteScenarioBacktest(ctx context.Context, exec repositories.Executor,
+	id uuid.UUID, status models.ScenarioBacktestStatus, backtestError *string,
+) (bool, error) {
+	args := r.Called(ctx, exec, id, status, backtestError)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) SaveScenarioBacktestOutcomes(ctx context.Context, exec repositories.Executor,
+	backtestId uuid.UUID, counts []models.ScenarioBacktestOutcomeCount,
+) error {
+	args := r.Called(ctx, exec, backtestId, counts)
+	return args.Error(0)
+}
+
+func (r *ScenarioBacktestRepository) ListScenarioBacktestOutcomes(ctx context.Context, exec repositories.Executor,
+	backtestId uuid.UUID,
+) ([]models.ScenarioBacktestOutcomeCount, error) {
+	args := r.Called(ctx, exec, backtestId)
+	return args.Get(0).([]models.ScenarioBacktestOutcomeCount), args.Error(1)
+}
+
+func (r *ScenarioBacktestRepository) ListScenarioBacktestDecisions(ctx context.Context, exec repositories.Executor,
+	backtest models.ScenarioBacktest, limit int,
+) ([]models.DecisionWithRuleExecutions, error) {
+	args := r.Called(ctx, exec, backtest, limit)
+	return args.Get(0).([]models.DecisionWithRuleExecutions), args.Error(1)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type ScenarioPublicationRequestRepository struct {
+	mock.Mock
+}
+
+func (r *ScenarioPublicationRequestRepository) CreateScenarioPublicationRequest(ctx context.Context,
+	exec repositories.Executor, request models.ScenarioPublicationRequest,
+) (models.ScenarioPublicationRequest, error) {
+	args := r.Called(ctx, exec, request)
+	return args.Get(0).(models.ScenarioPublicationRequest), args.Error(1)
+}
+
+func (r *ScenarioPublicationRequestRepository) GetScenarioPublicationRequest(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID, forUpdate bool,
+) (models.ScenarioPublicationRequest, error) {
+	args := r.Called(ctx, exec, id, forUpdate)
+	return args.Get(0).(models.ScenarioPublicationRequest), args.Error(1)
+}
+
+func (r *ScenarioPublicationRequestRepository) ListScenarioPublicationRequests(ctx context.Context,
+	exec repositories.Executor, orgId uuid.UUID, filters models.ListScenarioPublicationRequestsFilters,
+) ([]models.ScenarioPublicationRequest, error) {
+	args := r.Called(ctx, exec, orgId, filters)
+	return args.Get(0).([]models.ScenarioPublicationRequest), args.Error(1)
+}
+
+func (r *ScenarioPublicationRequestRepository) UpdateScenarioPublicationRequest(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID, input models.UpdateScenarioPublicationRequestInput,
+) (models.ScenarioPublicationRequest, error) {
+	args := r.Called(ctx, exec, id, input)
+	return args.Get(0).(models.ScenarioPublicationRequest), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type ScenarioPublicationScheduleRepository struct {
+	mock.Mock
+}
+
+func (r *ScenarioPublicationScheduleRepository) CreateScenarioPublicationSchedule(ctx context.Context,
+	exec repositories.Executor, schedule models.ScenarioPublicationSchedule,
+) (models.ScenarioPublicationSchedule, error) {
+	args := r.Called(ctx, exec, schedule)
+	return args.Get(0).(models.ScenarioPublicationSchedule), args.Error(1)
+}
+
+func (r *ScenarioPublicationScheduleRepository) GetScenarioPublicationSchedule(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID, forUpdate bool,
+) (models.ScenarioPublicationSchedule, error) {
+	args := r.Called(ctx, exec, id, forUpdate)
+	return args.Get(0).(models.ScenarioPublicationSchedule), args.Error(1)
+}
+
+func (r *ScenarioPublicationScheduleRepository) ListScenarioPublicationSchedules(ctx context.Context,
+	exec repositories.Executor, orgId uuid.UUID, filters models.ListScenarioPublicationsFilters,
+	statuses []models.ScenarioPublicationScheduleStatus,
+) ([]models.ScenarioPublicationSchedule, error) {
+	args := r.Called(ctx, exec, orgId, filters, statuses)
+	return args.Get(0).([]models.ScenarioPublicationSchedule), args.Error(1)
+}
+
+func (r *ScenarioPublicationScheduleRepository) UpdateScenarioPublicationSchedule(ctx context.Context,
+	exec repositories.Executor, id uuid.UUID, input models.UpdateScenarioPublicationScheduleInput,
+) (models.ScenarioPublicationSchedule, error) {
+	args := r.Called(ctx, exec, id, input)
+	return args.Get(0).(models.ScenarioPublicationSchedule), args.Error(1)
+}
@@ -24,6 +24,16 @@ func (m *ScenarioPublisher) PublishOrUnpublishIteration(
 	return args.Get(0).([]models.ScenarioPublication), args.Error(1)
 }
 
+func (m *ScenarioPublisher) StartCanary(
+	ctx context.Context,
+	tx repositories.Transaction,
+	scenarioAndIteration models.ScenarioAndIteration,
+	percentage int,
+) ([]models.ScenarioPublication, error) {
+	args := m.Called(ctx, tx, scenarioAndIteration, percentage)
+	return args.Get(0).([]models.ScenarioPublication), args.Error(1)
+}
+
 func (m *ScenarioPublisher) SaveScenarioPreparationAction(ctx context.Context,
 	exec repositories.Executor, orgId uuid.UUID, scenarioId, iterationId string,
 ) error {
@@ -19,6 +19,13 @@ func (s *ScenarioPublisherRepository) ListAllRulesAndScreenings(ctx context.Cont
 	return args.Get(0).([]models.RulesAndScreenings), args.Error(1)
 }
 
+func (s *ScenarioPublisherRepository) UpdateScenarioCanary(ctx context.Context,
+	exec repositories.Executor, scenarioId string, input models.UpdateScenarioCanaryInput,
+) error {
+	args := s.Called(ctx, exec, scenarioId, input)
+	return args.Error(0)
+}
+
 func (s *ScenarioPublisherRepository) UpdateScenarioLiveIterationId(ctx context.Context,
 	exec repositories.Executor, scenarioId string, scenarioIterationId *string,
 ) error {
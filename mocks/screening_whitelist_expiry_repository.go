@@ -0,0 +1,30 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type ScreeningWhitelistExpiryRepository struct {
+	mock.Mock
+}
+
+func (r *ScreeningWhitelistExpiryRepository) ListScreeningWhitelistsToNotifyOfExpiry(ctx context.Context,
+	exec repositories.Executor, orgId uuid.UUID, expiresBefore time.Time, limit int,
+) ([]models.ScreeningWhitelist, error) {
+	args := r.Called(ctx, exec, orgId, expiresBefore, limit)
+	return args.Get(0).([]models.ScreeningWhitelist), args.Error(1)
+}
+
+func (r *ScreeningWhitelistExpiryRepository) MarkScreeningWhitelistExpiryNotified(ctx context.Context,
+	exec repositories.Executor, id string,
+) error {
+	args := r.Called(ctx, exec, id)
+	return args.Error(0)
+}
@@ -154,6 +154,14 @@ func (m *TaskQueueRepository) EnqueueContinuousScreeningApplyDeltaFileTask(
 	return m.Called(ctx, tx, orgId, updateJobId).Error(0)
 }
 
+func (m *TaskQueueRepository) EnqueueContinuousScreeningCoverageAnalysisTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	orgId uuid.UUID,
+) error {
+	return m.Called(ctx, tx, orgId).Error(0)
+}
+
 func (m *TaskQueueRepository) EnqueueCsvIngestionTask(
 	ctx context.Context,
 	tx repositories.Transaction,
@@ -165,6 +173,56 @@ func (m *TaskQueueRepository) EnqueueCsvIngestionTask(
 	return args.Error(0)
 }
 
+func (m *TaskQueueRepository) EnqueueFieldTypeMigrationTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId uuid.UUID,
+	migrationId uuid.UUID,
+) error {
+	args := m.Called(ctx, tx, organizationId, migrationId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueDerivedFieldBackfillTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId uuid.UUID,
+	backfillId uuid.UUID,
+) error {
+	args := m.Called(ctx, tx, organizationId, backfillId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueDataSubjectErasureTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId uuid.UUID,
+	erasureId uuid.UUID,
+) error {
+	args := m.Called(ctx, tx, organizationId, erasureId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueScenarioBacktestTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId uuid.UUID,
+	backtestId uuid.UUID,
+) error {
+	args := m.Called(ctx, tx, organizationId, backtestId)
+	return args.Error(0)
+}
+
+func (m *TaskQueueRepository) EnqueueScenarioPublicationRequestTask(
+	ctx context.Context,
+	tx repositories.Transaction,
+	organizationId uuid.UUID,
+	requestId uuid.UUID,
+) error {
+	args := m.Called(ctx, tx, organizationId, requestId)
+	return args.Error(0)
+}
+
 func (m *TaskQueueRepository) EnqueueScheduledExecutionTask(
 	ctx context.Context,
 	tx repositories.Transaction,
@@ -289,8 +347,9 @@ func (m *TaskQueueRepository) EnqueueAsyncUploadTask(
 	organizationId uuid.UUID,
 	objectType string,
 	key string,
+	fileFormat models.IngestionFileFormat,
 	ingestionOptions models.IngestionOptions,
 ) error {
-	args := m.Called(ctx, tx, organizationId, objectType, key, ingestionOptions)
+	args := m.Called(ctx, tx, organizationId, objectType, key, fileFormat, ingestionOptions)
 	return args.Error(0)
 }
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+type WatermarkRepository struct {
+	mock.Mock
+}
+
+func (r *WatermarkRepository) GetWatermark(
+	ctx context.Context,
+	exec repositories.Executor,
+	orgId *uuid.UUID,
+	watermarkType models.WatermarkType,
+) (*models.Watermark, error) {
+	args := r.Called(ctx, exec, orgId, watermarkType)
+	return args.Get(0).(*models.Watermark), args.Error(1)
+}
+
+func (r *WatermarkRepository) SaveWatermark(
+	ctx context.Context,
+	exec repositories.Executor,
+	orgId *uuid.UUID,
+	watermarkType models.WatermarkType,
+	watermarkId *string,
+	watermarkTime time.Time,
+	params json.RawMessage,
+) error {
+	args := r.Called(ctx, exec, orgId, watermarkType, watermarkId, watermarkTime, params)
+	return args.Error(0)
+}
@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"github.com/google/uuid"
+)
+
+// RuleCaseOutcomes counts the hits of a rule whose decision reached a case, and those whose case is
+// closed by outcome of the case. Fields are scanned by position.
+type RuleCaseOutcomes struct {
+	RuleId              uuid.UUID
+	Cases               int
+	ConfirmedRisks      int
+	ValuableAlerts      int
+	FalsePositives      int
+	MedianHandlingHours *float64
+}
+
+type RulePairCaseOutcomes struct {
+	RuleX               uuid.UUID
+	RuleY               uuid.UUID
+	Cases               int
+	ConfirmedRisks      int
+	ValuableAlerts      int
+	FalsePositives      int
+	MedianHandlingHours *float64
+}
+
+type RuleHitCount struct {
+	RuleId   uuid.UUID
+	RuleName string
+	Hits     int
+}
+
+type RulePairHitCount struct {
+	RuleX     uuid.UUID
+	RuleXName string
+	RuleY     uuid.UUID
+	RuleYName string
+	Hits      int
+}
+
+// RulePrecisionMetrics measures how useful the hits of a rule are, using the outcomes of the cases
+// their decisions ended in as labels.
+type RulePrecisionMetrics struct {
+	Hits int `json:"hits"`
+	// Hits whose decision reached a case, closed or not
+	Cases          int `json:"cases"`
+	ConfirmedRisks int `json:"confirmed_risks"`
+	ValuableAlerts int `json:"valuable_alerts"`
+	FalsePositives int `json:"false_positives"`
+	// Share of the labelled hits that were confirmed risks or valuable alerts, null when no hit is
+	// labelled yet
+	Precision      *float64 `json:"precision"`
+	CaseConversion float64  `json:"case_conversion"`
+	// Median time between the creation of the case and its closure, over the closed cases
+	MedianHandlingHours *float64 `json:"median_handling_hours"`
+}
+
+type RulePrecision struct {
+	RuleId   uuid.UUID `json:"rule_id"`
+	RuleName string    `json:"rule_name"`
+	RulePrecisionMetrics
+}
+
+type RulePairPrecision struct {
+	RuleX     uuid.UUID `json:"rule_x"`
+	RuleXName string    `json:"rule_x_name"`
+	RuleY     uuid.UUID `json:"rule_y"`
+	RuleYName string    `json:"rule_y_name"`
+	RulePrecisionMetrics
+}
+
+func newRulePrecisionMetrics(hits, cases, confirmedRisks, valuableAlerts, falsePositives int,
+	medianHandlingHours *float64,
+) RulePrecisionMetrics {
+	metrics := RulePrecisionMetrics{
+		Hits:                hits,
+		Cases:               cases,
+		ConfirmedRisks:      confirmedRisks,
+		ValuableAlerts:      valuableAlerts,
+		FalsePositives:      falsePositives,
+		MedianHandlingHours: medianHandlingHours,
+	}
+	if labelled := confirmedRisks + valuableAlerts + falsePositives; labelled > 0 {
+		precision := float64(confirmedRisks+valuableAlerts) / float64(labelled) * 100
+		metrics.Precision = &precision
+	}
+	if hits > 0 {
+		metrics.CaseConversion = float64(cases) / float64(hits) * 100
+	}
+	return metrics
+}
+
+// NewRulePrecisions labels the hits of each rule with the outcomes of their cases. Rules whose hits
+// did not end in any case are kept, with no precision.
+func NewRulePrecisions(hits []RuleHitCount, outcomes []RuleCaseOutcomes) []RulePrecision {
+	byRule := make(map[uuid.UUID]RuleCaseOutcomes, len(outcomes))
+	for _, o := range outcomes {
+		byRule[o.RuleId] = o
+	}
+
+	precisions := make([]RulePrecision, len(hits))
+	for i, h := range hits {
+		o := byRule[h.RuleId]
+		precisions[i] = RulePrecision{
+			RuleId:   h.RuleId,
+			RuleName: h.RuleName,
+			RulePrecisionMetrics: newRulePrecisionMetrics(h.Hits, o.Cases, o.ConfirmedRisks,
+				o.ValuableAlerts, o.FalsePositives, o.MedianHandlingHours),
+		}
+	}
+	return precisions
+}
+
+func NewRulePairPrecisions(hits []RulePairHitCount, outcomes []RulePairCaseOutcomes) []RulePairPrecision {
+	byPair := make(map[[2]uuid.UUID]RulePairCaseOutcomes, len(outcomes))
+	for _, o := range outcomes {
+		byPair[[2]uuid.UUID{o.RuleX, o.RuleY}] = o
+	}
+
+	precisions := make([]RulePairPrecision, len(hits))
+	for i, h := range hits {
+		o := byPair[[2]uuid.UUID{h.RuleX, h.RuleY}]
+		precisions[i] = RulePairPrecision{
+			RuleX:     h.RuleX,
+			RuleXName: h.RuleXName,
+			RuleY:     h.RuleY,
+			RuleYName: h.RuleYName,
+			RulePrecisionMetrics: newRulePrecisionMetrics(h.Hits, o.Cases, o.ConfirmedRisks,
+				o.ValuableAlerts, o.FalsePositives, o.MedianHandlingHours),
+		}
+	}
+	return precisions
+}
@@ -0,0 +1,56 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRulePrecisions(t *testing.T) {
+	labelled, unlabelled := uuid.New(), uuid.New()
+	median := 12.5
+
+	precisions := NewRulePrecisions(
+		[]RuleHitCount{
+			{RuleId: labelled, RuleName: "high amount", Hits: 10},
+			{RuleId: unlabelled, RuleName: "new beneficiary", Hits: 4},
+		},
+		[]RuleCaseOutcomes{
+			{RuleId: labelled, Cases: 5, ConfirmedRisks: 1, ValuableAlerts: 2, FalsePositives: 1, MedianHandlingHours: &median},
+		},
+	)
+
+	require.Len(t, precisions, 2)
+	assert.Equal(t, "high amount", precisions[0].RuleName)
+	assert.Equal(t, 10, precisions[0].Hits)
+	assert.Equal(t, 5, precisions[0].Cases)
+	require.NotNil(t, precisions[0].Precision)
+	assert.InDelta(t, 75, *precisions[0].Precision, 0.001)
+	assert.InDelta(t, 50, precisions[0].CaseConversion, 0.001)
+	assert.Equal(t, &median, precisions[0].MedianHandlingHours)
+
+	assert.Equal(t, 4, precisions[1].Hits)
+	assert.Zero(t, precisions[1].Cases)
+	assert.Nil(t, precisions[1].Precision)
+	assert.Zero(t, precisions[1].CaseConversion)
+	assert.Nil(t, precisions[1].MedianHandlingHours)
+}
+
+func TestNewRulePairPrecisions(t *testing.T) {
+	x, y := uuid.New(), uuid.New()
+
+	precisions := NewRulePairPrecisions(
+		[]RulePairHitCount{{RuleX: x, RuleXName: "x", RuleY: y, RuleYName: "y", Hits: 4}},
+		[]RulePairCaseOutcomes{
+			{RuleX: x, RuleY: y, Cases: 1, FalsePositives: 1},
+			{RuleX: y, RuleY: x, Cases: 3, ConfirmedRisks: 3},
+		},
+	)
+
+	require.Len(t, precisions, 1)
+	require.NotNil(t, precisions[0].Precision)
+	assert.Zero(t, *precisions[0].Precision)
+	assert.InDelta(t, 25, precisions[0].CaseConversion, 0.001)
+}
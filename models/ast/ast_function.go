@@ -238,12 +238,12 @@ var FuncAttributesMap = map[Function]FuncAttributes{
 	FUNC_FUZZY_MATCH: {
 		DebugName:      "FUNC_FUZZY_MATCH",
 		AstName:        "FuzzyMatch",
-		NamedArguments: []string{"algorithm"},
+		NamedArguments: []string{"algorithm", "normalize"},
 	},
 	FUNC_FUZZY_MATCH_ANY_OF: {
 		DebugName:      "FUNC_FUZZY_MATCH_ANY_OF",
 		AstName:        "FuzzyMatchAnyOf",
-		NamedArguments: []string{"algorithm"},
+		NamedArguments: []string{"algorithm", "normalize"},
 	},
 	FUNC_FUZZY_MATCH_FILTER_OPTIONS: {
 		DebugName:      "FUNC_FUZZY_MATCH_FILTER_OPTIONS",
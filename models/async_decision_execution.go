@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -67,6 +68,15 @@ type AsyncDecisionExecutionCreate struct {
 	TriggerObject json.RawMessage
 	ScenarioId    *string
 	ShouldIngest  bool
+	// Executions sharing a deduplication key within an organization are only created once
+	DeduplicationKey *string
+}
+
+// IngestionDecisionDeduplicationKey identifies the evaluation of a version of an ingested object
+// by a scenario, the version being given by the id of its row in the ingested table. Every
+// ingestion writes a new row, even when the updated_at of the object does not change.
+func IngestionDecisionDeduplicationKey(scenarioId, objectInternalId string) string {
+	return fmt.Sprintf("ingestion:%s:%s", scenarioId, objectInternalId)
 }
 
 type AsyncDecisionExecutionUpdate struct {
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type Client360Table struct {
 	Table
 
@@ -10,4 +12,6 @@ type Client360SearchInput struct {
 	Table string
 	Terms string
 	Page  uint64
+	// Search the versions of the objects valid at that time instead of their live version
+	AsOf *time.Time
 }
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"cmp"
+	"reflect"
+	"slices"
 	"time"
 )
 
@@ -69,6 +72,78 @@ type ClientObjectMetadata struct {
 	ObjectType string
 }
 
+// ClientObjectVersion is one version of an ingested object, valid from ValidFrom until ValidUntil
+// (nil for the live version). Changes lists the fields that differ from the previous version, and
+// is empty for the first version of the object.
+type ClientObjectVersion struct {
+	ValidFrom  time.Time
+	ValidUntil *time.Time
+	Data       map[string]any
+	Changes    []ClientObjectFieldChange
+}
+
+func (v ClientObjectVersion) IsValidAt(t time.Time) bool {
+	return !v.ValidFrom.After(t) && (v.ValidUntil == nil || v.ValidUntil.After(t))
+}
+
+type ClientObjectFieldChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+// NewClientObjectHistory sorts the versions of an object from the most recent to the oldest, and
+// fills in the changes of each version against the one it replaced.
+func NewClientObjectHistory(versions []ClientObjectVersion) []ClientObjectVersion {
+	history := slices.Clone(versions)
+	slices.SortFunc(history, func(a, b ClientObjectVersion) int {
+		return b.ValidFrom.Compare(a.ValidFrom)
+	})
+
+	for i := range history {
+		if i+1 < len(history) {
+			history[i].Changes = DiffClientObjectData(history[i+1].Data, history[i].Data)
+		}
+	}
+	return history
+}
+
+// DiffClientObjectData returns the fields whose value differs between two versions of an object,
+// sorted by field name.
+func DiffClientObjectData(before, after map[string]any) []ClientObjectFieldChange {
+	fields := make(map[string]struct{}, len(after))
+	for field := range before {
+		fields[field] = struct{}{}
+	}
+	for field := range after {
+		fields[field] = struct{}{}
+	}
+
+	changes := make([]ClientObjectFieldChange, 0)
+	for field := range fields {
+		if !sameFieldValue(before[field], after[field]) {
+			changes = append(changes, ClientObjectFieldChange{
+				Field:  field,
+				Before: before[field],
+				After:  after[field],
+			})
+		}
+	}
+	slices.SortFunc(changes, func(a, b ClientObjectFieldChange) int {
+		return cmp.Compare(a.Field, b.Field)
+	})
+	return changes
+}
+
+func sameFieldValue(a, b any) bool {
+	// Timestamps read from the database may come back in different locations
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
 type StringOrNumber struct {
 	StringValue *string
 	FloatValue  *float64
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffClientObjectData(t *testing.T) {
+	ts := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	changes := DiffClientObjectData(
+		map[string]any{"object_id": "a", "amount": 10.0, "status": "open", "at": ts, "removed": "x"},
+		map[string]any{"object_id": "a", "amount": 12.5, "status": "open", "at": ts.In(time.FixedZone("", 3600)), "added": true},
+	)
+
+	assert.Equal(t, []ClientObjectFieldChange{
+		{Field: "added", Before: nil, After: true},
+		{Field: "amount", Before: 10.0, After: 12.5},
+		{Field: "removed", Before: "x", After: nil},
+	}, changes)
+}
+
+func TestNewClientObjectHistory(t *testing.T) {
+	t1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	history := NewClientObjectHistory([]ClientObjectVersion{
+		{ValidFrom: t2, ValidUntil: &t3, Data: map[string]any{"status": "pending"}},
+		{ValidFrom: t3, Data: map[string]any{"status": "closed"}},
+		{ValidFrom: t1, ValidUntil: &t2, Data: map[string]any{"status": "open"}},
+	})
+
+	assert.Len(t, history, 3)
+	assert.Equal(t, t3, history[0].ValidFrom)
+	assert.Equal(t, []ClientObjectFieldChange{{Field: "status", Before: "pending", After: "closed"}}, history[0].Changes)
+	assert.Equal(t, []ClientObjectFieldChange{{Field: "status", Before: "open", After: "pending"}}, history[1].Changes)
+	assert.Empty(t, history[2].Changes)
+
+	assert.True(t, history[0].IsValidAt(t3.Add(time.Hour)))
+	assert.True(t, history[1].IsValidAt(t2))
+	assert.False(t, history[1].IsValidAt(t3))
+	assert.False(t, history[2].IsValidAt(t1.Add(-time.Second)))
+}
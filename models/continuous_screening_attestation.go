@@ -0,0 +1,183 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContinuousScreeningAttestationReportFormat string
+
+const (
+	ContinuousScreeningAttestationReportFormatCsv  ContinuousScreeningAttestationReportFormat = "csv"
+	ContinuousScreeningAttestationReportFormatJson ContinuousScreeningAttestationReportFormat = "json"
+)
+
+func ContinuousScreeningAttestationReportFormatFrom(s string) (ContinuousScreeningAttestationReportFormat, bool) {
+	switch ContinuousScreeningAttestationReportFormat(s) {
+	case ContinuousScreeningAttestationReportFormatCsv:
+		return ContinuousScreeningAttestationReportFormatCsv, true
+	case ContinuousScreeningAttestationReportFormatJson:
+		return ContinuousScreeningAttestationReportFormatJson, true
+	}
+
+	return "", false
+}
+
+// ContinuousScreeningAttestationReport is a periodic snapshot of the monitoring state of an
+// organization, stored as CSV and JSON files in the continuous screening bucket.
+type ContinuousScreeningAttestationReport struct {
+	Id                 uuid.UUID
+	OrgId              uuid.UUID
+	CsvFilePath        string
+	JsonFilePath       string
+	ObjectCount        int
+	FlaggedObjectCount int
+	OpenMatchCount     int
+	CreatedAt          time.Time
+}
+
+func (r ContinuousScreeningAttestationReport) FilePath(format ContinuousScreeningAttestationReportFormat) string {
+	if format == ContinuousScreeningAttestationReportFormatJson {
+		return r.JsonFilePath
+	}
+	return r.CsvFilePath
+}
+
+type CreateContinuousScreeningAttestationReport struct {
+	Id                 uuid.UUID
+	OrgId              uuid.UUID
+	CsvFilePath        string
+	JsonFilePath       string
+	ObjectCount        int
+	FlaggedObjectCount int
+	OpenMatchCount     int
+}
+
+type CreateContinuousScreeningAttestationReportDownload struct {
+	OrgId    uuid.UUID
+	ReportId uuid.UUID
+	Format   ContinuousScreeningAttestationReportFormat
+}
+
+// ContinuousScreeningAttestationUpdate is a dataset update as processed for one config.
+type ContinuousScreeningAttestationUpdate struct {
+	ConfigStableId uuid.UUID
+	DatasetName    string
+	Version        string
+	ReceivedAt     time.Time
+	Status         ContinuousScreeningUpdateJobStatus
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+}
+
+// ContinuousScreeningAttestationObjectStats gathers, for a monitored object, what is needed to
+// attest of its screening on top of the dataset updates.
+type ContinuousScreeningAttestationObjectStats struct {
+	ObjectType     string
+	ObjectId       string
+	ConfigStableId uuid.UUID
+	// Latest screening of the object itself (object added or updated)
+	LastObjectScreeningAt *time.Time
+	// When the object was first part of the org dataset, which dataset updates are screened against
+	IndexedAt   *time.Time
+	OpenMatches int
+}
+
+type ContinuousScreeningAttestationEntry struct {
+	ObjectType          string
+	ObjectId            string
+	ConfigStableId      uuid.UUID
+	ConfigName          string
+	MonitoredSince      time.Time
+	LastScreenedAt      *time.Time
+	LastScreenedDataset string
+	LastScreenedVersion string
+	OpenMatches         int
+	MissedVersions      []string
+}
+
+func (e ContinuousScreeningAttestationEntry) Flagged() bool {
+	return len(e.MissedVersions) > 0
+}
+
+// BuildContinuousScreeningAttestationEntry computes the attestation of a monitored object.
+//
+// An object is screened either directly, when it is added or updated, against the dataset
+// version current at that time, or when a dataset update is applied to its config, provided the
+// object was already indexed in the org dataset. A dataset update received while the object was
+// monitored is missed if it did not cover the object, once it is over or older than the grace
+// period, unless the object was screened again directly afterwards.
+// Updates must be sorted by reception time and only contain the updates of the object's config.
+func BuildContinuousScreeningAttestationEntry(
+	object ContinuousScreeningMonitoredObject,
+	config ContinuousScreeningConfig,
+	stats ContinuousScreeningAttestationObjectStats,
+	updates []ContinuousScreeningAttestationUpdate,
+	now time.Time,
+	gracePeriod time.Duration,
+) ContinuousScreeningAttestationEntry {
+	entry := ContinuousScreeningAttestationEntry{
+		ObjectType:     object.ObjectType,
+		ObjectId:       object.ObjectId,
+		ConfigStableId: object.ConfigStableId,
+		ConfigName:     config.Name,
+		MonitoredSince: object.CreatedAt,
+		OpenMatches:    stats.OpenMatches,
+	}
+
+	if stats.LastObjectScreeningAt != nil {
+		entry.LastScreenedAt = stats.LastObjectScreeningAt
+		for _, update := range updates {
+			if update.ReceivedAt.After(*stats.LastObjectScreeningAt) {
+				break
+			}
+			entry.LastScreenedDataset = update.DatasetName
+			entry.LastScreenedVersion = update.Version
+		}
+	}
+
+	for _, update := range updates {
+		if update.ReceivedAt.Before(object.CreatedAt) {
+			continue
+		}
+
+		if update.covers(stats) {
+			if entry.LastScreenedAt == nil || update.FinishedAt.After(*entry.LastScreenedAt) {
+				entry.LastScreenedAt = update.FinishedAt
+				entry.LastScreenedDataset = update.DatasetName
+				entry.LastScreenedVersion = update.Version
+			}
+			continue
+		}
+
+		if !update.isOver() && now.Sub(update.ReceivedAt) < gracePeriod {
+			continue
+		}
+		if stats.LastObjectScreeningAt != nil && stats.LastObjectScreeningAt.After(update.ReceivedAt) {
+			continue
+		}
+		entry.MissedVersions = append(entry.MissedVersions, update.Version)
+	}
+
+	return entry
+}
+
+func (u ContinuousScreeningAttestationUpdate) isOver() bool {
+	return u.Status == ContinuousScreeningUpdateJobStatusCompleted ||
+		u.Status == ContinuousScreeningUpdateJobStatusFailed ||
+		u.Status == ContinuousScreeningUpdateJobStatusSkipped
+}
+
+func (u ContinuousScreeningAttestationUpdate) covers(stats ContinuousScreeningAttestationObjectStats) bool {
+	if u.Status != ContinuousScreeningUpdateJobStatusCompleted || u.FinishedAt == nil || stats.IndexedAt == nil {
+		return false
+	}
+
+	appliedAt := u.FinishedAt
+	if u.StartedAt != nil {
+		appliedAt = u.StartedAt
+	}
+
+	return !stats.IndexedAt.After(*appliedAt)
+}
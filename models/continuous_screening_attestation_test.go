@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildContinuousScreeningAttestationEntry(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return now.AddDate(0, 0, d) }
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	object := ContinuousScreeningMonitoredObject{
+		ObjectType:     "companies",
+		ObjectId:       "c1",
+		ConfigStableId: uuid.New(),
+		CreatedAt:      day(-10),
+	}
+	config := ContinuousScreeningConfig{StableId: object.ConfigStableId, Name: "KYB"}
+	update := func(version string, received time.Time, status ContinuousScreeningUpdateJobStatus) ContinuousScreeningAttestationUpdate {
+		u := ContinuousScreeningAttestationUpdate{
+			ConfigStableId: object.ConfigStableId,
+			DatasetName:    "default",
+			Version:        version,
+			ReceivedAt:     received,
+			Status:         status,
+		}
+		if status == ContinuousScreeningUpdateJobStatusCompleted {
+			u.StartedAt = ptr(received.Add(time.Minute))
+			u.FinishedAt = ptr(received.Add(time.Hour))
+		}
+		return u
+	}
+
+	t.Run("screened by dataset updates", func(t *testing.T) {
+		updates := []ContinuousScreeningAttestationUpdate{
+			update("v0", day(-12), ContinuousScreeningUpdateJobStatusCompleted),
+			update("v1", day(-5), ContinuousScreeningUpdateJobStatusCompleted),
+			update("v2", day(-2), ContinuousScreeningUpdateJobStatusCompleted),
+		}
+		stats := ContinuousScreeningAttestationObjectStats{
+			LastObjectScreeningAt: ptr(day(-10)),
+			IndexedAt:             ptr(day(-9)),
+			OpenMatches:           2,
+		}
+
+		entry := BuildContinuousScreeningAttestationEntry(object, config, stats, updates, now, 24*time.Hour)
+
+		assert.Equal(t, "KYB", entry.ConfigName)
+		assert.Equal(t, "v2", entry.LastScreenedVersion)
+		assert.Equal(t, day(-2).Add(time.Hour), *entry.LastScreenedAt)
+		assert.Equal(t, 2, entry.OpenMatches)
+		assert.False(t, entry.Flagged())
+	})
+
+	t.Run("only screened when added", func(t *testing.T) {
+		updates := []ContinuousScreeningAttestationUpdate{
+			update("v0", day(-12), ContinuousScreeningUpdateJobStatusCompleted),
+		}
+		stats := ContinuousScreeningAttestationObjectStats{LastObjectScreeningAt: ptr(day(-10))}
+
+		entry := BuildContinuousScreeningAttestationEntry(object, config, stats, updates, now, 24*time.Hour)
+
+		assert.Equal(t, "v0", entry.LastScreenedVersion)
+		assert.Equal(t, day(-10), *entry.LastScreenedAt)
+		assert.False(t, entry.Flagged())
+	})
+
+	t.Run("missed updates", func(t *testing.T) {
+		updates := []ContinuousScreeningAttestationUpdate{
+			// Applied before the object was written in the org dataset
+			update("v1", day(-10).Add(time.Hour), ContinuousScreeningUpdateJobStatusCompleted),
+			update("v2", day(-5), ContinuousScreeningUpdateJobStatusFailed),
+			update("v3", day(-4), ContinuousScreeningUpdateJobStatusCompleted),
+			update("v4", day(-3), ContinuousScreeningUpdateJobStatusPending),
+			// Still within the grace period
+			update("v5", now.Add(-time.Hour), ContinuousScreeningUpdateJobStatusProcessing),
+		}
+		stats := ContinuousScreeningAttestationObjectStats{
+			LastObjectScreeningAt: ptr(day(-10)),
+			IndexedAt:             ptr(day(-9)),
+		}
+
+		entry := BuildContinuousScreeningAttestationEntry(object, config, stats, updates, now, 24*time.Hour)
+
+		assert.Equal(t, "v3", entry.LastScreenedVersion)
+		assert.Equal(t, []string{"v1", "v2", "v4"}, entry.MissedVersions)
+		assert.True(t, entry.Flagged())
+	})
+
+	t.Run("missed update caught up by a later object screening", func(t *testing.T) {
+		updates := []ContinuousScreeningAttestationUpdate{
+			update("v1", day(-5), ContinuousScreeningUpdateJobStatusSkipped),
+		}
+		stats := ContinuousScreeningAttestationObjectStats{
+			LastObjectScreeningAt: ptr(day(-1)),
+			IndexedAt:             ptr(day(-9)),
+		}
+
+		entry := BuildContinuousScreeningAttestationEntry(object, config, stats, updates, now, 24*time.Hour)
+
+		assert.Equal(t, "v1", entry.LastScreenedVersion)
+		assert.Equal(t, day(-1), *entry.LastScreenedAt)
+		assert.False(t, entry.Flagged())
+	})
+}
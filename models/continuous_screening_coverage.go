@@ -0,0 +1,117 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ContinuousScreeningCoverageGapType string
+
+const (
+	// The object is a current row of a configured object type, but is not monitored by any config
+	// covering that object type.
+	ContinuousScreeningCoverageGapTypeUnmonitored ContinuousScreeningCoverageGapType = "unmonitored"
+	// The object is monitored, but has no current row in the client data anymore.
+	ContinuousScreeningCoverageGapTypeOrphaned ContinuousScreeningCoverageGapType = "orphaned"
+)
+
+func ContinuousScreeningCoverageGapTypeFrom(s string) (ContinuousScreeningCoverageGapType, bool) {
+	switch ContinuousScreeningCoverageGapType(s) {
+	case ContinuousScreeningCoverageGapTypeUnmonitored, ContinuousScreeningCoverageGapTypeOrphaned:
+		return ContinuousScreeningCoverageGapType(s), true
+	default:
+		return "", false
+	}
+}
+
+type ContinuousScreeningCoverageAnalysis struct {
+	Id               uuid.UUID
+	OrgId            uuid.UUID
+	ObjectTypes      []string
+	UnmonitoredCount int
+	OrphanedCount    int
+	// Set when the number of gaps of one type exceeded the number of gaps stored per analysis
+	Truncated    bool
+	BackfilledAt *time.Time
+	CreatedAt    time.Time
+}
+
+type CreateContinuousScreeningCoverageAnalysis struct {
+	Id               uuid.UUID
+	OrgId            uuid.UUID
+	ObjectTypes      []string
+	UnmonitoredCount int
+	OrphanedCount    int
+	Truncated        bool
+}
+
+type ContinuousScreeningCoverageGap struct {
+	Id         uuid.UUID
+	AnalysisId uuid.UUID
+	GapType    ContinuousScreeningCoverageGapType
+	ObjectType string
+	ObjectId   string
+	// Internal id of the current row of an unmonitored object, used to register it
+	ObjectInternalId *uuid.UUID
+	// Config monitoring an orphaned object
+	ConfigStableId *uuid.UUID
+	CreatedAt      time.Time
+}
+
+type ContinuousScreeningCoverageGapFilters struct {
+	GapType *ContinuousScreeningCoverageGapType
+}
+
+// Row of the client data found by the coverage analysis
+type ContinuousScreeningCoverageObject struct {
+	ObjectId   string
+	InternalId uuid.UUID
+}
+
+type ContinuousScreeningCoverageBackfill struct {
+	// Config to register the unmonitored objects in, required when several configs cover the
+	// object type of an unmonitored object.
+	ConfigStableId *uuid.UUID
+	ShouldScreen   bool
+}
+
+type ContinuousScreeningCoverageBackfillResult struct {
+	EnqueuedCount int
+	// Unmonitored objects for which no config could be chosen
+	SkippedCount int
+}
+
+// ContinuousScreeningConfigStableIdsByObjectType returns the stable IDs of the configs covering each
+// object type.
+func ContinuousScreeningConfigStableIdsByObjectType(configs []ContinuousScreeningConfig) map[string][]uuid.UUID {
+	res := make(map[string][]uuid.UUID)
+	for _, config := range configs {
+		for _, objectType := range config.ObjectTypes {
+			if !slices.Contains(res[objectType], config.StableId) {
+				res[objectType] = append(res[objectType], config.StableId)
+			}
+		}
+	}
+	return res
+}
+
+// ContinuousScreeningCoverageBackfillConfig picks the config an unmonitored object of the given
+// type is registered in: the requested config if it covers the object type, or the only config
+// covering it otherwise.
+func ContinuousScreeningCoverageBackfillConfig(
+	configStableIds []uuid.UUID,
+	requested *uuid.UUID,
+) (uuid.UUID, bool) {
+	if requested != nil {
+		if slices.Contains(configStableIds, *requested) {
+			return *requested, true
+		}
+		return uuid.Nil, false
+	}
+	if len(configStableIds) == 1 {
+		return configStableIds[0], true
+	}
+	return uuid.Nil, false
+}
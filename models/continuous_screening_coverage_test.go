@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContinuousScreeningCoverageBackfillConfig(t *testing.T) {
+	kyc, kyb, sanctions := uuid.New(), uuid.New(), uuid.New()
+	configs := []ContinuousScreeningConfig{
+		{StableId: kyc, ObjectTypes: []string{"persons"}},
+		{StableId: kyb, ObjectTypes: []string{"companies"}},
+		{StableId: sanctions, ObjectTypes: []string{"persons", "companies"}},
+	}
+	byObjectType := ContinuousScreeningConfigStableIdsByObjectType(configs)
+	assert.Equal(t, []uuid.UUID{kyc, sanctions}, byObjectType["persons"])
+	assert.Equal(t, []uuid.UUID{kyb, sanctions}, byObjectType["companies"])
+
+	t.Run("several configs without requested config", func(t *testing.T) {
+		_, ok := ContinuousScreeningCoverageBackfillConfig(byObjectType["persons"], nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("requested config covering the object type", func(t *testing.T) {
+		configStableId, ok := ContinuousScreeningCoverageBackfillConfig(byObjectType["persons"], &sanctions)
+		assert.True(t, ok)
+		assert.Equal(t, sanctions, configStableId)
+	})
+
+	t.Run("requested config not covering the object type", func(t *testing.T) {
+		_, ok := ContinuousScreeningCoverageBackfillConfig(byObjectType["companies"], &kyc)
+		assert.False(t, ok)
+	})
+
+	t.Run("single config", func(t *testing.T) {
+		configStableId, ok := ContinuousScreeningCoverageBackfillConfig([]uuid.UUID{kyb}, nil)
+		assert.True(t, ok)
+		assert.Equal(t, kyb, configStableId)
+	})
+}
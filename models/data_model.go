@@ -256,39 +256,48 @@ type Field struct {
 	UnicityConstraint UnicityConstraint
 	FTMProperty       *FollowTheMoneyProperty
 	Metadata          json.RawMessage
+	// Constraints are stored in Metadata, from which they are read
+	Constraints *FieldConstraints
+	// DerivedExpression, if set, computes the value of the field at ingestion instead of reading it
+	// from the payload
+	DerivedExpression *ast.Node
 	Archived          bool
 }
 
 func (f Field) ToMetadata() FieldMetadata {
 	return FieldMetadata{
-		ID:           f.ID,
-		DataType:     f.DataType,
-		Description:  f.Description,
-		Alias:        f.Alias,
-		SemanticType: f.SemanticType,
-		IsEnum:       f.IsEnum,
-		Name:         f.Name,
-		Nullable:     f.Nullable,
-		TableId:      f.TableId,
-		FTMProperty:  f.FTMProperty,
-		Metadata:     f.Metadata,
-		Archived:     f.Archived,
+		ID:                f.ID,
+		DataType:          f.DataType,
+		Description:       f.Description,
+		Alias:             f.Alias,
+		SemanticType:      f.SemanticType,
+		IsEnum:            f.IsEnum,
+		Name:              f.Name,
+		Nullable:          f.Nullable,
+		TableId:           f.TableId,
+		FTMProperty:       f.FTMProperty,
+		Metadata:          f.Metadata,
+		Constraints:       f.Constraints,
+		DerivedExpression: f.DerivedExpression,
+		Archived:          f.Archived,
 	}
 }
 
 type FieldMetadata struct {
-	ID           string
-	DataType     DataType
-	Description  string
-	Alias        string
-	SemanticType FieldSemanticType
-	IsEnum       bool
-	Name         string
-	Nullable     bool
-	TableId      string
-	FTMProperty  *FollowTheMoneyProperty
-	Metadata     json.RawMessage
-	Archived     bool
+	ID                string
+	DataType          DataType
+	Description       string
+	Alias             string
+	SemanticType      FieldSemanticType
+	IsEnum            bool
+	Name              string
+	Nullable          bool
+	TableId           string
+	FTMProperty       *FollowTheMoneyProperty
+	Metadata          json.RawMessage
+	Constraints       *FieldConstraints
+	DerivedExpression *ast.Node
+	Archived          bool
 }
 
 type UnicityConstraint int
@@ -335,6 +344,8 @@ type CreateFieldInput struct {
 	IsUnique     bool
 	FTMProperty  *FollowTheMoneyProperty
 	Metadata     json.RawMessage
+	// Merged into Metadata when the field is created
+	Constraints *FieldConstraints
 }
 
 type UpdateFieldInput struct {
@@ -346,6 +357,8 @@ type UpdateFieldInput struct {
 	Alias        *string
 	SemanticType pure_utils.Null[FieldSemanticType]
 	Metadata     *json.RawMessage
+	// Merged into Metadata when the field is updated
+	Constraints pure_utils.Null[FieldConstraints]
 }
 
 type EnumValues map[string]map[any]struct{}
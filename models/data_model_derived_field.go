@@ -0,0 +1,197 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+// The data types a derived field can have
+var DerivedFieldDataTypes = []DataType{Bool, Int, Float, String, Timestamp}
+
+// Functions that cannot be used in the expression of a derived field. Derived fields are computed
+// from the ingested object and the objects it links to, not from aggregates over the table or from
+// the state of cases and screenings, which change independently of the object.
+var derivedFieldForbiddenFunctions = []ast.Function{
+	ast.FUNC_AGGREGATOR,
+	ast.FUNC_LIST,
+	ast.FUNC_FILTER,
+	ast.FUNC_MONITORING_LIST_CHECK,
+	ast.FUNC_RECORD_HAS_TAGS,
+	ast.FUNC_RECORD_HAS_PAST_ALERTS,
+	ast.FUNC_RECORD_RISK_LEVEL,
+	ast.FUNC_SCORE_COMPUTATION,
+	ast.FUNC_UNDEFINED,
+	ast.FUNC_UNKNOWN,
+}
+
+func (f Field) IsDerived() bool {
+	return f.DerivedExpression != nil
+}
+
+// DerivedFields returns the derived fields of the table, sorted by name.
+func (t Table) DerivedFields() []Field {
+	fields := make([]Field, 0)
+	for _, field := range t.Fields {
+		if field.IsDerived() && !field.Archived {
+			fields = append(fields, field)
+		}
+	}
+	slices.SortFunc(fields, func(a, b Field) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return fields
+}
+
+// ValidateDerivedField checks that the field can be derived from the given expression. The expression
+// may read the fields of the ingested object that are not derived themselves, and the fields of the
+// objects it links to.
+func ValidateDerivedField(table Table, field Field, expression ast.Node) error {
+	if field.Name == "object_id" || field.Name == "updated_at" {
+		return errors.Wrap(BadParameterError, "the `object_id` and `updated_at` fields cannot be derived")
+	}
+	if field.Archived {
+		return errors.Wrap(BadParameterError, "an archived field cannot be derived")
+	}
+	if !slices.Contains(DerivedFieldDataTypes, field.DataType) {
+		return errors.Wrapf(BadParameterError, "a field of type %s cannot be derived", field.DataType)
+	}
+	if !field.Nullable {
+		// Rows ingested before the field was derived have no value until the backfill reaches them,
+		// and an expression reading a missing linked object evaluates to null.
+		return errors.Wrap(BadParameterError, "a derived field must be nullable")
+	}
+	if field.IsEnum {
+		return errors.Wrap(BadParameterError, "an enum field cannot be derived")
+	}
+
+	return validateDerivedExpressionNode(table, field, expression)
+}
+
+func validateDerivedExpressionNode(table Table, field Field, node ast.Node) error {
+	if slices.Contains(derivedFieldForbiddenFunctions, node.Function) {
+		return errors.Wrapf(BadParameterError, "function %s cannot be used in a derived field",
+			node.Function.DebugString())
+	}
+
+	switch node.Function {
+	case ast.FUNC_PAYLOAD:
+		if len(node.Children) == 0 {
+			return errors.Wrap(BadParameterError, "payload access without a field name")
+		}
+		name, ok := node.Children[0].Constant.(string)
+		if !ok {
+			return errors.Wrap(BadParameterError, "payload access with a field name that is not a string")
+		}
+		input, ok := table.Fields[name]
+		if !ok || input.Archived {
+			return errors.Wrapf(BadParameterError, "field %q not found in table %s", name, table.Name)
+		}
+		if input.IsDerived() || name == field.Name {
+			return errors.Wrapf(BadParameterError,
+				"derived field %q cannot read the derived field %q", field.Name, name)
+		}
+	case ast.FUNC_DB_ACCESS:
+		tableName, _ := node.NamedChildren["tableName"].Constant.(string)
+		if tableName != table.Name {
+			return errors.Wrapf(BadParameterError,
+				"a derived field of table %s can only read the objects linked to it", table.Name)
+		}
+	}
+
+	for _, child := range node.Children {
+		if err := validateDerivedExpressionNode(table, field, child); err != nil {
+			return err
+		}
+	}
+	for _, child := range node.NamedChildren {
+		if err := validateDerivedExpressionNode(table, field, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CoerceDerivedFieldValue converts the result of the expression of a derived field to the Go type
+// stored in a column of the given data type.
+func CoerceDerivedFieldValue(value any, dataType DataType) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch dataType {
+	case Bool:
+		if b, ok := value.(bool); ok {
+			return b, nil
+		}
+	case Float:
+		if f, ok := value.(float64); ok {
+			return f, nil
+		}
+	case String:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case Timestamp:
+		if t, ok := value.(time.Time); ok {
+			return t.UTC(), nil
+		}
+	case Int:
+		if n, ok := fieldTypeMigrationInt(value); ok {
+			// Int fields are stored as 32 bits integers
+			if n < math.MinInt32 || n > math.MaxInt32 {
+				return nil, fmt.Errorf("%d is out of range for an integer field", n)
+			}
+			return n, nil
+		}
+	}
+
+	return ConvertFieldValue(value, dataType, nil)
+}
+
+type DerivedFieldBackfillStatus string
+
+const (
+	DerivedFieldBackfillPending   DerivedFieldBackfillStatus = "pending"
+	DerivedFieldBackfillRunning   DerivedFieldBackfillStatus = "running"
+	DerivedFieldBackfillCompleted DerivedFieldBackfillStatus = "completed"
+	DerivedFieldBackfillFailed    DerivedFieldBackfillStatus = "failed"
+	// The definition of the field changed before the backfill completed, a new backfill replaced it
+	DerivedFieldBackfillSuperseded DerivedFieldBackfillStatus = "superseded"
+)
+
+func (s DerivedFieldBackfillStatus) IsTerminal() bool {
+	return s == DerivedFieldBackfillCompleted || s == DerivedFieldBackfillFailed ||
+		s == DerivedFieldBackfillSuperseded
+}
+
+// DerivedFieldBackfill recomputes the value of a derived field on the live rows of its table, after
+// the field was derived or its expression changed. Rows ingested since then are computed at
+// ingestion.
+type DerivedFieldBackfill struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	TableId        string
+	FieldId        string
+	TableName      string
+	FieldName      string
+	Status         DerivedFieldBackfillStatus
+	LastRowId      *uuid.UUID
+	RowsProcessed  int64
+	Error          *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+// DerivedFieldRow is a live row of a table, read to compute its derived fields.
+type DerivedFieldRow struct {
+	Id   uuid.UUID
+	Data map[string]any
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func derivedFieldTestTable() Table {
+	return Table{
+		Name: "transactions",
+		Fields: map[string]Field{
+			"object_id":  {Name: "object_id", DataType: String},
+			"amount":     {Name: "amount", DataType: Float},
+			"fee":        {Name: "fee", DataType: Float},
+			"total":      {Name: "total", DataType: Float, Nullable: true},
+			"rounded":    {Name: "rounded", DataType: Float, Nullable: true, DerivedExpression: &ast.Node{}},
+			"legacy":     {Name: "legacy", DataType: Float, Nullable: true, Archived: true},
+			"category":   {Name: "category", DataType: String, Nullable: true, IsEnum: true},
+			"ip_address": {Name: "ip_address", DataType: IpAddress, Nullable: true},
+		},
+	}
+}
+
+func derivedFieldTestPayload(name string) ast.Node {
+	return ast.Node{Function: ast.FUNC_PAYLOAD}.AddChild(ast.NewNodeConstant(name))
+}
+
+func TestValidateDerivedField(t *testing.T) {
+	table := derivedFieldTestTable()
+	sum := ast.Node{Function: ast.FUNC_ADD}.
+		AddChild(derivedFieldTestPayload("amount")).
+		AddChild(derivedFieldTestPayload("fee"))
+
+	assert.NoError(t, ValidateDerivedField(table, table.Fields["total"], sum))
+	assert.NoError(t, ValidateDerivedField(table, table.Fields["total"],
+		ast.NewNodeDatabaseAccess("transactions", "balance", []string{"account"})))
+
+	tests := map[string]struct {
+		field      string
+		expression ast.Node
+	}{
+		"object_id":           {"object_id", sum},
+		"not nullable":        {"amount", sum},
+		"archived":            {"legacy", sum},
+		"enum":                {"category", sum},
+		"unsupported type":    {"ip_address", sum},
+		"reads itself":        {"total", derivedFieldTestPayload("total")},
+		"reads derived field": {"total", derivedFieldTestPayload("rounded")},
+		"reads unknown field": {"total", derivedFieldTestPayload("unknown")},
+		"reads other table":   {"total", ast.NewNodeDatabaseAccess("accounts", "balance", []string{"owner"})},
+		"aggregates": {"total", ast.Node{Function: ast.FUNC_ADD}.
+			AddChild(derivedFieldTestPayload("amount")).
+			AddChild(ast.Node{Function: ast.FUNC_AGGREGATOR})},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateDerivedField(table, table.Fields[tt.field], tt.expression)
+			assert.ErrorIs(t, err, BadParameterError)
+		})
+	}
+}
+
+func TestTableDerivedFields(t *testing.T) {
+	table := derivedFieldTestTable()
+	table.Fields["adjusted"] = Field{Name: "adjusted", DataType: Float, Nullable: true, DerivedExpression: &ast.Node{}}
+
+	fields := table.DerivedFields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "adjusted", fields[0].Name)
+	assert.Equal(t, "rounded", fields[1].Name)
+}
+
+func TestCoerceDerivedFieldValue(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+
+	tests := []struct {
+		value    any
+		dataType DataType
+		expected any
+		err      bool
+	}{
+		{nil, Int, nil, false},
+		{true, Bool, true, false},
+		{1.5, Float, 1.5, false},
+		{int64(3), Float, 3.0, false},
+		{int64(3), Int, int64(3), false},
+		{int32(3), Int, int64(3), false},
+		{3.0, Int, int64(3), false},
+		{3.5, Int, nil, true},
+		{int64(math.MaxInt32) + 1, Int, nil, true},
+		{"abc", String, "abc", false},
+		{int64(12), String, "12", false},
+		{now, Timestamp, now.UTC(), false},
+		{"2026-10-19", Timestamp, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), false},
+		{"abc", Bool, nil, true},
+	}
+	for _, tt := range tests {
+		value, err := CoerceDerivedFieldValue(tt.value, tt.dataType)
+		if tt.err {
+			assert.Error(t, err, "%v to %s", tt.value, tt.dataType)
+			continue
+		}
+		require.NoError(t, err, "%v to %s", tt.value, tt.dataType)
+		assert.Equal(t, tt.expected, value, "%v to %s", tt.value, tt.dataType)
+	}
+}
@@ -0,0 +1,247 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/biter777/countries"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/cockroachdb/errors"
+)
+
+///////////////////////////////
+// Field Constraints
+///////////////////////////////
+
+type FieldConstraintFormat string
+
+const (
+	FieldConstraintFormatIsoCountry  FieldConstraintFormat = "iso_country"
+	FieldConstraintFormatIsoCurrency FieldConstraintFormat = "iso_currency"
+)
+
+// FieldConstraints are declarative rules on the values of a field, enforced on top of its data type
+// and nullability when objects are ingested or decisions are created. Null values are never checked,
+// and neither are empty strings on nullable fields, which is how CSV files express missing values.
+type FieldConstraints struct {
+	// Bounds on the value of numeric fields, inclusive
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Bounds on the number of characters of string fields, inclusive
+	MinLength *int `json:"min_length,omitempty"`
+	MaxLength *int `json:"max_length,omitempty"`
+	// Regular expression that string values must match, anchors are up to the author
+	Pattern *string `json:"pattern,omitempty"`
+	// Well-known format that string values must follow
+	Format *FieldConstraintFormat `json:"format,omitempty"`
+	// Exhaustive list of the values allowed for string or numeric fields
+	AllowedValues []any `json:"allowed_values,omitempty"`
+}
+
+// Constraints are stored in the metadata of the field, under this key.
+const fieldMetadataConstraintsKey = "constraints"
+
+// FieldConstraintsFromMetadata reads the constraints stored in the metadata of a field, if any.
+func FieldConstraintsFromMetadata(metadata json.RawMessage) (*FieldConstraints, error) {
+	var object map[string]json.RawMessage
+	if len(metadata) == 0 || json.Unmarshal(metadata, &object) != nil {
+		return nil, nil
+	}
+	raw, ok := object[fieldMetadataConstraintsKey]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var constraints FieldConstraints
+	if err := json.Unmarshal(raw, &constraints); err != nil {
+		return nil, errors.Wrapf(BadParameterError, "invalid constraints in field metadata: %s", err.Error())
+	}
+	return &constraints, nil
+}
+
+// SetFieldConstraintsInMetadata returns the metadata of a field with its constraints replaced, or
+// removed if they are nil. The other keys of the metadata are left untouched.
+func SetFieldConstraintsInMetadata(metadata json.RawMessage, constraints *FieldConstraints) (json.RawMessage, error) {
+	object := map[string]json.RawMessage{}
+	if len(metadata) > 0 && string(metadata) != "null" {
+		if err := json.Unmarshal(metadata, &object); err != nil {
+			return nil, errors.Wrap(BadParameterError, "field metadata must be a JSON object to hold constraints")
+		}
+	}
+
+	if constraints == nil {
+		if _, ok := object[fieldMetadataConstraintsKey]; !ok {
+			return metadata, nil
+		}
+		delete(object, fieldMetadataConstraintsKey)
+	} else {
+		raw, err := json.Marshal(constraints)
+		if err != nil {
+			return nil, err
+		}
+		object[fieldMetadataConstraintsKey] = raw
+	}
+
+	return json.Marshal(object)
+}
+
+func (c FieldConstraints) IsEmpty() bool {
+	return c.Min == nil && c.Max == nil && c.MinLength == nil && c.MaxLength == nil &&
+		c.Pattern == nil && c.Format == nil && len(c.AllowedValues) == 0
+}
+
+// Validate checks that the constraints are consistent and apply to the data type of the field.
+func (c FieldConstraints) Validate(dataType DataType) error {
+	isNumber := dataType == Int || dataType == Float
+	isString := dataType == String
+
+	if (c.Min != nil || c.Max != nil) && !isNumber {
+		return errors.Wrap(BadParameterError, "min and max constraints only apply to int and float fields")
+	}
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		return errors.Wrap(BadParameterError, "min constraint cannot be greater than max")
+	}
+
+	if (c.MinLength != nil || c.MaxLength != nil || c.Pattern != nil || c.Format != nil) && !isString {
+		return errors.Wrap(BadParameterError,
+			"length, pattern and format constraints only apply to string fields")
+	}
+	if (c.MinLength != nil && *c.MinLength < 0) || (c.MaxLength != nil && *c.MaxLength < 0) {
+		return errors.Wrap(BadParameterError, "length constraints cannot be negative")
+	}
+	if c.MinLength != nil && c.MaxLength != nil && *c.MinLength > *c.MaxLength {
+		return errors.Wrap(BadParameterError, "min_length constraint cannot be greater than max_length")
+	}
+	if c.Pattern != nil {
+		if _, err := regexp.Compile(*c.Pattern); err != nil {
+			return errors.Wrapf(BadParameterError, "invalid pattern constraint: %s", err.Error())
+		}
+	}
+	if c.Format != nil && *c.Format != FieldConstraintFormatIsoCountry && *c.Format != FieldConstraintFormatIsoCurrency {
+		return errors.Wrapf(BadParameterError, "unknown format constraint %q", *c.Format)
+	}
+
+	if len(c.AllowedValues) > 0 {
+		if !isString && !isNumber {
+			return errors.Wrap(BadParameterError, "allowed values constraints only apply to string, int and float fields")
+		}
+		for _, value := range c.AllowedValues {
+			_, valueIsString := value.(string)
+			_, valueIsNumber := constraintNumber(value)
+			if (isString && !valueIsString) || (isNumber && !valueIsNumber) {
+				return errors.Wrapf(BadParameterError,
+					"allowed value %v does not match the data type %s of the field", value, dataType)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Check returns an error describing why the value of the field does not satisfy the constraints, if
+// it does not. The value is expected to be already parsed into the data type of the field.
+func (c FieldConstraints) Check(field Field, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	if number, ok := constraintNumber(value); ok {
+		if c.Min != nil && number < *c.Min {
+			return fmt.Errorf("must be greater than or equal to %v", *c.Min)
+		}
+		if c.Max != nil && number > *c.Max {
+			return fmt.Errorf("must be less than or equal to %v", *c.Max)
+		}
+		if len(c.AllowedValues) > 0 && !slices.ContainsFunc(c.AllowedValues, func(allowed any) bool {
+			allowedNumber, ok := constraintNumber(allowed)
+			return ok && allowedNumber == number
+		}) {
+			return fmt.Errorf("must be one of %s", formatAllowedValues(c.AllowedValues))
+		}
+		return nil
+	}
+
+	str, ok := value.(string)
+	if !ok || (str == "" && field.Nullable) {
+		return nil
+	}
+
+	length := utf8.RuneCountInString(str)
+	if c.MinLength != nil && length < *c.MinLength {
+		return fmt.Errorf("must be at least %d characters long", *c.MinLength)
+	}
+	if c.MaxLength != nil && length > *c.MaxLength {
+		return fmt.Errorf("must be at most %d characters long", *c.MaxLength)
+	}
+	if c.Pattern != nil {
+		pattern, err := compiledConstraintPattern(*c.Pattern)
+		if err != nil {
+			return err
+		}
+		if !pattern.MatchString(str) {
+			return fmt.Errorf("must match the pattern %s", *c.Pattern)
+		}
+	}
+	if c.Format != nil {
+		switch *c.Format {
+		case FieldConstraintFormatIsoCountry:
+			if len(str) != 2 || countries.ByName(str).Alpha2() != str {
+				return fmt.Errorf("must be an ISO 3166-1 alpha-2 country code")
+			}
+		case FieldConstraintFormatIsoCurrency:
+			if !slices.Contains(pure_utils.CurrencyCodes, str) {
+				return fmt.Errorf("must be an ISO 4217 currency code")
+			}
+		}
+	}
+	if len(c.AllowedValues) > 0 && !slices.Contains(c.AllowedValues, any(str)) {
+		return fmt.Errorf("must be one of %s", formatAllowedValues(c.AllowedValues))
+	}
+
+	return nil
+}
+
+// Allowed values decoded from JSON are float64, while ingested values may be any integer type.
+func constraintNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func formatAllowedValues(values []any) string {
+	formatted := make([]string, len(values))
+	for i, value := range values {
+		formatted[i] = fmt.Sprintf("%v", value)
+	}
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
+
+// Constraints are checked on every ingested value, so patterns are only compiled once.
+var constraintPatterns sync.Map
+
+func compiledConstraintPattern(pattern string) (*regexp.Regexp, error) {
+	if compiled, ok := constraintPatterns.Load(pattern); ok {
+		return compiled.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern constraint %s: %w", pattern, err)
+	}
+	constraintPatterns.Store(pattern, compiled)
+	return compiled, nil
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func constraintsFromJson(t *testing.T, raw string) FieldConstraints {
+	t.Helper()
+	var constraints FieldConstraints
+	require.NoError(t, json.Unmarshal([]byte(raw), &constraints))
+	return constraints
+}
+
+func TestFieldConstraintsValidate(t *testing.T) {
+	valid := []struct {
+		dataType    DataType
+		constraints string
+	}{
+		{Float, `{"min": 0}`},
+		{Int, `{"min": 1, "max": 10, "allowed_values": [1, 5, 10]}`},
+		{String, `{"min_length": 2, "max_length": 2, "format": "iso_country"}`},
+		{String, `{"pattern": "^[^@]+@[^@]+$"}`},
+		{String, `{"allowed_values": ["EUR", "USD"]}`},
+	}
+	for _, tc := range valid {
+		assert.NoError(t, constraintsFromJson(t, tc.constraints).Validate(tc.dataType), tc.constraints)
+	}
+
+	invalid := []struct {
+		dataType    DataType
+		constraints string
+	}{
+		{String, `{"min": 0}`},
+		{Float, `{"min": 10, "max": 1}`},
+		{Int, `{"pattern": "^a$"}`},
+		{String, `{"min_length": -1}`},
+		{String, `{"min_length": 3, "max_length": 2}`},
+		{String, `{"pattern": "(unclosed"}`},
+		{String, `{"format": "iso_language"}`},
+		{Bool, `{"allowed_values": [true]}`},
+		{String, `{"allowed_values": [1]}`},
+		{Float, `{"allowed_values": ["1"]}`},
+	}
+	for _, tc := range invalid {
+		err := constraintsFromJson(t, tc.constraints).Validate(tc.dataType)
+		assert.ErrorIs(t, err, BadParameterError, tc.constraints)
+	}
+}
+
+func TestFieldConstraintsCheck(t *testing.T) {
+	field := Field{Nullable: true}
+
+	tests := []struct {
+		name        string
+		constraints string
+		value       any
+		wantErr     string
+	}{
+		{"min ok", `{"min": 0}`, 0.0, ""},
+		{"min ko", `{"min": 0}`, -0.5, "must be greater than or equal to 0"},
+		{"max on int", `{"max": 10}`, int64(11), "must be less than or equal to 10"},
+		{"allowed number", `{"allowed_values": [1, 2]}`, int64(2), ""},
+		{"disallowed number", `{"allowed_values": [1, 2]}`, 3, "must be one of [1, 2]"},
+		{"length counts characters", `{"max_length": 3}`, "été", ""},
+		{"too short", `{"min_length": 3}`, "ab", "must be at least 3 characters long"},
+		{"pattern", `{"pattern": "^[^@]+@[^@]+$"}`, "not an email", "must match the pattern ^[^@]+@[^@]+$"},
+		{"country", `{"format": "iso_country"}`, "FR", ""},
+		{"lower case country", `{"format": "iso_country"}`, "fr", "must be an ISO 3166-1 alpha-2 country code"},
+		{"alpha-3 country", `{"format": "iso_country"}`, "FRA", "must be an ISO 3166-1 alpha-2 country code"},
+		{"currency", `{"format": "iso_currency"}`, "EUR", ""},
+		{"unknown currency", `{"format": "iso_currency"}`, "EUX", "must be an ISO 4217 currency code"},
+		{"allowed string", `{"allowed_values": ["a", "b"]}`, "c", "must be one of [a, b]"},
+		{"null is not checked", `{"min_length": 3}`, nil, ""},
+		{"empty string on nullable field is not checked", `{"min_length": 3}`, "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := constraintsFromJson(t, tc.constraints).Check(field, tc.value)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("empty string on required field is checked", func(t *testing.T) {
+		err := constraintsFromJson(t, `{"min_length": 3}`).Check(Field{Nullable: false}, "")
+		assert.EqualError(t, err, "must be at least 3 characters long")
+	})
+}
+
+func TestFieldConstraintsInMetadata(t *testing.T) {
+	constraints, err := FieldConstraintsFromMetadata(json.RawMessage(`{"owner": "risk", "constraints": {"max_length": 3}}`))
+	require.NoError(t, err)
+	assert.Equal(t, &FieldConstraints{MaxLength: func() *int { v := 3; return &v }()}, constraints)
+
+	for _, metadata := range []string{``, `null`, `{"owner": "risk"}`, `["not", "an", "object"]`} {
+		constraints, err := FieldConstraintsFromMetadata(json.RawMessage(metadata))
+		assert.NoError(t, err)
+		assert.Nil(t, constraints)
+	}
+
+	_, err = FieldConstraintsFromMetadata(json.RawMessage(`{"constraints": {"min": "zero"}}`))
+	assert.ErrorIs(t, err, BadParameterError)
+
+	minValue := 0.0
+	metadata, err := SetFieldConstraintsInMetadata(json.RawMessage(`{"owner": "risk"}`), &FieldConstraints{Min: &minValue})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"owner": "risk", "constraints": {"min": 0}}`, string(metadata))
+
+	metadata, err = SetFieldConstraintsInMetadata(metadata, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"owner": "risk"}`, string(metadata))
+
+	metadata, err = SetFieldConstraintsInMetadata(nil, &FieldConstraints{Min: &minValue})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"constraints": {"min": 0}}`, string(metadata))
+
+	_, err = SetFieldConstraintsInMetadata(json.RawMessage(`[1]`), &FieldConstraints{Min: &minValue})
+	assert.ErrorIs(t, err, BadParameterError)
+}
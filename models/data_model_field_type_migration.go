@@ -0,0 +1,244 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-set/v2"
+)
+
+type FieldTypeMigrationStatus string
+
+const (
+	FieldTypeMigrationPending   FieldTypeMigrationStatus = "pending"
+	FieldTypeMigrationRunning   FieldTypeMigrationStatus = "running"
+	FieldTypeMigrationCompleted FieldTypeMigrationStatus = "completed"
+	FieldTypeMigrationFailed    FieldTypeMigrationStatus = "failed"
+)
+
+func (s FieldTypeMigrationStatus) IsTerminal() bool {
+	return s == FieldTypeMigrationCompleted || s == FieldTypeMigrationFailed
+}
+
+// Beyond this number of rows failing conversion, a migration is aborted: the data needs to be
+// cleaned up before the type of the field can be changed.
+const FieldTypeMigrationMaxFailures = 10_000
+
+// The data type conversions a field type migration supports, by source data type
+var fieldTypeConversions = map[DataType][]DataType{
+	Bool:      {String},
+	Int:       {Float, String},
+	Float:     {Int, String},
+	String:    {Bool, Int, Float, Timestamp},
+	Timestamp: {String},
+}
+
+func CanConvertFieldType(from, to DataType) bool {
+	return slices.Contains(fieldTypeConversions[from], to)
+}
+
+// FieldTypeMigration changes the data type of a field. The values of the field are converted into a
+// shadow column of the client table by a background job, which swaps it with the original column
+// once every row has been converted. The original column is kept, renamed, as for archived fields.
+type FieldTypeMigration struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	TableId        string
+	FieldId        string
+	TableName      string
+	FieldName      string
+	SourceDataType DataType
+	TargetDataType DataType
+	// Go reference time layout used to parse strings into timestamps, the formats accepted by
+	// ingestion are used when it is not set
+	TimestampFormat *string
+	// Rows failing conversion are set to null instead of aborting the migration
+	NullOnFailure bool
+	Status        FieldTypeMigrationStatus
+	// Id of the last row of the client table converted by the backfill, rows are converted by
+	// increasing id
+	LastRowId     *uuid.UUID
+	RowsProcessed int64
+	RowsFailed    int64
+	Error         *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// ShadowColumn is the column of the client table the converted values are written to.
+func (m FieldTypeMigration) ShadowColumn() string {
+	return "type_migration_" + strings.ReplaceAll(m.Id.String(), "-", "")[:12]
+}
+
+type CreateFieldTypeMigrationInput struct {
+	FieldId         string
+	DataType        DataType
+	TimestampFormat *string
+	NullOnFailure   bool
+}
+
+type FieldTypeMigrationFailure struct {
+	MigrationId uuid.UUID
+	RowId       uuid.UUID
+	ObjectId    string
+	Value       string
+	Error       string
+}
+
+// FieldTypeMigrationRow is a row of a client table read by a field type migration, with the value of
+// the migrated field as read from the database.
+type FieldTypeMigrationRow struct {
+	Id       uuid.UUID
+	ObjectId string
+	Value    any
+}
+
+// FieldTypeMigrationReport lists what prevents the type of a field from being changed. Formulas are
+// only reported if they stop evaluating properly once the field has its new type.
+type FieldTypeMigrationReport struct {
+	Migration  *FieldTypeMigration
+	Conflicts  FieldTypeMigrationConflicts
+	References map[string]string
+}
+
+type FieldTypeMigrationConflicts struct {
+	ContinuousScreening  bool
+	PrimaryOrderingField bool
+	Links                *set.Set[string]
+	Pivots               *set.Set[string]
+	AnalyticsSettings    int
+	NavigationOptions    int
+	// Only draft and live iterations are checked, other versions are revalidated when published
+	ScenarioIterations map[string]*DataModelDeleteFieldConflictIteration
+	Workflows          *set.Set[string]
+}
+
+func NewFieldTypeMigrationReport() FieldTypeMigrationReport {
+	return FieldTypeMigrationReport{
+		Conflicts: FieldTypeMigrationConflicts{
+			Links:              set.New[string](0),
+			Pivots:             set.New[string](0),
+			ScenarioIterations: make(map[string]*DataModelDeleteFieldConflictIteration),
+			Workflows:          set.New[string](0),
+		},
+		References: make(map[string]string),
+	}
+}
+
+func (r FieldTypeMigrationReport) HasConflicts() bool {
+	return r.Conflicts.ContinuousScreening ||
+		r.Conflicts.PrimaryOrderingField ||
+		r.Conflicts.Links.Size() > 0 ||
+		r.Conflicts.Pivots.Size() > 0 ||
+		r.Conflicts.AnalyticsSettings > 0 ||
+		r.Conflicts.NavigationOptions > 0 ||
+		len(r.Conflicts.ScenarioIterations) > 0 ||
+		r.Conflicts.Workflows.Size() > 0
+}
+
+// ConvertFieldValue converts a value read from a client table column into the target data type.
+// Strings are parsed as ingestion parses them, unless a timestamp format is given.
+func ConvertFieldValue(value any, target DataType, timestampFormat *string) (any, error) {
+	switch target {
+	case String:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		case time.Time:
+			return v.UTC().Format(time.RFC3339Nano), nil
+		}
+		if n, ok := fieldTypeMigrationInt(value); ok {
+			return strconv.FormatInt(n, 10), nil
+		}
+		if f, ok := value.(float64); ok {
+			return strconv.FormatFloat(f, 'f', -1, 64), nil
+		}
+	case Bool:
+		if s, ok := value.(string); ok {
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid boolean", s)
+			}
+			return b, nil
+		}
+	case Int:
+		var n int64
+		switch v := value.(type) {
+		case string:
+			parsed, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid integer", v)
+			}
+			n = parsed
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, fmt.Errorf("%v is not a whole number", v)
+			}
+			if v < math.MinInt32 || v > math.MaxInt32 {
+				return nil, fmt.Errorf("%v is out of range for an integer field", v)
+			}
+			n = int64(v)
+		default:
+			return nil, fmt.Errorf("cannot convert a value of type %T to %s", value, target)
+		}
+		// Int fields are stored as 32 bits integers
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, fmt.Errorf("%d is out of range for an integer field", n)
+		}
+		return n, nil
+	case Float:
+		if s, ok := value.(string); ok {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not a valid float", s)
+			}
+			return f, nil
+		}
+		if n, ok := fieldTypeMigrationInt(value); ok {
+			return float64(n), nil
+		}
+	case Timestamp:
+		if s, ok := value.(string); ok {
+			return parseFieldTypeMigrationTimestamp(s, timestampFormat)
+		}
+	}
+
+	return nil, fmt.Errorf("cannot convert a value of type %T to %s", value, target)
+}
+
+func fieldTypeMigrationInt(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+func parseFieldTypeMigrationTimestamp(value string, format *string) (time.Time, error) {
+	if format != nil {
+		t, err := time.Parse(*format, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%q does not match the timestamp format %q", value, *format)
+		}
+		return t.UTC(), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05.9", "2006-01-02T15:04:05.9", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a valid timestamp", value)
+}
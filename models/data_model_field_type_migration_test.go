@@ -0,0 +1,99 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanConvertFieldType(t *testing.T) {
+	assert.True(t, CanConvertFieldType(Int, String))
+	assert.True(t, CanConvertFieldType(String, Timestamp))
+	assert.False(t, CanConvertFieldType(Timestamp, Int))
+	assert.False(t, CanConvertFieldType(String, String))
+	assert.False(t, CanConvertFieldType(IpAddress, String))
+}
+
+func TestConvertFieldValue(t *testing.T) {
+	format := "02/01/2006"
+
+	tests := []struct {
+		name     string
+		value    any
+		target   DataType
+		format   *string
+		expected any
+	}{
+		{name: "int to string", value: int32(1234567), target: String, expected: "1234567"},
+		{name: "float to string", value: 12.5, target: String, expected: "12.5"},
+		{name: "bool to string", value: true, target: String, expected: "true"},
+		{
+			name:     "timestamp to string",
+			value:    time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("", 3600)),
+			target:   String,
+			expected: "2024-03-01T09:00:00Z",
+		},
+		{name: "string to int", value: "42", target: Int, expected: int64(42)},
+		{name: "whole float to int", value: 42.0, target: Int, expected: int64(42)},
+		{name: "string to float", value: "4.2", target: Float, expected: 4.2},
+		{name: "int to float", value: int32(4), target: Float, expected: 4.0},
+		{name: "string to bool", value: "false", target: Bool, expected: false},
+		{
+			name:     "string to timestamp",
+			value:    "2024-03-01T10:00:00+01:00",
+			target:   Timestamp,
+			expected: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "string to timestamp with format",
+			value:    "01/03/2024",
+			target:   Timestamp,
+			format:   &format,
+			expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := ConvertFieldValue(tt.value, tt.target, tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestConvertFieldValueFailures(t *testing.T) {
+	format := "02/01/2006"
+
+	tests := []struct {
+		name   string
+		value  any
+		target DataType
+		format *string
+	}{
+		{name: "not an int", value: "12a", target: Int},
+		{name: "int out of range", value: "3000000000", target: Int},
+		{name: "fractional float to int", value: 1.5, target: Int},
+		{name: "not a float", value: "abc", target: Float},
+		{name: "not a bool", value: "maybe", target: Bool},
+		{name: "not a timestamp", value: "yesterday", target: Timestamp},
+		{name: "timestamp not matching format", value: "2024-03-01", target: Timestamp, format: &format},
+		{name: "unsupported conversion", value: true, target: Int},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertFieldValue(tt.value, tt.target, tt.format)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFieldTypeMigrationShadowColumn(t *testing.T) {
+	migration := FieldTypeMigration{Id: uuid.MustParse("0192f3a4-5b6c-7d8e-9f00-112233445566")}
+
+	assert.Equal(t, "type_migration_0192f3a45b6c", migration.ShadowColumn())
+}
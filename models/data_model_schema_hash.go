@@ -0,0 +1,114 @@
+package models
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// The parts of the data model that shape the payloads sent to Marble. Descriptions, aliases and the
+// values seen on enum fields are left out, so that they do not change the hash.
+type dataModelSchemaHashInput struct {
+	Tables map[string]dataModelSchemaHashTable `json:"tables"`
+}
+
+type dataModelSchemaHashTable struct {
+	Fields map[string]dataModelSchemaHashField `json:"fields"`
+	Links  map[string]dataModelSchemaHashLink  `json:"links"`
+}
+
+type dataModelSchemaHashField struct {
+	DataType    string            `json:"data_type"`
+	Nullable    bool              `json:"nullable"`
+	IsEnum      bool              `json:"is_enum"`
+	Derived     bool              `json:"derived"`
+	Constraints *FieldConstraints `json:"constraints"`
+}
+
+type dataModelSchemaHashLink struct {
+	ParentTable string `json:"parent_table"`
+	ParentField string `json:"parent_field"`
+	ChildField  string `json:"child_field"`
+}
+
+// SchemaHash returns a hash of the shape of the objects of the data model: its tables, the type,
+// nullability and constraints of their fields, and their links. It changes whenever a payload valid
+// for the data model may become invalid, or the reverse.
+func (d DataModel) SchemaHash() string {
+	input := dataModelSchemaHashInput{Tables: make(map[string]dataModelSchemaHashTable, len(d.Tables))}
+	for tableName, table := range d.Tables {
+		hashTable := dataModelSchemaHashTable{
+			Fields: make(map[string]dataModelSchemaHashField, len(table.Fields)),
+			Links:  make(map[string]dataModelSchemaHashLink, len(table.LinksToSingle)),
+		}
+		for fieldName, field := range table.Fields {
+			if field.Archived {
+				continue
+			}
+			hashTable.Fields[fieldName] = dataModelSchemaHashField{
+				DataType:    field.DataType.String(),
+				Nullable:    field.Nullable,
+				IsEnum:      field.IsEnum,
+				Derived:     field.IsDerived(),
+				Constraints: field.Constraints,
+			}
+		}
+		for linkName, link := range table.LinksToSingle {
+			hashTable.Links[linkName] = dataModelSchemaHashLink{
+				ParentTable: link.ParentTableName,
+				ParentField: link.ParentFieldName,
+				ChildField:  link.ChildFieldName,
+			}
+		}
+		input.Tables[tableName] = hashTable
+	}
+
+	// Maps are marshalled with sorted keys, the encoding does not depend on the iteration order
+	encoded, _ := json.Marshal(input)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// EnumValuesHash returns a hash of the values seen so far on the enum fields of the data model. They
+// do not change the schema hash, but they are part of the exported schemas.
+func (d DataModel) EnumValuesHash() string {
+	input := make(map[string]map[string][]any, len(d.Tables))
+	for tableName, table := range d.Tables {
+		for fieldName, field := range table.Fields {
+			if field.Archived || !field.IsEnum || len(field.Values) == 0 {
+				continue
+			}
+			if input[tableName] == nil {
+				input[tableName] = make(map[string][]any)
+			}
+			input[tableName][fieldName] = SortedEnumValues(field.Values)
+		}
+	}
+
+	encoded, _ := json.Marshal(input)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// SortedEnumValues returns a sorted copy of the values of an enum field, which are read in no
+// particular order. Numbers come before strings.
+func SortedEnumValues(values []any) []any {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b any) int {
+		fa, aIsFloat := a.(float64)
+		fb, bIsFloat := b.(float64)
+		switch {
+		case aIsFloat && bIsFloat:
+			return cmp.Compare(fa, fb)
+		case aIsFloat:
+			return -1
+		case bIsFloat:
+			return 1
+		}
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	return sorted
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func schemaHashTestDataModel() DataModel {
+	return DataModel{
+		Tables: map[string]Table{
+			"transactions": {
+				Name: "transactions",
+				Fields: map[string]Field{
+					"object_id":  {Name: "object_id", DataType: String},
+					"account_id": {Name: "account_id", DataType: String, Nullable: true},
+					"amount":     {Name: "amount", DataType: Float, Description: "amount"},
+				},
+				LinksToSingle: map[string]LinkToSingle{
+					"account": {Name: "account", ParentTableName: "accounts", ParentFieldName: "object_id", ChildFieldName: "account_id"},
+				},
+			},
+			"accounts": {
+				Name:   "accounts",
+				Fields: map[string]Field{"object_id": {Name: "object_id", DataType: String}},
+			},
+		},
+	}
+}
+
+func TestDataModelSchemaHash(t *testing.T) {
+	hash := schemaHashTestDataModel().SchemaHash()
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, schemaHashTestDataModel().SchemaHash())
+
+	unchanged := schemaHashTestDataModel()
+	amount := unchanged.Tables["transactions"].Fields["amount"]
+	amount.Description = "amount in cents"
+	amount.Values = []any{1.0, 2.0}
+	unchanged.Tables["transactions"].Fields["amount"] = amount
+	unchanged.Tables["transactions"].Fields["legacy"] = Field{Name: "legacy", DataType: Int, Archived: true}
+	assert.Equal(t, hash, unchanged.SchemaHash(), "descriptions, enum values and archived fields are ignored")
+
+	changes := map[string]func(dm DataModel){
+		"nullability": func(dm DataModel) {
+			amount := dm.Tables["transactions"].Fields["amount"]
+			amount.Nullable = true
+			dm.Tables["transactions"].Fields["amount"] = amount
+		},
+		"data type": func(dm DataModel) {
+			amount := dm.Tables["transactions"].Fields["amount"]
+			amount.DataType = Int
+			dm.Tables["transactions"].Fields["amount"] = amount
+		},
+		"constraints": func(dm DataModel) {
+			amount := dm.Tables["transactions"].Fields["amount"]
+			amount.Constraints = &FieldConstraints{Min: ptr(0.0)}
+			dm.Tables["transactions"].Fields["amount"] = amount
+		},
+		"new field": func(dm DataModel) {
+			dm.Tables["accounts"].Fields["name"] = Field{Name: "name", DataType: String}
+		},
+		"link": func(dm DataModel) {
+			delete(dm.Tables["transactions"].LinksToSingle, "account")
+		},
+	}
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			dm := schemaHashTestDataModel()
+			change(dm)
+			assert.NotEqual(t, hash, dm.SchemaHash())
+		})
+	}
+}
+
+func TestDataModelEnumValuesHash(t *testing.T) {
+	withValues := func(values ...any) DataModel {
+		dm := schemaHashTestDataModel()
+		amount := dm.Tables["transactions"].Fields["amount"]
+		amount.IsEnum = true
+		amount.Values = values
+		dm.Tables["transactions"].Fields["amount"] = amount
+		return dm
+	}
+
+	hash := withValues(1.0, 2.0).EnumValuesHash()
+	assert.Equal(t, hash, withValues(2.0, 1.0).EnumValuesHash(), "the order of the values is ignored")
+	assert.NotEqual(t, hash, withValues(1.0, 2.0, 3.0).EnumValuesHash())
+	assert.NotEqual(t, hash, schemaHashTestDataModel().EnumValuesHash())
+}
+
+func TestSortedEnumValues(t *testing.T) {
+	values := []any{"b", 10.0, "a", 2.0}
+	assert.Equal(t, []any{2.0, 10.0, "a", "b"}, SortedEnumValues(values))
+	assert.Equal(t, []any{"b", 10.0, "a", 2.0}, values, "the input is not modified")
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+// DataRetentionTarget is the kind of data a retention policy applies to: the ingested rows of a
+// table of the data model, or the decisions of the organization.
+type DataRetentionTarget string
+
+const (
+	DataRetentionTargetTable     DataRetentionTarget = "table"
+	DataRetentionTargetDecisions DataRetentionTarget = "decisions"
+)
+
+// Reason recorded on a run whose expired objects were kept, because a live scenario aggregates
+// over the table and deleting them would change its outcomes.
+const DataRetentionSkippedLiveAggregates = "table is aggregated by a live scenario"
+
+// Reason recorded on a run whose superseded versions were kept, because the index the retention
+// finds them with is still being built.
+const DataRetentionSkippedIndexPending = "the index on superseded versions is being created"
+
+// DataRetentionPolicy describes how long the data of a table, or the decisions, are kept.
+//   - SupersededVersionsDays: how long the history of an ingested object is kept after it is
+//     replaced by a newer version. Only applies to tables.
+//   - MaxAgeDays: how long an ingested object is kept after its last update, or a decision after
+//     its creation.
+type DataRetentionPolicy struct {
+	Id                     uuid.UUID
+	OrganizationId         uuid.UUID
+	Target                 DataRetentionTarget
+	TableId                *uuid.UUID
+	SupersededVersionsDays *int
+	MaxAgeDays             *int
+	Enabled                bool
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+func (p DataRetentionPolicy) Validate() error {
+	switch p.Target {
+	case DataRetentionTargetTable:
+		if p.TableId == nil {
+			return errors.Wrap(BadParameterError, "table_id is required for a table retention policy")
+		}
+		if p.SupersededVersionsDays == nil && p.MaxAgeDays == nil {
+			return errors.Wrap(BadParameterError,
+				"one of superseded_versions_days or max_age_days is required")
+		}
+	case DataRetentionTargetDecisions:
+		if p.TableId != nil {
+			return errors.Wrap(BadParameterError, "table_id is not allowed for a decisions retention policy")
+		}
+		if p.SupersededVersionsDays != nil {
+			return errors.Wrap(BadParameterError,
+				"superseded_versions_days is not allowed for a decisions retention policy")
+		}
+		if p.MaxAgeDays == nil {
+			return errors.Wrap(BadParameterError, "max_age_days is required for a decisions retention policy")
+		}
+	default:
+		return errors.Wrapf(BadParameterError, "invalid retention target %q", p.Target)
+	}
+
+	if p.SupersededVersionsDays != nil && *p.SupersededVersionsDays < 1 {
+		return errors.Wrap(BadParameterError, "superseded_versions_days must be at least 1")
+	}
+	if p.MaxAgeDays != nil && *p.MaxAgeDays < 1 {
+		return errors.Wrap(BadParameterError, "max_age_days must be at least 1")
+	}
+	return nil
+}
+
+// SupersededBefore returns the time before which superseded versions are deleted, if the policy
+// deletes them.
+func (p DataRetentionPolicy) SupersededBefore(now time.Time) *time.Time {
+	if p.SupersededVersionsDays == nil {
+		return nil
+	}
+	before := now.AddDate(0, 0, -*p.SupersededVersionsDays)
+	return &before
+}
+
+// ExpiredBefore returns the time before which objects, or decisions, are deleted, if the policy
+// deletes them.
+func (p DataRetentionPolicy) ExpiredBefore(now time.Time) *time.Time {
+	if p.MaxAgeDays == nil {
+		return nil
+	}
+	before := now.AddDate(0, 0, -*p.MaxAgeDays)
+	return &before
+}
+
+type CreateDataRetentionPolicyInput struct {
+	OrganizationId         uuid.UUID
+	Target                 DataRetentionTarget
+	TableId                *uuid.UUID
+	SupersededVersionsDays *int
+	MaxAgeDays             *int
+	Enabled                bool
+}
+
+// UpdateDataRetentionPolicyInput replaces the retention durations of a policy. A nil duration keeps
+// the data forever.
+type UpdateDataRetentionPolicyInput struct {
+	Id                     uuid.UUID
+	SupersededVersionsDays *int
+	MaxAgeDays             *int
+	Enabled                bool
+}
+
+// DataRetentionRun reports what a run of the retention job reclaimed for a policy.
+type DataRetentionRun struct {
+	Id                    uuid.UUID
+	OrganizationId        uuid.UUID
+	PolicyId              uuid.UUID
+	SupersededRowsDeleted int64
+	ExpiredRowsDeleted    int64
+	DecisionsDeleted      int64
+	SkippedReason         *string
+	Error                 *string
+	StartedAt             time.Time
+	FinishedAt            *time.Time
+}
+
+func (r DataRetentionRun) Reclaimed() int64 {
+	return r.SupersededRowsDeleted + r.ExpiredRowsDeleted + r.DecisionsDeleted
+}
+
+type DataRetentionDecision struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+	Outcome   string
+}
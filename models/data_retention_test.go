@@ -0,0 +1,75 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataRetentionPolicyValidate(t *testing.T) {
+	tableId := uuid.New()
+
+	tests := []struct {
+		name   string
+		policy DataRetentionPolicy
+		valid  bool
+	}{
+		{
+			name: "table with superseded versions",
+			policy: DataRetentionPolicy{
+				Target: DataRetentionTargetTable, TableId: &tableId, SupersededVersionsDays: ptr(400),
+			},
+			valid: true,
+		},
+		{
+			name:   "table without duration",
+			policy: DataRetentionPolicy{Target: DataRetentionTargetTable, TableId: &tableId},
+		},
+		{
+			name:   "table without table id",
+			policy: DataRetentionPolicy{Target: DataRetentionTargetTable, MaxAgeDays: ptr(30)},
+		},
+		{
+			name: "zero duration",
+			policy: DataRetentionPolicy{
+				Target: DataRetentionTargetTable, TableId: &tableId, MaxAgeDays: ptr(0),
+			},
+		},
+		{
+			name:   "decisions",
+			policy: DataRetentionPolicy{Target: DataRetentionTargetDecisions, MaxAgeDays: ptr(2555)},
+			valid:  true,
+		},
+		{
+			name: "decisions with superseded versions",
+			policy: DataRetentionPolicy{
+				Target: DataRetentionTargetDecisions, MaxAgeDays: ptr(2555), SupersededVersionsDays: ptr(1),
+			},
+		},
+		{
+			name:   "unknown target",
+			policy: DataRetentionPolicy{Target: "cases", MaxAgeDays: ptr(30)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, BadParameterError)
+			}
+		})
+	}
+}
+
+func TestDataRetentionPolicyHorizons(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	policy := DataRetentionPolicy{SupersededVersionsDays: ptr(400)}
+
+	assert.Equal(t, time.Date(2025, 9, 14, 12, 0, 0, 0, time.UTC), *policy.SupersededBefore(now))
+	assert.Nil(t, policy.ExpiredBefore(now))
+}
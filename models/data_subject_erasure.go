@@ -0,0 +1,253 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type DataSubjectErasureStatus string
+
+const (
+	DataSubjectErasurePending   DataSubjectErasureStatus = "pending"
+	DataSubjectErasureRunning   DataSubjectErasureStatus = "running"
+	DataSubjectErasureCompleted DataSubjectErasureStatus = "completed"
+	DataSubjectErasureFailed    DataSubjectErasureStatus = "failed"
+)
+
+func (s DataSubjectErasureStatus) IsTerminal() bool {
+	return s == DataSubjectErasureCompleted || s == DataSubjectErasureFailed
+}
+
+// DataSubjectErasureStage is the step an erasure is at. Every stage can be run again from the
+// start, so that an interrupted erasure resumes at the stage it was at.
+type DataSubjectErasureStage string
+
+const (
+	// Find the objects linked to the data subject and the pivot values identifying it
+	DataSubjectErasureStageCollect DataSubjectErasureStage = "collect"
+	// Pseudonymize the decisions, their rule executions and screenings, delete the offloaded blobs
+	DataSubjectErasureStageDecisions DataSubjectErasureStage = "decisions"
+	// Pseudonymize the continuous screenings of the objects and their matches, delete the offloaded blobs
+	DataSubjectErasureStageContinuousScreenings DataSubjectErasureStage = "continuous_screenings"
+	// Delete the annotations of the objects, and their files
+	DataSubjectErasureStageAnnotations DataSubjectErasureStage = "annotations"
+	// Pseudonymize the case comments mentioning the objects
+	DataSubjectErasureStageCaseComments DataSubjectErasureStage = "case_comments"
+	// Delete the ingested rows of the objects, history included, and their continuous screening registration
+	DataSubjectErasureStageIngestedData DataSubjectErasureStage = "ingested_data"
+	DataSubjectErasureStageDone         DataSubjectErasureStage = "done"
+)
+
+var dataSubjectErasureStages = []DataSubjectErasureStage{
+	DataSubjectErasureStageCollect,
+	DataSubjectErasureStageDecisions,
+	DataSubjectErasureStageContinuousScreenings,
+	DataSubjectErasureStageAnnotations,
+	DataSubjectErasureStageCaseComments,
+	DataSubjectErasureStageIngestedData,
+	DataSubjectErasureStageDone,
+}
+
+func (s DataSubjectErasureStage) Next() DataSubjectErasureStage {
+	idx := slices.Index(dataSubjectErasureStages, s)
+	if idx < 0 || idx == len(dataSubjectErasureStages)-1 {
+		return DataSubjectErasureStageDone
+	}
+	return dataSubjectErasureStages[idx+1]
+}
+
+const (
+	// Links are followed from the data subject to the objects referencing it, up to that depth
+	DataSubjectErasureMaxDepth = 3
+	// An erasure reaching more objects than this is refused, as it is most likely following a link
+	// to objects shared with other data subjects.
+	DataSubjectErasureMaxObjects = 10_000
+)
+
+// DataSubjectErasure removes the personal data of a data subject, an ingested object, from every
+// store: ingested rows in the client schema, decisions, screenings, continuous screenings, annotations,
+// case comments and the blobs offloaded for them. Data that must be kept, such as decisions, is
+// pseudonymized.
+//
+// Once completed or failed, the erasure only records what kind of data was erased and how much of
+// it: the id of the object and the targets are cleared.
+type DataSubjectErasure struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	ObjectType     string
+	ObjectId       *string
+	Status         DataSubjectErasureStatus
+	Stage          DataSubjectErasureStage
+	Targets        *DataSubjectErasureTargets
+	Counts         DataSubjectErasureCounts
+	Error          *string
+	CreatedBy      *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	CompletedAt    *time.Time
+}
+
+// Pseudonym replaces the identifiers of the data subject in the records that are kept.
+func (e DataSubjectErasure) Pseudonym() string {
+	return "erased:" + e.Id.String()
+}
+
+type DataSubjectErasureObject struct {
+	ObjectType string `json:"object_type"`
+	ObjectId   string `json:"object_id"`
+	// Number of links followed from the data subject to reach the object
+	Depth int `json:"depth"`
+}
+
+type DataSubjectErasurePivotValue struct {
+	PivotId uuid.UUID `json:"pivot_id"`
+	Value   string    `json:"value"`
+}
+
+// DataSubjectErasureTargets identifies the data to erase: the data subject, the objects linked to
+// it, and the pivot values it is known by in decisions.
+type DataSubjectErasureTargets struct {
+	Objects     []DataSubjectErasureObject     `json:"objects"`
+	PivotValues []DataSubjectErasurePivotValue `json:"pivot_values"`
+}
+
+func (t DataSubjectErasureTargets) ObjectIdsByType() map[string][]string {
+	ids := make(map[string][]string)
+	for _, object := range t.Objects {
+		ids[object.ObjectType] = append(ids[object.ObjectType], object.ObjectId)
+	}
+	return ids
+}
+
+func (t DataSubjectErasureTargets) PivotValuesById() map[uuid.UUID][]string {
+	values := make(map[uuid.UUID][]string)
+	for _, pivot := range t.PivotValues {
+		values[pivot.PivotId] = append(values[pivot.PivotId], pivot.Value)
+	}
+	return values
+}
+
+// Identifiers are the values replaced by the pseudonym in free text
+func (t DataSubjectErasureTargets) Identifiers() []string {
+	identifiers := make([]string, 0, len(t.Objects)+len(t.PivotValues))
+	for _, object := range t.Objects {
+		identifiers = append(identifiers, object.ObjectId)
+	}
+	for _, pivot := range t.PivotValues {
+		identifiers = append(identifiers, pivot.Value)
+	}
+	slices.Sort(identifiers)
+	return slices.Compact(identifiers)
+}
+
+type DataSubjectErasureCounts struct {
+	Objects          int64 `json:"objects"`
+	IngestedRows     int64 `json:"ingested_rows"`
+	MonitoredObjects int64 `json:"monitored_objects"`
+	Decisions        int64 `json:"decisions"`
+	Screenings       int64 `json:"screenings"`
+	ScreeningMatches int64 `json:"screening_matches"`
+	// Continuous screenings of the objects, and the matches on them
+	ContinuousScreenings       int64 `json:"continuous_screenings"`
+	ContinuousScreeningMatches int64 `json:"continuous_screening_matches"`
+	Annotations                int64 `json:"annotations"`
+	Files                      int64 `json:"files"`
+	CaseComments               int64 `json:"case_comments"`
+}
+
+type DataSubjectErasureReport struct {
+	// Set when the erasure was started, nil on a dry run
+	Erasure *DataSubjectErasure
+	Targets DataSubjectErasureTargets
+	Counts  DataSubjectErasureCounts
+}
+
+type CreateDataSubjectErasureInput struct {
+	OrganizationId uuid.UUID
+	ObjectType     string
+	ObjectId       string
+}
+
+type DataSubjectErasureDecision struct {
+	Id        uuid.UUID
+	CreatedAt time.Time
+	Outcome   string
+}
+
+type DataSubjectErasureDecisionRule struct {
+	DecisionId string
+	RuleId     string
+	Outcome    string
+}
+
+type DataSubjectErasureScreeningMatch struct {
+	Id          string
+	ScreeningId string
+}
+
+type DataSubjectErasureContinuousScreening struct {
+	Id uuid.UUID
+	// Whether one of the objects is screened. Otherwise a sanctioned entity is screened and one of
+	// the objects is among its matches.
+	OfTarget bool
+}
+
+type DataSubjectErasureContinuousScreeningMatch struct {
+	Id                    uuid.UUID
+	ContinuousScreeningId uuid.UUID
+}
+
+type DataSubjectErasureCaseComment struct {
+	Id   string
+	Note string
+}
+
+// ReplaceErasedIdentifiers replaces the identifiers found in a text by the pseudonym. Identifiers
+// are only replaced as whole tokens, so that an identifier contained in a longer one is left alone.
+// It returns the new text and the number of replacements.
+func ReplaceErasedIdentifiers(text string, identifiers []string, pseudonym string) (string, int) {
+	// Longer identifiers first, in case one contains another
+	sorted := slices.Clone(identifiers)
+	slices.SortFunc(sorted, func(a, b string) int { return len(b) - len(a) })
+
+	count := 0
+	for _, identifier := range sorted {
+		if identifier == "" {
+			continue
+		}
+
+		var b strings.Builder
+		rest := text
+		for {
+			idx := strings.Index(rest, identifier)
+			if idx < 0 {
+				b.WriteString(rest)
+				break
+			}
+
+			end := idx + len(identifier)
+			before, _ := utf8.DecodeLastRuneInString(rest[:idx])
+			after, _ := utf8.DecodeRuneInString(rest[end:])
+			if (idx > 0 && isIdentifierRune(before)) || (end < len(rest) && isIdentifierRune(after)) {
+				b.WriteString(rest[:end])
+			} else {
+				b.WriteString(rest[:idx])
+				b.WriteString(pseudonym)
+				count += 1
+			}
+			rest = rest[end:]
+		}
+		text = b.String()
+	}
+
+	return text, count
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
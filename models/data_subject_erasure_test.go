@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSubjectErasureStageNext(t *testing.T) {
+	assert.Equal(t, DataSubjectErasureStageDecisions, DataSubjectErasureStageCollect.Next())
+	assert.Equal(t, DataSubjectErasureStageContinuousScreenings, DataSubjectErasureStageDecisions.Next())
+	assert.Equal(t, DataSubjectErasureStageAnnotations, DataSubjectErasureStageContinuousScreenings.Next())
+	assert.Equal(t, DataSubjectErasureStageIngestedData, DataSubjectErasureStageCaseComments.Next())
+	assert.Equal(t, DataSubjectErasureStageDone, DataSubjectErasureStageIngestedData.Next())
+	assert.Equal(t, DataSubjectErasureStageDone, DataSubjectErasureStageDone.Next())
+	assert.Equal(t, DataSubjectErasureStageDone, DataSubjectErasureStage("unknown").Next())
+}
+
+func TestDataSubjectErasureTargetsIdentifiers(t *testing.T) {
+	targets := DataSubjectErasureTargets{
+		Objects: []DataSubjectErasureObject{
+			{ObjectType: "users", ObjectId: "u1"},
+			{ObjectType: "accounts", ObjectId: "a1", Depth: 1},
+		},
+		PivotValues: []DataSubjectErasurePivotValue{
+			{PivotId: uuid.New(), Value: "u1"},
+			{PivotId: uuid.New(), Value: "FR7630001007941234567890185"},
+		},
+	}
+
+	assert.Equal(t, []string{"FR7630001007941234567890185", "a1", "u1"}, targets.Identifiers())
+	assert.Equal(t, map[string][]string{"users": {"u1"}, "accounts": {"a1"}}, targets.ObjectIdsByType())
+}
+
+func TestReplaceErasedIdentifiers(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		identifiers []string
+		expected    string
+		count       int
+	}{
+		{
+			name:        "whole tokens are replaced",
+			text:        "Customer 1234 called about account acc-1234.",
+			identifiers: []string{"1234", "acc-1234"},
+			expected:    "Customer erased called about account erased.",
+			count:       2,
+		},
+		{
+			name:        "identifiers inside longer tokens are left alone",
+			text:        "See 12345 and x1234, not 1234",
+			identifiers: []string{"1234"},
+			expected:    "See 12345 and x1234, not erased",
+			count:       1,
+		},
+		{
+			name:        "repeated mentions",
+			text:        "user_1/user_1 (user_1)",
+			identifiers: []string{"user_1"},
+			expected:    "erased/erased (erased)",
+			count:       3,
+		},
+		{
+			name:        "empty identifiers are ignored",
+			text:        "nothing to see",
+			identifiers: []string{""},
+			expected:    "nothing to see",
+			count:       0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, count := ReplaceErasedIdentifiers(tt.text, tt.identifiers, "erased")
+			assert.Equal(t, tt.expected, text)
+			assert.Equal(t, tt.count, count)
+		})
+	}
+}
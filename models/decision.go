@@ -33,6 +33,7 @@ type Decision struct {
 	Score                int
 	ScheduledExecutionId *string
 	ScenarioIterationId  uuid.UUID
+	RolloutArm           RolloutArm
 }
 
 const (
@@ -87,6 +88,7 @@ type ScenarioExecution struct {
 	Outcome             Outcome
 	OrganizationId      uuid.UUID
 	TestRunId           string
+	RolloutArm          RolloutArm
 
 	ExecutionMetrics *ScenarioExecutionMetrics
 }
@@ -109,11 +111,13 @@ type RuleExecution struct {
 	DecisionId          string
 	ExecutionError      ast.ExecutionError
 	Evaluation          *ast.NodeEvaluationDto
-	Outcome             string // enum: hit, no_hit, snoozed, error
+	Outcome             string // enum: hit, no_hit, snoozed, throttled, error
 	Result              bool
 	ResultScoreModifier int
 	Rule                Rule
 	Duration            time.Duration
+	// The snooze that applied to the rule, when the outcome is snoozed
+	RuleSnoozeId *string
 }
 
 func AdaptScenarExecToDecision(scenarioExecution ScenarioExecution, clientObject ClientObject, scheduledExecutionId *string) DecisionWithRuleExecutions {
@@ -141,6 +145,7 @@ func AdaptScenarExecToDecision(scenarioExecution ScenarioExecution, clientObject
 			ScenarioVersion:      scenarioExecution.ScenarioVersion,
 			ScheduledExecutionId: scheduledExecutionId,
 			Score:                scenarioExecution.Score,
+			RolloutArm:           scenarioExecution.RolloutArm,
 		},
 		RuleExecutions: scenarioExecution.RuleExecutions,
 		ScreeningExecutions: pure_utils.Map(scenarioExecution.ScreeningExecutions,
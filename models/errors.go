@@ -57,6 +57,10 @@ var (
 	ErrDataPreparationServiceUnavailable = errors.Wrap(
 		ConflictError,
 		"data preparation service is unavailable: an index is being created in the client db schema")
+	ErrScenarioPublicationRequiresApproval = errors.Wrap(ForbiddenError,
+		"the organization requires publications to be approved: submit a publication request")
+	ErrScenarioPublicationRequestNotPending = errors.Wrap(ConflictError,
+		"the publication request is not pending")
 
 	// execution
 	ErrScenarioHasNoLiveVersion                       = errors.Wrap(BadParameterError, "scenario has no live version")
@@ -12,6 +12,7 @@ const (
 	FollowTheMoneyEntityOrganization FollowTheMoneyEntity = "Organization"
 	FollowTheMoneyEntityVessel       FollowTheMoneyEntity = "Vessel"
 	FollowTheMoneyEntityAirplane     FollowTheMoneyEntity = "Airplane"
+	FollowTheMoneyEntityCryptoWallet FollowTheMoneyEntity = "CryptoWallet"
 )
 
 func FollowTheMoneyEntityFrom(s string) FollowTheMoneyEntity {
@@ -26,6 +27,8 @@ func FollowTheMoneyEntityFrom(s string) FollowTheMoneyEntity {
 		return FollowTheMoneyEntityVessel
 	case "Airplane":
 		return FollowTheMoneyEntityAirplane
+	case "CryptoWallet":
+		return FollowTheMoneyEntityCryptoWallet
 	default:
 		return FollowTheMoneyEntityUnknown
 	}
@@ -65,6 +68,8 @@ const (
 	FollowTheMoneyPropertyMainCountry          FollowTheMoneyProperty = "mainCountry"
 	FollowTheMoneyPropertyFlag                 FollowTheMoneyProperty = "flag"
 	FollowTheMoneyPropertyNotes                FollowTheMoneyProperty = "notes"
+	FollowTheMoneyPropertyPublicKey            FollowTheMoneyProperty = "publicKey"
+	FollowTheMoneyPropertyCurrency             FollowTheMoneyProperty = "currency"
 )
 
 func FollowTheMoneyPropertyFrom(s string) FollowTheMoneyProperty {
@@ -115,6 +120,10 @@ func FollowTheMoneyPropertyFrom(s string) FollowTheMoneyProperty {
 		return FollowTheMoneyPropertyFlag
 	case "notes":
 		return FollowTheMoneyPropertyNotes
+	case "publicKey":
+		return FollowTheMoneyPropertyPublicKey
+	case "currency":
+		return FollowTheMoneyPropertyCurrency
 	default:
 		return FollowTheMoneyPropertyUnknown
 	}
@@ -175,4 +184,8 @@ var FollowTheMoneyEntityProperties = map[FollowTheMoneyEntity][]FollowTheMoneyPr
 		FollowTheMoneyPropertyRegistrationNumber,
 		FollowTheMoneyPropertyCountry,
 	},
+	FollowTheMoneyEntityCryptoWallet: {
+		FollowTheMoneyPropertyPublicKey,
+		FollowTheMoneyPropertyCurrency,
+	},
 }
@@ -11,6 +11,9 @@ type IngestionOptions struct {
 type IngestionResult struct {
 	PreviousInternalId string
 	NewInternalId      string
+	// Among the fields the ingestion was asked to compare, those whose value differs from the
+	// previous version of the object
+	ChangedFields []string
 }
 
 type IngestionResults map[string]IngestionResult
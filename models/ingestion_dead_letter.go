@@ -0,0 +1,133 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+// IngestionDeadLetterSource is the path through which a rejected object was sent to Marble.
+type IngestionDeadLetterSource string
+
+const (
+	IngestionDeadLetterSourceApi  IngestionDeadLetterSource = "api"
+	IngestionDeadLetterSourceFile IngestionDeadLetterSource = "file"
+)
+
+// IngestionDeadLetterFormat tells how the payload of a dead letter is parsed when it is replayed.
+type IngestionDeadLetterFormat string
+
+const (
+	// A JSON object parsed like the payloads of the ingestion API
+	IngestionDeadLetterFormatJson IngestionDeadLetterFormat = "json"
+	// A JSON object mapping the columns of a CSV row to their raw text values
+	IngestionDeadLetterFormatCsv IngestionDeadLetterFormat = "csv"
+)
+
+type IngestionDeadLetterStatus string
+
+const (
+	IngestionDeadLetterPending  IngestionDeadLetterStatus = "pending"
+	IngestionDeadLetterReplayed IngestionDeadLetterStatus = "replayed"
+)
+
+// IngestionDeadLetter is an object rejected at ingestion, kept with the reason it was rejected so
+// that it can be fixed and replayed.
+type IngestionDeadLetter struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	ObjectType     string
+	// Nil if the payload was rejected before its object_id could be read
+	ObjectId      *string
+	Source        IngestionDeadLetterSource
+	PayloadFormat IngestionDeadLetterFormat
+	Payload       json.RawMessage
+	// Whether the payload is a partial update of an existing object
+	IsPatch          bool
+	Error            string
+	FieldErrors      IngestionValidationErrorsSingle
+	UploadLogId      *uuid.UUID
+	IngestionOptions IngestionOptions
+	Status           IngestionDeadLetterStatus
+	ReplayCount      int
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	ReplayedAt       *time.Time
+}
+
+type IngestionDeadLetterFilters struct {
+	ObjectType  *string
+	Status      *IngestionDeadLetterStatus
+	UploadLogId *uuid.UUID
+}
+
+// IngestionDeadLetterReplayReport sums up a bulk replay. Dead letters that still failed are left
+// pending and are tried after the others by the next bulk replay.
+type IngestionDeadLetterReplayReport struct {
+	Replayed int
+	Failed   int
+	HasMore  bool
+}
+
+// NewIngestionDeadLetter builds the dead letter of a rejected payload, reading the field errors out
+// of the rejection error when it is a validation error.
+func NewIngestionDeadLetter(
+	organizationId uuid.UUID,
+	objectType string,
+	source IngestionDeadLetterSource,
+	format IngestionDeadLetterFormat,
+	payload json.RawMessage,
+	rejection error,
+	ingestionOptions IngestionOptions,
+) IngestionDeadLetter {
+	deadLetter := IngestionDeadLetter{
+		Id:               pure_utils.NewId(),
+		OrganizationId:   organizationId,
+		ObjectType:       objectType,
+		Source:           source,
+		PayloadFormat:    format,
+		IngestionOptions: ingestionOptions,
+		Status:           IngestionDeadLetterPending,
+	}
+	deadLetter.SetPayload(payload)
+	deadLetter.SetRejection(rejection)
+	return deadLetter
+}
+
+// SetRejection records the reason the payload was rejected.
+func (d *IngestionDeadLetter) SetRejection(rejection error) {
+	d.Error = rejection.Error()
+	d.FieldErrors = IngestionValidationErrorsSingle{}
+
+	var validationErrors IngestionValidationErrors
+	var singleValidationErrors IngestionValidationErrorsSingle
+	switch {
+	case errors.As(rejection, &validationErrors):
+		for _, fieldErrors := range validationErrors {
+			for field, message := range fieldErrors {
+				d.FieldErrors[field] = message
+			}
+		}
+	case errors.As(rejection, &singleValidationErrors):
+		for field, message := range singleValidationErrors {
+			d.FieldErrors[field] = message
+		}
+	}
+}
+
+// SetPayload replaces the payload, reading the object_id out of it if it has one.
+func (d *IngestionDeadLetter) SetPayload(payload json.RawMessage) {
+	d.Payload = payload
+	d.ObjectId = nil
+
+	var object map[string]any
+	if err := json.Unmarshal(payload, &object); err != nil {
+		return
+	}
+	if objectId, ok := object["object_id"].(string); ok && objectId != "" {
+		d.ObjectId = &objectId
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIngestionDeadLetter(t *testing.T) {
+	orgId := uuid.New()
+
+	deadLetter := NewIngestionDeadLetter(orgId, "transactions", IngestionDeadLetterSourceApi,
+		IngestionDeadLetterFormatJson, json.RawMessage(`{"object_id": "tx-1", "amount": "abc"}`),
+		errors.Wrap(IngestionValidationErrors{"tx-1": {"amount": "expected a number"}}, "invalid payload"),
+		IngestionOptions{ShouldMonitor: true})
+
+	assert.NotEqual(t, uuid.Nil, deadLetter.Id)
+	assert.Equal(t, IngestionDeadLetterPending, deadLetter.Status)
+	assert.Equal(t, "tx-1", *deadLetter.ObjectId)
+	assert.Equal(t, IngestionValidationErrorsSingle{"amount": "expected a number"}, deadLetter.FieldErrors)
+	assert.Contains(t, deadLetter.Error, "invalid payload")
+	assert.True(t, deadLetter.IngestionOptions.ShouldMonitor)
+
+	deadLetter.SetRejection(errors.New("table not found"))
+	assert.Equal(t, "table not found", deadLetter.Error)
+	assert.Empty(t, deadLetter.FieldErrors)
+
+	deadLetter.SetPayload(json.RawMessage(`"not an object"`))
+	assert.Nil(t, deadLetter.ObjectId)
+}
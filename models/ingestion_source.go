@@ -0,0 +1,224 @@
+package models
+
+import (
+	"maps"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+// Blob storage schemes an ingestion source can point at, as understood by gocloud.dev/blob.
+var ingestionSourceSchemes = []string{"gs", "s3", "azblob", "file"}
+
+// IngestionSource is a blob storage prefix watched for new files, which are ingested through the
+// upload log pipeline as if they had been uploaded by the organization.
+type IngestionSource struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	Name           string
+	// gocloud.dev/blob url of the bucket, e.g. s3://bucket?region=eu-west-3. It must be one of the
+	// bucket urls allowed on the instance, as is.
+	BucketUrl string
+	// Only the files whose key starts with the prefix are considered. The prefix is relative to the
+	// directory of the organization in the bucket, see BucketPrefix.
+	Prefix string
+	// The first mapping whose pattern matches a file decides which table it is ingested into.
+	// Files matching no mapping are ignored.
+	FileMappings []IngestionSourceFileMapping
+	Enabled      bool
+	// The user who last configured the source, whom the files picked up from it are uploaded as
+	ConfiguredBy uuid.UUID
+	LastPolledAt *time.Time
+	// Why the last poll of the source failed, nil if it succeeded
+	LastError *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IngestionSourceFileMapping routes the files of a source to a table of the data model.
+//   - Pattern: a path.Match pattern, matched against the key of the file relative to the prefix
+//     of the source, e.g. "accounts_*.csv".
+//   - FileFormat: format of the files, inferred from their extension if empty.
+type IngestionSourceFileMapping struct {
+	Pattern    string
+	TableName  string
+	FileFormat IngestionFileFormat
+}
+
+type CreateIngestionSourceInput struct {
+	OrganizationId uuid.UUID
+	Name           string
+	BucketUrl      string
+	Prefix         string
+	FileMappings   []IngestionSourceFileMapping
+	Enabled        bool
+}
+
+type UpdateIngestionSourceInput struct {
+	Id           uuid.UUID
+	Name         string
+	BucketUrl    string
+	Prefix       string
+	FileMappings []IngestionSourceFileMapping
+	Enabled      bool
+	ConfiguredBy uuid.UUID
+}
+
+func (s IngestionSource) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return errors.Wrap(BadParameterError, "name is required")
+	}
+
+	bucketUrl, err := url.Parse(s.BucketUrl)
+	if err != nil || bucketUrl.Scheme == "" {
+		return errors.Wrapf(BadParameterError, "invalid bucket url %q", s.BucketUrl)
+	}
+	if !slices.Contains(ingestionSourceSchemes, bucketUrl.Scheme) {
+		return errors.Wrapf(BadParameterError, "bucket url scheme must be one of %s",
+			strings.Join(ingestionSourceSchemes, ", "))
+	}
+
+	if strings.HasPrefix(s.Prefix, "/") || slices.Contains(strings.Split(s.Prefix, "/"), "..") {
+		return errors.Wrapf(BadParameterError, "invalid prefix %q", s.Prefix)
+	}
+
+	if len(s.FileMappings) == 0 {
+		return errors.Wrap(BadParameterError, "at least one file mapping is required")
+	}
+	for _, mapping := range s.FileMappings {
+		if mapping.Pattern == "" {
+			return errors.Wrap(BadParameterError, "file mapping pattern is required")
+		}
+		if _, err := path.Match(mapping.Pattern, ""); err != nil {
+			return errors.Wrapf(BadParameterError, "invalid file mapping pattern %q", mapping.Pattern)
+		}
+		if mapping.TableName == "" {
+			return errors.Wrap(BadParameterError, "file mapping table_name is required")
+		}
+		switch mapping.FileFormat {
+		case "", IngestionFileFormatCsv, IngestionFileFormatNdjson, IngestionFileFormatParquet:
+		default:
+			return errors.Wrapf(BadParameterError, "invalid file format %q", mapping.FileFormat)
+		}
+	}
+
+	return nil
+}
+
+// BucketPrefix is the prefix of the keys of the files of the source in its bucket. Buckets are shared
+// by the organizations of the instance, each organization only reads from its own directory.
+func (s IngestionSource) BucketPrefix() string {
+	return s.OrganizationId.String() + "/" + s.Prefix
+}
+
+// SameBlobBucket reports whether two bucket urls point at the same bucket with the same query
+// parameters. The parameters are options of the driver, such as the endpoint of the bucket, so a url
+// differing by them does not read from the same place.
+func SameBlobBucket(bucketUrl, otherUrl string) bool {
+	bucket, ok := blobBucketLocation(bucketUrl)
+	if !ok {
+		return false
+	}
+	other, ok := blobBucketLocation(otherUrl)
+	if !ok || bucket != other {
+		return false
+	}
+
+	bucketQuery, err := blobBucketQuery(bucketUrl)
+	if err != nil {
+		return false
+	}
+	otherQuery, err := blobBucketQuery(otherUrl)
+	if err != nil {
+		return false
+	}
+	return maps.EqualFunc(bucketQuery, otherQuery, slices.Equal)
+}
+
+func blobBucketQuery(bucketUrl string) (url.Values, error) {
+	u, err := url.Parse(bucketUrl)
+	if err != nil {
+		return nil, err
+	}
+	return url.ParseQuery(u.RawQuery)
+}
+
+// BlobBucketWithin reports whether the bucket url points at the same bucket as parentUrl, or at a
+// directory under it for file:// urls. Query parameters, such as the region, are ignored.
+func BlobBucketWithin(bucketUrl, parentUrl string) bool {
+	bucket, ok := blobBucketLocation(bucketUrl)
+	if !ok {
+		return false
+	}
+	parent, ok := blobBucketLocation(parentUrl)
+	if !ok {
+		return false
+	}
+	return bucket == parent || strings.HasPrefix(bucket, strings.TrimSuffix(parent, "/")+"/")
+}
+
+// blobBucketLocation returns the scheme, bucket and path of a bucket url, e.g. s3://extracts for
+// s3://extracts?region=eu-west-3.
+func blobBucketLocation(bucketUrl string) (string, bool) {
+	u, err := url.Parse(bucketUrl)
+	if err != nil || u.Scheme == "" {
+		return "", false
+	}
+	location := u.Scheme + "://" + u.Host
+	if p := strings.TrimSuffix(u.Path, "/"); p != "" {
+		location += path.Clean(p)
+	}
+	return location, true
+}
+
+// MatchFile returns the mapping of the file with the given key, and the format it is read with. The
+// format is empty if the mapping does not set it and it cannot be inferred from the extension.
+func (s IngestionSource) MatchFile(key string) (IngestionSourceFileMapping, IngestionFileFormat, bool) {
+	relativeKey := strings.TrimPrefix(strings.TrimPrefix(key, s.BucketPrefix()), "/")
+
+	for _, mapping := range s.FileMappings {
+		if ok, _ := path.Match(mapping.Pattern, relativeKey); !ok {
+			continue
+		}
+		if mapping.FileFormat != "" {
+			return mapping, mapping.FileFormat, true
+		}
+		return mapping, ingestionFileFormatFromExtension(key), true
+	}
+
+	return IngestionSourceFileMapping{}, "", false
+}
+
+func ingestionFileFormatFromExtension(key string) IngestionFileFormat {
+	switch strings.ToLower(path.Ext(key)) {
+	case ".csv":
+		return IngestionFileFormatCsv
+	case ".ndjson", ".jsonl":
+		return IngestionFileFormatNdjson
+	case ".parquet":
+		return IngestionFileFormatParquet
+	}
+	return ""
+}
+
+// IngestionSourceFile is a version of a file picked up from an ingestion source, identified by its
+// key and ETag so that it is ingested once, and again only if it is replaced.
+type IngestionSourceFile struct {
+	Id             uuid.UUID
+	OrganizationId uuid.UUID
+	SourceId       uuid.UUID
+	Key            string
+	ETag           string
+	Size           int64
+	TableName      string
+	// The upload log the file is ingested through, nil if it was not ingested
+	UploadLogId *uuid.UUID
+	// Why the file was not ingested
+	Error     *string
+	CreatedAt time.Time
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestionSourceValidate(t *testing.T) {
+	valid := IngestionSource{
+		Name:      "core banking",
+		BucketUrl: "s3://extracts?region=eu-west-3",
+		FileMappings: []IngestionSourceFileMapping{
+			{Pattern: "accounts_*.csv", TableName: "accounts"},
+		},
+	}
+	assert.NoError(t, valid.Validate())
+
+	invalid := map[string]func(s *IngestionSource){
+		"missing name":         func(s *IngestionSource) { s.Name = " " },
+		"relative bucket url":  func(s *IngestionSource) { s.BucketUrl = "extracts" },
+		"unsupported scheme":   func(s *IngestionSource) { s.BucketUrl = "mem://" },
+		"no mapping":           func(s *IngestionSource) { s.FileMappings = nil },
+		"bad pattern":          func(s *IngestionSource) { s.FileMappings[0].Pattern = "accounts_[.csv" },
+		"missing table":        func(s *IngestionSource) { s.FileMappings[0].TableName = "" },
+		"unsupported format":   func(s *IngestionSource) { s.FileMappings[0].FileFormat = "xlsx" },
+		"missing file pattern": func(s *IngestionSource) { s.FileMappings[0].Pattern = "" },
+		"absolute prefix":      func(s *IngestionSource) { s.Prefix = "/extracts/" },
+		"parent prefix":        func(s *IngestionSource) { s.Prefix = "extracts/../../" },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			source := valid
+			source.FileMappings = append([]IngestionSourceFileMapping{}, valid.FileMappings...)
+			mutate(&source)
+			assert.ErrorIs(t, source.Validate(), BadParameterError)
+		})
+	}
+}
+
+func TestIngestionSourceMatchFile(t *testing.T) {
+	source := IngestionSource{
+		OrganizationId: uuid.MustParse("0193b6a8-7a3f-7c4e-9f36-2b1c8d7e4a10"),
+		Prefix:         "extracts/",
+		FileMappings: []IngestionSourceFileMapping{
+			{Pattern: "accounts_*", TableName: "accounts"},
+			{Pattern: "*.dat", TableName: "transactions", FileFormat: IngestionFileFormatNdjson},
+		},
+	}
+	assert.Equal(t, "0193b6a8-7a3f-7c4e-9f36-2b1c8d7e4a10/extracts/", source.BucketPrefix())
+
+	mapping, format, ok := source.MatchFile(source.BucketPrefix() + "accounts_20261019.parquet")
+	assert.True(t, ok)
+	assert.Equal(t, "accounts", mapping.TableName)
+	assert.Equal(t, IngestionFileFormatParquet, format)
+
+	mapping, format, ok = source.MatchFile(source.BucketPrefix() + "transactions.dat")
+	assert.True(t, ok)
+	assert.Equal(t, "transactions", mapping.TableName)
+	assert.Equal(t, IngestionFileFormatNdjson, format)
+
+	_, format, ok = source.MatchFile(source.BucketPrefix() + "accounts_20261019.xlsx")
+	assert.True(t, ok)
+	assert.Empty(t, format)
+
+	_, _, ok = source.MatchFile(source.BucketPrefix() + "archive/accounts_20261019.csv")
+	assert.False(t, ok, "patterns do not match across directories")
+}
+
+func TestBlobBucketWithin(t *testing.T) {
+	assert.True(t, BlobBucketWithin("s3://extracts?region=eu-west-3", "s3://extracts"))
+	assert.True(t, BlobBucketWithin("s3://extracts", "s3://extracts?region=eu-west-3"))
+	assert.False(t, BlobBucketWithin("s3://extracts-other", "s3://extracts"))
+	assert.False(t, BlobBucketWithin("gs://extracts", "s3://extracts"))
+
+	assert.True(t, BlobBucketWithin("file:///data/extracts/bank", "file:///data/extracts/"))
+	assert.False(t, BlobBucketWithin("file:///data/extracts-other", "file:///data/extracts"))
+	assert.False(t, BlobBucketWithin("file:///data/extracts/../ingestion", "file:///data/extracts"))
+	assert.False(t, BlobBucketWithin("extracts", "file:///data/extracts"))
+}
+
+func TestSameBlobBucket(t *testing.T) {
+	assert.True(t, SameBlobBucket("s3://extracts?region=eu-west-3", "s3://extracts?region=eu-west-3"))
+	assert.True(t, SameBlobBucket("s3://extracts/?a=1&b=2", "s3://extracts?b=2&a=1"))
+	assert.False(t, SameBlobBucket("s3://extracts?endpoint=https://attacker.example", "s3://extracts"))
+	assert.False(t, SameBlobBucket("s3://extracts", "s3://extracts?region=eu-west-3"))
+	assert.False(t, SameBlobBucket("s3://extracts-other", "s3://extracts"))
+	assert.False(t, SameBlobBucket("file:///data/extracts/bank", "file:///data/extracts"))
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/adhocore/gronx"
@@ -112,6 +114,40 @@ func (q *OpenSanctionsCheckQuery) SetName(name string) {
 // 	return fmt.Sprintf("%s (%s)", q.Type, m)
 // }
 
+// WithNormalizedNames returns a copy of the query where the name of every subquery is expanded with
+// its normalized variants, if the organization enabled name normalization. The query itself, which is
+// stored with the screening, is left untouched. Applying it more than once is harmless.
+func (q OpenSanctionsQuery) WithNormalizedNames() OpenSanctionsQuery {
+	if !q.OrgConfig.NameNormalization {
+		return q
+	}
+
+	normalizer := q.OrgConfig.NameNormalizer()
+	q.Queries = slices.Clone(q.Queries)
+
+	for idx, subquery := range q.Queries {
+		names, ok := subquery.Filters["name"]
+		if !ok || len(names) == 0 {
+			continue
+		}
+
+		filters := maps.Clone(subquery.Filters)
+		filters["name"] = make([]string, 0, len(names))
+
+		for _, name := range names {
+			for _, variant := range normalizer.Variants(name) {
+				if !slices.Contains(filters["name"], variant) {
+					filters["name"] = append(filters["name"], variant)
+				}
+			}
+		}
+
+		q.Queries[idx].Filters = filters
+	}
+
+	return q
+}
+
 type OpenSanctionsFilter map[string][]string
 
 var OPEN_SANCTIONS_ABSTRACT_TYPES_MAPPING = map[string][]string{
@@ -44,3 +44,23 @@ func TestOpenSanctionsAbstractTypeMapping(t *testing.T) {
 		assert.True(t, valuesEqual)
 	}
 }
+
+func TestOpenSanctionsQueryNormalizeNames(t *testing.T) {
+	original := []OpenSanctionsCheckQuery{
+		{Type: "Organization", Filters: OpenSanctionsFilter{"name": {"ООО Ромашка"}, "country": {"ru"}}},
+		{Type: "Thing", Filters: OpenSanctionsFilter{"idNumber": {"123"}}},
+	}
+
+	query := OpenSanctionsQuery{Queries: original}
+	assert.Equal(t, []string{"ООО Ромашка"}, query.WithNormalizedNames().Queries[0].Filters["name"])
+
+	query.OrgConfig.NameNormalization = true
+	normalized := query.WithNormalizedNames().WithNormalizedNames()
+
+	assert.Equal(t, []string{"ООО Ромашка", "Ромашка", "OOO Romashka", "Romashka"},
+		normalized.Queries[0].Filters["name"])
+	assert.Equal(t, []string{"ru"}, normalized.Queries[0].Filters["country"])
+	assert.Equal(t, OpenSanctionsFilter{"idNumber": {"123"}}, normalized.Queries[1].Filters)
+	assert.Equal(t, []string{"ООО Ромашка"}, query.Queries[0].Filters["name"])
+	assert.Equal(t, []string{"ООО Ромашка"}, original[0].Filters["name"])
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"net"
 
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/google/uuid"
 )
 
@@ -60,6 +61,10 @@ type Organization struct {
 	// Flag to enable Sentry session replay capture for this organization (used for test orgs).
 	SentryReplayEnabled bool
 
+	// Flag to require publications of scenario iterations to be approved by a second person, through
+	// a publication request.
+	RequirePublicationApproval bool
+
 	// Environment of the organization (production or demo). Used to skip Sentry cron monitoring for demo orgs.
 	Environment OrganizationEnvironment
 }
@@ -76,12 +81,23 @@ type OrganizationOpenSanctionsConfig struct {
 	Providers      map[ScreeningFeature]ScreeningProvider
 	MatchThreshold int
 	MatchLimit     int
+
+	// When enabled, names sent to the screening provider are expanded with their transliterated
+	// variants, stripped of honorifics, legal forms and the words of NameStoplist.
+	NameNormalization bool
+	NameStoplist      []string
+}
+
+func (cfg OrganizationOpenSanctionsConfig) NameNormalizer() pure_utils.NameNormalizer {
+	return pure_utils.NewNameNormalizer(cfg.NameStoplist)
 }
 
 type OrganizationOpenSanctionsConfigUpdateInput struct {
-	Providers      map[string]ScreeningProvider
-	MatchThreshold *int
-	MatchLimit     *int
+	Providers         map[string]ScreeningProvider
+	MatchThreshold    *int
+	MatchLimit        *int
+	NameNormalization *bool
+	NameStoplist      *[]string
 }
 
 type CreateOrganizationInput struct {
@@ -90,11 +106,12 @@ type CreateOrganizationInput struct {
 }
 
 type UpdateOrganizationInput struct {
-	DefaultScenarioTimezone *string
-	ScreeningConfig         OrganizationOpenSanctionsConfigUpdateInput
-	AutoAssignQueueLimit    *int
-	SentryReplayEnabled     *bool
-	Environment             *OrganizationEnvironment
+	DefaultScenarioTimezone    *string
+	ScreeningConfig            OrganizationOpenSanctionsConfigUpdateInput
+	AutoAssignQueueLimit       *int
+	SentryReplayEnabled        *bool
+	RequirePublicationApproval *bool
+	Environment                *OrganizationEnvironment
 }
 
 type SeedOrgConfiguration struct {
@@ -3,6 +3,7 @@ package models
 import (
 	"encoding/json"
 	"reflect"
+	"time"
 )
 
 type DbFieldReadParams struct {
@@ -11,6 +12,8 @@ type DbFieldReadParams struct {
 	FieldName        string
 	DataModel        DataModel
 	ClientObject     ClientObject
+	// Read the version of the rows valid at that time instead of the live version
+	AsOf *time.Time
 }
 
 type MissingField struct {
@@ -207,6 +207,22 @@ func (ContinuousScreeningCreateFullDatasetArgs) Kind() string {
 	return "continuous_screening_create_full_dataset"
 }
 
+type ContinuousScreeningAttestationReportArgs struct {
+	OrgId uuid.UUID `json:"org_id"`
+}
+
+func (ContinuousScreeningAttestationReportArgs) Kind() string {
+	return "continuous_screening_attestation_report"
+}
+
+type ContinuousScreeningCoverageAnalysisArgs struct {
+	OrgId uuid.UUID `json:"org_id"`
+}
+
+func (ContinuousScreeningCoverageAnalysisArgs) Kind() string {
+	return "continuous_screening_coverage_analysis"
+}
+
 // Scheduled scenario periodic job - checks and schedules due scenarios for an org
 type ScheduledScenarioArgs struct {
 	OrgId uuid.UUID `json:"org_id"`
@@ -293,6 +309,11 @@ func (AsyncDecisionExecutionCleanupArgs) Kind() string {
 	return "async_decision_execution_cleanup"
 }
 
+// IngestionDeadLetterCleanupArgs - Cleanup rejected ingestion payloads past their retention period
+type IngestionDeadLetterCleanupArgs struct{}
+
+func (IngestionDeadLetterCleanupArgs) Kind() string { return "ingestion_dead_letter_cleanup" }
+
 type RulesetDryRunArgs struct {
 	OrgId     uuid.UUID `json:"org_id"`
 	RulesetId uuid.UUID `json:"ruleset_id"`
@@ -318,6 +339,8 @@ type AsyncUploadArgs struct {
 	ObjectType       string           `json:"object_type"`
 	Key              string           `json:"key"`
 	IngestionOptions IngestionOptions `json:"ingestion_options"`
+	// Empty for jobs enqueued before NDJSON and Parquet support, which are CSV uploads
+	FileFormat IngestionFileFormat `json:"file_format,omitempty"`
 }
 
 func (AsyncUploadArgs) Kind() string { return "async_upload" }
@@ -327,3 +350,62 @@ type RuleDescriptionArgs struct {
 }
 
 func (RuleDescriptionArgs) Kind() string { return "rule_description" }
+
+type FieldTypeMigrationArgs struct {
+	OrgId       uuid.UUID `json:"org_id"`
+	MigrationId uuid.UUID `json:"migration_id"`
+}
+
+func (FieldTypeMigrationArgs) Kind() string { return "field_type_migration" }
+
+type DerivedFieldBackfillArgs struct {
+	OrgId      uuid.UUID `json:"org_id"`
+	BackfillId uuid.UUID `json:"backfill_id"`
+}
+
+func (DerivedFieldBackfillArgs) Kind() string { return "derived_field_backfill" }
+
+type DataSubjectErasureArgs struct {
+	OrgId     uuid.UUID `json:"org_id"`
+	ErasureId uuid.UUID `json:"erasure_id"`
+}
+
+func (DataSubjectErasureArgs) Kind() string { return "data_subject_erasure" }
+
+type ScenarioBacktestArgs struct {
+	OrgId      uuid.UUID `json:"org_id"`
+	BacktestId uuid.UUID `json:"backtest_id"`
+}
+
+func (ScenarioBacktestArgs) Kind() string { return "scenario_backtest" }
+
+type ScenarioPublicationRequestArgs struct {
+	OrgId     uuid.UUID `json:"org_id"`
+	RequestId uuid.UUID `json:"request_id"`
+}
+
+func (ScenarioPublicationRequestArgs) Kind() string { return "scenario_publication_request" }
+
+type ScenarioPublicationScheduleArgs struct {
+	OrgId uuid.UUID `json:"org_id"`
+}
+
+func (ScenarioPublicationScheduleArgs) Kind() string { return "scenario_publication_schedule" }
+
+type ScreeningWhitelistExpiryArgs struct {
+	OrgId uuid.UUID `json:"org_id"`
+}
+
+func (ScreeningWhitelistExpiryArgs) Kind() string { return "screening_whitelist_expiry" }
+
+type DataRetentionArgs struct {
+	OrgId uuid.UUID `json:"org_id"`
+}
+
+func (DataRetentionArgs) Kind() string { return "data_retention" }
+
+type IngestionSourcePollArgs struct {
+	OrgId uuid.UUID `json:"org_id"`
+}
+
+func (IngestionSourcePollArgs) Kind() string { return "ingestion_source_poll" }
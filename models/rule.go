@@ -25,6 +25,11 @@ type Rule struct {
 	RuleGroup            string
 	SnoozeGroupId        *string
 	StableRuleId         string
+
+	// When positive, at most one hit of the rule is counted per throttle key (see RuleThrottleKey)
+	// over this period. The other hits are recorded as throttled and do not count in the score.
+	ThrottlePeriod time.Duration
+	ThrottleScope  RuleThrottleScope
 }
 
 func (r Rule) ToMetadata() RuleMetadata {
@@ -83,6 +88,8 @@ type CreateRuleInput struct {
 	RuleGroup            string
 	SnoozeGroupId        *string
 	StableRuleId         string
+	ThrottlePeriod       time.Duration
+	ThrottleScope        RuleThrottleScope
 }
 
 type UpdateRuleInput struct {
@@ -96,6 +103,8 @@ type UpdateRuleInput struct {
 	RuleGroup            *string
 	SnoozeGroupId        *string
 	StableRuleId         *string
+	ThrottlePeriod       *time.Duration
+	ThrottleScope        *RuleThrottleScope
 }
 
 type AiRuleDescription struct {
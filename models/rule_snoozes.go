@@ -1,8 +1,11 @@
 package models
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/checkmarble/marble-backend/models/ast"
 	"github.com/google/uuid"
 )
 
@@ -18,10 +21,21 @@ type RuleSnooze struct {
 	CreatedByUser         *string
 	CreatedFromDecisionId *string
 	CreatedFromRuleId     string
-	PivotValue            string
-	SnoozeGroupId         string
-	StartsAt              time.Time
-	ExpiresAt             time.Time
+	// PivotValue is the value the snooze applies to: the pivot value of the decisions, or the value of
+	// KeyField on their trigger object if it is set.
+	PivotValue    string
+	KeyField      *string
+	SnoozeGroupId string
+	// The snooze does not apply to the decisions for which UnlessCondition is true. The condition is
+	// evaluated on each decision, so a threshold relative to the decision the snooze was created from (e.g.
+	// twice its amount) must be written in it as a constant.
+	UnlessCondition *ast.Node
+	StartsAt        time.Time
+	ExpiresAt       time.Time
+}
+
+func (s RuleSnooze) Key() RuleSnoozeKey {
+	return RuleSnoozeKey{Field: s.KeyField, Value: s.PivotValue}
 }
 
 type RuleSnoozeWithRuleId struct {
@@ -30,12 +44,90 @@ type RuleSnoozeWithRuleId struct {
 	CreatedFromDecisionId *string
 	CreatedFromRuleId     string
 	PivotValue            string
+	KeyField              *string
 	RuleId                string
 	SnoozeGroupId         string
+	UnlessCondition       *ast.Node
 	StartsAt              time.Time
 	ExpiresAt             time.Time
 }
 
+// RuleSnoozeKey is what a snooze is keyed on: the pivot value of the decision if Field is nil, or the
+// value of Field on the trigger object of the decision.
+type RuleSnoozeKey struct {
+	Field *string
+	Value string
+}
+
+// RuleSnoozeKeys lists the keys of the snoozes that can apply to a decision: its pivot value, and the
+// values of the fields of its trigger object. Only the scalar fields of the trigger object itself can
+// be snoozed on, not those of the objects it links to.
+func RuleSnoozeKeys(pivotValue *string, clientObject ClientObject) []RuleSnoozeKey {
+	keys := make([]RuleSnoozeKey, 0, len(clientObject.Data)+1)
+	if pivotValue != nil && strings.TrimSpace(*pivotValue) != "" {
+		keys = append(keys, RuleSnoozeKey{Value: *pivotValue})
+	}
+	for field := range clientObject.Data {
+		if key, ok := RuleSnoozeKeyOfField(field, clientObject); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// RuleSnoozeKeyOfField returns the key of a snooze on a field of the trigger object, if the field
+// has a value that can be snoozed on.
+func RuleSnoozeKeyOfField(field string, clientObject ClientObject) (RuleSnoozeKey, bool) {
+	var value string
+	switch v := clientObject.Data[field].(type) {
+	case string:
+		value = v
+	case int:
+		value = strconv.FormatInt(int64(v), 10)
+	case int32:
+		value = strconv.FormatInt(int64(v), 10)
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case float32:
+		value = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		// the same number can be read as an int64 or a float64 depending on where the object comes from,
+		// so integral floats must give the same key as ints
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return RuleSnoozeKey{}, false
+	}
+	if strings.TrimSpace(value) == "" {
+		return RuleSnoozeKey{}, false
+	}
+	return RuleSnoozeKey{Field: &field, Value: value}, true
+}
+
+// IsRuleSnoozeKeyType tells if a rule can be snoozed on the values of a field of this type.
+func IsRuleSnoozeKeyType(dataType DataType) bool {
+	return dataType == String || dataType == Int || dataType == Float
+}
+
+// RuleSnoozeValueOfField normalizes a value given for a snooze on a field of this type, so that it
+// matches the key RuleSnoozeKeyOfField returns for the trigger objects having that value.
+func RuleSnoozeValueOfField(dataType DataType, value string) (string, bool) {
+	switch dataType {
+	case String:
+		return value, true
+	case Int, Float:
+		if integer, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil {
+			return strconv.FormatInt(integer, 10), true
+		}
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
 type SnoozesOfDecision struct {
 	DecisionId  string
 	Iteration   ScenarioIteration
@@ -55,8 +147,10 @@ func NewSnoozesOfDecision(decisionId string, snoozes []RuleSnooze, iteration Sce
 					CreatedFromDecisionId: s.CreatedFromDecisionId,
 					CreatedFromRuleId:     s.CreatedFromRuleId,
 					PivotValue:            s.PivotValue,
+					KeyField:              s.KeyField,
 					RuleId:                ruleId,
 					SnoozeGroupId:         s.SnoozeGroupId,
+					UnlessCondition:       s.UnlessCondition,
 					StartsAt:              s.StartsAt,
 					ExpiresAt:             s.ExpiresAt,
 				})
@@ -75,11 +169,13 @@ func NewSnoozesOfDecision(decisionId string, snoozes []RuleSnooze, iteration Sce
 type RuleSnoozeCreateInput struct {
 	Id                    string
 	CreatedByUserId       *UserId
-	CreatedFromDecisionId string
+	CreatedFromDecisionId *string
 	CreatedFromRuleId     string
 	ExpiresAt             time.Time
 	PivotValue            string
+	KeyField              *string
 	SnoozeGroupId         string
+	UnlessCondition       *ast.Node
 }
 
 type SnoozesOfIteration struct {
@@ -100,6 +196,28 @@ type SnoozeDecisionInput struct {
 	OrganizationId uuid.UUID
 	RuleId         string
 	UserId         *UserId
+	// If set, the rule is snoozed on the value of this field of the decision's trigger object rather
+	// than on the decision's pivot value
+	KeyField        *string
+	UnlessCondition *ast.Node
+}
+
+// SnoozeRuleInput snoozes a rule on several values at once, independently of any decision.
+type SnoozeRuleInput struct {
+	RuleId          string
+	Duration        string
+	KeyField        *string
+	Values          []string
+	UnlessCondition *ast.Node
+	UserId          *UserId
+}
+
+// EndRuleSnoozesInput ends the active snoozes of a rule, either those listed in SnoozeIds or all of
+// them.
+type EndRuleSnoozesInput struct {
+	RuleId    string
+	SnoozeIds []string
+	All       bool
 }
 
 type RuleSnoozeCaseEventInput struct {
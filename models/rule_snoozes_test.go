@@ -0,0 +1,72 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleSnoozeKeys(t *testing.T) {
+	pivotValue, blank := "customer-1", " "
+	iban, amount := "counterparty_iban", "amount"
+	clientObject := ClientObject{Data: map[string]any{
+		"counterparty_iban": "FR7630006000011234567890189",
+		"amount":            float64(120),
+		"reference":         "",
+		"is_recurring":      true,
+		"merchant_id":       nil,
+	}}
+
+	assert.ElementsMatch(t, []RuleSnoozeKey{
+		{Value: "customer-1"},
+		{Field: &iban, Value: "FR7630006000011234567890189"},
+		{Field: &amount, Value: "120"},
+	}, RuleSnoozeKeys(&pivotValue, clientObject))
+
+	assert.ElementsMatch(t, []RuleSnoozeKey{
+		{Field: &iban, Value: "FR7630006000011234567890189"},
+		{Field: &amount, Value: "120"},
+	}, RuleSnoozeKeys(&blank, clientObject))
+}
+
+func TestRuleSnoozeKeyOfField(t *testing.T) {
+	clientObject := ClientObject{Data: map[string]any{"merchant_id": int64(42), "name": " "}}
+
+	key, ok := RuleSnoozeKeyOfField("merchant_id", clientObject)
+	assert.True(t, ok)
+	assert.Equal(t, "42", key.Value)
+	assert.Equal(t, "merchant_id", *key.Field)
+
+	_, ok = RuleSnoozeKeyOfField("name", clientObject)
+	assert.False(t, ok)
+	_, ok = RuleSnoozeKeyOfField("missing", clientObject)
+	assert.False(t, ok)
+}
+
+func TestRuleSnoozeKeyOfField_large_numbers(t *testing.T) {
+	// the same int field is read as an int64 from the database and as a float64 from a JSON payload
+	fromDb := ClientObject{Data: map[string]any{"merchant_id": int64(12_345_678)}}
+	fromPayload := ClientObject{Data: map[string]any{"merchant_id": float64(12_345_678)}}
+
+	keyFromDb, ok := RuleSnoozeKeyOfField("merchant_id", fromDb)
+	assert.True(t, ok)
+	keyFromPayload, ok := RuleSnoozeKeyOfField("merchant_id", fromPayload)
+	assert.True(t, ok)
+	assert.Equal(t, "12345678", keyFromDb.Value)
+	assert.Equal(t, keyFromDb, keyFromPayload)
+
+	value, ok := RuleSnoozeValueOfField(Int, "12345678")
+	assert.True(t, ok)
+	assert.Equal(t, keyFromDb.Value, value)
+	value, ok = RuleSnoozeValueOfField(Float, "1.2345678e7")
+	assert.True(t, ok)
+	assert.Equal(t, keyFromDb.Value, value)
+	value, ok = RuleSnoozeValueOfField(Float, "120.50")
+	assert.True(t, ok)
+	assert.Equal(t, "120.5", value)
+
+	_, ok = RuleSnoozeValueOfField(Int, "not a number")
+	assert.False(t, ok)
+	_, ok = RuleSnoozeValueOfField(Bool, "true")
+	assert.False(t, ok)
+}
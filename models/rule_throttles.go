@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RuleThrottle is the hit of a throttled rule counted on a throttle key. Until it expires, the other
+// hits of the rule on the same key are recorded as throttled.
+type RuleThrottle struct {
+	Id             string
+	OrganizationId uuid.UUID
+	StableRuleId   string
+	ThrottleKey    string
+	DecisionId     string
+	StartsAt       time.Time
+	ExpiresAt      time.Time
+}
+
+// RuleThrottleScope is what the hits of a throttled rule are counted on.
+type RuleThrottleScope string
+
+const (
+	// The pivot value of the decision, so that an incident on a customer is throttled across all its
+	// transactions. Decisions without a pivot value are throttled on their trigger object.
+	RuleThrottleScopePivot RuleThrottleScope = "pivot"
+	// The trigger object of the decision, so that only the new evaluations of the same object are
+	// throttled.
+	RuleThrottleScopeTriggerObject RuleThrottleScope = "trigger_object"
+)
+
+// RuleThrottleScopeFrom reads a throttle scope. The pivot scope is the default.
+func RuleThrottleScopeFrom(scope string) (RuleThrottleScope, error) {
+	switch RuleThrottleScope(scope) {
+	case "", RuleThrottleScopePivot:
+		return RuleThrottleScopePivot, nil
+	case RuleThrottleScopeTriggerObject:
+		return RuleThrottleScopeTriggerObject, nil
+	}
+	return "", fmt.Errorf("invalid throttle_scope %q: %w", scope, BadParameterError)
+}
+
+// RuleThrottleKey is the value the hits of a throttled rule are counted on, depending on its scope:
+// the pivot value of the decision, or the trigger object id.
+func RuleThrottleKey(scope RuleThrottleScope, pivotValue *string, clientObject ClientObject) string {
+	if scope != RuleThrottleScopeTriggerObject && pivotValue != nil && *pivotValue != "" {
+		return *pivotValue
+	}
+	return fmt.Sprint(clientObject.Data["object_id"])
+}
+
+// RuleThrottlesOfDecision lists the throttles started by the hits of the throttled rules of a decision.
+func RuleThrottlesOfDecision(decision DecisionWithRuleExecutions) []RuleThrottle {
+	throttles := make([]RuleThrottle, 0)
+	for _, ruleExecution := range decision.RuleExecutions {
+		if ruleExecution.Outcome != "hit" || ruleExecution.Rule.ThrottlePeriod <= 0 {
+			continue
+		}
+		throttles = append(throttles, RuleThrottle{
+			OrganizationId: decision.OrganizationId,
+			StableRuleId:   ruleExecution.Rule.StableRuleId,
+			ThrottleKey:    RuleThrottleKey(ruleExecution.Rule.ThrottleScope, decision.PivotValue, decision.ClientObject),
+			DecisionId:     decision.DecisionId.String(),
+			StartsAt:       decision.CreatedAt,
+			ExpiresAt:      decision.CreatedAt.Add(ruleExecution.Rule.ThrottlePeriod),
+		})
+	}
+	return throttles
+}
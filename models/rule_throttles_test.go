@@ -0,0 +1,78 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleThrottleKey(t *testing.T) {
+	customer, empty := "customer-1", ""
+	clientObject := ClientObject{Data: map[string]any{"object_id": "transaction-1"}}
+
+	assert.Equal(t, "customer-1", RuleThrottleKey(RuleThrottleScopePivot, &customer, clientObject))
+	assert.Equal(t, "transaction-1", RuleThrottleKey(RuleThrottleScopePivot, &empty, clientObject))
+	assert.Equal(t, "transaction-1", RuleThrottleKey(RuleThrottleScopePivot, nil, clientObject))
+	assert.Equal(t, "transaction-1", RuleThrottleKey(RuleThrottleScopeTriggerObject, &customer, clientObject))
+}
+
+func TestRuleThrottleScopeFrom(t *testing.T) {
+	scope, err := RuleThrottleScopeFrom("")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleThrottleScopePivot, scope)
+
+	scope, err = RuleThrottleScopeFrom("trigger_object")
+	assert.NoError(t, err)
+	assert.Equal(t, RuleThrottleScopeTriggerObject, scope)
+
+	_, err = RuleThrottleScopeFrom("customer")
+	assert.ErrorIs(t, err, BadParameterError)
+}
+
+func TestRuleThrottlesOfDecision(t *testing.T) {
+	orgId := uuid.New()
+	decisionId := uuid.New()
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	customer := "customer-1"
+	throttledRule := Rule{StableRuleId: "throttled", ThrottlePeriod: 24 * time.Hour}
+	objectThrottledRule := Rule{
+		StableRuleId:   "object throttled",
+		ThrottlePeriod: time.Hour,
+		ThrottleScope:  RuleThrottleScopeTriggerObject,
+	}
+
+	decision := DecisionWithRuleExecutions{
+		Decision: Decision{
+			DecisionId:     decisionId,
+			OrganizationId: orgId,
+			CreatedAt:      createdAt,
+			PivotValue:     &customer,
+			ClientObject:   ClientObject{Data: map[string]any{"object_id": "transaction-1"}},
+		},
+		RuleExecutions: []RuleExecution{
+			{Outcome: "hit", Rule: throttledRule},
+			{Outcome: "hit", Rule: objectThrottledRule},
+			{Outcome: "hit", Rule: Rule{StableRuleId: "not throttled"}},
+			{Outcome: "no_hit", Rule: Rule{StableRuleId: "no hit", ThrottlePeriod: time.Hour}},
+			{Outcome: "throttled", Rule: Rule{StableRuleId: "already throttled", ThrottlePeriod: time.Hour}},
+		},
+	}
+
+	assert.Equal(t, []RuleThrottle{{
+		OrganizationId: orgId,
+		StableRuleId:   "throttled",
+		ThrottleKey:    "customer-1",
+		DecisionId:     decisionId.String(),
+		StartsAt:       createdAt,
+		ExpiresAt:      createdAt.Add(24 * time.Hour),
+	}, {
+		OrganizationId: orgId,
+		StableRuleId:   "object throttled",
+		ThrottleKey:    "transaction-1",
+		DecisionId:     decisionId.String(),
+		StartsAt:       createdAt,
+		ExpiresAt:      createdAt.Add(time.Hour),
+	}}, RuleThrottlesOfDecision(decision))
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	// The longest period of past decisions a single backtest replays
+	ScenarioBacktestMaxWindow = 366 * 24 * time.Hour
+
+	// The most past decisions a single backtest replays. Replays read the data as it was when the
+	// decision was made, which the indexes of the client tables do not cover: every aggregate of
+	// the draft scans the versions of the aggregated table, so the cost of a backtest grows with
+	// the number of decisions times the size of those tables.
+	ScenarioBacktestMaxDecisions = 10_000
+
+	// The version under which the results of the backtested draft are recorded in the test run
+	// summaries. Published versions start at 1.
+	ScenarioBacktestDraftVersion = 0
+
+	// The backtest outcome of the past decisions whose trigger condition the draft does not match
+	ScenarioBacktestOutcomeNotTriggered = "not_triggered"
+)
+
+type ScenarioBacktestStatus string
+
+const (
+	ScenarioBacktestPending   ScenarioBacktestStatus = "pending"
+	ScenarioBacktestRunning   ScenarioBacktestStatus = "running"
+	ScenarioBacktestCompleted ScenarioBacktestStatus = "completed"
+	ScenarioBacktestFailed    ScenarioBacktestStatus = "failed"
+	ScenarioBacktestCancelled ScenarioBacktestStatus = "cancelled"
+)
+
+func (s ScenarioBacktestStatus) IsTerminal() bool {
+	return s == ScenarioBacktestCompleted || s == ScenarioBacktestFailed || s == ScenarioBacktestCancelled
+}
+
+// ScenarioBacktest replays the trigger objects of the past decisions of a scenario, made in a time
+// window, through a draft iteration. The results are stored as the phantom decisions of a test run
+// of kind backtest, which is summarized like shadow test runs are. Past decisions are replayed in
+// creation order, and the data they read is taken as it was when the decision was made. Only the
+// first ScenarioBacktestMaxDecisions decisions of the window are replayed, a shorter window covers
+// a later period.
+//
+// The draft is read when each batch of decisions is replayed, editing it while the backtest runs
+// affects the decisions not replayed yet.
+type ScenarioBacktest struct {
+	Id                  uuid.UUID
+	OrganizationId      uuid.UUID
+	ScenarioId          uuid.UUID
+	ScenarioIterationId uuid.UUID
+	TestRunId           uuid.UUID
+	WindowStart         time.Time
+	WindowEnd           time.Time
+	Status              ScenarioBacktestStatus
+	// The last past decision replayed, decisions are replayed in (created_at, id) order
+	CursorCreatedAt    *time.Time
+	CursorDecisionId   *uuid.UUID
+	DecisionsEvaluated int64
+	// Decisions whose trigger object could not be replayed, for instance because the data model
+	// changed since
+	DecisionsFailed int64
+	Error           *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	FinishedAt      *time.Time
+}
+
+type CreateScenarioBacktestInput struct {
+	OrganizationId      uuid.UUID
+	ScenarioId          string
+	ScenarioIterationId string
+	WindowStart         time.Time
+	WindowEnd           time.Time
+}
+
+func (i CreateScenarioBacktestInput) Validate(now time.Time) error {
+	if i.WindowStart.IsZero() || i.WindowEnd.IsZero() {
+		return errors.Wrap(BadParameterError, "the window start and end are required")
+	}
+	if !i.WindowEnd.After(i.WindowStart) {
+		return errors.Wrap(BadParameterError, "the window end must be after the window start")
+	}
+	if i.WindowEnd.After(now) {
+		return errors.Wrap(BadParameterError, "the window end cannot be in the future")
+	}
+	if i.WindowEnd.Sub(i.WindowStart) > ScenarioBacktestMaxWindow {
+		return errors.Wrapf(BadParameterError, "the window cannot be longer than %d days",
+			int(ScenarioBacktestMaxWindow.Hours()/24))
+	}
+	return nil
+}
+
+// ScenarioBacktestOutcomeCount is a cell of the confusion matrix of a backtest: the number of past
+// decisions that had the live outcome and were given the backtest outcome by the draft.
+type ScenarioBacktestOutcomeCount struct {
+	LiveOutcome     string
+	BacktestOutcome string
+	Total           int64
+}
+
+// SortScenarioBacktestOutcomeCounts orders the confusion matrix by live outcome, then backtest
+// outcome, from the least to the most severe.
+func SortScenarioBacktestOutcomeCounts(counts []ScenarioBacktestOutcomeCount) {
+	rank := func(outcome string) int {
+		if outcome == ScenarioBacktestOutcomeNotTriggered {
+			return -1
+		}
+		return slices.Index(ValidOutcomes, OutcomeFrom(outcome))
+	}
+	slices.SortFunc(counts, func(a, b ScenarioBacktestOutcomeCount) int {
+		return cmp.Or(
+			cmp.Compare(rank(a.LiveOutcome), rank(b.LiveOutcome)),
+			cmp.Compare(rank(a.BacktestOutcome), rank(b.BacktestOutcome)),
+		)
+	})
+}
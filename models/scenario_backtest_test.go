@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateScenarioBacktestInputValidate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	valid := CreateScenarioBacktestInput{
+		WindowStart: now.Add(-30 * 24 * time.Hour),
+		WindowEnd:   now.Add(-time.Hour),
+	}
+	assert.NoError(t, valid.Validate(now))
+
+	invalid := map[string]func(i *CreateScenarioBacktestInput){
+		"missing start":      func(i *CreateScenarioBacktestInput) { i.WindowStart = time.Time{} },
+		"end before start":   func(i *CreateScenarioBacktestInput) { i.WindowEnd = i.WindowStart.Add(-time.Hour) },
+		"empty window":       func(i *CreateScenarioBacktestInput) { i.WindowEnd = i.WindowStart },
+		"end in the future":  func(i *CreateScenarioBacktestInput) { i.WindowEnd = now.Add(time.Hour) },
+		"window over a year": func(i *CreateScenarioBacktestInput) { i.WindowStart = now.Add(-400 * 24 * time.Hour) },
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			input := valid
+			mutate(&input)
+			assert.ErrorIs(t, input.Validate(now), BadParameterError)
+		})
+	}
+}
+
+func TestSortScenarioBacktestOutcomeCounts(t *testing.T) {
+	counts := []ScenarioBacktestOutcomeCount{
+		{LiveOutcome: "decline", BacktestOutcome: "review"},
+		{LiveOutcome: "approve", BacktestOutcome: "decline"},
+		{LiveOutcome: "approve", BacktestOutcome: ScenarioBacktestOutcomeNotTriggered},
+		{LiveOutcome: "decline", BacktestOutcome: ScenarioBacktestOutcomeNotTriggered},
+	}
+
+	SortScenarioBacktestOutcomeCounts(counts)
+
+	assert.Equal(t, []ScenarioBacktestOutcomeCount{
+		{LiveOutcome: "approve", BacktestOutcome: ScenarioBacktestOutcomeNotTriggered},
+		{LiveOutcome: "approve", BacktestOutcome: "decline"},
+		{LiveOutcome: "decline", BacktestOutcome: ScenarioBacktestOutcomeNotTriggered},
+		{LiveOutcome: "decline", BacktestOutcome: "review"},
+	}, counts)
+}
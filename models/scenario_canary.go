@@ -0,0 +1,115 @@
+package models
+
+import (
+	"hash/fnv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RolloutArm records which iteration of a scenario running a canary was used to take a decision.
+// It is empty on decisions taken while no canary was running.
+type RolloutArm string
+
+const (
+	RolloutArmLive   RolloutArm = "live"
+	RolloutArmCanary RolloutArm = "canary"
+)
+
+const (
+	MinCanaryPercentage = 1
+	MaxCanaryPercentage = 99
+)
+
+// CanaryBucket deterministically places a trigger object in one of 100 buckets. The scenario id
+// salts the hash, so that the same objects do not land in the canary arm of every scenario.
+func CanaryBucket(scenarioId, objectId string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(scenarioId))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(objectId))
+	return int(h.Sum32() % 100)
+}
+
+func (s Scenario) HasCanary() bool {
+	return s.LiveVersionID != nil && s.CanaryIterationId != nil && s.CanaryPercentage > 0
+}
+
+// ServesIteration returns whether decisions of the scenario are taken with the iteration, as its
+// live version or as its canary.
+func (s Scenario) ServesIteration(iterationId string) bool {
+	return (s.LiveVersionID != nil && *s.LiveVersionID == iterationId) ||
+		(s.CanaryIterationId != nil && *s.CanaryIterationId == iterationId)
+}
+
+// PickRolloutArm returns the arm a trigger object is evaluated on. An object always hits the same
+// arm as long as the canary percentage does not decrease.
+func (s Scenario) PickRolloutArm(objectId string) RolloutArm {
+	if !s.HasCanary() {
+		return ""
+	}
+	if CanaryBucket(s.Id, objectId) < s.CanaryPercentage {
+		return RolloutArmCanary
+	}
+	return RolloutArmLive
+}
+
+type UpdateScenarioCanaryInput struct {
+	CanaryIterationId *string
+	CanaryPercentage  int
+	CanaryStartedAt   *time.Time
+}
+
+type RolloutArmOutcomeCount struct {
+	Arm                 RolloutArm
+	ScenarioIterationId uuid.UUID
+	Outcome             Outcome
+	Count               int
+}
+
+type RolloutArmStats struct {
+	Arm                 RolloutArm
+	ScenarioIterationId uuid.UUID
+	Decisions           int
+	Outcomes            map[Outcome]int
+	// Share of the decisions of the arm taken with each outcome, in percent
+	OutcomeRates map[Outcome]float64
+}
+
+type ScenarioCanaryStats struct {
+	ScenarioId        string
+	LiveIterationId   *string
+	CanaryIterationId *string
+	CanaryPercentage  int
+	CanaryStartedAt   *time.Time
+	Arms              []RolloutArmStats
+}
+
+// NewRolloutArmStats groups outcome counts by arm. Both arms are always returned, live first, so that
+// an arm that did not take any decision yet still shows up.
+func NewRolloutArmStats(counts []RolloutArmOutcomeCount) []RolloutArmStats {
+	arms := []RolloutArmStats{
+		{Arm: RolloutArmLive, Outcomes: map[Outcome]int{}, OutcomeRates: map[Outcome]float64{}},
+		{Arm: RolloutArmCanary, Outcomes: map[Outcome]int{}, OutcomeRates: map[Outcome]float64{}},
+	}
+	for _, c := range counts {
+		for i := range arms {
+			if arms[i].Arm != c.Arm {
+				continue
+			}
+			// Counts start with the current canary, so each arm ran a single iteration.
+			arms[i].ScenarioIterationId = c.ScenarioIterationId
+			arms[i].Decisions += c.Count
+			arms[i].Outcomes[c.Outcome] += c.Count
+		}
+	}
+	for i := range arms {
+		if arms[i].Decisions == 0 {
+			continue
+		}
+		for outcome, count := range arms[i].Outcomes {
+			arms[i].OutcomeRates[outcome] = float64(count) / float64(arms[i].Decisions) * 100
+		}
+	}
+	return arms
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanaryBucket(t *testing.T) {
+	assert.Equal(t, CanaryBucket("scenario", "object"), CanaryBucket("scenario", "object"),
+		"an object always lands in the same bucket")
+
+	inCanary := 0
+	for i := range 10000 {
+		if CanaryBucket("scenario", fmt.Sprintf("object_%d", i)) < 10 {
+			inCanary++
+		}
+	}
+	assert.InDelta(t, 1000, inCanary, 150, "buckets are evenly spread")
+}
+
+func TestScenario_PickRolloutArm(t *testing.T) {
+	scenario := Scenario{
+		Id:                "scenario",
+		LiveVersionID:     ptr("live"),
+		CanaryIterationId: ptr("canary"),
+		CanaryPercentage:  30,
+	}
+
+	arms := map[RolloutArm]int{}
+	for i := range 1000 {
+		objectId := fmt.Sprintf("object_%d", i)
+		arm := scenario.PickRolloutArm(objectId)
+		arms[arm]++
+
+		// Raising the percentage never moves an object out of the canary arm
+		raised := scenario
+		raised.CanaryPercentage = 60
+		if arm == RolloutArmCanary {
+			assert.Equal(t, RolloutArmCanary, raised.PickRolloutArm(objectId))
+		}
+	}
+	assert.Len(t, arms, 2)
+	assert.InDelta(t, 300, arms[RolloutArmCanary], 60)
+
+	noCanary := scenario
+	noCanary.CanaryIterationId = nil
+	assert.Equal(t, RolloutArm(""), noCanary.PickRolloutArm("object_1"))
+
+	noLive := scenario
+	noLive.LiveVersionID = nil
+	assert.Equal(t, RolloutArm(""), noLive.PickRolloutArm("object_1"))
+}
+
+func TestScenario_ServesIteration(t *testing.T) {
+	scenario := Scenario{LiveVersionID: ptr("live"), CanaryIterationId: ptr("canary")}
+
+	assert.True(t, scenario.ServesIteration("live"))
+	assert.True(t, scenario.ServesIteration("canary"))
+	assert.False(t, scenario.ServesIteration("draft"))
+	assert.False(t, Scenario{}.ServesIteration("live"))
+}
+
+func TestNewRolloutArmStats(t *testing.T) {
+	liveId, canaryId := uuid.New(), uuid.New()
+
+	arms := NewRolloutArmStats([]RolloutArmOutcomeCount{
+		{Arm: RolloutArmLive, ScenarioIterationId: liveId, Outcome: Approve, Count: 75},
+		{Arm: RolloutArmLive, ScenarioIterationId: liveId, Outcome: Decline, Count: 25},
+		{Arm: RolloutArmCanary, ScenarioIterationId: canaryId, Outcome: Decline, Count: 10},
+	})
+
+	assert.Len(t, arms, 2)
+	assert.Equal(t, RolloutArmLive, arms[0].Arm)
+	assert.Equal(t, liveId, arms[0].ScenarioIterationId)
+	assert.Equal(t, 100, arms[0].Decisions)
+	assert.Equal(t, map[Outcome]float64{Approve: 75, Decline: 25}, arms[0].OutcomeRates)
+	assert.Equal(t, RolloutArmCanary, arms[1].Arm)
+	assert.Equal(t, canaryId, arms[1].ScenarioIterationId)
+	assert.Equal(t, map[Outcome]int{Decline: 10}, arms[1].Outcomes)
+	assert.Equal(t, map[Outcome]float64{Decline: 100}, arms[1].OutcomeRates)
+
+	empty := NewRolloutArmStats(nil)
+	assert.Len(t, empty, 2)
+	assert.Zero(t, empty[1].Decisions)
+	assert.Empty(t, empty[1].OutcomeRates)
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenario_EvaluatesIngestedObject(t *testing.T) {
+	created := IngestionResult{NewInternalId: "new"}
+	statusChanged := IngestionResult{PreviousInternalId: "old", NewInternalId: "new", ChangedFields: []string{"status"}}
+	unchanged := IngestionResult{PreviousInternalId: "old", NewInternalId: "new"}
+
+	assert.False(t, Scenario{}.EvaluatesIngestedObject(created))
+
+	everyVersion := Scenario{EvaluateOnIngest: true}
+	assert.True(t, everyVersion.EvaluatesIngestedObject(created))
+	assert.True(t, everyVersion.EvaluatesIngestedObject(unchanged))
+
+	onStatus := Scenario{EvaluateOnIngest: true, EvaluateOnIngestFields: []string{"status"}}
+	assert.True(t, onStatus.EvaluatesIngestedObject(created))
+	assert.True(t, onStatus.EvaluatesIngestedObject(statusChanged))
+	assert.False(t, onStatus.EvaluatesIngestedObject(unchanged))
+}
@@ -0,0 +1,380 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
+)
+
+type DiffStatus string
+
+const (
+	DiffStatusAdded    DiffStatus = "added"
+	DiffStatusRemoved  DiffStatus = "removed"
+	DiffStatusModified DiffStatus = "modified"
+)
+
+type DiffLineOp string
+
+const (
+	DiffLineKept    DiffLineOp = " "
+	DiffLineAdded   DiffLineOp = "+"
+	DiffLineRemoved DiffLineOp = "-"
+)
+
+type DiffLine struct {
+	Op   DiffLineOp
+	Text string
+}
+
+// FieldChange is a change of a scalar field, with both values rendered as text.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// AstDiff is a line by line diff of the human-readable rendering of two formulas.
+type AstDiff struct {
+	Before string
+	After  string
+	Lines  []DiffLine
+}
+
+type RuleDiff struct {
+	StableRuleId string
+	Name         string
+	Status       DiffStatus
+	BeforeRuleId *string
+	AfterRuleId  *string
+	Changes      []FieldChange
+	Formula      *AstDiff
+}
+
+type ScreeningConfigDiff struct {
+	StableId                 string
+	Name                     string
+	Status                   DiffStatus
+	BeforeId                 *string
+	AfterId                  *string
+	Changes                  []FieldChange
+	TriggerRule              *AstDiff
+	CounterpartyIdExpression *AstDiff
+	// Diffs of the query formulas, by query field
+	Query map[string]AstDiff
+}
+
+type ScenarioIterationDiff struct {
+	BaseIterationId   string
+	BaseVersion       *int
+	TargetIterationId string
+	TargetVersion     *int
+	TriggerCondition  *AstDiff
+	// Score thresholds and schedule
+	Changes          []FieldChange
+	Rules            []RuleDiff
+	ScreeningConfigs []ScreeningConfigDiff
+}
+
+func (d ScenarioIterationDiff) IsEmpty() bool {
+	return d.TriggerCondition == nil && len(d.Changes) == 0 &&
+		len(d.Rules) == 0 && len(d.ScreeningConfigs) == 0
+}
+
+// DiffScenarioIterations lists what changes from the base iteration to the target iteration. Rules and
+// screening configs are matched on their stable id, so that a rule edited in a new version shows up
+// as modified rather than removed and added. The base can be an empty iteration, to diff against a
+// scenario with no live iteration.
+func DiffScenarioIterations(base, target ScenarioIteration) ScenarioIterationDiff {
+	diff := ScenarioIterationDiff{
+		BaseIterationId:   base.Id,
+		BaseVersion:       base.Version,
+		TargetIterationId: target.Id,
+		TargetVersion:     target.Version,
+		TriggerCondition:  diffAst(base.TriggerConditionAstExpression, target.TriggerConditionAstExpression),
+		Changes:           []FieldChange{},
+		Rules:             []RuleDiff{},
+		ScreeningConfigs:  []ScreeningConfigDiff{},
+	}
+
+	diff.Changes = appendFieldChange(diff.Changes, "score_review_threshold",
+		intPtrString(base.ScoreReviewThreshold), intPtrString(target.ScoreReviewThreshold))
+	diff.Changes = appendFieldChange(diff.Changes, "score_block_and_review_threshold",
+		intPtrString(base.ScoreBlockAndReviewThreshold), intPtrString(target.ScoreBlockAndReviewThreshold))
+	diff.Changes = appendFieldChange(diff.Changes, "score_decline_threshold",
+		intPtrString(base.ScoreDeclineThreshold), intPtrString(target.ScoreDeclineThreshold))
+	diff.Changes = appendFieldChange(diff.Changes, "schedule", base.Schedule, target.Schedule)
+
+	matchByStableId(base.Rules, target.Rules, ruleStableKey, func(before, after *Rule) {
+		if d, changed := diffRule(before, after); changed {
+			diff.Rules = append(diff.Rules, d)
+		}
+	})
+	matchByStableId(base.ScreeningConfigs, target.ScreeningConfigs, screeningConfigStableKey,
+		func(before, after *ScreeningConfig) {
+			if d, changed := diffScreeningConfig(before, after); changed {
+				diff.ScreeningConfigs = append(diff.ScreeningConfigs, d)
+			}
+		})
+
+	return diff
+}
+
+func ruleStableKey(r Rule) string {
+	if r.StableRuleId != "" {
+		return r.StableRuleId
+	}
+	return r.Id
+}
+
+func screeningConfigStableKey(scc ScreeningConfig) string {
+	if scc.StableId != "" {
+		return scc.StableId
+	}
+	return scc.Id
+}
+
+// matchByStableId calls fn for every pair of items sharing a key, then for the items only present in
+// one of the lists. Items are visited in the order of the target list, then the removed ones.
+func matchByStableId[T any](before, after []T, key func(T) string, fn func(before, after *T)) {
+	beforeByKey := make(map[string]*T, len(before))
+	for i := range before {
+		beforeByKey[key(before[i])] = &before[i]
+	}
+
+	seen := make(map[string]bool, len(after))
+	for i := range after {
+		k := key(after[i])
+		seen[k] = true
+		fn(beforeByKey[k], &after[i])
+	}
+	for i := range before {
+		if !seen[key(before[i])] {
+			fn(&before[i], nil)
+		}
+	}
+}
+
+func diffRule(before, after *Rule) (RuleDiff, bool) {
+	switch {
+	case before == nil:
+		return RuleDiff{
+			StableRuleId: ruleStableKey(*after),
+			Name:         after.Name,
+			Status:       DiffStatusAdded,
+			AfterRuleId:  &after.Id,
+			Changes:      []FieldChange{},
+			Formula:      diffAst(nil, after.FormulaAstExpression),
+		}, true
+	case after == nil:
+		return RuleDiff{
+			StableRuleId: ruleStableKey(*before),
+			Name:         before.Name,
+			Status:       DiffStatusRemoved,
+			BeforeRuleId: &before.Id,
+			Changes:      []FieldChange{},
+			Formula:      diffAst(before.FormulaAstExpression, nil),
+		}, true
+	}
+
+	d := RuleDiff{
+		StableRuleId: ruleStableKey(*after),
+		Name:         after.Name,
+		Status:       DiffStatusModified,
+		BeforeRuleId: &before.Id,
+		AfterRuleId:  &after.Id,
+		Changes:      []FieldChange{},
+		Formula:      diffAst(before.FormulaAstExpression, after.FormulaAstExpression),
+	}
+	d.Changes = appendFieldChange(d.Changes, "name", before.Name, after.Name)
+	d.Changes = appendFieldChange(d.Changes, "description", before.Description, after.Description)
+	d.Changes = appendFieldChange(d.Changes, "rule_group", before.RuleGroup, after.RuleGroup)
+	d.Changes = appendFieldChange(d.Changes, "score_modifier",
+		fmt.Sprint(before.ScoreModifier), fmt.Sprint(after.ScoreModifier))
+	d.Changes = appendFieldChange(d.Changes, "throttle_period",
+		before.ThrottlePeriod.String(), after.ThrottlePeriod.String())
+	d.Changes = appendFieldChange(d.Changes, "throttle_scope",
+		string(before.ThrottleScope), string(after.ThrottleScope))
+
+	return d, d.Formula != nil || len(d.Changes) > 0
+}
+
+func diffScreeningConfig(before, after *ScreeningConfig) (ScreeningConfigDiff, bool) {
+	empty := ScreeningConfig{ForcedOutcome: UnknownOutcome}
+	d := ScreeningConfigDiff{Status: DiffStatusModified, Changes: []FieldChange{}, Query: map[string]AstDiff{}}
+	switch {
+	case before == nil:
+		d.Status = DiffStatusAdded
+		before = &empty
+	case after == nil:
+		d.Status = DiffStatusRemoved
+		after = &empty
+	}
+	if d.Status != DiffStatusAdded {
+		d.BeforeId = &before.Id
+		d.StableId, d.Name = screeningConfigStableKey(*before), before.Name
+	}
+	if d.Status != DiffStatusRemoved {
+		d.AfterId = &after.Id
+		d.StableId, d.Name = screeningConfigStableKey(*after), after.Name
+	}
+
+	d.Changes = appendFieldChange(d.Changes, "name", before.Name, after.Name)
+	d.Changes = appendFieldChange(d.Changes, "description", before.Description, after.Description)
+	d.Changes = appendFieldChange(d.Changes, "rule_group",
+		pure_utils.PtrValueOrDefault(before.RuleGroup, ""), pure_utils.PtrValueOrDefault(after.RuleGroup, ""))
+	d.Changes = appendFieldChange(d.Changes, "provider", string(before.Provider), string(after.Provider))
+	d.Changes = appendFieldChange(d.Changes, "datasets", sortedList(before.Datasets), sortedList(after.Datasets))
+	d.Changes = appendFieldChange(d.Changes, "entity_type", before.EntityType, after.EntityType)
+	d.Changes = appendFieldChange(d.Changes, "threshold", intPtrString(before.Threshold), intPtrString(after.Threshold))
+	d.Changes = appendFieldChange(d.Changes, "forced_outcome", outcomeString(before.ForcedOutcome),
+		outcomeString(after.ForcedOutcome))
+	d.Changes = appendFieldChange(d.Changes, "filters", jsonString(before.Filters), jsonString(after.Filters))
+	d.Changes = appendFieldChange(d.Changes, "preprocessing", jsonString(before.Preprocessing),
+		jsonString(after.Preprocessing))
+	d.Changes = appendFieldChange(d.Changes, "weights", jsonString(before.Weights), jsonString(after.Weights))
+
+	d.TriggerRule = diffAst(before.TriggerRule, after.TriggerRule)
+	d.CounterpartyIdExpression = diffAst(before.CounterpartyIdExpression, after.CounterpartyIdExpression)
+
+	fields := make([]string, 0, len(before.Query)+len(after.Query))
+	for field := range before.Query {
+		fields = append(fields, field)
+	}
+	for field := range after.Query {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	for _, field := range slices.Compact(fields) {
+		var b, a *ast.Node
+		if node, ok := before.Query[field]; ok {
+			b = &node
+		}
+		if node, ok := after.Query[field]; ok {
+			a = &node
+		}
+		if queryDiff := diffAst(b, a); queryDiff != nil {
+			d.Query[field] = *queryDiff
+		}
+	}
+
+	changed := d.Status != DiffStatusModified || len(d.Changes) > 0 || d.TriggerRule != nil ||
+		d.CounterpartyIdExpression != nil || len(d.Query) > 0
+	return d, changed
+}
+
+// diffAst renders both formulas in human-readable form and diffs them, or returns nil if they are
+// structurally identical.
+func diffAst(before, after *ast.Node) *AstDiff {
+	if before == nil && after == nil {
+		return nil
+	}
+	if before != nil && after != nil && before.Hash() == after.Hash() {
+		return nil
+	}
+
+	d := AstDiff{}
+	if before != nil {
+		d.Before = before.ToHumanReadable()
+	}
+	if after != nil {
+		d.After = after.ToHumanReadable()
+	}
+	// Hashes also cover node fields that are not rendered, only report visible changes
+	if d.Before == d.After {
+		return nil
+	}
+	d.Lines = diffLines(splitLines(d.Before), splitLines(d.After))
+	return &d
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines computes a minimal line diff from the longest common subsequence of both texts. Formulas
+// are short enough for the quadratic table not to matter.
+func diffLines(before, after []string) []DiffLine {
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]DiffLine, 0, max(len(before), len(after)))
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i] == after[j]:
+			lines = append(lines, DiffLine{Op: DiffLineKept, Text: before[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffLineRemoved, Text: before[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffLineAdded, Text: after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		lines = append(lines, DiffLine{Op: DiffLineRemoved, Text: before[i]})
+	}
+	for ; j < len(after); j++ {
+		lines = append(lines, DiffLine{Op: DiffLineAdded, Text: after[j]})
+	}
+	return lines
+}
+
+func appendFieldChange(changes []FieldChange, field, before, after string) []FieldChange {
+	if before == after {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Before: before, After: after})
+}
+
+func intPtrString(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(*v)
+}
+
+func outcomeString(o Outcome) string {
+	if o == UnknownOutcome {
+		return ""
+	}
+	return o.String()
+}
+
+func sortedList(values []string) string {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if s := string(b); s != "null" && s != "{}" {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models/ast"
+)
+
+func greaterThan(field string, value int) *ast.Node {
+	node := ast.Node{Function: ast.FUNC_AND}.
+		AddChild(ast.Node{Function: ast.FUNC_GREATER}.
+			AddChild(ast.Node{Function: ast.FUNC_PAYLOAD}.AddChild(ast.NewNodeConstant(field))).
+			AddChild(ast.NewNodeConstant(value))).
+		AddChild(ast.NewNodeConstant(true))
+	return &node
+}
+
+func TestDiffScenarioIterations(t *testing.T) {
+	base := ScenarioIteration{
+		Id:                            "base",
+		Version:                       ptr(1),
+		TriggerConditionAstExpression: greaterThan("amount", 0),
+		ScoreReviewThreshold:          ptr(10),
+		ScoreDeclineThreshold:         ptr(50),
+		Rules: []Rule{
+			{Id: "r1", StableRuleId: "stable_1", Name: "Large amount", FormulaAstExpression: greaterThan("amount", 1000), ScoreModifier: 10},
+			{Id: "r2", StableRuleId: "stable_2", Name: "Unchanged", FormulaAstExpression: greaterThan("age", 18), ScoreModifier: 5},
+			{Id: "r3", StableRuleId: "stable_3", Name: "Removed", ScoreModifier: 1},
+		},
+		ScreeningConfigs: []ScreeningConfig{
+			{Id: "s1", StableId: "screening_1", Name: "Sanctions", Datasets: []string{"b", "a"}, ForcedOutcome: Review},
+		},
+	}
+	target := ScenarioIteration{
+		Id:                            "target",
+		TriggerConditionAstExpression: greaterThan("amount", 0),
+		ScoreReviewThreshold:          ptr(20),
+		ScoreDeclineThreshold:         ptr(50),
+		Rules: []Rule{
+			{Id: "r4", StableRuleId: "stable_2", Name: "Unchanged", FormulaAstExpression: greaterThan("age", 18), ScoreModifier: 5},
+			{Id: "r5", StableRuleId: "stable_1", Name: "Large amount", FormulaAstExpression: greaterThan("amount", 5000), ScoreModifier: 20},
+			{Id: "r6", StableRuleId: "stable_4", Name: "Added", ScoreModifier: 3},
+		},
+		ScreeningConfigs: []ScreeningConfig{
+			{Id: "s2", StableId: "screening_1", Name: "Sanctions", Datasets: []string{"a", "b"}, ForcedOutcome: Decline},
+		},
+	}
+
+	diff := DiffScenarioIterations(base, target)
+
+	assert.Nil(t, diff.TriggerCondition, "identical trigger conditions are not reported")
+	assert.Equal(t, []FieldChange{{Field: "score_review_threshold", Before: "10", After: "20"}}, diff.Changes)
+
+	if assert.Len(t, diff.Rules, 3) {
+		modified := diff.Rules[0]
+		assert.Equal(t, "stable_1", modified.StableRuleId)
+		assert.Equal(t, DiffStatusModified, modified.Status)
+		assert.Equal(t, "r1", *modified.BeforeRuleId)
+		assert.Equal(t, "r5", *modified.AfterRuleId)
+		assert.Equal(t, []FieldChange{{Field: "score_modifier", Before: "10", After: "20"}}, modified.Changes)
+		if assert.NotNil(t, modified.Formula) {
+			assert.Equal(t, []DiffLine{
+				{Op: DiffLineKept, Text: "("},
+				{Op: DiffLineRemoved, Text: "  (Payload(amount) > 1000)"},
+				{Op: DiffLineAdded, Text: "  (Payload(amount) > 5000)"},
+				{Op: DiffLineKept, Text: "  AND"},
+				{Op: DiffLineKept, Text: "  true"},
+				{Op: DiffLineKept, Text: ")"},
+			}, modified.Formula.Lines)
+		}
+
+		assert.Equal(t, "stable_4", diff.Rules[1].StableRuleId)
+		assert.Equal(t, DiffStatusAdded, diff.Rules[1].Status)
+		assert.Nil(t, diff.Rules[1].BeforeRuleId)
+
+		assert.Equal(t, "stable_3", diff.Rules[2].StableRuleId)
+		assert.Equal(t, DiffStatusRemoved, diff.Rules[2].Status)
+		assert.Nil(t, diff.Rules[2].AfterRuleId)
+	}
+
+	if assert.Len(t, diff.ScreeningConfigs, 1) {
+		assert.Equal(t, DiffStatusModified, diff.ScreeningConfigs[0].Status)
+		assert.Equal(t, []FieldChange{{Field: "forced_outcome", Before: "review", After: "decline"}},
+			diff.ScreeningConfigs[0].Changes, "dataset order does not matter")
+	}
+}
+
+func TestDiffScenarioIterations_no_live_iteration(t *testing.T) {
+	target := ScenarioIteration{
+		Id:                            "target",
+		TriggerConditionAstExpression: greaterThan("amount", 0),
+		Rules:                         []Rule{{Id: "r1", StableRuleId: "stable_1", Name: "Rule"}},
+	}
+
+	diff := DiffScenarioIterations(ScenarioIteration{}, target)
+
+	assert.False(t, diff.IsEmpty())
+	if assert.NotNil(t, diff.TriggerCondition) {
+		assert.Empty(t, diff.TriggerCondition.Before)
+		for _, line := range diff.TriggerCondition.Lines {
+			assert.Equal(t, DiffLineAdded, line.Op)
+		}
+	}
+	assert.Len(t, diff.Rules, 1)
+	assert.Equal(t, DiffStatusAdded, diff.Rules[0].Status)
+
+	assert.True(t, DiffScenarioIterations(target, target).IsEmpty())
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type ScenarioPublicationRequestStatus string
+
+const (
+	ScenarioPublicationRequestPending   ScenarioPublicationRequestStatus = "pending"
+	ScenarioPublicationRequestApproved  ScenarioPublicationRequestStatus = "approved"
+	ScenarioPublicationRequestRejected  ScenarioPublicationRequestStatus = "rejected"
+	ScenarioPublicationRequestCancelled ScenarioPublicationRequestStatus = "cancelled"
+	ScenarioPublicationRequestPublished ScenarioPublicationRequestStatus = "published"
+	ScenarioPublicationRequestFailed    ScenarioPublicationRequestStatus = "failed"
+)
+
+func (s ScenarioPublicationRequestStatus) IsOpen() bool {
+	return s == ScenarioPublicationRequestPending || s == ScenarioPublicationRequestApproved
+}
+
+// The publication actions that put an iteration in front of live traffic. They need an approved
+// publication request when the organization requires approval.
+var PublicationActionsRequiringApproval = []PublicationAction{Publish, StartCanary, PromoteCanary}
+
+func (o PublicationAction) RequiresApproval() bool {
+	return slices.Contains(PublicationActionsRequiringApproval, o)
+}
+
+// ScenarioPublicationRequest asks for a publication action to be executed once a second person
+// approved it. The diff against the live iteration is recorded when the request is submitted, so
+// the reviewer approves what the requester saw.
+type ScenarioPublicationRequest struct {
+	Id                  uuid.UUID
+	OrganizationId      uuid.UUID
+	ScenarioId          string
+	ScenarioIterationId string
+	PublicationAction   PublicationAction
+	CanaryPercentage    int
+	Status              ScenarioPublicationRequestStatus
+	Comment             string
+	Diff                ScenarioIterationDiff
+	RequestedBy         UserId
+	ReviewedBy          *UserId
+	ReviewComment       *string
+	// Why the approved publication could not be executed
+	Error *string
+	// Set when the request approves a scheduled publication, which is executed by its schedule when
+	// it is due instead of right after the approval
+	ScheduledAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ReviewedAt  *time.Time
+}
+
+type CreateScenarioPublicationRequestInput struct {
+	OrganizationId      uuid.UUID
+	ScenarioIterationId string
+	PublicationAction   PublicationAction
+	CanaryPercentage    int
+	Comment             string
+}
+
+func (i CreateScenarioPublicationRequestInput) Validate() error {
+	if !i.PublicationAction.RequiresApproval() {
+		return errors.Wrapf(BadParameterError,
+			"the %s publication action does not require an approval", i.PublicationAction)
+	}
+	if i.PublicationAction == StartCanary &&
+		(i.CanaryPercentage < MinCanaryPercentage || i.CanaryPercentage > MaxCanaryPercentage) {
+		return errors.Wrapf(BadParameterError, "the canary percentage must be between %d and %d",
+			MinCanaryPercentage, MaxCanaryPercentage)
+	}
+	return nil
+}
+
+type ReviewScenarioPublicationRequestInput struct {
+	Id       uuid.UUID
+	Approved bool
+	Comment  *string
+}
+
+type UpdateScenarioPublicationRequestInput struct {
+	Status        ScenarioPublicationRequestStatus
+	ReviewedBy    *UserId
+	ReviewComment *string
+	Error         *string
+}
+
+type ListScenarioPublicationRequestsFilters struct {
+	ScenarioId *string
+	Status     *ScenarioPublicationRequestStatus
+}
+
+// CanBeReviewedBy checks that a pending request is reviewed by someone else than its requester.
+func (r ScenarioPublicationRequest) CanBeReviewedBy(userId UserId) error {
+	if r.Status != ScenarioPublicationRequestPending {
+		return errors.Wrapf(ErrScenarioPublicationRequestNotPending, "the request is %s", r.Status)
+	}
+	if userId == "" {
+		return errors.Wrap(ForbiddenError, "a publication request can only be reviewed by a user")
+	}
+	if userId == r.RequestedBy {
+		return errors.Wrap(ForbiddenError, "a publication request cannot be reviewed by its requester")
+	}
+	return nil
+}
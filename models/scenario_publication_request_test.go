@@ -0,0 +1,33 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenarioPublicationRequest_CanBeReviewedBy(t *testing.T) {
+	request := ScenarioPublicationRequest{
+		Status:      ScenarioPublicationRequestPending,
+		RequestedBy: "builder",
+	}
+
+	assert.NoError(t, request.CanBeReviewedBy("publisher"))
+	assert.ErrorIs(t, request.CanBeReviewedBy("builder"), ForbiddenError)
+	assert.ErrorIs(t, request.CanBeReviewedBy(""), ForbiddenError)
+
+	request.Status = ScenarioPublicationRequestApproved
+	assert.ErrorIs(t, request.CanBeReviewedBy("publisher"), ErrScenarioPublicationRequestNotPending)
+}
+
+func TestCreateScenarioPublicationRequestInput_Validate(t *testing.T) {
+	assert.NoError(t, CreateScenarioPublicationRequestInput{PublicationAction: Publish}.Validate())
+	assert.NoError(t, CreateScenarioPublicationRequestInput{
+		PublicationAction: StartCanary,
+		CanaryPercentage:  10,
+	}.Validate())
+	assert.ErrorIs(t, CreateScenarioPublicationRequestInput{PublicationAction: Unpublish}.Validate(),
+		BadParameterError)
+	assert.ErrorIs(t, CreateScenarioPublicationRequestInput{PublicationAction: StartCanary}.Validate(),
+		BadParameterError)
+}
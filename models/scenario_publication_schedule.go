@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	// How long before a scheduled publication an alert is raised if the indexes of its iteration are
+	// still not ready
+	ScenarioPublicationSchedulePreparationAlertDelay = 1 * time.Hour
+)
+
+type ScenarioPublicationScheduleStatus string
+
+const (
+	ScenarioPublicationScheduleScheduled ScenarioPublicationScheduleStatus = "scheduled"
+	ScenarioPublicationScheduleExecuted  ScenarioPublicationScheduleStatus = "executed"
+	ScenarioPublicationScheduleFailed    ScenarioPublicationScheduleStatus = "failed"
+	ScenarioPublicationScheduleCancelled ScenarioPublicationScheduleStatus = "cancelled"
+)
+
+// ScenarioPublicationSchedule is a publish or unpublish action to execute on an iteration at a given
+// time. The indexes a scheduled publication needs are prepared ahead of time, the publication fails
+// if they are not ready when it is due. When the organization requires approval, a scheduled
+// publication also fails if its publication request is not approved when it is due.
+type ScenarioPublicationSchedule struct {
+	Id                  uuid.UUID
+	OrganizationId      uuid.UUID
+	ScenarioId          string
+	ScenarioIterationId string
+	PublicationAction   PublicationAction
+	ScheduledAt         time.Time
+	Status              ScenarioPublicationScheduleStatus
+	Error               *string
+	CreatedBy           *UserId
+	// The publication request that must be approved before the schedule is due, when the organization
+	// requires publications to be approved
+	PublicationRequestId *uuid.UUID
+	// When the organization was alerted that the indexes would not be ready in time
+	PreparationAlertedAt *time.Time
+	ExecutedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (s ScenarioPublicationSchedule) IsDue(now time.Time) bool {
+	return !s.ScheduledAt.After(now)
+}
+
+// ShouldAlertPreparation tells whether the organization must be alerted that the indexes of a
+// scheduled publication are not ready yet.
+func (s ScenarioPublicationSchedule) ShouldAlertPreparation(now time.Time) bool {
+	return s.PreparationAlertedAt == nil &&
+		!s.ScheduledAt.After(now.Add(ScenarioPublicationSchedulePreparationAlertDelay))
+}
+
+// AsScenarioPublication shows a schedule that is not executed among the publications of its scenario.
+func (s ScenarioPublicationSchedule) AsScenarioPublication() ScenarioPublication {
+	status := ScenarioPublicationStatusScheduled
+	if s.Status == ScenarioPublicationScheduleFailed {
+		status = ScenarioPublicationStatusFailed
+	}
+	scheduledAt := s.ScheduledAt
+	return ScenarioPublication{
+		Id:                  s.Id.String(),
+		OrganizationId:      s.OrganizationId,
+		ScenarioId:          s.ScenarioId,
+		ScenarioIterationId: s.ScenarioIterationId,
+		PublicationAction:   s.PublicationAction,
+		CreatedAt:           s.CreatedAt,
+		Status:              status,
+		ScheduledAt:         &scheduledAt,
+		Error:               s.Error,
+	}
+}
+
+type CreateScenarioPublicationScheduleInput struct {
+	OrganizationId      uuid.UUID
+	ScenarioIterationId string
+	PublicationAction   PublicationAction
+	ScheduledAt         time.Time
+	// Submitted with the publication request, when the organization requires approval
+	Comment string
+}
+
+func (i CreateScenarioPublicationScheduleInput) Validate(now time.Time) error {
+	if i.PublicationAction != Publish && i.PublicationAction != Unpublish {
+		return errors.Wrap(BadParameterError, "only publish and unpublish actions can be scheduled")
+	}
+	if !i.ScheduledAt.After(now) {
+		return errors.Wrap(BadParameterError, "a publication can only be scheduled in the future")
+	}
+	return nil
+}
+
+type UpdateScenarioPublicationScheduleInput struct {
+	Status             *ScenarioPublicationScheduleStatus
+	Error              *string
+	PreparationAlerted bool
+}
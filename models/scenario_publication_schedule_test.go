@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateScenarioPublicationScheduleInput_Validate(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, CreateScenarioPublicationScheduleInput{
+		PublicationAction: Publish,
+		ScheduledAt:       now.Add(time.Hour),
+	}.Validate(now))
+	assert.NoError(t, CreateScenarioPublicationScheduleInput{
+		PublicationAction: Unpublish,
+		ScheduledAt:       now.Add(time.Hour),
+	}.Validate(now))
+	assert.ErrorIs(t, CreateScenarioPublicationScheduleInput{
+		PublicationAction: StartCanary,
+		ScheduledAt:       now.Add(time.Hour),
+	}.Validate(now), BadParameterError)
+	assert.ErrorIs(t, CreateScenarioPublicationScheduleInput{
+		PublicationAction: Publish,
+		ScheduledAt:       now.Add(-time.Minute),
+	}.Validate(now), BadParameterError)
+}
+
+func TestScenarioPublicationSchedule_ShouldAlertPreparation(t *testing.T) {
+	now := time.Now()
+	schedule := ScenarioPublicationSchedule{ScheduledAt: now.Add(2 * time.Hour)}
+
+	assert.False(t, schedule.ShouldAlertPreparation(now))
+	assert.True(t, schedule.ShouldAlertPreparation(now.Add(90*time.Minute)))
+
+	schedule.PreparationAlertedAt = &now
+	assert.False(t, schedule.ShouldAlertPreparation(now.Add(90*time.Minute)))
+}
+
+func TestScenarioPublicationSchedule_AsScenarioPublication(t *testing.T) {
+	schedule := ScenarioPublicationSchedule{
+		PublicationAction: Unpublish,
+		ScheduledAt:       time.Now(),
+		Status:            ScenarioPublicationScheduleScheduled,
+	}
+	assert.Equal(t, ScenarioPublicationStatusScheduled, schedule.AsScenarioPublication().Status)
+	assert.Equal(t, Unpublish, schedule.AsScenarioPublication().PublicationAction)
+
+	schedule.Status = ScenarioPublicationScheduleFailed
+	assert.Equal(t, ScenarioPublicationStatusFailed, schedule.AsScenarioPublication().Status)
+}
@@ -14,14 +14,29 @@ type ScenarioPublication struct {
 	ScenarioIterationId string
 	PublicationAction   PublicationAction
 	CreatedAt           time.Time
+	// Publications scheduled for later are listed along with the executed ones
+	Status      ScenarioPublicationStatus
+	ScheduledAt *time.Time
+	Error       *string
 }
 
+type ScenarioPublicationStatus string
+
+const (
+	ScenarioPublicationStatusExecuted  ScenarioPublicationStatus = "executed"
+	ScenarioPublicationStatusScheduled ScenarioPublicationStatus = "scheduled"
+	ScenarioPublicationStatusFailed    ScenarioPublicationStatus = "failed"
+)
+
 type PublicationAction int
 
 const (
 	Publish PublicationAction = iota
 	Unpublish
 	Prepare
+	StartCanary
+	PromoteCanary
+	RollbackCanary
 	UnknownPublicationAction
 )
 
@@ -34,6 +49,12 @@ func (o PublicationAction) String() string {
 		return "unpublish"
 	case Prepare:
 		return "prepare"
+	case StartCanary:
+		return "start_canary"
+	case PromoteCanary:
+		return "promote_canary"
+	case RollbackCanary:
+		return "rollback_canary"
 	}
 	return "unknown"
 }
@@ -47,6 +68,12 @@ func PublicationActionFrom(s string) PublicationAction {
 		return Unpublish
 	case "prepare":
 		return Prepare
+	case "start_canary":
+		return StartCanary
+	case "promote_canary":
+		return PromoteCanary
+	case "rollback_canary":
+		return RollbackCanary
 	case "unknown":
 		return UnknownPublicationAction
 	}
@@ -99,6 +126,8 @@ type ListScenarioPublicationsFilters struct {
 type PublishScenarioIterationInput struct {
 	ScenarioIterationId string
 	PublicationAction   PublicationAction
+	// Share of the trigger objects evaluated on the iteration, for the StartCanary action
+	CanaryPercentage int
 }
 
 type CreateScenarioPublicationInput struct {
@@ -42,8 +42,19 @@ func ScenarioTestStatusFrom(s string) TestrunStatus {
 	return Unknown
 }
 
+type ScenarioTestRunKind string
+
+const (
+	// Shadow test runs evaluate the live traffic of the scenario against another iteration
+	ScenarioTestRunShadow ScenarioTestRunKind = "shadow"
+	// Backtest test runs replay past decisions, see ScenarioBacktest. They are never up, so that
+	// live traffic is not evaluated against them.
+	ScenarioTestRunBacktest ScenarioTestRunKind = "backtest"
+)
+
 type ScenarioTestRun struct {
 	Id                      string
+	Kind                    ScenarioTestRunKind
 	ScenarioIterationId     string
 	ScenarioId              string
 	ScenarioLiveIterationId string
@@ -74,6 +85,7 @@ type ScenarioTestRunInput struct {
 
 func (i ScenarioTestRunInput) CreateDbInput(liveIterationId string) ScenarioTestRunCreateDbInput {
 	return ScenarioTestRunCreateDbInput{
+		Kind:               ScenarioTestRunShadow,
 		ScenarioId:         i.ScenarioId,
 		PhantomIterationId: i.PhantomIterationId,
 		LiveScenarioId:     liveIterationId,
@@ -82,6 +94,7 @@ func (i ScenarioTestRunInput) CreateDbInput(liveIterationId string) ScenarioTest
 }
 
 type ScenarioTestRunCreateDbInput struct {
+	Kind               ScenarioTestRunKind
 	ScenarioId         string
 	PhantomIterationId string
 	LiveScenarioId     string
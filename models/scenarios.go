@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -46,6 +47,42 @@ type Scenario struct {
 	// Enabling it on an existing scenario does not backfill, so the first run after the
 	// toggle scores everything once. Only honoured under the BATCH_EXECUTION_V2 flag.
 	DeduplicateBatchObjects bool
+
+	// When set, real-time decisions on a share of the trigger objects run this published
+	// iteration instead of the live one. See PickRolloutArm.
+	CanaryIterationId *string
+	CanaryPercentage  int
+	CanaryStartedAt   *time.Time
+
+	// When set, objects of the trigger object type ingested through IngestObjects are evaluated
+	// by the scenario through an async decision execution. When EvaluateOnIngestFields is not
+	// empty, an update of an existing object is only evaluated if one of those fields changed.
+	EvaluateOnIngest       bool
+	EvaluateOnIngestFields []string
+
+	// When set, scheduled executions only evaluate the objects written since the previous
+	// successful execution (see ScheduledExecutionWatermarkParams). When FullRefreshIntervalDays
+	// is positive, a full execution still runs every FullRefreshIntervalDays days, so that rules
+	// depending on time passing catch the objects that did not change.
+	IncrementalScheduledExecution bool
+	FullRefreshIntervalDays       int
+}
+
+// EvaluatesIngestedObject tells whether an object version written by the ingestion must be
+// evaluated by the scenario.
+func (s Scenario) EvaluatesIngestedObject(result IngestionResult) bool {
+	if !s.EvaluateOnIngest {
+		return false
+	}
+	if len(s.EvaluateOnIngestFields) == 0 || result.PreviousInternalId == "" {
+		return true
+	}
+	for _, field := range s.EvaluateOnIngestFields {
+		if slices.Contains(result.ChangedFields, field) {
+			return true
+		}
+	}
+	return false
 }
 
 type CreateScenarioInput struct {
@@ -61,6 +98,11 @@ type UpdateScenarioInput struct {
 	Name                    *string
 	Archived                *bool
 	DeduplicateBatchObjects *bool
+	EvaluateOnIngest        *bool
+	EvaluateOnIngestFields  *[]string
+
+	IncrementalScheduledExecution *bool
+	FullRefreshIntervalDays       *int
 }
 
 type ListAllScenariosFilters struct {
@@ -2,6 +2,7 @@ package models
 
 import (
 	"testing"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models/ast"
 	"github.com/stretchr/testify/assert"
@@ -89,3 +90,25 @@ func TestFilterToSql(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduledExecutionWatermarkParams_FullRefreshDue(t *testing.T) {
+	lastFullRefresh := time.Date(2026, 10, 1, 2, 0, 0, 0, time.UTC)
+	params := ScheduledExecutionWatermarkParams{
+		ScenarioIterationId: "iteration",
+		LastFullRefreshAt:   lastFullRefresh,
+	}
+
+	t.Run("other iteration", func(t *testing.T) {
+		assert.True(t, params.FullRefreshDue(Scenario{}, "other_iteration", lastFullRefresh))
+	})
+
+	t.Run("no full refresh interval", func(t *testing.T) {
+		assert.False(t, params.FullRefreshDue(Scenario{}, "iteration", lastFullRefresh.AddDate(1, 0, 0)))
+	})
+
+	t.Run("full refresh interval", func(t *testing.T) {
+		scenario := Scenario{FullRefreshIntervalDays: 7}
+		assert.False(t, params.FullRefreshDue(scenario, "iteration", lastFullRefresh.AddDate(0, 0, 6)))
+		assert.True(t, params.FullRefreshDue(scenario, "iteration", lastFullRefresh.AddDate(0, 0, 7)))
+	})
+}
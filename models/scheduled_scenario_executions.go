@@ -36,6 +36,34 @@ type ScheduledExecution struct {
 	// the scenario, since a run can span several River slices and the setting must not
 	// change mid-run (see the scheduled_execution_deduplicate migration).
 	DeduplicateObjects bool
+
+	// Set when the execution only evaluates the objects written after this time, resolved when
+	// the objects are listed (see Scenario.IncrementalScheduledExecution). Nil for a full execution.
+	IncrementalSince *time.Time
+}
+
+// ScheduledExecutionWatermarkParams is stored with the watermark of the scheduled executions of a
+// scenario, whose time is the start of its last successful execution.
+type ScheduledExecutionWatermarkParams struct {
+	ScenarioIterationId string    `json:"scenario_iteration_id"`
+	LastFullRefreshAt   time.Time `json:"last_full_refresh_at"`
+}
+
+func ScheduledExecutionWatermarkType(scenarioId string) WatermarkType {
+	return SpecializedWatermark(WatermarkTypeScheduledExecution, scenarioId)
+}
+
+// FullRefreshDue tells whether the next execution of the scenario's iteration must evaluate every
+// object, either because the watermark was left by another iteration or because the scenario's
+// full refresh interval elapsed since the last full execution.
+func (p ScheduledExecutionWatermarkParams) FullRefreshDue(scenario Scenario, scenarioIterationId string, now time.Time) bool {
+	if p.ScenarioIterationId != scenarioIterationId {
+		return true
+	}
+	if scenario.FullRefreshIntervalDays <= 0 {
+		return false
+	}
+	return !now.Before(p.LastFullRefreshAt.AddDate(0, 0, scenario.FullRefreshIntervalDays))
 }
 
 type PaginatedScheduledExecutions struct {
@@ -97,6 +125,7 @@ type UpdateScheduledExecutionInput struct {
 	NumberOfPlannedDecisions *int
 	ManifestBlobKey          *string
 	Deadline                 *time.Time
+	IncrementalSince         *time.Time
 }
 
 // AdvanceScheduledExecutionManifestInput records progress of the v2 coordinator after a
@@ -183,6 +183,11 @@ type ScreeningRawSearchResponseWithMatches struct {
 }
 
 func (s ScreeningRawSearchResponseWithMatches) AdaptScreeningFromSearchResponse(query OpenSanctionsQuery) ScreeningWithMatches {
+	if query.IsIdentifierOnly() {
+		s.Matches = query.FilterExactIdentifierMatches(s.Matches)
+		s.Count = len(s.Matches)
+	}
+
 	screening := ScreeningWithMatches{
 		Screening: Screening{
 			ScreeningConfigId: query.Config.Id,
@@ -253,6 +258,8 @@ type ScreeningMatchUpdate struct {
 	Status     ScreeningMatchStatus
 	Comment    *ScreeningMatchComment
 	Whitelist  bool
+
+	WhitelistOptions ScreeningWhitelistOptions
 }
 
 type ScreeningRefineRequest struct {
@@ -285,12 +292,3 @@ type ScreeningFileInput struct {
 	FileReference string
 	FileName      string
 }
-
-type ScreeningWhitelist struct {
-	Id             string
-	OrgId          uuid.UUID
-	CounterpartyId string
-	EntityId       string
-	WhitelistedBy  *string
-	CreatedAt      time.Time
-}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Identifier properties are matched exactly (after normalization) instead of fuzzily. A
+// screening query only made of identifiers returns no match unless a candidate carries the
+// very same identifier.
+var ScreeningIdentifierProperties = []FollowTheMoneyProperty{
+	FollowTheMoneyPropertyPublicKey,
+	FollowTheMoneyPropertyImoNumber,
+	FollowTheMoneyPropertyMmsi,
+}
+
+var (
+	evmAddressRegexp     = regexp.MustCompile(`^0x[0-9a-f]{40}$`)
+	bech32AddressRegexp  = regexp.MustCompile(`^(bc|tb|bcrt|ltc|tltc)1[02-9ac-hj-np-z]{6,87}$`)
+	cashAddrRegexp       = regexp.MustCompile(`^[qp][02-9ac-hj-np-z]{41}$`)
+	nonDigitRegexp       = regexp.MustCompile(`[^0-9]`)
+	imoNumberPrefixRegex = regexp.MustCompile(`(?i)^\s*imo[\s:.-]*`)
+)
+
+func IsScreeningIdentifierProperty(property string) bool {
+	return slices.Contains(ScreeningIdentifierProperties, FollowTheMoneyProperty(property))
+}
+
+// NormalizeScreeningIdentifier returns the canonical form of an identifier, used both in
+// the query sent to the provider and to compare it with the identifiers of the candidates.
+// The boolean is false if the value is not a valid identifier of that kind.
+func NormalizeScreeningIdentifier(property FollowTheMoneyProperty, value string) (string, bool) {
+	switch property {
+	case FollowTheMoneyPropertyPublicKey:
+		return normalizeCryptoWalletAddress(value)
+	case FollowTheMoneyPropertyImoNumber:
+		return normalizeImoNumber(value)
+	case FollowTheMoneyPropertyMmsi:
+		digits := nonDigitRegexp.ReplaceAllString(value, "")
+		return digits, len(digits) == 9
+	default:
+		return strings.TrimSpace(value), false
+	}
+}
+
+// normalizeCryptoWalletAddress is chain-aware: hexadecimal (EVM) and bech32 addresses are
+// case-insensitive and are lowercased, while base58 addresses (bitcoin legacy, Tron, Solana...)
+// are case-sensitive and are kept as they are. Payment URI schemes and parameters are removed.
+func normalizeCryptoWalletAddress(value string) (string, bool) {
+	address := strings.TrimSpace(value)
+	if idx := strings.LastIndex(address, ":"); idx >= 0 {
+		address = address[idx+1:]
+	}
+	if idx := strings.IndexAny(address, "?@/"); idx >= 0 {
+		address = address[:idx]
+	}
+	if address == "" {
+		return "", false
+	}
+
+	lower := strings.ToLower(address)
+
+	switch {
+	case evmAddressRegexp.MatchString(lower),
+		bech32AddressRegexp.MatchString(lower),
+		cashAddrRegexp.MatchString(lower):
+		return lower, true
+	default:
+		return address, true
+	}
+}
+
+// normalizeImoNumber validates the IMO number checksum and returns it in the "IMO1234567"
+// form used by the sanctions lists.
+func normalizeImoNumber(value string) (string, bool) {
+	digits := nonDigitRegexp.ReplaceAllString(imoNumberPrefixRegex.ReplaceAllString(value, ""), "")
+	if len(digits) != 7 {
+		return digits, false
+	}
+
+	sum := 0
+	for idx := range 6 {
+		sum += int(digits[idx]-'0') * (7 - idx)
+	}
+	if sum%10 != int(digits[6]-'0') {
+		return digits, false
+	}
+
+	return "IMO" + digits, true
+}
+
+// IsIdentifierOnly is true if the query only contains identifier properties.
+func (q OpenSanctionsCheckQuery) IsIdentifierOnly() bool {
+	if len(q.Filters) == 0 {
+		return false
+	}
+
+	for property := range q.Filters {
+		if !IsScreeningIdentifierProperty(property) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (q OpenSanctionsQuery) IsIdentifierOnly() bool {
+	return len(q.Queries) > 0 && !slices.ContainsFunc(q.Queries, func(subquery OpenSanctionsCheckQuery) bool {
+		return !subquery.IsIdentifierOnly()
+	})
+}
+
+// WithNormalizedIdentifiers returns a copy of the query where the value of identifier properties
+// in every subquery is replaced by its canonical form. The query itself, which is stored with the
+// screening, is left untouched. Invalid values are kept as is and will not produce exact matches.
+func (q OpenSanctionsQuery) WithNormalizedIdentifiers() OpenSanctionsQuery {
+	q.Queries = slices.Clone(q.Queries)
+
+	for idx, subquery := range q.Queries {
+		var filters OpenSanctionsFilter
+
+		for property, values := range subquery.Filters {
+			if !IsScreeningIdentifierProperty(property) {
+				continue
+			}
+			if filters == nil {
+				filters = maps.Clone(subquery.Filters)
+			}
+
+			filters[property] = make([]string, 0, len(values))
+			for _, value := range values {
+				normalized, _ := NormalizeScreeningIdentifier(FollowTheMoneyProperty(property), value)
+				if normalized != "" && !slices.Contains(filters[property], normalized) {
+					filters[property] = append(filters[property], normalized)
+				}
+			}
+		}
+
+		if filters != nil {
+			q.Queries[idx].Filters = filters
+		}
+	}
+
+	return q
+}
+
+// FilterExactIdentifierMatches keeps the matches that carry one of the identifiers of the
+// query. It is a no-op for queries that are not identifier-only.
+func (q OpenSanctionsQuery) FilterExactIdentifierMatches(matches []ScreeningMatch) []ScreeningMatch {
+	if !q.IsIdentifierOnly() {
+		return matches
+	}
+
+	wanted := make(map[FollowTheMoneyProperty][]string)
+	for _, subquery := range q.Queries {
+		for property, values := range subquery.Filters {
+			for _, value := range values {
+				if normalized, ok := NormalizeScreeningIdentifier(
+					FollowTheMoneyProperty(property), value); ok {
+					wanted[FollowTheMoneyProperty(property)] = append(
+						wanted[FollowTheMoneyProperty(property)], normalized)
+				}
+			}
+		}
+	}
+
+	out := make([]ScreeningMatch, 0, len(matches))
+
+	for _, match := range matches {
+		var payload struct {
+			Properties map[string][]string `json:"properties"`
+		}
+		if err := json.Unmarshal(match.Payload, &payload); err != nil {
+			continue
+		}
+
+		if slices.ContainsFunc(ScreeningIdentifierProperties, func(property FollowTheMoneyProperty) bool {
+			return slices.ContainsFunc(payload.Properties[property.String()], func(value string) bool {
+				normalized, ok := NormalizeScreeningIdentifier(property, value)
+				return ok && slices.Contains(wanted[property], normalized)
+			})
+		}) {
+			out = append(out, match)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeScreeningIdentifier(t *testing.T) {
+	tts := []struct {
+		property FollowTheMoneyProperty
+		input    string
+		output   string
+		valid    bool
+	}{
+		{FollowTheMoneyPropertyPublicKey, "0x8576ACC5C05D6CE88F4E49BF65BDF0C62F91353C", "0x8576acc5c05d6ce88f4e49bf65bdf0c62f91353c", true},
+		{FollowTheMoneyPropertyPublicKey, "ethereum:0x8576acc5c05d6ce88f4e49bf65bdf0c62f91353c@1", "0x8576acc5c05d6ce88f4e49bf65bdf0c62f91353c", true},
+		{FollowTheMoneyPropertyPublicKey, "BC1QXY2KGDYGJRSQTZQ2N0YRF2493P83KKFJHX0WLH", "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh", true},
+		{FollowTheMoneyPropertyPublicKey, "bitcoin:1BoatSLRHtKNngkdXEeobR76b53LETtpyT?amount=1", "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", true},
+		{FollowTheMoneyPropertyPublicKey, " TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW ", "TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW", true},
+		{FollowTheMoneyPropertyPublicKey, "   ", "", false},
+		{FollowTheMoneyPropertyImoNumber, "9321483", "IMO9321483", true},
+		{FollowTheMoneyPropertyImoNumber, "IMO 9321483", "IMO9321483", true},
+		{FollowTheMoneyPropertyImoNumber, "imo: 9321483", "IMO9321483", true},
+		{FollowTheMoneyPropertyImoNumber, "9321484", "9321484", false},
+		{FollowTheMoneyPropertyImoNumber, "932148", "932148", false},
+		{FollowTheMoneyPropertyMmsi, "636 092 932", "636092932", true},
+		{FollowTheMoneyPropertyMmsi, "63609293", "63609293", false},
+	}
+
+	for _, tt := range tts {
+		output, valid := NormalizeScreeningIdentifier(tt.property, tt.input)
+
+		assert.Equal(t, tt.output, output, tt.input)
+		assert.Equal(t, tt.valid, valid, tt.input)
+	}
+}
+
+func TestOpenSanctionsQueryIsIdentifierOnly(t *testing.T) {
+	assert.True(t, OpenSanctionsQuery{Queries: []OpenSanctionsCheckQuery{
+		{Type: "CryptoWallet", Filters: OpenSanctionsFilter{"publicKey": {"0xabc"}}},
+	}}.IsIdentifierOnly())
+	assert.True(t, OpenSanctionsQuery{Queries: []OpenSanctionsCheckQuery{
+		{Type: "Vessel", Filters: OpenSanctionsFilter{"imoNumber": {"9321483"}, "mmsi": {"636092932"}}},
+	}}.IsIdentifierOnly())
+	assert.False(t, OpenSanctionsQuery{Queries: []OpenSanctionsCheckQuery{
+		{Type: "Vessel", Filters: OpenSanctionsFilter{"name": {"Ever Given"}, "imoNumber": {"9811000"}}},
+	}}.IsIdentifierOnly())
+	assert.False(t, OpenSanctionsQuery{}.IsIdentifierOnly())
+}
+
+func TestOpenSanctionsQueryWithNormalizedIdentifiers(t *testing.T) {
+	filters := OpenSanctionsFilter{
+		"name":      {"Ever Given"},
+		"imoNumber": {"IMO 9321483", "9321483"},
+	}
+	query := OpenSanctionsQuery{Queries: []OpenSanctionsCheckQuery{{Type: "Vessel", Filters: filters}}}
+
+	normalized := query.WithNormalizedIdentifiers()
+
+	assert.Equal(t, []string{"IMO9321483"}, normalized.Queries[0].Filters["imoNumber"])
+	assert.Equal(t, []string{"Ever Given"}, normalized.Queries[0].Filters["name"])
+	assert.Equal(t, []string{"IMO 9321483", "9321483"}, query.Queries[0].Filters["imoNumber"],
+		"the query must not be mutated")
+	assert.Equal(t, []string{"IMO 9321483", "9321483"}, filters["imoNumber"], "original filters must not be mutated")
+}
+
+func TestOpenSanctionsQueryFilterExactIdentifierMatches(t *testing.T) {
+	matches := []ScreeningMatch{
+		{EntityId: "exact", Payload: []byte(`{"properties":{"publicKey":["0x8576ACC5C05D6CE88F4E49BF65BDF0C62F91353C"]}}`)},
+		{EntityId: "close", Payload: []byte(`{"properties":{"publicKey":["0x8576acc5c05d6ce88f4e49bf65bdf0c62f91353d"]}}`)},
+		{EntityId: "other", Payload: []byte(`{"properties":{"name":["Someone"]}}`)},
+	}
+
+	query := OpenSanctionsQuery{Queries: []OpenSanctionsCheckQuery{
+		{Type: "CryptoWallet", Filters: OpenSanctionsFilter{"publicKey": {"0x8576acc5c05d6ce88f4e49bf65bdf0c62f91353c"}}},
+	}}
+
+	filtered := query.FilterExactIdentifierMatches(matches)
+
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "exact", filtered[0].EntityId)
+
+	query = OpenSanctionsQuery{Queries: []OpenSanctionsCheckQuery{
+		{Type: "Person", Filters: OpenSanctionsFilter{"name": {"Someone"}}},
+	}}
+
+	assert.Len(t, query.FilterExactIdentifierMatches(matches), 3)
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ScreeningWhitelist struct {
+	Id             string
+	OrgId          uuid.UUID
+	CounterpartyId string
+	EntityId       string
+	Scope          ScreeningWhitelistScope
+	ExpiresAt      *time.Time
+	// Set once the entry has been reported as nearing its expiry, until it is renewed
+	ExpiryNotifiedAt *time.Time
+	WhitelistedBy    *string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// SCREENING_WHITELIST_EXPIRY_NOTICE is how long before their expiry whitelist entries are reported
+// for re-review, so that they can be renewed before their matches show up again.
+const SCREENING_WHITELIST_EXPIRY_NOTICE = 14 * 24 * time.Hour
+
+// ScreeningWhitelistScope narrows down where a whitelist entry applies, on top of its
+// counterparty. Fields left empty are not restrictive.
+type ScreeningWhitelistScope struct {
+	// Stable ID of the scenario screening config or of the continuous screening config
+	ScreeningConfigStableId *uuid.UUID
+	// Screened (or monitored) object
+	ObjectType *string
+	ObjectId   *string
+}
+
+// NewScreeningWhitelistScope builds the context of a screening, used both to check which
+// whitelist entries apply to it and to scope the entries created while reviewing it.
+func NewScreeningWhitelistScope(screeningConfigStableId, objectType, objectId string) ScreeningWhitelistScope {
+	scope := ScreeningWhitelistScope{}
+
+	if stableId, err := uuid.Parse(screeningConfigStableId); err == nil {
+		scope.ScreeningConfigStableId = &stableId
+	}
+	if objectType != "" && objectId != "" {
+		scope.ObjectType = &objectType
+		scope.ObjectId = &objectId
+	}
+
+	return scope
+}
+
+func (s ScreeningWhitelistScope) IsEmpty() bool {
+	return s.ScreeningConfigStableId == nil && s.ObjectType == nil && s.ObjectId == nil
+}
+
+func (w ScreeningWhitelist) IsExpired(now time.Time) bool {
+	return w.ExpiresAt != nil && !w.ExpiresAt.After(now)
+}
+
+// AppliesTo tells whether the whitelist entry should be honoured for a screening performed in
+// the given context. Expired entries never apply, and every scope set on the entry must be
+// matched by the context.
+func (w ScreeningWhitelist) AppliesTo(context ScreeningWhitelistScope, now time.Time) bool {
+	if w.IsExpired(now) {
+		return false
+	}
+
+	if w.Scope.ScreeningConfigStableId != nil && (context.ScreeningConfigStableId == nil ||
+		*w.Scope.ScreeningConfigStableId != *context.ScreeningConfigStableId) {
+		return false
+	}
+	if w.Scope.ObjectType != nil && (context.ObjectType == nil || *w.Scope.ObjectType != *context.ObjectType) {
+		return false
+	}
+	if w.Scope.ObjectId != nil && (context.ObjectId == nil || *w.Scope.ObjectId != *context.ObjectId) {
+		return false
+	}
+
+	return true
+}
+
+func FilterApplicableScreeningWhitelists(
+	entries []ScreeningWhitelist,
+	context ScreeningWhitelistScope,
+	now time.Time,
+) []ScreeningWhitelist {
+	out := make([]ScreeningWhitelist, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.AppliesTo(context, now) {
+			out = append(out, entry)
+		}
+	}
+
+	return out
+}
+
+type ScreeningWhitelistCreateInput struct {
+	OrgId          uuid.UUID
+	CounterpartyId string
+	EntityId       string
+	Scope          ScreeningWhitelistScope
+	ExpiresAt      *time.Time
+	WhitelistedBy  *UserId
+}
+
+// ScreeningWhitelistOptions are chosen by the reviewer when a match is whitelisted from the
+// review of a screening, the scopes being taken from the reviewed screening.
+type ScreeningWhitelistOptions struct {
+	ScopeToScreeningConfig bool
+	ScopeToObject          bool
+	ExpiresAt              *time.Time
+}
+
+// Scope returns the scope of an entry created from the review of a screening performed in the
+// given context.
+func (o ScreeningWhitelistOptions) Scope(context ScreeningWhitelistScope) ScreeningWhitelistScope {
+	scope := ScreeningWhitelistScope{}
+
+	if o.ScopeToScreeningConfig {
+		scope.ScreeningConfigStableId = context.ScreeningConfigStableId
+	}
+	if o.ScopeToObject {
+		scope.ObjectType = context.ObjectType
+		scope.ObjectId = context.ObjectId
+	}
+
+	return scope
+}
+
+type ScreeningWhitelistSearchFilters struct {
+	CounterpartyId *string
+	EntityId       *string
+	IncludeExpired bool
+	// Only return the entries expiring before that date, to list the entries up for re-review
+	ExpiresBefore *time.Time
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestScreeningWhitelistAppliesTo(t *testing.T) {
+	now := time.Now()
+	configId := uuid.New()
+	otherConfigId := uuid.New()
+
+	context := ScreeningWhitelistScope{
+		ScreeningConfigStableId: &configId,
+		ObjectType:              ptr("transactions"),
+		ObjectId:                ptr("tx-1"),
+	}
+
+	tts := []struct {
+		name     string
+		entry    ScreeningWhitelist
+		context  ScreeningWhitelistScope
+		expected bool
+	}{
+		{"unscoped", ScreeningWhitelist{}, context, true},
+		{"unscoped in empty context", ScreeningWhitelist{}, ScreeningWhitelistScope{}, true},
+		{"not expired yet", ScreeningWhitelist{ExpiresAt: ptr(now.Add(time.Hour))}, context, true},
+		{"expired", ScreeningWhitelist{ExpiresAt: ptr(now.Add(-time.Hour))}, context, false},
+		{
+			"same config",
+			ScreeningWhitelist{Scope: ScreeningWhitelistScope{ScreeningConfigStableId: &configId}},
+			context, true,
+		},
+		{
+			"other config",
+			ScreeningWhitelist{Scope: ScreeningWhitelistScope{ScreeningConfigStableId: &otherConfigId}},
+			context, false,
+		},
+		{
+			"config scope without config in context",
+			ScreeningWhitelist{Scope: ScreeningWhitelistScope{ScreeningConfigStableId: &configId}},
+			ScreeningWhitelistScope{}, false,
+		},
+		{
+			"same object",
+			ScreeningWhitelist{Scope: ScreeningWhitelistScope{
+				ObjectType: ptr("transactions"), ObjectId: ptr("tx-1"),
+			}},
+			context, true,
+		},
+		{
+			"other object",
+			ScreeningWhitelist{Scope: ScreeningWhitelistScope{
+				ObjectType: ptr("transactions"), ObjectId: ptr("tx-2"),
+			}},
+			context, false,
+		},
+	}
+
+	for _, tt := range tts {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.entry.AppliesTo(tt.context, now))
+		})
+	}
+}
+
+func TestNewScreeningWhitelistScope(t *testing.T) {
+	configId := uuid.New()
+
+	scope := NewScreeningWhitelistScope(configId.String(), "transactions", "tx-1")
+	assert.Equal(t, &configId, scope.ScreeningConfigStableId)
+	assert.Equal(t, ptr("transactions"), scope.ObjectType)
+	assert.Equal(t, ptr("tx-1"), scope.ObjectId)
+
+	assert.True(t, NewScreeningWhitelistScope("", "transactions", "").IsEmpty())
+}
+
+func TestScreeningWhitelistOptionsScope(t *testing.T) {
+	configId := uuid.New()
+	context := NewScreeningWhitelistScope(configId.String(), "transactions", "tx-1")
+
+	assert.True(t, ScreeningWhitelistOptions{}.Scope(context).IsEmpty())
+
+	scope := ScreeningWhitelistOptions{ScopeToScreeningConfig: true}.Scope(context)
+	assert.Equal(t, &configId, scope.ScreeningConfigStableId)
+	assert.Nil(t, scope.ObjectType)
+
+	scope = ScreeningWhitelistOptions{ScopeToObject: true}.Scope(context)
+	assert.Nil(t, scope.ScreeningConfigStableId)
+	assert.Equal(t, ptr("tx-1"), scope.ObjectId)
+}
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"path"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,18 +18,80 @@ type UploadLog struct {
 	UserId         string
 	FileName       string
 	TableName      string
+	FileFormat     IngestionFileFormat
 	UploadStatus   UploadStatus
 	StartedAt      time.Time
 	FinishedAt     *time.Time
 	LinesProcessed int
 	RowsIngested   int
-	// ByteOffset is the offset of the first CSV row not yet ingested. Zero means the file has not
-	// been read yet; ingestion resumes from here when a previous attempt ran out of time.
+	// ByteOffset is the offset of the first CSV or NDJSON row not yet ingested. Parquet files are
+	// not read as a stream, so it is the index of the first row not yet ingested instead. Zero means
+	// the file has not been read yet; ingestion resumes from here when a previous attempt ran out of
+	// time.
 	ByteOffset int64
 	InputError *string
 	Error      *string
 }
 
+type IngestionFileFormat string
+
+const (
+	IngestionFileFormatCsv     IngestionFileFormat = "csv"
+	IngestionFileFormatNdjson  IngestionFileFormat = "ndjson"
+	IngestionFileFormatParquet IngestionFileFormat = "parquet"
+)
+
+// IngestionFileFormatFromContentType returns the format of an uploaded file from the content type it
+// is uploaded with.
+func IngestionFileFormatFromContentType(contentType string) (IngestionFileFormat, bool) {
+	switch contentType {
+	case "text/csv":
+		return IngestionFileFormatCsv, true
+	case "application/x-ndjson":
+		return IngestionFileFormatNdjson, true
+	case "application/vnd.apache.parquet":
+		return IngestionFileFormatParquet, true
+	}
+	return "", false
+}
+
+// IngestionFileFormatOfUpload returns the format of a file uploaded in a multipart form. HTTP clients
+// rarely know the content type of NDJSON and Parquet files, so the extension of the file name is
+// used when the content type is not one of the supported formats. Files are read as CSV by default.
+func IngestionFileFormatOfUpload(contentType, fileName string) IngestionFileFormat {
+	if fileFormat, ok := IngestionFileFormatFromContentType(contentType); ok {
+		return fileFormat
+	}
+	switch strings.ToLower(path.Ext(fileName)) {
+	case ".ndjson", ".jsonl":
+		return IngestionFileFormatNdjson
+	case ".parquet":
+		return IngestionFileFormatParquet
+	}
+	return IngestionFileFormatCsv
+}
+
+func (f IngestionFileFormat) ContentType() string {
+	switch f {
+	case IngestionFileFormatNdjson:
+		return "application/x-ndjson"
+	case IngestionFileFormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+	return "text/csv"
+}
+
+// Upload logs created before NDJSON and Parquet support have no format
+func IngestionFileFormatFrom(s string) IngestionFileFormat {
+	switch s {
+	case "ndjson":
+		return IngestionFileFormatNdjson
+	case "parquet":
+		return IngestionFileFormatParquet
+	}
+	return IngestionFileFormatCsv
+}
+
 // CsvIngestionOutcome tells the CsvIngestionWorker whether an upload log is done with or whether it
 // ran out of time and should be resumed from its checkpoint on a later attempt. It exists so the
 // ingestion usecase does not have to return river.JobSnooze itself.
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngestionFileFormatOfUpload(t *testing.T) {
+	assert.Equal(t, IngestionFileFormatCsv, IngestionFileFormatOfUpload("text/csv", "transactions.ndjson"))
+	assert.Equal(t, IngestionFileFormatNdjson, IngestionFileFormatOfUpload("application/x-ndjson", "transactions"))
+	assert.Equal(t, IngestionFileFormatNdjson, IngestionFileFormatOfUpload("application/octet-stream", "transactions.jsonl"))
+	assert.Equal(t, IngestionFileFormatParquet, IngestionFileFormatOfUpload("", "Transactions.PARQUET"))
+	assert.Equal(t, IngestionFileFormatCsv, IngestionFileFormatOfUpload("application/octet-stream", "transactions.txt"))
+}
@@ -18,6 +18,8 @@ const (
 	WatermarkTypeMergedAnalyticsDecisions     WatermarkType = "analytics_merged_decisions"
 	WatermarkTypeMergedAnalyticsDecisionRules WatermarkType = "analytics_merged_decision_rules"
 	WatermarkTypeMergedAnalyticsScreenings    WatermarkType = "analytics_merged_screenings"
+
+	WatermarkTypeScheduledExecution WatermarkType = "scheduled_execution"
 )
 
 func (t WatermarkType) String() string {
@@ -39,6 +41,8 @@ func WatermarkTypeFromString(s string) (WatermarkType, error) {
 		return WatermarkType(WatermarkTypeMergedAnalyticsDecisionRules.String()), nil
 	case "analytics_merged_screenings":
 		return WatermarkTypeMergedAnalyticsScreenings, nil
+	case "scheduled_execution":
+		return WatermarkTypeScheduledExecution, nil
 	default:
 		return "", errors.New("invalid watermark type")
 	}
@@ -39,6 +39,15 @@ const (
 	WebhookEventType_ContinuousScreeningCreated       WebhookEventType = "continuous_screening.created"
 	WebhookEventType_ContinuousScreeningMatchReviewed WebhookEventType = "continuous_screening.match_reviewed"
 	WebhookEventType_ScoringRiskLevelChangedChanged   WebhookEventType = "user_scoring.risk_level_changed"
+	WebhookEventType_PublicationRequestSubmitted      WebhookEventType = "scenario_publication_request.submitted"
+	WebhookEventType_PublicationRequestApproved       WebhookEventType = "scenario_publication_request.approved"
+	WebhookEventType_PublicationRequestRejected       WebhookEventType = "scenario_publication_request.rejected"
+	WebhookEventType_PublicationRequestCancelled      WebhookEventType = "scenario_publication_request.cancelled"
+	WebhookEventType_PublicationRequestPublished      WebhookEventType = "scenario_publication_request.published"
+	WebhookEventType_PublicationRequestFailed         WebhookEventType = "scenario_publication_request.failed"
+	WebhookEventType_PublicationSchedulePreparation   WebhookEventType = "scenario_publication_schedule.preparation_not_ready"
+	WebhookEventType_PublicationScheduleFailed        WebhookEventType = "scenario_publication_schedule.failed"
+	WebhookEventType_ScreeningWhitelistExpiring       WebhookEventType = "screening_whitelist.expiring"
 )
 
 var validWebhookEventTypes = []WebhookEventType{
@@ -56,6 +65,15 @@ var validWebhookEventTypes = []WebhookEventType{
 	WebhookEventType_ContinuousScreeningCreated,
 	WebhookEventType_ContinuousScreeningMatchReviewed,
 	WebhookEventType_ScoringRiskLevelChangedChanged,
+	WebhookEventType_PublicationRequestSubmitted,
+	WebhookEventType_PublicationRequestApproved,
+	WebhookEventType_PublicationRequestRejected,
+	WebhookEventType_PublicationRequestCancelled,
+	WebhookEventType_PublicationRequestPublished,
+	WebhookEventType_PublicationRequestFailed,
+	WebhookEventType_PublicationSchedulePreparation,
+	WebhookEventType_PublicationScheduleFailed,
+	WebhookEventType_ScreeningWhitelistExpiring,
 }
 
 type WebhookEventContent struct {
@@ -78,6 +96,9 @@ type WebhookEventData struct {
 	ContinuousScreening      *ContinuousScreeningWithMatches
 	ContinuousScreeningMatch *ContinuousScreeningMatch
 	Score                    *ScoringScore
+	PublicationRequest       *ScenarioPublicationRequest
+	PublicationSchedule      *ScenarioPublicationSchedule
+	ScreeningWhitelist       *ScreeningWhitelist
 }
 
 type WebhookEvent struct {
@@ -248,6 +269,47 @@ func NewWebhookScoringScoreChanged(score ScoringScore) WebhookEventContent {
 	})
 }
 
+// NewWebhookEventPublicationRequest notifies of the new status of a publication request: each status
+// has its own event type.
+func NewWebhookEventPublicationRequest(r ScenarioPublicationRequest) WebhookEventContent {
+	var eventType WebhookEventType
+	switch r.Status {
+	case ScenarioPublicationRequestPending:
+		eventType = WebhookEventType_PublicationRequestSubmitted
+	case ScenarioPublicationRequestApproved:
+		eventType = WebhookEventType_PublicationRequestApproved
+	case ScenarioPublicationRequestRejected:
+		eventType = WebhookEventType_PublicationRequestRejected
+	case ScenarioPublicationRequestCancelled:
+		eventType = WebhookEventType_PublicationRequestCancelled
+	case ScenarioPublicationRequestPublished:
+		eventType = WebhookEventType_PublicationRequestPublished
+	case ScenarioPublicationRequestFailed:
+		eventType = WebhookEventType_PublicationRequestFailed
+	}
+	return newWebhookContent(eventType, WebhookEventData{PublicationRequest: &r})
+}
+
+func NewWebhookEventPublicationSchedulePreparationNotReady(s ScenarioPublicationSchedule) WebhookEventContent {
+	return newWebhookContent(WebhookEventType_PublicationSchedulePreparation, WebhookEventData{
+		PublicationSchedule: &s,
+	})
+}
+
+func NewWebhookEventPublicationScheduleFailed(s ScenarioPublicationSchedule) WebhookEventContent {
+	return newWebhookContent(WebhookEventType_PublicationScheduleFailed, WebhookEventData{
+		PublicationSchedule: &s,
+	})
+}
+
+// NewWebhookEventScreeningWhitelistExpiring notifies that a whitelist entry is about to expire and
+// must be re-reviewed, and renewed if it still holds.
+func NewWebhookEventScreeningWhitelistExpiring(w ScreeningWhitelist) WebhookEventContent {
+	return newWebhookContent(WebhookEventType_ScreeningWhitelistExpiring, WebhookEventData{
+		ScreeningWhitelist: &w,
+	})
+}
+
 type Webhook struct {
 	Id                string
 	OrganizationId    uuid.UUID
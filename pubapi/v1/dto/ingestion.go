@@ -15,6 +15,7 @@ type AsyncUpload struct {
 type UploadLog struct {
 	Id            uuid.UUID  `json:"id"`
 	Status        string     `json:"status"`
+	FileFormat    string     `json:"file_format"`
 	StartedAt     time.Time  `json:"started_at"`
 	FinishedAt    *time.Time `json:"finished_at"`
 	RowsProcessed int        `json:"rows_processed"`
@@ -26,6 +27,7 @@ func AdaptUploadLog(log models.UploadLog) UploadLog {
 	return UploadLog{
 		Id:            log.Id,
 		Status:        string(log.UploadStatus),
+		FileFormat:    string(log.FileFormat),
 		StartedAt:     log.StartedAt,
 		FinishedAt:    log.FinishedAt,
 		RowsProcessed: max(log.LinesProcessed, log.RowsIngested),
@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pubapi/types"
+	"github.com/google/uuid"
+)
+
+type ScenarioPublicationRequest struct {
+	Id                  uuid.UUID       `json:"id"`
+	ScenarioId          string          `json:"scenario_id"`
+	ScenarioIterationId string          `json:"scenario_iteration_id"`
+	PublicationAction   string          `json:"publication_action"`
+	CanaryPercentage    int             `json:"canary_percentage,omitempty"`
+	Status              string          `json:"status"`
+	Comment             string          `json:"comment"`
+	RequestedBy         string          `json:"requested_by"`
+	ReviewedBy          *string         `json:"reviewed_by"`
+	ReviewComment       *string         `json:"review_comment"`
+	Error               *string         `json:"error"`
+	CreatedAt           types.DateTime  `json:"created_at"`
+	ReviewedAt          *types.DateTime `json:"reviewed_at"`
+}
+
+func AdaptScenarioPublicationRequest(m models.ScenarioPublicationRequest) ScenarioPublicationRequest {
+	r := ScenarioPublicationRequest{
+		Id:                  m.Id,
+		ScenarioId:          m.ScenarioId,
+		ScenarioIterationId: m.ScenarioIterationId,
+		PublicationAction:   m.PublicationAction.String(),
+		CanaryPercentage:    m.CanaryPercentage,
+		Status:              string(m.Status),
+		Comment:             m.Comment,
+		RequestedBy:         string(m.RequestedBy),
+		ReviewComment:       m.ReviewComment,
+		Error:               m.Error,
+		CreatedAt:           types.DateTime(m.CreatedAt),
+	}
+	if m.ReviewedBy != nil {
+		reviewedBy := string(*m.ReviewedBy)
+		r.ReviewedBy = &reviewedBy
+	}
+	if m.ReviewedAt != nil {
+		reviewedAt := types.DateTime(*m.ReviewedAt)
+		r.ReviewedAt = &reviewedAt
+	}
+	return r
+}
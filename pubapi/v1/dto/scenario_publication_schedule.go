@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pubapi/types"
+	"github.com/google/uuid"
+)
+
+type ScenarioPublicationSchedule struct {
+	Id                  uuid.UUID      `json:"id"`
+	ScenarioId          string         `json:"scenario_id"`
+	ScenarioIterationId string         `json:"scenario_iteration_id"`
+	PublicationAction   string         `json:"publication_action"`
+	ScheduledAt         types.DateTime `json:"scheduled_at"`
+	Status              string         `json:"status"`
+	Error               *string        `json:"error"`
+}
+
+func AdaptScenarioPublicationSchedule(m models.ScenarioPublicationSchedule) ScenarioPublicationSchedule {
+	return ScenarioPublicationSchedule{
+		Id:                  m.Id,
+		ScenarioId:          m.ScenarioId,
+		ScenarioIterationId: m.ScenarioIterationId,
+		PublicationAction:   m.PublicationAction.String(),
+		ScheduledAt:         types.DateTime(m.ScheduledAt),
+		Status:              string(m.Status),
+		Error:               m.Error,
+	}
+}
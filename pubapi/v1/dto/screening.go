@@ -6,6 +6,8 @@ import (
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/pubapi/types"
 	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
 )
 
 type Screening struct {
@@ -74,15 +76,30 @@ func AdaptScreeningMatch(model models.ScreeningMatch) ScreeningMatch {
 }
 
 type ScreeningWhitelist struct {
-	Counterparty string         `json:"counterparty"`
-	EntityId     string         `json:"entity_id"`
-	CreatedAt    types.DateTime `json:"created_at"`
+	Counterparty      string          `json:"counterparty"`
+	EntityId          string          `json:"entity_id"`
+	ScreeningConfigId *uuid.UUID      `json:"screening_config_id"`
+	ObjectType        *string         `json:"object_type"`
+	ObjectId          *string         `json:"object_id"`
+	ExpiresAt         *types.DateTime `json:"expires_at"`
+	CreatedAt         types.DateTime  `json:"created_at"`
+	UpdatedAt         types.DateTime  `json:"updated_at"`
 }
 
 func AdaptScreeningWhitelist(model models.ScreeningWhitelist) ScreeningWhitelist {
-	return ScreeningWhitelist{
-		Counterparty: model.CounterpartyId,
-		EntityId:     model.EntityId,
-		CreatedAt:    types.DateTime(model.CreatedAt),
+	whitelist := ScreeningWhitelist{
+		Counterparty:      model.CounterpartyId,
+		EntityId:          model.EntityId,
+		ScreeningConfigId: model.Scope.ScreeningConfigStableId,
+		ObjectType:        model.Scope.ObjectType,
+		ObjectId:          model.Scope.ObjectId,
+		CreatedAt:         types.DateTime(model.CreatedAt),
+		UpdatedAt:         types.DateTime(model.UpdatedAt),
 	}
+
+	if model.ExpiresAt != nil {
+		whitelist.ExpiresAt = utils.Ptr(types.DateTime(*model.ExpiresAt))
+	}
+
+	return whitelist
 }
@@ -30,14 +30,17 @@ func (p WebhookEventPayload) ApiVersion() string {
 }
 
 type WebhookEventData struct {
-	Decision            *Decision                 `json:"decision,omitzero"`
-	Case                *Case                     `json:"case,omitzero"`
-	Files               *[]CaseFile               `json:"files,omitempty"`
-	Comments            *CaseComment              `json:"comments,omitempty"`
-	AsyncDecision       *AsyncDecisionExecution   `json:"async_decision,omitzero"`
-	ContinuousScreening *ContinuousScreening      `json:"continuous_screening,omitzero"`
-	Match               *ContinuousScreeningMatch `json:"match,omitzero"`
-	RiskLevel           *RiskLevel                `json:"risk_level,omitzero"`
+	Decision            *Decision                    `json:"decision,omitzero"`
+	Case                *Case                        `json:"case,omitzero"`
+	Files               *[]CaseFile                  `json:"files,omitempty"`
+	Comments            *CaseComment                 `json:"comments,omitempty"`
+	AsyncDecision       *AsyncDecisionExecution      `json:"async_decision,omitzero"`
+	ContinuousScreening *ContinuousScreening         `json:"continuous_screening,omitzero"`
+	Match               *ContinuousScreeningMatch    `json:"match,omitzero"`
+	RiskLevel           *RiskLevel                   `json:"risk_level,omitzero"`
+	PublicationRequest  *ScenarioPublicationRequest  `json:"publication_request,omitzero"`
+	PublicationSchedule *ScenarioPublicationSchedule `json:"publication_schedule,omitzero"`
+	ScreeningWhitelist  *ScreeningWhitelist          `json:"screening_whitelist,omitzero"`
 }
 
 func AdaptWebhookEventData(
@@ -106,6 +109,10 @@ func AdaptWebhookEventData(
 			RiskLevel: applyWebhookEventData(m.Content.Score, func(rl models.ScoringScore) RiskLevel {
 				return AdaptRiskLevel(rl, nil)
 			}),
+			PublicationRequest: applyWebhookEventData(m.Content.PublicationRequest, AdaptScenarioPublicationRequest),
+			PublicationSchedule: applyWebhookEventData(m.Content.PublicationSchedule,
+				AdaptScenarioPublicationSchedule),
+			ScreeningWhitelist: applyWebhookEventData(m.Content.ScreeningWhitelist, AdaptScreeningWhitelist),
 		},
 		Timestamp: m.Timestamp,
 	}
@@ -14,6 +14,7 @@ import (
 type SnoozeRuleParams struct {
 	RuleId   string `json:"rule_id" binding:"required,uuid"`
 	Duration string `json:"duration" binding:"required"`
+	KeyField string `json:"key_field"`
 }
 
 func HandleSnoozeRule(uc usecases.Usecases) gin.HandlerFunc {
@@ -46,6 +47,9 @@ func HandleSnoozeRule(uc usecases.Usecases) gin.HandlerFunc {
 			RuleId:         params.RuleId,
 			Duration:       params.Duration,
 		}
+		if params.KeyField != "" {
+			snooze.KeyField = &params.KeyField
+		}
 
 		if _, err = ruleSnoozeUsecase.SnoozeDecisionWithoutCase(c.Request.Context(), snooze); err != nil {
 			types.NewErrorResponse().WithError(err).Serve(c)
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	gdto "github.com/checkmarble/marble-backend/dto"
 	"github.com/checkmarble/marble-backend/models"
@@ -14,6 +15,7 @@ import (
 	"github.com/checkmarble/marble-backend/utils"
 	"github.com/cockroachdb/errors"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 func HandleListScreenings(uc usecases.Usecases) gin.HandlerFunc {
@@ -50,8 +52,27 @@ func HandleListScreenings(uc usecases.Usecases) gin.HandlerFunc {
 }
 
 type UpdateScreeningMatchStatusParams struct {
-	Status    string `json:"status" binding:"required,oneof=no_hit confirmed_hit"`
-	Whitelist bool   `json:"whitelist" binding:"excluded_unless=Status no_hit"`
+	Status           string                  `json:"status" binding:"required,oneof=no_hit confirmed_hit"`
+	Whitelist        bool                    `json:"whitelist" binding:"excluded_unless=Status no_hit"`
+	WhitelistOptions *WhitelistOptionsParams `json:"whitelist_options"`
+}
+
+type WhitelistOptionsParams struct {
+	ScopeToScreeningConfig bool       `json:"scope_to_screening_config"`
+	ScopeToObject          bool       `json:"scope_to_object"`
+	ExpiresAt              *time.Time `json:"expires_at"`
+}
+
+func (p *WhitelistOptionsParams) adapt() models.ScreeningWhitelistOptions {
+	if p == nil {
+		return models.ScreeningWhitelistOptions{}
+	}
+
+	return models.ScreeningWhitelistOptions{
+		ScopeToScreeningConfig: p.ScopeToScreeningConfig,
+		ScopeToObject:          p.ScopeToObject,
+		ExpiresAt:              p.ExpiresAt,
+	}
 }
 
 func HandleUpdateScreeningMatchStatus(uc usecases.Usecases) gin.HandlerFunc {
@@ -73,9 +94,10 @@ func HandleUpdateScreeningMatchStatus(uc usecases.Usecases) gin.HandlerFunc {
 		screeningUsecase := uc.NewScreeningUsecase()
 
 		match, err := screeningUsecase.UpdateMatchStatus(c.Request.Context(), models.ScreeningMatchUpdate{
-			MatchId:   matchId.String(),
-			Status:    models.ScreeningMatchStatusFrom(params.Status),
-			Whitelist: params.Whitelist,
+			MatchId:          matchId.String(),
+			Status:           models.ScreeningMatchStatusFrom(params.Status),
+			Whitelist:        params.Whitelist,
+			WhitelistOptions: params.WhitelistOptions.adapt(),
 		})
 		if err != nil {
 			types.NewErrorResponse().WithError(err).Serve(c)
@@ -226,8 +248,12 @@ func HandleGetScreeningEntity(uc usecases.Usecases) gin.HandlerFunc {
 }
 
 type AddWhitelistParams struct {
-	Counterparty string `json:"counterparty" binding:"required"`
-	EntityId     string `json:"entity_id" binding:"required"`
+	Counterparty      string     `json:"counterparty" binding:"required"`
+	EntityId          string     `json:"entity_id" binding:"required"`
+	ScreeningConfigId *uuid.UUID `json:"screening_config_id"`
+	ObjectType        *string    `json:"object_type" binding:"required_with=ObjectId"`
+	ObjectId          *string    `json:"object_id" binding:"required_with=ObjectType"`
+	ExpiresAt         *time.Time `json:"expires_at"`
 }
 
 func HandleAddWhitelist(uc usecases.Usecases) gin.HandlerFunc {
@@ -249,8 +275,17 @@ func HandleAddWhitelist(uc usecases.Usecases) gin.HandlerFunc {
 		uc := pubapi.UsecasesWithCreds(ctx, uc)
 		screeningUsecase := uc.NewScreeningUsecase()
 
-		if err := screeningUsecase.CreateWhitelist(ctx, nil, orgId,
-			params.Counterparty, params.EntityId, nil); err != nil {
+		if err := screeningUsecase.CreateWhitelist(ctx, nil, models.ScreeningWhitelistCreateInput{
+			OrgId:          orgId,
+			CounterpartyId: params.Counterparty,
+			EntityId:       params.EntityId,
+			Scope: models.ScreeningWhitelistScope{
+				ScreeningConfigStableId: params.ScreeningConfigId,
+				ObjectType:              params.ObjectType,
+				ObjectId:                params.ObjectId,
+			},
+			ExpiresAt: params.ExpiresAt,
+		}); err != nil {
 			types.NewErrorResponse().WithError(err).Serve(c)
 			return
 		}
@@ -294,8 +329,10 @@ func HandleDeleteWhitelist(uc usecases.Usecases) gin.HandlerFunc {
 }
 
 type SearchWhitelistParams struct {
-	Counterparty *string `json:"counterparty"`
-	EntityId     *string `json:"entity_id"`
+	Counterparty   *string    `json:"counterparty"`
+	EntityId       *string    `json:"entity_id"`
+	IncludeExpired bool       `json:"include_expired"`
+	ExpiresBefore  *time.Time `json:"expires_before"`
 }
 
 func HandleSearchWhitelist(uc usecases.Usecases) gin.HandlerFunc {
@@ -313,11 +350,11 @@ func HandleSearchWhitelist(uc usecases.Usecases) gin.HandlerFunc {
 			types.NewErrorResponse().WithError(err).Serve(c)
 			return
 		}
-		if params.Counterparty == nil && params.EntityId == nil {
+		if params.Counterparty == nil && params.EntityId == nil && params.ExpiresBefore == nil {
 			types.
 				NewErrorResponse().
 				WithError(errors.WithDetail(models.BadParameterError,
-					"at least one of `counterparty` or `entity_id` must be provided")).
+					"at least one of `counterparty`, `entity_id` or `expires_before` must be provided")).
 				Serve(c)
 			return
 		}
@@ -325,8 +362,12 @@ func HandleSearchWhitelist(uc usecases.Usecases) gin.HandlerFunc {
 		uc := pubapi.UsecasesWithCreds(ctx, uc)
 		screeningUsecase := uc.NewScreeningUsecase()
 
-		whitelists, err := screeningUsecase.SearchWhitelist(ctx, nil,
-			orgId, params.Counterparty, params.EntityId, nil)
+		whitelists, err := screeningUsecase.ListWhitelists(ctx, orgId, models.ScreeningWhitelistSearchFilters{
+			CounterpartyId: params.Counterparty,
+			EntityId:       params.EntityId,
+			IncludeExpired: params.IncludeExpired || params.ExpiresBefore != nil,
+			ExpiresBefore:  params.ExpiresBefore,
+		})
 		if err != nil {
 			types.NewErrorResponse().WithError(err).Serve(c)
 			return
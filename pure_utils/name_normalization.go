@@ -0,0 +1,185 @@
+package pure_utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Honorifics are only stripped when they prefix a name.
+var nameHonorifics = map[string]struct{}{
+	"mr": {}, "mrs": {}, "ms": {}, "miss": {}, "mx": {}, "dr": {}, "prof": {}, "sir": {}, "dame": {},
+	"lord": {}, "lady": {}, "madam": {}, "madame": {}, "mme": {}, "mlle": {}, "monsieur": {},
+	"herr": {}, "frau": {}, "senor": {}, "senora": {}, "sheikh": {}, "shaikh": {}, "hon": {},
+	"rev": {}, "gospodin": {}, "gospozha": {}, "gn": {}, "gzha": {},
+}
+
+// Legal forms are stripped when they suffix an organization name. Dots are removed before
+// comparison, so "S.A.R.L." and "sarl" are equivalent.
+var nameLegalForms = map[string]struct{}{
+	"llc": {}, "llp": {}, "lp": {}, "ltd": {}, "limited": {}, "inc": {}, "incorporated": {},
+	"corp": {}, "corporation": {}, "co": {}, "company": {}, "plc": {}, "pllc": {},
+	"gmbh": {}, "ag": {}, "kg": {}, "ohg": {}, "ug": {}, "ev": {},
+	"sarl": {}, "sas": {}, "sasu": {}, "sa": {}, "eurl": {}, "sci": {}, "snc": {},
+	"bv": {}, "nv": {}, "vof": {}, "spa": {}, "srl": {}, "sl": {}, "slu": {},
+	"oy": {}, "oyj": {}, "ab": {}, "as": {}, "asa": {}, "aps": {},
+	"pte": {}, "pty": {}, "bhd": {}, "sdn": {}, "kk": {},
+	"ooo": {}, "oao": {}, "zao": {}, "pao": {}, "ojsc": {}, "cjsc": {}, "jsc": {}, "tov": {},
+	"fzco": {}, "fze": {}, "fzllc": {}, "wll": {},
+}
+
+// Some legal forms are customarily written before the name (OOO "Romashka", PT Maju).
+var nameLegalFormPrefixes = map[string]struct{}{
+	"ooo": {}, "oao": {}, "zao": {}, "pao": {}, "ojsc": {}, "cjsc": {}, "jsc": {}, "tov": {}, "pt": {},
+}
+
+var nameTransliterationTable = map[rune]string{
+	// Cyrillic (Russian, Ukrainian, Belarusian, Serbian, Bulgarian)
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'ђ': "dj", 'е': "e", 'ё': "e",
+	'є': "ye", 'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'й': "y", 'ј': "j", 'к': "k",
+	'л': "l", 'љ': "lj", 'м': "m", 'н': "n", 'њ': "nj", 'о': "o", 'п': "p", 'р': "r", 'с': "s",
+	'т': "t", 'ћ': "c", 'у': "u", 'ў': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'џ': "dz",
+	'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i",
+	'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s",
+	'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+
+	// Arabic (and Persian additions). Short vowels are not written, so the result is
+	// consonantal, which is what most romanized watchlist aliases look like anyway.
+	'ا': "a", 'أ': "a", 'إ': "i", 'آ': "a", 'ء': "", 'ؤ': "", 'ئ': "", 'ب': "b", 'پ': "p",
+	'ت': "t", 'ث': "th", 'ج': "j", 'چ': "ch", 'ح': "h", 'خ': "kh", 'د': "d", 'ذ': "dh", 'ر': "r",
+	'ز': "z", 'ژ': "zh", 'س': "s", 'ش': "sh", 'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'ع': "",
+	'غ': "gh", 'ف': "f", 'ق': "q", 'ك': "k", 'ک': "k", 'گ': "g", 'ل': "l", 'م': "m", 'ن': "n",
+	'ه': "h", 'ة': "a", 'و': "w", 'ى': "a", 'ي': "y", 'ی': "y",
+}
+
+// Transliterate converts Cyrillic, Greek and Arabic characters to their closest latin
+// equivalent, romanizes chinese, korean and japanese kana names (see transliterateCJK) and
+// removes diacritics. Characters from other scripts are left untouched.
+func Transliterate(s string) string {
+	var out strings.Builder
+
+	for _, r := range transliterateCJK(Normalize(s)) {
+		lower := unicode.ToLower(r)
+		latin, ok := nameTransliterationTable[lower]
+		if !ok {
+			// precomposed letters such as the accented greek vowels are looked up without their accent
+			if base := []rune(normalizeAndRemoveDiacritics(string(lower))); len(base) == 1 {
+				latin, ok = nameTransliterationTable[base[0]]
+			}
+		}
+
+		switch {
+		case !ok:
+			out.WriteRune(r)
+		case lower != r && latin != "":
+			out.WriteString(strings.ToUpper(latin[:1]) + latin[1:])
+		default:
+			out.WriteString(latin)
+		}
+	}
+
+	return normalizeAndRemoveDiacritics(out.String())
+}
+
+// NameNormalizer prepares names before they are sent to a screening provider or compared
+// in a fuzzy match rule, so both agree on what a name is. On top of built-in honorifics and
+// legal forms, organizations can provide a stoplist of words to ignore anywhere in a name.
+type NameNormalizer struct {
+	stoplist map[string]struct{}
+}
+
+func NewNameNormalizer(stoplist []string) NameNormalizer {
+	n := NameNormalizer{stoplist: make(map[string]struct{}, len(stoplist))}
+
+	for _, word := range stoplist {
+		if key := nameTokenKey(word); key != "" {
+			n.stoplist[key] = struct{}{}
+		}
+	}
+
+	return n
+}
+
+func nameTokenKey(token string) string {
+	return strings.ReplaceAll(cleanseString(Transliterate(token)), " ", "")
+}
+
+// Strip removes leading honorifics, legal forms and stoplist words from
+// a name, keeping the remaining words as they were written. If stripping would leave
+// nothing, the name is returned as is.
+func (n NameNormalizer) Strip(name string) string {
+	tokens := strings.FieldsFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	keys := Map(tokens, nameTokenKey)
+	start, end := 0, len(tokens)
+
+	for start < end {
+		_, honorific := nameHonorifics[keys[start]]
+		_, legalForm := nameLegalFormPrefixes[keys[start]]
+
+		if !honorific && !legalForm {
+			break
+		}
+		start++
+	}
+	for end > start {
+		if _, legalForm := nameLegalForms[keys[end-1]]; !legalForm {
+			break
+		}
+		end--
+	}
+
+	out := make([]string, 0, end-start)
+
+	for idx := start; idx < end; idx++ {
+		if _, stop := n.stoplist[keys[idx]]; stop || keys[idx] == "" {
+			continue
+		}
+		out = append(out, tokens[idx])
+	}
+
+	if len(out) == 0 {
+		return strings.TrimSpace(name)
+	}
+
+	return strings.Join(out, " ")
+}
+
+// Normalize returns the canonical form of a name: transliterated, stripped and cleansed
+// (lowercase letters and numbers only).
+func (n NameNormalizer) Normalize(name string) string {
+	return strings.Join(strings.Fields(cleanseString(n.Strip(Transliterate(name)))), " ")
+}
+
+// Variants returns the original name followed by its stripped and transliterated forms,
+// without duplicates. The original name always comes first.
+func (n NameNormalizer) Variants(name string) []string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+
+	variants := []string{name}
+	seen := []string{cleanseString(name)}
+
+	for _, candidate := range []string{
+		n.Strip(name),
+		Transliterate(name),
+		n.Strip(Transliterate(name)),
+	} {
+		key := cleanseString(candidate)
+		if key == "" || slices.Contains(seen, key) {
+			continue
+		}
+
+		variants = append(variants, candidate)
+		seen = append(seen, key)
+	}
+
+	return variants
+}
@@ -0,0 +1,77 @@
+package pure_utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransliterate(t *testing.T) {
+	examples := []struct {
+		input    string
+		expected string
+	}{
+		{"Владимир Путин", "Vladimir Putin"},
+		{"Сергей Шойгу", "Sergey Shoygu"},
+		{"Αλέξης Τσίπρας", "Alexis Tsipras"},
+		{"محمد", "mhmd"},
+		{"François Hollande", "Francois Hollande"},
+		{"习近平", "Xi Jinping"},
+		{"王小明", "Wang Xiaoming"},
+		{"歐陽娜娜", "Ouyang Nana"},
+		{"华为技术有限公司", "华为技术有限公司"},
+		{"김정은", "Kim Jeongeun"},
+		{"박지성", "Park Jiseong"},
+		{"ヤマダ・タロウ", "Yamada Tarou"},
+		{"きっしゃ", "Kissha"},
+		{"Mr 王小明", "Mr Wang Xiaoming"},
+	}
+
+	for _, example := range examples {
+		t.Run(example.input, func(t *testing.T) {
+			assert.Equal(t, example.expected, Transliterate(example.input))
+		})
+	}
+}
+
+func TestNameNormalizerStrip(t *testing.T) {
+	normalizer := NewNameNormalizer([]string{"Holding", "group"})
+
+	examples := []struct {
+		input    string
+		expected string
+	}{
+		{"Mr. John Doe", "John Doe"},
+		{"Dr John Smith Jr", "John Smith Jr"},
+		{"Acme Holding GmbH", "Acme"},
+		{"Marble S.A.R.L.", "Marble"},
+		{"Rosneft Co., Ltd.", "Rosneft"},
+		{"OOO Romashka", "Romashka"},
+		{"Arab Group Co", "Arab"},
+		{"AS Roma", "AS Roma"},
+		{"LLC", "LLC"},
+	}
+
+	for _, example := range examples {
+		t.Run(example.input, func(t *testing.T) {
+			assert.Equal(t, example.expected, normalizer.Strip(example.input))
+		})
+	}
+}
+
+func TestNameNormalizerNormalize(t *testing.T) {
+	normalizer := NewNameNormalizer(nil)
+
+	assert.Equal(t, "vladimir putin", normalizer.Normalize("Г-н Владимир ПУТИН"))
+	assert.Equal(t, "romashka", normalizer.Normalize("ООО Ромашка"))
+	assert.Equal(t, "societe generale", normalizer.Normalize("Société Générale S.A."))
+}
+
+func TestNameNormalizerVariants(t *testing.T) {
+	normalizer := NewNameNormalizer(nil)
+
+	assert.Equal(t, []string{"ООО Ромашка", "Ромашка", "OOO Romashka", "Romashka"},
+		normalizer.Variants("ООО Ромашка"))
+	assert.Equal(t, []string{"John Doe"}, normalizer.Variants("  John Doe "))
+	assert.Nil(t, normalizer.Variants(""))
+}
@@ -0,0 +1,272 @@
+package pure_utils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Mandarin readings (without tones) of the characters most commonly found in chinese names,
+// in both simplified and traditional forms. Chinese has no character-level romanization, so
+// names using other characters are left untouched rather than partially romanized.
+var hanReadings = []string{
+	"ai 爱愛", "an 安",
+	"bai 白", "bin 斌彬", "bo 波博",
+	"cai 蔡", "cao 曹", "chang 长長", "chao 超", "chen 陈陳晨辰宸", "cheng 程成", "chun 春", "cui 崔",
+	"da 大达達", "dai 戴", "dan 丹", "de 德", "deng 邓鄧", "ding 丁", "dong 董东東冬", "du 杜", "duan 段",
+	"er 二",
+	"fa 发發", "fan 范帆", "fang 方芳", "fei 飞飛菲", "feng 冯馮峰凤鳳", "fu 付福",
+	"gang 刚剛", "gao 高", "ge 葛", "gong 龚龔", "gu 顾顧", "guan 官", "guang 光", "gui 桂贵貴", "guo 郭国國",
+	"hai 海", "han 韩韓涵", "hang 航", "hao 郝浩昊豪", "he 何贺賀和河", "heng 恒", "hong 红紅宏鸿鴻洪",
+	"hou 侯", "hu 胡湖虎", "hua 华華", "huan 欢歡", "huang 黄黃", "hui 辉輝慧",
+	"jia 贾賈佳嘉家", "jian 建健", "jiang 蒋蔣姜江", "jie 杰傑", "jin 金近", "jing 静靜晶", "jun 军軍俊",
+	"kai 凯凱", "kang 康", "kong 孔",
+	"lan 兰蘭", "le 乐樂", "lei 雷磊蕾", "li 李黎丽麗立莉礼禮利", "liang 梁亮", "liao 廖", "lin 林琳",
+	"ling 玲", "liu 刘劉", "long 龙龍", "lu 卢盧陆陸吕呂璐露", "lun 伦倫", "luo 罗羅",
+	"ma 马馬", "mao 毛", "mei 梅美", "meng 孟梦夢", "min 敏民", "ming 明铭銘", "mo 莫",
+	"na 娜", "ning 宁寧",
+	"ou 欧歐",
+	"pan 潘", "peng 彭鹏鵬", "ping 平萍",
+	"qi 琪", "qian 钱錢倩", "qiang 强強", "qin 秦覃琴", "qing 清庆慶晴", "qiu 邱秋",
+	"ran 然", "ren 任人仁", "rong 荣榮蓉", "rui 瑞睿",
+	"san 三", "shan 山", "shang 上", "shao 邵少", "shen 沈", "sheng 生胜勝", "shi 石史诗詩", "si 司思",
+	"song 宋松", "su 苏蘇", "sun 孙孫",
+	"tan 谭譚", "tang 唐汤湯", "tao 陶涛濤", "tian 田天", "ting 婷", "tong 通彤",
+	"wan 万萬婉", "wang 王汪", "wei 魏韦韋伟偉卫衛薇为為", "wen 文温溫", "wu 吴吳武",
+	"xi 习習喜", "xia 夏霞", "xiang 向祥", "xiao 肖小晓曉", "xie 谢謝", "xin 新欣鑫信", "xing 星兴興",
+	"xiong 熊", "xiu 秀", "xu 徐许許旭", "xuan 轩軒萱", "xue 薛雪学學",
+	"yan 闫严嚴艳艷燕岩妍", "yang 杨楊阳陽洋", "yao 姚瑶瑤", "ye 叶葉", "yi 一怡毅逸义義", "yin 尹",
+	"ying 英颖穎莹瑩", "yong 勇永", "yu 于余玉宇雨", "yuan 袁远遠", "yue 悦悅月", "yun 云雲",
+	"ze 泽澤", "zeng 曾", "zhang 张張", "zhao 赵趙", "zhe 哲", "zhen 振", "zheng 郑鄭正", "zhi 志智",
+	"zhong 钟鍾中忠", "zhou 周", "zhu 朱诸諸", "zi 子梓", "zou 邹鄒",
+}
+
+var hanReadingTable = func() map[rune]string {
+	table := make(map[rune]string)
+	for _, line := range hanReadings {
+		reading, chars, _ := strings.Cut(line, " ")
+		for _, r := range chars {
+			table[r] = reading
+		}
+	}
+	return table
+}()
+
+var hanSurnames = []rune("王李张張刘劉陈陳杨楊黄黃赵趙吴吳周徐孙孫马馬朱胡郭何高林罗羅郑鄭梁谢謝宋唐许許韩韓冯馮" +
+	"邓鄧曹彭曾肖田董袁潘于蒋蔣蔡余杜叶葉程苏蘇魏吕呂丁任沈姚卢盧姜崔钟鍾谭譚陆陸汪范金石廖贾賈夏韦韋付方白邹鄒" +
+	"孟熊秦邱江尹薛闫段雷侯龙龍史陶黎贺賀顾顧毛郝龚龔邵万萬钱錢严嚴覃武戴莫孔向汤湯习習温溫")
+
+var hanCompoundSurnames = []string{"欧阳", "歐陽", "司马", "司馬", "诸葛", "諸葛", "上官"}
+
+// Revised Romanization of the initial consonants, vowels and final consonants of hangul syllables.
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulVowels   = []string{
+		"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae",
+		"oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i",
+	}
+	hangulFinals = []string{
+		"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l",
+		"p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t",
+	}
+)
+
+// The usual spelling of the most common korean surnames, which differs from their Revised Romanization.
+var hangulSurnames = map[rune]string{
+	'김': "kim", '이': "lee", '박': "park", '최': "choi", '정': "jung", '조': "cho", '윤': "yoon",
+	'임': "lim", '오': "oh", '신': "shin", '권': "kwon", '안': "ahn", '류': "ryu", '유': "yoo",
+}
+
+// Hepburn romanization of hiragana. Katakana is looked up through the corresponding hiragana.
+var kanaTable = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko", 'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so", 'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to", 'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho", 'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+type cjkScript int
+
+const (
+	notCJK cjkScript = iota
+	han
+	hangul
+	kana
+)
+
+func cjkScriptOf(r rune) cjkScript {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return han
+	case r >= 0xAC00 && r <= 0xD7A3:
+		return hangul
+	case unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー':
+		return kana
+	}
+	return notCJK
+}
+
+// transliterateCJK romanizes chinese names (pinyin), korean hangul (Revised Romanization) and
+// japanese kana (Hepburn). A run of two to four han characters or hangul syllables is read as a
+// family name followed by a given name, as is customary in these languages: 王小明 becomes
+// "Wang Xiaoming".
+func transliterateCJK(s string) string {
+	runes := []rune(s)
+	var out strings.Builder
+	afterRun := false
+
+	for i := 0; i < len(runes); {
+		script := cjkScriptOf(runes[i])
+		if script == notCJK {
+			r := runes[i]
+			if r == '・' {
+				r = ' '
+			}
+			if afterRun && !unicode.IsSpace(r) {
+				out.WriteRune(' ')
+			}
+			out.WriteRune(r)
+			afterRun = false
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && cjkScriptOf(runes[end]) == script {
+			end++
+		}
+
+		var latin string
+		switch script {
+		case han:
+			latin = romanizeHan(runes[i:end])
+		case hangul:
+			latin = romanizeHangul(runes[i:end])
+		case kana:
+			latin = capitalize(romanizeKana(runes[i:end]))
+		}
+		if latin == "" {
+			latin = string(runes[i:end])
+		}
+
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), " ") {
+			out.WriteRune(' ')
+		}
+		out.WriteString(latin)
+		afterRun = true
+		i = end
+	}
+
+	return out.String()
+}
+
+// romanizeHan returns an empty string if any of the characters has no known reading.
+func romanizeHan(chars []rune) string {
+	readings := make([]string, len(chars))
+	for i, r := range chars {
+		reading, ok := hanReadingTable[r]
+		if !ok {
+			return ""
+		}
+		readings[i] = reading
+	}
+
+	if len(chars) >= 2 && len(chars) <= 4 {
+		surnameLength := 0
+		for _, compound := range hanCompoundSurnames {
+			if len(chars) > 2 && strings.HasPrefix(string(chars), compound) {
+				surnameLength = 2
+			}
+		}
+		if surnameLength == 0 && len(chars) <= 3 && slices.Contains(hanSurnames, chars[0]) {
+			surnameLength = 1
+		}
+		if surnameLength > 0 {
+			return capitalize(strings.Join(readings[:surnameLength], "")) + " " +
+				capitalize(strings.Join(readings[surnameLength:], ""))
+		}
+	}
+
+	return capitalize(strings.Join(readings, ""))
+}
+
+func romanizeHangul(syllables []rune) string {
+	romanized := make([]string, len(syllables))
+	for i, r := range syllables {
+		index := int(r - 0xAC00)
+		romanized[i] = hangulInitials[index/588] + hangulVowels[(index%588)/28] + hangulFinals[index%28]
+	}
+
+	if len(syllables) >= 2 && len(syllables) <= 4 {
+		surname := romanized[0]
+		if usual, ok := hangulSurnames[syllables[0]]; ok {
+			surname = usual
+		}
+		return capitalize(surname) + " " + capitalize(strings.Join(romanized[1:], ""))
+	}
+
+	return capitalize(strings.Join(romanized, ""))
+}
+
+func romanizeKana(chars []rune) string {
+	var out string
+	geminate := false
+
+	for _, r := range chars {
+		// katakana to hiragana
+		if r >= 'ァ' && r <= 'ヶ' {
+			r -= 0x60
+		}
+
+		switch r {
+		case 'っ':
+			geminate = true
+			continue
+		case 'ー':
+			continue
+		case 'ゃ', 'ゅ', 'ょ':
+			vowel := map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}[r]
+			if base, ok := strings.CutSuffix(out, "i"); ok && base != "" {
+				if strings.HasSuffix(base, "sh") || strings.HasSuffix(base, "ch") || strings.HasSuffix(base, "j") {
+					out = base + vowel
+				} else {
+					out = base + "y" + vowel
+				}
+			} else {
+				out += "y" + vowel
+			}
+			continue
+		}
+
+		syllable, ok := kanaTable[r]
+		if !ok {
+			syllable = string(r)
+		}
+		if geminate && syllable != "" && !strings.ContainsRune("aiueon", rune(syllable[0])) {
+			if strings.HasPrefix(syllable, "ch") {
+				syllable = "t" + syllable
+			} else {
+				syllable = syllable[:1] + syllable
+			}
+		}
+		geminate = false
+		out += syllable
+	}
+
+	return out
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
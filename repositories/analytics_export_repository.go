@@ -484,6 +484,85 @@ func AnalyticsCopyCaseEvents(ctx context.Context, exec AnalyticsExecutor, req An
 	return int(nRows), nil
 }
 
+// AnalyticsCopyDecisionCaseOutcomes exports the state of the case of each decision, once for each
+// event that may change it: the case is created, a decision is added to it, or its status or outcome
+// is updated. The status and outcome are those of the case when it is exported, so that an outcome
+// set before the case is closed is exported along with the closing of the case. The latest row of a
+// decision holds the state of its case. Like decision rules, a batch of case events is selected
+// first and all their decisions are exported, so a batch never holds a partial case.
+func AnalyticsCopyDecisionCaseOutcomes(ctx context.Context, exec AnalyticsExecutor, req AnalyticsCopyRequest) (int, error) {
+	sql, args, err := analyticsDecisionCaseOutcomesQuery(req).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	unsafeQuery, err := unsafeBuildSqlQuery(sql, args)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf(`copy ( select * from postgres_query(?, ?) ) to '%s' (format parquet, compression zstd, partition_by (org_id, year, month, trigger_object_type), append)`, req.Table)
+
+	result, err := exec.ExecContext(ctx, query, "pg", unsafeQuery)
+	if err != nil {
+		return 0, err
+	}
+	nRows, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(nRows), nil
+}
+
+func analyticsDecisionCaseOutcomesQuery(req AnalyticsCopyRequest) squirrel.SelectBuilder {
+	events := squirrel.
+		Select(
+			"ce.id",
+			"ce.case_id",
+			"c.status as case_status",
+			"c.outcome",
+			"ce.created_at",
+			"c.created_at as case_created_at",
+		).
+		From(dbmodels.TABLE_CASE_EVENTS+" ce").
+		InnerJoin(dbmodels.TABLE_CASES+" c on c.id = ce.case_id").
+		Where("ce.org_id = ?", req.OrgId).
+		Where("ce.event_type in ('case_created', 'decision_added', 'status_updated', 'outcome_updated')").
+		// Events of cases without decisions on this trigger object would hold the watermark back forever
+		Where(squirrel.Expr("exists (select 1 from "+dbmodels.TABLE_DECISIONS+
+			" d where d.case_id = c.id and d.org_id = ? and d.trigger_object_type = ?)", req.OrgId, req.TriggerObject)).
+		Where("ce.created_at < ?", req.EndTime).
+		OrderBy("ce.created_at, ce.id").
+		Limit(uint64(req.Limit))
+
+	if req.Watermark != nil {
+		events = events.Where("(ce.created_at, ce.id) > (?::timestamp with time zone, ?)",
+			req.Watermark.WatermarkTime, req.Watermark.WatermarkId)
+	}
+
+	return squirrel.
+		Select(
+			"e.id",
+			"e.case_id",
+			"d.id as decision_id",
+			"d.scenario_id",
+			"e.case_status",
+			"e.outcome",
+			"e.case_created_at",
+			"e.created_at",
+			"d.org_id",
+			"extract(year from e.created_at)::int as year",
+			"extract(month from e.created_at)::int as month",
+			"d.trigger_object_type",
+		).
+		FromSelect(events, "e").
+		InnerJoin(dbmodels.TABLE_DECISIONS+" d on d.case_id = e.case_id").
+		Where("d.org_id = ?", req.OrgId).
+		Where("d.trigger_object_type = ?", req.TriggerObject).
+		OrderBy("e.created_at, e.id")
+}
+
 func analyticsAddTriggerObjectField(b squirrel.SelectBuilder, field models.Field, anyValue bool) squirrel.SelectBuilder {
 	sqlType := "text"
 
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsDecisionCaseOutcomesQuery(t *testing.T) {
+	orgId := uuid.New()
+	end := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	watermark := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	sql, args, err := analyticsDecisionCaseOutcomesQuery(AnalyticsCopyRequest{
+		OrgId:         orgId,
+		TriggerObject: "transactions",
+		EndTime:       end,
+		Limit:         1000,
+		Watermark:     &models.Watermark{WatermarkTime: watermark, WatermarkId: utils.Ptr("event-id")},
+	}).ToSql()
+
+	require.NoError(t, err)
+	require.Equal(t, "SELECT e.id, e.case_id, d.id as decision_id, d.scenario_id, e.case_status, e.outcome, "+
+		"e.case_created_at, e.created_at, d.org_id, extract(year from e.created_at)::int as year, "+
+		"extract(month from e.created_at)::int as month, d.trigger_object_type "+
+		"FROM (SELECT ce.id, ce.case_id, c.status as case_status, c.outcome, ce.created_at, "+
+		"c.created_at as case_created_at "+
+		"FROM case_events ce INNER JOIN cases c on c.id = ce.case_id "+
+		"WHERE ce.org_id = ? "+
+		"AND ce.event_type in ('case_created', 'decision_added', 'status_updated', 'outcome_updated') "+
+		"AND exists (select 1 from decisions d where d.case_id = c.id and d.org_id = ? and d.trigger_object_type = ?) "+
+		"AND ce.created_at < ? AND (ce.created_at, ce.id) > (?::timestamp with time zone, ?) "+
+		"ORDER BY ce.created_at, ce.id LIMIT 1000) AS e "+
+		"INNER JOIN decisions d on d.case_id = e.case_id "+
+		"WHERE d.org_id = ? AND d.trigger_object_type = ? ORDER BY e.created_at, e.id", sql)
+	require.Equal(t, []any{
+		orgId, orgId, "transactions", end, watermark, utils.Ptr("event-id"),
+		orgId, "transactions",
+	}, args)
+}
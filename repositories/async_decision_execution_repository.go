@@ -7,6 +7,7 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/repositories/dbmodels"
@@ -15,6 +16,8 @@ import (
 type AsyncDecisionExecutionRepository interface {
 	CreateAsyncDecisionExecutions(ctx context.Context, exec Executor,
 		inputs []models.AsyncDecisionExecutionCreate) error
+	CreateDeduplicatedAsyncDecisionExecutions(ctx context.Context, exec Executor,
+		inputs []models.AsyncDecisionExecutionCreate) ([]uuid.UUID, error)
 	GetAsyncDecisionExecution(ctx context.Context, exec Executor, id uuid.UUID) (models.AsyncDecisionExecution, error)
 	UpdateAsyncDecisionExecution(ctx context.Context, exec Executor,
 		input models.AsyncDecisionExecutionUpdate) error
@@ -33,6 +36,52 @@ func (repo *MarbleDbRepository) CreateAsyncDecisionExecutions(
 		return nil
 	}
 
+	sql, args, err := insertAsyncDecisionExecutions(inputs).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "error building query for CreateAsyncDecisionExecutions")
+	}
+
+	_, err = exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return errors.Wrap(err, "error batch creating async decision executions")
+	}
+	return nil
+}
+
+// CreateDeduplicatedAsyncDecisionExecutions creates the executions whose deduplication key is not
+// used yet in the organization, and returns the ids of the executions actually created.
+func (repo *MarbleDbRepository) CreateDeduplicatedAsyncDecisionExecutions(
+	ctx context.Context,
+	exec Executor,
+	inputs []models.AsyncDecisionExecutionCreate,
+) ([]uuid.UUID, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 {
+		return nil, nil
+	}
+
+	sql, args, err := insertAsyncDecisionExecutions(inputs).
+		Suffix("ON CONFLICT (org_id, deduplication_key) WHERE deduplication_key IS NOT NULL DO NOTHING").
+		Suffix("RETURNING id").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query for CreateDeduplicatedAsyncDecisionExecutions")
+	}
+
+	rows, err := exec.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error batch creating deduplicated async decision executions")
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading created async decision execution ids")
+	}
+	return ids, nil
+}
+
+func insertAsyncDecisionExecutions(inputs []models.AsyncDecisionExecutionCreate) squirrel.InsertBuilder {
 	query := NewQueryBuilder().Insert(dbmodels.TABLE_ASYNC_DECISION_EXECUTIONS).
 		Columns(
 			"id",
@@ -41,6 +90,7 @@ func (repo *MarbleDbRepository) CreateAsyncDecisionExecutions(
 			"trigger_object",
 			"scenario_id",
 			"should_ingest",
+			"deduplication_key",
 		)
 
 	for _, input := range inputs {
@@ -51,19 +101,10 @@ func (repo *MarbleDbRepository) CreateAsyncDecisionExecutions(
 			input.TriggerObject,
 			input.ScenarioId,
 			input.ShouldIngest,
+			input.DeduplicationKey,
 		)
 	}
-
-	sql, args, err := query.ToSql()
-	if err != nil {
-		return errors.Wrap(err, "error building query for CreateAsyncDecisionExecutions")
-	}
-
-	_, err = exec.Exec(ctx, sql, args...)
-	if err != nil {
-		return errors.Wrap(err, "error batch creating async decision executions")
-	}
-	return nil
+	return query
 }
 
 func (repo *MarbleDbRepository) GetAsyncDecisionExecution(
@@ -48,7 +48,7 @@ type BlobRepository interface {
 	RawBucket(ctx context.Context, bucketUrl string) (*blob.Bucket, error)
 	DeleteFile(ctx context.Context, bucketUrl, key string) error
 	GenerateSignedUrl(ctx context.Context, bucketUrl, key string) (string, error)
-	GenerateWriteSignedUrl(ctx context.Context, bucketUrl, key string, expiry time.Duration, hostOverride string) (string, error)
+	GenerateWriteSignedUrl(ctx context.Context, bucketUrl, key, contentType string, expiry time.Duration, hostOverride string) (string, error)
 	GetContentType(ctx context.Context, bucketUrl, key string) string
 
 	ExtractHost(bucketUrl string) []string
@@ -306,7 +306,7 @@ func (repository *blobRepository) GenerateSignedUrl(ctx context.Context, bucketU
 		})
 }
 
-func (repository *blobRepository) GenerateWriteSignedUrl(ctx context.Context, bucketUrl, key string, expiry time.Duration, hostOverride string) (string, error) {
+func (repository *blobRepository) GenerateWriteSignedUrl(ctx context.Context, bucketUrl, key, contentType string, expiry time.Duration, hostOverride string) (string, error) {
 	if strings.HasPrefix(bucketUrl, "file://") {
 		logger := utils.LoggerFromContext(ctx)
 		logger.Warn("Signed URL generation is not supported with a file bucket. Please use a GCS, S3 or Azure bucket instead. Returning a placeholder URL instead.")
@@ -331,11 +331,11 @@ func (repository *blobRepository) GenerateWriteSignedUrl(ctx context.Context, bu
 
 		if asFunc(&s3Writer) {
 			s3Writer.IfNoneMatch = new("*")
-			s3Writer.ContentType = new("text/csv")
+			s3Writer.ContentType = new(contentType)
 		}
 		if asFunc(&gcsWriter) {
 			gcsWriter.Headers = []string{"x-goog-if-generation-match:0"}
-			gcsWriter.ContentType = "text/csv"
+			gcsWriter.ContentType = contentType
 
 			if len(hostOverride) > 0 {
 				gcsWriter.Hostname = hostOverride
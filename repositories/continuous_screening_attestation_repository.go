@@ -0,0 +1,231 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+// ListContinuousScreeningAttestationUpdates returns every dataset update processed for the
+// configs of the org, identified by config stable ID, sorted by reception time.
+func (repo *MarbleDbRepository) ListContinuousScreeningAttestationUpdates(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	provider models.ScreeningProvider,
+) ([]models.ContinuousScreeningAttestationUpdate, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(
+			"cs.stable_id AS config_stable_id",
+			"ds.dataset_name AS dataset_name",
+			"ds.version AS version",
+			"ds.created_at AS received_at",
+			"ucs.status AS status",
+			"ucs.started_at AS started_at",
+			"ucs.finished_at AS finished_at",
+		).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_UPDATE_JOBS+" AS ucs").
+		Join(dbmodels.TABLE_CONTINUOUS_SCREENING_CONFIGS+
+			" AS cs ON (ucs.continuous_screening_config_id = cs.id)").
+		Join(dbmodels.TABLE_CONTINUOUS_SCREENING_DATASET_UPDATES+
+			" AS ds ON (ucs.continuous_screening_dataset_update_id = ds.id)").
+		Where(squirrel.Eq{
+			"ucs.org_id":   orgId,
+			"ucs.provider": provider,
+		}).
+		OrderBy("ds.created_at", "ds.version")
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningAttestationUpdate)
+}
+
+// ListContinuousScreeningAttestationObjectStats returns, for each monitored object, its latest
+// direct screening, the date it was first written in the org dataset and its number of pending
+// matches, both from its own screenings and from dataset updates matching it.
+func (repo *MarbleDbRepository) ListContinuousScreeningAttestationObjectStats(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	objects []models.ContinuousScreeningMonitoredObject,
+) ([]models.ContinuousScreeningAttestationObjectStats, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, nil
+	}
+
+	query := continuousScreeningAttestationObjectStatsQuery(orgId, objects)
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningAttestationObjectStats)
+}
+
+func continuousScreeningAttestationObjectStatsQuery(
+	orgId uuid.UUID,
+	objects []models.ContinuousScreeningMonitoredObject,
+) squirrel.SelectBuilder {
+	objectTypes := make([]string, len(objects))
+	objectIds := make([]string, len(objects))
+	configStableIds := make([]string, len(objects))
+	for i, object := range objects {
+		objectTypes[i] = object.ObjectType
+		objectIds[i] = object.ObjectId
+		configStableIds[i] = object.ConfigStableId.String()
+	}
+
+	objectTriggers := []string{
+		models.ContinuousScreeningTriggerTypeObjectAdded.String(),
+		models.ContinuousScreeningTriggerTypeObjectUpdated.String(),
+	}
+
+	lastObjectScreening := fmt.Sprintf(`(
+		SELECT MAX(s.created_at) FROM %s AS s
+		WHERE s.org_id = ? AND s.object_type = o.object_type AND s.object_id = o.object_id
+			AND s.continuous_screening_config_stable_id = o.config_stable_id
+			AND s.trigger_type IN ('%s')
+	) AS last_object_screening_at`,
+		dbmodels.TABLE_CONTINUOUS_SCREENINGS, strings.Join(objectTriggers, "','"))
+
+	indexedAt := fmt.Sprintf(`(
+		SELECT MIN(f.created_at) FROM %s AS t
+		JOIN %s AS f ON (f.id = t.dataset_file_id)
+		WHERE t.org_id = ? AND t.object_type = o.object_type AND t.object_id = o.object_id
+			AND t.dataset_file_id IS NOT NULL
+	) AS indexed_at`,
+		dbmodels.TABLE_CONTINUOUS_SCREENING_DELTA_TRACKS, dbmodels.TABLE_CONTINUOUS_SCREENING_DATASET_FILES)
+
+	// Matches on the object are either found when screening the object itself, or when a dataset
+	// update hits the object, in which case the match entity is the object in the org dataset.
+	openMatches := fmt.Sprintf(`(
+		SELECT COUNT(*) FROM %s AS m
+		JOIN %s AS s ON (s.id = m.continuous_screening_id)
+		WHERE s.org_id = ? AND s.continuous_screening_config_stable_id = o.config_stable_id
+			AND m.status = '%s'
+			AND (
+				(s.object_type = o.object_type AND s.object_id = o.object_id)
+				OR m.opensanction_entity_id = 'marble_' || o.object_type || '_' || o.object_id
+			)
+	)::int AS open_matches`,
+		dbmodels.TABLE_CONTINUOUS_SCREENING_MATCHES, dbmodels.TABLE_CONTINUOUS_SCREENINGS,
+		models.ScreeningMatchStatusPending.String())
+
+	return NewQueryBuilder().
+		Select("o.object_type", "o.object_id", "o.config_stable_id").
+		Prefix(`WITH o AS (
+			SELECT * FROM UNNEST(?::text[], ?::text[], ?::uuid[]) AS o(object_type, object_id, config_stable_id)
+		)`, objectTypes, objectIds, configStableIds).
+		Column(lastObjectScreening, orgId).
+		Column(indexedAt, orgId).
+		Column(openMatches, orgId).
+		From("o")
+}
+
+func (repo *MarbleDbRepository) CreateContinuousScreeningAttestationReport(
+	ctx context.Context,
+	exec Executor,
+	input models.CreateContinuousScreeningAttestationReport,
+) (models.ContinuousScreeningAttestationReport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ContinuousScreeningAttestationReport{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORTS).
+		Columns(
+			"id",
+			"org_id",
+			"csv_file_path",
+			"json_file_path",
+			"object_count",
+			"flagged_object_count",
+			"open_match_count",
+		).
+		Values(
+			input.Id,
+			input.OrgId,
+			input.CsvFilePath,
+			input.JsonFilePath,
+			input.ObjectCount,
+			input.FlaggedObjectCount,
+			input.OpenMatchCount,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s",
+			strings.Join(dbmodels.SelectContinuousScreeningAttestationReportColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptContinuousScreeningAttestationReport)
+}
+
+func (repo *MarbleDbRepository) GetContinuousScreeningAttestationReport(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.ContinuousScreeningAttestationReport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ContinuousScreeningAttestationReport{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectContinuousScreeningAttestationReportColumn...).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORTS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptContinuousScreeningAttestationReport)
+}
+
+func (repo *MarbleDbRepository) ListContinuousScreeningAttestationReports(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	pagination models.PaginationAndSorting,
+) ([]models.ContinuousScreeningAttestationReport, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+	if err := validateContinuousScreeningSorting(pagination, models.SortingFieldCreatedAt); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(columnsNames("r", dbmodels.SelectContinuousScreeningAttestationReportColumn)...).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORTS + " AS r").
+		Where(squirrel.Eq{"r.org_id": orgId}).
+		OrderBy(continuousScreeningKeysetOrder("r", pagination)).
+		Limit(uint64(pagination.Limit))
+
+	offsetQuery := NewQueryBuilder().
+		Select(fmt.Sprintf("%s AS offset_value", pagination.Sorting)).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORTS).
+		Where(squirrel.Eq{"id": pagination.OffsetId, "org_id": orgId})
+
+	query, err := repo.applyContinuousScreeningKeysetPagination(ctx, exec, query, offsetQuery, "r", pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningAttestationReport)
+}
+
+func (repo *MarbleDbRepository) CreateContinuousScreeningAttestationReportDownload(
+	ctx context.Context,
+	exec Executor,
+	input models.CreateContinuousScreeningAttestationReportDownload,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORT_DOWNLOADS).
+		Columns("org_id", "report_id", "format").
+		Values(input.OrgId, input.ReportId, string(input.Format))
+
+	return ExecBuilder(ctx, exec, query)
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinuousScreeningAttestationObjectStatsQuery(t *testing.T) {
+	orgId := uuid.New()
+	configStableId := uuid.New()
+	objects := []models.ContinuousScreeningMonitoredObject{
+		{ObjectType: "companies", ObjectId: "c1", ConfigStableId: configStableId},
+		{ObjectType: "persons", ObjectId: "p1", ConfigStableId: configStableId},
+	}
+
+	sql, args, err := continuousScreeningAttestationObjectStatsQuery(orgId, objects).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, "UNNEST($1::text[], $2::text[], $3::uuid[])")
+	require.Contains(t, sql, "s.org_id = $4")
+	require.Contains(t, sql, "t.org_id = $5")
+	require.Contains(t, sql, "s.org_id = $6")
+	require.Contains(t, sql, "s.trigger_type IN ('object_added','object_updated')")
+	require.Contains(t, sql, "m.status = 'pending'")
+	require.Contains(t, sql, "FROM o")
+	require.Equal(t, []string{"companies", "persons"}, args[0])
+	require.Equal(t, []string{"c1", "p1"}, args[1])
+	require.Equal(t, []string{configStableId.String(), configStableId.String()}, args[2])
+	require.Len(t, args, 6)
+}
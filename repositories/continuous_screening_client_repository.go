@@ -474,3 +474,90 @@ func (repo *ClientDbRepository) CountMonitoredObjectsByConfigStableIds(
 
 	return count, nil
 }
+
+// ListContinuousScreeningUnmonitoredObjects returns the current rows of an object type that are not
+// monitored by any of the given configs, sorted by internal id. Pass the internal id of the last
+// row of the previous page as afterInternalId to get the next page.
+func (repo *ClientDbRepository) ListContinuousScreeningUnmonitoredObjects(
+	ctx context.Context,
+	exec Executor,
+	objectType string,
+	configStableIds []uuid.UUID,
+	afterInternalId *uuid.UUID,
+	limit int,
+) ([]models.ContinuousScreeningCoverageObject, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := continuousScreeningUnmonitoredObjectsQuery(exec, objectType, configStableIds, afterInternalId, limit)
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningCoverageObject)
+}
+
+func continuousScreeningUnmonitoredObjectsQuery(
+	exec Executor,
+	objectType string,
+	configStableIds []uuid.UUID,
+	afterInternalId *uuid.UUID,
+	limit int,
+) squirrel.SelectBuilder {
+	query := NewQueryBuilder().
+		Select("t.id", "t.object_id").
+		From(pgIdentifierWithSchema(exec, objectType) + " AS t").
+		Where("t.valid_until = 'infinity'").
+		Where(fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM %s AS mo
+			WHERE mo.object_type = ? AND mo.object_id = t.object_id AND mo.config_stable_id = ANY(?)
+		)`, sanitizedTableName(exec, dbmodels.TABLE_CONTINUOUS_SCREENING_MONITORED_OBJECTS)),
+			objectType, configStableIds).
+		OrderBy("t.id").
+		Limit(uint64(limit))
+	if afterInternalId != nil {
+		query = query.Where(squirrel.Gt{"t.id": *afterInternalId})
+	}
+
+	return query
+}
+
+// ListContinuousScreeningOrphanedObjects returns the monitored objects of an object type that have
+// no current row in the client data anymore, sorted by id. Pass the id of the last monitored object
+// of the previous page as afterId to get the next page.
+func (repo *ClientDbRepository) ListContinuousScreeningOrphanedObjects(
+	ctx context.Context,
+	exec Executor,
+	objectType string,
+	afterId *uuid.UUID,
+	limit int,
+) ([]models.ContinuousScreeningMonitoredObject, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := continuousScreeningOrphanedObjectsQuery(exec, objectType, afterId, limit)
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningMonitoredObject)
+}
+
+func continuousScreeningOrphanedObjectsQuery(
+	exec Executor,
+	objectType string,
+	afterId *uuid.UUID,
+	limit int,
+) squirrel.SelectBuilder {
+	query := NewQueryBuilder().
+		Select(columnsNames("mo", dbmodels.SelectContinuousScreeningMonitoredObjectColumn)...).
+		From(sanitizedTableName(exec, dbmodels.TABLE_CONTINUOUS_SCREENING_MONITORED_OBJECTS) + " AS mo").
+		Where(squirrel.Eq{"mo.object_type": objectType}).
+		Where(fmt.Sprintf(`NOT EXISTS (
+			SELECT 1 FROM %s AS t
+			WHERE t.object_id = mo.object_id AND t.valid_until = 'infinity'
+		)`, pgIdentifierWithSchema(exec, objectType))).
+		OrderBy("mo.id").
+		Limit(uint64(limit))
+	if afterId != nil {
+		query = query.Where(squirrel.Gt{"mo.id": *afterId})
+	}
+
+	return query
+}
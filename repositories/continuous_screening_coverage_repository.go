@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+const continuousScreeningCoverageGapInsertBatchSize = 1000
+
+func (repo *MarbleDbRepository) CreateContinuousScreeningCoverageAnalysis(
+	ctx context.Context,
+	exec Executor,
+	input models.CreateContinuousScreeningCoverageAnalysis,
+) (models.ContinuousScreeningCoverageAnalysis, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ContinuousScreeningCoverageAnalysis{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_ANALYSES).
+		Columns(
+			"id",
+			"org_id",
+			"object_types",
+			"unmonitored_count",
+			"orphaned_count",
+			"truncated",
+		).
+		Values(
+			input.Id,
+			input.OrgId,
+			input.ObjectTypes,
+			input.UnmonitoredCount,
+			input.OrphanedCount,
+			input.Truncated,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s",
+			strings.Join(dbmodels.SelectContinuousScreeningCoverageAnalysisColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptContinuousScreeningCoverageAnalysis)
+}
+
+func (repo *MarbleDbRepository) CreateContinuousScreeningCoverageGaps(
+	ctx context.Context,
+	exec Executor,
+	gaps []models.ContinuousScreeningCoverageGap,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(gaps); start += continuousScreeningCoverageGapInsertBatchSize {
+		end := min(start+continuousScreeningCoverageGapInsertBatchSize, len(gaps))
+
+		query := NewQueryBuilder().
+			Insert(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_GAPS).
+			Columns(
+				"analysis_id",
+				"gap_type",
+				"object_type",
+				"object_id",
+				"object_internal_id",
+				"config_stable_id",
+			)
+		for _, gap := range gaps[start:end] {
+			query = query.Values(
+				gap.AnalysisId,
+				string(gap.GapType),
+				gap.ObjectType,
+				gap.ObjectId,
+				gap.ObjectInternalId,
+				gap.ConfigStableId,
+			)
+		}
+
+		if err := ExecBuilder(ctx, exec, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *MarbleDbRepository) GetContinuousScreeningCoverageAnalysis(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.ContinuousScreeningCoverageAnalysis, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ContinuousScreeningCoverageAnalysis{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectContinuousScreeningCoverageAnalysisColumn...).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_ANALYSES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptContinuousScreeningCoverageAnalysis)
+}
+
+func (repo *MarbleDbRepository) ListContinuousScreeningCoverageAnalyses(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	pagination models.PaginationAndSorting,
+) ([]models.ContinuousScreeningCoverageAnalysis, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+	if err := validateContinuousScreeningSorting(pagination, models.SortingFieldCreatedAt); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(columnsNames("a", dbmodels.SelectContinuousScreeningCoverageAnalysisColumn)...).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_ANALYSES + " AS a").
+		Where(squirrel.Eq{"a.org_id": orgId}).
+		OrderBy(continuousScreeningKeysetOrder("a", pagination)).
+		Limit(uint64(pagination.Limit))
+
+	offsetQuery := NewQueryBuilder().
+		Select(fmt.Sprintf("%s AS offset_value", pagination.Sorting)).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_ANALYSES).
+		Where(squirrel.Eq{"id": pagination.OffsetId, "org_id": orgId})
+
+	query, err := repo.applyContinuousScreeningKeysetPagination(ctx, exec, query, offsetQuery, "a", pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningCoverageAnalysis)
+}
+
+func (repo *MarbleDbRepository) ListContinuousScreeningCoverageGaps(
+	ctx context.Context,
+	exec Executor,
+	analysisId uuid.UUID,
+	filters models.ContinuousScreeningCoverageGapFilters,
+	pagination models.PaginationAndSorting,
+) ([]models.ContinuousScreeningCoverageGap, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+	if err := validateContinuousScreeningSorting(pagination, models.SortingFieldCreatedAt); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(columnsNames("g", dbmodels.SelectContinuousScreeningCoverageGapColumn)...).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_GAPS + " AS g").
+		Where(squirrel.Eq{"g.analysis_id": analysisId}).
+		OrderBy(continuousScreeningKeysetOrder("g", pagination)).
+		Limit(uint64(pagination.Limit))
+	if filters.GapType != nil {
+		query = query.Where(squirrel.Eq{"g.gap_type": string(*filters.GapType)})
+	}
+
+	offsetQuery := NewQueryBuilder().
+		Select(fmt.Sprintf("%s AS offset_value", pagination.Sorting)).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_GAPS).
+		Where(squirrel.Eq{"id": pagination.OffsetId, "analysis_id": analysisId})
+
+	query, err := repo.applyContinuousScreeningKeysetPagination(ctx, exec, query, offsetQuery, "g", pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptContinuousScreeningCoverageGap)
+}
+
+func (repo *MarbleDbRepository) MarkContinuousScreeningCoverageAnalysisBackfilled(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_CONTINUOUS_SCREENING_COVERAGE_ANALYSES).
+		Set("backfilled_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, query)
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContinuousScreeningUnmonitoredObjectsQuery(t *testing.T) {
+	configStableIds := []uuid.UUID{uuid.New(), uuid.New()}
+	after := uuid.New()
+
+	sql, args, err := continuousScreeningUnmonitoredObjectsQuery(
+		TransactionTest{}, "companies", configStableIds, &after, 100).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, `FROM "test_schema"."companies" AS t`)
+	require.Contains(t, sql, "t.valid_until = 'infinity'")
+	require.Contains(t, sql, `FROM "test_schema"."_monitored_objects" AS mo`)
+	require.Contains(t, sql, "mo.object_type = $1 AND mo.object_id = t.object_id AND mo.config_stable_id = ANY($2)")
+	require.Contains(t, sql, "t.id > $3")
+	require.Contains(t, sql, "ORDER BY t.id LIMIT 100")
+	require.Equal(t, []any{"companies", configStableIds, after.String()}, args)
+}
+
+func TestContinuousScreeningOrphanedObjectsQuery(t *testing.T) {
+	sql, args, err := continuousScreeningOrphanedObjectsQuery(TransactionTest{}, "companies", nil, 100).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, `FROM "test_schema"."_monitored_objects" AS mo`)
+	require.Contains(t, sql, `FROM "test_schema"."companies" AS t`)
+	require.Contains(t, sql, "t.object_id = mo.object_id AND t.valid_until = 'infinity'")
+	require.NotContains(t, sql, "mo.id >")
+	require.Contains(t, sql, "ORDER BY mo.id LIMIT 100")
+	require.Equal(t, []any{"companies"}, args)
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+// UpdateDataModelFieldDerivedExpression sets the expression the field is derived from, or makes it a
+// regular field again if the expression is nil.
+func (repo *MarbleDbRepository) UpdateDataModelFieldDerivedExpression(
+	ctx context.Context,
+	exec Executor,
+	fieldId string,
+	expression *ast.Node,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	serialized, err := dbmodels.SerializeFormulaAstExpression(expression)
+	if err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TableDataModelFields).
+		Set("derived_expression", serialized).
+		Where(squirrel.Eq{"id": fieldId})
+
+	if err := ExecBuilder(ctx, exec, query); err != nil {
+		return err
+	}
+
+	return repo.DeleteDataModelCache(ctx, exec)
+}
+
+// CreateDerivedFieldBackfill starts a backfill of the field, superseding the backfill in progress
+// on the field if there is one.
+func (repo *MarbleDbRepository) CreateDerivedFieldBackfill(
+	ctx context.Context,
+	exec Executor,
+	backfill models.DerivedFieldBackfill,
+) (models.DerivedFieldBackfill, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	if err := repo.SupersedeDerivedFieldBackfills(ctx, exec, backfill.FieldId); err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS).
+		Columns(
+			"id",
+			"organization_id",
+			"table_id",
+			"field_id",
+			"table_name",
+			"field_name",
+		).
+		Values(
+			backfill.Id,
+			backfill.OrganizationId,
+			backfill.TableId,
+			backfill.FieldId,
+			backfill.TableName,
+			backfill.FieldName,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDerivedFieldBackfillColumn, ",")))
+
+	created, err := SqlToModel(ctx, exec, query, dbmodels.AdaptDerivedFieldBackfill)
+	if IsUniqueViolationError(err) {
+		return models.DerivedFieldBackfill{}, fmt.Errorf(
+			"a backfill of this field is already starting: %w", models.ConflictError)
+	}
+	return created, err
+}
+
+// SupersedeDerivedFieldBackfills stops the backfill in progress on the field, if any.
+func (repo *MarbleDbRepository) SupersedeDerivedFieldBackfills(ctx context.Context, exec Executor, fieldId string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS).
+		Set("status", string(models.DerivedFieldBackfillSuperseded)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("completed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"field_id": fieldId}).
+		Where(squirrel.Eq{"status": []string{
+			string(models.DerivedFieldBackfillPending),
+			string(models.DerivedFieldBackfillRunning),
+		}}))
+}
+
+func (repo *MarbleDbRepository) GetDerivedFieldBackfill(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.DerivedFieldBackfill, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDerivedFieldBackfillColumn...).
+		From(dbmodels.TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptDerivedFieldBackfill)
+}
+
+func (repo *MarbleDbRepository) ListDerivedFieldBackfills(
+	ctx context.Context,
+	exec Executor,
+	fieldId string,
+) ([]models.DerivedFieldBackfill, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDerivedFieldBackfillColumn...).
+		From(dbmodels.TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS).
+		Where(squirrel.Eq{"field_id": fieldId}).
+		OrderBy("created_at DESC")
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptDerivedFieldBackfill)
+}
+
+// UpdateDerivedFieldBackfillProgress records that the backfill computed the rows up to lastRowId.
+func (repo *MarbleDbRepository) UpdateDerivedFieldBackfillProgress(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	lastRowId *uuid.UUID,
+	rowsProcessed int64,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS).
+		Set("status", string(models.DerivedFieldBackfillRunning)).
+		Set("last_row_id", lastRowId).
+		Set("rows_processed", squirrel.Expr("rows_processed + ?", rowsProcessed)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+func (repo *MarbleDbRepository) CompleteDerivedFieldBackfill(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	status models.DerivedFieldBackfillStatus,
+	backfillError *string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS).
+		Set("status", string(status)).
+		Set("error", backfillError).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("completed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, query)
+}
@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+func (repo *MarbleDbRepository) CreateFieldTypeMigration(
+	ctx context.Context,
+	exec Executor,
+	migration models.FieldTypeMigration,
+) (models.FieldTypeMigration, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.FieldTypeMigration{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATIONS).
+		Columns(
+			"id",
+			"organization_id",
+			"table_id",
+			"field_id",
+			"table_name",
+			"field_name",
+			"source_data_type",
+			"target_data_type",
+			"timestamp_format",
+			"null_on_failure",
+		).
+		Values(
+			migration.Id,
+			migration.OrganizationId,
+			migration.TableId,
+			migration.FieldId,
+			migration.TableName,
+			migration.FieldName,
+			migration.SourceDataType.String(),
+			migration.TargetDataType.String(),
+			migration.TimestampFormat,
+			migration.NullOnFailure,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectFieldTypeMigrationColumn, ",")))
+
+	created, err := SqlToModel(ctx, exec, query, dbmodels.AdaptFieldTypeMigration)
+	if IsUniqueViolationError(err) {
+		return models.FieldTypeMigration{}, fmt.Errorf(
+			"a type migration is already in progress on this field: %w", models.ConflictError)
+	}
+	return created, err
+}
+
+func (repo *MarbleDbRepository) GetFieldTypeMigration(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.FieldTypeMigration, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.FieldTypeMigration{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectFieldTypeMigrationColumn...).
+		From(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATIONS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptFieldTypeMigration)
+}
+
+func (repo *MarbleDbRepository) ListFieldTypeMigrations(
+	ctx context.Context,
+	exec Executor,
+	fieldId string,
+) ([]models.FieldTypeMigration, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectFieldTypeMigrationColumn...).
+		From(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATIONS).
+		Where(squirrel.Eq{"field_id": fieldId}).
+		OrderBy("created_at DESC")
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptFieldTypeMigration)
+}
+
+// UpdateFieldTypeMigrationProgress records that the backfill converted the rows up to lastRowId.
+func (repo *MarbleDbRepository) UpdateFieldTypeMigrationProgress(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	lastRowId *uuid.UUID,
+	rowsProcessed int64,
+	rowsFailed int64,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATIONS).
+		Set("status", string(models.FieldTypeMigrationRunning)).
+		Set("last_row_id", lastRowId).
+		Set("rows_processed", squirrel.Expr("rows_processed + ?", rowsProcessed)).
+		Set("rows_failed", squirrel.Expr("rows_failed + ?", rowsFailed)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+func (repo *MarbleDbRepository) CompleteFieldTypeMigration(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	status models.FieldTypeMigrationStatus,
+	migrationError *string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATIONS).
+		Set("status", string(status)).
+		Set("error", migrationError).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("completed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+// CreateFieldTypeMigrationFailures records the rows that failed conversion, returning the number of
+// rows that were not recorded yet: the rows left unconverted by the backfill are read again when
+// the migration completes.
+func (repo *MarbleDbRepository) CreateFieldTypeMigrationFailures(
+	ctx context.Context,
+	exec Executor,
+	failures []models.FieldTypeMigrationFailure,
+) (int64, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+	if len(failures) == 0 {
+		return 0, nil
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATION_FAILURES).
+		Columns("migration_id", "row_id", "object_id", "value", "error").
+		Suffix("ON CONFLICT (migration_id, row_id) DO NOTHING")
+	for _, failure := range failures {
+		query = query.Values(failure.MigrationId, failure.RowId, failure.ObjectId, failure.Value, failure.Error)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (repo *MarbleDbRepository) ListFieldTypeMigrationFailures(
+	ctx context.Context,
+	exec Executor,
+	migrationId uuid.UUID,
+	limit int,
+) ([]models.FieldTypeMigrationFailure, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectFieldTypeMigrationFailureColumn...).
+		From(dbmodels.TABLE_DATA_MODEL_FIELD_TYPE_MIGRATION_FAILURES).
+		Where(squirrel.Eq{"migration_id": migrationId}).
+		OrderBy("row_id").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptFieldTypeMigrationFailure)
+}
+
+func (repo *MarbleDbRepository) UpdateDataModelFieldDataType(
+	ctx context.Context,
+	exec Executor,
+	fieldId string,
+	dataType models.DataType,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TableDataModelFields).
+		Set("type", dataType.String()).
+		Where(squirrel.Eq{"id": fieldId})
+
+	if err := ExecBuilder(ctx, exec, query); err != nil {
+		return err
+	}
+
+	return repo.DeleteDataModelCache(ctx, exec)
+}
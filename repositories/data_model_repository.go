@@ -145,6 +145,18 @@ func (repo MarbleDbRepository) GetDataModel(
 			ftmProperty = &property
 		}
 
+		constraints, err := models.FieldConstraintsFromMetadata(field.FieldMetadata)
+		if err != nil {
+			return models.DataModel{}, errors.Wrapf(err,
+				"could not read the constraints of field %s.%s", field.TableName, field.FieldName)
+		}
+
+		derivedExpression, err := dbmodels.AdaptSerializedAstExpression(field.FieldDerivedExpression)
+		if err != nil {
+			return models.DataModel{}, errors.Wrapf(err,
+				"could not read the derived expression of field %s.%s", field.TableName, field.FieldName)
+		}
+
 		_, ok := dataModel.Tables[field.TableName]
 		if !ok {
 			var ftmEntity *models.FollowTheMoneyEntity
@@ -167,18 +179,20 @@ func (repo MarbleDbRepository) GetDataModel(
 			}
 		}
 		dataModel.Tables[field.TableName].Fields[field.FieldName] = models.Field{
-			ID:           field.FieldID,
-			DataType:     models.DataTypeFrom(field.FieldType),
-			Description:  field.FieldDescription,
-			Alias:        field.FieldAlias,
-			SemanticType: models.FieldSemanticType(field.FieldSemanticType),
-			Name:         field.FieldName,
-			Nullable:     field.FieldNullable,
-			IsEnum:       field.FieldIsEnum,
-			TableId:      field.TableID,
-			Values:       values,
-			FTMProperty:  ftmProperty,
-			Metadata:     field.FieldMetadata,
+			ID:                field.FieldID,
+			DataType:          models.DataTypeFrom(field.FieldType),
+			Description:       field.FieldDescription,
+			Alias:             field.FieldAlias,
+			SemanticType:      models.FieldSemanticType(field.FieldSemanticType),
+			Name:              field.FieldName,
+			Nullable:          field.FieldNullable,
+			IsEnum:            field.FieldIsEnum,
+			TableId:           field.TableID,
+			Values:            values,
+			FTMProperty:       ftmProperty,
+			Metadata:          field.FieldMetadata,
+			Constraints:       constraints,
+			DerivedExpression: derivedExpression,
 		}
 	}
 
@@ -498,6 +512,7 @@ func (repo MarbleDbRepository) getTablesAndFields(ctx context.Context, exec Exec
 			&dbModel.FieldFTMProperty,
 			&dbModel.FieldMetadata,
 			&dbModel.FieldArchived,
+			&dbModel.FieldDerivedExpression,
 		); err != nil {
 			return dbmodels.DbDataModelTableJoinField{}, err
 		}
@@ -630,7 +645,8 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 			data_model_fields.type,
 			data_model_fields.ftm_property,
 			data_model_fields.metadata,
-			data_model_fields.archived
+			data_model_fields.archived,
+			data_model_fields.derived_expression
 		FROM data_model_fields
 		WHERE id = $1 and archived is false
 	`
@@ -641,6 +657,7 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 	var dataType string
 	var ftmProperty *string
 	var semanticType string
+	var derivedExpression []byte
 	if err := row.Scan(
 		&field.Description,
 		&field.Alias,
@@ -653,6 +670,7 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 		&ftmProperty,
 		&field.Metadata,
 		&field.Archived,
+		&derivedExpression,
 	); errors.Is(err, pgx.ErrNoRows) {
 		return models.FieldMetadata{}, fmt.Errorf("error in GetDataModelField: %w", models.NotFoundError)
 	} else if err != nil {
@@ -665,6 +683,14 @@ func (repo MarbleDbRepository) GetDataModelField(ctx context.Context, exec Execu
 		property := models.FollowTheMoneyPropertyFrom(*ftmProperty)
 		field.FTMProperty = &property
 	}
+	expression, err := dbmodels.AdaptSerializedAstExpression(derivedExpression)
+	if err != nil {
+		return models.FieldMetadata{}, errors.Wrap(err, "could not read the derived expression of the field")
+	}
+	field.DerivedExpression = expression
+	if field.Constraints, err = models.FieldConstraintsFromMetadata(field.Metadata); err != nil {
+		return models.FieldMetadata{}, errors.Wrap(err, "could not read the constraints of the field")
+	}
 
 	return field, nil
 }
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// dataRetentionIndexName names the index the retention finds the superseded versions of a table
+// with. Every other index of a client table only covers the live versions.
+func dataRetentionIndexName(tableName string) string {
+	name := "retention_" + tableName
+	return name[:min(len(name), 63)]
+}
+
+func dataRetentionIndexSql(exec Executor, tableName string, concurrently bool) string {
+	mode := ""
+	if concurrently {
+		mode = "CONCURRENTLY "
+	}
+	return fmt.Sprintf("CREATE INDEX %sIF NOT EXISTS %s ON %s (valid_until) WHERE valid_until <> 'infinity'",
+		mode,
+		pgx.Identifier.Sanitize([]string{dataRetentionIndexName(tableName)}),
+		pgIdentifierWithSchema(exec, tableName))
+}
+
+// dataRetentionSupersededRowsQuery deletes a batch of the versions of objects that were replaced
+// by a newer version before the given time. The live version of an object is never superseded.
+func dataRetentionSupersededRowsQuery(tableName string, before time.Time, limit int) squirrel.DeleteBuilder {
+	batch := squirrel.
+		Select("id").
+		From(tableName).
+		Where(squirrel.Lt{"valid_until": before}).
+		Limit(uint64(limit)).
+		// Keep nested placeholders unnumbered so the outer query can number all arguments once.
+		PlaceholderFormat(squirrel.Question)
+
+	return NewQueryBuilder().
+		Delete(tableName).
+		Where(squirrel.Expr("id IN (?)", batch))
+}
+
+// dataRetentionExpiredObjectsQuery deletes every version of a batch of objects whose live version
+// was last updated before the given time.
+func dataRetentionExpiredObjectsQuery(tableName string, before time.Time, limit int) squirrel.DeleteBuilder {
+	batch := squirrel.
+		Select("object_id").
+		From(tableName).
+		Where(squirrel.Eq{"valid_until": "Infinity"}).
+		Where(squirrel.Lt{"updated_at": before}).
+		Limit(uint64(limit)).
+		// Keep nested placeholders unnumbered so the outer query can number all arguments once.
+		PlaceholderFormat(squirrel.Question)
+
+	return NewQueryBuilder().
+		Delete(tableName).
+		Where(squirrel.Expr("object_id IN (?)", batch))
+}
+
+// DeleteDataRetentionSupersededRows deletes a batch of superseded versions, returning the number of
+// rows deleted.
+func (repo *ClientDbRepository) DeleteDataRetentionSupersededRows(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	before time.Time,
+	limit int,
+) (int64, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	return execDataRetentionDelete(ctx, exec,
+		dataRetentionSupersededRowsQuery(sanitizedTableName(exec, tableName), before, limit))
+}
+
+// DeleteDataRetentionExpiredObjects deletes a batch of expired objects, returning the number of
+// rows deleted.
+func (repo *ClientDbRepository) DeleteDataRetentionExpiredObjects(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	before time.Time,
+	limit int,
+) (int64, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	return execDataRetentionDelete(ctx, exec,
+		dataRetentionExpiredObjectsQuery(sanitizedTableName(exec, tableName), before, limit))
+}
+
+func execDataRetentionDelete(ctx context.Context, exec Executor, query squirrel.DeleteBuilder) (int64, error) {
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, err
+	}
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// GetDataRetentionIndexStatus returns the status of the index on the superseded versions of a
+// table, unknown if it does not exist.
+func (repo *ClientDbRepository) GetDataRetentionIndexStatus(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+) (models.IndexStatus, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return models.IndexStatusUnknown, err
+	}
+
+	pgIndexes, err := repo.listAllPgIndexes(ctx, exec)
+	if err != nil {
+		return models.IndexStatusUnknown, errors.Wrap(err, "error while listing all indexes")
+	}
+
+	name := dataRetentionIndexName(tableName)
+	for _, pgIndex := range pgIndexes {
+		if pgIndex.Name == name && pgIndex.TableName == tableName {
+			return pgIndex.AdaptConcreteIndex().Status, nil
+		}
+	}
+	return models.IndexStatusUnknown, nil
+}
+
+// CreateDataRetentionIndexAsync starts building the index on the superseded versions of a table,
+// concurrently so that ingestion is not blocked on large tables. An invalid index left by a failed
+// build is dropped first.
+func (repo *ClientDbRepository) CreateDataRetentionIndexAsync(ctx context.Context, exec Executor, tableName string) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+
+	indexName := dataRetentionIndexName(tableName)
+	if _, err := exec.Exec(ctx, dropIdxSqlQuery(indexName, exec)); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("Error while dropping index %s", indexName))
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	ctx, cancel := context.WithTimeout(ctx, INDEX_CREATION_TIMEOUT)
+
+	go func() {
+		defer cancel()
+		if _, err := exec.Exec(ctx, dataRetentionIndexSql(exec, tableName, true)); err != nil {
+			utils.LogAndReportSentryError(ctx, errors.Wrap(err,
+				fmt.Sprintf("Error while creating index %s", indexName)))
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (repo *MarbleDbRepository) CreateDataRetentionPolicy(
+	ctx context.Context,
+	exec Executor,
+	policy models.DataRetentionPolicy,
+) (models.DataRetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DATA_RETENTION_POLICIES).
+		Columns("id", "org_id", "target", "table_id", "superseded_versions_days", "max_age_days", "enabled").
+		Values(policy.Id, policy.OrganizationId, string(policy.Target), policy.TableId,
+			policy.SupersededVersionsDays, policy.MaxAgeDays, policy.Enabled).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDataRetentionPolicyColumn, ",")))
+
+	created, err := SqlToModel(ctx, exec, query, dbmodels.AdaptDataRetentionPolicy)
+	if IsUniqueViolationError(err) {
+		return models.DataRetentionPolicy{}, fmt.Errorf(
+			"a retention policy already exists for this target: %w", models.ConflictError)
+	}
+	return created, err
+}
+
+func (repo *MarbleDbRepository) GetDataRetentionPolicy(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.DataRetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDataRetentionPolicyColumn...).
+		From(dbmodels.TABLE_DATA_RETENTION_POLICIES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptDataRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) ListDataRetentionPolicies(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+) ([]models.DataRetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDataRetentionPolicyColumn...).
+		From(dbmodels.TABLE_DATA_RETENTION_POLICIES).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at")
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptDataRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) UpdateDataRetentionPolicy(
+	ctx context.Context,
+	exec Executor,
+	input models.UpdateDataRetentionPolicyInput,
+) (models.DataRetentionPolicy, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_RETENTION_POLICIES).
+		Set("superseded_versions_days", input.SupersededVersionsDays).
+		Set("max_age_days", input.MaxAgeDays).
+		Set("enabled", input.Enabled).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": input.Id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDataRetentionPolicyColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptDataRetentionPolicy)
+}
+
+func (repo *MarbleDbRepository) DeleteDataRetentionPolicy(ctx context.Context, exec Executor, id uuid.UUID) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_DATA_RETENTION_POLICIES).
+		Where(squirrel.Eq{"id": id}))
+}
+
+func (repo *MarbleDbRepository) CreateDataRetentionRun(
+	ctx context.Context,
+	exec Executor,
+	run models.DataRetentionRun,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Insert(dbmodels.TABLE_DATA_RETENTION_RUNS).
+		Columns(
+			"id",
+			"org_id",
+			"policy_id",
+			"superseded_rows_deleted",
+			"expired_rows_deleted",
+			"decisions_deleted",
+			"skipped_reason",
+			"error",
+			"started_at",
+			"finished_at",
+		).
+		Values(
+			run.Id,
+			run.OrganizationId,
+			run.PolicyId,
+			run.SupersededRowsDeleted,
+			run.ExpiredRowsDeleted,
+			run.DecisionsDeleted,
+			run.SkippedReason,
+			run.Error,
+			run.StartedAt,
+			run.FinishedAt,
+		))
+}
+
+func (repo *MarbleDbRepository) ListDataRetentionRuns(
+	ctx context.Context,
+	exec Executor,
+	policyId uuid.UUID,
+	limit int,
+) ([]models.DataRetentionRun, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDataRetentionRunColumn...).
+		From(dbmodels.TABLE_DATA_RETENTION_RUNS).
+		Where(squirrel.Eq{"policy_id": policyId}).
+		OrderBy("started_at DESC").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptDataRetentionRun)
+}
+
+// dataRetentionDecisionsQuery selects the oldest decisions created before the given time that can
+// be deleted. Decisions attached to a case, or that ran a screening, are part of an investigation
+// and are kept whatever their age.
+func dataRetentionDecisionsQuery(orgId uuid.UUID, before time.Time, limit int) squirrel.SelectBuilder {
+	return NewQueryBuilder().
+		Select("d.id", "d.created_at", "d.outcome").
+		From(dbmodels.TABLE_DECISIONS + " AS d").
+		Where(squirrel.Eq{"d.org_id": orgId}).
+		Where(squirrel.Lt{"d.created_at": before}).
+		Where("d.case_id IS NULL").
+		Where(fmt.Sprintf("NOT EXISTS (SELECT 1 FROM %s AS s WHERE s.decision_id = d.id)", dbmodels.TABLE_SCREENINGS)).
+		OrderBy("d.created_at").
+		Limit(uint64(limit))
+}
+
+func (repo *MarbleDbRepository) ListDataRetentionDecisions(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	before time.Time,
+	limit int,
+) ([]models.DataRetentionDecision, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	return SqlToListOfRow(ctx, exec, dataRetentionDecisionsQuery(orgId, before, limit),
+		func(row pgx.CollectableRow) (models.DataRetentionDecision, error) {
+			var decision models.DataRetentionDecision
+			err := row.Scan(&decision.Id, &decision.CreatedAt, &decision.Outcome)
+			return decision, err
+		})
+}
+
+// DeleteDataRetentionDecisions deletes the decisions and their rule executions, returning the
+// number of decisions deleted.
+func (repo *MarbleDbRepository) DeleteDataRetentionDecisions(
+	ctx context.Context,
+	exec Executor,
+	decisionIds []uuid.UUID,
+) (int64, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_DECISION_RULES).
+		Where(squirrel.Eq{"decision_id": decisionIds})); err != nil {
+		return 0, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Delete(dbmodels.TABLE_DECISIONS).
+		Where(squirrel.Eq{"id": decisionIds}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataRetentionSupersededRowsQuery(t *testing.T) {
+	before := time.Now()
+
+	sql, args, err := dataRetentionSupersededRowsQuery(`"org"."transactions"`, before, 1000).ToSql()
+
+	require.NoError(t, err)
+	require.Equal(t, `DELETE FROM "org"."transactions" WHERE id IN `+
+		`(SELECT id FROM "org"."transactions" WHERE valid_until < $1 LIMIT 1000)`, sql)
+	require.Equal(t, []any{before}, args)
+}
+
+func TestDataRetentionExpiredObjectsQuery(t *testing.T) {
+	before := time.Now()
+
+	sql, args, err := dataRetentionExpiredObjectsQuery(`"org"."transactions"`, before, 1000).ToSql()
+
+	require.NoError(t, err)
+	require.Equal(t, `DELETE FROM "org"."transactions" WHERE object_id IN `+
+		`(SELECT object_id FROM "org"."transactions" WHERE valid_until = $1 AND updated_at < $2 LIMIT 1000)`, sql)
+	require.Equal(t, []any{"Infinity", before}, args)
+}
+
+func TestDataRetentionDecisionsQuery(t *testing.T) {
+	orgId := uuid.New()
+	before := time.Now()
+
+	sql, args, err := dataRetentionDecisionsQuery(orgId, before, 500).ToSql()
+
+	require.NoError(t, err)
+	require.Equal(t, "SELECT d.id, d.created_at, d.outcome FROM decisions AS d "+
+		"WHERE d.org_id = $1 AND d.created_at < $2 AND d.case_id IS NULL "+
+		"AND NOT EXISTS (SELECT 1 FROM screenings AS s WHERE s.decision_id = d.id) "+
+		"ORDER BY d.created_at LIMIT 500", sql)
+	require.Equal(t, []any{orgId.String(), before}, args)
+}
+
+func TestDataRetentionIndexSql(t *testing.T) {
+	require.Equal(t, `CREATE INDEX CONCURRENTLY IF NOT EXISTS "retention_transactions" `+
+		`ON "test_schema"."transactions" (valid_until) WHERE valid_until <> 'infinity'`,
+		dataRetentionIndexSql(TransactionTest{}, "transactions", true))
+	require.Equal(t, `CREATE INDEX IF NOT EXISTS "retention_transactions" `+
+		`ON "test_schema"."transactions" (valid_until) WHERE valid_until <> 'infinity'`,
+		dataRetentionIndexSql(TransactionTest{}, "transactions", false))
+}
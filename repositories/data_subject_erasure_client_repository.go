@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetDataSubjectErasureFieldValues reads the given fields of the latest version of an object,
+// returning nil if the object was never ingested.
+func (repo *ClientDbRepository) GetDataSubjectErasureFieldValues(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	objectId string,
+	fields []string,
+) (map[string]any, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		fields = []string{"object_id"}
+	}
+
+	columns := make([]string, len(fields))
+	for i, field := range fields {
+		columns[i] = pgx.Identifier{field}.Sanitize()
+	}
+
+	query := NewQueryBuilder().
+		Select(columns...).
+		From(sanitizedTableName(exec, tableName)).
+		Where(squirrel.Eq{"object_id": objectId}).
+		OrderBy("valid_from DESC").
+		Limit(1)
+
+	values, err := SqlToOptionalRow(ctx, exec, query, func(row pgx.CollectableRow) (map[string]any, error) {
+		raw, err := row.Values()
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]any, len(fields))
+		for i, field := range fields {
+			values[field] = raw[i]
+		}
+		return values, nil
+	})
+	if err != nil || values == nil {
+		return nil, err
+	}
+	return *values, nil
+}
+
+// ListDataSubjectErasureLinkedObjectIds lists the objects of a table that reference a value through
+// the given field, in any of their versions.
+func (repo *ClientDbRepository) ListDataSubjectErasureLinkedObjectIds(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	fieldName string,
+	value any,
+	limit int,
+) ([]string, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select("object_id").
+		Distinct().
+		From(sanitizedTableName(exec, tableName)).
+		Where(squirrel.Eq{pgx.Identifier{fieldName}.Sanitize(): value}).
+		Limit(uint64(limit))
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (string, error) {
+		var objectId string
+		err := row.Scan(&objectId)
+		return objectId, err
+	})
+}
+
+func (repo *ClientDbRepository) CountDataSubjectErasureRows(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	objectIds []string,
+) (int64, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	query := NewQueryBuilder().
+		Select("count(*)").
+		From(sanitizedTableName(exec, tableName)).
+		Where(squirrel.Eq{"object_id": objectIds})
+
+	return SqlToRow(ctx, exec, query, func(row pgx.CollectableRow) (int64, error) {
+		var count int64
+		err := row.Scan(&count)
+		return count, err
+	})
+}
+
+// DeleteDataSubjectErasureRows deletes every version of the objects, returning the number of rows
+// deleted.
+func (repo *ClientDbRepository) DeleteDataSubjectErasureRows(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	objectIds []string,
+) (int64, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Delete(sanitizedTableName(exec, tableName)).
+		Where(squirrel.Eq{"object_id": objectIds}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteDataSubjectErasureMonitoredObjects removes the objects from continuous screening, returning
+// the number of registrations deleted. The history of the registrations is kept, pseudonymized.
+func (repo *ClientDbRepository) DeleteDataSubjectErasureMonitoredObjects(
+	ctx context.Context,
+	exec Executor,
+	objectType string,
+	objectIds []string,
+	pseudonym string,
+) (int64, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Delete(sanitizedTableName(exec, dbmodels.TABLE_CONTINUOUS_SCREENING_MONITORED_OBJECTS)).
+		Where(squirrel.Eq{"object_type": objectType}).
+		Where(squirrel.Eq{"object_id": objectIds}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(sanitizedTableName(exec, dbmodels.TABLE_CONTINUOUS_SCREENING_AUDIT)).
+		Set("object_id", pseudonym).
+		Set("extra", nil).
+		Where(squirrel.Eq{"object_type": objectType}).
+		Where(squirrel.Eq{"object_id": objectIds})); err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,728 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// Tables whose audit events hold a copy of the rows an erasure pseudonymizes or deletes
+var dataSubjectErasureAuditedTables = []string{
+	dbmodels.TABLE_DECISIONS,
+	dbmodels.TABLE_SCREENINGS,
+	dbmodels.TABLE_SCREENING_MATCHES,
+	dbmodels.TABLE_ENTITY_ANNOTATIONS,
+	// Names of the screening tables when their audit triggers were created
+	"sanction_checks",
+	"sanction_check_matches",
+}
+
+func (repo *MarbleDbRepository) CreateDataSubjectErasure(
+	ctx context.Context,
+	exec Executor,
+	erasure models.DataSubjectErasure,
+) (models.DataSubjectErasure, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DataSubjectErasure{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_DATA_SUBJECT_ERASURES).
+		Columns("id", "org_id", "object_type", "object_id", "created_by").
+		Values(erasure.Id, erasure.OrganizationId, erasure.ObjectType, erasure.ObjectId, erasure.CreatedBy).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectDataSubjectErasureColumn, ",")))
+
+	created, err := SqlToModel(ctx, exec, query, dbmodels.AdaptDataSubjectErasure)
+	if IsUniqueViolationError(err) {
+		return models.DataSubjectErasure{}, fmt.Errorf(
+			"an erasure of this object is already in progress: %w", models.ConflictError)
+	}
+	return created, err
+}
+
+func (repo *MarbleDbRepository) GetDataSubjectErasure(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.DataSubjectErasure, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DataSubjectErasure{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDataSubjectErasureColumn...).
+		From(dbmodels.TABLE_DATA_SUBJECT_ERASURES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptDataSubjectErasure)
+}
+
+func (repo *MarbleDbRepository) ListDataSubjectErasures(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+) ([]models.DataSubjectErasure, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectDataSubjectErasureColumn...).
+		From(dbmodels.TABLE_DATA_SUBJECT_ERASURES).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at DESC")
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptDataSubjectErasure)
+}
+
+// UpdateDataSubjectErasureProgress records the stage an erasure reached, the targets it works on and
+// what it erased so far.
+func (repo *MarbleDbRepository) UpdateDataSubjectErasureProgress(
+	ctx context.Context,
+	exec Executor,
+	erasure models.DataSubjectErasure,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	targets, err := json.Marshal(erasure.Targets)
+	if err != nil {
+		return err
+	}
+	counts, err := json.Marshal(erasure.Counts)
+	if err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_SUBJECT_ERASURES).
+		Set("status", string(models.DataSubjectErasureRunning)).
+		Set("stage", string(erasure.Stage)).
+		Set("targets", targets).
+		Set("counts", counts).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": erasure.Id})
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+// CompleteDataSubjectErasure ends an erasure. A completed or failed erasure forgets the data subject
+// and its targets, only keeping what kind of data was erased.
+func (repo *MarbleDbRepository) CompleteDataSubjectErasure(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	status models.DataSubjectErasureStatus,
+	erasureError *string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_DATA_SUBJECT_ERASURES).
+		Set("status", string(status)).
+		Set("error", erasureError).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("completed_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id})
+
+	if status.IsTerminal() {
+		query = query.
+			Set("object_id", nil).
+			Set("targets", nil)
+	}
+	if status == models.DataSubjectErasureCompleted {
+		query = query.Set("stage", string(models.DataSubjectErasureStageDone))
+	}
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+// dataSubjectErasureDecisionsFilter matches the decisions taken on one of the objects, or on one of
+// the pivot values, of the targets. Pseudonymized decisions no longer match it.
+func dataSubjectErasureDecisionsFilter(orgId uuid.UUID, targets models.DataSubjectErasureTargets) squirrel.Sqlizer {
+	matches := squirrel.Or{}
+
+	objectIds := targets.ObjectIdsByType()
+	for _, objectType := range slices.Sorted(maps.Keys(objectIds)) {
+		matches = append(matches, squirrel.And{
+			squirrel.Eq{"trigger_object_type": objectType},
+			squirrel.Eq{"trigger_object->>'object_id'": objectIds[objectType]},
+		})
+	}
+
+	pivotValues := targets.PivotValuesById()
+	for _, pivotId := range slices.SortedFunc(maps.Keys(pivotValues), func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	}) {
+		matches = append(matches, squirrel.And{
+			squirrel.Eq{"pivot_id": pivotId},
+			squirrel.Eq{"pivot_value": pivotValues[pivotId]},
+		})
+	}
+
+	return squirrel.And{squirrel.Eq{"org_id": orgId}, matches}
+}
+
+func (repo *MarbleDbRepository) ListDataSubjectErasureDecisions(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	targets models.DataSubjectErasureTargets,
+	limit int,
+) ([]models.DataSubjectErasureDecision, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select("id", "created_at", "outcome").
+		From(dbmodels.TABLE_DECISIONS).
+		Where(dataSubjectErasureDecisionsFilter(orgId, targets)).
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptDataSubjectErasureDecision)
+}
+
+// CountDataSubjectErasureRecords counts the decisions, screenings, continuous screenings, annotations
+// and annotation files an erasure would pseudonymize or delete.
+func (repo *MarbleDbRepository) CountDataSubjectErasureRecords(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	targets models.DataSubjectErasureTargets,
+) (models.DataSubjectErasureCounts, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.DataSubjectErasureCounts{}, err
+	}
+
+	query := dataSubjectErasureCountQuery(orgId, targets)
+
+	return SqlToRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DataSubjectErasureCounts, error) {
+		var counts models.DataSubjectErasureCounts
+		err := row.Scan(&counts.Decisions, &counts.Screenings, &counts.ScreeningMatches,
+			&counts.ContinuousScreenings, &counts.ContinuousScreeningMatches, &counts.Annotations, &counts.Files)
+		return counts, err
+	})
+}
+
+// dataSubjectErasureCountQuery counts, in a single row, the records of the targets held by the
+// marble database.
+func dataSubjectErasureCountQuery(orgId uuid.UUID, targets models.DataSubjectErasureTargets) squirrel.SelectBuilder {
+	// Keep nested placeholders unnumbered so the outer query can number all arguments once.
+	decisions := NewQueryBuilder().
+		Select("id").
+		From(dbmodels.TABLE_DECISIONS).
+		Where(dataSubjectErasureDecisionsFilter(orgId, targets)).
+		PlaceholderFormat(squirrel.Question)
+	screenings := NewQueryBuilder().
+		Select("s.id").
+		From(dbmodels.TABLE_SCREENINGS + " s").
+		Where(decisions.Prefix("s.decision_id IN (").Suffix(")")).
+		PlaceholderFormat(squirrel.Question)
+
+	return NewQueryBuilder().
+		Select().
+		Column(squirrel.Alias(decisions.Prefix("(SELECT count(*) FROM (").Suffix(") d)"), "decisions")).
+		Column(squirrel.Alias(screenings.Prefix("(SELECT count(*) FROM (").Suffix(") s)"), "screenings")).
+		Column(squirrel.Alias(NewQueryBuilder().
+			Select("count(*)").
+			From(dbmodels.TABLE_SCREENING_MATCHES).
+			Where(screenings.Prefix("screening_id IN (").Suffix(")")).
+			Prefix("(").Suffix(")").
+			PlaceholderFormat(squirrel.Question), "screening_matches")).
+		Column(squirrel.Alias(NewQueryBuilder().
+			Select("count(*)").
+			From(dbmodels.TABLE_CONTINUOUS_SCREENINGS).
+			Where(dataSubjectErasureContinuousScreeningsFilter(orgId, targets)).
+			Prefix("(").Suffix(")").
+			PlaceholderFormat(squirrel.Question), "continuous_screenings")).
+		Column(squirrel.Alias(NewQueryBuilder().
+			Select("count(*)").
+			From(dbmodels.TABLE_CONTINUOUS_SCREENING_MATCHES).
+			Where(dataSubjectErasureContinuousScreeningMatchesFilter(orgId, targets)).
+			Prefix("(").Suffix(")").
+			PlaceholderFormat(squirrel.Question), "continuous_screening_matches")).
+		Column(squirrel.Alias(NewQueryBuilder().
+			Select("count(*)").
+			From(dbmodels.TABLE_ENTITY_ANNOTATIONS).
+			Where(dataSubjectErasureAnnotationsFilter(orgId, targets)).
+			Prefix("(").Suffix(")").
+			PlaceholderFormat(squirrel.Question), "annotations")).
+		Column(squirrel.Alias(NewQueryBuilder().
+			Select("coalesce(sum(jsonb_array_length(payload->'files')), 0)").
+			From(dbmodels.TABLE_ENTITY_ANNOTATIONS).
+			Where(dataSubjectErasureAnnotationsFilter(orgId, targets)).
+			Where(squirrel.Eq{"annotation_type": models.EntityAnnotationFile.String()}).
+			Prefix("(").Suffix(")").
+			PlaceholderFormat(squirrel.Question), "files"))
+}
+
+func (repo *MarbleDbRepository) ListDataSubjectErasureDecisionRules(
+	ctx context.Context,
+	exec Executor,
+	decisionIds []uuid.UUID,
+) ([]models.DataSubjectErasureDecisionRule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select("decision_id", "rule_id", "coalesce(outcome, '')").
+		From(dbmodels.TABLE_DECISION_RULES).
+		Where(squirrel.Eq{"decision_id": decisionIds})
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DataSubjectErasureDecisionRule, error) {
+		var rule models.DataSubjectErasureDecisionRule
+		err := row.Scan(&rule.DecisionId, &rule.RuleId, &rule.Outcome)
+		return rule, err
+	})
+}
+
+func (repo *MarbleDbRepository) ListDataSubjectErasureScreeningMatches(
+	ctx context.Context,
+	exec Executor,
+	decisionIds []uuid.UUID,
+) ([]models.DataSubjectErasureScreeningMatch, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select("m.id", "m.screening_id").
+		From(dbmodels.TABLE_SCREENING_MATCHES + " m").
+		Join(dbmodels.TABLE_SCREENINGS + " s ON s.id = m.screening_id").
+		Where(squirrel.Eq{"s.decision_id": decisionIds})
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DataSubjectErasureScreeningMatch, error) {
+		var match models.DataSubjectErasureScreeningMatch
+		err := row.Scan(&match.Id, &match.ScreeningId)
+		return match, err
+	})
+}
+
+func (repo *MarbleDbRepository) ListDataSubjectErasureScreeningFiles(
+	ctx context.Context,
+	exec Executor,
+	decisionIds []uuid.UUID,
+) ([]models.ScreeningFile, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(columnsNames("f", dbmodels.SelectScreeningFileColumn)...).
+		From(dbmodels.TABLE_SCREENING_FILES + " f").
+		Join(dbmodels.TABLE_SCREENINGS + " s ON s.id = f.screening_id").
+		Where(squirrel.Eq{"s.decision_id": decisionIds})
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptScreeningFile)
+}
+
+// PseudonymizeDataSubjectErasureDecisions replaces the data subject by the pseudonym in decisions
+// and removes the personal data of their rule executions and screenings, returning the number of
+// screenings and screening matches modified. The blobs offloaded for the decisions and the
+// screening files must have been deleted beforehand, as their keys cannot be found afterwards.
+func (repo *MarbleDbRepository) PseudonymizeDataSubjectErasureDecisions(
+	ctx context.Context,
+	exec Executor,
+	decisionIds []uuid.UUID,
+	pseudonym string,
+) (int64, int64, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, 0, err
+	}
+
+	screeningIds := NewQueryBuilder().
+		Select("id").
+		From(dbmodels.TABLE_SCREENINGS).
+		Where(squirrel.Eq{"decision_id": decisionIds}).
+		PlaceholderFormat(squirrel.Question)
+
+	if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_SCREENING_FILES).
+		Where(screeningIds.Prefix("screening_id IN (").Suffix(")"))); err != nil {
+		return 0, 0, err
+	}
+
+	// An empty payload means the payload was offloaded, an empty object is kept instead.
+	matchesQuery := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCREENING_MATCHES).
+		Set("payload", squirrel.Expr("'{}'::jsonb")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(screeningIds.Prefix("screening_id IN (").Suffix(")")).
+		Suffix("RETURNING id")
+	matchIds, err := SqlToListOfRow(ctx, exec, matchesQuery, func(row pgx.CollectableRow) (string, error) {
+		var id string
+		err := row.Scan(&id)
+		return id, err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	screeningsQuery := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCREENINGS).
+		Set("search_input", nil).
+		Set("initial_query", nil).
+		Set("counterparty_id", nil).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"decision_id": decisionIds}).
+		Suffix("RETURNING id")
+	screeningIdList, err := SqlToListOfRow(ctx, exec, screeningsQuery, func(row pgx.CollectableRow) (string, error) {
+		var id string
+		err := row.Scan(&id)
+		return id, err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISION_RULES).
+		Set("rule_evaluation", nil).
+		Where(squirrel.Eq{"decision_id": decisionIds})); err != nil {
+		return 0, 0, err
+	}
+
+	if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_DECISIONS).
+		Set("trigger_object", squirrel.Expr("jsonb_build_object('object_id', ?::text)", pseudonym)).
+		Set("pivot_value", squirrel.Expr("CASE WHEN pivot_value IS NULL THEN NULL ELSE ?::text END", pseudonym)).
+		Set("analytics_fields", nil).
+		Where(squirrel.Eq{"id": decisionIds})); err != nil {
+		return 0, 0, err
+	}
+
+	entityIds := make([]string, 0, len(decisionIds)+len(screeningIdList)+len(matchIds))
+	for _, id := range decisionIds {
+		entityIds = append(entityIds, id.String())
+	}
+	entityIds = append(entityIds, screeningIdList...)
+	entityIds = append(entityIds, matchIds...)
+	if err := repo.redactDataSubjectErasureAuditEvents(ctx, exec, entityIds); err != nil {
+		return 0, 0, err
+	}
+
+	return int64(len(screeningIdList)), int64(len(matchIds)), nil
+}
+
+// dataSubjectErasureScreenedObjectsFilter matches the continuous screenings of one of the objects of
+// the targets. Pseudonymized screenings no longer match it.
+func dataSubjectErasureScreenedObjectsFilter(targets models.DataSubjectErasureTargets) squirrel.Or {
+	matches := squirrel.Or{}
+	objectIds := targets.ObjectIdsByType()
+	for _, objectType := range slices.Sorted(maps.Keys(objectIds)) {
+		matches = append(matches, squirrel.And{
+			squirrel.Eq{"object_type": objectType},
+			squirrel.Eq{"object_id": objectIds[objectType]},
+		})
+	}
+	return matches
+}
+
+// dataSubjectErasureMarbleEntityIds are the ids of the objects of the targets in the matches of the
+// continuous screenings of sanctioned entities.
+func dataSubjectErasureMarbleEntityIds(targets models.DataSubjectErasureTargets) []string {
+	ids := make([]string, 0, len(targets.Objects))
+	for _, object := range targets.Objects {
+		ids = append(ids, pure_utils.MarbleEntityIdBuilder(object.ObjectType, object.ObjectId))
+	}
+	return ids
+}
+
+// dataSubjectErasureContinuousScreeningsFilter matches the continuous screenings of one of the
+// objects of the targets, and those of sanctioned entities matching one of them.
+func dataSubjectErasureContinuousScreeningsFilter(orgId uuid.UUID, targets models.DataSubjectErasureTargets) squirrel.Sqlizer {
+	matched := NewQueryBuilder().
+		Select("continuous_screening_id").
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_MATCHES).
+		Where(squirrel.Eq{"opensanction_entity_id": dataSubjectErasureMarbleEntityIds(targets)}).
+		PlaceholderFormat(squirrel.Question)
+
+	return squirrel.And{
+		squirrel.Eq{"org_id": orgId},
+		squirrel.Or{
+			dataSubjectErasureScreenedObjectsFilter(targets),
+			matched.Prefix("id IN (").Suffix(")"),
+		},
+	}
+}
+
+// dataSubjectErasureContinuousScreeningMatchesFilter matches the matches of the continuous screenings
+// of the objects of the targets, and the matches on the objects in other continuous screenings.
+func dataSubjectErasureContinuousScreeningMatchesFilter(orgId uuid.UUID, targets models.DataSubjectErasureTargets) squirrel.Sqlizer {
+	screened := NewQueryBuilder().
+		Select("id").
+		From(dbmodels.TABLE_CONTINUOUS_SCREENINGS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		Where(dataSubjectErasureScreenedObjectsFilter(targets)).
+		PlaceholderFormat(squirrel.Question)
+	organization := NewQueryBuilder().
+		Select("id").
+		From(dbmodels.TABLE_CONTINUOUS_SCREENINGS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		PlaceholderFormat(squirrel.Question)
+
+	return squirrel.Or{
+		screened.Prefix("continuous_screening_id IN (").Suffix(")"),
+		squirrel.And{
+			squirrel.Eq{"opensanction_entity_id": dataSubjectErasureMarbleEntityIds(targets)},
+			organization.Prefix("continuous_screening_id IN (").Suffix(")"),
+		},
+	}
+}
+
+// ListDataSubjectErasureContinuousScreenings lists the continuous screenings of the targets, or
+// matching them, that are not pseudonymized yet.
+func (repo *MarbleDbRepository) ListDataSubjectErasureContinuousScreenings(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	targets models.DataSubjectErasureTargets,
+	limit int,
+) ([]models.DataSubjectErasureContinuousScreening, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select("id").
+		Column(squirrel.Alias(squirrel.Expr("coalesce(?, false)",
+			dataSubjectErasureScreenedObjectsFilter(targets)), "of_target")).
+		From(dbmodels.TABLE_CONTINUOUS_SCREENINGS).
+		Where(dataSubjectErasureContinuousScreeningsFilter(orgId, targets)).
+		OrderBy("id").
+		Limit(uint64(limit))
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DataSubjectErasureContinuousScreening, error) {
+		var screening models.DataSubjectErasureContinuousScreening
+		err := row.Scan(&screening.Id, &screening.OfTarget)
+		return screening, err
+	})
+}
+
+// ListDataSubjectErasureContinuousScreeningMatches lists every match of the screenings of the targets,
+// and the matches on the targets in the other screenings.
+func (repo *MarbleDbRepository) ListDataSubjectErasureContinuousScreeningMatches(
+	ctx context.Context,
+	exec Executor,
+	targets models.DataSubjectErasureTargets,
+	screenings []models.DataSubjectErasureContinuousScreening,
+) ([]models.DataSubjectErasureContinuousScreeningMatch, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	var screenedIds, matchedIds []uuid.UUID
+	for _, screening := range screenings {
+		if screening.OfTarget {
+			screenedIds = append(screenedIds, screening.Id)
+		} else {
+			matchedIds = append(matchedIds, screening.Id)
+		}
+	}
+
+	query := NewQueryBuilder().
+		Select("id", "continuous_screening_id").
+		From(dbmodels.TABLE_CONTINUOUS_SCREENING_MATCHES).
+		Where(squirrel.Or{
+			squirrel.Eq{"continuous_screening_id": screenedIds},
+			squirrel.And{
+				squirrel.Eq{"continuous_screening_id": matchedIds},
+				squirrel.Eq{"opensanction_entity_id": dataSubjectErasureMarbleEntityIds(targets)},
+			},
+		})
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DataSubjectErasureContinuousScreeningMatch, error) {
+		var match models.DataSubjectErasureContinuousScreeningMatch
+		err := row.Scan(&match.Id, &match.ContinuousScreeningId)
+		return match, err
+	})
+}
+
+// PseudonymizeDataSubjectErasureContinuousScreenings replaces the data subject by the pseudonym in the
+// continuous screenings of the targets, and removes the payloads of the given matches. The matches on
+// the targets in screenings of sanctioned entities get the pseudonym as entity id, so that they no
+// longer match the targets. The offloaded blobs must have been deleted beforehand.
+func (repo *MarbleDbRepository) PseudonymizeDataSubjectErasureContinuousScreenings(
+	ctx context.Context,
+	exec Executor,
+	targets models.DataSubjectErasureTargets,
+	screeningIds []uuid.UUID,
+	matchIds []uuid.UUID,
+	pseudonym string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	if len(matchIds) > 0 {
+		if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+			Update(dbmodels.TABLE_CONTINUOUS_SCREENING_MATCHES).
+			Set("payload", squirrel.Expr("'{}'::jsonb")).
+			Set("opensanction_entity_id", squirrel.Expr(
+				"CASE WHEN opensanction_entity_id = ANY(?::text[]) THEN ?::text ELSE opensanction_entity_id END",
+				dataSubjectErasureMarbleEntityIds(targets), pseudonym)).
+			Set("updated_at", squirrel.Expr("NOW()")).
+			Where(squirrel.Eq{"id": matchIds})); err != nil {
+			return err
+		}
+	}
+
+	if len(screeningIds) == 0 {
+		return nil
+	}
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_CONTINUOUS_SCREENINGS).
+		Set("object_id", pseudonym).
+		Set("search_input", nil).
+		Set("opensanction_entity_payload", nil).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": screeningIds}))
+}
+
+func dataSubjectErasureAnnotationsFilter(orgId uuid.UUID, targets models.DataSubjectErasureTargets) squirrel.Sqlizer {
+	matches := squirrel.Or{}
+	objectIds := targets.ObjectIdsByType()
+	for _, objectType := range slices.Sorted(maps.Keys(objectIds)) {
+		matches = append(matches, squirrel.And{
+			squirrel.Eq{"object_type": objectType},
+			squirrel.Eq{"object_id": objectIds[objectType]},
+		})
+	}
+
+	return squirrel.And{squirrel.Eq{"org_id": orgId}, matches}
+}
+
+// ListDataSubjectErasureAnnotations lists the annotations of the targets, deleted ones included.
+func (repo *MarbleDbRepository) ListDataSubjectErasureAnnotations(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	targets models.DataSubjectErasureTargets,
+	limit int,
+) ([]models.EntityAnnotation, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.EntityAnnotationColumns...).
+		From(dbmodels.TABLE_ENTITY_ANNOTATIONS).
+		Where(dataSubjectErasureAnnotationsFilter(orgId, targets)).
+		OrderBy("id").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptEntityAnnotation)
+}
+
+func (repo *MarbleDbRepository) DeleteDataSubjectErasureAnnotations(
+	ctx context.Context,
+	exec Executor,
+	ids []string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	if err := ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_ENTITY_ANNOTATIONS).
+		Where(squirrel.Eq{"id": ids})); err != nil {
+		return err
+	}
+
+	return repo.redactDataSubjectErasureAuditEvents(ctx, exec, ids)
+}
+
+// ListDataSubjectErasureCaseComments lists the case comments that may mention one of the
+// identifiers, after the given comment id. The comments are only prefiltered: the caller checks
+// that an identifier is actually mentioned.
+func (repo *MarbleDbRepository) ListDataSubjectErasureCaseComments(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	identifiers []string,
+	afterId string,
+	limit int,
+) ([]models.DataSubjectErasureCaseComment, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	patterns := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		if identifier != "" {
+			patterns = append(patterns, "%"+escapeLikePattern(identifier)+"%")
+		}
+	}
+
+	query := NewQueryBuilder().
+		Select("id", "additional_note").
+		From(dbmodels.TABLE_CASE_EVENTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		Where(squirrel.Eq{"event_type": string(models.CaseCommentAdded)}).
+		Where("additional_note LIKE ANY(?)", patterns).
+		OrderBy("id").
+		Limit(uint64(limit))
+	if afterId != "" {
+		query = query.Where(squirrel.Gt{"id": afterId})
+	}
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DataSubjectErasureCaseComment, error) {
+		var comment models.DataSubjectErasureCaseComment
+		err := row.Scan(&comment.Id, &comment.Note)
+		return comment, err
+	})
+}
+
+func (repo *MarbleDbRepository) UpdateDataSubjectErasureCaseComment(
+	ctx context.Context,
+	exec Executor,
+	id string,
+	note string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_CASE_EVENTS).
+		Set("additional_note", note).
+		Where(squirrel.Eq{"id": id}))
+}
+
+// redactDataSubjectErasureAuditEvents removes the copy of erased rows kept by the audit trail. The
+// events themselves are kept, so that the trail still shows who did what and when.
+func (repo *MarbleDbRepository) redactDataSubjectErasureAuditEvents(
+	ctx context.Context,
+	exec Executor,
+	entityIds []string,
+) error {
+	if len(entityIds) == 0 {
+		return nil
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_AUDIT_EVENTS).
+		Set("data", squirrel.Expr("jsonb_build_object('id', entity_id, 'erased', true)")).
+		Set("previous_data", nil).
+		Where(squirrel.Eq{`"table"`: dataSubjectErasureAuditedTables}).
+		Where("entity_id = ANY(?::uuid[])", entityIds))
+}
+
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
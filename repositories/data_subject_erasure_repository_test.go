@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSubjectErasureDecisionsFilter(t *testing.T) {
+	orgId := uuid.New()
+	pivotId := uuid.New()
+	targets := models.DataSubjectErasureTargets{
+		Objects: []models.DataSubjectErasureObject{
+			{ObjectType: "users", ObjectId: "u1"},
+			{ObjectType: "accounts", ObjectId: "a1"},
+			{ObjectType: "accounts", ObjectId: "a2", Depth: 1},
+		},
+		PivotValues: []models.DataSubjectErasurePivotValue{{PivotId: pivotId, Value: "u1"}},
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Select("id").
+		From("decisions").
+		Where(dataSubjectErasureDecisionsFilter(orgId, targets)).
+		ToSql()
+
+	require.NoError(t, err)
+	require.Equal(t, "SELECT id FROM decisions WHERE (org_id = $1 AND "+
+		"((trigger_object_type = $2 AND trigger_object->>'object_id' IN ($3,$4)) OR "+
+		"(trigger_object_type = $5 AND trigger_object->>'object_id' IN ($6)) OR "+
+		"(pivot_id = $7 AND pivot_value IN ($8))))", sql)
+	require.Equal(t, []any{orgId.String(), "accounts", "a1", "a2", "users", "u1", pivotId.String(), "u1"}, args)
+}
+
+func TestDataSubjectErasureCountQuery(t *testing.T) {
+	orgId := uuid.New()
+	targets := models.DataSubjectErasureTargets{
+		Objects: []models.DataSubjectErasureObject{{ObjectType: "users", ObjectId: "u1"}},
+	}
+
+	sql, args, err := dataSubjectErasureCountQuery(orgId, targets).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, "SELECT id FROM decisions WHERE (org_id = $1 AND "+
+		"((trigger_object_type = $2 AND trigger_object->>'object_id' IN ($3))))")
+	require.Contains(t, sql, "SELECT s.id FROM screenings s WHERE s.decision_id IN ( "+
+		"SELECT id FROM decisions WHERE (org_id = $7 AND")
+	require.Contains(t, sql, "SELECT count(*) FROM continuous_screenings WHERE (org_id = $10 AND "+
+		"(((object_type = $11 AND object_id IN ($12))) OR id IN ( "+
+		"SELECT continuous_screening_id FROM continuous_screening_matches WHERE opensanction_entity_id IN ($13) )))")
+	require.Equal(t, "marble_users_u1", args[12])
+	require.Contains(t, sql, "annotation_type = $25")
+	require.Len(t, args, 25)
+}
@@ -0,0 +1,84 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORTS          = "continuous_screening_attestation_reports"
+	TABLE_CONTINUOUS_SCREENING_ATTESTATION_REPORT_DOWNLOADS = "continuous_screening_attestation_report_downloads"
+)
+
+var SelectContinuousScreeningAttestationReportColumn = utils.ColumnList[DBContinuousScreeningAttestationReport]()
+
+type DBContinuousScreeningAttestationReport struct {
+	Id                 uuid.UUID `db:"id"`
+	OrgId              uuid.UUID `db:"org_id"`
+	CsvFilePath        string    `db:"csv_file_path"`
+	JsonFilePath       string    `db:"json_file_path"`
+	ObjectCount        int       `db:"object_count"`
+	FlaggedObjectCount int       `db:"flagged_object_count"`
+	OpenMatchCount     int       `db:"open_match_count"`
+	CreatedAt          time.Time `db:"created_at"`
+}
+
+func AdaptContinuousScreeningAttestationReport(db DBContinuousScreeningAttestationReport) (models.ContinuousScreeningAttestationReport, error) {
+	return models.ContinuousScreeningAttestationReport{
+		Id:                 db.Id,
+		OrgId:              db.OrgId,
+		CsvFilePath:        db.CsvFilePath,
+		JsonFilePath:       db.JsonFilePath,
+		ObjectCount:        db.ObjectCount,
+		FlaggedObjectCount: db.FlaggedObjectCount,
+		OpenMatchCount:     db.OpenMatchCount,
+		CreatedAt:          db.CreatedAt,
+	}, nil
+}
+
+type DBContinuousScreeningAttestationUpdate struct {
+	ConfigStableId uuid.UUID  `db:"config_stable_id"`
+	DatasetName    string     `db:"dataset_name"`
+	Version        string     `db:"version"`
+	ReceivedAt     time.Time  `db:"received_at"`
+	Status         string     `db:"status"`
+	StartedAt      *time.Time `db:"started_at"`
+	FinishedAt     *time.Time `db:"finished_at"`
+}
+
+func AdaptContinuousScreeningAttestationUpdate(db DBContinuousScreeningAttestationUpdate) (models.ContinuousScreeningAttestationUpdate, error) {
+	return models.ContinuousScreeningAttestationUpdate{
+		ConfigStableId: db.ConfigStableId,
+		DatasetName:    db.DatasetName,
+		Version:        db.Version,
+		ReceivedAt:     db.ReceivedAt,
+		Status:         models.ContinuousScreeningUpdateJobStatusFrom(db.Status),
+		StartedAt:      db.StartedAt,
+		FinishedAt:     db.FinishedAt,
+	}, nil
+}
+
+type DBContinuousScreeningAttestationObjectStats struct {
+	ObjectType            string     `db:"object_type"`
+	ObjectId              string     `db:"object_id"`
+	ConfigStableId        uuid.UUID  `db:"config_stable_id"`
+	LastObjectScreeningAt *time.Time `db:"last_object_screening_at"`
+	IndexedAt             *time.Time `db:"indexed_at"`
+	OpenMatches           int        `db:"open_matches"`
+}
+
+func AdaptContinuousScreeningAttestationObjectStats(
+	db DBContinuousScreeningAttestationObjectStats,
+) (models.ContinuousScreeningAttestationObjectStats, error) {
+	return models.ContinuousScreeningAttestationObjectStats{
+		ObjectType:            db.ObjectType,
+		ObjectId:              db.ObjectId,
+		ConfigStableId:        db.ConfigStableId,
+		LastObjectScreeningAt: db.LastObjectScreeningAt,
+		IndexedAt:             db.IndexedAt,
+		OpenMatches:           db.OpenMatches,
+	}, nil
+}
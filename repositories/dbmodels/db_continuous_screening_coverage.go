@@ -0,0 +1,79 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	TABLE_CONTINUOUS_SCREENING_COVERAGE_ANALYSES = "continuous_screening_coverage_analyses"
+	TABLE_CONTINUOUS_SCREENING_COVERAGE_GAPS     = "continuous_screening_coverage_gaps"
+)
+
+var SelectContinuousScreeningCoverageAnalysisColumn = utils.ColumnList[DBContinuousScreeningCoverageAnalysis]()
+
+type DBContinuousScreeningCoverageAnalysis struct {
+	Id               uuid.UUID  `db:"id"`
+	OrgId            uuid.UUID  `db:"org_id"`
+	ObjectTypes      []string   `db:"object_types"`
+	UnmonitoredCount int        `db:"unmonitored_count"`
+	OrphanedCount    int        `db:"orphaned_count"`
+	Truncated        bool       `db:"truncated"`
+	BackfilledAt     *time.Time `db:"backfilled_at"`
+	CreatedAt        time.Time  `db:"created_at"`
+}
+
+func AdaptContinuousScreeningCoverageAnalysis(db DBContinuousScreeningCoverageAnalysis) (models.ContinuousScreeningCoverageAnalysis, error) {
+	return models.ContinuousScreeningCoverageAnalysis{
+		Id:               db.Id,
+		OrgId:            db.OrgId,
+		ObjectTypes:      db.ObjectTypes,
+		UnmonitoredCount: db.UnmonitoredCount,
+		OrphanedCount:    db.OrphanedCount,
+		Truncated:        db.Truncated,
+		BackfilledAt:     db.BackfilledAt,
+		CreatedAt:        db.CreatedAt,
+	}, nil
+}
+
+var SelectContinuousScreeningCoverageGapColumn = utils.ColumnList[DBContinuousScreeningCoverageGap]()
+
+type DBContinuousScreeningCoverageGap struct {
+	Id               uuid.UUID  `db:"id"`
+	AnalysisId       uuid.UUID  `db:"analysis_id"`
+	GapType          string     `db:"gap_type"`
+	ObjectType       string     `db:"object_type"`
+	ObjectId         string     `db:"object_id"`
+	ObjectInternalId *uuid.UUID `db:"object_internal_id"`
+	ConfigStableId   *uuid.UUID `db:"config_stable_id"`
+	CreatedAt        time.Time  `db:"created_at"`
+}
+
+func AdaptContinuousScreeningCoverageGap(db DBContinuousScreeningCoverageGap) (models.ContinuousScreeningCoverageGap, error) {
+	return models.ContinuousScreeningCoverageGap{
+		Id:               db.Id,
+		AnalysisId:       db.AnalysisId,
+		GapType:          models.ContinuousScreeningCoverageGapType(db.GapType),
+		ObjectType:       db.ObjectType,
+		ObjectId:         db.ObjectId,
+		ObjectInternalId: db.ObjectInternalId,
+		ConfigStableId:   db.ConfigStableId,
+		CreatedAt:        db.CreatedAt,
+	}, nil
+}
+
+// Current row of the client data, read by the coverage analysis
+type DBContinuousScreeningCoverageObject struct {
+	Id       uuid.UUID `db:"id"`
+	ObjectId string    `db:"object_id"`
+}
+
+func AdaptContinuousScreeningCoverageObject(db DBContinuousScreeningCoverageObject) (models.ContinuousScreeningCoverageObject, error) {
+	return models.ContinuousScreeningCoverageObject{
+		ObjectId:   db.ObjectId,
+		InternalId: db.Id,
+	}, nil
+}
@@ -71,6 +71,7 @@ type DbDataModelTableJoinField struct {
 	FieldFTMProperty          *string         `db:"data_model_fields.ftm_property"`
 	FieldMetadata             json.RawMessage `db:"data_model_fields.metadata"`
 	FieldArchived             bool            `db:"data_model_fields.archived"`
+	FieldDerivedExpression    []byte          `db:"data_model_fields.derived_expression"`
 }
 
 var SelectDataModelTableJoinFieldColumns = utils.ColumnList[DbDataModelTableJoinField]()
@@ -0,0 +1,47 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const TABLE_DATA_MODEL_DERIVED_FIELD_BACKFILLS = "data_model_derived_field_backfills"
+
+var SelectDerivedFieldBackfillColumn = utils.ColumnList[DBDerivedFieldBackfill]()
+
+type DBDerivedFieldBackfill struct {
+	Id             uuid.UUID  `db:"id"`
+	OrganizationId uuid.UUID  `db:"organization_id"`
+	TableId        string     `db:"table_id"`
+	FieldId        string     `db:"field_id"`
+	TableName      string     `db:"table_name"`
+	FieldName      string     `db:"field_name"`
+	Status         string     `db:"status"`
+	LastRowId      *uuid.UUID `db:"last_row_id"`
+	RowsProcessed  int64      `db:"rows_processed"`
+	Error          *string    `db:"error"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+	CompletedAt    *time.Time `db:"completed_at"`
+}
+
+func AdaptDerivedFieldBackfill(db DBDerivedFieldBackfill) (models.DerivedFieldBackfill, error) {
+	return models.DerivedFieldBackfill{
+		Id:             db.Id,
+		OrganizationId: db.OrganizationId,
+		TableId:        db.TableId,
+		FieldId:        db.FieldId,
+		TableName:      db.TableName,
+		FieldName:      db.FieldName,
+		Status:         models.DerivedFieldBackfillStatus(db.Status),
+		LastRowId:      db.LastRowId,
+		RowsProcessed:  db.RowsProcessed,
+		Error:          db.Error,
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+		CompletedAt:    db.CompletedAt,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	TABLE_DATA_MODEL_FIELD_TYPE_MIGRATIONS         = "data_model_field_type_migrations"
+	TABLE_DATA_MODEL_FIELD_TYPE_MIGRATION_FAILURES = "data_model_field_type_migration_failures"
+)
+
+var SelectFieldTypeMigrationColumn = utils.ColumnList[DBFieldTypeMigration]()
+
+type DBFieldTypeMigration struct {
+	Id              uuid.UUID  `db:"id"`
+	OrganizationId  uuid.UUID  `db:"organization_id"`
+	TableId         string     `db:"table_id"`
+	FieldId         string     `db:"field_id"`
+	TableName       string     `db:"table_name"`
+	FieldName       string     `db:"field_name"`
+	SourceDataType  string     `db:"source_data_type"`
+	TargetDataType  string     `db:"target_data_type"`
+	TimestampFormat *string    `db:"timestamp_format"`
+	NullOnFailure   bool       `db:"null_on_failure"`
+	Status          string     `db:"status"`
+	LastRowId       *uuid.UUID `db:"last_row_id"`
+	RowsProcessed   int64      `db:"rows_processed"`
+	RowsFailed      int64      `db:"rows_failed"`
+	Error           *string    `db:"error"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
+	CompletedAt     *time.Time `db:"completed_at"`
+}
+
+func AdaptFieldTypeMigration(db DBFieldTypeMigration) (models.FieldTypeMigration, error) {
+	return models.FieldTypeMigration{
+		Id:              db.Id,
+		OrganizationId:  db.OrganizationId,
+		TableId:         db.TableId,
+		FieldId:         db.FieldId,
+		TableName:       db.TableName,
+		FieldName:       db.FieldName,
+		SourceDataType:  models.DataTypeFrom(db.SourceDataType),
+		TargetDataType:  models.DataTypeFrom(db.TargetDataType),
+		TimestampFormat: db.TimestampFormat,
+		NullOnFailure:   db.NullOnFailure,
+		Status:          models.FieldTypeMigrationStatus(db.Status),
+		LastRowId:       db.LastRowId,
+		RowsProcessed:   db.RowsProcessed,
+		RowsFailed:      db.RowsFailed,
+		Error:           db.Error,
+		CreatedAt:       db.CreatedAt,
+		UpdatedAt:       db.UpdatedAt,
+		CompletedAt:     db.CompletedAt,
+	}, nil
+}
+
+var SelectFieldTypeMigrationFailureColumn = utils.ColumnList[DBFieldTypeMigrationFailure]()
+
+type DBFieldTypeMigrationFailure struct {
+	MigrationId uuid.UUID `db:"migration_id"`
+	RowId       uuid.UUID `db:"row_id"`
+	ObjectId    string    `db:"object_id"`
+	Value       string    `db:"value"`
+	Error       string    `db:"error"`
+}
+
+func AdaptFieldTypeMigrationFailure(db DBFieldTypeMigrationFailure) (models.FieldTypeMigrationFailure, error) {
+	return models.FieldTypeMigrationFailure(db), nil
+}
@@ -0,0 +1,73 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	TABLE_DATA_RETENTION_POLICIES = "data_retention_policies"
+	TABLE_DATA_RETENTION_RUNS     = "data_retention_runs"
+)
+
+var (
+	SelectDataRetentionPolicyColumn = utils.ColumnList[DBDataRetentionPolicy]()
+	SelectDataRetentionRunColumn    = utils.ColumnList[DBDataRetentionRun]()
+)
+
+type DBDataRetentionPolicy struct {
+	Id                     uuid.UUID  `db:"id"`
+	OrgId                  uuid.UUID  `db:"org_id"`
+	Target                 string     `db:"target"`
+	TableId                *uuid.UUID `db:"table_id"`
+	SupersededVersionsDays *int       `db:"superseded_versions_days"`
+	MaxAgeDays             *int       `db:"max_age_days"`
+	Enabled                bool       `db:"enabled"`
+	CreatedAt              time.Time  `db:"created_at"`
+	UpdatedAt              time.Time  `db:"updated_at"`
+}
+
+func AdaptDataRetentionPolicy(db DBDataRetentionPolicy) (models.DataRetentionPolicy, error) {
+	return models.DataRetentionPolicy{
+		Id:                     db.Id,
+		OrganizationId:         db.OrgId,
+		Target:                 models.DataRetentionTarget(db.Target),
+		TableId:                db.TableId,
+		SupersededVersionsDays: db.SupersededVersionsDays,
+		MaxAgeDays:             db.MaxAgeDays,
+		Enabled:                db.Enabled,
+		CreatedAt:              db.CreatedAt,
+		UpdatedAt:              db.UpdatedAt,
+	}, nil
+}
+
+type DBDataRetentionRun struct {
+	Id                    uuid.UUID  `db:"id"`
+	OrgId                 uuid.UUID  `db:"org_id"`
+	PolicyId              uuid.UUID  `db:"policy_id"`
+	SupersededRowsDeleted int64      `db:"superseded_rows_deleted"`
+	ExpiredRowsDeleted    int64      `db:"expired_rows_deleted"`
+	DecisionsDeleted      int64      `db:"decisions_deleted"`
+	SkippedReason         *string    `db:"skipped_reason"`
+	Error                 *string    `db:"error"`
+	StartedAt             time.Time  `db:"started_at"`
+	FinishedAt            *time.Time `db:"finished_at"`
+}
+
+func AdaptDataRetentionRun(db DBDataRetentionRun) (models.DataRetentionRun, error) {
+	return models.DataRetentionRun{
+		Id:                    db.Id,
+		OrganizationId:        db.OrgId,
+		PolicyId:              db.PolicyId,
+		SupersededRowsDeleted: db.SupersededRowsDeleted,
+		ExpiredRowsDeleted:    db.ExpiredRowsDeleted,
+		DecisionsDeleted:      db.DecisionsDeleted,
+		SkippedReason:         db.SkippedReason,
+		Error:                 db.Error,
+		StartedAt:             db.StartedAt,
+		FinishedAt:            db.FinishedAt,
+	}, nil
+}
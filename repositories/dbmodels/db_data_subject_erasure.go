@@ -0,0 +1,72 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const TABLE_DATA_SUBJECT_ERASURES = "data_subject_erasures"
+
+var SelectDataSubjectErasureColumn = utils.ColumnList[DBDataSubjectErasure]()
+
+type DBDataSubjectErasure struct {
+	Id          uuid.UUID       `db:"id"`
+	OrgId       uuid.UUID       `db:"org_id"`
+	ObjectType  string          `db:"object_type"`
+	ObjectId    *string         `db:"object_id"`
+	Status      string          `db:"status"`
+	Stage       string          `db:"stage"`
+	Targets     json.RawMessage `db:"targets"`
+	Counts      json.RawMessage `db:"counts"`
+	Error       *string         `db:"error"`
+	CreatedBy   *string         `db:"created_by"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+	CompletedAt *time.Time      `db:"completed_at"`
+}
+
+func AdaptDataSubjectErasure(db DBDataSubjectErasure) (models.DataSubjectErasure, error) {
+	erasure := models.DataSubjectErasure{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		ObjectType:     db.ObjectType,
+		ObjectId:       db.ObjectId,
+		Status:         models.DataSubjectErasureStatus(db.Status),
+		Stage:          models.DataSubjectErasureStage(db.Stage),
+		Error:          db.Error,
+		CreatedBy:      db.CreatedBy,
+		CreatedAt:      db.CreatedAt,
+		UpdatedAt:      db.UpdatedAt,
+		CompletedAt:    db.CompletedAt,
+	}
+
+	if len(db.Targets) > 0 && string(db.Targets) != "null" {
+		var targets models.DataSubjectErasureTargets
+		if err := json.Unmarshal(db.Targets, &targets); err != nil {
+			return models.DataSubjectErasure{}, errors.Wrap(err, "could not unmarshal data subject erasure targets")
+		}
+		erasure.Targets = &targets
+	}
+	if len(db.Counts) > 0 {
+		if err := json.Unmarshal(db.Counts, &erasure.Counts); err != nil {
+			return models.DataSubjectErasure{}, errors.Wrap(err, "could not unmarshal data subject erasure counts")
+		}
+	}
+
+	return erasure, nil
+}
+
+type DBDataSubjectErasureDecision struct {
+	Id        uuid.UUID `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	Outcome   string    `db:"outcome"`
+}
+
+func AdaptDataSubjectErasureDecision(db DBDataSubjectErasureDecision) (models.DataSubjectErasureDecision, error) {
+	return models.DataSubjectErasureDecision(db), nil
+}
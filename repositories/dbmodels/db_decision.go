@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/utils"
 	"github.com/google/uuid"
 )
@@ -28,6 +29,7 @@ type DbCoreDecision struct {
 	TriggerObjectRaw     []byte         `db:"trigger_object"`
 	TriggerObjectType    string         `db:"trigger_object_type"`
 	AnalyticsFields      map[string]any `db:"analytics_fields"`
+	RolloutArm           *string        `db:"rollout_arm"`
 }
 
 type DbCoreDecisionWithScenario struct {
@@ -75,6 +77,7 @@ func adaptCoreDecision(db DbCoreDecision, decisionCase *models.Case) models.Deci
 		ScenarioIterationId:  db.ScenarioIterationId,
 		Score:                db.Score,
 		ScheduledExecutionId: db.ScheduledExecutionId,
+		RolloutArm:           models.RolloutArm(pure_utils.PtrValueOrDefault(db.RolloutArm, "")),
 	}
 }
 
@@ -21,6 +21,7 @@ type DbDecisionRule struct {
 	RuleEvaluation []byte             `db:"rule_evaluation"`
 	Outcome        string             `db:"outcome"`
 	StableRuleId   string             `db:"stable_rule_id"`
+	RuleSnoozeId   *string            `db:"rule_snooze_id"`
 }
 
 const TABLE_DECISION_RULES = "decision_rules"
@@ -48,6 +49,7 @@ func AdaptRuleExecution(db DbDecisionRule) (models.RuleExecution, error) {
 		Outcome:             outcome,
 		Result:              db.Result,
 		ResultScoreModifier: db.ScoreModifier,
+		RuleSnoozeId:        db.RuleSnoozeId,
 		Rule: models.Rule{
 			Id:           db.RuleId,
 			Name:         db.Name,
@@ -0,0 +1,76 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const TABLE_INGESTION_DEAD_LETTERS = "ingestion_dead_letters"
+
+var SelectIngestionDeadLetterColumn = utils.ColumnList[DBIngestionDeadLetter]()
+
+type DBIngestionDeadLetter struct {
+	Id               uuid.UUID                    `db:"id"`
+	OrganizationId   uuid.UUID                    `db:"org_id"`
+	ObjectType       string                       `db:"object_type"`
+	ObjectId         *string                      `db:"object_id"`
+	Source           string                       `db:"source"`
+	PayloadFormat    string                       `db:"payload_format"`
+	Payload          json.RawMessage              `db:"payload"`
+	IsPatch          bool                         `db:"is_patch"`
+	Error            string                       `db:"error"`
+	FieldErrors      map[string]string            `db:"field_errors"`
+	UploadLogId      *uuid.UUID                   `db:"upload_log_id"`
+	IngestionOptions DBIngestionDeadLetterOptions `db:"ingestion_options"`
+	Status           string                       `db:"status"`
+	ReplayCount      int                          `db:"replay_count"`
+	CreatedAt        time.Time                    `db:"created_at"`
+	UpdatedAt        time.Time                    `db:"updated_at"`
+	ReplayedAt       *time.Time                   `db:"replayed_at"`
+}
+
+// DBIngestionDeadLetterOptions are the ingestion options the object was sent with, applied again
+// when it is replayed.
+type DBIngestionDeadLetterOptions struct {
+	ShouldMonitor          bool        `json:"should_monitor"`
+	ShouldScreen           bool        `json:"should_screen"`
+	ContinuousScreeningIds []uuid.UUID `json:"continuous_screening_ids"`
+}
+
+func AdaptIngestionDeadLetterOptions(options models.IngestionOptions) DBIngestionDeadLetterOptions {
+	return DBIngestionDeadLetterOptions{
+		ShouldMonitor:          options.ShouldMonitor,
+		ShouldScreen:           options.ShouldScreen,
+		ContinuousScreeningIds: options.ContinuousScreeningIds,
+	}
+}
+
+func AdaptIngestionDeadLetter(db DBIngestionDeadLetter) (models.IngestionDeadLetter, error) {
+	return models.IngestionDeadLetter{
+		Id:             db.Id,
+		OrganizationId: db.OrganizationId,
+		ObjectType:     db.ObjectType,
+		ObjectId:       db.ObjectId,
+		Source:         models.IngestionDeadLetterSource(db.Source),
+		PayloadFormat:  models.IngestionDeadLetterFormat(db.PayloadFormat),
+		Payload:        db.Payload,
+		IsPatch:        db.IsPatch,
+		Error:          db.Error,
+		FieldErrors:    db.FieldErrors,
+		UploadLogId:    db.UploadLogId,
+		IngestionOptions: models.IngestionOptions{
+			ShouldMonitor:          db.IngestionOptions.ShouldMonitor,
+			ShouldScreen:           db.IngestionOptions.ShouldScreen,
+			ContinuousScreeningIds: db.IngestionOptions.ContinuousScreeningIds,
+		},
+		Status:      models.IngestionDeadLetterStatus(db.Status),
+		ReplayCount: db.ReplayCount,
+		CreatedAt:   db.CreatedAt,
+		UpdatedAt:   db.UpdatedAt,
+		ReplayedAt:  db.ReplayedAt,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	TABLE_INGESTION_SOURCES      = "ingestion_sources"
+	TABLE_INGESTION_SOURCE_FILES = "ingestion_source_files"
+)
+
+var (
+	SelectIngestionSourceColumn     = utils.ColumnList[DBIngestionSource]()
+	SelectIngestionSourceFileColumn = utils.ColumnList[DBIngestionSourceFile]()
+)
+
+type DBIngestionSource struct {
+	Id           uuid.UUID                      `db:"id"`
+	OrgId        uuid.UUID                      `db:"org_id"`
+	Name         string                         `db:"name"`
+	BucketUrl    string                         `db:"bucket_url"`
+	Prefix       string                         `db:"prefix"`
+	FileMappings []DBIngestionSourceFileMapping `db:"file_mappings"`
+	Enabled      bool                           `db:"enabled"`
+	ConfiguredBy uuid.UUID                      `db:"configured_by"`
+	LastPolledAt *time.Time                     `db:"last_polled_at"`
+	LastError    *string                        `db:"last_error"`
+	CreatedAt    time.Time                      `db:"created_at"`
+	UpdatedAt    time.Time                      `db:"updated_at"`
+}
+
+type DBIngestionSourceFileMapping struct {
+	Pattern    string `json:"pattern"`
+	TableName  string `json:"table_name"`
+	FileFormat string `json:"file_format,omitempty"`
+}
+
+func AdaptIngestionSourceFileMappings(mappings []models.IngestionSourceFileMapping) []DBIngestionSourceFileMapping {
+	return pure_utils.Map(mappings, func(m models.IngestionSourceFileMapping) DBIngestionSourceFileMapping {
+		return DBIngestionSourceFileMapping{
+			Pattern:    m.Pattern,
+			TableName:  m.TableName,
+			FileFormat: string(m.FileFormat),
+		}
+	})
+}
+
+func AdaptIngestionSource(db DBIngestionSource) (models.IngestionSource, error) {
+	return models.IngestionSource{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		Name:           db.Name,
+		BucketUrl:      db.BucketUrl,
+		Prefix:         db.Prefix,
+		FileMappings: pure_utils.Map(db.FileMappings, func(m DBIngestionSourceFileMapping) models.IngestionSourceFileMapping {
+			return models.IngestionSourceFileMapping{
+				Pattern:    m.Pattern,
+				TableName:  m.TableName,
+				FileFormat: models.IngestionFileFormat(m.FileFormat),
+			}
+		}),
+		Enabled:      db.Enabled,
+		ConfiguredBy: db.ConfiguredBy,
+		LastPolledAt: db.LastPolledAt,
+		LastError:    db.LastError,
+		CreatedAt:    db.CreatedAt,
+		UpdatedAt:    db.UpdatedAt,
+	}, nil
+}
+
+type DBIngestionSourceFile struct {
+	Id          uuid.UUID  `db:"id"`
+	OrgId       uuid.UUID  `db:"org_id"`
+	SourceId    uuid.UUID  `db:"source_id"`
+	Key         string     `db:"key"`
+	ETag        string     `db:"etag"`
+	Size        int64      `db:"size"`
+	TableName   string     `db:"table_name"`
+	UploadLogId *uuid.UUID `db:"upload_log_id"`
+	Error       *string    `db:"error"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+func AdaptIngestionSourceFile(db DBIngestionSourceFile) (models.IngestionSourceFile, error) {
+	return models.IngestionSourceFile{
+		Id:             db.Id,
+		OrganizationId: db.OrgId,
+		SourceId:       db.SourceId,
+		Key:            db.Key,
+		ETag:           db.ETag,
+		Size:           db.Size,
+		TableName:      db.TableName,
+		UploadLogId:    db.UploadLogId,
+		Error:          db.Error,
+		CreatedAt:      db.CreatedAt,
+	}, nil
+}
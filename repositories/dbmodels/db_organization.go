@@ -10,19 +10,22 @@ import (
 )
 
 type DBOrganizationResult struct {
-	Id                      uuid.UUID       `db:"id"`
-	PublicId                uuid.UUID       `db:"public_id"`
-	DeletedAt               *int            `db:"deleted_at"`
-	Name                    string          `db:"name"`
-	AllowedNetworks         []net.IPNet     `db:"allowed_networks"`
-	AiCaseReviewEnabled     bool            `db:"ai_case_review_enabled"`
-	DefaultScenarioTimezone *string         `db:"default_scenario_timezone"`
-	ScreeningProviders      json.RawMessage `db:"screening_providers"`
-	ScreeningThreshold      int             `db:"sanctions_threshold"`
-	ScreeningLimit          int             `db:"sanctions_limit"`
-	AutoAssignQueueLimit    int             `db:"auto_assign_queue_limit"`
-	SentryReplayEnabled     bool            `db:"sentry_replay_enabled"`
-	Environment             string          `db:"environment"`
+	Id                         uuid.UUID       `db:"id"`
+	PublicId                   uuid.UUID       `db:"public_id"`
+	DeletedAt                  *int            `db:"deleted_at"`
+	Name                       string          `db:"name"`
+	AllowedNetworks            []net.IPNet     `db:"allowed_networks"`
+	AiCaseReviewEnabled        bool            `db:"ai_case_review_enabled"`
+	DefaultScenarioTimezone    *string         `db:"default_scenario_timezone"`
+	ScreeningProviders         json.RawMessage `db:"screening_providers"`
+	ScreeningThreshold         int             `db:"sanctions_threshold"`
+	ScreeningLimit             int             `db:"sanctions_limit"`
+	NameNormalization          bool            `db:"screening_name_normalization"`
+	NameStoplist               []string        `db:"screening_name_stoplist"`
+	AutoAssignQueueLimit       int             `db:"auto_assign_queue_limit"`
+	SentryReplayEnabled        bool            `db:"sentry_replay_enabled"`
+	RequirePublicationApproval bool            `db:"require_publication_approval"`
+	Environment                string          `db:"environment"`
 }
 
 const TABLE_ORGANIZATION = "organizations"
@@ -44,13 +47,16 @@ func AdaptOrganization(db DBOrganizationResult) (models.Organization, error) {
 		AiCaseReviewEnabled:     db.AiCaseReviewEnabled,
 		DefaultScenarioTimezone: db.DefaultScenarioTimezone,
 		OpenSanctionsConfig: models.OrganizationOpenSanctionsConfig{
-			Providers:      screeningProviders,
-			MatchThreshold: db.ScreeningThreshold,
-			MatchLimit:     db.ScreeningLimit,
+			Providers:         screeningProviders,
+			MatchThreshold:    db.ScreeningThreshold,
+			MatchLimit:        db.ScreeningLimit,
+			NameNormalization: db.NameNormalization,
+			NameStoplist:      db.NameStoplist,
 		},
-		AutoAssignQueueLimit: db.AutoAssignQueueLimit,
-		SentryReplayEnabled:  db.SentryReplayEnabled,
-		Environment:          models.ParseOrganizationEnvironment(db.Environment),
+		AutoAssignQueueLimit:       db.AutoAssignQueueLimit,
+		SentryReplayEnabled:        db.SentryReplayEnabled,
+		RequirePublicationApproval: db.RequirePublicationApproval,
+		Environment:                models.ParseOrganizationEnvironment(db.Environment),
 	}, nil
 }
 
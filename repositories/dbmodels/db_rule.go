@@ -1,6 +1,7 @@
 package dbmodels
 
 import (
+	"cmp"
 	"fmt"
 	"time"
 
@@ -33,6 +34,8 @@ type DBRule struct {
 	RuleGroup            string      `db:"rule_group"`
 	SnoozeGroupId        *string     `db:"snooze_group_id"`
 	StableRuleId         string      `db:"stable_rule_id"`
+	ThrottlePeriodSecs   int         `db:"throttle_period_seconds"`
+	ThrottleScope        string      `db:"throttle_scope"`
 }
 
 func AdaptRule(db DBRule) (models.Rule, error) {
@@ -55,6 +58,8 @@ func AdaptRule(db DBRule) (models.Rule, error) {
 		RuleGroup:            db.RuleGroup,
 		SnoozeGroupId:        db.SnoozeGroupId,
 		StableRuleId:         db.StableRuleId,
+		ThrottlePeriod:       time.Duration(db.ThrottlePeriodSecs) * time.Second,
+		ThrottleScope:        models.RuleThrottleScope(db.ThrottleScope),
 	}, nil
 }
 
@@ -119,6 +124,8 @@ type DBCreateRuleInput struct {
 	RuleGroup            string    `db:"rule_group"`
 	SnoozeGroupId        *string   `db:"snooze_group_id"`
 	StableRuleId         string    `db:"stable_rule_id"`
+	ThrottlePeriodSecs   int       `db:"throttle_period_seconds"`
+	ThrottleScope        string    `db:"throttle_scope"`
 }
 
 func AdaptDBCreateRuleInput(rule models.CreateRuleInput) (DBCreateRuleInput, error) {
@@ -140,6 +147,8 @@ func AdaptDBCreateRuleInput(rule models.CreateRuleInput) (DBCreateRuleInput, err
 		RuleGroup:            rule.RuleGroup,
 		SnoozeGroupId:        rule.SnoozeGroupId,
 		StableRuleId:         rule.StableRuleId,
+		ThrottlePeriodSecs:   int(rule.ThrottlePeriod.Seconds()),
+		ThrottleScope:        string(cmp.Or(rule.ThrottleScope, models.RuleThrottleScopePivot)),
 	}, nil
 }
 
@@ -154,6 +163,8 @@ type DBUpdateRuleInput struct {
 	RuleGroup            *string `db:"rule_group"`
 	SnoozeGroupId        *string `db:"snooze_group_id"`
 	StableRuleId         *string `db:"stable_rule_id"`
+	ThrottlePeriodSecs   *int    `db:"throttle_period_seconds"`
+	ThrottleScope        *string `db:"throttle_scope"`
 }
 
 func AdaptDBUpdateRuleInput(rule models.UpdateRuleInput) (DBUpdateRuleInput, error) {
@@ -162,6 +173,11 @@ func AdaptDBUpdateRuleInput(rule models.UpdateRuleInput) (DBUpdateRuleInput, err
 		return DBUpdateRuleInput{}, fmt.Errorf("unable to marshal expression formula: %w", err)
 	}
 
+	var throttlePeriodSecs *int
+	if rule.ThrottlePeriod != nil {
+		throttlePeriodSecs = utils.Ptr(int(rule.ThrottlePeriod.Seconds()))
+	}
+
 	return DBUpdateRuleInput{
 		Id:                   rule.Id,
 		DisplayOrder:         rule.DisplayOrder,
@@ -173,5 +189,7 @@ func AdaptDBUpdateRuleInput(rule models.UpdateRuleInput) (DBUpdateRuleInput, err
 		RuleGroup:            rule.RuleGroup,
 		SnoozeGroupId:        rule.SnoozeGroupId,
 		StableRuleId:         rule.StableRuleId,
+		ThrottlePeriodSecs:   throttlePeriodSecs,
+		ThrottleScope:        (*string)(rule.ThrottleScope),
 	}, nil
 }
@@ -1,6 +1,7 @@
 package dbmodels
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
@@ -31,17 +32,24 @@ const TABLE_RULE_SNOOZES = "rule_snoozes"
 var SelectRuleSnoozesColumn = utils.ColumnList[DBRuleSnooze]()
 
 type DBRuleSnooze struct {
-	Id                    string    `db:"id"`
-	CreatedByUser         *string   `db:"created_by_user"`
-	CreatedFromDecisionId *string   `db:"created_from_decision_id"`
-	CreatedFromRuleId     string    `db:"created_from_rule_id"`
-	SnoozeGroupId         string    `db:"snooze_group_id"`
-	PivotValue            string    `db:"pivot_value"`
-	StartsAt              time.Time `db:"starts_at"`
-	ExpiresAt             time.Time `db:"expires_at"`
+	Id                           string    `db:"id"`
+	CreatedByUser                *string   `db:"created_by_user"`
+	CreatedFromDecisionId        *string   `db:"created_from_decision_id"`
+	CreatedFromRuleId            string    `db:"created_from_rule_id"`
+	SnoozeGroupId                string    `db:"snooze_group_id"`
+	PivotValue                   string    `db:"pivot_value"`
+	KeyField                     *string   `db:"key_field"`
+	UnlessConditionAstExpression []byte    `db:"unless_condition_ast_expression"`
+	StartsAt                     time.Time `db:"starts_at"`
+	ExpiresAt                    time.Time `db:"expires_at"`
 }
 
 func AdaptRuleSnooze(s DBRuleSnooze) (models.RuleSnooze, error) {
+	unlessCondition, err := AdaptSerializedAstExpression(s.UnlessConditionAstExpression)
+	if err != nil {
+		return models.RuleSnooze{}, fmt.Errorf("unable to unmarshal snooze condition ast expression: %w", err)
+	}
+
 	return models.RuleSnooze{
 		Id:                    s.Id,
 		CreatedByUser:         s.CreatedByUser,
@@ -49,6 +57,8 @@ func AdaptRuleSnooze(s DBRuleSnooze) (models.RuleSnooze, error) {
 		CreatedFromRuleId:     s.CreatedFromRuleId,
 		SnoozeGroupId:         s.SnoozeGroupId,
 		PivotValue:            s.PivotValue,
+		KeyField:              s.KeyField,
+		UnlessCondition:       unlessCondition,
 		StartsAt:              s.StartsAt,
 		ExpiresAt:             s.ExpiresAt,
 	}, nil
@@ -0,0 +1,35 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const TABLE_RULE_THROTTLES = "rule_throttles"
+
+var SelectRuleThrottlesColumn = utils.ColumnList[DBRuleThrottle]()
+
+type DBRuleThrottle struct {
+	Id             string    `db:"id"`
+	OrganizationId uuid.UUID `db:"org_id"`
+	StableRuleId   string    `db:"stable_rule_id"`
+	ThrottleKey    string    `db:"throttle_key"`
+	DecisionId     string    `db:"decision_id"`
+	StartsAt       time.Time `db:"starts_at"`
+	ExpiresAt      time.Time `db:"expires_at"`
+}
+
+func AdaptRuleThrottle(t DBRuleThrottle) (models.RuleThrottle, error) {
+	return models.RuleThrottle{
+		Id:             t.Id,
+		OrganizationId: t.OrganizationId,
+		StableRuleId:   t.StableRuleId,
+		ThrottleKey:    t.ThrottleKey,
+		DecisionId:     t.DecisionId,
+		StartsAt:       t.StartsAt,
+		ExpiresAt:      t.ExpiresAt,
+	}, nil
+}
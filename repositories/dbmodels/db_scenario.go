@@ -11,16 +11,23 @@ import (
 )
 
 type DBScenario struct {
-	Id                      string      `db:"id"`
-	CreatedAt               time.Time   `db:"created_at"`
-	DeletedAt               pgtype.Time `db:"deleted_at"`
-	Description             string      `db:"description"`
-	LiveVersionID           pgtype.Text `db:"live_scenario_iteration_id"`
-	Name                    string      `db:"name"`
-	OrganizationId          uuid.UUID   `db:"org_id"`
-	TriggerObjectType       string      `db:"trigger_object_type"`
-	Archived                bool        `db:"archived"`
-	DeduplicateBatchObjects bool        `db:"deduplicate_batch_objects"`
+	Id                            string      `db:"id"`
+	CreatedAt                     time.Time   `db:"created_at"`
+	DeletedAt                     pgtype.Time `db:"deleted_at"`
+	Description                   string      `db:"description"`
+	LiveVersionID                 pgtype.Text `db:"live_scenario_iteration_id"`
+	Name                          string      `db:"name"`
+	OrganizationId                uuid.UUID   `db:"org_id"`
+	TriggerObjectType             string      `db:"trigger_object_type"`
+	Archived                      bool        `db:"archived"`
+	DeduplicateBatchObjects       bool        `db:"deduplicate_batch_objects"`
+	CanaryIterationId             pgtype.Text `db:"canary_iteration_id"`
+	CanaryPercentage              int         `db:"canary_percentage"`
+	CanaryStartedAt               *time.Time  `db:"canary_started_at"`
+	EvaluateOnIngest              bool        `db:"evaluate_on_ingest"`
+	EvaluateOnIngestFields        []string    `db:"evaluate_on_ingest_fields"`
+	IncrementalScheduledExecution bool        `db:"incremental_scheduled_execution"`
+	FullRefreshIntervalDays       int         `db:"full_refresh_interval_days"`
 }
 
 const TABLE_SCENARIOS = "scenarios"
@@ -29,19 +36,28 @@ var SelectScenarioColumn = utils.ColumnList[DBScenario]()
 
 func AdaptScenario(dto DBScenario) (models.Scenario, error) {
 	scenario := models.Scenario{
-		Id:                      dto.Id,
-		CreatedAt:               dto.CreatedAt,
-		Description:             dto.Description,
-		Name:                    dto.Name,
-		OrganizationId:          dto.OrganizationId,
-		TriggerObjectType:       dto.TriggerObjectType,
-		Archived:                dto.Archived,
-		DeduplicateBatchObjects: dto.DeduplicateBatchObjects,
+		Id:                            dto.Id,
+		CreatedAt:                     dto.CreatedAt,
+		Description:                   dto.Description,
+		Name:                          dto.Name,
+		OrganizationId:                dto.OrganizationId,
+		TriggerObjectType:             dto.TriggerObjectType,
+		Archived:                      dto.Archived,
+		DeduplicateBatchObjects:       dto.DeduplicateBatchObjects,
+		CanaryPercentage:              dto.CanaryPercentage,
+		CanaryStartedAt:               dto.CanaryStartedAt,
+		EvaluateOnIngest:              dto.EvaluateOnIngest,
+		EvaluateOnIngestFields:        dto.EvaluateOnIngestFields,
+		IncrementalScheduledExecution: dto.IncrementalScheduledExecution,
+		FullRefreshIntervalDays:       dto.FullRefreshIntervalDays,
 	}
 
 	if dto.LiveVersionID.Valid {
 		scenario.LiveVersionID = &dto.LiveVersionID.String
 	}
+	if dto.CanaryIterationId.Valid {
+		scenario.CanaryIterationId = &dto.CanaryIterationId.String
+	}
 
 	return scenario, nil
 }
@@ -0,0 +1,74 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const (
+	TABLE_SCENARIO_BACKTESTS         = "scenario_backtests"
+	TABLE_SCENARIO_BACKTEST_OUTCOMES = "scenario_backtest_outcomes"
+)
+
+var (
+	SelectScenarioBacktestColumn        = utils.ColumnList[DBScenarioBacktest]()
+	SelectScenarioBacktestOutcomeColumn = utils.ColumnList[DBScenarioBacktestOutcome]()
+)
+
+type DBScenarioBacktest struct {
+	Id                  uuid.UUID  `db:"id"`
+	OrgId               uuid.UUID  `db:"org_id"`
+	ScenarioId          uuid.UUID  `db:"scenario_id"`
+	ScenarioIterationId uuid.UUID  `db:"scenario_iteration_id"`
+	TestRunId           uuid.UUID  `db:"test_run_id"`
+	WindowStart         time.Time  `db:"window_start"`
+	WindowEnd           time.Time  `db:"window_end"`
+	Status              string     `db:"status"`
+	CursorCreatedAt     *time.Time `db:"cursor_created_at"`
+	CursorDecisionId    *uuid.UUID `db:"cursor_decision_id"`
+	DecisionsEvaluated  int64      `db:"decisions_evaluated"`
+	DecisionsFailed     int64      `db:"decisions_failed"`
+	Error               *string    `db:"error"`
+	CreatedAt           time.Time  `db:"created_at"`
+	UpdatedAt           time.Time  `db:"updated_at"`
+	FinishedAt          *time.Time `db:"finished_at"`
+}
+
+func AdaptScenarioBacktest(db DBScenarioBacktest) (models.ScenarioBacktest, error) {
+	return models.ScenarioBacktest{
+		Id:                  db.Id,
+		OrganizationId:      db.OrgId,
+		ScenarioId:          db.ScenarioId,
+		ScenarioIterationId: db.ScenarioIterationId,
+		TestRunId:           db.TestRunId,
+		WindowStart:         db.WindowStart,
+		WindowEnd:           db.WindowEnd,
+		Status:              models.ScenarioBacktestStatus(db.Status),
+		CursorCreatedAt:     db.CursorCreatedAt,
+		CursorDecisionId:    db.CursorDecisionId,
+		DecisionsEvaluated:  db.DecisionsEvaluated,
+		DecisionsFailed:     db.DecisionsFailed,
+		Error:               db.Error,
+		CreatedAt:           db.CreatedAt,
+		UpdatedAt:           db.UpdatedAt,
+		FinishedAt:          db.FinishedAt,
+	}, nil
+}
+
+type DBScenarioBacktestOutcome struct {
+	BacktestId      uuid.UUID `db:"backtest_id"`
+	LiveOutcome     string    `db:"live_outcome"`
+	BacktestOutcome string    `db:"backtest_outcome"`
+	Total           int64     `db:"total"`
+}
+
+func AdaptScenarioBacktestOutcomeCount(db DBScenarioBacktestOutcome) (models.ScenarioBacktestOutcomeCount, error) {
+	return models.ScenarioBacktestOutcomeCount{
+		LiveOutcome:     db.LiveOutcome,
+		BacktestOutcome: db.BacktestOutcome,
+		Total:           db.Total,
+	}, nil
+}
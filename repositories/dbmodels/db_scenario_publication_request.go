@@ -0,0 +1,65 @@
+package dbmodels
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const TABLE_SCENARIO_PUBLICATION_REQUESTS = "scenario_publication_requests"
+
+var SelectScenarioPublicationRequestColumn = utils.ColumnList[DBScenarioPublicationRequest]()
+
+type DBScenarioPublicationRequest struct {
+	Id                  uuid.UUID       `db:"id"`
+	OrgId               uuid.UUID       `db:"org_id"`
+	ScenarioId          string          `db:"scenario_id"`
+	ScenarioIterationId string          `db:"scenario_iteration_id"`
+	PublicationAction   string          `db:"publication_action"`
+	CanaryPercentage    int             `db:"canary_percentage"`
+	Status              string          `db:"status"`
+	Comment             string          `db:"comment"`
+	Diff                json.RawMessage `db:"diff"`
+	RequestedBy         string          `db:"requested_by"`
+	ReviewedBy          *string         `db:"reviewed_by"`
+	ReviewComment       *string         `db:"review_comment"`
+	Error               *string         `db:"error"`
+	ScheduledAt         *time.Time      `db:"scheduled_at"`
+	CreatedAt           time.Time       `db:"created_at"`
+	UpdatedAt           time.Time       `db:"updated_at"`
+	ReviewedAt          *time.Time      `db:"reviewed_at"`
+}
+
+func AdaptScenarioPublicationRequest(db DBScenarioPublicationRequest) (models.ScenarioPublicationRequest, error) {
+	var diff models.ScenarioIterationDiff
+	if err := json.Unmarshal(db.Diff, &diff); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	request := models.ScenarioPublicationRequest{
+		Id:                  db.Id,
+		OrganizationId:      db.OrgId,
+		ScenarioId:          db.ScenarioId,
+		ScenarioIterationId: db.ScenarioIterationId,
+		PublicationAction:   models.PublicationActionFrom(db.PublicationAction),
+		CanaryPercentage:    db.CanaryPercentage,
+		Status:              models.ScenarioPublicationRequestStatus(db.Status),
+		Comment:             db.Comment,
+		Diff:                diff,
+		RequestedBy:         models.UserId(db.RequestedBy),
+		ReviewComment:       db.ReviewComment,
+		Error:               db.Error,
+		ScheduledAt:         db.ScheduledAt,
+		CreatedAt:           db.CreatedAt,
+		UpdatedAt:           db.UpdatedAt,
+		ReviewedAt:          db.ReviewedAt,
+	}
+	if db.ReviewedBy != nil {
+		reviewedBy := models.UserId(*db.ReviewedBy)
+		request.ReviewedBy = &reviewedBy
+	}
+	return request, nil
+}
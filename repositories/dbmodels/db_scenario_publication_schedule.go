@@ -0,0 +1,53 @@
+package dbmodels
+
+import (
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+)
+
+const TABLE_SCENARIO_PUBLICATION_SCHEDULES = "scenario_publication_schedules"
+
+var SelectScenarioPublicationScheduleColumn = utils.ColumnList[DBScenarioPublicationSchedule]()
+
+type DBScenarioPublicationSchedule struct {
+	Id                   uuid.UUID  `db:"id"`
+	OrgId                uuid.UUID  `db:"org_id"`
+	ScenarioId           string     `db:"scenario_id"`
+	ScenarioIterationId  string     `db:"scenario_iteration_id"`
+	PublicationAction    string     `db:"publication_action"`
+	ScheduledAt          time.Time  `db:"scheduled_at"`
+	Status               string     `db:"status"`
+	Error                *string    `db:"error"`
+	CreatedBy            *string    `db:"created_by"`
+	PublicationRequestId *uuid.UUID `db:"publication_request_id"`
+	PreparationAlertedAt *time.Time `db:"preparation_alerted_at"`
+	ExecutedAt           *time.Time `db:"executed_at"`
+	CreatedAt            time.Time  `db:"created_at"`
+	UpdatedAt            time.Time  `db:"updated_at"`
+}
+
+func AdaptScenarioPublicationSchedule(db DBScenarioPublicationSchedule) (models.ScenarioPublicationSchedule, error) {
+	schedule := models.ScenarioPublicationSchedule{
+		Id:                   db.Id,
+		OrganizationId:       db.OrgId,
+		ScenarioId:           db.ScenarioId,
+		ScenarioIterationId:  db.ScenarioIterationId,
+		PublicationAction:    models.PublicationActionFrom(db.PublicationAction),
+		ScheduledAt:          db.ScheduledAt,
+		Status:               models.ScenarioPublicationScheduleStatus(db.Status),
+		Error:                db.Error,
+		PublicationRequestId: db.PublicationRequestId,
+		PreparationAlertedAt: db.PreparationAlertedAt,
+		ExecutedAt:           db.ExecutedAt,
+		CreatedAt:            db.CreatedAt,
+		UpdatedAt:            db.UpdatedAt,
+	}
+	if db.CreatedBy != nil {
+		createdBy := models.UserId(*db.CreatedBy)
+		schedule.CreatedBy = &createdBy
+	}
+	return schedule, nil
+}
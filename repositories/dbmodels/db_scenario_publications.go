@@ -29,6 +29,7 @@ func AdaptScenarioPublication(dto DBScenarioPublication) (models.ScenarioPublica
 		ScenarioIterationId: dto.ScenarioIterationId,
 		CreatedAt:           dto.CreatedAt,
 		PublicationAction:   models.PublicationActionFrom(dto.PublicationAction),
+		Status:              models.ScenarioPublicationStatusExecuted,
 	}
 
 	return scenarioPublication, nil
@@ -10,6 +10,7 @@ import (
 
 type DBScenarioTestRun struct {
 	Id                      string    `db:"id"`
+	Kind                    string    `db:"kind"`
 	ScenarioIterationId     string    `db:"scenario_iteration_id"`
 	LiveScenarioIterationId string    `db:"live_scenario_iteration_id"`
 	CreatedAt               time.Time `db:"created_at"`
@@ -39,6 +40,7 @@ func AdaptScenarioTestrun(db DBScenarioTestRun) (models.ScenarioTestRun, error)
 	return models.ScenarioTestRun{
 		ScenarioIterationId:     db.ScenarioIterationId,
 		Id:                      db.Id,
+		Kind:                    models.ScenarioTestRunKind(db.Kind),
 		ScenarioLiveIterationId: db.LiveScenarioIterationId,
 		CreatedAt:               db.CreatedAt,
 		ExpiresAt:               db.ExpiresAt,
@@ -52,6 +54,7 @@ func AdaptScenarioTestrunWithInfo(db DBScenarioTestRunWitInfo) (models.ScenarioT
 	return models.ScenarioTestRun{
 		ScenarioIterationId:     db.ScenarioIterationId,
 		Id:                      db.Id,
+		Kind:                    models.ScenarioTestRunKind(db.Kind),
 		ScenarioLiveIterationId: db.LiveScenarioIterationId,
 		CreatedAt:               db.CreatedAt,
 		ExpiresAt:               db.ExpiresAt,
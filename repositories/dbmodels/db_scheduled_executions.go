@@ -26,6 +26,7 @@ type DBScheduledExecution struct {
 	ManifestRowsProcessed      int64      `db:"manifest_rows_processed"`
 	Deadline                   *time.Time `db:"deadline"`
 	DeduplicateObjects         bool       `db:"deduplicate_objects"`
+	IncrementalSince           *time.Time `db:"incremental_since"`
 }
 
 const TABLE_SCHEDULED_EXECUTIONS = "scheduled_executions"
@@ -54,5 +55,6 @@ func AdaptScheduledExecution(db DBScheduledExecution, scenario models.Scenario,
 		ManifestRowsProcessed:      db.ManifestRowsProcessed,
 		Deadline:                   db.Deadline,
 		DeduplicateObjects:         db.DeduplicateObjects,
+		IncrementalSince:           db.IncrementalSince,
 	}
 }
@@ -11,12 +11,18 @@ import (
 const TABLE_SCREENING_WHITELISTS = "screening_whitelists"
 
 type DBScreeningWhitelists struct {
-	Id             string    `db:"id"`
-	OrgId          uuid.UUID `db:"org_id"`
-	CounterpartyId string    `db:"counterparty_id"`
-	EntityId       string    `db:"entity_id"`
-	WhitelistedBy  *string   `db:"whitelisted_by"`
-	CreatedAt      time.Time `db:"created_at"`
+	Id                      string     `db:"id"`
+	OrgId                   uuid.UUID  `db:"org_id"`
+	CounterpartyId          string     `db:"counterparty_id"`
+	EntityId                string     `db:"entity_id"`
+	ScreeningConfigStableId *uuid.UUID `db:"screening_config_stable_id"`
+	ObjectType              *string    `db:"object_type"`
+	ObjectId                *string    `db:"object_id"`
+	ExpiresAt               *time.Time `db:"expires_at"`
+	ExpiryNotifiedAt        *time.Time `db:"expiry_notified_at"`
+	WhitelistedBy           *string    `db:"whitelisted_by"`
+	CreatedAt               time.Time  `db:"created_at"`
+	UpdatedAt               time.Time  `db:"updated_at"`
 }
 
 var ScreeningWhitelistColumnList = utils.ColumnList[DBScreeningWhitelists]()
@@ -27,7 +33,15 @@ func AdaptScreeningWhitelist(db DBScreeningWhitelists) (models.ScreeningWhitelis
 		OrgId:          db.OrgId,
 		CounterpartyId: db.CounterpartyId,
 		EntityId:       db.EntityId,
-		WhitelistedBy:  db.WhitelistedBy,
-		CreatedAt:      db.CreatedAt,
+		Scope: models.ScreeningWhitelistScope{
+			ScreeningConfigStableId: db.ScreeningConfigStableId,
+			ObjectType:              db.ObjectType,
+			ObjectId:                db.ObjectId,
+		},
+		ExpiresAt:        db.ExpiresAt,
+		ExpiryNotifiedAt: db.ExpiryNotifiedAt,
+		WhitelistedBy:    db.WhitelistedBy,
+		CreatedAt:        db.CreatedAt,
+		UpdatedAt:        db.UpdatedAt,
 	}, nil
 }
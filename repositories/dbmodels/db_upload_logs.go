@@ -14,6 +14,7 @@ type DBUploadLog struct {
 	UserId          string     `db:"user_id"`
 	FileName        string     `db:"file_name"`
 	TableName       string     `db:"table_name"`
+	FileFormat      string     `db:"file_format"`
 	Status          string     `db:"status"`
 	StartedAt       time.Time  `db:"started_at"`
 	FinishedAt      *time.Time `db:"finished_at"`
@@ -35,6 +36,7 @@ func AdaptUploadLog(db DBUploadLog) (models.UploadLog, error) {
 		UserId:         db.UserId,
 		FileName:       db.FileName,
 		TableName:      db.TableName,
+		FileFormat:     models.IngestionFileFormatFrom(db.FileFormat),
 		UploadStatus:   models.UploadStatusFrom(db.Status),
 		StartedAt:      db.StartedAt,
 		FinishedAt:     db.FinishedAt,
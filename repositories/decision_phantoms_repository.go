@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/repositories/dbmodels"
@@ -100,3 +102,11 @@ func (repo *MarbleDbRepository) StorePhantomDecision(
 	err = ExecBuilder(ctx, exec, builderForRules)
 	return err
 }
+
+// shadowTestRunPhantomDecisions filters out the phantom decisions of backtests. They are stored at the
+// time of the past decision they replay, and must not be counted with the shadow test runs running
+// at that time.
+func shadowTestRunPhantomDecisions(alias string) squirrel.Sqlizer {
+	return squirrel.Expr(fmt.Sprintf("%s.test_run_id IN (SELECT id FROM %s WHERE kind = ?)",
+		alias, dbmodels.TABLE_SCENARIO_TESTRUN), models.ScenarioTestRunShadow)
+}
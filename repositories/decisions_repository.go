@@ -130,7 +130,8 @@ func (repo *MarbleDbRepository) DecisionsByOutcomeAndScore(
 		Where(squirrel.Eq{
 			"org_id":      organizationId,
 			"scenario_id": scenarioId,
-		})
+		}).
+		Where(shadowTestRunPhantomDecisions(dbmodels.TABLE_PHANTOM_DECISIONS))
 	query, err := WithUnionAll(decisionQuery, phantomDecisionQuery)
 	if err != nil {
 		return nil, err
@@ -506,6 +507,7 @@ func (repo *MarbleDbRepository) StoreDecision(
 				"trigger_object_type",
 				"scheduled_execution_id",
 				"analytics_fields",
+				"rollout_arm",
 			).
 			Values(
 				newDecisionId,
@@ -522,6 +524,7 @@ func (repo *MarbleDbRepository) StoreDecision(
 				decision.ClientObject.TableName,
 				decision.ScheduledExecutionId,
 				analyticsFields,
+				utils.PtrTo(string(decision.RolloutArm), &utils.PtrToOptions{OmitZero: true}),
 			),
 	)
 	if err != nil {
@@ -550,6 +553,7 @@ func (repo *MarbleDbRepository) StoreDecision(
 			"rule_id",
 			"rule_evaluation",
 			"outcome",
+			"rule_snooze_id",
 		)
 
 	// If we have a bucket for offloading decision, directly offload them here
@@ -577,12 +581,22 @@ func (repo *MarbleDbRepository) StoreDecision(
 				ruleExecution.Rule.Id,
 				serializedRuleEvaluation,
 				ruleExecution.Outcome,
+				ruleExecution.RuleSnoozeId,
 			)
 	}
 	if err := ExecBuilder(ctx, exec, builderForRules); err != nil {
 		return err
 	}
 
+	throttles := models.RuleThrottlesOfDecision(decision)
+	for i := range throttles {
+		throttles[i].OrganizationId = organizationId
+		throttles[i].DecisionId = newDecisionId
+	}
+	if err := storeRuleThrottles(ctx, exec, throttles); err != nil {
+		return errors.Wrap(err, "could not store rule throttles")
+	}
+
 	// If we immediately offload rule evaluation, spawn the write into a goroutine and try a few times
 	if offloadRuleEvaluation {
 		go func() {
@@ -646,7 +660,7 @@ func (repo *MarbleDbRepository) rulesOfDecisions(
 		return nil, err
 	}
 
-	columns := "d.id, d.org_id, d.decision_id, r.name, r.description, d.score_modifier, d.result, d.error_code, d.rule_id, d.outcome, r.stable_rule_id"
+	columns := "d.id, d.org_id, d.decision_id, r.name, r.description, d.score_modifier, d.result, d.error_code, d.rule_id, d.outcome, r.stable_rule_id, d.rule_snooze_id"
 	if withEvaluation {
 		columns += ", d.rule_evaluation"
 	}
@@ -673,6 +687,7 @@ func (repo *MarbleDbRepository) rulesOfDecisions(
 				&r.RuleId,
 				&r.Outcome,
 				&r.StableRuleId,
+				&r.RuleSnoozeId,
 			}
 			if withEvaluation {
 				fields = append(fields, &r.RuleEvaluation)
@@ -832,3 +847,37 @@ func (repo *MarbleDbRepository) CountDecisionsByOrg(ctx context.Context, exec Ex
 
 	return countByHelper(ctx, exec, query, orgIds)
 }
+
+// Counts the decisions of a scenario taken since the given time by rollout arm, iteration and outcome.
+// Decisions taken while no canary was running have no arm and are left out.
+func (repo *MarbleDbRepository) CountDecisionsByRolloutArm(ctx context.Context, exec Executor,
+	organizationId uuid.UUID, scenarioId string, since time.Time,
+) ([]models.RolloutArmOutcomeCount, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select("rollout_arm, scenario_iteration_id, outcome, count(*) as total").
+		From(dbmodels.TABLE_DECISIONS).
+		Where(squirrel.Eq{
+			"org_id":      organizationId,
+			"scenario_id": scenarioId,
+		}).
+		Where(squirrel.GtOrEq{"created_at": since}).
+		Where(squirrel.NotEq{"rollout_arm": nil}).
+		GroupBy("rollout_arm, scenario_iteration_id, outcome")
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.RolloutArmOutcomeCount, error) {
+		var (
+			arm, outcome string
+			count        models.RolloutArmOutcomeCount
+		)
+		if err := row.Scan(&arm, &count.ScenarioIterationId, &outcome, &count.Count); err != nil {
+			return models.RolloutArmOutcomeCount{}, err
+		}
+		count.Arm = models.RolloutArm(arm)
+		count.Outcome = models.OutcomeFrom(outcome)
+		return count, nil
+	})
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ListDerivedFieldRows returns the live rows of the table with the values of its fields, sorted by
+// id. Pass the id of the last row of the previous page as afterId to get the next page.
+func (repo *ClientDbRepository) ListDerivedFieldRows(
+	ctx context.Context,
+	exec Executor,
+	table models.Table,
+	afterId *uuid.UUID,
+	limit int,
+) ([]models.DerivedFieldRow, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	fieldNames := derivedFieldRowColumns(table)
+	query := derivedFieldRowsQuery(exec, table.Name, fieldNames, afterId, limit)
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.DerivedFieldRow, error) {
+		values, err := row.Values()
+		if err != nil {
+			return models.DerivedFieldRow{}, err
+		}
+		id, ok := values[0].([16]byte)
+		if !ok {
+			return models.DerivedFieldRow{}, errors.Newf("unexpected id of type %T", values[0])
+		}
+		result := models.DerivedFieldRow{Id: id, Data: make(map[string]any, len(fieldNames))}
+		for i, name := range fieldNames {
+			result.Data[name] = values[i+1]
+		}
+		return result, nil
+	})
+}
+
+func derivedFieldRowColumns(table models.Table) []string {
+	fieldNames := make([]string, 0, len(table.Fields))
+	for name, field := range table.Fields {
+		if !field.Archived {
+			fieldNames = append(fieldNames, name)
+		}
+	}
+	slices.Sort(fieldNames)
+	return fieldNames
+}
+
+func derivedFieldRowsQuery(
+	exec Executor,
+	tableName string,
+	fieldNames []string,
+	afterId *uuid.UUID,
+	limit int,
+) squirrel.SelectBuilder {
+	columns := make([]string, 0, len(fieldNames)+1)
+	columns = append(columns, "id")
+	for _, name := range fieldNames {
+		columns = append(columns, pgx.Identifier{name}.Sanitize())
+	}
+
+	query := NewQueryBuilder().
+		Select(columns...).
+		From(sanitizedTableName(exec, tableName)).
+		Where(squirrel.Eq{"valid_until": "Infinity"}).
+		OrderBy("id").
+		Limit(uint64(limit))
+	if afterId != nil {
+		query = query.Where(squirrel.Gt{"id": *afterId})
+	}
+
+	return query
+}
+
+// WriteDerivedFieldValues sets the derived field of the given rows to the computed values, which must
+// all be nil or of the Go type matching the data type of the field.
+func (repo *ClientDbRepository) WriteDerivedFieldValues(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	fieldName string,
+	dataType models.DataType,
+	ids []uuid.UUID,
+	values []any,
+) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	arrayType, typedValues, err := derivedFieldArray(dataType, values)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s AS t SET %s = v.value
+		FROM unnest($1::uuid[], $2::%s) AS v(id, value)
+		WHERE t.id = v.id`,
+		sanitizedTableName(exec, tableName), pgx.Identifier{fieldName}.Sanitize(), arrayType)
+
+	_, err = exec.Exec(ctx, sql, ids, typedValues)
+	return err
+}
+
+func derivedFieldArray(dataType models.DataType, values []any) (string, any, error) {
+	switch dataType {
+	case models.Bool:
+		return derivedFieldTypedArray[bool]("boolean[]", values)
+	case models.Int:
+		// Assigning a bigint to an integer column is checked by postgres
+		return derivedFieldTypedArray[int64]("bigint[]", values)
+	case models.Float:
+		return derivedFieldTypedArray[float64]("float8[]", values)
+	case models.String:
+		return derivedFieldTypedArray[string]("text[]", values)
+	case models.Timestamp:
+		return derivedFieldTypedArray[time.Time]("timestamptz[]", values)
+	}
+	return "", nil, errors.Newf("unsupported data type %s for a derived field", dataType)
+}
+
+// derivedFieldTypedArray is like fieldTypeMigrationTypedArray, with null values allowed.
+func derivedFieldTypedArray[T any](arrayType string, values []any) (string, any, error) {
+	typed := make([]*T, len(values))
+	for i, value := range values {
+		if value == nil {
+			continue
+		}
+		v, ok := value.(T)
+		if !ok {
+			return "", nil, errors.Newf("unexpected value of type %T for a %s column", value, arrayType)
+		}
+		typed[i] = &v
+	}
+	return arrayType, typed, nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivedFieldRowsQuery(t *testing.T) {
+	table := models.Table{
+		Name: "transactions",
+		Fields: map[string]models.Field{
+			"object_id": {Name: "object_id"},
+			"amount":    {Name: "amount"},
+			"legacy":    {Name: "legacy", Archived: true},
+		},
+	}
+	after := uuid.New()
+
+	sql, args, err := derivedFieldRowsQuery(TransactionTest{}, table.Name,
+		derivedFieldRowColumns(table), &after, 1000).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, `SELECT id, "amount", "object_id" FROM "test_schema"."transactions"`)
+	require.Contains(t, sql, "valid_until = $1 AND id > $2")
+	require.Contains(t, sql, "ORDER BY id LIMIT 1000")
+	require.Equal(t, []any{"Infinity", after.String()}, args)
+}
+
+func TestDerivedFieldArray(t *testing.T) {
+	arrayType, values, err := derivedFieldArray(models.Float, []any{1.5, nil})
+	require.NoError(t, err)
+	require.Equal(t, "float8[]", arrayType)
+	f := 1.5
+	require.Equal(t, []*float64{&f, nil}, values)
+
+	_, _, err = derivedFieldArray(models.Int, []any{"1"})
+	require.Error(t, err)
+
+	_, _, err = derivedFieldArray(models.IpAddress, nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (repo *ClientDbRepository) AddFieldTypeMigrationColumn(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	column string,
+	dataType models.DataType,
+) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s",
+		sanitizedTableName(exec, tableName), pgx.Identifier{column}.Sanitize(), toPgType(dataType))
+
+	_, err := exec.Exec(ctx, sql)
+	return err
+}
+
+func (repo *ClientDbRepository) DropFieldTypeMigrationColumn(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	column string,
+) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s",
+		sanitizedTableName(exec, tableName), pgx.Identifier{column}.Sanitize())
+
+	_, err := exec.Exec(ctx, sql)
+	return err
+}
+
+// RenameFieldTypeMigrationColumn gives the shadow column of a migration the name of the field, once
+// the original column has been renamed out of the way.
+func (repo *ClientDbRepository) RenameFieldTypeMigrationColumn(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	column string,
+	fieldName string,
+) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s",
+		sanitizedTableName(exec, tableName), pgx.Identifier{column}.Sanitize(), pgx.Identifier{fieldName}.Sanitize())
+
+	_, err := exec.Exec(ctx, sql)
+	return err
+}
+
+// LockTableForFieldTypeMigration blocks writes to the table until the end of the transaction, so that
+// no row is ingested with the previous type of the field while the columns are swapped. Reads are
+// still allowed.
+func (repo *ClientDbRepository) LockTableForFieldTypeMigration(ctx context.Context, exec Executor, tableName string) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+
+	_, err := exec.Exec(ctx, fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE",
+		sanitizedTableName(exec, tableName)))
+	return err
+}
+
+// ListFieldTypeMigrationRows returns the rows with a non-null value for the field, current or not,
+// sorted by id. Pass the id of the last row of the previous page as afterId to get the next page.
+// With unconvertedOnly, only the rows that have no value in the shadow column are returned.
+func (repo *ClientDbRepository) ListFieldTypeMigrationRows(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	fieldName string,
+	column string,
+	afterId *uuid.UUID,
+	limit int,
+	unconvertedOnly bool,
+) ([]models.FieldTypeMigrationRow, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := fieldTypeMigrationRowsQuery(exec, tableName, fieldName, column, afterId, limit, unconvertedOnly)
+
+	return SqlToListOfRow(ctx, exec, query, func(row pgx.CollectableRow) (models.FieldTypeMigrationRow, error) {
+		var result models.FieldTypeMigrationRow
+		err := row.Scan(&result.Id, &result.ObjectId, &result.Value)
+		return result, err
+	})
+}
+
+func fieldTypeMigrationRowsQuery(
+	exec Executor,
+	tableName string,
+	fieldName string,
+	column string,
+	afterId *uuid.UUID,
+	limit int,
+	unconvertedOnly bool,
+) squirrel.SelectBuilder {
+	field := pgx.Identifier{fieldName}.Sanitize()
+
+	query := NewQueryBuilder().
+		Select("id", "object_id", field).
+		From(sanitizedTableName(exec, tableName)).
+		Where(field + " IS NOT NULL").
+		OrderBy("id").
+		Limit(uint64(limit))
+	if unconvertedOnly {
+		query = query.Where(pgx.Identifier{column}.Sanitize() + " IS NULL")
+	}
+	if afterId != nil {
+		query = query.Where(squirrel.Gt{"id": *afterId})
+	}
+
+	return query
+}
+
+// WriteFieldTypeMigrationValues sets the shadow column of the given rows to the converted values,
+// which must all be of the Go type matching the target data type.
+func (repo *ClientDbRepository) WriteFieldTypeMigrationValues(
+	ctx context.Context,
+	exec Executor,
+	tableName string,
+	column string,
+	dataType models.DataType,
+	ids []uuid.UUID,
+	values []any,
+) error {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	arrayType, typedValues, err := fieldTypeMigrationArray(dataType, values)
+	if err != nil {
+		return err
+	}
+
+	sql := fmt.Sprintf(`UPDATE %s AS t SET %s = v.value
+		FROM unnest($1::uuid[], $2::%s) AS v(id, value)
+		WHERE t.id = v.id`,
+		sanitizedTableName(exec, tableName), pgx.Identifier{column}.Sanitize(), arrayType)
+
+	_, err = exec.Exec(ctx, sql, ids, typedValues)
+	return err
+}
+
+func fieldTypeMigrationArray(dataType models.DataType, values []any) (string, any, error) {
+	switch dataType {
+	case models.Bool:
+		return fieldTypeMigrationTypedArray[bool]("boolean[]", values)
+	case models.Int:
+		// Assigning a bigint to an integer column is checked by postgres
+		return fieldTypeMigrationTypedArray[int64]("bigint[]", values)
+	case models.Float:
+		return fieldTypeMigrationTypedArray[float64]("float8[]", values)
+	case models.String:
+		return fieldTypeMigrationTypedArray[string]("text[]", values)
+	case models.Timestamp:
+		return fieldTypeMigrationTypedArray[time.Time]("timestamptz[]", values)
+	}
+	return "", nil, errors.Newf("unsupported data type %s for a field type migration", dataType)
+}
+
+func fieldTypeMigrationTypedArray[T any](arrayType string, values []any) (string, any, error) {
+	typed := make([]T, len(values))
+	for i, value := range values {
+		v, ok := value.(T)
+		if !ok {
+			return "", nil, errors.Newf("unexpected value of type %T for a %s column", value, arrayType)
+		}
+		typed[i] = v
+	}
+	return arrayType, typed, nil
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldTypeMigrationRowsQuery(t *testing.T) {
+	after := uuid.New()
+
+	sql, args, err := fieldTypeMigrationRowsQuery(TransactionTest{}, "accounts",
+		"account_number", "type_migration_0123", &after, 500, true).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, `SELECT id, object_id, "account_number" FROM "test_schema"."accounts"`)
+	require.Contains(t, sql, `"account_number" IS NOT NULL AND "type_migration_0123" IS NULL AND id > $1`)
+	require.Contains(t, sql, "ORDER BY id LIMIT 500")
+	require.Equal(t, []any{after.String()}, args)
+
+	sql, args, err = fieldTypeMigrationRowsQuery(TransactionTest{}, "accounts",
+		"account_number", "type_migration_0123", nil, 500, false).ToSql()
+
+	require.NoError(t, err)
+	require.NotContains(t, sql, "type_migration_0123")
+	require.NotContains(t, sql, "id >")
+	require.Empty(t, args)
+}
+
+func TestFieldTypeMigrationArray(t *testing.T) {
+	arrayType, values, err := fieldTypeMigrationArray(models.Int, []any{int64(1), int64(-2)})
+	require.NoError(t, err)
+	require.Equal(t, "bigint[]", arrayType)
+	require.Equal(t, []int64{1, -2}, values)
+
+	now := time.Now()
+	arrayType, values, err = fieldTypeMigrationArray(models.Timestamp, []any{now})
+	require.NoError(t, err)
+	require.Equal(t, "timestamptz[]", arrayType)
+	require.Equal(t, []time.Time{now}, values)
+
+	_, _, err = fieldTypeMigrationArray(models.Float, []any{"1.5"})
+	require.Error(t, err)
+
+	_, _, err = fieldTypeMigrationArray(models.IpAddress, nil)
+	require.Error(t, err)
+}
@@ -533,3 +533,78 @@ func (repo *ClientDbRepository) DeleteIndex(ctx context.Context, exec Executor,
 
 	return nil
 }
+
+// historyIndexName names the index reading the versions of an object, live or superseded, for the
+// reads as of a past time and the version history. Every other index of a client table only covers
+// the live versions.
+func historyIndexName(tableName string) string {
+	name := "history_" + tableName
+	return name[:min(len(name), models.MAX_POSTGRES_INDEX_NAME_LENGTH)]
+}
+
+func historyIndexSql(exec Executor, tableName string, concurrently bool) string {
+	mode := ""
+	if concurrently {
+		mode = "CONCURRENTLY "
+	}
+	return fmt.Sprintf("CREATE INDEX %sIF NOT EXISTS %s ON %s (object_id, valid_from)",
+		mode,
+		pgx.Identifier.Sanitize([]string{historyIndexName(tableName)}),
+		pgIdentifierWithSchema(exec, tableName))
+}
+
+// CreateMissingHistoryIndexesAsync starts building the history index of the ingested tables that do
+// not have it, concurrently so that ingestion is not blocked on large tables. It returns the tables
+// whose index is being built. Tables created before as-of reads were introduced do not have it.
+func (repo *ClientDbRepository) CreateMissingHistoryIndexesAsync(ctx context.Context, exec Executor) ([]string, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	// Ingested tables are the only ones with versions
+	rows, err := exec.Query(ctx, `
+		select c.relname
+		from pg_class c
+		inner join pg_namespace n on n.oid = c.relnamespace
+		inner join pg_attribute a on a.attrelid = c.oid
+		where n.nspname = $1 and c.relkind = 'r' and a.attname = 'valid_from' and not a.attisdropped
+	`, exec.DatabaseSchema().Schema)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while listing the ingested tables")
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, errors.Wrap(err, "error while collecting the ingested tables")
+	}
+
+	pgIndexes, err := repo.listAllPgIndexes(ctx, exec)
+	if err != nil {
+		return nil, errors.Wrap(err, "error while listing all indexes")
+	}
+
+	var missing []string
+	for _, table := range tables {
+		if !slices.ContainsFunc(pgIndexes, func(pgIndex pg_indexes.PGIndex) bool {
+			return pgIndex.TableName == table && pgIndex.Name == historyIndexName(table)
+		}) {
+			missing = append(missing, table)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	go func() {
+		ctx := context.WithoutCancel(ctx)
+		ctx, cancel := context.WithTimeout(ctx, INDEX_CREATION_TIMEOUT)
+		defer cancel()
+		// One after the other, as for the other indexes
+		for _, table := range missing {
+			if _, err := exec.Exec(ctx, historyIndexSql(exec, table, true)); err != nil {
+				utils.LogAndReportSentryError(ctx, errors.Wrap(err,
+					fmt.Sprintf("Error while creating index %s", historyIndexName(table))))
+			}
+		}
+	}()
+	return missing, nil
+}
@@ -76,7 +76,14 @@ type IngestedDataReadRepository interface {
 		aggregator ast.Aggregator,
 		filters []models.FilterWithType,
 		options map[string]any,
+		asOf *time.Time,
 	) (any, error)
+	QueryIngestedObjectVersions(
+		ctx context.Context,
+		exec Executor,
+		table models.Table,
+		objectId string,
+	) ([]models.DataModelObject, error)
 	ListIngestedObjects(
 		ctx context.Context,
 		exec Executor,
@@ -96,6 +103,7 @@ type IngestedDataReadRepository interface {
 		terms string,
 		pageSize uint64,
 		offset uint64,
+		asOf *time.Time,
 	) ([]models.DataModelObject, error)
 
 	SampleObjectIds(
@@ -192,7 +200,7 @@ func createQueryDbForField(exec Executor, readParams models.DbFieldReadParams) (
 		Select(fmt.Sprintf("%s.%s", lastTableAlias, readParams.FieldName)).
 		From(fmt.Sprintf("%s AS %s", firstTableName, firstTableAlias)).
 		Where(squirrel.Eq{fmt.Sprintf("%s.%s", firstTableAlias, link.ParentFieldName): firstTableLinkValue}).
-		Where(rowIsValid(firstTableAlias))
+		Where(rowIsValidAt(firstTableAlias, readParams.AsOf))
 
 	b, err = addJoinsOnIntermediateTables(exec, query, readParams, firstTable)
 	return false, b, err
@@ -233,7 +241,7 @@ func addJoinsOnIntermediateTables(
 			link.ParentFieldName)
 		query = query.
 			Join(joinClause).
-			Where(rowIsValid(aliastNextTable))
+			Where(rowIsValidAt(aliastNextTable, readParams.AsOf))
 
 		currentTable = nextTable
 	}
@@ -244,6 +252,19 @@ func rowIsValid(tableName string) squirrel.Eq {
 	return squirrel.Eq{fmt.Sprintf("%s.valid_until", tableName): "Infinity"}
 }
 
+// rowIsValidAt selects the version of the rows that was valid at the given time, or the live version if no
+// time is given. Reading past versions cannot use the indexes on the live rows: only the reads by object_id
+// are served by an index, the history index of the table.
+func rowIsValidAt(tableName string, asOf *time.Time) squirrel.Sqlizer {
+	if asOf == nil {
+		return rowIsValid(tableName)
+	}
+	return squirrel.And{
+		squirrel.LtOrEq{fmt.Sprintf("%s.valid_from", tableName): *asOf},
+		squirrel.Gt{fmt.Sprintf("%s.valid_until", tableName): *asOf},
+	}
+}
+
 func (repo *IngestedDataReadRepositoryImpl) ListAllObjectIdsFromTable(
 	ctx context.Context,
 	exec Executor,
@@ -480,6 +501,53 @@ func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObject(
 	return ingestedObjects, nil
 }
 
+// QueryIngestedObjectVersions returns every version of the object, including the superseded ones, with
+// their valid_from and valid_until in the Metadata field.
+func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObjectVersions(
+	ctx context.Context,
+	exec Executor,
+	table models.Table,
+	objectId string,
+) ([]models.DataModelObject, error) {
+	if err := validateClientDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	columnNames := models.ColumnNames(table)
+
+	qualifiedTableName := pgIdentifierWithSchema(exec, table.Name)
+	objectsAsMap, err := queryWithDynamicColumnList(
+		ctx,
+		exec,
+		qualifiedTableName,
+		append(columnNames, "valid_from", "valid_until"),
+		true,
+		[]models.Filter{{
+			LeftSql:    fmt.Sprintf("%s.object_id", qualifiedTableName),
+			Operator:   ast.FUNC_EQUAL,
+			RightValue: objectId,
+		}}...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]models.DataModelObject, len(objectsAsMap))
+	for i, object := range objectsAsMap {
+		version := models.DataModelObject{Data: map[string]any{}, Metadata: map[string]any{}}
+		for fieldName, fieldValue := range object {
+			if slices.Contains(columnNames, fieldName) {
+				version.Data[fieldName] = fieldValue
+			} else {
+				version.Metadata[fieldName] = fieldValue
+			}
+		}
+		versions[i] = version
+	}
+
+	return versions, nil
+}
+
 func (repo *IngestedDataReadRepositoryImpl) QueryIngestedObjectByUniqueField(
 	ctx context.Context,
 	exec Executor,
@@ -584,6 +652,7 @@ func createQueryAggregated(
 	aggregator ast.Aggregator,
 	filters []models.FilterWithType,
 	options map[string]any,
+	asOf *time.Time,
 ) (squirrel.SelectBuilder, error) {
 	var selectExpression string
 	if aggregator == ast.AGGREGATOR_COUNT_DISTINCT {
@@ -617,7 +686,7 @@ func createQueryAggregated(
 	query := NewQueryBuilder().
 		Select(selectExpression).
 		From(qualifiedTableName).
-		Where(rowIsValid(qualifiedTableName))
+		Where(rowIsValidAt(qualifiedTableName, asOf))
 
 	var err error
 	for _, filter := range filters {
@@ -645,12 +714,13 @@ func (repo *IngestedDataReadRepositoryImpl) QueryAggregatedValue(
 	aggregator ast.Aggregator,
 	filters []models.FilterWithType,
 	options map[string]any,
+	asOf *time.Time,
 ) (any, error) {
 	if err := validateClientDbExecutor(exec); err != nil {
 		return nil, err
 	}
 
-	query, err := createQueryAggregated(exec, tableName, fieldName, fieldType, aggregator, filters, options)
+	query, err := createQueryAggregated(exec, tableName, fieldName, fieldType, aggregator, filters, options, asOf)
 	if err != nil {
 		return nil, fmt.Errorf("error while building SQL query: %w", err)
 	}
@@ -971,6 +1041,7 @@ func (repo *IngestedDataReadRepositoryImpl) SearchObjects(
 	terms string,
 	pageSize uint64,
 	offset uint64,
+	asOf *time.Time,
 ) ([]models.DataModelObject, error) {
 	if err := validateClientDbExecutor(exec); err != nil {
 		return nil, err
@@ -983,7 +1054,7 @@ func (repo *IngestedDataReadRepositoryImpl) SearchObjects(
 		Select(columnNames...).
 		From(tableName).
 		Where(squirrel.And{
-			squirrel.Eq{"valid_until": "infinity"},
+			rowIsValidAt(tableName, asOf),
 			squirrel.Or{
 				squirrel.Eq{"object_id": terms},
 				squirrel.Expr(fmt.Sprintf("%s %%> ?", field), terms),
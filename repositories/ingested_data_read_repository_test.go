@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -110,6 +111,58 @@ func TestIngestedDataGetDbFieldWithJoin(t *testing.T) {
 	assert.Equal(t, stripQuery(expected), stripQuery(sql))
 }
 
+func TestIngestedDataGetDbFieldAsOf(t *testing.T) {
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, query, err := createQueryDbForField(TransactionTest{}, models.DbFieldReadParams{
+		TriggerTableName: utils.DummyTableNameFirst,
+		Path:             []string{utils.DummyTableNameSecond},
+		FieldName:        utils.DummyFieldNameForInt,
+		DataModel:        utils.GetDummyDataModel(),
+		ClientObject: models.ClientObject{
+			TableName: utils.DummyTableNameFirst,
+			Data:      map[string]any{utils.DummyFieldNameId: utils.DummyFieldNameId},
+		},
+		AsOf: &asOf,
+	})
+	assert.Empty(t, err)
+	sql, args, err := query.ToSql()
+	assert.Empty(t, err)
+	assert.Equal(t, []any{utils.DummyFieldNameId, asOf, asOf}, args)
+	expected := `
+	SELECT table_1.int_var
+	FROM "test_schema"."second" AS table_1
+	WHERE table_1.id = $1
+	AND (table_1.valid_from <= $2 AND table_1.valid_until > $3)
+	`
+	assert.Equal(t, stripQuery(expected), stripQuery(sql))
+}
+
+func TestIngestedDataQueryAggregatedValueAsOf(t *testing.T) {
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	query, err := createQueryAggregated(
+		TransactionTest{},
+		utils.DummyTableNameFirst,
+		utils.DummyFieldNameForInt,
+		models.Int,
+		ast.AGGREGATOR_SUM,
+		[]models.FilterWithType{},
+		map[string]any{},
+		&asOf,
+	)
+	assert.Empty(t, err)
+	sql, args, err := query.ToSql()
+	assert.Empty(t, err)
+	assert.Equal(t, []any{asOf, asOf}, args)
+	expected := `
+	SELECT SUM(int_var)::float8
+	FROM "test_schema"."first"
+	WHERE ("test_schema"."first".valid_from <= $1 AND "test_schema"."first".valid_until > $2)
+	`
+	assert.Equal(t, stripQuery(expected), stripQuery(sql))
+}
+
 func TestIngestedDataQueryAggregatedValueWithoutFilter(t *testing.T) {
 	query, err := createQueryAggregated(
 		TransactionTest{},
@@ -119,6 +172,7 @@ func TestIngestedDataQueryAggregatedValueWithoutFilter(t *testing.T) {
 		ast.AGGREGATOR_AVG,
 		[]models.FilterWithType{},
 		map[string]any{},
+		nil,
 	)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
@@ -142,7 +196,8 @@ func TestIngestedDataQueryCountWithoutFilter(t *testing.T) {
 		models.Int,
 		ast.AGGREGATOR_COUNT,
 		[]models.FilterWithType{},
-		map[string]any{})
+		map[string]any{},
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -186,7 +241,8 @@ func TestIngestedDataQueryAggregatedValueWithSimpleFilter(t *testing.T) {
 		models.Int,
 		ast.AGGREGATOR_AVG,
 		filters,
-		map[string]any{})
+		map[string]any{},
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -231,7 +287,8 @@ func TestIngestedDataQueryAggregatedValueWithFuzzyMatchFilter_1(t *testing.T) {
 		models.Int,
 		ast.AGGREGATOR_COUNT,
 		filters,
-		map[string]any{})
+		map[string]any{},
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -282,7 +339,8 @@ func TestIngestedDataQueryAggregatedValueWithFuzzyMatchFilter_2(t *testing.T) {
 		models.Int,
 		ast.AGGREGATOR_COUNT,
 		filters,
-		map[string]any{})
+		map[string]any{},
+		nil)
 	assert.Empty(t, err)
 	sql, args, err := query.ToSql()
 	assert.Empty(t, err)
@@ -320,3 +378,10 @@ var normalizeWhitespaceRe = regexp.MustCompile(`\s+`)
 func stripQuery(q string) (s string) {
 	return strings.TrimSpace(normalizeWhitespaceRe.ReplaceAllString(q, " "))
 }
+
+func TestHistoryIndexSql(t *testing.T) {
+	assert.Equal(t, `CREATE INDEX CONCURRENTLY IF NOT EXISTS "history_transactions" `+
+		`ON "test_schema"."transactions" (object_id, valid_from)`,
+		historyIndexSql(TransactionTest{}, "transactions", true))
+	assert.Equal(t, 63, len(historyIndexName(strings.Repeat("a", 63))))
+}
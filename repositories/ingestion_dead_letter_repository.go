@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+func (repo *MarbleDbRepository) CreateIngestionDeadLetters(
+	ctx context.Context,
+	exec Executor,
+	deadLetters []models.IngestionDeadLetter,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+	if len(deadLetters) == 0 {
+		return nil
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Columns(
+			"id",
+			"org_id",
+			"object_type",
+			"object_id",
+			"source",
+			"payload_format",
+			"payload",
+			"is_patch",
+			"error",
+			"field_errors",
+			"upload_log_id",
+			"ingestion_options",
+		)
+	for _, deadLetter := range deadLetters {
+		query = query.Values(
+			deadLetter.Id,
+			deadLetter.OrganizationId,
+			deadLetter.ObjectType,
+			deadLetter.ObjectId,
+			string(deadLetter.Source),
+			string(deadLetter.PayloadFormat),
+			deadLetter.Payload,
+			deadLetter.IsPatch,
+			deadLetter.Error,
+			map[string]string(deadLetter.FieldErrors),
+			deadLetter.UploadLogId,
+			dbmodels.AdaptIngestionDeadLetterOptions(deadLetter.IngestionOptions),
+		)
+	}
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+func (repo *MarbleDbRepository) GetIngestionDeadLetter(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.IngestionDeadLetter, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.IngestionDeadLetter{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectIngestionDeadLetterColumn...).
+		From(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptIngestionDeadLetter)
+}
+
+// ListIngestionDeadLetters returns the dead letters of the organization, the most recent first.
+func (repo *MarbleDbRepository) ListIngestionDeadLetters(
+	ctx context.Context,
+	exec Executor,
+	organizationId uuid.UUID,
+	filters models.IngestionDeadLetterFilters,
+	pagination models.PaginationAndSorting,
+) ([]models.IngestionDeadLetter, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := ingestionDeadLettersQuery(organizationId, filters).
+		OrderBy("created_at DESC, id DESC").
+		Limit(uint64(pagination.Limit))
+
+	if pagination.OffsetId != "" {
+		offsetId, err := uuid.Parse(pagination.OffsetId)
+		if err != nil {
+			return nil, errors.Wrap(models.BadParameterError, "provided dead letter offset ID was not a UUID")
+		}
+		offset, err := repo.GetIngestionDeadLetter(ctx, exec, offsetId)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(created_at, id) < (?, ?)", offset.CreatedAt, offset.Id)
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptIngestionDeadLetter)
+}
+
+// ListIngestionDeadLettersToReplay returns pending dead letters of the organization, those that were
+// replayed the fewest times first, so that a bulk replay does not get stuck on the same failures.
+func (repo *MarbleDbRepository) ListIngestionDeadLettersToReplay(
+	ctx context.Context,
+	exec Executor,
+	organizationId uuid.UUID,
+	filters models.IngestionDeadLetterFilters,
+	limit int,
+) ([]models.IngestionDeadLetter, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	filters.Status = nil
+	query := ingestionDeadLettersQuery(organizationId, filters).
+		Where(squirrel.Eq{"status": string(models.IngestionDeadLetterPending)}).
+		OrderBy("replay_count ASC, created_at ASC, id ASC").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptIngestionDeadLetter)
+}
+
+func ingestionDeadLettersQuery(organizationId uuid.UUID, filters models.IngestionDeadLetterFilters) squirrel.SelectBuilder {
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectIngestionDeadLetterColumn...).
+		From(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Where(squirrel.Eq{"org_id": organizationId})
+
+	if filters.ObjectType != nil {
+		query = query.Where(squirrel.Eq{"object_type": *filters.ObjectType})
+	}
+	if filters.Status != nil {
+		query = query.Where(squirrel.Eq{"status": string(*filters.Status)})
+	}
+	if filters.UploadLogId != nil {
+		query = query.Where(squirrel.Eq{"upload_log_id": *filters.UploadLogId})
+	}
+
+	return query
+}
+
+// UpdateIngestionDeadLetterPayload replaces the payload of the dead letter with a fixed one.
+func (repo *MarbleDbRepository) UpdateIngestionDeadLetterPayload(
+	ctx context.Context,
+	exec Executor,
+	deadLetter models.IngestionDeadLetter,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Set("payload", deadLetter.Payload).
+		Set("object_id", deadLetter.ObjectId).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": deadLetter.Id}))
+}
+
+// RecordIngestionDeadLetterReplay records the outcome of a replay of the dead letter: it is marked
+// replayed if the replay succeeded, or keeps the reason it was rejected again otherwise.
+func (repo *MarbleDbRepository) RecordIngestionDeadLetterReplay(
+	ctx context.Context,
+	exec Executor,
+	deadLetter models.IngestionDeadLetter,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Set("status", string(deadLetter.Status)).
+		Set("error", deadLetter.Error).
+		Set("field_errors", map[string]string(deadLetter.FieldErrors)).
+		Set("replay_count", squirrel.Expr("replay_count + 1")).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": deadLetter.Id})
+	if deadLetter.Status == models.IngestionDeadLetterReplayed {
+		query = query.Set("replayed_at", squirrel.Expr("NOW()"))
+	}
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+func (repo *MarbleDbRepository) DeleteOldIngestionDeadLettersBatch(
+	ctx context.Context,
+	exec Executor,
+	olderThan time.Time,
+	limit int,
+) (int64, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return 0, err
+	}
+
+	subquery := NewQueryBuilder().
+		Select("id").
+		From(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Where(squirrel.Lt{"created_at": olderThan}).
+		OrderBy("created_at ASC").
+		Limit(uint64(limit))
+
+	query := NewQueryBuilder().
+		Delete(dbmodels.TABLE_INGESTION_DEAD_LETTERS).
+		Where(squirrel.Expr("id IN (?)", subquery))
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "error building query for DeleteOldIngestionDeadLettersBatch")
+	}
+
+	result, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "error deleting old ingestion dead letters")
+	}
+	return result.RowsAffected(), nil
+}
@@ -20,19 +20,27 @@ type IngestionRepository interface {
 		tx Transaction,
 		payloads []models.ClientObject,
 		table models.Table,
+		fieldsToCompare []string,
+		completePayloads func(payloads []models.ClientObject) error,
 	) (models.IngestionResults, error)
 }
 
 type IngestionRepositoryImpl struct{}
 
 // Ingest objects and return:
-//   - Map of object_id to internal_id for the inserted objects
+//   - Map of object_id to internal_id for the inserted objects, along with which of the fieldsToCompare
+//     changed from the previous version of the object
 //   - Error if any
+//
+// completePayloads, if not nil, is called on the payloads about to be inserted, once partial updates have
+// been merged with the previous version of their object.
 func (repo *IngestionRepositoryImpl) IngestObjects(
 	ctx context.Context,
 	tx Transaction,
 	payloads []models.ClientObject,
 	table models.Table,
+	fieldsToCompare []string,
+	completePayloads func(payloads []models.ClientObject) error,
 ) (models.IngestionResults, error) {
 	if err := validateClientDbExecutor(tx); err != nil {
 		return nil, err
@@ -41,16 +49,16 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 
 	mostRecentObjectIds, mostRecentPayloads := mostRecentPayloadsByObjectId(payloads)
 
-	fieldsToLoad := fieldsToLoadFromDb(payloads)
+	fieldsToLoad := fieldsToLoadFromDb(payloads, fieldsToCompare)
 	previouslyIngestedObjects, err := repo.loadPreviouslyIngestedObjects(ctx, tx,
 		mostRecentObjectIds, table, fieldsToLoad)
 	if err != nil {
 		return nil, err
 	}
 
-	mapObjectIdToPreviousInternalId := make(map[string]string, len(previouslyIngestedObjects))
+	mapObjectIdToPreviousObject := make(map[string]ingestedObject, len(previouslyIngestedObjects))
 	for _, obj := range previouslyIngestedObjects {
-		mapObjectIdToPreviousInternalId[obj.objectId] = obj.id
+		mapObjectIdToPreviousObject[obj.objectId] = obj
 	}
 
 	payloadsToInsert, obsoleteIngestedObjectIds, validationErrors := compareAndMergePayloadsWithIngestedObjects(
@@ -61,6 +69,12 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 		return nil, errors.Join(models.BadParameterError, validationErrors)
 	}
 
+	if completePayloads != nil && len(payloadsToInsert) > 0 {
+		if err := completePayloads(payloadsToInsert); err != nil {
+			return nil, err
+		}
+	}
+
 	if len(obsoleteIngestedObjectIds) > 0 {
 		err := repo.batchUpdateValidUntilOnObsoleteObjects(
 			ctx,
@@ -80,13 +94,21 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 		}
 	}
 
+	mapObjectIdToPayload := make(map[string]models.ClientObject, len(payloadsToInsert))
+	for _, payload := range payloadsToInsert {
+		objectId, _ := objectIdAndUpdatedAtFromPayload(payload)
+		mapObjectIdToPayload[objectId] = payload
+	}
+
 	ingestionResults := make(models.IngestionResults, len(mapObjectIdToNewInternalId))
 	for objectId, newInternalId := range mapObjectIdToNewInternalId {
 		// Assumes that the object_id is always present as it is a mandatory field
-		ingestionResults[objectId] = models.IngestionResult{
-			PreviousInternalId: mapObjectIdToPreviousInternalId[objectId],
-			NewInternalId:      newInternalId,
+		result := models.IngestionResult{NewInternalId: newInternalId}
+		if previous, ok := mapObjectIdToPreviousObject[objectId]; ok {
+			result.PreviousInternalId = previous.id
+			result.ChangedFields = changedFields(previous.data, mapObjectIdToPayload[objectId].Data, fieldsToCompare)
 		}
+		ingestionResults[objectId] = result
 	}
 
 	return ingestionResults, nil
@@ -97,10 +119,14 @@ func (repo *IngestionRepositoryImpl) IngestObjects(
 // covering index on the object_id and updated_at columns that can be used.
 // This makes the POST endpoint possibly faster to respond than the PATCH endpoint for ingestion (when there
 // is data present and some payload have missing fields).
-func fieldsToLoadFromDb(payloads []models.ClientObject) []string {
+// The fields to compare with the previous version are always loaded.
+func fieldsToLoadFromDb(payloads []models.ClientObject, fieldsToCompare []string) []string {
 	missingFields := make(map[string]struct{})
 	missingFields["object_id"] = struct{}{}
 	missingFields["updated_at"] = struct{}{}
+	for _, field := range fieldsToCompare {
+		missingFields[field] = struct{}{}
+	}
 	for _, payload := range payloads {
 		for _, field := range payload.MissingFieldsToLookup {
 			missingFields[field.Field.Name] = struct{}{}
@@ -113,6 +139,28 @@ func fieldsToLoadFromDb(payloads []models.ClientObject) []string {
 	return missingFieldsList
 }
 
+// changedFields lists the fields whose value differs between the previously ingested version of an
+// object, as read from the database, and the new version about to be inserted.
+func changedFields(previous, next map[string]any, fieldsToCompare []string) []string {
+	var changed []string
+	for _, field := range fieldsToCompare {
+		if ingestedValueChanged(previous[field], next[field]) {
+			changed = append(changed, field)
+		}
+	}
+	return changed
+}
+
+// The values read from the database and the values parsed from a payload do not always have the same
+// Go types (e.g. integers and floats), so they are compared through their string representation.
+func ingestedValueChanged(previous, next any) bool {
+	if previousTime, ok := previous.(time.Time); ok {
+		nextTime, ok := next.(time.Time)
+		return !ok || !previousTime.Equal(nextTime)
+	}
+	return fmt.Sprint(previous) != fmt.Sprint(next)
+}
+
 func objectIdAndUpdatedAtFromPayload(payload models.ClientObject) (string, time.Time) {
 	objectIdItf := payload.Data["object_id"]
 	updatedAtItf := payload.Data["updated_at"]
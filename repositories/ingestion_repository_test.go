@@ -362,3 +362,23 @@ func TestMostRecentPayloadsByObjectId(t *testing.T) {
 		assert.ElementsMatch(t, expectedPayloads, actualPayloads)
 	})
 }
+
+func TestChangedFields(t *testing.T) {
+	testTime := time.Now()
+	previous := map[string]any{
+		"status":     "OK",
+		"amount":     int64(100),
+		"updated_at": testTime,
+		"comment":    nil,
+	}
+	next := map[string]any{
+		"status":     "BLOCKED",
+		"amount":     float64(100),
+		"updated_at": testTime.UTC(),
+		"comment":    nil,
+	}
+
+	assert.Equal(t, []string{"status"},
+		changedFields(previous, next, []string{"status", "amount", "updated_at", "comment"}))
+	assert.Empty(t, changedFields(previous, next, nil))
+}
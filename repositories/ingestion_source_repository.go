@@ -0,0 +1,207 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+func (repo *MarbleDbRepository) CreateIngestionSource(
+	ctx context.Context,
+	exec Executor,
+	source models.IngestionSource,
+) (models.IngestionSource, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_INGESTION_SOURCES).
+		Columns("id", "org_id", "name", "bucket_url", "prefix", "file_mappings", "enabled", "configured_by").
+		Values(source.Id, source.OrganizationId, source.Name, source.BucketUrl, source.Prefix,
+			dbmodels.AdaptIngestionSourceFileMappings(source.FileMappings), source.Enabled, source.ConfiguredBy).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectIngestionSourceColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptIngestionSource)
+}
+
+func (repo *MarbleDbRepository) GetIngestionSource(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.IngestionSource, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectIngestionSourceColumn...).
+		From(dbmodels.TABLE_INGESTION_SOURCES).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptIngestionSource)
+}
+
+func (repo *MarbleDbRepository) ListIngestionSources(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+) ([]models.IngestionSource, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectIngestionSourceColumn...).
+		From(dbmodels.TABLE_INGESTION_SOURCES).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at")
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptIngestionSource)
+}
+
+func (repo *MarbleDbRepository) UpdateIngestionSource(
+	ctx context.Context,
+	exec Executor,
+	input models.UpdateIngestionSourceInput,
+) (models.IngestionSource, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_INGESTION_SOURCES).
+		Set("name", input.Name).
+		Set("bucket_url", input.BucketUrl).
+		Set("prefix", input.Prefix).
+		Set("file_mappings", dbmodels.AdaptIngestionSourceFileMappings(input.FileMappings)).
+		Set("enabled", input.Enabled).
+		Set("configured_by", input.ConfiguredBy).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": input.Id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectIngestionSourceColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptIngestionSource)
+}
+
+func (repo *MarbleDbRepository) DeleteIngestionSource(ctx context.Context, exec Executor, id uuid.UUID) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Delete(dbmodels.TABLE_INGESTION_SOURCES).
+		Where(squirrel.Eq{"id": id}))
+}
+
+// RecordIngestionSourcePoll records when the source was last polled, and why the poll failed if it did.
+func (repo *MarbleDbRepository) RecordIngestionSourcePoll(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	pollError *string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	return ExecBuilder(ctx, exec, NewQueryBuilder().
+		Update(dbmodels.TABLE_INGESTION_SOURCES).
+		Set("last_polled_at", squirrel.Expr("NOW()")).
+		Set("last_error", pollError).
+		Where(squirrel.Eq{"id": id}))
+}
+
+// ListIngestionSourceFileVersions returns the ETags of the files already picked up from the source,
+// by file key.
+func (repo *MarbleDbRepository) ListIngestionSourceFileVersions(
+	ctx context.Context,
+	exec Executor,
+	sourceId uuid.UUID,
+) (map[string][]string, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Select("key", "etag").
+		From(dbmodels.TABLE_INGESTION_SOURCE_FILES).
+		Where(squirrel.Eq{"source_id": sourceId}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "error building query for ListIngestionSourceFileVersions")
+	}
+
+	rows, err := exec.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing ingestion source file versions")
+	}
+	defer rows.Close()
+
+	versions := make(map[string][]string)
+	for rows.Next() {
+		var key, etag string
+		if err := rows.Scan(&key, &etag); err != nil {
+			return nil, errors.Wrap(err, "error scanning ingestion source file version")
+		}
+		versions[key] = append(versions[key], etag)
+	}
+
+	return versions, rows.Err()
+}
+
+// CreateIngestionSourceFile tracks a file picked up from a source. It returns false, and creates
+// nothing, if that version of the file was already tracked.
+func (repo *MarbleDbRepository) CreateIngestionSourceFile(
+	ctx context.Context,
+	exec Executor,
+	file models.IngestionSourceFile,
+) (bool, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return false, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Insert(dbmodels.TABLE_INGESTION_SOURCE_FILES).
+		Columns("id", "org_id", "source_id", "key", "etag", "size", "table_name", "upload_log_id", "error").
+		Values(file.Id, file.OrganizationId, file.SourceId, file.Key, file.ETag, file.Size,
+			file.TableName, file.UploadLogId, file.Error).
+		Suffix("ON CONFLICT (source_id, key, etag) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "error building query for CreateIngestionSourceFile")
+	}
+
+	result, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "error creating ingestion source file")
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ListIngestionSourceFiles returns the files picked up from the source, the most recent first.
+func (repo *MarbleDbRepository) ListIngestionSourceFiles(
+	ctx context.Context,
+	exec Executor,
+	sourceId uuid.UUID,
+	limit int,
+) ([]models.IngestionSourceFile, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectIngestionSourceFileColumn...).
+		From(dbmodels.TABLE_INGESTION_SOURCE_FILES).
+		Where(squirrel.Eq{"source_id": sourceId}).
+		OrderBy("created_at DESC, id DESC").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptIngestionSourceFile)
+}
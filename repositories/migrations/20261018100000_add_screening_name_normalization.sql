@@ -0,0 +1,9 @@
+-- +goose Up
+alter table organizations
+    add column screening_name_normalization boolean not null default false,
+    add column screening_name_stoplist text[] not null default '{}';
+
+-- +goose Down
+alter table organizations
+    drop column screening_name_normalization,
+    drop column screening_name_stoplist;
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+
+alter table screening_whitelists
+    add column screening_config_stable_id uuid,
+    add column object_type text,
+    add column object_id text,
+    add column expires_at timestamp with time zone,
+    -- when the entry was reported as nearing its expiry, reset when it is renewed
+    add column expiry_notified_at timestamp with time zone,
+    add column updated_at timestamp with time zone not null default now();
+
+drop index if exists idx_screening_whitelist;
+
+create unique index idx_screening_whitelist
+    on screening_whitelists (org_id, counterparty_id, entity_id, screening_config_stable_id, object_type, object_id)
+    nulls not distinct;
+
+create index idx_screening_whitelists_expires_at
+    on screening_whitelists (org_id, expires_at)
+    where expires_at is not null;
+
+-- Any screening whitelist creation, renewal or removal
+
+create or replace trigger audit
+after insert or update or delete
+on screening_whitelists
+for each row
+execute function global_audit();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+create or replace trigger audit
+after insert
+on screening_whitelists
+for each row
+execute function global_audit();
+
+delete from screening_whitelists
+where screening_config_stable_id is not null or object_type is not null or object_id is not null;
+
+drop index if exists idx_screening_whitelists_expires_at;
+drop index if exists idx_screening_whitelist;
+
+create unique index idx_screening_whitelist on screening_whitelists (org_id, counterparty_id, entity_id);
+
+alter table screening_whitelists
+    drop column screening_config_stable_id,
+    drop column object_type,
+    drop column object_id,
+    drop column expires_at,
+    drop column expiry_notified_at,
+    drop column updated_at;
+
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+create table continuous_screening_attestation_reports (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    csv_file_path text not null,
+    json_file_path text not null,
+    object_count int not null default 0,
+    flagged_object_count int not null default 0,
+    open_match_count int not null default 0,
+    created_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade
+);
+
+create index idx_cs_attestation_reports_org_created_at on continuous_screening_attestation_reports (org_id, created_at desc);
+
+create table continuous_screening_attestation_report_downloads (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    report_id uuid not null,
+    format text not null constraint attestation_report_downloads_format_check check (format in ('csv', 'json')),
+    created_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_report foreign key (report_id) references continuous_screening_attestation_reports (id) on delete cascade
+);
+
+-- Reports are generated by a background job, without any user in the session, so their
+-- creation is always recorded, on behalf of the organization.
+create or replace function continuous_screening_attestation_report_audit() returns trigger as $$
+begin
+    insert into audit.audit_events ("operation", "org_id", "user_id", "api_key_id", "table", "entity_id", "data", "created_at")
+    values ('INSERT', new.org_id, null, null, TG_TABLE_NAME, new.id, to_jsonb(NEW), now());
+    return null;
+end;
+$$ language plpgsql;
+
+create trigger audit
+after insert
+on continuous_screening_attestation_reports
+for each row execute function continuous_screening_attestation_report_audit();
+
+create trigger audit
+after insert
+on continuous_screening_attestation_report_downloads
+for each row execute function global_audit();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table continuous_screening_attestation_report_downloads;
+drop table continuous_screening_attestation_reports;
+drop function continuous_screening_attestation_report_audit;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+
+-- Get the indexing date of monitored objects
+create index concurrently idx_cs_delta_tracks_indexed_by_org_object
+    on continuous_screening_delta_tracks (org_id, object_type, object_id)
+    where dataset_file_id is not null;
+
+-- Count the open matches of monitored objects found by dataset updates
+create index concurrently idx_cs_matches_pending_entity_id
+    on continuous_screening_matches (opensanction_entity_id)
+    where status = 'pending';
+
+-- +goose Down
+drop index idx_cs_matches_pending_entity_id;
+drop index idx_cs_delta_tracks_indexed_by_org_object;
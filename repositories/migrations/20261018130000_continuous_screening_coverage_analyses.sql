@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+create table continuous_screening_coverage_analyses (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    object_types text[] not null default '{}',
+    unmonitored_count int not null default 0,
+    orphaned_count int not null default 0,
+    truncated boolean not null default false,
+    backfilled_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade
+);
+
+create index idx_cs_coverage_analyses_org_created_at on continuous_screening_coverage_analyses (org_id, created_at desc);
+
+create table continuous_screening_coverage_gaps (
+    id uuid primary key default uuid_generate_v4 (),
+    analysis_id uuid not null,
+    gap_type text not null constraint coverage_gaps_gap_type_check check (gap_type in ('unmonitored', 'orphaned')),
+    object_type text not null,
+    object_id text not null,
+    object_internal_id uuid,
+    config_stable_id uuid,
+    created_at timestamp with time zone not null default now(),
+
+    constraint fk_analysis foreign key (analysis_id) references continuous_screening_coverage_analyses (id) on delete cascade
+);
+
+create index idx_cs_coverage_gaps_analysis on continuous_screening_coverage_gaps (analysis_id, gap_type, created_at, id);
+
+-- The backfill of an analysis is triggered by a user or an API key
+create trigger audit
+after update
+on continuous_screening_coverage_analyses
+for each row execute function global_audit();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table continuous_screening_coverage_gaps;
+drop table continuous_screening_coverage_analyses;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table upload_logs
+    add column file_format text not null default 'csv'
+    constraint upload_logs_file_format_check check (file_format in ('csv', 'ndjson', 'parquet'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table upload_logs drop column file_format;
+-- +goose StatementEnd
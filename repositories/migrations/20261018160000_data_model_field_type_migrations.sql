@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+create table data_model_field_type_migrations (
+    id uuid primary key default uuid_generate_v4 (),
+    organization_id uuid not null,
+    table_id uuid not null,
+    field_id uuid not null,
+    table_name text not null,
+    field_name text not null,
+    source_data_type text not null,
+    target_data_type text not null,
+    timestamp_format text,
+    null_on_failure boolean not null default false,
+    status text not null default 'pending' constraint field_type_migrations_status_check check (status in ('pending', 'running', 'completed', 'failed')),
+    last_row_id uuid,
+    rows_processed bigint not null default 0,
+    rows_failed bigint not null default 0,
+    error text,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    completed_at timestamp with time zone,
+
+    constraint fk_organization foreign key (organization_id) references organizations (id) on delete cascade,
+    constraint fk_field foreign key (field_id) references data_model_fields (id) on delete cascade
+);
+
+create index idx_field_type_migrations_field on data_model_field_type_migrations (field_id, created_at desc);
+
+-- A field can only have one migration in progress at a time
+create unique index uniq_field_type_migrations_active_field on data_model_field_type_migrations (field_id)
+where status in ('pending', 'running');
+
+create table data_model_field_type_migration_failures (
+    migration_id uuid not null,
+    row_id uuid not null,
+    object_id text not null,
+    value text not null,
+    error text not null,
+
+    primary key (migration_id, row_id),
+    constraint fk_migration foreign key (migration_id) references data_model_field_type_migrations (id) on delete cascade
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table data_model_field_type_migration_failures;
+drop table data_model_field_type_migrations;
+-- +goose StatementEnd
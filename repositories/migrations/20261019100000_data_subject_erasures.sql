@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+create table data_subject_erasures (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    object_type text not null,
+    -- Cleared, along with the targets, once the erasure is completed or failed
+    object_id text,
+    status text not null default 'pending' constraint data_subject_erasures_status_check check (status in ('pending', 'running', 'completed', 'failed')),
+    stage text not null default 'collect',
+    targets jsonb,
+    counts jsonb not null default '{}'::jsonb,
+    error text,
+    created_by text,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    completed_at timestamp with time zone,
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade
+);
+
+create index idx_data_subject_erasures_org_created_at on data_subject_erasures (org_id, created_at desc);
+
+-- An object can only have one erasure in progress at a time
+create unique index uniq_data_subject_erasures_active_object on data_subject_erasures (org_id, object_type, object_id)
+where status in ('pending', 'running');
+
+-- The audit record of an erasure is written when it ends, from the columns that do not identify
+-- the data subject, so that the audit trail does not keep the personal data that was erased.
+create or replace function data_subject_erasure_audit() returns trigger as $$
+begin
+    insert into audit.audit_events ("operation", "org_id", "user_id", "api_key_id", "table", "entity_id", "data", "created_at")
+    values ('UPDATE', new.org_id, new.created_by, null, TG_TABLE_NAME, new.id, jsonb_build_object(
+        'id', new.id,
+        'object_type', new.object_type,
+        'status', new.status,
+        'counts', new.counts,
+        'created_at', new.created_at,
+        'completed_at', new.completed_at
+    ), now());
+    return null;
+end;
+$$ language plpgsql;
+
+create trigger audit
+after update
+on data_subject_erasures
+for each row when (
+    old.status != new.status and new.status in ('completed', 'failed')
+)
+execute function data_subject_erasure_audit();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table data_subject_erasures;
+drop function data_subject_erasure_audit;
+-- +goose StatementEnd
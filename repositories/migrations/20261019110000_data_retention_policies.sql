@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+create table data_retention_policies (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    target text not null constraint data_retention_policies_target_check check (target in ('table', 'decisions')),
+    table_id uuid,
+    superseded_versions_days int constraint data_retention_policies_superseded_check check (superseded_versions_days > 0),
+    max_age_days int constraint data_retention_policies_max_age_check check (max_age_days > 0),
+    enabled boolean not null default true,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_table foreign key (table_id) references data_model_tables (id) on delete cascade,
+    constraint data_retention_policies_table_check check ((target = 'table') = (table_id is not null))
+);
+
+create unique index uniq_data_retention_policies_table on data_retention_policies (org_id, table_id)
+where target = 'table';
+
+create unique index uniq_data_retention_policies_decisions on data_retention_policies (org_id)
+where target = 'decisions';
+
+create table data_retention_runs (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    policy_id uuid not null,
+    superseded_rows_deleted bigint not null default 0,
+    expired_rows_deleted bigint not null default 0,
+    decisions_deleted bigint not null default 0,
+    skipped_reason text,
+    error text,
+    started_at timestamp with time zone not null default now(),
+    finished_at timestamp with time zone,
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_policy foreign key (policy_id) references data_retention_policies (id) on delete cascade
+);
+
+create index idx_data_retention_runs_policy_started_at on data_retention_runs (policy_id, started_at desc);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table data_retention_runs;
+drop table data_retention_policies;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table data_model_fields add column derived_expression jsonb default null;
+
+create table data_model_derived_field_backfills (
+    id uuid primary key default uuid_generate_v4 (),
+    organization_id uuid not null,
+    table_id uuid not null,
+    field_id uuid not null,
+    table_name text not null,
+    field_name text not null,
+    status text not null default 'pending' constraint derived_field_backfills_status_check check (status in ('pending', 'running', 'completed', 'failed', 'superseded')),
+    last_row_id uuid,
+    rows_processed bigint not null default 0,
+    error text,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    completed_at timestamp with time zone,
+
+    constraint fk_organization foreign key (organization_id) references organizations (id) on delete cascade,
+    constraint fk_field foreign key (field_id) references data_model_fields (id) on delete cascade
+);
+
+create index idx_derived_field_backfills_field on data_model_derived_field_backfills (field_id, created_at desc);
+
+-- A field can only have one backfill in progress at a time
+create unique index uniq_derived_field_backfills_active_field on data_model_derived_field_backfills (field_id)
+where status in ('pending', 'running');
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table data_model_derived_field_backfills;
+alter table data_model_fields drop column derived_expression;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+create table ingestion_dead_letters (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    object_type text not null,
+    object_id text,
+    source text not null constraint ingestion_dead_letters_source_check check (source in ('api', 'file')),
+    payload_format text not null constraint ingestion_dead_letters_payload_format_check check (payload_format in ('json', 'csv')),
+    payload jsonb not null,
+    is_patch boolean not null default false,
+    error text not null,
+    field_errors jsonb not null default '{}',
+    upload_log_id uuid,
+    ingestion_options jsonb not null default '{}',
+    status text not null default 'pending' constraint ingestion_dead_letters_status_check check (status in ('pending', 'replayed')),
+    replay_count int not null default 0,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    replayed_at timestamp with time zone,
+
+    constraint fk_organization foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_upload_log foreign key (upload_log_id) references upload_logs (id) on delete set null
+);
+
+create index idx_ingestion_dead_letters_org on ingestion_dead_letters (org_id, created_at desc, id desc);
+create index idx_ingestion_dead_letters_upload_log on ingestion_dead_letters (upload_log_id) where upload_log_id is not null;
+create index idx_ingestion_dead_letters_created_at on ingestion_dead_letters (created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table ingestion_dead_letters;
+-- +goose StatementEnd
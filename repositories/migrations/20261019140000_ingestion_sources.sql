@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+create table ingestion_sources (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    name text not null,
+    bucket_url text not null,
+    prefix text not null default '',
+    file_mappings jsonb not null default '[]',
+    enabled boolean not null default true,
+    configured_by uuid not null,
+    last_polled_at timestamp with time zone,
+    last_error text,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade
+);
+
+create index idx_ingestion_sources_org on ingestion_sources (org_id);
+
+create table ingestion_source_files (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    source_id uuid not null,
+    key text not null,
+    etag text not null,
+    size bigint not null,
+    table_name text not null,
+    upload_log_id uuid,
+    error text,
+    created_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_source foreign key (source_id) references ingestion_sources (id) on delete cascade,
+    constraint fk_upload_log foreign key (upload_log_id) references upload_logs (id) on delete set null
+);
+
+create unique index uniq_ingestion_source_files_version on ingestion_source_files (source_id, key, etag);
+
+create index idx_ingestion_source_files_created_at on ingestion_source_files (source_id, created_at desc);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table ingestion_source_files;
+drop table ingestion_sources;
+-- +goose StatementEnd
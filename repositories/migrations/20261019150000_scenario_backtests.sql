@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table scenario_test_run
+    add column kind text not null default 'shadow';
+
+create table scenario_backtests (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    scenario_id uuid not null,
+    scenario_iteration_id uuid not null,
+    test_run_id uuid not null,
+    window_start timestamp with time zone not null,
+    window_end timestamp with time zone not null,
+    status text not null,
+    cursor_created_at timestamp with time zone,
+    cursor_decision_id uuid,
+    decisions_evaluated bigint not null default 0,
+    decisions_failed bigint not null default 0,
+    error text,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    finished_at timestamp with time zone,
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_scenario foreign key (scenario_id) references scenarios (id) on delete cascade,
+    constraint fk_scenario_iteration foreign key (scenario_iteration_id) references scenario_iterations (id) on delete cascade,
+    constraint fk_test_run foreign key (test_run_id) references scenario_test_run (id) on delete cascade,
+    constraint uniq_scenario_backtests_test_run unique (test_run_id)
+);
+
+create index idx_scenario_backtests_scenario on scenario_backtests (org_id, scenario_id, created_at desc);
+
+create table scenario_backtest_outcomes (
+    backtest_id uuid not null,
+    live_outcome text not null,
+    backtest_outcome text not null,
+    total bigint not null default 0,
+
+    primary key (backtest_id, live_outcome, backtest_outcome),
+    constraint fk_backtest foreign key (backtest_id) references scenario_backtests (id) on delete cascade
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table scenario_backtest_outcomes;
+drop table scenario_backtests;
+
+delete from scenario_test_run_summaries
+where test_run_id in (select id from scenario_test_run where kind <> 'shadow');
+delete from scenario_test_run where kind <> 'shadow';
+
+alter table scenario_test_run
+    drop column kind;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table scenarios
+    add column canary_iteration_id uuid,
+    add column canary_percentage int not null default 0,
+    add column canary_started_at timestamp with time zone,
+    add constraint fk_scenarios_canary_iteration foreign key (canary_iteration_id) references scenario_iterations (id) on delete set null,
+    add constraint scenarios_canary_percentage_check check (canary_percentage between 0 and 100);
+
+alter table decisions
+    add column rollout_arm text;
+
+create or replace trigger audit
+after insert
+on scenario_publications
+for each row when (
+    new.publication_action in ('publish', 'unpublish', 'start_canary', 'rollback_canary')
+)
+execute function global_audit();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+create or replace trigger audit
+after insert
+on scenario_publications
+for each row when (
+    new.publication_action in ('publish', 'unpublish')
+)
+execute function global_audit();
+
+alter table decisions
+    drop column rollout_arm;
+
+alter table scenarios
+    drop constraint scenarios_canary_percentage_check,
+    drop constraint fk_scenarios_canary_iteration,
+    drop column canary_started_at,
+    drop column canary_percentage,
+    drop column canary_iteration_id;
+-- +goose StatementEnd
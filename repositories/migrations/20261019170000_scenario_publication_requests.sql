@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table organizations
+    add column require_publication_approval boolean not null default false;
+
+create table scenario_publication_requests (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    scenario_id uuid not null,
+    scenario_iteration_id uuid not null,
+    publication_action text not null,
+    canary_percentage int not null default 0,
+    status text not null,
+    comment text not null default '',
+    diff jsonb not null,
+    requested_by uuid not null,
+    reviewed_by uuid,
+    review_comment text,
+    error text,
+    scheduled_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    reviewed_at timestamp with time zone,
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_scenario foreign key (scenario_id) references scenarios (id) on delete cascade,
+    constraint fk_scenario_iteration foreign key (scenario_iteration_id) references scenario_iterations (id) on delete cascade,
+    constraint fk_requested_by foreign key (requested_by) references users (id),
+    constraint fk_reviewed_by foreign key (reviewed_by) references users (id)
+);
+
+create index idx_scenario_publication_requests_org on scenario_publication_requests (org_id, created_at desc);
+
+-- A scenario has at most one request waiting for a review, or approved and not published yet
+create unique index uniq_scenario_publication_requests_open
+    on scenario_publication_requests (scenario_id)
+    where status in ('pending', 'approved');
+
+create trigger audit
+after insert or update
+on scenario_publication_requests
+for each row execute function global_audit();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table scenario_publication_requests;
+
+alter table organizations
+    drop column require_publication_approval;
+-- +goose StatementEnd
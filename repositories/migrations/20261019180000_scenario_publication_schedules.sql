@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+create table scenario_publication_schedules (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    scenario_id uuid not null,
+    scenario_iteration_id uuid not null,
+    publication_action text not null,
+    scheduled_at timestamp with time zone not null,
+    status text not null,
+    error text,
+    created_by uuid,
+    publication_request_id uuid,
+    preparation_alerted_at timestamp with time zone,
+    executed_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint fk_scenario foreign key (scenario_id) references scenarios (id) on delete cascade,
+    constraint fk_scenario_iteration foreign key (scenario_iteration_id) references scenario_iterations (id) on delete cascade,
+    constraint fk_created_by foreign key (created_by) references users (id),
+    constraint fk_publication_request foreign key (publication_request_id) references scenario_publication_requests (id)
+);
+
+create index idx_scenario_publication_schedules_pending
+    on scenario_publication_schedules (org_id, scheduled_at)
+    where status = 'scheduled';
+
+create trigger audit
+after insert or update
+on scenario_publication_schedules
+for each row execute function global_audit();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table scenario_publication_schedules;
+-- +goose StatementEnd
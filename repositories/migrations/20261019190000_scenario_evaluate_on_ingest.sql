@@ -0,0 +1,13 @@
+-- +goose Up
+alter table scenarios add column evaluate_on_ingest boolean not null default false;
+alter table scenarios add column evaluate_on_ingest_fields text[] not null default '{}';
+
+-- Decisions created on ingestion are keyed on the scenario and the row of the object version, so
+-- that enqueuing the decisions of the same version twice does not evaluate it twice.
+alter table async_decision_executions add column deduplication_key text;
+
+-- +goose Down
+alter table async_decision_executions drop column deduplication_key;
+
+alter table scenarios drop column evaluate_on_ingest_fields;
+alter table scenarios drop column evaluate_on_ingest;
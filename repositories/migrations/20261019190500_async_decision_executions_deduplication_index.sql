@@ -0,0 +1,8 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+create unique index concurrently async_decision_executions_deduplication_key_idx
+    on async_decision_executions (org_id, deduplication_key)
+    where deduplication_key is not null;
+
+-- +goose Down
+drop index async_decision_executions_deduplication_key_idx;
@@ -0,0 +1,13 @@
+-- +goose Up
+alter table scenarios add column incremental_scheduled_execution boolean not null default false;
+alter table scenarios add column full_refresh_interval_days integer not null default 0;
+
+-- Resolved when the execution lists its objects: the run only evaluates the objects whose
+-- valid_from is after this time. Null for a full run.
+alter table scheduled_executions add column incremental_since timestamp with time zone;
+
+-- +goose Down
+alter table scheduled_executions drop column incremental_since;
+
+alter table scenarios drop column full_refresh_interval_days;
+alter table scenarios drop column incremental_scheduled_execution;
@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table scenario_iteration_rules
+    add column throttle_period_seconds integer not null default 0,
+    add column throttle_scope text not null default 'pivot';
+
+-- One row per throttled rule and throttle key (the decision's pivot value, or its trigger object id,
+-- depending on the throttle scope of the rule):
+-- the hit counted last, and until when the following hits of the rule on that key are throttled.
+create table rule_throttles (
+    id uuid primary key default uuid_generate_v4 (),
+    org_id uuid not null,
+    stable_rule_id uuid not null,
+    throttle_key text not null,
+    decision_id uuid not null,
+    starts_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null,
+
+    constraint fk_org foreign key (org_id) references organizations (id) on delete cascade,
+    constraint uniq_rule_throttles_rule_key unique (org_id, stable_rule_id, throttle_key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table rule_throttles;
+
+alter table scenario_iteration_rules
+    drop column throttle_period_seconds,
+    drop column throttle_scope;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A snooze applies to the decisions whose pivot value is the snooze's pivot_value, or, if key_field is set,
+-- to the decisions whose trigger object has pivot_value in that field.
+alter table rule_snoozes
+    add column key_field text;
+
+-- When set, the snooze does not apply to the decisions for which the condition is true.
+alter table rule_snoozes
+    add column unless_condition_ast_expression jsonb;
+
+-- The snooze that applied to a rule execution with the snoozed outcome.
+alter table decision_rules
+    add column rule_snooze_id uuid;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table decision_rules
+    drop column rule_snooze_id;
+
+alter table rule_snoozes
+    drop column unless_condition_ast_expression;
+
+alter table rule_snoozes
+    drop column key_field;
+-- +goose StatementEnd
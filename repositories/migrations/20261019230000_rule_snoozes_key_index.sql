@@ -0,0 +1,6 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+create index concurrently idx_rule_snoozes_group_key on rule_snoozes (snooze_group_id, key_field, pivot_value);
+
+-- +goose Down
+drop index idx_rule_snoozes_group_key;
@@ -144,6 +144,16 @@ func (repo *MarbleDbRepository) UpdateOrganization(
 			*updateOrganization.ScreeningConfig.MatchLimit)
 		hasUpdates = true
 	}
+	if updateOrganization.ScreeningConfig.NameNormalization != nil {
+		updateRequest = updateRequest.Set("screening_name_normalization",
+			*updateOrganization.ScreeningConfig.NameNormalization)
+		hasUpdates = true
+	}
+	if updateOrganization.ScreeningConfig.NameStoplist != nil {
+		updateRequest = updateRequest.Set("screening_name_stoplist",
+			*updateOrganization.ScreeningConfig.NameStoplist)
+		hasUpdates = true
+	}
 	if updateOrganization.ScreeningConfig.Providers != nil {
 		updateRequest = updateRequest.Set("screening_providers",
 			updateOrganization.ScreeningConfig.Providers)
@@ -159,6 +169,11 @@ func (repo *MarbleDbRepository) UpdateOrganization(
 			*updateOrganization.SentryReplayEnabled)
 		hasUpdates = true
 	}
+	if updateOrganization.RequirePublicationApproval != nil {
+		updateRequest = updateRequest.Set("require_publication_approval",
+			*updateOrganization.RequirePublicationApproval)
+		hasUpdates = true
+	}
 	if updateOrganization.Environment != nil {
 		updateRequest = updateRequest.Set("environment",
 			*updateOrganization.Environment)
@@ -61,7 +61,15 @@ func (repo *OrganizationSchemaRepositoryPostgresql) CreateTable(ctx context.Cont
 		valid_until TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT 'INFINITY'
 	  )`, sanitizedTableName)
 
-	_, err := exec.Exec(ctx, sql)
+	if _, err := exec.Exec(ctx, sql); err != nil {
+		return err
+	}
+
+	// The table is empty, the indexes on its past versions can be built right away
+	if _, err := exec.Exec(ctx, historyIndexSql(exec, tableName, false)); err != nil {
+		return err
+	}
+	_, err := exec.Exec(ctx, dataRetentionIndexSql(exec, tableName, false))
 	return err
 }
 
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/duckdb/duckdb-go/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// ParquetFile reads the rows of a local parquet file through an in-memory DuckDB database. Rows are
+// addressed by their index in the file, which is stable across reads of the same file and can
+// therefore be used as a checkpoint to resume from.
+type ParquetFile struct {
+	db      *sql.DB
+	path    string
+	columns []string
+	// selectList reads every column of the file, with the types the database driver does not map
+	// onto a native Go type cast to text.
+	selectList string
+}
+
+func OpenParquetFile(ctx context.Context, path string) (*ParquetFile, error) {
+	connector, err := duckdb.NewConnector("", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create duckdb connector")
+	}
+	db := sql.OpenDB(connector)
+
+	rows, err := db.QueryContext(ctx, "DESCRIBE SELECT * FROM read_parquet(?)", path)
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "could not read parquet file schema")
+	}
+	defer rows.Close()
+
+	file := &ParquetFile{db: db, path: path}
+	selectList := make([]string, 0)
+	for rows.Next() {
+		var name, columnType string
+		var null, key, defaultValue, extra sql.NullString
+		if err := rows.Scan(&name, &columnType, &null, &key, &defaultValue, &extra); err != nil {
+			db.Close()
+			return nil, errors.Wrap(err, "could not read parquet file schema")
+		}
+
+		file.columns = append(file.columns, name)
+		identifier := pgx.Identifier{name}.Sanitize()
+		// UUIDs are scanned as raw bytes otherwise
+		if columnType == "UUID" {
+			selectList = append(selectList, fmt.Sprintf("CAST(%s AS VARCHAR) AS %s", identifier, identifier))
+		} else {
+			selectList = append(selectList, identifier)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "could not read parquet file schema")
+	}
+	file.selectList = strings.Join(selectList, ", ")
+
+	return file, nil
+}
+
+func (f *ParquetFile) Columns() []string {
+	return f.columns
+}
+
+func (f *ParquetFile) NumRows(ctx context.Context) (int64, error) {
+	var numRows int64
+	err := f.db.QueryRowContext(ctx, "SELECT count(*) FROM read_parquet(?)", f.path).Scan(&numRows)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not count parquet file rows")
+	}
+	return numRows, nil
+}
+
+// ReadRows returns at most `limit` rows starting at the row of index `from`, in file order. Each row
+// maps column names to the native value read from the file, nil for null values. Decimals are read
+// as floats.
+func (f *ParquetFile) ReadRows(ctx context.Context, from int64, limit int) ([]map[string]any, error) {
+	query := fmt.Sprintf(`SELECT %s FROM read_parquet(?, file_row_number = true)
+		WHERE file_row_number >= ? AND file_row_number < ?
+		ORDER BY file_row_number`, f.selectList)
+
+	rows, err := f.db.QueryContext(ctx, query, f.path, from, from+int64(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read parquet file rows")
+	}
+	defer rows.Close()
+
+	result := make([]map[string]any, 0, limit)
+	values := make([]any, len(f.columns))
+	pointers := make([]any, len(f.columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, errors.Wrap(err, "could not read parquet file rows")
+		}
+		row := make(map[string]any, len(f.columns))
+		for i, column := range f.columns {
+			// Keep the driver types out of the rows
+			if decimal, ok := values[i].(duckdb.Decimal); ok {
+				row[column] = decimal.Float64()
+			} else {
+				row[column] = values[i]
+			}
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read parquet file rows")
+	}
+
+	return result, nil
+}
+
+func (f *ParquetFile) Close() error {
+	return f.db.Close()
+}
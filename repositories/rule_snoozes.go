@@ -10,6 +10,7 @@ import (
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/checkmarble/marble-backend/utils"
 )
 
 func selectSnoozeGroups() squirrel.SelectBuilder {
@@ -32,6 +33,8 @@ func (repo *MarbleDbRepository) GetSnoozeById(ctx context.Context, exec Executor
 		rs.created_from_rule_id,
 		rs.snooze_group_id,
 		rs.pivot_value,
+		rs.key_field,
+		rs.unless_condition_ast_expression,
 		rs.starts_at,
 		rs.expires_at
 	FROM rule_snoozes AS rs
@@ -40,23 +43,31 @@ func (repo *MarbleDbRepository) GetSnoozeById(ctx context.Context, exec Executor
 	`
 
 	row := exec.QueryRow(ctx, sql, id)
-	s := models.RuleSnooze{}
+	var organizationId uuid.UUID
+	db := dbmodels.DBRuleSnooze{}
 	if err := row.Scan(
-		&s.Id,
-		&s.OrganizationId,
-		&s.CreatedByUser,
-		&s.CreatedFromDecisionId,
-		&s.CreatedFromRuleId,
-		&s.SnoozeGroupId,
-		&s.PivotValue,
-		&s.StartsAt,
-		&s.ExpiresAt); err != nil {
+		&db.Id,
+		&organizationId,
+		&db.CreatedByUser,
+		&db.CreatedFromDecisionId,
+		&db.CreatedFromRuleId,
+		&db.SnoozeGroupId,
+		&db.PivotValue,
+		&db.KeyField,
+		&db.UnlessConditionAstExpression,
+		&db.StartsAt,
+		&db.ExpiresAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.RuleSnooze{}, errors.Wrapf(models.NotFoundError, "Snooze %s not found", id)
 		} else {
 			return models.RuleSnooze{}, err
 		}
 	}
+	s, err := dbmodels.AdaptRuleSnooze(db)
+	if err != nil {
+		return models.RuleSnooze{}, err
+	}
+	s.OrganizationId = organizationId
 	return s, nil
 }
 
@@ -102,7 +113,12 @@ func (repo *MarbleDbRepository) CreateRuleSnooze(ctx context.Context, exec Execu
 		return err
 	}
 
-	err := ExecBuilder(
+	unlessCondition, err := dbmodels.SerializeFormulaAstExpression(input.UnlessCondition)
+	if err != nil {
+		return err
+	}
+
+	err = ExecBuilder(
 		ctx,
 		exec,
 		NewQueryBuilder().
@@ -115,6 +131,8 @@ func (repo *MarbleDbRepository) CreateRuleSnooze(ctx context.Context, exec Execu
 				"created_from_rule_id",
 				"snooze_group_id",
 				"pivot_value",
+				"key_field",
+				"unless_condition_ast_expression",
 				"starts_at",
 				"expires_at",
 			).
@@ -126,6 +144,8 @@ func (repo *MarbleDbRepository) CreateRuleSnooze(ctx context.Context, exec Execu
 				input.CreatedFromRuleId,
 				input.SnoozeGroupId,
 				input.PivotValue,
+				input.KeyField,
+				unlessCondition,
 				"NOW()",
 				input.ExpiresAt,
 			),
@@ -149,17 +169,39 @@ func (repo *MarbleDbRepository) GetRuleSnooze(ctx context.Context, exec Executor
 	)
 }
 
+// ListActiveRuleSnoozesForDecision lists the active snoozes of the snooze groups keyed on one of the
+// given keys (see models.RuleSnoozeKeys). Only the keys on the fields that the active snoozes of the
+// groups are keyed on are looked up, rather than every field of the trigger object.
 func (repo *MarbleDbRepository) ListActiveRuleSnoozesForDecision(
 	ctx context.Context,
 	exec Executor,
 	snoozeGroupIds []string,
-	pivotValue string,
+	keys []models.RuleSnoozeKey,
 ) ([]models.RuleSnooze, error) {
 	if err := validateMarbleDbExecutor(exec); err != nil {
 		return nil, err
 	}
 
-	if len(snoozeGroupIds) == 0 {
+	if len(snoozeGroupIds) == 0 || len(keys) == 0 {
+		return []models.RuleSnooze{}, nil
+	}
+
+	keyFields, err := repo.listActiveRuleSnoozeKeyFields(ctx, exec, snoozeGroupIds)
+	if err != nil {
+		return nil, err
+	}
+
+	keyConditions := make(squirrel.Or, 0, len(keys))
+	for _, key := range keys {
+		if !keyFields[utils.Or(key.Field, "")] {
+			continue
+		}
+		keyConditions = append(keyConditions, squirrel.Eq{
+			"key_field":   key.Field,
+			"pivot_value": key.Value,
+		})
+	}
+	if len(keyConditions) == 0 {
 		return []models.RuleSnooze{}, nil
 	}
 
@@ -169,16 +211,91 @@ func (repo *MarbleDbRepository) ListActiveRuleSnoozesForDecision(
 		NewQueryBuilder().
 			Select(dbmodels.SelectRuleSnoozesColumn...).
 			From(dbmodels.TABLE_RULE_SNOOZES).
-			Where(squirrel.Eq{
-				"snooze_group_id": snoozeGroupIds,
-				"pivot_value":     pivotValue,
-			}).
+			Where(squirrel.Eq{"snooze_group_id": snoozeGroupIds}).
+			Where(keyConditions).
 			Where(squirrel.Gt{"expires_at": "now()"}).
 			Limit(200),
 		dbmodels.AdaptRuleSnooze,
 	)
 }
 
+func (repo *MarbleDbRepository) ListActiveRuleSnoozesOfSnoozeGroup(
+	ctx context.Context,
+	exec Executor,
+	snoozeGroupId string,
+) ([]models.RuleSnooze, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	return SqlToListOfModels(
+		ctx,
+		exec,
+		NewQueryBuilder().
+			Select(dbmodels.SelectRuleSnoozesColumn...).
+			From(dbmodels.TABLE_RULE_SNOOZES).
+			Where(squirrel.Eq{"snooze_group_id": snoozeGroupId}).
+			Where(squirrel.Gt{"expires_at": "now()"}).
+			OrderBy("starts_at DESC", "id"),
+		dbmodels.AdaptRuleSnooze,
+	)
+}
+
+// listActiveRuleSnoozeKeyFields returns the fields the active snoozes of the snooze groups are keyed
+// on, with "" standing for the pivot value.
+func (repo *MarbleDbRepository) listActiveRuleSnoozeKeyFields(
+	ctx context.Context,
+	exec Executor,
+	snoozeGroupIds []string,
+) (map[string]bool, error) {
+	query := `
+	SELECT DISTINCT COALESCE(key_field, '')
+	FROM rule_snoozes
+	WHERE snooze_group_id = ANY($1)
+	AND expires_at > NOW()`
+
+	rows, err := exec.Query(ctx, query, snoozeGroupIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keyFields := make(map[string]bool)
+	for rows.Next() {
+		var keyField string
+		if err := rows.Scan(&keyField); err != nil {
+			return nil, err
+		}
+		keyFields[keyField] = true
+	}
+
+	return keyFields, rows.Err()
+}
+
+// EndRuleSnoozes expires the active snoozes of a snooze group, only those listed in snoozeIds if it is
+// not nil.
+func (repo *MarbleDbRepository) EndRuleSnoozes(
+	ctx context.Context,
+	exec Executor,
+	snoozeGroupId string,
+	snoozeIds []string,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_RULE_SNOOZES).
+		Set("expires_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"snooze_group_id": snoozeGroupId}).
+		Where(squirrel.Gt{"expires_at": "now()"})
+	if snoozeIds != nil {
+		query = query.Where(squirrel.Eq{"id": snoozeIds})
+	}
+
+	return ExecBuilder(ctx, exec, query)
+}
+
 func (repo *MarbleDbRepository) AnySnoozesForIteration(
 	ctx context.Context,
 	exec Executor,
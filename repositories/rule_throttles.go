@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+)
+
+// ListActiveRuleThrottlesForDecision lists the throttles of the organization that have not expired on
+// any of the rules and any of the throttle keys. The caller matches each throttle with the key of its
+// rule.
+func (repo *MarbleDbRepository) ListActiveRuleThrottlesForDecision(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	stableRuleIds []string,
+	throttleKeys []string,
+) ([]models.RuleThrottle, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	if len(stableRuleIds) == 0 || len(throttleKeys) == 0 {
+		return []models.RuleThrottle{}, nil
+	}
+
+	return SqlToListOfModels(
+		ctx,
+		exec,
+		NewQueryBuilder().
+			Select(dbmodels.SelectRuleThrottlesColumn...).
+			From(dbmodels.TABLE_RULE_THROTTLES).
+			Where(squirrel.Eq{
+				"org_id":         orgId,
+				"stable_rule_id": stableRuleIds,
+				"throttle_key":   throttleKeys,
+			}).
+			Where(squirrel.Gt{"expires_at": "now()"}),
+		dbmodels.AdaptRuleThrottle,
+	)
+}
+
+// storeRuleThrottles starts the throttles of the rules hit by a decision. A throttle that has not
+// expired yet is left untouched, so that concurrent hits do not extend it.
+func storeRuleThrottles(ctx context.Context, exec Executor, throttles []models.RuleThrottle) error {
+	if len(throttles) == 0 {
+		return nil
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_RULE_THROTTLES).
+		Columns(
+			"org_id",
+			"stable_rule_id",
+			"throttle_key",
+			"decision_id",
+			"starts_at",
+			"expires_at",
+		)
+	for _, throttle := range throttles {
+		query = query.Values(
+			throttle.OrganizationId,
+			throttle.StableRuleId,
+			throttle.ThrottleKey,
+			throttle.DecisionId,
+			throttle.StartsAt,
+			throttle.ExpiresAt,
+		)
+	}
+	query = query.Suffix(`ON CONFLICT (org_id, stable_rule_id, throttle_key) DO UPDATE SET
+		decision_id = excluded.decision_id,
+		starts_at = excluded.starts_at,
+		expires_at = excluded.expires_at
+		WHERE rule_throttles.expires_at <= excluded.starts_at`)
+
+	return ExecBuilder(ctx, exec, query)
+}
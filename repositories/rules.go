@@ -147,6 +147,7 @@ func (repo *MarbleDbRepository) PhanomRulesExecutionStats(
 			"d.org_id":                organizationId,
 			"d.scenario_iteration_id": iterationId,
 		}).
+		Where(shadowTestRunPhantomDecisions("d")).
 		GroupBy("scir.stable_rule_id, scir.name, dr.outcome, scit.version")
 
 	return SqlToListOfModels(
@@ -298,6 +299,8 @@ func (repo *MarbleDbRepository) CreateRules(ctx context.Context, exec Executor,
 			"rule_group",
 			"snooze_group_id",
 			"stable_rule_id",
+			"throttle_period_seconds",
+			"throttle_scope",
 		).
 		Suffix("RETURNING *")
 
@@ -315,6 +318,8 @@ func (repo *MarbleDbRepository) CreateRules(ctx context.Context, exec Executor,
 			rule.RuleGroup,
 			rule.SnoozeGroupId,
 			rule.StableRuleId,
+			rule.ThrottlePeriodSecs,
+			rule.ThrottleScope,
 		)
 	}
 
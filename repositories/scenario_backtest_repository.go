@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (repo *MarbleDbRepository) CreateScenarioBacktest(
+	ctx context.Context,
+	exec Executor,
+	backtest models.ScenarioBacktest,
+) (models.ScenarioBacktest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_SCENARIO_BACKTESTS).
+		Columns(
+			"id",
+			"org_id",
+			"scenario_id",
+			"scenario_iteration_id",
+			"test_run_id",
+			"window_start",
+			"window_end",
+			"status",
+		).
+		Values(
+			backtest.Id,
+			backtest.OrganizationId,
+			backtest.ScenarioId,
+			backtest.ScenarioIterationId,
+			backtest.TestRunId,
+			backtest.WindowStart,
+			backtest.WindowEnd,
+			string(models.ScenarioBacktestPending),
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectScenarioBacktestColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioBacktest)
+}
+
+func (repo *MarbleDbRepository) GetScenarioBacktest(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+) (models.ScenarioBacktest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioBacktestColumn...).
+		From(dbmodels.TABLE_SCENARIO_BACKTESTS).
+		Where(squirrel.Eq{"id": id})
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioBacktest)
+}
+
+func (repo *MarbleDbRepository) ListScenarioBacktests(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	scenarioId *uuid.UUID,
+) ([]models.ScenarioBacktest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioBacktestColumn...).
+		From(dbmodels.TABLE_SCENARIO_BACKTESTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at DESC")
+	if scenarioId != nil {
+		query = query.Where(squirrel.Eq{"scenario_id": *scenarioId})
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptScenarioBacktest)
+}
+
+// UpdateScenarioBacktestProgress records that the backtest replayed the decisions up to the cursor.
+// It returns false if the backtest is over, for instance because it was cancelled.
+func (repo *MarbleDbRepository) UpdateScenarioBacktestProgress(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	cursorCreatedAt time.Time,
+	cursorDecisionId uuid.UUID,
+	evaluated, failed int64,
+) (bool, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return false, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCENARIO_BACKTESTS).
+		Set("status", string(models.ScenarioBacktestRunning)).
+		Set("cursor_created_at", cursorCreatedAt).
+		Set("cursor_decision_id", cursorDecisionId).
+		Set("decisions_evaluated", squirrel.Expr("decisions_evaluated + ?", evaluated)).
+		Set("decisions_failed", squirrel.Expr("decisions_failed + ?", failed)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		Where(scenarioBacktestInProgress()).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// CompleteScenarioBacktest ends the backtest, unless it is already over. It returns whether the
+// backtest was ended by this call.
+func (repo *MarbleDbRepository) CompleteScenarioBacktest(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	status models.ScenarioBacktestStatus,
+	backtestError *string,
+) (bool, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return false, err
+	}
+
+	sql, args, err := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCENARIO_BACKTESTS).
+		Set("status", string(status)).
+		Set("error", backtestError).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Set("finished_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		Where(scenarioBacktestInProgress()).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	tag, err := exec.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// SaveScenarioBacktestOutcomes adds the counts to the confusion matrix of the backtest.
+func (repo *MarbleDbRepository) SaveScenarioBacktestOutcomes(
+	ctx context.Context,
+	exec Executor,
+	backtestId uuid.UUID,
+	counts []models.ScenarioBacktestOutcomeCount,
+) error {
+	if len(counts) == 0 {
+		return nil
+	}
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_SCENARIO_BACKTEST_OUTCOMES+" AS orig").
+		Columns("backtest_id", "live_outcome", "backtest_outcome", "total").
+		Suffix(`ON CONFLICT (backtest_id, live_outcome, backtest_outcome) DO UPDATE
+			SET total = orig.total + EXCLUDED.total`)
+	for _, count := range counts {
+		query = query.Values(backtestId, count.LiveOutcome, count.BacktestOutcome, count.Total)
+	}
+
+	return ExecBuilder(ctx, exec, query)
+}
+
+func (repo *MarbleDbRepository) ListScenarioBacktestOutcomes(
+	ctx context.Context,
+	exec Executor,
+	backtestId uuid.UUID,
+) ([]models.ScenarioBacktestOutcomeCount, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioBacktestOutcomeColumn...).
+		From(dbmodels.TABLE_SCENARIO_BACKTEST_OUTCOMES).
+		Where(squirrel.Eq{"backtest_id": backtestId})
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptScenarioBacktestOutcomeCount)
+}
+
+// ListScenarioBacktestDecisions returns the next decisions of the scenario to replay, made in the
+// window of the backtest and after its cursor, with their rule executions.
+func (repo *MarbleDbRepository) ListScenarioBacktestDecisions(
+	ctx context.Context,
+	exec Executor,
+	backtest models.ScenarioBacktest,
+	limit int,
+) ([]models.DecisionWithRuleExecutions, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	decisions, err := SqlToListOfRow(
+		ctx,
+		exec,
+		scenarioBacktestDecisionsQuery(backtest, limit),
+		func(row pgx.CollectableRow) (models.DecisionWithRuleExecutions, error) {
+			db, err := pgx.RowToStructByPos[dbmodels.DbCoreDecisionWithCaseAndScenario](row)
+			if err != nil {
+				return models.DecisionWithRuleExecutions{}, err
+			}
+
+			decision, err := dbmodels.AdaptDecisionWithCase(db)
+			if err != nil {
+				return models.DecisionWithRuleExecutions{}, err
+			}
+			return models.DecisionWithRuleExecutions{Decision: decision}, nil
+		},
+	)
+	if err != nil || len(decisions) == 0 {
+		return decisions, err
+	}
+
+	decisionIds := make([]string, len(decisions))
+	for i, decision := range decisions {
+		decisionIds[i] = decision.DecisionId.String()
+	}
+	rules, err := repo.rulesOfDecisions(ctx, exec, decisionIds, false)
+	if err != nil {
+		return nil, err
+	}
+	for i, decision := range decisions {
+		decisions[i].RuleExecutions = rules[decision.DecisionId.String()]
+	}
+
+	return decisions, nil
+}
+
+func scenarioBacktestDecisionsQuery(backtest models.ScenarioBacktest, limit int) squirrel.SelectBuilder {
+	query := selectDecisionAndCase().
+		Where(squirrel.Eq{
+			"d.org_id":      backtest.OrganizationId,
+			"d.scenario_id": backtest.ScenarioId,
+		}).
+		Where(squirrel.GtOrEq{"d.created_at": backtest.WindowStart}).
+		Where(squirrel.Lt{"d.created_at": backtest.WindowEnd}).
+		OrderBy("d.created_at", "d.id").
+		Limit(uint64(limit))
+	if backtest.CursorCreatedAt != nil && backtest.CursorDecisionId != nil {
+		query = query.Where(squirrel.Expr("(d.created_at, d.id) > (?, ?)",
+			*backtest.CursorCreatedAt, *backtest.CursorDecisionId))
+	}
+	return query
+}
+
+func scenarioBacktestInProgress() squirrel.Eq {
+	return squirrel.Eq{"status": []string{
+		string(models.ScenarioBacktestPending),
+		string(models.ScenarioBacktestRunning),
+	}}
+}
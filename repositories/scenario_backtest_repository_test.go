@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScenarioBacktestDecisionsQuery(t *testing.T) {
+	backtest := models.ScenarioBacktest{
+		OrganizationId: uuid.New(),
+		ScenarioId:     uuid.New(),
+		WindowStart:    time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		WindowEnd:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	sql, args, err := scenarioBacktestDecisionsQuery(backtest, 200).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, " WHERE d.org_id = $1 AND d.scenario_id = $2 "+
+		"AND d.created_at >= $3 AND d.created_at < $4 ORDER BY d.created_at, d.id LIMIT 200")
+	require.Equal(t, []any{backtest.OrganizationId.String(), backtest.ScenarioId.String(),
+		backtest.WindowStart, backtest.WindowEnd}, args)
+}
+
+func TestScenarioBacktestDecisionsQuery_resumes_after_cursor(t *testing.T) {
+	cursorCreatedAt := time.Date(2026, 9, 15, 0, 0, 0, 0, time.UTC)
+	cursorDecisionId := uuid.New()
+	backtest := models.ScenarioBacktest{
+		OrganizationId:   uuid.New(),
+		ScenarioId:       uuid.New(),
+		WindowStart:      time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		WindowEnd:        time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		CursorCreatedAt:  &cursorCreatedAt,
+		CursorDecisionId: &cursorDecisionId,
+	}
+
+	sql, args, err := scenarioBacktestDecisionsQuery(backtest, 200).ToSql()
+
+	require.NoError(t, err)
+	require.Contains(t, sql, "AND d.created_at < $4 AND (d.created_at, d.id) > ($5, $6) ORDER BY")
+	require.Equal(t, []any{cursorCreatedAt, cursorDecisionId}, args[4:])
+}
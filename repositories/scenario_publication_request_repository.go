@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+func (repo *MarbleDbRepository) CreateScenarioPublicationRequest(
+	ctx context.Context,
+	exec Executor,
+	request models.ScenarioPublicationRequest,
+) (models.ScenarioPublicationRequest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	diff, err := json.Marshal(request.Diff)
+	if err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_SCENARIO_PUBLICATION_REQUESTS).
+		Columns(
+			"id",
+			"org_id",
+			"scenario_id",
+			"scenario_iteration_id",
+			"publication_action",
+			"canary_percentage",
+			"status",
+			"comment",
+			"diff",
+			"requested_by",
+			"scheduled_at",
+		).
+		Values(
+			request.Id,
+			request.OrganizationId,
+			request.ScenarioId,
+			request.ScenarioIterationId,
+			request.PublicationAction.String(),
+			request.CanaryPercentage,
+			string(models.ScenarioPublicationRequestPending),
+			request.Comment,
+			diff,
+			string(request.RequestedBy),
+			request.ScheduledAt,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectScenarioPublicationRequestColumn, ",")))
+
+	created, err := SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioPublicationRequest)
+	if IsUniqueViolationError(err) {
+		return models.ScenarioPublicationRequest{}, fmt.Errorf(
+			"a publication request is already open on this scenario: %w", models.ConflictError)
+	}
+	return created, err
+}
+
+func (repo *MarbleDbRepository) GetScenarioPublicationRequest(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	forUpdate bool,
+) (models.ScenarioPublicationRequest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioPublicationRequestColumn...).
+		From(dbmodels.TABLE_SCENARIO_PUBLICATION_REQUESTS).
+		Where(squirrel.Eq{"id": id})
+
+	if forUpdate {
+		query = query.Suffix("for update")
+	}
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioPublicationRequest)
+}
+
+func (repo *MarbleDbRepository) ListScenarioPublicationRequests(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	filters models.ListScenarioPublicationRequestsFilters,
+) ([]models.ScenarioPublicationRequest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioPublicationRequestColumn...).
+		From(dbmodels.TABLE_SCENARIO_PUBLICATION_REQUESTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at DESC")
+	if filters.ScenarioId != nil {
+		query = query.Where(squirrel.Eq{"scenario_id": *filters.ScenarioId})
+	}
+	if filters.Status != nil {
+		query = query.Where(squirrel.Eq{"status": string(*filters.Status)})
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptScenarioPublicationRequest)
+}
+
+// UpdateScenarioPublicationRequest moves the request to a new status. Reviews set the reviewer and
+// the review time, which are kept when the approved request is then published or fails.
+func (repo *MarbleDbRepository) UpdateScenarioPublicationRequest(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	input models.UpdateScenarioPublicationRequestInput,
+) (models.ScenarioPublicationRequest, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCENARIO_PUBLICATION_REQUESTS).
+		Set("status", string(input.Status)).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectScenarioPublicationRequestColumn, ",")))
+	if input.ReviewedBy != nil {
+		query = query.
+			Set("reviewed_by", string(*input.ReviewedBy)).
+			Set("review_comment", input.ReviewComment).
+			Set("reviewed_at", squirrel.Expr("NOW()"))
+	}
+	if input.Error != nil {
+		query = query.Set("error", *input.Error)
+	}
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioPublicationRequest)
+}
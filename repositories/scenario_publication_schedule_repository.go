@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories/dbmodels"
+	"github.com/google/uuid"
+)
+
+func (repo *MarbleDbRepository) CreateScenarioPublicationSchedule(
+	ctx context.Context,
+	exec Executor,
+	schedule models.ScenarioPublicationSchedule,
+) (models.ScenarioPublicationSchedule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+
+	var createdBy *string
+	if schedule.CreatedBy != nil {
+		userId := string(*schedule.CreatedBy)
+		createdBy = &userId
+	}
+
+	query := NewQueryBuilder().
+		Insert(dbmodels.TABLE_SCENARIO_PUBLICATION_SCHEDULES).
+		Columns(
+			"id",
+			"org_id",
+			"scenario_id",
+			"scenario_iteration_id",
+			"publication_action",
+			"scheduled_at",
+			"status",
+			"created_by",
+			"publication_request_id",
+		).
+		Values(
+			schedule.Id,
+			schedule.OrganizationId,
+			schedule.ScenarioId,
+			schedule.ScenarioIterationId,
+			schedule.PublicationAction.String(),
+			schedule.ScheduledAt,
+			string(models.ScenarioPublicationScheduleScheduled),
+			createdBy,
+			schedule.PublicationRequestId,
+		).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectScenarioPublicationScheduleColumn, ",")))
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioPublicationSchedule)
+}
+
+func (repo *MarbleDbRepository) GetScenarioPublicationSchedule(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	forUpdate bool,
+) (models.ScenarioPublicationSchedule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioPublicationScheduleColumn...).
+		From(dbmodels.TABLE_SCENARIO_PUBLICATION_SCHEDULES).
+		Where(squirrel.Eq{"id": id})
+
+	if forUpdate {
+		query = query.Suffix("for update")
+	}
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioPublicationSchedule)
+}
+
+// ListScenarioPublicationSchedules lists the schedules of the organization in the given statuses, in
+// the order they are due.
+func (repo *MarbleDbRepository) ListScenarioPublicationSchedules(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	filters models.ListScenarioPublicationsFilters,
+	statuses []models.ScenarioPublicationScheduleStatus,
+) ([]models.ScenarioPublicationSchedule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	statusValues := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusValues[i] = string(status)
+	}
+
+	query := NewQueryBuilder().
+		Select(dbmodels.SelectScenarioPublicationScheduleColumn...).
+		From(dbmodels.TABLE_SCENARIO_PUBLICATION_SCHEDULES).
+		Where(squirrel.Eq{"org_id": orgId, "status": statusValues}).
+		OrderBy("scheduled_at", "created_at")
+	if filters.ScenarioId != nil {
+		query = query.Where(squirrel.Eq{"scenario_id": *filters.ScenarioId})
+	}
+	if filters.ScenarioIterationId != nil {
+		query = query.Where(squirrel.Eq{"scenario_iteration_id": *filters.ScenarioIterationId})
+	}
+
+	return SqlToListOfModels(ctx, exec, query, dbmodels.AdaptScenarioPublicationSchedule)
+}
+
+func (repo *MarbleDbRepository) UpdateScenarioPublicationSchedule(
+	ctx context.Context,
+	exec Executor,
+	id uuid.UUID,
+	input models.UpdateScenarioPublicationScheduleInput,
+) (models.ScenarioPublicationSchedule, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+
+	query := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCENARIO_PUBLICATION_SCHEDULES).
+		Set("updated_at", squirrel.Expr("NOW()")).
+		Where(squirrel.Eq{"id": id}).
+		Suffix(fmt.Sprintf("RETURNING %s", strings.Join(dbmodels.SelectScenarioPublicationScheduleColumn, ",")))
+	if input.Status != nil {
+		query = query.Set("status", string(*input.Status))
+		if *input.Status == models.ScenarioPublicationScheduleExecuted {
+			query = query.Set("executed_at", squirrel.Expr("NOW()"))
+		}
+	}
+	if input.Error != nil {
+		query = query.Set("error", *input.Error)
+	}
+	if input.PreparationAlerted {
+		query = query.Set("preparation_alerted_at", squirrel.Expr("NOW()"))
+	}
+
+	return SqlToModel(ctx, exec, query, dbmodels.AdaptScenarioPublicationSchedule)
+}
@@ -59,6 +59,7 @@ func (repo *MarbleDbRepository) CreateTestRun(
 			Insert(dbmodels.TABLE_SCENARIO_TESTRUN).
 			Columns(
 				"id",
+				"kind",
 				"scenario_iteration_id",
 				"live_scenario_iteration_id",
 				"created_at",
@@ -67,6 +68,7 @@ func (repo *MarbleDbRepository) CreateTestRun(
 			).
 			Values(
 				testrunID,
+				input.Kind,
 				input.PhantomIterationId,
 				input.LiveScenarioId,
 				time.Now(),
@@ -104,7 +106,10 @@ func (repo *MarbleDbRepository) GetTestRunByLiveVersionID(
 		return nil, err
 	}
 	query := selectTestruns().
-		Where(squirrel.Eq{"live_scenario_iteration_id": liveVersionID}).
+		Where(squirrel.Eq{
+			"live_scenario_iteration_id": liveVersionID,
+			"kind":                       models.ScenarioTestRunShadow,
+		}).
 		OrderBy("created_at DESC")
 	testruns, err := SqlToListOfModels(ctx, exec, query, dbmodels.AdaptScenarioTestrun)
 	if err != nil {
@@ -124,7 +129,8 @@ func (repo *MarbleDbRepository) ListRunningTestRun(
 	}
 	query := NewQueryBuilder().
 		Select(`
-			tr.id, 
+			tr.id,
+			tr.kind,
 			tr.scenario_iteration_id,
 			tr.live_scenario_iteration_id,
 			tr.created_at, 
@@ -136,6 +142,7 @@ func (repo *MarbleDbRepository) ListRunningTestRun(
 		Join(dbmodels.TABLE_SCENARIO_ITERATIONS + " AS scit ON scit.id = tr.scenario_iteration_id").
 		Where(squirrel.And{
 			squirrel.Eq{"tr.status": models.Up},
+			squirrel.Eq{"tr.kind": models.ScenarioTestRunShadow},
 			squirrel.Eq{"scit.org_id": organizationId},
 		}).
 		OrderBy("created_at DESC")
@@ -157,11 +164,11 @@ func (repo *MarbleDbRepository) ListTestRunsByScenarioID(
 		return nil, err
 	}
 	query := NewQueryBuilder().
-		Select("tr.id, tr.scenario_iteration_id, tr.live_scenario_iteration_id, tr.created_at, tr.expires_at, tr.status, tr.summarized, tr.updated_at, scit.org_id, scit.scenario_id").
+		Select("tr.id, tr.kind, tr.scenario_iteration_id, tr.live_scenario_iteration_id, tr.created_at, tr.expires_at, tr.status, tr.summarized, tr.updated_at, scit.org_id, scit.scenario_id").
 		From(dbmodels.TABLE_SCENARIO_TESTRUN + " AS tr").
 		Join(dbmodels.TABLE_SCENARIO_ITERATIONS + " AS scit ON scit.id = tr.scenario_iteration_id").
 		Join(dbmodels.TABLE_SCENARIOS + " AS sc ON sc.id = scit.scenario_id").
-		Where(squirrel.Eq{"sc.id": scenarioID}).
+		Where(squirrel.Eq{"sc.id": scenarioID, "tr.kind": models.ScenarioTestRunShadow}).
 		OrderBy("tr.created_at DESC")
 
 	if len(status) > 0 {
@@ -182,6 +189,7 @@ func (repo *MarbleDbRepository) GetTestRunByID(ctx context.Context, exec Executo
 	}
 	query := NewQueryBuilder().
 		Select(`tr.id,
+			tr.kind,
 			tr.scenario_iteration_id,
 			tr.live_scenario_iteration_id,
 			tr.created_at,
@@ -218,7 +226,12 @@ func (repo *MarbleDbRepository) GetRecentTestRunForOrg(ctx context.Context, exec
 		From(dbmodels.TABLE_SCENARIO_TESTRUN+" as str").
 		Join(dbmodels.TABLE_SCENARIO_ITERATIONS+" as si on si.id = str.scenario_iteration_id").
 		LeftJoin("scenario_test_run_summaries as strs on strs.test_run_id = str.id").
-		Where(squirrel.Eq{"si.org_id": orgId, "str.summarized": false}).
+		// Backtests write their summaries as they replay decisions
+		Where(squirrel.Eq{
+			"si.org_id":      orgId,
+			"str.summarized": false,
+			"str.kind":       models.ScenarioTestRunShadow,
+		}).
 		Where(squirrel.Or{
 			squirrel.Eq{"strs.watermark": nil},
 			squirrel.And{
@@ -140,10 +140,35 @@ func (repo *MarbleDbRepository) ListAllScenarios(ctx context.Context, exec Execu
 	)
 }
 
+// ListScenariosEvaluatingOnIngest returns the live scenarios that evaluate the objects of the
+// given type when they are ingested.
+func (repo *MarbleDbRepository) ListScenariosEvaluatingOnIngest(ctx context.Context, exec Executor,
+	organizationId uuid.UUID, objectType string,
+) ([]models.Scenario, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	return SqlToListOfModels(
+		ctx,
+		exec,
+		selectScenarios().
+			Where(squirrel.Eq{
+				"org_id":              organizationId,
+				"trigger_object_type": objectType,
+				"evaluate_on_ingest":  true,
+				"archived":            false,
+			}).
+			Where(squirrel.NotEq{"live_scenario_iteration_id": nil}).
+			OrderBy("id"),
+		dbmodels.AdaptScenario,
+	)
+}
+
 // ListLiveIterationsAndNeighbors returns a list of scenario iterations,
 // whatever the scenarios is, that may considered live-adjacent. It returns the
-// live iterations and all iterations that were live within a time period before
-// the current time.
+// live iterations, the canary iterations running alongside them, and all iterations
+// that were live or canaries within a time period before the current time.
 //
 // The final query looks like this (useful for debugging):
 /*
@@ -151,7 +176,7 @@ func (repo *MarbleDbRepository) ListAllScenarios(ctx context.Context, exec Execu
 	  live as (
 	    select si.id as iteration_id
 	    from scenario_iterations si
-	    inner join scenarios s on s.live_scenario_iteration_id = si.id
+	    inner join scenarios s on s.live_scenario_iteration_id = si.id or s.canary_iteration_id = si.id
 	    where si.org_id = '<org_id>'
 	  ),
 	  test_runs as (
@@ -167,7 +192,7 @@ func (repo *MarbleDbRepository) ListAllScenarios(ctx context.Context, exec Execu
 	    from scenario_publications sp
 	    where
 	      org_id = '<org_id>' and
-	      publication_action in ('publish', 'prepare') and
+	      publication_action in ('publish', 'unpublish', 'prepare', 'start_canary') and
 	      created_at > now() - interval '72 hour'
 	  )
 	select
@@ -195,7 +220,7 @@ func (repo *MarbleDbRepository) ListLiveIterationsAndNeighbors(ctx context.Conte
 		return b.
 			Select("si.id as id").
 			From(dbmodels.TABLE_SCENARIO_ITERATIONS+" si").
-			InnerJoin("scenarios s on s.live_scenario_iteration_id = si.id").
+			InnerJoin("scenarios s on s.live_scenario_iteration_id = si.id or s.canary_iteration_id = si.id").
 			Where("si.org_id = ?", orgId)
 	}).
 		With("test_run", func(b squirrel.StatementBuilderType) squirrel.SelectBuilder {
@@ -216,6 +241,7 @@ func (repo *MarbleDbRepository) ListLiveIterationsAndNeighbors(ctx context.Conte
 						"publication_action": []string{
 							models.Prepare.String(),
 							models.Publish.String(), models.Unpublish.String(),
+							models.StartCanary.String(),
 						},
 					},
 					squirrel.Gt{
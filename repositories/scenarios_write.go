@@ -68,6 +68,22 @@ func (repo *MarbleDbRepository) UpdateScenario(ctx context.Context, exec Executo
 		sql = sql.Set("deduplicate_batch_objects", *scenario.DeduplicateBatchObjects)
 		countApply++
 	}
+	if scenario.EvaluateOnIngest != nil {
+		sql = sql.Set("evaluate_on_ingest", *scenario.EvaluateOnIngest)
+		countApply++
+	}
+	if scenario.EvaluateOnIngestFields != nil {
+		sql = sql.Set("evaluate_on_ingest_fields", *scenario.EvaluateOnIngestFields)
+		countApply++
+	}
+	if scenario.IncrementalScheduledExecution != nil {
+		sql = sql.Set("incremental_scheduled_execution", *scenario.IncrementalScheduledExecution)
+		countApply++
+	}
+	if scenario.FullRefreshIntervalDays != nil {
+		sql = sql.Set("full_refresh_interval_days", *scenario.FullRefreshIntervalDays)
+		countApply++
+	}
 
 	if countApply == 0 {
 		return nil
@@ -95,3 +111,20 @@ func (repo *MarbleDbRepository) UpdateScenarioLiveIterationId(ctx context.Contex
 	}
 	return nil
 }
+
+func (repo *MarbleDbRepository) UpdateScenarioCanary(ctx context.Context, exec Executor,
+	scenarioId string, input models.UpdateScenarioCanaryInput,
+) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCENARIOS).
+		Where("id = ?", scenarioId).
+		Set("canary_iteration_id", input.CanaryIterationId).
+		Set("canary_percentage", input.CanaryPercentage).
+		Set("canary_started_at", input.CanaryStartedAt)
+
+	return ExecBuilder(ctx, exec, sql)
+}
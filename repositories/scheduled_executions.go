@@ -203,6 +203,9 @@ func (repo *MarbleDbRepository) UpdateScheduledExecution(
 	if input.Deadline != nil {
 		query = query.Set("deadline", *input.Deadline)
 	}
+	if input.IncrementalSince != nil {
+		query = query.Set("incremental_since", *input.IncrementalSince)
+	}
 
 	return ExecBuilder(ctx, exec, query)
 }
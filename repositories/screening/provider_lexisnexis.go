@@ -27,6 +27,8 @@ type ScreeningLexisNexisProvider struct {
 func (p ScreeningLexisNexisProvider) SearchRequest(ctx context.Context,
 	query *models.OpenSanctionsQuery,
 ) (*http.Request, []byte, error) {
+	queries := query.WithNormalizedIdentifiers().WithNormalizedNames().Queries
+
 	q := openSanctionsRequest{
 		Queries: make(map[string]openSanctionsRequestQuery, len(query.Queries)),
 	}
@@ -45,7 +47,7 @@ func (p ScreeningLexisNexisProvider) SearchRequest(ctx context.Context,
 		q.Weights = query.Config.Weights
 	}
 
-	for _, subquery := range query.Queries {
+	for _, subquery := range queries {
 		filters := query.Config.Filters.Resolve()
 
 		for topic, filter := range filters.WithRootTopics() {
@@ -24,6 +24,8 @@ type ScreeningOpenSanctionsProvider struct {
 func (p ScreeningOpenSanctionsProvider) SearchRequest(ctx context.Context,
 	query *models.OpenSanctionsQuery,
 ) (*http.Request, []byte, error) {
+	queries := query.WithNormalizedIdentifiers().WithNormalizedNames().Queries
+
 	q := openSanctionsRequest{
 		Queries: make(map[string]openSanctionsRequestQuery, len(query.Queries)),
 	}
@@ -49,7 +51,7 @@ func (p ScreeningOpenSanctionsProvider) SearchRequest(ctx context.Context,
 		q.Weights = query.Config.Weights
 	}
 
-	for _, subquery := range query.Queries {
+	for _, subquery := range queries {
 		rq := openSanctionsRequestQuery{
 			Schema:     subquery.Type,
 			Properties: subquery.Filters,
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/checkmarble/marble-backend/models"
@@ -9,12 +10,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// AddScreeningMatchWhitelist creates a whitelist entry, or renews it if the same entry already
+// exists with the same scope.
 func (repo *MarbleDbRepository) AddScreeningMatchWhitelist(
 	ctx context.Context,
 	exec Executor,
-	orgId uuid.UUID,
-	counterpartyId string, entityId string,
-	reviewerId *models.UserId,
+	input models.ScreeningWhitelistCreateInput,
 ) error {
 	if err := validateMarbleDbExecutor(exec); err != nil {
 		return err
@@ -22,9 +23,32 @@ func (repo *MarbleDbRepository) AddScreeningMatchWhitelist(
 
 	sql := NewQueryBuilder().
 		Insert(dbmodels.TABLE_SCREENING_WHITELISTS).
-		Columns("org_id", "counterparty_id", "entity_id", "whitelisted_by").
-		Values(orgId, counterpartyId, entityId, reviewerId).
-		Suffix("ON CONFLICT (org_id, counterparty_id, entity_id) DO NOTHING")
+		Columns(
+			"org_id",
+			"counterparty_id",
+			"entity_id",
+			"screening_config_stable_id",
+			"object_type",
+			"object_id",
+			"expires_at",
+			"whitelisted_by",
+		).
+		Values(
+			input.OrgId,
+			input.CounterpartyId,
+			input.EntityId,
+			input.Scope.ScreeningConfigStableId,
+			input.Scope.ObjectType,
+			input.Scope.ObjectId,
+			input.ExpiresAt,
+			input.WhitelistedBy,
+		).
+		Suffix("ON CONFLICT (org_id, counterparty_id, entity_id, screening_config_stable_id, object_type, object_id) " +
+			"DO UPDATE SET " +
+			"expires_at = EXCLUDED.expires_at, " +
+			"expiry_notified_at = null, " +
+			"whitelisted_by = EXCLUDED.whitelisted_by, " +
+			"updated_at = now()")
 
 	return ExecBuilder(ctx, exec, sql)
 }
@@ -76,6 +100,35 @@ func (repo *MarbleDbRepository) SearchScreeningMatchWhitelist(ctx context.Contex
 	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptScreeningWhitelist)
 }
 
+func (repo *MarbleDbRepository) ListScreeningMatchWhitelists(ctx context.Context,
+	exec Executor, orgId uuid.UUID, filters models.ScreeningWhitelistSearchFilters,
+) ([]models.ScreeningWhitelist, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.ScreeningWhitelistColumnList...).
+		From(dbmodels.TABLE_SCREENING_WHITELISTS).
+		Where(squirrel.Eq{"org_id": orgId}).
+		OrderBy("created_at, id")
+
+	if filters.EntityId != nil {
+		sql = sql.Where(squirrel.Eq{"entity_id": filters.EntityId})
+	}
+	if filters.CounterpartyId != nil {
+		sql = sql.Where(squirrel.Eq{"counterparty_id": filters.CounterpartyId})
+	}
+	if !filters.IncludeExpired {
+		sql = sql.Where("(expires_at is null or expires_at > now())")
+	}
+	if filters.ExpiresBefore != nil {
+		sql = sql.Where(squirrel.Lt{"expires_at": filters.ExpiresBefore})
+	}
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptScreeningWhitelist)
+}
+
 func (repo *MarbleDbRepository) SearchScreeningMatchWhitelistByIds(
 	ctx context.Context,
 	exec Executor,
@@ -106,3 +159,41 @@ func (repo *MarbleDbRepository) SearchScreeningMatchWhitelistByIds(
 
 	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptScreeningWhitelist)
 }
+
+// ListScreeningWhitelistsToNotifyOfExpiry lists the entries of an organization that expire before the
+// given date and were not reported yet.
+func (repo *MarbleDbRepository) ListScreeningWhitelistsToNotifyOfExpiry(
+	ctx context.Context,
+	exec Executor,
+	orgId uuid.UUID,
+	expiresBefore time.Time,
+	limit int,
+) ([]models.ScreeningWhitelist, error) {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return nil, err
+	}
+
+	sql := NewQueryBuilder().
+		Select(dbmodels.ScreeningWhitelistColumnList...).
+		From(dbmodels.TABLE_SCREENING_WHITELISTS).
+		Where(squirrel.Eq{"org_id": orgId, "expiry_notified_at": nil}).
+		Where(squirrel.Gt{"expires_at": "now()"}).
+		Where(squirrel.Lt{"expires_at": expiresBefore}).
+		OrderBy("expires_at, id").
+		Limit(uint64(limit))
+
+	return SqlToListOfModels(ctx, exec, sql, dbmodels.AdaptScreeningWhitelist)
+}
+
+func (repo *MarbleDbRepository) MarkScreeningWhitelistExpiryNotified(ctx context.Context, exec Executor, id string) error {
+	if err := validateMarbleDbExecutor(exec); err != nil {
+		return err
+	}
+
+	sql := NewQueryBuilder().
+		Update(dbmodels.TABLE_SCREENING_WHITELISTS).
+		Set("expiry_notified_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": id})
+
+	return ExecBuilder(ctx, exec, sql)
+}
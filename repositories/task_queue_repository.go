@@ -109,6 +109,11 @@ type TaskQueueRepository interface {
 		orgId uuid.UUID,
 		updateId uuid.UUID,
 	) error
+	EnqueueContinuousScreeningCoverageAnalysisTask(
+		ctx context.Context,
+		tx Transaction,
+		orgId uuid.UUID,
+	) error
 	EnqueueCsvIngestionTask(
 		ctx context.Context,
 		tx Transaction,
@@ -116,12 +121,43 @@ type TaskQueueRepository interface {
 		uploadLogId uuid.UUID,
 		ingestionOptions models.IngestionOptions,
 	) error
+	EnqueueFieldTypeMigrationTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId uuid.UUID,
+		migrationId uuid.UUID,
+	) error
+	EnqueueDerivedFieldBackfillTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId uuid.UUID,
+		backfillId uuid.UUID,
+	) error
+	EnqueueDataSubjectErasureTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId uuid.UUID,
+		erasureId uuid.UUID,
+	) error
+	EnqueueScenarioBacktestTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId uuid.UUID,
+		backtestId uuid.UUID,
+	) error
+	EnqueueScenarioPublicationRequestTask(
+		ctx context.Context,
+		tx Transaction,
+		organizationId uuid.UUID,
+		requestId uuid.UUID,
+	) error
 	EnqueueAsyncUploadTask(
 		ctx context.Context,
 		tx Transaction,
 		organizationId uuid.UUID,
 		objectType string,
 		key string,
+		fileFormat models.IngestionFileFormat,
 		ingestionOptions models.IngestionOptions,
 	) error
 	EnqueueScheduledExecutionTask(
@@ -593,6 +629,33 @@ func (r riverRepository) EnqueueContinuousScreeningRegisterObjectTaskMany(
 	return nil
 }
 
+func (r riverRepository) EnqueueContinuousScreeningCoverageAnalysisTask(
+	ctx context.Context,
+	tx Transaction,
+	orgId uuid.UUID,
+) error {
+	res, err := r.client.InsertTx(
+		ctx,
+		tx.RawTx(),
+		models.ContinuousScreeningCoverageAnalysisArgs{
+			OrgId: orgId,
+		},
+		&river.InsertOpts{
+			Queue:    orgId.String(),
+			Priority: 4,
+			// Requested by a user, so not delayed like the periodic analysis
+			Metadata: []byte(`{"manual": true}`),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued continuous screening coverage analysis task", "job_id", res.Job.ID)
+	return nil
+}
+
 // EnqueueContinuousScreeningEnsureDeltaTrackTask enqueues a delayed job that creates a missing
 // Add delta track if the original RegisterObjectWorker failed to commit it. The enqueue runs
 // against the marble pool directly (no client tx participation): callers should invoke it inside
@@ -660,12 +723,118 @@ func (r riverRepository) EnqueueCsvIngestionTask(
 	return nil
 }
 
+func (r riverRepository) EnqueueFieldTypeMigrationTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId uuid.UUID,
+	migrationId uuid.UUID,
+) error {
+	res, err := r.client.InsertTx(ctx, tx.RawTx(), models.FieldTypeMigrationArgs{
+		OrgId:       organizationId,
+		MigrationId: migrationId,
+	}, &river.InsertOpts{
+		Queue: organizationId.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued field type migration task", "migration_id", migrationId, "job_id", res.Job.ID)
+	return nil
+}
+
+func (r riverRepository) EnqueueDerivedFieldBackfillTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId uuid.UUID,
+	backfillId uuid.UUID,
+) error {
+	res, err := r.client.InsertTx(ctx, tx.RawTx(), models.DerivedFieldBackfillArgs{
+		OrgId:      organizationId,
+		BackfillId: backfillId,
+	}, &river.InsertOpts{
+		Queue: organizationId.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued derived field backfill task", "backfill_id", backfillId, "job_id", res.Job.ID)
+	return nil
+}
+
+func (r riverRepository) EnqueueDataSubjectErasureTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId uuid.UUID,
+	erasureId uuid.UUID,
+) error {
+	res, err := r.client.InsertTx(ctx, tx.RawTx(), models.DataSubjectErasureArgs{
+		OrgId:     organizationId,
+		ErasureId: erasureId,
+	}, &river.InsertOpts{
+		Queue: organizationId.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued data subject erasure task", "erasure_id", erasureId, "job_id", res.Job.ID)
+	return nil
+}
+
+func (r riverRepository) EnqueueScenarioBacktestTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId uuid.UUID,
+	backtestId uuid.UUID,
+) error {
+	res, err := r.client.InsertTx(ctx, tx.RawTx(), models.ScenarioBacktestArgs{
+		OrgId:      organizationId,
+		BacktestId: backtestId,
+	}, &river.InsertOpts{
+		Queue: organizationId.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued scenario backtest task", "backtest_id", backtestId, "job_id", res.Job.ID)
+	return nil
+}
+
+func (r riverRepository) EnqueueScenarioPublicationRequestTask(
+	ctx context.Context,
+	tx Transaction,
+	organizationId uuid.UUID,
+	requestId uuid.UUID,
+) error {
+	res, err := r.client.InsertTx(ctx, tx.RawTx(), models.ScenarioPublicationRequestArgs{
+		OrgId:     organizationId,
+		RequestId: requestId,
+	}, &river.InsertOpts{
+		Queue: organizationId.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	logger := utils.LoggerFromContext(ctx)
+	logger.DebugContext(ctx, "Enqueued scenario publication request task", "request_id", requestId, "job_id", res.Job.ID)
+	return nil
+}
+
 func (r riverRepository) EnqueueAsyncUploadTask(
 	ctx context.Context,
 	tx Transaction,
 	organizationId uuid.UUID,
 	objectType string,
 	key string,
+	fileFormat models.IngestionFileFormat,
 	ingestionOptions models.IngestionOptions,
 ) error {
 	args := models.AsyncUploadArgs{
@@ -673,6 +842,7 @@ func (r riverRepository) EnqueueAsyncUploadTask(
 		ObjectType:       objectType,
 		Key:              key,
 		IngestionOptions: ingestionOptions,
+		FileFormat:       fileFormat,
 	}
 
 	res, err := r.client.InsertTx(ctx, tx.RawTx(), args, &river.InsertOpts{
@@ -39,6 +39,7 @@ func (repo *UploadLogRepositoryImpl) CreateUploadLog(ctx context.Context, exec E
 				"user_id",
 				"file_name",
 				"table_name",
+				"file_format",
 				"status",
 				"started_at",
 				"finished_at",
@@ -52,6 +53,7 @@ func (repo *UploadLogRepositoryImpl) CreateUploadLog(ctx context.Context, exec E
 				log.UserId,
 				log.FileName,
 				log.TableName,
+				models.IngestionFileFormatFrom(string(log.FileFormat)),
 				log.UploadStatus,
 				log.StartedAt,
 				log.FinishedAt,
@@ -108,7 +110,7 @@ func (repo *UploadLogRepositoryImpl) UpdateUploadLogStatus(
 	return tag.RowsAffected() > 0, nil
 }
 
-// SaveUploadLogCheckpoint records how far into the CSV the ingestion got, so a later attempt can
+// SaveUploadLogCheckpoint records how far into the file the ingestion got, so a later attempt can
 // resume from there rather than restarting the file.
 func (repo *UploadLogRepositoryImpl) SaveUploadLogCheckpoint(
 	ctx context.Context,
@@ -259,6 +259,172 @@ func (uc AnalyticsQueryUsecase) RuleCoOccurenceMatrix(ctx context.Context,
 	return repositories.AnalyticsScanStruct[analytics.RuleCoOccurence](ctx, exec, query)
 }
 
+// RulePrecision labels the hits of each rule with the outcome of the case their decision ended in,
+// to measure how often a hit is useful.
+func (uc AnalyticsQueryUsecase) RulePrecision(ctx context.Context,
+	filters dto.AnalyticsQueryFilters,
+) ([]analytics.RulePrecision, error) {
+	if !uc.license.Analytics {
+		return []analytics.RulePrecision{}, nil
+	}
+
+	scenario, exec, err := uc.getExecutor(ctx, filters.ScenarioId)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleHits, err := uc.ruleHitsQuery(scenario, filters)
+	if err != nil {
+		return nil, err
+	}
+	hitsCte := func(b squirrel.StatementBuilderType) squirrel.SelectBuilder { return ruleHits }
+
+	hitsQuery := squirrel.
+		Select(
+			"rule_id",
+			"any_value(rule_name) as rule_name",
+			"count() as hits",
+		).
+		PrefixExpr(repositories.WithCtesRaw("hits", hitsCte)).
+		From("hits").
+		GroupBy("rule_id").
+		OrderBy("hits desc")
+
+	hits, err := repositories.AnalyticsScanStruct[analytics.RuleHitCount](ctx, exec, hitsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomesQuery := squirrel.
+		Select(
+			"h.rule_id",
+			"count() as cases",
+			"count() filter (where o.case_status = 'closed' and o.outcome = 'confirmed_risk') as confirmed_risks",
+			"count() filter (where o.case_status = 'closed' and o.outcome = 'valuable_alert') as valuable_alerts",
+			"count() filter (where o.case_status = 'closed' and o.outcome = 'false_positive') as false_positives",
+			"median(epoch(o.created_at - o.case_created_at)) filter (where o.case_status = 'closed') / 3600 "+
+				"as median_handling_hours",
+		).
+		PrefixExpr(repositories.WithCtesRaw("hits", hitsCte).With("outcomes", uc.caseOutcomesQuery(scenario))).
+		From("hits h").
+		InnerJoin("outcomes o on o.decision_id = h.decision_id").
+		GroupBy("h.rule_id")
+
+	outcomes, err := repositories.AnalyticsScanStruct[analytics.RuleCaseOutcomes](ctx, exec, outcomesQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return analytics.NewRulePrecisions(hits, outcomes), nil
+}
+
+// RulePairPrecision is RulePrecision for the decisions where both rules of a pair hit.
+func (uc AnalyticsQueryUsecase) RulePairPrecision(ctx context.Context,
+	filters dto.AnalyticsQueryFilters,
+) ([]analytics.RulePairPrecision, error) {
+	if !uc.license.Analytics {
+		return []analytics.RulePairPrecision{}, nil
+	}
+
+	scenario, exec, err := uc.getExecutor(ctx, filters.ScenarioId)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleHits, err := uc.ruleHitsQuery(scenario, filters)
+	if err != nil {
+		return nil, err
+	}
+	pairsCte := func() *repositories.QueryCte {
+		return repositories.
+			WithCtesRaw("hits", func(b squirrel.StatementBuilderType) squirrel.SelectBuilder { return ruleHits }).
+			With("pairs", func(b squirrel.StatementBuilderType) squirrel.SelectBuilder {
+				return b.
+					Select(
+						"h1.decision_id",
+						"h1.rule_id as rule_x",
+						"h1.rule_name as rule_x_name",
+						"h2.rule_id as rule_y",
+						"h2.rule_name as rule_y_name",
+					).
+					From("hits h1").
+					InnerJoin("hits h2 on h2.decision_id = h1.decision_id and h1.rule_id < h2.rule_id")
+			})
+	}
+
+	hitsQuery := squirrel.
+		Select(
+			"rule_x",
+			"any_value(rule_x_name) as rule_x_name",
+			"rule_y",
+			"any_value(rule_y_name) as rule_y_name",
+			"count() as hits",
+		).
+		PrefixExpr(pairsCte()).
+		From("pairs").
+		GroupBy("rule_x", "rule_y").
+		OrderBy("hits desc")
+
+	hits, err := repositories.AnalyticsScanStruct[analytics.RulePairHitCount](ctx, exec, hitsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomesQuery := squirrel.
+		Select(
+			"p.rule_x",
+			"p.rule_y",
+			"count() as cases",
+			"count() filter (where o.case_status = 'closed' and o.outcome = 'confirmed_risk') as confirmed_risks",
+			"count() filter (where o.case_status = 'closed' and o.outcome = 'valuable_alert') as valuable_alerts",
+			"count() filter (where o.case_status = 'closed' and o.outcome = 'false_positive') as false_positives",
+			"median(epoch(o.created_at - o.case_created_at)) filter (where o.case_status = 'closed') / 3600 "+
+				"as median_handling_hours",
+		).
+		PrefixExpr(pairsCte().With("outcomes", uc.caseOutcomesQuery(scenario))).
+		From("pairs p").
+		InnerJoin("outcomes o on o.decision_id = p.decision_id").
+		GroupBy("p.rule_x", "p.rule_y")
+
+	outcomes, err := repositories.AnalyticsScanStruct[analytics.RulePairCaseOutcomes](ctx, exec, outcomesQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return analytics.NewRulePairPrecisions(hits, outcomes), nil
+}
+
+// ruleHitsQuery selects the rule hits matching the filters.
+func (uc AnalyticsQueryUsecase) ruleHitsQuery(scenario models.Scenario,
+	filters dto.AnalyticsQueryFilters,
+) (squirrel.SelectBuilder, error) {
+	query := squirrel.
+		Select(
+			"dr.decision_id",
+			"dr.stable_rule_id as rule_id",
+			"dr.rule_name",
+		).
+		From(uc.analyticsFactory.BuildTarget("decision_rules", scenario.OrganizationId, scenario.TriggerObjectType, "dr")).
+		Where("dr.created_at between ? and ?", filters.Start, filters.End).
+		Where("dr.outcome = 'hit' and dr.stable_rule_id is not null")
+
+	return uc.analyticsFactory.ApplyFilters(query, scenario, filters, "dr")
+}
+
+// caseOutcomesQuery selects the latest state of the case of each decision of the scenario, closed or
+// not. It is not filtered on the queried period: cases are often closed after it.
+func (uc AnalyticsQueryUsecase) caseOutcomesQuery(scenario models.Scenario,
+) func(b squirrel.StatementBuilderType) squirrel.SelectBuilder {
+	return func(b squirrel.StatementBuilderType) squirrel.SelectBuilder {
+		return b.
+			Select("decision_id", "case_status", "outcome", "case_created_at", "created_at").
+			From(uc.analyticsFactory.BuildTarget("decision_case_outcomes",
+				scenario.OrganizationId, scenario.TriggerObjectType, "o")).
+			Where("o.scenario_id = ?", scenario.Id).
+			Suffix("qualify row_number() over (partition by decision_id order by created_at desc) = 1")
+	}
+}
+
 func (uc AnalyticsQueryUsecase) ScreeningHits(ctx context.Context,
 	filters dto.AnalyticsQueryFilters,
 ) ([]analytics.ScreeningHits, error) {
@@ -2,6 +2,7 @@ package evaluate
 
 import (
 	"context"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 
@@ -9,12 +10,36 @@ import (
 	"github.com/checkmarble/marble-backend/pure_utils"
 )
 
+// NameNormalizerLoader returns the name normalizer of the organization the evaluation runs for.
+type NameNormalizerLoader func(ctx context.Context) (pure_utils.NameNormalizer, error)
+
+// NewCachedNameNormalizerLoader wraps a loader so that it is called at most once.
+func NewCachedNameNormalizerLoader(loader NameNormalizerLoader) NameNormalizerLoader {
+	var (
+		once       sync.Once
+		normalizer pure_utils.NameNormalizer
+		err        error
+	)
+
+	return func(ctx context.Context) (pure_utils.NameNormalizer, error) {
+		once.Do(func() {
+			normalizer, err = loader(ctx)
+		})
+		return normalizer, err
+	}
+}
+
 // List of string cleaning steps applied:
 // - normalize
 // - remove diacritics
 // - set to lower case
 // - only letters and numbers
-type FuzzyMatch struct{}
+//
+// If the optional "normalize" named argument is true, both sides are first normalized as
+// screening queries are (transliteration, honorifics, legal forms and organization stoplist).
+type FuzzyMatch struct {
+	NameNormalizer NameNormalizerLoader
+}
 
 func (fuzzyMatcher FuzzyMatch) Evaluate(ctx context.Context, arguments ast.Arguments) (any, []error) {
 	leftAny, rightAny, err := leftAndRight(arguments.Args)
@@ -43,10 +68,17 @@ func (fuzzyMatcher FuzzyMatch) Evaluate(ctx context.Context, arguments ast.Argum
 		return MakeEvaluateError(err)
 	}
 
-	return f(left, right), nil
+	normalize, err := nameNormalizationFunc(ctx, arguments.NamedArgs, fuzzyMatcher.NameNormalizer)
+	if err != nil {
+		return MakeEvaluateError(err)
+	}
+
+	return f(normalize(left), normalize(right)), nil
 }
 
-type FuzzyMatchAnyOf struct{}
+type FuzzyMatchAnyOf struct {
+	NameNormalizer NameNormalizerLoader
+}
 
 func (fuzzyMatcher FuzzyMatchAnyOf) Evaluate(ctx context.Context, arguments ast.Arguments) (any, []error) {
 	leftAny, rightAny, err := leftAndRight(arguments.Args)
@@ -71,9 +103,15 @@ func (fuzzyMatcher FuzzyMatchAnyOf) Evaluate(ctx context.Context, arguments ast.
 		return MakeEvaluateError(err)
 	}
 
+	normalize, err := nameNormalizationFunc(ctx, arguments.NamedArgs, fuzzyMatcher.NameNormalizer)
+	if err != nil {
+		return MakeEvaluateError(err)
+	}
+
+	left = normalize(left)
 	maxScore := 0
 	for _, rVal := range right {
-		maxScore = max(maxScore, f(left, rVal))
+		maxScore = max(maxScore, f(left, normalize(rVal)))
 		if maxScore == 100 {
 			break
 		}
@@ -81,6 +119,34 @@ func (fuzzyMatcher FuzzyMatchAnyOf) Evaluate(ctx context.Context, arguments ast.
 	return maxScore, nil
 }
 
+// nameNormalizationFunc returns the identity unless the "normalize" named argument is set to true.
+// Without a loader (pure evaluation environment), the default normalizer without stoplist is used.
+func nameNormalizationFunc(ctx context.Context, namedArgs map[string]any,
+	loader NameNormalizerLoader,
+) (func(string) string, error) {
+	identity := func(s string) string { return s }
+
+	if _, ok := namedArgs["normalize"]; !ok {
+		return identity, nil
+	}
+	enabled, err := AdaptNamedArgument(namedArgs, "normalize", adaptArgumentToBool)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return identity, nil
+	}
+
+	normalizer := pure_utils.NewNameNormalizer(nil)
+	if loader != nil {
+		if normalizer, err = loader(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not load name normalizer")
+		}
+	}
+
+	return normalizer.Normalize, nil
+}
+
 func getSimilarityAlgo(s string) (func(s1 string, s2 string) int, error) {
 	var f func(s1 string, s2 string) int
 
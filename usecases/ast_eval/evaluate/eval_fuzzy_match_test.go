@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -125,3 +126,32 @@ func TestFuzzyMatchAnyOf(t *testing.T) {
 		})
 	}
 }
+
+func TestFuzzyMatchNormalize(t *testing.T) {
+	args := []any{"ООО Ромашка", "Romashka LLC"}
+
+	r, errs := FuzzyMatch{}.Evaluate(context.TODO(), ast.Arguments{
+		Args:      args,
+		NamedArgs: map[string]any{"algorithm": "ratio"},
+	})
+	assert.Empty(t, errs)
+	assert.Less(t, r, 50)
+
+	r, errs = FuzzyMatch{}.Evaluate(context.TODO(), ast.Arguments{
+		Args:      args,
+		NamedArgs: map[string]any{"algorithm": "ratio", "normalize": true},
+	})
+	assert.Empty(t, errs)
+	assert.Equal(t, 100, r)
+
+	loader := func(ctx context.Context) (pure_utils.NameNormalizer, error) {
+		return pure_utils.NewNameNormalizer([]string{"trading"}), nil
+	}
+
+	r, errs = FuzzyMatchAnyOf{NameNormalizer: loader}.Evaluate(context.TODO(), ast.Arguments{
+		Args:      []any{"Acme Trading GmbH", []string{"Globex", "ACME Ltd"}},
+		NamedArgs: map[string]any{"algorithm": "ratio", "normalize": true},
+	})
+	assert.Empty(t, errs)
+	assert.Equal(t, 100, r)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -22,6 +23,8 @@ type AggregatorEvaluator struct {
 	ExecutorFactory            executor_factory.ExecutorFactory
 	IngestedDataReadRepository repositories.IngestedDataReadRepository
 	ReturnFakeValue            bool
+	// AsOf, if set, aggregates over the versions of the rows valid at that time
+	AsOf *time.Time
 }
 
 var ValidTypesForAggregator = map[ast.Aggregator][]models.DataType{
@@ -172,7 +175,7 @@ func (a AggregatorEvaluator) runQueryInRepository(
 		return nil, err
 	}
 	return a.IngestedDataReadRepository.QueryAggregatedValue(ctx, db, tableName,
-		fieldName, fieldType, aggregator, filters, options)
+		fieldName, fieldType, aggregator, filters, options, a.AsOf)
 }
 
 func (a AggregatorEvaluator) defaultValueForAggregator(aggregator ast.Aggregator) (any, []error) {
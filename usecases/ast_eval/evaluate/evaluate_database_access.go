@@ -2,6 +2,7 @@ package evaluate
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -20,6 +21,8 @@ type DatabaseAccess struct {
 	ExecutorFactory            executor_factory.ExecutorFactory
 	IngestedDataReadRepository repositories.IngestedDataReadRepository
 	ReturnFakeValue            bool
+	// AsOf, if set, reads the versions of the rows valid at that time
+	AsOf *time.Time
 }
 
 func (d DatabaseAccess) Evaluate(ctx context.Context, arguments ast.Arguments) (any, []error) {
@@ -81,5 +84,6 @@ func (d DatabaseAccess) getDbField(ctx context.Context, tableName string,
 		FieldName:        fieldName,
 		DataModel:        d.DataModel,
 		ClientObject:     d.ClientObject,
+		AsOf:             d.AsOf,
 	})
 }
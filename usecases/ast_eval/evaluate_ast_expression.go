@@ -2,6 +2,7 @@ package ast_eval
 
 import (
 	"context"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -21,12 +22,14 @@ func (evaluator EvaluateAstExpression) EvaluateAstExpression(
 	organizationId uuid.UUID,
 	payload models.ClientObject,
 	dataModel models.DataModel,
+	asOf *time.Time,
 ) (ast.NodeEvaluation, error) {
 	environment := evaluator.AstEvaluationEnvironmentFactory(EvaluationEnvironmentFactoryParams{
 		OrganizationId:                organizationId,
 		ClientObject:                  payload,
 		DataModel:                     dataModel,
 		DatabaseAccessReturnFakeValue: false,
+		DatabaseAccessAsOf:            asOf,
 	})
 
 	evaluation, ok := EvaluateAst(ctx, cache, environment, ruleAstExpression)
@@ -22,6 +22,15 @@ func (environment *AstEvaluationEnvironment) AddEvaluator(function ast.Function,
 	environment.availableFunctions[function] = evaluator
 }
 
+// ReplaceEvaluator swaps the evaluator of an already registered function, for example to give
+// a pure function access to organization settings.
+func (environment *AstEvaluationEnvironment) ReplaceEvaluator(function ast.Function, evaluator evaluate.Evaluator) {
+	if _, ok := environment.availableFunctions[function]; !ok {
+		panic(fmt.Sprintf("function '%s' is not registered", function.DebugString()))
+	}
+	environment.availableFunctions[function] = evaluator
+}
+
 func (environment *AstEvaluationEnvironment) GetEvaluator(function ast.Function) (evaluate.Evaluator, error) {
 	if funcClass, ok := environment.availableFunctions[function]; ok {
 		return funcClass, nil
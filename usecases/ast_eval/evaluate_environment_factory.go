@@ -1,6 +1,8 @@
 package ast_eval
 
 import (
+	"time"
+
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/google/uuid"
 )
@@ -10,6 +12,9 @@ type EvaluationEnvironmentFactoryParams struct {
 	ClientObject                  models.ClientObject
 	DataModel                     models.DataModel
 	DatabaseAccessReturnFakeValue bool
+	// DatabaseAccessAsOf, if set, makes database reads and aggregates see the data as it was at
+	// that time instead of the live data.
+	DatabaseAccessAsOf *time.Time
 }
 
 type AstEvaluationEnvironmentFactory func(params EvaluationEnvironmentFactoryParams) AstEvaluationEnvironment
@@ -172,6 +172,31 @@ func (usecase *AsyncDecisionExecutionUsecase) CreateAsyncDecisionExecution(
 	return executions, nil
 }
 
+// CreateIngestionAsyncDecisionExecutions creates and enqueues the executions of the scenarios that
+// evaluate objects when they are ingested. The caller already checked the ingestion permission, and
+// the executions whose deduplication key was already used are skipped.
+func (usecase *AsyncDecisionExecutionUsecase) CreateIngestionAsyncDecisionExecutions(
+	ctx context.Context,
+	orgId uuid.UUID,
+	inputs []models.AsyncDecisionExecutionCreate,
+) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	return usecase.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		executionIds, err := usecase.asyncDecisionExecutionRepository.CreateDeduplicatedAsyncDecisionExecutions(
+			ctx, tx, inputs)
+		if err != nil {
+			return errors.Wrap(err, "error creating async decision executions on ingestion")
+		}
+
+		return errors.Wrap(
+			usecase.taskQueueRepository.EnqueueAsyncDecisionExecutions(ctx, tx, orgId, executionIds),
+			"error enqueuing async decision executions on ingestion")
+	})
+}
+
 func (usecase *AsyncDecisionExecutionUsecase) GetAsyncDecisionExecution(
 	ctx context.Context,
 	executionId uuid.UUID,
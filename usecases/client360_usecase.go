@@ -111,7 +111,7 @@ func (uc Client360Usecase) SearchObject(ctx context.Context, input models.Client
 	offset := OBJECTS_PER_PAGE * (input.Page - 1)
 
 	objects, err := uc.ingestedDataRepository.SearchObjects(ctx, clientDbExec, table,
-		table.CaptionField, input.Terms, pageSize, offset)
+		table.CaptionField, input.Terms, pageSize, offset, input.AsOf)
 	if err != nil {
 		return nil, err
 	}
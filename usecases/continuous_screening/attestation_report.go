@@ -0,0 +1,132 @@
+package continuous_screening
+
+import (
+	"context"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+type ContinuousScreeningAttestationReportRepository interface {
+	ListContinuousScreeningAttestationReports(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		pagination models.PaginationAndSorting,
+	) ([]models.ContinuousScreeningAttestationReport, error)
+	GetContinuousScreeningAttestationReport(
+		ctx context.Context,
+		exec repositories.Executor,
+		id uuid.UUID,
+	) (models.ContinuousScreeningAttestationReport, error)
+	CreateContinuousScreeningAttestationReportDownload(
+		ctx context.Context,
+		exec repositories.Executor,
+		input models.CreateContinuousScreeningAttestationReportDownload,
+	) error
+}
+
+type ContinuousScreeningAttestationReportUsecase struct {
+	executorFactory     executor_factory.ExecutorFactory
+	enforceSecurity     security.EnforceSecurityContinuousScreening
+	repository          ContinuousScreeningAttestationReportRepository
+	featureAccessReader featureAccessReader
+	blobRepository      repositories.BlobRepository
+	bucketUrl           string
+}
+
+func NewContinuousScreeningAttestationReportUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	enforceSecurity security.EnforceSecurityContinuousScreening,
+	repository ContinuousScreeningAttestationReportRepository,
+	featureAccessReader featureAccessReader,
+	blobRepository repositories.BlobRepository,
+	bucketUrl string,
+) *ContinuousScreeningAttestationReportUsecase {
+	return &ContinuousScreeningAttestationReportUsecase{
+		executorFactory:     executorFactory,
+		enforceSecurity:     enforceSecurity,
+		repository:          repository,
+		featureAccessReader: featureAccessReader,
+		blobRepository:      blobRepository,
+		bucketUrl:           bucketUrl,
+	}
+}
+
+func (uc *ContinuousScreeningAttestationReportUsecase) checkAccess(ctx context.Context, orgId uuid.UUID) error {
+	features, err := uc.featureAccessReader.GetOrganizationFeatureAccess(ctx, orgId, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not check feature access")
+	}
+	if !features.ContinuousScreening.IsAllowed() {
+		return errors.Wrap(models.ForbiddenError, "continuous screening feature is not allowed")
+	}
+
+	return uc.enforceSecurity.ReadContinuousScreeningObject(orgId)
+}
+
+func (uc *ContinuousScreeningAttestationReportUsecase) ListAttestationReports(
+	ctx context.Context,
+	orgId uuid.UUID,
+	pagination models.PaginationAndSorting,
+) (models.Paginated[models.ContinuousScreeningAttestationReport], error) {
+	if err := uc.checkAccess(ctx, orgId); err != nil {
+		return models.Paginated[models.ContinuousScreeningAttestationReport]{}, err
+	}
+	if err := models.ValidatePagination(pagination); err != nil {
+		return models.Paginated[models.ContinuousScreeningAttestationReport]{}, err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+
+	reports, err := listContinuousScreeningPage(
+		pagination,
+		func(pagination models.PaginationAndSorting) ([]models.ContinuousScreeningAttestationReport, error) {
+			return uc.repository.ListContinuousScreeningAttestationReports(ctx, exec, orgId, pagination)
+		},
+	)
+	if err != nil {
+		return models.Paginated[models.ContinuousScreeningAttestationReport]{},
+			errors.Wrap(err, "failed to list continuous screening attestation reports")
+	}
+
+	return reports, nil
+}
+
+// GetAttestationReportDownloadUrl returns a signed URL to download a report file. Each download is
+// recorded, which leaves a trace of who accessed the report in the audit trail.
+func (uc *ContinuousScreeningAttestationReportUsecase) GetAttestationReportDownloadUrl(
+	ctx context.Context,
+	orgId uuid.UUID,
+	reportId uuid.UUID,
+	format models.ContinuousScreeningAttestationReportFormat,
+) (string, error) {
+	if err := uc.checkAccess(ctx, orgId); err != nil {
+		return "", err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+
+	report, err := uc.repository.GetContinuousScreeningAttestationReport(ctx, exec, reportId)
+	if err != nil {
+		return "", err
+	}
+	if report.OrgId != orgId {
+		return "", errors.Wrap(models.NotFoundError, "attestation report not found")
+	}
+
+	if err := uc.repository.CreateContinuousScreeningAttestationReportDownload(ctx, exec,
+		models.CreateContinuousScreeningAttestationReportDownload{
+			OrgId:    orgId,
+			ReportId: report.Id,
+			Format:   format,
+		}); err != nil {
+		return "", errors.Wrap(err, "failed to record attestation report download")
+	}
+
+	return uc.blobRepository.GenerateSignedUrl(ctx, uc.bucketUrl, report.FilePath(format))
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
 )
@@ -156,3 +157,32 @@ func buildCaseName(
 
 	return objectId, nil
 }
+
+// whitelistedCounterparties returns the counterparties excluded from a dataset-triggered screening
+// (OpenSanctions → Marble direction) run by the given config. In that direction the screened
+// objects are the counterparties themselves, so an entry scoped to an object applies when that
+// object is its counterparty.
+func whitelistedCounterparties(
+	whitelists []models.ScreeningWhitelist,
+	configStableId uuid.UUID,
+	now time.Time,
+) []string {
+	counterpartyIds := make([]string, 0, len(whitelists))
+
+	for _, whitelist := range whitelists {
+		scope := models.ScreeningWhitelistScope{ScreeningConfigStableId: &configStableId}
+
+		if whitelist.Scope.ObjectType != nil && whitelist.Scope.ObjectId != nil &&
+			pure_utils.MarbleEntityIdBuilder(*whitelist.Scope.ObjectType,
+				*whitelist.Scope.ObjectId) == whitelist.CounterpartyId {
+			scope.ObjectType = whitelist.Scope.ObjectType
+			scope.ObjectId = whitelist.Scope.ObjectId
+		}
+
+		if whitelist.AppliesTo(scope, now) {
+			counterpartyIds = append(counterpartyIds, whitelist.CounterpartyId)
+		}
+	}
+
+	return counterpartyIds
+}
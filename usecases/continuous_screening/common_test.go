@@ -2,8 +2,11 @@ package continuous_screening
 
 import (
 	"testing"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -261,3 +264,35 @@ func TestBuildCaseName(t *testing.T) {
 		})
 	}
 }
+
+func TestWhitelistedCounterparties(t *testing.T) {
+	now := time.Now()
+	configId := uuid.New()
+	otherConfigId := uuid.New()
+	objectType, objectId, otherObjectId := "customers", "c-1", "c-2"
+
+	whitelists := []models.ScreeningWhitelist{
+		{CounterpartyId: "unscoped"},
+		{CounterpartyId: "expired", ExpiresAt: utils.Ptr(now.Add(-time.Minute))},
+		{
+			CounterpartyId: "same-config",
+			Scope:          models.ScreeningWhitelistScope{ScreeningConfigStableId: &configId},
+		},
+		{
+			CounterpartyId: "other-config",
+			Scope:          models.ScreeningWhitelistScope{ScreeningConfigStableId: &otherConfigId},
+		},
+		{
+			CounterpartyId: "marble_customers_c-1",
+			Scope:          models.ScreeningWhitelistScope{ObjectType: &objectType, ObjectId: &objectId},
+		},
+		{
+			CounterpartyId: "marble_customers_c-1",
+			Scope:          models.ScreeningWhitelistScope{ObjectType: &objectType, ObjectId: &otherObjectId},
+		},
+	}
+
+	assert.Equal(t,
+		[]string{"unscoped", "same-config", "marble_customers_c-1"},
+		whitelistedCounterparties(whitelists, configId, now))
+}
@@ -0,0 +1,300 @@
+package continuous_screening
+
+import (
+	"context"
+	"slices"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const coverageBackfillBatchSize = 1000
+
+type ContinuousScreeningCoverageAnalysisRepository interface {
+	GetOrganizationById(ctx context.Context, exec repositories.Executor, organizationId uuid.UUID) (models.Organization, error)
+	GetContinuousScreeningConfigsByOrgId(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		provider models.ScreeningProvider,
+	) ([]models.ContinuousScreeningConfig, error)
+	GetContinuousScreeningCoverageAnalysis(
+		ctx context.Context,
+		exec repositories.Executor,
+		id uuid.UUID,
+	) (models.ContinuousScreeningCoverageAnalysis, error)
+	ListContinuousScreeningCoverageAnalyses(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		pagination models.PaginationAndSorting,
+	) ([]models.ContinuousScreeningCoverageAnalysis, error)
+	ListContinuousScreeningCoverageGaps(
+		ctx context.Context,
+		exec repositories.Executor,
+		analysisId uuid.UUID,
+		filters models.ContinuousScreeningCoverageGapFilters,
+		pagination models.PaginationAndSorting,
+	) ([]models.ContinuousScreeningCoverageGap, error)
+	MarkContinuousScreeningCoverageAnalysisBackfilled(
+		ctx context.Context,
+		exec repositories.Executor,
+		id uuid.UUID,
+	) error
+}
+
+type coverageAnalysisTaskQueueRepository interface {
+	EnqueueContinuousScreeningCoverageAnalysisTask(
+		ctx context.Context,
+		tx repositories.Transaction,
+		orgId uuid.UUID,
+	) error
+	EnqueueContinuousScreeningRegisterObjectTaskMany(
+		ctx context.Context,
+		tx repositories.Transaction,
+		orgId uuid.UUID,
+		objectType string,
+		tasks []models.ContinuousScreeningRegisterObjectTask,
+		shouldScreen bool,
+	) error
+}
+
+type ContinuousScreeningCoverageAnalysisUsecase struct {
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	enforceSecurity     security.EnforceSecurityContinuousScreening
+	repository          ContinuousScreeningCoverageAnalysisRepository
+	taskQueueRepository coverageAnalysisTaskQueueRepository
+	featureAccessReader featureAccessReader
+}
+
+func NewContinuousScreeningCoverageAnalysisUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityContinuousScreening,
+	repository ContinuousScreeningCoverageAnalysisRepository,
+	taskQueueRepository coverageAnalysisTaskQueueRepository,
+	featureAccessReader featureAccessReader,
+) *ContinuousScreeningCoverageAnalysisUsecase {
+	return &ContinuousScreeningCoverageAnalysisUsecase{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		enforceSecurity:     enforceSecurity,
+		repository:          repository,
+		taskQueueRepository: taskQueueRepository,
+		featureAccessReader: featureAccessReader,
+	}
+}
+
+func (uc *ContinuousScreeningCoverageAnalysisUsecase) checkFeatureAccess(ctx context.Context, orgId uuid.UUID) error {
+	features, err := uc.featureAccessReader.GetOrganizationFeatureAccess(ctx, orgId, nil)
+	if err != nil {
+		return errors.Wrap(err, "could not check feature access")
+	}
+	if !features.ContinuousScreening.IsAllowed() {
+		return errors.Wrap(models.ForbiddenError, "continuous screening feature is not allowed")
+	}
+	return nil
+}
+
+func (uc *ContinuousScreeningCoverageAnalysisUsecase) getAnalysis(
+	ctx context.Context,
+	exec repositories.Executor,
+	orgId uuid.UUID,
+	analysisId uuid.UUID,
+) (models.ContinuousScreeningCoverageAnalysis, error) {
+	analysis, err := uc.repository.GetContinuousScreeningCoverageAnalysis(ctx, exec, analysisId)
+	if err != nil {
+		return models.ContinuousScreeningCoverageAnalysis{}, err
+	}
+	if analysis.OrgId != orgId {
+		return models.ContinuousScreeningCoverageAnalysis{},
+			errors.Wrap(models.NotFoundError, "coverage analysis not found")
+	}
+	return analysis, nil
+}
+
+func (uc *ContinuousScreeningCoverageAnalysisUsecase) ListCoverageAnalyses(
+	ctx context.Context,
+	orgId uuid.UUID,
+	pagination models.PaginationAndSorting,
+) (models.Paginated[models.ContinuousScreeningCoverageAnalysis], error) {
+	if err := uc.checkFeatureAccess(ctx, orgId); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageAnalysis]{}, err
+	}
+	if err := uc.enforceSecurity.ReadContinuousScreeningObject(orgId); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageAnalysis]{}, err
+	}
+	if err := models.ValidatePagination(pagination); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageAnalysis]{}, err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+
+	analyses, err := listContinuousScreeningPage(
+		pagination,
+		func(pagination models.PaginationAndSorting) ([]models.ContinuousScreeningCoverageAnalysis, error) {
+			return uc.repository.ListContinuousScreeningCoverageAnalyses(ctx, exec, orgId, pagination)
+		},
+	)
+	if err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageAnalysis]{},
+			errors.Wrap(err, "failed to list continuous screening coverage analyses")
+	}
+
+	return analyses, nil
+}
+
+func (uc *ContinuousScreeningCoverageAnalysisUsecase) ListCoverageGaps(
+	ctx context.Context,
+	orgId uuid.UUID,
+	analysisId uuid.UUID,
+	filters models.ContinuousScreeningCoverageGapFilters,
+	pagination models.PaginationAndSorting,
+) (models.Paginated[models.ContinuousScreeningCoverageGap], error) {
+	if err := uc.checkFeatureAccess(ctx, orgId); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageGap]{}, err
+	}
+	if err := uc.enforceSecurity.ReadContinuousScreeningObject(orgId); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageGap]{}, err
+	}
+	if err := models.ValidatePagination(pagination); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageGap]{}, err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+
+	if _, err := uc.getAnalysis(ctx, exec, orgId, analysisId); err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageGap]{}, err
+	}
+
+	gaps, err := listContinuousScreeningPage(
+		pagination,
+		func(pagination models.PaginationAndSorting) ([]models.ContinuousScreeningCoverageGap, error) {
+			return uc.repository.ListContinuousScreeningCoverageGaps(ctx, exec, analysisId, filters, pagination)
+		},
+	)
+	if err != nil {
+		return models.Paginated[models.ContinuousScreeningCoverageGap]{},
+			errors.Wrap(err, "failed to list continuous screening coverage gaps")
+	}
+
+	return gaps, nil
+}
+
+// RequestCoverageAnalysis enqueues a coverage analysis for the org, on top of the daily one.
+func (uc *ContinuousScreeningCoverageAnalysisUsecase) RequestCoverageAnalysis(ctx context.Context, orgId uuid.UUID) error {
+	if err := uc.checkFeatureAccess(ctx, orgId); err != nil {
+		return err
+	}
+	if err := uc.enforceSecurity.WriteContinuousScreeningObject(orgId); err != nil {
+		return err
+	}
+
+	return uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		return uc.taskQueueRepository.EnqueueContinuousScreeningCoverageAnalysisTask(ctx, tx, orgId)
+	})
+}
+
+// BackfillCoverageAnalysis registers the unmonitored objects found by an analysis, by enqueuing
+// the same registration tasks as an ingestion with monitoring enabled. Each object is registered
+// in the requested config, or in the only config covering its object type. Objects whose type is
+// not covered by the requested config, or covered by several configs when none is requested, are
+// skipped.
+func (uc *ContinuousScreeningCoverageAnalysisUsecase) BackfillCoverageAnalysis(
+	ctx context.Context,
+	orgId uuid.UUID,
+	analysisId uuid.UUID,
+	input models.ContinuousScreeningCoverageBackfill,
+) (models.ContinuousScreeningCoverageBackfillResult, error) {
+	if err := uc.checkFeatureAccess(ctx, orgId); err != nil {
+		return models.ContinuousScreeningCoverageBackfillResult{}, err
+	}
+	if err := uc.enforceSecurity.WriteContinuousScreeningObject(orgId); err != nil {
+		return models.ContinuousScreeningCoverageBackfillResult{}, err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+
+	if _, err := uc.getAnalysis(ctx, exec, orgId, analysisId); err != nil {
+		return models.ContinuousScreeningCoverageBackfillResult{}, err
+	}
+
+	org, err := uc.repository.GetOrganizationById(ctx, exec, orgId)
+	if err != nil {
+		return models.ContinuousScreeningCoverageBackfillResult{}, err
+	}
+	configs, err := uc.repository.GetContinuousScreeningConfigsByOrgId(ctx, exec, orgId,
+		org.GetScreeningProviderFor(models.ScreeningFeatureContinuousMonitoring))
+	if err != nil {
+		return models.ContinuousScreeningCoverageBackfillResult{}, err
+	}
+	if input.ConfigStableId != nil && !slices.ContainsFunc(configs, func(config models.ContinuousScreeningConfig) bool {
+		return config.StableId == *input.ConfigStableId
+	}) {
+		return models.ContinuousScreeningCoverageBackfillResult{},
+			errors.Wrap(models.BadParameterError, "continuous screening config not found or disabled")
+	}
+	configStableIdsByObjectType := models.ContinuousScreeningConfigStableIdsByObjectType(configs)
+
+	gapType := models.ContinuousScreeningCoverageGapTypeUnmonitored
+	filters := models.ContinuousScreeningCoverageGapFilters{GapType: &gapType}
+	pagination := models.PaginationAndSorting{
+		Sorting: models.SortingFieldCreatedAt,
+		Order:   models.SortingOrderAsc,
+		Limit:   coverageBackfillBatchSize,
+	}
+
+	var result models.ContinuousScreeningCoverageBackfillResult
+	tasksByObjectType := make(map[string][]models.ContinuousScreeningRegisterObjectTask)
+	for {
+		gaps, err := uc.repository.ListContinuousScreeningCoverageGaps(ctx, exec, analysisId, filters, pagination)
+		if err != nil {
+			return models.ContinuousScreeningCoverageBackfillResult{}, err
+		}
+
+		for _, gap := range gaps {
+			configStableId, ok := models.ContinuousScreeningCoverageBackfillConfig(
+				configStableIdsByObjectType[gap.ObjectType], input.ConfigStableId)
+			if !ok || gap.ObjectInternalId == nil {
+				result.SkippedCount++
+				continue
+			}
+			tasksByObjectType[gap.ObjectType] = append(tasksByObjectType[gap.ObjectType],
+				models.ContinuousScreeningRegisterObjectTask{
+					ObjectId:       gap.ObjectId,
+					ConfigStableId: configStableId,
+					NewInternalId:  gap.ObjectInternalId.String(),
+					UserId:         uc.enforceSecurity.UserId(),
+					ApiKeyId:       uc.enforceSecurity.ApiKeyId(),
+				})
+			result.EnqueuedCount++
+		}
+
+		if len(gaps) < coverageBackfillBatchSize {
+			break
+		}
+		pagination.OffsetId = gaps[len(gaps)-1].Id.String()
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		for objectType, tasks := range tasksByObjectType {
+			if err := uc.taskQueueRepository.EnqueueContinuousScreeningRegisterObjectTaskMany(
+				ctx, tx, orgId, objectType, tasks, input.ShouldScreen,
+			); err != nil {
+				return err
+			}
+		}
+		return uc.repository.MarkContinuousScreeningCoverageAnalysisBackfilled(ctx, tx, analysisId)
+	})
+	if err != nil {
+		return models.ContinuousScreeningCoverageBackfillResult{},
+			errors.Wrap(err, "failed to enqueue coverage backfill")
+	}
+
+	return result, nil
+}
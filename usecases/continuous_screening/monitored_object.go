@@ -279,12 +279,27 @@ func prepareScreeningFilters(
 	return filters, nil
 }
 
+// continuousScreeningOrgConfig uses the match threshold and limit of the continuous screening
+// configuration, and the name normalization settings of the organization.
+func continuousScreeningOrgConfig(
+	orgConfig models.OrganizationOpenSanctionsConfig,
+	matchThreshold, matchLimit int,
+) models.OrganizationOpenSanctionsConfig {
+	return models.OrganizationOpenSanctionsConfig{
+		MatchThreshold:    matchThreshold,
+		MatchLimit:        matchLimit,
+		NameNormalization: orgConfig.NameNormalization,
+		NameStoplist:      orgConfig.NameStoplist,
+	}
+}
+
 // Build the OpenSanctions Query
 func prepareOpenSanctionsQuery(
 	ingestedObject models.DataModelObject,
 	dataModelEntityType string,
 	dataModelMapping map[string]string,
 	config models.ContinuousScreeningConfig,
+	orgConfig models.OrganizationOpenSanctionsConfig,
 	whitelistedEntityIds []string,
 ) (models.OpenSanctionsQuery, error) {
 	screeningFilters, err := prepareScreeningFilters(ingestedObject, dataModelMapping)
@@ -293,10 +308,7 @@ func prepareOpenSanctionsQuery(
 	}
 
 	return models.OpenSanctionsQuery{
-		OrgConfig: models.OrganizationOpenSanctionsConfig{
-			MatchThreshold: config.MatchThreshold,
-			MatchLimit:     config.MatchLimit,
-		},
+		OrgConfig: continuousScreeningOrgConfig(orgConfig, config.MatchThreshold, config.MatchLimit),
 		Config: models.ScreeningConfig{
 			Datasets: config.Datasets,
 			Filters:  config.Filters,
@@ -447,11 +459,19 @@ func (uc *ContinuousScreeningUsecase) DoScreening(
 	if err != nil {
 		return models.ScreeningWithMatches{}, err
 	}
+	whitelists = models.FilterApplicableScreeningWhitelists(whitelists,
+		models.NewScreeningWhitelistScope(config.StableId.String(), objectType, objectId), time.Now())
 	whitelistEntityIds := pure_utils.Map(whitelists, func(whitelist models.ScreeningWhitelist) string {
 		return whitelist.EntityId
 	})
 
-	query, err := prepareOpenSanctionsQuery(ingestedObject, mapping.Entity, mapping.Properties, config, whitelistEntityIds)
+	org, err := uc.repository.GetOrganizationById(ctx, exec, config.OrgId)
+	if err != nil {
+		return models.ScreeningWithMatches{}, errors.Wrap(err, "could not retrieve organization")
+	}
+
+	query, err := prepareOpenSanctionsQuery(ingestedObject, mapping.Entity, mapping.Properties, config,
+		org.OpenSanctionsConfig, whitelistEntityIds)
 	if err != nil {
 		return models.ScreeningWithMatches{}, err
 	}
@@ -479,17 +499,16 @@ func (uc *ContinuousScreeningUsecase) DoScreeningForEntity(
 	if err != nil {
 		return models.ScreeningWithMatches{}, err
 	}
-	whitelistedEntityIds := make([]string, len(whitelists))
-	for i, whitelist := range whitelists {
-		whitelistedEntityIds[i] = whitelist.CounterpartyId
+	whitelistedEntityIds := whitelistedCounterparties(whitelists, config.StableId, time.Now())
+
+	org, err := uc.repository.GetOrganizationById(ctx, exec, orgId)
+	if err != nil {
+		return models.ScreeningWithMatches{}, errors.Wrap(err, "could not retrieve organization")
 	}
 
 	// Create the OpenSanction query to search Marble's custom dataset
 	query := models.OpenSanctionsQuery{
-		OrgConfig: models.OrganizationOpenSanctionsConfig{
-			MatchThreshold: config.MatchThreshold,
-			MatchLimit:     config.MatchLimit,
-		},
+		OrgConfig: continuousScreeningOrgConfig(org.OpenSanctionsConfig, config.MatchThreshold, config.MatchLimit),
 		Queries: []models.OpenSanctionsCheckQuery{
 			{
 				Type:    entity.Schema,
@@ -500,11 +519,6 @@ func (uc *ContinuousScreeningUsecase) DoScreeningForEntity(
 		Scope:                orgCustomDatasetName(orgId),
 	}
 
-	org, err := uc.repository.GetOrganizationById(ctx, exec, orgId)
-	if err != nil {
-		return models.ScreeningWithMatches{}, errors.Wrap(err, "could not retrieve organization")
-	}
-
 	return uc.executeScreeningWithRetry(ctx, org.GetScreeningProviderFor(models.ScreeningFeatureContinuousMonitoring), query)
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"slices"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/pure_utils"
@@ -184,6 +185,7 @@ func (uc *ContinuousScreeningUsecase) UpdateContinuousScreeningMatchStatus(
 				continuousScreeningWithMatches,
 				continuousScreeningMatch,
 				reviewerId,
+				update.WhitelistOptions,
 			); err != nil {
 				return err
 			}
@@ -265,15 +267,17 @@ func (uc *ContinuousScreeningUsecase) checkPermissionOnCaseAndContinuousScreenin
 func (uc *ContinuousScreeningUsecase) createWhitelist(
 	ctx context.Context,
 	exec repositories.Executor,
-	orgId uuid.UUID,
-	counterpartyId, entityId string,
-	reviewerId *models.UserId,
+	input models.ScreeningWhitelistCreateInput,
 ) error {
 	if err := uc.enforceSecurityScreening.WriteWhitelist(ctx); err != nil {
 		return err
 	}
 
-	return uc.repository.AddScreeningMatchWhitelist(ctx, exec, orgId, counterpartyId, entityId, reviewerId)
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return errors.WithDetail(models.BadParameterError, "whitelist expiry date must be in the future")
+	}
+
+	return uc.repository.AddScreeningMatchWhitelist(ctx, exec, input)
 }
 
 // Dismiss function can only be called if the continuous screening is in review and in case and by an admin user
@@ -766,9 +770,11 @@ func (uc *ContinuousScreeningUsecase) handleWhitelistCreation(
 	screening models.ContinuousScreeningWithMatches,
 	match models.ContinuousScreeningMatch,
 	reviewerId *models.UserId,
+	options models.ScreeningWhitelistOptions,
 ) error {
 	var counterpartyId string
 	var openSanctionEntityId string
+	var objectType, objectId string
 
 	switch {
 	case screening.IsDatasetTriggered():
@@ -777,6 +783,7 @@ func (uc *ContinuousScreeningUsecase) handleWhitelistCreation(
 		}
 
 		// The counterparty (Marble entity) is the one being screened and saved in the match as OpenSanctionEntityId
+		// The counterparty is the monitored object itself, there is no object scope to add.
 		counterpartyId = match.OpenSanctionEntityId
 		openSanctionEntityId = *screening.OpenSanctionEntityId
 	case screening.IsObjectTriggered():
@@ -789,19 +796,23 @@ func (uc *ContinuousScreeningUsecase) handleWhitelistCreation(
 			*screening.ObjectId,
 		)
 		openSanctionEntityId = match.OpenSanctionEntityId
+		objectType, objectId = *screening.ObjectType, *screening.ObjectId
 	default:
 		// Should not happen
 		return errors.New("unable to determine screening type for whitelist creation")
 	}
 
-	if err := uc.createWhitelist(
-		ctx,
-		tx,
-		screening.OrgId,
-		counterpartyId,
-		openSanctionEntityId,
-		reviewerId,
-	); err != nil {
+	scope := models.NewScreeningWhitelistScope(
+		screening.ContinuousScreeningConfigStableId.String(), objectType, objectId)
+
+	if err := uc.createWhitelist(ctx, tx, models.ScreeningWhitelistCreateInput{
+		OrgId:          screening.OrgId,
+		CounterpartyId: counterpartyId,
+		EntityId:       openSanctionEntityId,
+		Scope:          options.Scope(scope),
+		ExpiresAt:      options.ExpiresAt,
+		WhitelistedBy:  reviewerId,
+	}); err != nil {
 		return errors.Wrap(err, "could not whitelist match")
 	}
 
@@ -656,7 +656,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_NoHit_
 	suite.caseEditor.On("PerformCaseActionSideEffects", mock.Anything, mock.Anything, caseData).Return(nil)
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble_transactions_test-object-id", "test-entity-id-1", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble_transactions_test-object-id",
+			EntityId:       "test-entity-id-1",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
 		attrs models.CreateCaseEventAttributes,
 	) bool {
@@ -719,7 +724,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_NoHit_
 	// Expect whitelist creation on NoHit
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble_transactions_test-object-id", "test-entity-id", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble_transactions_test-object-id",
+			EntityId:       "test-entity-id",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 
 	caseData := models.Case{
 		Id: suite.caseId.String(),
@@ -833,7 +843,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_NoHit_
 	// Whitelist expectations: despite Whitelist=false, we still whitelist
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble-entity-123", "open-sanction-entity-abc", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble-entity-123",
+			EntityId:       "open-sanction-entity-abc",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	suite.repository.On("UpdateContinuousScreeningStatus", mock.Anything, mock.Anything,
 		suite.screeningId, models.ScreeningStatusNoHit).Return(models.ContinuousScreening{}, nil)
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
@@ -913,7 +928,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_NoHit_
 	// Expect whitelist creation on NoHit even if IsPartial
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble_transactions_test-object-id", "test-entity-id", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble_transactions_test-object-id",
+			EntityId:       "test-entity-id",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 
 	caseData := models.Case{
 		Id: suite.caseId.String(),
@@ -1326,6 +1346,9 @@ func (suite *ScreeningTestSuite) TestLoadMoreContinuousScreeningMatches() {
 	suite.ingestedDataReader.On("QueryIngestedObject", mock.Anything, mock.Anything, table, "test-object-id", mock.Anything).
 		Return([]models.DataModelObject{ingestedObject}, nil)
 
+	suite.repository.On("GetOrganizationById", mock.Anything, mock.Anything, suite.orgId).
+		Return(models.Organization{Id: suite.orgId}, nil)
+
 	suite.screeningProvider.On("Search", mock.Anything, mock.Anything, mock.MatchedBy(func(q models.OpenSanctionsQuery) bool {
 		return q.OrgConfig.MatchLimit == 500 && q.Config.Datasets[0] == "dataset1"
 	})).Return(searchResponse, nil)
@@ -1520,6 +1543,9 @@ func (suite *ScreeningTestSuite) TestLoadMoreContinuousScreeningMatches_NoNewMat
 	suite.ingestedDataReader.On("QueryIngestedObject", mock.Anything, mock.Anything, table, "test-object-id", mock.Anything).
 		Return([]models.DataModelObject{ingestedObject}, nil)
 
+	suite.repository.On("GetOrganizationById", mock.Anything, mock.Anything, suite.orgId).
+		Return(models.Organization{Id: suite.orgId}, nil)
+
 	suite.screeningProvider.On("Search", mock.Anything, mock.Anything, mock.MatchedBy(func(q models.OpenSanctionsQuery) bool {
 		return q.OrgConfig.MatchLimit == 500 && q.Config.Datasets[0] == "dataset1"
 	})).Return(searchResponse, nil)
@@ -1794,7 +1820,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_Datase
 	suite.caseEditor.On("PerformCaseActionSideEffects", mock.Anything, mock.Anything, caseData).Return(nil)
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble-entity-123", "open-sanction-entity-abc", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble-entity-123",
+			EntityId:       "open-sanction-entity-abc",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	// No screening status update since there are more pending matches (not last)
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
 		attrs models.CreateCaseEventAttributes,
@@ -1879,7 +1910,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_Datase
 	// Whitelist creation
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble-entity-123", "open-sanction-entity-abc", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble-entity-123",
+			EntityId:       "open-sanction-entity-abc",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	// Events for match update and screening status update
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
 		attrs models.CreateCaseEventAttributes,
@@ -1985,7 +2021,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_Datase
 	// Whitelist creation
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble-entity-123", "open-sanction-entity-abc", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble-entity-123",
+			EntityId:       "open-sanction-entity-abc",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	// Events for match update and screening status update
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
 		attrs models.CreateCaseEventAttributes,
@@ -2155,7 +2196,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_Datase
 	// Whitelist creation for no_hit
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble-entity-123", "open-sanction-entity-abc", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble-entity-123",
+			EntityId:       "open-sanction-entity-abc",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
 		attrs models.CreateCaseEventAttributes,
 	) bool {
@@ -2243,7 +2289,12 @@ func (suite *ScreeningTestSuite) TestUpdateContinuousScreeningMatchStatus_WithCo
 	suite.caseEditor.On("PerformCaseActionSideEffects", mock.Anything, mock.Anything, caseData).Return(nil)
 	suite.enforceSecurity.On("WriteWhitelist", mock.Anything).Return(nil)
 	suite.repository.On("AddScreeningMatchWhitelist", mock.Anything, mock.Anything,
-		suite.orgId, "marble-entity-123", "open-sanction-entity-abc", &suite.userId).Return(nil)
+		models.ScreeningWhitelistCreateInput{
+			OrgId:          suite.orgId,
+			CounterpartyId: "marble-entity-123",
+			EntityId:       "open-sanction-entity-abc",
+			WhitelistedBy:  &suite.userId,
+		}).Return(nil)
 	suite.repository.On("CreateCaseEvent", mock.Anything, mock.Anything, mock.MatchedBy(func(
 		attrs models.CreateCaseEventAttributes,
 	) bool {
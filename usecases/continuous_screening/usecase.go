@@ -172,10 +172,7 @@ type ContinuousScreeningUsecaseRepository interface {
 	AddScreeningMatchWhitelist(
 		ctx context.Context,
 		exec repositories.Executor,
-		orgId uuid.UUID,
-		counterpartyId string,
-		entityId string,
-		reviewerId *models.UserId,
+		input models.ScreeningWhitelistCreateInput,
 	) error
 	SearchScreeningMatchWhitelist(ctx context.Context, exec repositories.Executor,
 		orgId uuid.UUID, counterpartyId, entityId *string,
@@ -45,6 +45,7 @@ var AllowedSchemaTypes = []string{
 	"Vessel",
 	"Airplane",
 	"LegalEntity",
+	"CryptoWallet",
 }
 
 type applyDeltaFileWorkerRepository interface {
@@ -250,6 +251,11 @@ func (w *ApplyDeltaFileWorker) Work(ctx context.Context, job *river.Job[models.C
 		return err
 	}
 
+	org, err := w.repository.GetOrganizationById(ctx, exec, updateJob.OrgId)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve organization")
+	}
+
 	initialOffset, initialItemsProcessed, err := w.getLastIterationOffset(
 		ctx,
 		exec,
@@ -368,7 +374,7 @@ func (w *ApplyDeltaFileWorker) Work(ctx context.Context, job *river.Job[models.C
 			}
 		}
 
-		query, err := w.buildOpenSanctionQuery(iterCtx, exec, updateJob, record)
+		query, err := w.buildOpenSanctionQuery(iterCtx, exec, updateJob, org.OpenSanctionsConfig, record)
 		if err != nil {
 			if hErr := w.handleError(ctx, exec, job, err, true); hErr != nil {
 				return hErr
@@ -539,6 +545,7 @@ func (w *ApplyDeltaFileWorker) buildOpenSanctionQuery(
 	ctx context.Context,
 	exec repositories.Executor,
 	updateJob models.EnrichedContinuousScreeningUpdateJob,
+	orgConfig models.OrganizationOpenSanctionsConfig,
 	record models.OpenSanctionsDeltaFileRecord,
 ) (query models.OpenSanctionsQuery, err error) {
 	// Fetch whitelist entries for the entity and all its referent (previous) IDs.
@@ -554,10 +561,7 @@ func (w *ApplyDeltaFileWorker) buildOpenSanctionQuery(
 	if err != nil {
 		return models.OpenSanctionsQuery{}, err
 	}
-	whitelistedEntityIds := make([]string, len(whitelists))
-	for i, whitelist := range whitelists {
-		whitelistedEntityIds[i] = whitelist.CounterpartyId
-	}
+	whitelistedEntityIds := whitelistedCounterparties(whitelists, updateJob.Config.StableId, time.Now())
 
 	// Create the openSanction query
 	filters := record.Entity.Properties
@@ -569,10 +573,8 @@ func (w *ApplyDeltaFileWorker) buildOpenSanctionQuery(
 	delete(filters, "programId")
 
 	return models.OpenSanctionsQuery{
-		OrgConfig: models.OrganizationOpenSanctionsConfig{
-			MatchThreshold: updateJob.Config.MatchThreshold,
-			MatchLimit:     updateJob.Config.MatchLimit,
-		},
+		OrgConfig: continuousScreeningOrgConfig(orgConfig,
+			updateJob.Config.MatchThreshold, updateJob.Config.MatchLimit),
 		Config: models.ScreeningConfig{
 			Weights: updateJob.Config.Weights,
 		},
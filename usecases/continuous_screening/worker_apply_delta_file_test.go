@@ -150,9 +150,23 @@ func TestBuildOpenSanctionQuery(t *testing.T) {
 		},
 	}
 
-	query, err := worker.buildOpenSanctionQuery(context.Background(), nil, job, record)
+	orgConfig := models.OrganizationOpenSanctionsConfig{
+		MatchThreshold:    30,
+		NameNormalization: true,
+		NameStoplist:      []string{"holding"},
+	}
+
+	query, err := worker.buildOpenSanctionQuery(context.Background(), nil, job, orgConfig, record)
 	assert.NoError(t, err)
 
+	// Name normalization settings come from the organization, thresholds from the configuration
+	assert.Equal(t, models.OrganizationOpenSanctionsConfig{
+		MatchThreshold:    80,
+		MatchLimit:        50,
+		NameNormalization: true,
+		NameStoplist:      []string{"holding"},
+	}, query.OrgConfig)
+
 	// ObjectTypes threaded from config
 	assert.Equal(t, []string{"person_A"}, query.ObjectTypes)
 
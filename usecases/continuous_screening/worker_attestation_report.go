@@ -0,0 +1,430 @@
+package continuous_screening
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/worker_jobs"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"gocloud.dev/blob"
+)
+
+const (
+	AttestationReportInterval        = 24 * time.Hour
+	AttestationReportFolderName      = "attestation-reports"
+	AttestationReportObjectBatchSize = 1000
+
+	// Dataset updates still being processed are not reported as missed before that delay
+	AttestationReportMissedUpdateGracePeriod = 24 * time.Hour
+)
+
+var attestationReportCsvHeader = []string{
+	"object_type",
+	"object_id",
+	"config_stable_id",
+	"config_name",
+	"monitored_since",
+	"last_screened_at",
+	"last_screened_dataset",
+	"last_screened_version",
+	"open_matches",
+	"missed_update",
+	"missed_versions",
+}
+
+// Periodic job
+func NewContinuousScreeningAttestationReportPeriodicJob(orgId uuid.UUID) *river.PeriodicJob {
+	return worker_jobs.NewPeriodicJob(
+		river.PeriodicInterval(AttestationReportInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.ContinuousScreeningAttestationReportArgs{
+					OrgId: orgId,
+				}, &river.InsertOpts{
+					Queue: orgId.String(),
+					UniqueOpts: river.UniqueOpts{
+						ByQueue:  true,
+						ByPeriod: AttestationReportInterval,
+					},
+				}
+		},
+	)
+}
+
+type attestationReportWorkerRepository interface {
+	GetOrganizationById(ctx context.Context, exec repositories.Executor, organizationId uuid.UUID) (models.Organization, error)
+	GetContinuousScreeningConfigsByOrgId(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		provider models.ScreeningProvider,
+	) ([]models.ContinuousScreeningConfig, error)
+	ListContinuousScreeningAttestationUpdates(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		provider models.ScreeningProvider,
+	) ([]models.ContinuousScreeningAttestationUpdate, error)
+	ListContinuousScreeningAttestationObjectStats(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		objects []models.ContinuousScreeningMonitoredObject,
+	) ([]models.ContinuousScreeningAttestationObjectStats, error)
+	CreateContinuousScreeningAttestationReport(
+		ctx context.Context,
+		exec repositories.Executor,
+		input models.CreateContinuousScreeningAttestationReport,
+	) (models.ContinuousScreeningAttestationReport, error)
+}
+
+type attestationReportWorkerClientDbRepository interface {
+	ListMonitoredObjects(
+		ctx context.Context,
+		exec repositories.Executor,
+		filters models.ListMonitoredObjectsFilters,
+		pagination models.PaginationAndSorting,
+	) ([]models.ContinuousScreeningMonitoredObject, error)
+}
+
+type AttestationReportWorker struct {
+	river.WorkerDefaults[models.ContinuousScreeningAttestationReportArgs]
+	executorFactory executor_factory.ExecutorFactory
+
+	repo           attestationReportWorkerRepository
+	clientDbRepo   attestationReportWorkerClientDbRepository
+	blobRepository repositories.BlobRepository
+	bucketUrl      string
+}
+
+func NewAttestationReportWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	repo attestationReportWorkerRepository,
+	clientDbRepo attestationReportWorkerClientDbRepository,
+	blobRepository repositories.BlobRepository,
+	bucketUrl string,
+) *AttestationReportWorker {
+	return &AttestationReportWorker{
+		executorFactory: executorFactory,
+		repo:            repo,
+		clientDbRepo:    clientDbRepo,
+		blobRepository:  blobRepository,
+		bucketUrl:       bucketUrl,
+	}
+}
+
+func (w *AttestationReportWorker) Timeout(job *river.Job[models.ContinuousScreeningAttestationReportArgs]) time.Duration {
+	return 1 * time.Hour
+}
+
+// For an org, list every monitored object with the last time and dataset version it was screened
+// against and its open matches, flagging the objects that missed a dataset update. The report is
+// written to the continuous screening bucket, both as CSV and JSON.
+func (w *AttestationReportWorker) Work(ctx context.Context,
+	job *river.Job[models.ContinuousScreeningAttestationReportArgs],
+) error {
+	logger := utils.LoggerFromContext(ctx)
+
+	if err := worker_jobs.AddStrideDelay(job, AttestationReportInterval); err != nil {
+		return err
+	}
+
+	if w.bucketUrl == "" {
+		logger.DebugContext(ctx, "No bucket url provided for storing attestation reports, skipping", "job", job)
+		return nil
+	}
+
+	orgId := job.Args.OrgId
+	exec := w.executorFactory.NewExecutor()
+
+	org, err := w.repo.GetOrganizationById(ctx, exec, orgId)
+	if err != nil {
+		return errors.Wrap(err, "failed to get organization")
+	}
+	provider := org.GetScreeningProviderFor(models.ScreeningFeatureContinuousMonitoring)
+
+	configs, err := w.repo.GetContinuousScreeningConfigsByOrgId(ctx, exec, orgId, provider)
+	if err != nil {
+		return errors.Wrap(err, "failed to get continuous screening configs by org id")
+	}
+	if len(configs) == 0 {
+		logger.DebugContext(ctx, "No continuous screening config found for org, skipping", "orgId", orgId)
+		return nil
+	}
+	configsByStableId := make(map[uuid.UUID]models.ContinuousScreeningConfig, len(configs))
+	for _, config := range configs {
+		configsByStableId[config.StableId] = config
+	}
+
+	updates, err := w.repo.ListContinuousScreeningAttestationUpdates(ctx, exec, orgId, provider)
+	if err != nil {
+		return errors.Wrap(err, "failed to list dataset updates")
+	}
+	updatesByConfig := make(map[uuid.UUID][]models.ContinuousScreeningAttestationUpdate)
+	for _, update := range updates {
+		updatesByConfig[update.ConfigStableId] = append(updatesByConfig[update.ConfigStableId], update)
+	}
+
+	clientDbExec, err := w.executorFactory.NewClientDbExecutor(ctx, orgId)
+	if err != nil {
+		return errors.Wrap(err, "failed to get client db executor")
+	}
+
+	now := time.Now()
+	reportId := pure_utils.NewId()
+	basePath := fmt.Sprintf("%s/%s/%s-%s", AttestationReportFolderName, orgId,
+		now.Format("2006-01-02"), reportId)
+	csvFilePath := basePath + ".csv"
+	jsonFilePath := basePath + ".json"
+
+	// Cancelling the context of the blob writers aborts the upload, so that no partial report is left
+	// in the bucket if the job fails.
+	uploadCtx, cancelUpload := context.WithCancel(ctx)
+
+	csvBlob, err := w.blobRepository.OpenStreamWithOptions(uploadCtx, w.bucketUrl, csvFilePath,
+		&blob.WriterOptions{ContentType: "text/csv"})
+	if err != nil {
+		cancelUpload()
+		return errors.Wrap(err, "failed to open csv report stream")
+	}
+	defer csvBlob.Close()
+
+	jsonBlob, err := w.blobRepository.OpenStreamWithOptions(uploadCtx, w.bucketUrl, jsonFilePath,
+		&blob.WriterOptions{ContentType: "application/json"})
+	if err != nil {
+		cancelUpload()
+		return errors.Wrap(err, "failed to open json report stream")
+	}
+	defer jsonBlob.Close()
+	defer cancelUpload()
+
+	writer, err := newAttestationReportWriter(csvBlob, jsonBlob, orgId, reportId, now)
+	if err != nil {
+		return err
+	}
+
+	pagination := models.PaginationAndSorting{
+		Sorting: models.SortingFieldCreatedAt,
+		Order:   models.SortingOrderAsc,
+		Limit:   AttestationReportObjectBatchSize,
+	}
+
+	for {
+		objects, err := w.clientDbRepo.ListMonitoredObjects(ctx, clientDbExec,
+			models.ListMonitoredObjectsFilters{}, pagination)
+		if err != nil {
+			return errors.Wrap(err, "failed to list monitored objects")
+		}
+		if len(objects) == 0 {
+			break
+		}
+
+		stats, err := w.repo.ListContinuousScreeningAttestationObjectStats(ctx, exec, orgId, objects)
+		if err != nil {
+			return errors.Wrap(err, "failed to get monitored objects screening stats")
+		}
+		statsByObject := make(map[string]models.ContinuousScreeningAttestationObjectStats, len(stats))
+		for _, s := range stats {
+			statsByObject[attestationObjectKey(s.ObjectType, s.ObjectId, s.ConfigStableId)] = s
+		}
+
+		for _, object := range objects {
+			entry := models.BuildContinuousScreeningAttestationEntry(
+				object,
+				configsByStableId[object.ConfigStableId],
+				statsByObject[attestationObjectKey(object.ObjectType, object.ObjectId, object.ConfigStableId)],
+				updatesByConfig[object.ConfigStableId],
+				now,
+				AttestationReportMissedUpdateGracePeriod,
+			)
+			if err := writer.write(entry); err != nil {
+				return errors.Wrap(err, "failed to write attestation report entry")
+			}
+		}
+
+		if len(objects) < AttestationReportObjectBatchSize {
+			break
+		}
+		pagination.OffsetId = objects[len(objects)-1].Id.String()
+	}
+
+	if err := writer.close(); err != nil {
+		return errors.Wrap(err, "failed to finalize attestation report")
+	}
+	// The files are only committed to the bucket once the writers are closed
+	if err := csvBlob.Close(); err != nil {
+		return errors.Wrap(err, "failed to upload csv report")
+	}
+	if err := jsonBlob.Close(); err != nil {
+		return errors.Wrap(err, "failed to upload json report")
+	}
+
+	report, err := w.repo.CreateContinuousScreeningAttestationReport(ctx, exec,
+		models.CreateContinuousScreeningAttestationReport{
+			Id:                 reportId,
+			OrgId:              orgId,
+			CsvFilePath:        csvFilePath,
+			JsonFilePath:       jsonFilePath,
+			ObjectCount:        writer.objectCount,
+			FlaggedObjectCount: writer.flaggedObjectCount,
+			OpenMatchCount:     writer.openMatchCount,
+		})
+	if err != nil {
+		return errors.Wrap(err, "failed to save attestation report")
+	}
+
+	logger.DebugContext(ctx, "Created continuous screening attestation report",
+		"orgId", orgId, "reportId", report.Id, "objects", report.ObjectCount,
+		"flagged", report.FlaggedObjectCount)
+	return nil
+}
+
+func attestationObjectKey(objectType, objectId string, configStableId uuid.UUID) string {
+	return fmt.Sprintf("%s/%s/%s", objectType, objectId, configStableId)
+}
+
+type attestationReportEntryJson struct {
+	ObjectType          string     `json:"object_type"`
+	ObjectId            string     `json:"object_id"`
+	ConfigStableId      uuid.UUID  `json:"config_stable_id"`
+	ConfigName          string     `json:"config_name"`
+	MonitoredSince      time.Time  `json:"monitored_since"`
+	LastScreenedAt      *time.Time `json:"last_screened_at"`
+	LastScreenedDataset string     `json:"last_screened_dataset"`
+	LastScreenedVersion string     `json:"last_screened_version"`
+	OpenMatches         int        `json:"open_matches"`
+	MissedUpdate        bool       `json:"missed_update"`
+	MissedVersions      []string   `json:"missed_versions"`
+}
+
+// attestationReportWriter streams the entries of a report to both its CSV and JSON files. The JSON
+// file is a single document, its object list being written incrementally.
+type attestationReportWriter struct {
+	csv  *csv.Writer
+	json io.Writer
+
+	objectCount        int
+	flaggedObjectCount int
+	openMatchCount     int
+}
+
+func newAttestationReportWriter(
+	csvOut, jsonOut io.Writer,
+	orgId, reportId uuid.UUID,
+	generatedAt time.Time,
+) (*attestationReportWriter, error) {
+	w := &attestationReportWriter{csv: csv.NewWriter(csvOut), json: jsonOut}
+
+	if err := w.csv.Write(attestationReportCsvHeader); err != nil {
+		return nil, errors.Wrap(err, "failed to write csv report header")
+	}
+
+	header, err := json.Marshal(struct {
+		Id          uuid.UUID `json:"id"`
+		OrgId       uuid.UUID `json:"org_id"`
+		GeneratedAt time.Time `json:"generated_at"`
+	}{reportId, orgId, generatedAt})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal json report header")
+	}
+	// Reopen the header object to append the object list to it
+	if _, err := fmt.Fprintf(jsonOut, `%s,"objects":[`, header[:len(header)-1]); err != nil {
+		return nil, errors.Wrap(err, "failed to write json report header")
+	}
+
+	return w, nil
+}
+
+func (w *attestationReportWriter) write(entry models.ContinuousScreeningAttestationEntry) error {
+	lastScreenedAt := ""
+	if entry.LastScreenedAt != nil {
+		lastScreenedAt = entry.LastScreenedAt.UTC().Format(time.RFC3339)
+	}
+
+	if err := w.csv.Write(csvSafeRow([]string{
+		entry.ObjectType,
+		entry.ObjectId,
+		entry.ConfigStableId.String(),
+		entry.ConfigName,
+		entry.MonitoredSince.UTC().Format(time.RFC3339),
+		lastScreenedAt,
+		entry.LastScreenedDataset,
+		entry.LastScreenedVersion,
+		strconv.Itoa(entry.OpenMatches),
+		strconv.FormatBool(entry.Flagged()),
+		strings.Join(entry.MissedVersions, " "),
+	})); err != nil {
+		return err
+	}
+
+	missedVersions := entry.MissedVersions
+	if missedVersions == nil {
+		missedVersions = []string{}
+	}
+	line, err := json.Marshal(attestationReportEntryJson{
+		ObjectType:          entry.ObjectType,
+		ObjectId:            entry.ObjectId,
+		ConfigStableId:      entry.ConfigStableId,
+		ConfigName:          entry.ConfigName,
+		MonitoredSince:      entry.MonitoredSince,
+		LastScreenedAt:      entry.LastScreenedAt,
+		LastScreenedDataset: entry.LastScreenedDataset,
+		LastScreenedVersion: entry.LastScreenedVersion,
+		OpenMatches:         entry.OpenMatches,
+		MissedUpdate:        entry.Flagged(),
+		MissedVersions:      missedVersions,
+	})
+	if err != nil {
+		return err
+	}
+	if w.objectCount > 0 {
+		if _, err := w.json.Write([]byte(",")); err != nil {
+			return err
+		}
+	}
+	if _, err := w.json.Write(line); err != nil {
+		return err
+	}
+
+	w.objectCount++
+	w.openMatchCount += entry.OpenMatches
+	if entry.Flagged() {
+		w.flaggedObjectCount++
+	}
+
+	return nil
+}
+
+func (w *attestationReportWriter) close() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w.json, `],"object_count":%d,"flagged_object_count":%d,"open_match_count":%d}`,
+		w.objectCount, w.flaggedObjectCount, w.openMatchCount)
+	return err
+}
+
+// csvSafeRow neutralizes the cells that a spreadsheet would interpret as a formula, since object ids and
+// configuration names come from users.
+func csvSafeRow(row []string) []string {
+	for idx, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			row[idx] = "'" + cell
+		}
+	}
+	return row
+}
@@ -0,0 +1,86 @@
+package continuous_screening
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationReportWriter(t *testing.T) {
+	var csvOut, jsonOut bytes.Buffer
+
+	orgId := uuid.New()
+	reportId := uuid.New()
+	configStableId := uuid.New()
+	generatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	lastScreenedAt := generatedAt.Add(-time.Hour)
+
+	writer, err := newAttestationReportWriter(&csvOut, &jsonOut, orgId, reportId, generatedAt)
+	require.NoError(t, err)
+
+	require.NoError(t, writer.write(models.ContinuousScreeningAttestationEntry{
+		ObjectType:          "companies",
+		ObjectId:            "c1",
+		ConfigStableId:      configStableId,
+		ConfigName:          "KYB, daily",
+		MonitoredSince:      generatedAt.AddDate(0, -1, 0),
+		LastScreenedAt:      &lastScreenedAt,
+		LastScreenedDataset: "default",
+		LastScreenedVersion: "20261018",
+		OpenMatches:         3,
+	}))
+	require.NoError(t, writer.write(models.ContinuousScreeningAttestationEntry{
+		ObjectType:     "companies",
+		ObjectId:       "c2",
+		ConfigStableId: configStableId,
+		ConfigName:     "KYB, daily",
+		MonitoredSince: generatedAt.AddDate(0, -1, 0),
+		MissedVersions: []string{"20261016", "20261017"},
+	}))
+	require.NoError(t, writer.write(models.ContinuousScreeningAttestationEntry{
+		ObjectType:     "companies",
+		ObjectId:       "=HYPERLINK(\"https://example.com\")",
+		ConfigStableId: configStableId,
+		ConfigName:     "@KYB",
+		MonitoredSince: generatedAt.AddDate(0, -1, 0),
+	}))
+	require.NoError(t, writer.close())
+
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, attestationReportCsvHeader, rows[0])
+	assert.Equal(t, []string{
+		"companies", "c1", configStableId.String(), "KYB, daily", "2026-09-18T12:00:00Z",
+		"2026-10-18T11:00:00Z", "default", "20261018", "3", "false", "",
+	}, rows[1])
+	assert.Equal(t, "true", rows[2][9])
+	assert.Equal(t, "20261016 20261017", rows[2][10])
+	assert.Equal(t, `'=HYPERLINK("https://example.com")`, rows[3][1])
+	assert.Equal(t, "'@KYB", rows[3][3])
+
+	var report struct {
+		Id                 uuid.UUID `json:"id"`
+		OrgId              uuid.UUID `json:"org_id"`
+		ObjectCount        int       `json:"object_count"`
+		FlaggedObjectCount int       `json:"flagged_object_count"`
+		OpenMatchCount     int       `json:"open_match_count"`
+		Objects            []attestationReportEntryJson
+	}
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &report))
+	assert.Equal(t, reportId, report.Id)
+	assert.Equal(t, orgId, report.OrgId)
+	assert.Equal(t, 3, report.ObjectCount)
+	assert.Equal(t, 1, report.FlaggedObjectCount)
+	assert.Equal(t, 3, report.OpenMatchCount)
+	require.Len(t, report.Objects, 3)
+	assert.Equal(t, []string{}, report.Objects[0].MissedVersions)
+	assert.True(t, report.Objects[1].MissedUpdate)
+}
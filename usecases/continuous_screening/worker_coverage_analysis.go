@@ -0,0 +1,262 @@
+package continuous_screening
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/worker_jobs"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const (
+	CoverageAnalysisInterval  = 24 * time.Hour
+	CoverageAnalysisBatchSize = 1000
+
+	// Gaps beyond that number are counted but not stored, per gap type
+	CoverageAnalysisMaxStoredGaps = 100_000
+)
+
+// Periodic job
+func NewContinuousScreeningCoverageAnalysisPeriodicJob(orgId uuid.UUID) *river.PeriodicJob {
+	return worker_jobs.NewPeriodicJob(
+		river.PeriodicInterval(CoverageAnalysisInterval),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.ContinuousScreeningCoverageAnalysisArgs{
+					OrgId: orgId,
+				}, &river.InsertOpts{
+					Queue: orgId.String(),
+					UniqueOpts: river.UniqueOpts{
+						ByQueue:  true,
+						ByPeriod: CoverageAnalysisInterval,
+					},
+				}
+		},
+	)
+}
+
+type coverageAnalysisWorkerRepository interface {
+	GetOrganizationById(ctx context.Context, exec repositories.Executor, organizationId uuid.UUID) (models.Organization, error)
+	GetContinuousScreeningConfigsByOrgId(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		provider models.ScreeningProvider,
+	) ([]models.ContinuousScreeningConfig, error)
+	GetDataModel(
+		ctx context.Context,
+		exec repositories.Executor,
+		organizationID uuid.UUID,
+		fetchEnumValues bool,
+		useCache bool,
+	) (models.DataModel, error)
+	CreateContinuousScreeningCoverageAnalysis(
+		ctx context.Context,
+		exec repositories.Executor,
+		input models.CreateContinuousScreeningCoverageAnalysis,
+	) (models.ContinuousScreeningCoverageAnalysis, error)
+	CreateContinuousScreeningCoverageGaps(
+		ctx context.Context,
+		exec repositories.Executor,
+		gaps []models.ContinuousScreeningCoverageGap,
+	) error
+}
+
+type coverageAnalysisWorkerClientDbRepository interface {
+	IsContinuousScreeningSetup(ctx context.Context, exec repositories.Executor) (bool, error)
+	ListContinuousScreeningUnmonitoredObjects(
+		ctx context.Context,
+		exec repositories.Executor,
+		objectType string,
+		configStableIds []uuid.UUID,
+		afterInternalId *uuid.UUID,
+		limit int,
+	) ([]models.ContinuousScreeningCoverageObject, error)
+	ListContinuousScreeningOrphanedObjects(
+		ctx context.Context,
+		exec repositories.Executor,
+		objectType string,
+		afterId *uuid.UUID,
+		limit int,
+	) ([]models.ContinuousScreeningMonitoredObject, error)
+}
+
+type CoverageAnalysisWorker struct {
+	river.WorkerDefaults[models.ContinuousScreeningCoverageAnalysisArgs]
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+
+	repo         coverageAnalysisWorkerRepository
+	clientDbRepo coverageAnalysisWorkerClientDbRepository
+}
+
+func NewCoverageAnalysisWorker(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	repo coverageAnalysisWorkerRepository,
+	clientDbRepo coverageAnalysisWorkerClientDbRepository,
+) *CoverageAnalysisWorker {
+	return &CoverageAnalysisWorker{
+		executorFactory:    executorFactory,
+		transactionFactory: transactionFactory,
+		repo:               repo,
+		clientDbRepo:       clientDbRepo,
+	}
+}
+
+func (w *CoverageAnalysisWorker) Timeout(job *river.Job[models.ContinuousScreeningCoverageAnalysisArgs]) time.Duration {
+	return 1 * time.Hour
+}
+
+// Compare the current rows of the object types covered by the continuous screening configs of the
+// org with the monitored objects, and store the objects that are not monitored by any config
+// covering their type and the monitored objects whose row has been deleted.
+func (w *CoverageAnalysisWorker) Work(ctx context.Context,
+	job *river.Job[models.ContinuousScreeningCoverageAnalysisArgs],
+) error {
+	logger := utils.LoggerFromContext(ctx)
+
+	if err := worker_jobs.AddStrideDelay(job, CoverageAnalysisInterval); err != nil {
+		return err
+	}
+
+	orgId := job.Args.OrgId
+	exec := w.executorFactory.NewExecutor()
+
+	org, err := w.repo.GetOrganizationById(ctx, exec, orgId)
+	if err != nil {
+		return errors.Wrap(err, "failed to get organization")
+	}
+	provider := org.GetScreeningProviderFor(models.ScreeningFeatureContinuousMonitoring)
+
+	configs, err := w.repo.GetContinuousScreeningConfigsByOrgId(ctx, exec, orgId, provider)
+	if err != nil {
+		return errors.Wrap(err, "failed to get continuous screening configs by org id")
+	}
+	if len(configs) == 0 {
+		logger.DebugContext(ctx, "No continuous screening config found for org, skipping", "orgId", orgId)
+		return nil
+	}
+
+	dataModel, err := w.repo.GetDataModel(ctx, exec, orgId, false, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to get data model")
+	}
+
+	clientDbExec, err := w.executorFactory.NewClientDbExecutor(ctx, orgId)
+	if err != nil {
+		return errors.Wrap(err, "failed to get client db executor")
+	}
+	setup, err := w.clientDbRepo.IsContinuousScreeningSetup(ctx, clientDbExec)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if continuous screening is setup")
+	}
+	if !setup {
+		logger.DebugContext(ctx, "Continuous screening is not setup for org, skipping", "orgId", orgId)
+		return nil
+	}
+
+	analysis := models.CreateContinuousScreeningCoverageAnalysis{
+		Id:    pure_utils.NewId(),
+		OrgId: orgId,
+	}
+	var gaps []models.ContinuousScreeningCoverageGap
+
+	configStableIdsByObjectType := models.ContinuousScreeningConfigStableIdsByObjectType(configs)
+	objectTypes := make([]string, 0, len(configStableIdsByObjectType))
+	for objectType := range configStableIdsByObjectType {
+		if _, ok := dataModel.Tables[objectType]; !ok {
+			logger.WarnContext(ctx, "Continuous screening config object type not found in data model, skipping",
+				"orgId", orgId, "objectType", objectType)
+			continue
+		}
+		objectTypes = append(objectTypes, objectType)
+	}
+	slices.Sort(objectTypes)
+	analysis.ObjectTypes = objectTypes
+
+	for _, objectType := range objectTypes {
+		var afterInternalId *uuid.UUID
+		for {
+			objects, err := w.clientDbRepo.ListContinuousScreeningUnmonitoredObjects(ctx, clientDbExec,
+				objectType, configStableIdsByObjectType[objectType], afterInternalId, CoverageAnalysisBatchSize)
+			if err != nil {
+				return errors.Wrapf(err, "failed to list unmonitored objects of type %s", objectType)
+			}
+
+			for _, object := range objects {
+				analysis.UnmonitoredCount++
+				if analysis.UnmonitoredCount > CoverageAnalysisMaxStoredGaps {
+					analysis.Truncated = true
+					continue
+				}
+				gaps = append(gaps, models.ContinuousScreeningCoverageGap{
+					AnalysisId:       analysis.Id,
+					GapType:          models.ContinuousScreeningCoverageGapTypeUnmonitored,
+					ObjectType:       objectType,
+					ObjectId:         object.ObjectId,
+					ObjectInternalId: &object.InternalId,
+				})
+			}
+
+			if len(objects) < CoverageAnalysisBatchSize {
+				break
+			}
+			afterInternalId = &objects[len(objects)-1].InternalId
+		}
+
+		var afterId *uuid.UUID
+		for {
+			objects, err := w.clientDbRepo.ListContinuousScreeningOrphanedObjects(ctx, clientDbExec,
+				objectType, afterId, CoverageAnalysisBatchSize)
+			if err != nil {
+				return errors.Wrapf(err, "failed to list orphaned monitored objects of type %s", objectType)
+			}
+
+			for _, object := range objects {
+				analysis.OrphanedCount++
+				if analysis.OrphanedCount > CoverageAnalysisMaxStoredGaps {
+					analysis.Truncated = true
+					continue
+				}
+				gaps = append(gaps, models.ContinuousScreeningCoverageGap{
+					AnalysisId:     analysis.Id,
+					GapType:        models.ContinuousScreeningCoverageGapTypeOrphaned,
+					ObjectType:     objectType,
+					ObjectId:       object.ObjectId,
+					ConfigStableId: &object.ConfigStableId,
+				})
+			}
+
+			if len(objects) < CoverageAnalysisBatchSize {
+				break
+			}
+			afterId = &objects[len(objects)-1].Id
+		}
+	}
+
+	err = w.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if _, err := w.repo.CreateContinuousScreeningCoverageAnalysis(ctx, tx, analysis); err != nil {
+			return err
+		}
+		return w.repo.CreateContinuousScreeningCoverageGaps(ctx, tx, gaps)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to store coverage analysis")
+	}
+
+	logger.InfoContext(ctx, "Continuous screening coverage analysis done",
+		"orgId", orgId,
+		"analysisId", analysis.Id,
+		"unmonitored", analysis.UnmonitoredCount,
+		"orphaned", analysis.OrphanedCount)
+
+	return nil
+}
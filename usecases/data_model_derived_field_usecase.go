@@ -0,0 +1,463 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval/evaluate"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const (
+	derivedFieldBackfillChunkSize = 1000
+	// The backfill gives the job back to the queue after that duration, to be resumed where it stopped
+	derivedFieldBackfillTimeBudget  = 4 * time.Minute
+	derivedFieldBackfillSnoozeDelay = 5 * time.Second
+)
+
+type derivedFieldRepository interface {
+	UpdateDataModelFieldDerivedExpression(ctx context.Context, exec repositories.Executor,
+		fieldId string, expression *ast.Node) error
+	CreateDerivedFieldBackfill(ctx context.Context, exec repositories.Executor,
+		backfill models.DerivedFieldBackfill) (models.DerivedFieldBackfill, error)
+	SupersedeDerivedFieldBackfills(ctx context.Context, exec repositories.Executor, fieldId string) error
+	GetDerivedFieldBackfill(ctx context.Context, exec repositories.Executor, id uuid.UUID) (models.DerivedFieldBackfill, error)
+	ListDerivedFieldBackfills(ctx context.Context, exec repositories.Executor, fieldId string) ([]models.DerivedFieldBackfill, error)
+	UpdateDerivedFieldBackfillProgress(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		lastRowId *uuid.UUID, rowsProcessed int64) error
+	CompleteDerivedFieldBackfill(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		status models.DerivedFieldBackfillStatus, backfillError *string) error
+}
+
+type derivedFieldClientDbRepository interface {
+	ListDerivedFieldRows(ctx context.Context, exec repositories.Executor, table models.Table,
+		afterId *uuid.UUID, limit int) ([]models.DerivedFieldRow, error)
+	WriteDerivedFieldValues(ctx context.Context, exec repositories.Executor, tableName string, fieldName string,
+		dataType models.DataType, ids []uuid.UUID, values []any) error
+	ListAllUniqueIndexes(ctx context.Context, exec repositories.Executor) ([]models.UnicityIndex, error)
+}
+
+// DerivedFieldUsecase manages the fields whose value is computed at ingestion from an expression over
+// the ingested object and the objects it links to. Rows ingested before the expression was set are
+// computed by a background backfill.
+type DerivedFieldUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityOrganization
+
+	dataModelRepository    repositories.DataModelRepository
+	derivedFieldRepository derivedFieldRepository
+	clientDbRepository     derivedFieldClientDbRepository
+	taskQueueRepository    repositories.TaskQueueRepository
+
+	evaluateAstExpression           ast_eval.EvaluateAstExpression
+	astEvaluationEnvironmentFactory ast_eval.AstEvaluationEnvironmentFactory
+}
+
+func NewDerivedFieldUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityOrganization,
+	dataModelRepository repositories.DataModelRepository,
+	derivedFieldRepository derivedFieldRepository,
+	clientDbRepository derivedFieldClientDbRepository,
+	taskQueueRepository repositories.TaskQueueRepository,
+	evaluateAstExpression ast_eval.EvaluateAstExpression,
+	astEvaluationEnvironmentFactory ast_eval.AstEvaluationEnvironmentFactory,
+) DerivedFieldUsecase {
+	return DerivedFieldUsecase{
+		executorFactory:                 executorFactory,
+		transactionFactory:              transactionFactory,
+		enforceSecurity:                 enforceSecurity,
+		dataModelRepository:             dataModelRepository,
+		derivedFieldRepository:          derivedFieldRepository,
+		clientDbRepository:              clientDbRepository,
+		taskQueueRepository:             taskQueueRepository,
+		evaluateAstExpression:           evaluateAstExpression,
+		astEvaluationEnvironmentFactory: astEvaluationEnvironmentFactory,
+	}
+}
+
+// SetFieldDerivation derives the field from the expression, and starts the backfill of the rows
+// already ingested. Setting the expression of a field that is already derived recomputes it.
+func (uc DerivedFieldUsecase) SetFieldDerivation(
+	ctx context.Context,
+	fieldId string,
+	expression ast.Node,
+) (models.DerivedFieldBackfill, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	fieldMetadata, err := uc.dataModelRepository.GetDataModelField(ctx, exec, fieldId)
+	if err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+	tableMetadata, err := uc.dataModelRepository.GetDataModelTable(ctx, exec, fieldMetadata.TableId)
+	if err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(tableMetadata.OrganizationID); err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, tableMetadata.OrganizationID, false, false)
+	if err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+	table, ok := dataModel.Tables[tableMetadata.Name]
+	if !ok {
+		return models.DerivedFieldBackfill{}, errors.Wrapf(models.NotFoundError,
+			"table %s not found in data model", tableMetadata.Name)
+	}
+	field, ok := table.Fields[fieldMetadata.Name]
+	if !ok {
+		return models.DerivedFieldBackfill{}, errors.Wrapf(models.NotFoundError,
+			"field %s not found in table %s", fieldMetadata.Name, table.Name)
+	}
+
+	if err := models.ValidateDerivedField(table, field, expression); err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, tableMetadata.OrganizationID)
+	if err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+	uniqueIndexes, err := uc.clientDbRepository.ListAllUniqueIndexes(ctx, clientExec)
+	if err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+	if slices.ContainsFunc(uniqueIndexes, func(index models.UnicityIndex) bool {
+		return index.TableName == table.Name && slices.Contains(index.Fields, field.Name)
+	}) {
+		return models.DerivedFieldBackfill{}, errors.Wrap(models.BadParameterError, "a unique field cannot be derived")
+	}
+
+	if err := uc.dryRunDerivedField(ctx, tableMetadata.OrganizationID, dataModel, table, field, expression); err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	var backfill models.DerivedFieldBackfill
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if err := uc.derivedFieldRepository.UpdateDataModelFieldDerivedExpression(ctx, tx,
+			field.ID, &expression); err != nil {
+			return err
+		}
+		backfill, err = uc.derivedFieldRepository.CreateDerivedFieldBackfill(ctx, tx, models.DerivedFieldBackfill{
+			Id:             pure_utils.NewId(),
+			OrganizationId: tableMetadata.OrganizationID,
+			TableId:        table.ID,
+			FieldId:        field.ID,
+			TableName:      table.Name,
+			FieldName:      field.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		return uc.taskQueueRepository.EnqueueDerivedFieldBackfillTask(ctx, tx,
+			tableMetadata.OrganizationID, backfill.Id)
+	})
+	if err != nil {
+		return models.DerivedFieldBackfill{}, err
+	}
+
+	return backfill, nil
+}
+
+// dryRunDerivedField evaluates the expression on a fake payload, to check that it evaluates and that
+// its result can be stored in the field.
+func (uc DerivedFieldUsecase) dryRunDerivedField(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	dataModel models.DataModel,
+	table models.Table,
+	field models.Field,
+	expression ast.Node,
+) error {
+	env := uc.astEvaluationEnvironmentFactory(ast_eval.EvaluationEnvironmentFactoryParams{
+		OrganizationId: organizationId,
+		ClientObject: models.ClientObject{
+			TableName: table.Name,
+			Data:      evaluate.DryRunPayload(table),
+		},
+		DataModel:                     dataModel,
+		DatabaseAccessReturnFakeValue: true,
+	}).WithoutOptimizations()
+
+	evaluation, ok := ast_eval.EvaluateAst(ctx, nil, env, expression)
+	if !ok {
+		for _, err := range evaluation.FlattenErrors() {
+			if !ast.IsAuthorizedError(err) {
+				return errors.Wrapf(models.BadParameterError, "the expression does not evaluate: %s", err.Error())
+			}
+		}
+		return nil
+	}
+	if _, err := models.CoerceDerivedFieldValue(evaluation.ReturnValue, field.DataType); err != nil {
+		return errors.Wrapf(models.BadParameterError,
+			"the expression does not return a value of type %s: %s", field.DataType, err.Error())
+	}
+	return nil
+}
+
+// RemoveFieldDerivation makes the field a regular field again. Its values are kept, and it is written
+// by ingestion from then on.
+func (uc DerivedFieldUsecase) RemoveFieldDerivation(ctx context.Context, fieldId string) error {
+	exec := uc.executorFactory.NewExecutor()
+
+	field, err := uc.dataModelRepository.GetDataModelField(ctx, exec, fieldId)
+	if err != nil {
+		return err
+	}
+	table, err := uc.dataModelRepository.GetDataModelTable(ctx, exec, field.TableId)
+	if err != nil {
+		return err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(table.OrganizationID); err != nil {
+		return err
+	}
+	if field.DerivedExpression == nil {
+		return errors.Wrap(models.BadParameterError, "the field is not derived")
+	}
+
+	return uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if err := uc.derivedFieldRepository.UpdateDataModelFieldDerivedExpression(ctx, tx, field.ID, nil); err != nil {
+			return err
+		}
+		return uc.derivedFieldRepository.SupersedeDerivedFieldBackfills(ctx, tx, field.ID)
+	})
+}
+
+func (uc DerivedFieldUsecase) ListDerivedFieldBackfills(ctx context.Context, fieldId string) ([]models.DerivedFieldBackfill, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	field, err := uc.dataModelRepository.GetDataModelField(ctx, exec, fieldId)
+	if err != nil {
+		return nil, err
+	}
+	table, err := uc.dataModelRepository.GetDataModelTable(ctx, exec, field.TableId)
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(uc.enforceSecurity.ReadDataModel(),
+		uc.enforceSecurity.ReadOrganization(table.OrganizationID)); err != nil {
+		return nil, err
+	}
+
+	return uc.derivedFieldRepository.ListDerivedFieldBackfills(ctx, exec, fieldId)
+}
+
+// RunDerivedFieldBackfill computes the derived field on the live rows of its table until the time
+// budget runs out. It returns whether the backfill is over, successfully or not.
+func (uc DerivedFieldUsecase) RunDerivedFieldBackfill(ctx context.Context, backfillId uuid.UUID) (bool, error) {
+	logger := utils.LoggerFromContext(ctx)
+	exec := uc.executorFactory.NewExecutor()
+
+	backfill, err := uc.derivedFieldRepository.GetDerivedFieldBackfill(ctx, exec, backfillId)
+	if errors.Is(err, models.NotFoundError) {
+		// The field, and its backfills with it, was deleted
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if backfill.Status.IsTerminal() {
+		return true, nil
+	}
+
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, backfill.OrganizationId, false, false)
+	if err != nil {
+		return false, err
+	}
+	table, ok := dataModel.Tables[backfill.TableName]
+	if !ok {
+		return true, uc.failDerivedFieldBackfill(ctx, backfill, "the table was modified during the backfill")
+	}
+	field, ok := table.Fields[backfill.FieldName]
+	if !ok || field.ID != backfill.FieldId || field.Archived {
+		return true, uc.failDerivedFieldBackfill(ctx, backfill, "the field was modified during the backfill")
+	}
+	if !field.IsDerived() {
+		return true, uc.derivedFieldRepository.CompleteDerivedFieldBackfill(ctx, exec, backfill.Id,
+			models.DerivedFieldBackfillSuperseded, nil)
+	}
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, backfill.OrganizationId)
+	if err != nil {
+		return false, err
+	}
+
+	deadline := time.Now().Add(derivedFieldBackfillTimeBudget)
+	for {
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		rows, err := uc.clientDbRepository.ListDerivedFieldRows(ctx, clientExec, table,
+			backfill.LastRowId, derivedFieldBackfillChunkSize)
+		if err != nil {
+			return false, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		ids := make([]uuid.UUID, 0, len(rows))
+		values := make([]any, 0, len(rows))
+		for _, row := range rows {
+			value, err := evaluateDerivedField(ctx, uc.evaluateAstExpression, backfill.OrganizationId,
+				dataModel, table, field, row.Data)
+			if err != nil {
+				return true, uc.failDerivedFieldBackfill(ctx, backfill, err.Error())
+			}
+			// Rows ingested before the field was derived may hold values the expression was not
+			// written for, the field is left empty on those.
+			value, err = models.CoerceDerivedFieldValue(value, field.DataType)
+			if err != nil {
+				value = nil
+			}
+			ids = append(ids, row.Id)
+			values = append(values, value)
+		}
+
+		if err := uc.clientDbRepository.WriteDerivedFieldValues(ctx, clientExec, table.Name,
+			field.Name, field.DataType, ids, values); err != nil {
+			return false, err
+		}
+
+		lastRowId := rows[len(rows)-1].Id
+		if err := uc.derivedFieldRepository.UpdateDerivedFieldBackfillProgress(ctx, exec, backfill.Id,
+			&lastRowId, int64(len(rows))); err != nil {
+			return false, err
+		}
+		backfill.LastRowId = &lastRowId
+		backfill.RowsProcessed += int64(len(rows))
+
+		if len(rows) < derivedFieldBackfillChunkSize {
+			break
+		}
+	}
+
+	if err := uc.derivedFieldRepository.CompleteDerivedFieldBackfill(ctx, exec, backfill.Id,
+		models.DerivedFieldBackfillCompleted, nil); err != nil {
+		return false, err
+	}
+
+	logger.InfoContext(ctx, "derived field backfill completed", "backfill_id", backfill.Id,
+		"rows_processed", backfill.RowsProcessed)
+	return true, nil
+}
+
+func (uc DerivedFieldUsecase) failDerivedFieldBackfill(ctx context.Context, backfill models.DerivedFieldBackfill, reason string) error {
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "derived field backfill failed",
+		"backfill_id", backfill.Id, "reason", reason)
+
+	return uc.derivedFieldRepository.CompleteDerivedFieldBackfill(ctx, uc.executorFactory.NewExecutor(),
+		backfill.Id, models.DerivedFieldBackfillFailed, &reason)
+}
+
+// evaluateDerivedField evaluates the expression of the derived field on an object of its table.
+// As for rules, authorized errors such as a division by zero evaluate to null.
+func evaluateDerivedField(
+	ctx context.Context,
+	evaluator ast_eval.EvaluateAstExpression,
+	organizationId uuid.UUID,
+	dataModel models.DataModel,
+	table models.Table,
+	field models.Field,
+	data map[string]any,
+) (any, error) {
+	evaluation, err := evaluator.EvaluateAstExpression(ctx, nil, *field.DerivedExpression, organizationId,
+		models.ClientObject{TableName: table.Name, Data: data}, dataModel, nil)
+	switch {
+	case ast.IsAuthorizedError(err):
+		return nil, nil
+	case err != nil:
+		return nil, errors.Wrapf(err, "error while evaluating derived field %s", field.Name)
+	}
+	return evaluation.ReturnValue, nil
+}
+
+// computeDerivedFields sets the derived fields of the ingested payloads to the value of their
+// expression. Partial updates are expected to be merged with the previous version of their object
+// beforehand, so that the derived fields are computed from the complete object.
+func computeDerivedFields(
+	ctx context.Context,
+	evaluator ast_eval.EvaluateAstExpression,
+	organizationId uuid.UUID,
+	dataModel models.DataModel,
+	table models.Table,
+	payloads []models.ClientObject,
+) error {
+	derivedFields := table.DerivedFields()
+	if len(derivedFields) == 0 {
+		return nil
+	}
+
+	validationErrors := make(models.IngestionValidationErrors)
+	addError := func(objectId, fieldName, message string) {
+		if validationErrors[objectId] == nil {
+			validationErrors[objectId] = make(models.IngestionValidationErrorsSingle)
+		}
+		validationErrors[objectId][fieldName] = message
+	}
+
+	for _, payload := range payloads {
+		objectId := fmt.Sprint(payload.Data["object_id"])
+		for _, field := range derivedFields {
+			value, err := evaluateDerivedField(ctx, evaluator, organizationId, dataModel, table, field, payload.Data)
+			if err != nil {
+				return err
+			}
+			value, err = models.CoerceDerivedFieldValue(value, field.DataType)
+			if err != nil {
+				addError(objectId, field.Name, err.Error())
+				continue
+			}
+			payload.Data[field.Name] = value
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.Join(models.BadParameterError, validationErrors)
+	}
+	return nil
+}
+
+// DerivedFieldBackfillWorker is a River worker that runs derived field backfills, resuming them until
+// they are over.
+type DerivedFieldBackfillWorker struct {
+	river.WorkerDefaults[models.DerivedFieldBackfillArgs]
+	usecase DerivedFieldUsecase
+}
+
+func NewDerivedFieldBackfillWorker(usecase DerivedFieldUsecase) *DerivedFieldBackfillWorker {
+	return &DerivedFieldBackfillWorker{usecase: usecase}
+}
+
+func (w *DerivedFieldBackfillWorker) Timeout(job *river.Job[models.DerivedFieldBackfillArgs]) time.Duration {
+	return 2 * derivedFieldBackfillTimeBudget
+}
+
+func (w *DerivedFieldBackfillWorker) Work(ctx context.Context, job *river.Job[models.DerivedFieldBackfillArgs]) error {
+	done, err := w.usecase.RunDerivedFieldBackfill(ctx, job.Args.BackfillId)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return river.JobSnooze(derivedFieldBackfillSnoozeDelay)
+	}
+	return nil
+}
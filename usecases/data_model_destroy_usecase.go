@@ -503,8 +503,7 @@ func (uc DataModelDestroyUsecase) canDeleteRef(
 
 		scenarioConflicts[scenario.Id].allIterations.Insert(it.ScenarioIterationId.String())
 
-		if it.Version == nil || (scenario.LiveVersionID != nil &&
-			it.ScenarioIterationId.String() == *scenario.LiveVersionID) {
+		if it.Version == nil || scenario.ServesIteration(it.ScenarioIterationId.String()) {
 			scenarioConflicts[scenario.Id].hasDraftOrLive = true
 		}
 
@@ -525,42 +524,41 @@ func (uc DataModelDestroyUsecase) canDeleteRef(
 			iterationReport = *previousReport
 		}
 
-		if uc.isRefUsedInAst(it.TriggerAst, scenario.TriggerObjectType, links, table, field) {
+		if isRefUsedInAst(it.TriggerAst, scenario.TriggerObjectType, links, table, field) {
 			iterationReport.TriggerCondition = true
 			found = true
 		}
 
 		if it.ScreeningTriggerAst != nil {
-			if uc.isRefUsedInAst(it.ScreeningTriggerAst, scenario.TriggerObjectType, links, table, field) {
+			if isRefUsedInAst(it.ScreeningTriggerAst, scenario.TriggerObjectType, links, table, field) {
 				iterationReport.Screening.Insert(it.RuleId.String())
 				found = true
 			}
 		}
 
 		if it.RuleAst != nil {
-			if uc.isRefUsedInAst(it.RuleAst, scenario.TriggerObjectType, links, table, field) {
+			if isRefUsedInAst(it.RuleAst, scenario.TriggerObjectType, links, table, field) {
 				iterationReport.Rules.Insert(it.RuleId.String())
 				found = true
 			}
 		}
 		if it.ScreeningCounterpartyAst != nil {
-			if uc.isRefUsedInAst(it.ScreeningCounterpartyAst,
+			if isRefUsedInAst(it.ScreeningCounterpartyAst,
 				scenario.TriggerObjectType, links, table, field) {
 				iterationReport.Screening.Insert(it.RuleId.String())
 				found = true
 			}
 		}
 		for _, sc := range it.ScreeningAst {
-			if uc.isRefUsedInAst(&sc, scenario.TriggerObjectType, links, table, field) {
+			if isRefUsedInAst(&sc, scenario.TriggerObjectType, links, table, field) {
 				iterationReport.Screening.Insert(it.RuleId.String())
 				found = true
 			}
 		}
 
 		if found {
-			// We cannot delete a field if it is used in a draft or a live scenario iteration
-			if it.Version == nil || (scenario.LiveVersionID != nil &&
-				it.ScenarioIterationId.String() == *scenario.LiveVersionID) {
+			// We cannot delete a field if it is used in a draft, a live or a canary scenario iteration
+			if it.Version == nil || scenario.ServesIteration(it.ScenarioIterationId.String()) {
 				canDelete = false
 				report.Conflicts.ScenarioIterations[it.ScenarioIterationId.String()] = &iterationReport
 				continue
@@ -600,7 +598,7 @@ func (uc DataModelDestroyUsecase) canDeleteRef(
 				if err != nil {
 					return false, models.DataModelDeleteFieldReport{}, err
 				}
-				if uc.isRefUsedInAst(&payloadExpression, scenario.TriggerObjectType, links, table, field) {
+				if isRefUsedInAst(&payloadExpression, scenario.TriggerObjectType, links, table, field) {
 					canDelete = false
 					report.Conflicts.Workflows.Insert(wk.ScenarioId.String())
 				}
@@ -619,7 +617,7 @@ func (uc DataModelDestroyUsecase) canDeleteRef(
 					if err != nil {
 						return false, models.DataModelDeleteFieldReport{}, err
 					}
-					if uc.isRefUsedInAst(&titleTemplateAst,
+					if isRefUsedInAst(&titleTemplateAst,
 						scenario.TriggerObjectType, links, table, field) {
 						canDelete = false
 						report.Conflicts.Workflows.Insert(wk.ScenarioId.String())
@@ -761,8 +759,7 @@ func (uc DataModelDestroyUsecase) canDeleteLink(
 
 		scenarioConflicts[scenario.Id].allIterations.Insert(it.ScenarioIterationId.String())
 
-		if it.Version == nil || (scenario.LiveVersionID != nil &&
-			it.ScenarioIterationId.String() == *scenario.LiveVersionID) {
+		if it.Version == nil || scenario.ServesIteration(it.ScenarioIterationId.String()) {
 			scenarioConflicts[scenario.Id].hasDraftOrLive = true
 		}
 
@@ -815,9 +812,8 @@ func (uc DataModelDestroyUsecase) canDeleteLink(
 		}
 
 		if found {
-			// We cannot delete a field if it is used in a draft or a live scenario iteration
-			if it.Version == nil || (scenario.LiveVersionID != nil &&
-				it.ScenarioIterationId.String() == *scenario.LiveVersionID) {
+			// We cannot delete a field if it is used in a draft, a live or a canary scenario iteration
+			if it.Version == nil || scenario.ServesIteration(it.ScenarioIterationId.String()) {
 				canDelete = false
 				report.Conflicts.ScenarioIterations[it.ScenarioIterationId.String()] = &iterationReport
 				continue
@@ -885,7 +881,7 @@ func (uc DataModelDestroyUsecase) canDeleteLink(
 	return canDelete, report, nil
 }
 
-func (uc DataModelDestroyUsecase) isRefUsedInAst(tree *ast.Node, triggerObjectType string,
+func isRefUsedInAst(tree *ast.Node, triggerObjectType string,
 	links []models.LinkToSingle, table models.TableMetadata, field *models.FieldMetadata,
 ) bool {
 	if tree == nil {
@@ -920,14 +916,14 @@ func (uc DataModelDestroyUsecase) isRefUsedInAst(tree *ast.Node, triggerObjectTy
 		}
 
 		if value, ok := tree.NamedChildren["value"]; ok {
-			if found := uc.isRefUsedInAst(utils.Ptr(value), triggerObjectType, links, table, field); found {
+			if found := isRefUsedInAst(utils.Ptr(value), triggerObjectType, links, table, field); found {
 				return true
 			}
 		}
 
 	case ast.FUNC_DB_ACCESS, ast.FUNC_AGGREGATOR:
 		if filters, ok := tree.NamedChildren["filters"]; ok {
-			if found := uc.isRefUsedInAst(utils.Ptr(filters), triggerObjectType, links, table, field); found {
+			if found := isRefUsedInAst(utils.Ptr(filters), triggerObjectType, links, table, field); found {
 				return found
 			}
 		}
@@ -970,12 +966,12 @@ func (uc DataModelDestroyUsecase) isRefUsedInAst(tree *ast.Node, triggerObjectTy
 
 	default:
 		for _, ch := range tree.Children {
-			if found := uc.isRefUsedInAst(&ch, triggerObjectType, links, table, field); found {
+			if found := isRefUsedInAst(&ch, triggerObjectType, links, table, field); found {
 				return found
 			}
 		}
 		for _, ch := range tree.NamedChildren {
-			if found := uc.isRefUsedInAst(&ch, triggerObjectType, links, table, field); found {
+			if found := isRefUsedInAst(&ch, triggerObjectType, links, table, field); found {
 				return found
 			}
 		}
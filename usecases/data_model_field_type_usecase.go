@@ -0,0 +1,855 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/dto"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/scenarios"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-set/v2"
+	"github.com/riverqueue/river"
+)
+
+const (
+	fieldTypeMigrationChunkSize = 1000
+	// The backfill gives the job back to the queue after that duration, to be resumed where it stopped
+	fieldTypeMigrationTimeBudget  = 4 * time.Minute
+	fieldTypeMigrationSnoozeDelay = 5 * time.Second
+	// Failures beyond that number are counted but not returned with the migration
+	fieldTypeMigrationListedFailures = 100
+)
+
+type fieldTypeMigrationRepository interface {
+	CreateFieldTypeMigration(ctx context.Context, exec repositories.Executor,
+		migration models.FieldTypeMigration) (models.FieldTypeMigration, error)
+	GetFieldTypeMigration(ctx context.Context, exec repositories.Executor, id uuid.UUID) (models.FieldTypeMigration, error)
+	ListFieldTypeMigrations(ctx context.Context, exec repositories.Executor, fieldId string) ([]models.FieldTypeMigration, error)
+	UpdateFieldTypeMigrationProgress(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		lastRowId *uuid.UUID, rowsProcessed int64, rowsFailed int64) error
+	CompleteFieldTypeMigration(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		status models.FieldTypeMigrationStatus, migrationError *string) error
+	CreateFieldTypeMigrationFailures(ctx context.Context, exec repositories.Executor,
+		failures []models.FieldTypeMigrationFailure) (int64, error)
+	ListFieldTypeMigrationFailures(ctx context.Context, exec repositories.Executor,
+		migrationId uuid.UUID, limit int) ([]models.FieldTypeMigrationFailure, error)
+	UpdateDataModelFieldDataType(ctx context.Context, exec repositories.Executor, fieldId string, dataType models.DataType) error
+}
+
+type fieldTypeMigrationClientDbRepository interface {
+	AddFieldTypeMigrationColumn(ctx context.Context, exec repositories.Executor, tableName string,
+		column string, dataType models.DataType) error
+	DropFieldTypeMigrationColumn(ctx context.Context, exec repositories.Executor, tableName string, column string) error
+	RenameFieldTypeMigrationColumn(ctx context.Context, exec repositories.Executor, tableName string,
+		column string, fieldName string) error
+	LockTableForFieldTypeMigration(ctx context.Context, exec repositories.Executor, tableName string) error
+	ListFieldTypeMigrationRows(ctx context.Context, exec repositories.Executor, tableName string, fieldName string,
+		column string, afterId *uuid.UUID, limit int, unconvertedOnly bool) ([]models.FieldTypeMigrationRow, error)
+	WriteFieldTypeMigrationValues(ctx context.Context, exec repositories.Executor, tableName string, column string,
+		dataType models.DataType, ids []uuid.UUID, values []any) error
+	ListAllIndexes(ctx context.Context, exec repositories.Executor, indexTypes ...models.IndexType) ([]models.ConcreteIndex, error)
+	ListAllUniqueIndexes(ctx context.Context, exec repositories.Executor) ([]models.UnicityIndex, error)
+}
+
+// DataModelFieldTypeUsecase changes the data type of fields that already hold data. The values are
+// converted by a background job, and the change is only committed if no formula using the field
+// breaks with its new type.
+type DataModelFieldTypeUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityOrganization
+
+	dataModelRepository          repositories.DataModelRepository
+	migrationRepository          fieldTypeMigrationRepository
+	clientDbRepository           fieldTypeMigrationClientDbRepository
+	organizationSchemaRepository repositories.OrganizationSchemaRepository
+	scenarioRepository           repositories.ScenarioUsecaseRepository
+	iterationsRepository         scenarios.ScenarioPublisherRepository
+	workflowRepository           workflowRepository
+	analyticsSettingsRepository  analyticsSettingsRepository
+	taskQueueRepository          repositories.TaskQueueRepository
+
+	astEvaluationEnvironmentFactory ast_eval.AstEvaluationEnvironmentFactory
+}
+
+func NewDataModelFieldTypeUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityOrganization,
+	dataModelRepository repositories.DataModelRepository,
+	migrationRepository fieldTypeMigrationRepository,
+	clientDbRepository fieldTypeMigrationClientDbRepository,
+	organizationSchemaRepository repositories.OrganizationSchemaRepository,
+	scenarioRepository repositories.ScenarioUsecaseRepository,
+	iterationsRepository scenarios.ScenarioPublisherRepository,
+	workflowRepository workflowRepository,
+	analyticsSettingsRepository analyticsSettingsRepository,
+	taskQueueRepository repositories.TaskQueueRepository,
+	astEvaluationEnvironmentFactory ast_eval.AstEvaluationEnvironmentFactory,
+) DataModelFieldTypeUsecase {
+	return DataModelFieldTypeUsecase{
+		executorFactory:                 executorFactory,
+		transactionFactory:              transactionFactory,
+		enforceSecurity:                 enforceSecurity,
+		dataModelRepository:             dataModelRepository,
+		migrationRepository:             migrationRepository,
+		clientDbRepository:              clientDbRepository,
+		organizationSchemaRepository:    organizationSchemaRepository,
+		scenarioRepository:              scenarioRepository,
+		iterationsRepository:            iterationsRepository,
+		workflowRepository:              workflowRepository,
+		analyticsSettingsRepository:     analyticsSettingsRepository,
+		taskQueueRepository:             taskQueueRepository,
+		astEvaluationEnvironmentFactory: astEvaluationEnvironmentFactory,
+	}
+}
+
+// CreateFieldTypeMigration checks that the type of the field can be changed and, unless this is a
+// dry run, starts the migration. A ConflictError is returned along with the report if something
+// depends on the current type of the field.
+func (uc DataModelFieldTypeUsecase) CreateFieldTypeMigration(
+	ctx context.Context,
+	dryRun bool,
+	input models.CreateFieldTypeMigrationInput,
+) (models.FieldTypeMigrationReport, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	field, err := uc.dataModelRepository.GetDataModelField(ctx, exec, input.FieldId)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	table, err := uc.dataModelRepository.GetDataModelTable(ctx, exec, field.TableId)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(table.OrganizationID); err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, table.OrganizationID, false, false)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, table.OrganizationID)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	uniqueIndexes, err := uc.clientDbRepository.ListAllUniqueIndexes(ctx, clientExec)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	isUnique := slices.ContainsFunc(uniqueIndexes, func(index models.UnicityIndex) bool {
+		return index.TableName == table.Name && slices.Contains(index.Fields, field.Name)
+	})
+
+	if err := validateFieldTypeMigration(dataModel, table, field, isUnique, input); err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+
+	report, err := uc.fieldTypeMigrationConflicts(ctx, exec, dataModel, table, field, input.DataType)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	if report.HasConflicts() {
+		return report, errors.Wrap(models.ConflictError, "the type of the field cannot be changed")
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		migration, err := uc.migrationRepository.CreateFieldTypeMigration(ctx, tx, models.FieldTypeMigration{
+			Id:              pure_utils.NewId(),
+			OrganizationId:  table.OrganizationID,
+			TableId:         table.ID,
+			FieldId:         field.ID,
+			TableName:       table.Name,
+			FieldName:       field.Name,
+			SourceDataType:  field.DataType,
+			TargetDataType:  input.DataType,
+			TimestampFormat: input.TimestampFormat,
+			NullOnFailure:   input.NullOnFailure,
+		})
+		if err != nil {
+			return err
+		}
+		report.Migration = &migration
+
+		return uc.taskQueueRepository.EnqueueFieldTypeMigrationTask(ctx, tx, table.OrganizationID, migration.Id)
+	})
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+
+	return report, nil
+}
+
+func validateFieldTypeMigration(
+	dataModel models.DataModel,
+	table models.TableMetadata,
+	field models.FieldMetadata,
+	isUnique bool,
+	input models.CreateFieldTypeMigrationInput,
+) error {
+	if field.Name == "object_id" || field.Name == "updated_at" {
+		return errors.Wrap(models.BadParameterError, "the type of the `object_id` and `updated_at` fields cannot be changed")
+	}
+	if field.Archived {
+		return errors.Wrap(models.BadParameterError, "the type of an archived field cannot be changed")
+	}
+	if !models.CanConvertFieldType(field.DataType, input.DataType) {
+		return errors.Wrapf(models.BadParameterError, "cannot convert a field of type %s to %s",
+			field.DataType, input.DataType)
+	}
+	if input.TimestampFormat != nil && input.DataType != models.Timestamp {
+		return errors.Wrap(models.BadParameterError, "a timestamp format can only be given when converting to a timestamp")
+	}
+	if input.NullOnFailure && !field.Nullable {
+		return errors.Wrap(models.BadParameterError, "values of a required field cannot be set to null on failure")
+	}
+	if field.IsEnum {
+		return errors.Wrap(models.BadParameterError, "the type of an enum field cannot be changed")
+	}
+	if field.DerivedExpression != nil {
+		return errors.Wrap(models.BadParameterError, "the type of a derived field cannot be changed, remove its derivation first")
+	}
+	if field.Constraints != nil {
+		if err := field.Constraints.Validate(input.DataType); err != nil {
+			return errors.Wrapf(err, "the constraints of field %q do not apply to type %s", field.Name, input.DataType)
+		}
+	}
+
+	if isUnique {
+		return errors.Wrap(models.BadParameterError, "the type of a unique field cannot be changed")
+	}
+	if _, ok := dataModel.Tables[table.Name]; !ok {
+		return errors.Wrapf(models.NotFoundError, "table %s not found in data model", table.Name)
+	}
+
+	migrated := withFieldDataType(dataModel, table.Name, field.Name, input.DataType)
+	if err := models.ValidateField(migrated.Tables[table.Name].Fields[field.Name]); err != nil {
+		return err
+	}
+	return runTableSemanticValidation(migrated.Tables[table.Name], migrated)
+}
+
+// withFieldDataType returns a copy of the data model where the field has the given data type.
+func withFieldDataType(dataModel models.DataModel, tableName, fieldName string, dataType models.DataType) models.DataModel {
+	out := dataModel.Copy()
+	if table, ok := out.Tables[tableName]; ok {
+		if field, ok := table.Fields[fieldName]; ok {
+			field.DataType = dataType
+			table.Fields[fieldName] = field
+		}
+	}
+	return out
+}
+
+// fieldTypeMigrationConflicts lists what depends on the current type of the field. Formulas using
+// the field are evaluated on a dry run payload with both the current and the new type of the field,
+// and are only reported if they error or return a different type of value with the new one.
+func (uc DataModelFieldTypeUsecase) fieldTypeMigrationConflicts(
+	ctx context.Context,
+	exec repositories.Executor,
+	dataModel models.DataModel,
+	table models.TableMetadata,
+	field models.FieldMetadata,
+	dataType models.DataType,
+) (models.FieldTypeMigrationReport, error) {
+	report := models.NewFieldTypeMigrationReport()
+	orgId := table.OrganizationID
+
+	report.Conflicts.ContinuousScreening = field.FTMProperty != nil
+	report.Conflicts.PrimaryOrderingField = table.PrimaryOrderingField == field.Name
+
+	// Navigation options rely on indexes of the field, which would be left on the previous column
+	for _, t := range dataModel.Tables {
+		for _, option := range t.NavigationOptions {
+			if option.SourceFieldId == field.ID || option.FilterFieldId == field.ID ||
+				option.OrderingFieldId == field.ID {
+				report.Conflicts.NavigationOptions += 1
+			}
+		}
+	}
+
+	links, err := uc.dataModelRepository.GetLinks(ctx, exec, orgId)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	for _, link := range links {
+		if (link.ParentTableId == table.ID && link.ParentFieldName == field.Name) ||
+			(link.ChildTableId == table.ID && link.ChildFieldName == field.Name) {
+			report.Conflicts.Links.Insert(link.Id)
+		}
+	}
+
+	pivots, err := uc.dataModelRepository.ListPivots(ctx, exec, orgId, nil, false, false)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	for _, pivot := range pivots {
+		if pivot.FieldId != nil && *pivot.FieldId == field.ID {
+			report.Conflicts.Pivots.Insert(pivot.Id.String())
+		}
+	}
+
+	// Exported data keeps the type of the field it was first exported with
+	analyticsSettings, err := uc.analyticsSettingsRepository.GetAnalyticsSettings(ctx, exec, orgId)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	for _, setting := range analyticsSettings {
+		if setting.TriggerObjectType == table.Name && slices.Contains(setting.TriggerFields, field.Name) {
+			report.Conflicts.AnalyticsSettings += 1
+			continue
+		}
+		for _, dbField := range setting.DbFields {
+			tableRef := setting.TriggerObjectType
+			for _, linkName := range dbField.Path {
+				for _, link := range links {
+					if link.ChildTableName == tableRef && link.Name == linkName {
+						tableRef = link.ParentTableName
+						break
+					}
+				}
+			}
+			if len(dbField.Path) > 0 && tableRef == table.Name && dbField.Name == field.Name {
+				report.Conflicts.AnalyticsSettings += 1
+			}
+		}
+	}
+
+	scenarioList, err := uc.scenarioRepository.ListScenariosOfOrganization(ctx, exec, orgId, "")
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	scenarioMap := make(map[string]models.Scenario, len(scenarioList))
+	for _, s := range scenarioList {
+		scenarioMap[s.Id] = s
+	}
+
+	regressions := fieldTypeRegressionChecker{
+		factory:   uc.astEvaluationEnvironmentFactory,
+		current:   dataModel,
+		migrated:  withFieldDataType(dataModel, table.Name, field.Name, dataType),
+		envs:      make(map[string]*fieldTypeRegressionEnvs),
+		scenarios: scenarioMap,
+	}
+	tableMetadata := table
+	fieldMetadata := field
+	regresses := func(ctx context.Context, scenarioId string, node *ast.Node) bool {
+		scenario := scenarioMap[scenarioId]
+		return isRefUsedInAst(node, scenario.TriggerObjectType, links, tableMetadata, &fieldMetadata) &&
+			regressions.regresses(ctx, scenarioId, node)
+	}
+
+	iterations, err := uc.iterationsRepository.ListAllRulesAndScreenings(ctx, exec, orgId)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	for _, it := range iterations {
+		scenario := scenarioMap[it.ScenarioId.String()]
+		if it.Version != nil && !scenario.ServesIteration(it.ScenarioIterationId.String()) {
+			continue
+		}
+
+		iterationId := it.ScenarioIterationId.String()
+		iterationReport, ok := report.Conflicts.ScenarioIterations[iterationId]
+		if !ok {
+			name := scenario.Name
+			if it.Version != nil {
+				name = fmt.Sprintf("%s (v%d)", scenario.Name, *it.Version)
+			}
+			iterationReport = &models.DataModelDeleteFieldConflictIteration{
+				Name:       name,
+				ScenarioId: scenario.Id,
+				Draft:      it.Version == nil,
+				Rules:      set.New[string](0),
+				Screening:  set.New[string](0),
+			}
+		}
+
+		if regresses(ctx, scenario.Id, it.TriggerAst) {
+			iterationReport.TriggerCondition = true
+		}
+		if regresses(ctx, scenario.Id, it.RuleAst) {
+			iterationReport.Rules.Insert(it.RuleId.String())
+		}
+		if regresses(ctx, scenario.Id, it.ScreeningTriggerAst) ||
+			regresses(ctx, scenario.Id, it.ScreeningCounterpartyAst) {
+			iterationReport.Screening.Insert(it.RuleId.String())
+		}
+		for _, sc := range it.ScreeningAst {
+			if regresses(ctx, scenario.Id, &sc) {
+				iterationReport.Screening.Insert(it.RuleId.String())
+			}
+		}
+
+		if iterationReport.TriggerCondition || iterationReport.Rules.Size() > 0 || iterationReport.Screening.Size() > 0 {
+			report.Conflicts.ScenarioIterations[iterationId] = iterationReport
+			report.References[scenario.Id] = scenario.Name
+			report.References[iterationId] = iterationReport.Name
+			report.References[it.RuleId.String()] = it.Name
+		}
+	}
+
+	workflows, err := uc.workflowRepository.ListAllOrgWorkflows(ctx, exec, orgId)
+	if err != nil {
+		return models.FieldTypeMigrationReport{}, err
+	}
+	for _, wk := range workflows {
+		scenarioId := wk.ScenarioId.String()
+
+		for _, cond := range wk.Conditions {
+			if cond.Function != models.WorkflowPayloadEvaluates {
+				continue
+			}
+			var params dto.WorkflowConditionEvaluatesParams
+			if err := json.Unmarshal(cond.Params, &params); err != nil {
+				return models.FieldTypeMigrationReport{}, err
+			}
+			expression, err := dto.AdaptASTNode(params.Expression)
+			if err != nil {
+				return models.FieldTypeMigrationReport{}, err
+			}
+			if regresses(ctx, scenarioId, &expression) {
+				report.Conflicts.Workflows.Insert(wk.Id.String())
+				report.References[wk.Id.String()] = wk.Name
+			}
+		}
+
+		for _, act := range wk.Actions {
+			if act.Action != models.WorkflowCreateCase && act.Action != models.WorkflowAddToCaseIfPossible {
+				continue
+			}
+			action, err := models.ParseWorkflowAction[dto.WorkflowActionCaseParams](act)
+			if err != nil {
+				return models.FieldTypeMigrationReport{}, err
+			}
+			if action.Params.TitleTemplate == nil {
+				continue
+			}
+			titleTemplate, err := dto.AdaptASTNode(*action.Params.TitleTemplate)
+			if err != nil {
+				return models.FieldTypeMigrationReport{}, err
+			}
+			if regresses(ctx, scenarioId, &titleTemplate) {
+				report.Conflicts.Workflows.Insert(wk.Id.String())
+				report.References[wk.Id.String()] = wk.Name
+			}
+		}
+	}
+
+	return report, nil
+}
+
+type fieldTypeRegressionEnvs struct {
+	current  ast_eval.AstEvaluationEnvironment
+	migrated ast_eval.AstEvaluationEnvironment
+	valid    bool
+}
+
+// fieldTypeRegressionChecker evaluates formulas against the current data model and the data model
+// with the migrated field, reusing the dry run environments of each scenario.
+type fieldTypeRegressionChecker struct {
+	factory   ast_eval.AstEvaluationEnvironmentFactory
+	current   models.DataModel
+	migrated  models.DataModel
+	envs      map[string]*fieldTypeRegressionEnvs
+	scenarios map[string]models.Scenario
+}
+
+func (c fieldTypeRegressionChecker) regresses(ctx context.Context, scenarioId string, node *ast.Node) bool {
+	envs, ok := c.envs[scenarioId]
+	if !ok {
+		envs = &fieldTypeRegressionEnvs{}
+		scenario := c.scenarios[scenarioId]
+		current, errCurrent := scenarios.MakeDryRunEnvironmentWithDataModel(c.factory, scenario, c.current)
+		migrated, errMigrated := scenarios.MakeDryRunEnvironmentWithDataModel(c.factory, scenario, c.migrated)
+		if errCurrent == nil && errMigrated == nil {
+			envs.current, envs.migrated, envs.valid = current, migrated, true
+		}
+		c.envs[scenarioId] = envs
+	}
+	// The scenario does not evaluate today, the type of the field makes no difference
+	if !envs.valid {
+		return false
+	}
+
+	before, _ := ast_eval.EvaluateAst(ctx, nil, envs.current, *node)
+	after, _ := ast_eval.EvaluateAst(ctx, nil, envs.migrated, *node)
+
+	return len(after.FlattenErrors()) > len(before.FlattenErrors()) ||
+		reflect.TypeOf(after.ReturnValue) != reflect.TypeOf(before.ReturnValue)
+}
+
+func (uc DataModelFieldTypeUsecase) ListFieldTypeMigrations(ctx context.Context, fieldId string) ([]models.FieldTypeMigration, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	field, err := uc.dataModelRepository.GetDataModelField(ctx, exec, fieldId)
+	if err != nil {
+		return nil, err
+	}
+	table, err := uc.dataModelRepository.GetDataModelTable(ctx, exec, field.TableId)
+	if err != nil {
+		return nil, err
+	}
+	if err := errors.Join(uc.enforceSecurity.ReadDataModel(),
+		uc.enforceSecurity.ReadOrganization(table.OrganizationID)); err != nil {
+		return nil, err
+	}
+
+	return uc.migrationRepository.ListFieldTypeMigrations(ctx, exec, fieldId)
+}
+
+// GetFieldTypeMigration returns the migration along with the first rows that failed conversion.
+func (uc DataModelFieldTypeUsecase) GetFieldTypeMigration(ctx context.Context, migrationId uuid.UUID) (
+	models.FieldTypeMigration, []models.FieldTypeMigrationFailure, error,
+) {
+	exec := uc.executorFactory.NewExecutor()
+
+	migration, err := uc.migrationRepository.GetFieldTypeMigration(ctx, exec, migrationId)
+	if err != nil {
+		return models.FieldTypeMigration{}, nil, err
+	}
+	if err := errors.Join(uc.enforceSecurity.ReadDataModel(),
+		uc.enforceSecurity.ReadOrganization(migration.OrganizationId)); err != nil {
+		return models.FieldTypeMigration{}, nil, err
+	}
+
+	failures, err := uc.migrationRepository.ListFieldTypeMigrationFailures(ctx, exec, migrationId,
+		fieldTypeMigrationListedFailures)
+	if err != nil {
+		return models.FieldTypeMigration{}, nil, err
+	}
+
+	return migration, failures, nil
+}
+
+// RunFieldTypeMigration converts the values of the field into the shadow column until the time
+// budget runs out, and swaps the columns once every row has been converted. It returns whether
+// the migration is over, successfully or not.
+func (uc DataModelFieldTypeUsecase) RunFieldTypeMigration(ctx context.Context, migrationId uuid.UUID) (bool, error) {
+	logger := utils.LoggerFromContext(ctx)
+	exec := uc.executorFactory.NewExecutor()
+
+	migration, err := uc.migrationRepository.GetFieldTypeMigration(ctx, exec, migrationId)
+	if errors.Is(err, models.NotFoundError) {
+		// The field, and its migrations with it, was deleted
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if migration.Status.IsTerminal() {
+		return true, nil
+	}
+
+	field, err := uc.dataModelRepository.GetDataModelField(ctx, exec, migration.FieldId)
+	if err != nil {
+		return false, err
+	}
+	if field.Archived || field.Name != migration.FieldName || field.DataType != migration.SourceDataType {
+		return true, uc.failFieldTypeMigration(ctx, migration, "the field was modified during the migration")
+	}
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, migration.OrganizationId)
+	if err != nil {
+		return false, err
+	}
+
+	if migration.Status == models.FieldTypeMigrationPending {
+		if err := uc.clientDbRepository.AddFieldTypeMigrationColumn(ctx, clientExec, migration.TableName,
+			migration.ShadowColumn(), migration.TargetDataType); err != nil {
+			return false, errors.Wrap(err, "could not add the shadow column of the field type migration")
+		}
+	}
+
+	deadline := time.Now().Add(fieldTypeMigrationTimeBudget)
+	for {
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		lastRowId, count, failed, err := uc.convertFieldTypeMigrationChunk(ctx, clientExec, migration,
+			migration.LastRowId, false)
+		if err != nil {
+			return false, err
+		}
+		if count == 0 {
+			break
+		}
+
+		if err := uc.migrationRepository.UpdateFieldTypeMigrationProgress(ctx, exec, migration.Id,
+			lastRowId, int64(count), failed); err != nil {
+			return false, err
+		}
+		migration.LastRowId = lastRowId
+		migration.RowsProcessed += int64(count)
+		migration.RowsFailed += failed
+
+		if migration.RowsFailed > models.FieldTypeMigrationMaxFailures {
+			return true, uc.failFieldTypeMigration(ctx, migration,
+				fmt.Sprintf("more than %d rows failed conversion", models.FieldTypeMigrationMaxFailures))
+		}
+		if count < fieldTypeMigrationChunkSize {
+			break
+		}
+	}
+
+	// Rows ingested since the backfill started are converted before locking the table, so that it is
+	// locked for as short as possible.
+	failed, err := uc.catchUpFieldTypeMigration(ctx, clientExec, migration)
+	if err != nil {
+		return false, err
+	}
+	migration.RowsFailed += failed
+
+	failure, err := uc.commitFieldTypeMigration(ctx, migration)
+	if err != nil {
+		return false, err
+	}
+	if failure != "" {
+		return true, uc.failFieldTypeMigration(ctx, migration, failure)
+	}
+
+	logger.InfoContext(ctx, "field type migration completed", "migration_id", migration.Id,
+		"rows_processed", migration.RowsProcessed, "rows_failed", migration.RowsFailed)
+	return true, nil
+}
+
+// convertFieldTypeMigrationChunk converts the next rows of the table, recording the rows that fail
+// conversion. It returns the id of the last row read, the number of rows read and the number of
+// new failures.
+func (uc DataModelFieldTypeUsecase) convertFieldTypeMigrationChunk(
+	ctx context.Context,
+	clientExec repositories.Executor,
+	migration models.FieldTypeMigration,
+	afterId *uuid.UUID,
+	unconvertedOnly bool,
+) (*uuid.UUID, int, int64, error) {
+	rows, err := uc.clientDbRepository.ListFieldTypeMigrationRows(ctx, clientExec, migration.TableName,
+		migration.FieldName, migration.ShadowColumn(), afterId, fieldTypeMigrationChunkSize, unconvertedOnly)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(rows) == 0 {
+		return afterId, 0, 0, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows))
+	values := make([]any, 0, len(rows))
+	failures := make([]models.FieldTypeMigrationFailure, 0)
+	for _, row := range rows {
+		value, err := models.ConvertFieldValue(row.Value, migration.TargetDataType, migration.TimestampFormat)
+		if err != nil {
+			failures = append(failures, models.FieldTypeMigrationFailure{
+				MigrationId: migration.Id,
+				RowId:       row.Id,
+				ObjectId:    row.ObjectId,
+				Value:       fmt.Sprint(row.Value),
+				Error:       err.Error(),
+			})
+			continue
+		}
+		ids = append(ids, row.Id)
+		values = append(values, value)
+	}
+
+	if err := uc.clientDbRepository.WriteFieldTypeMigrationValues(ctx, clientExec, migration.TableName,
+		migration.ShadowColumn(), migration.TargetDataType, ids, values); err != nil {
+		return nil, 0, 0, err
+	}
+	failed, err := uc.migrationRepository.CreateFieldTypeMigrationFailures(ctx,
+		uc.executorFactory.NewExecutor(), failures)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return &rows[len(rows)-1].Id, len(rows), failed, nil
+}
+
+// catchUpFieldTypeMigration converts the rows that have no converted value yet: rows ingested
+// during the backfill, and rows that previously failed conversion. It returns the number of new
+// failures, which are also added to the migration.
+func (uc DataModelFieldTypeUsecase) catchUpFieldTypeMigration(
+	ctx context.Context,
+	clientExec repositories.Executor,
+	migration models.FieldTypeMigration,
+) (int64, error) {
+	var afterId *uuid.UUID
+	var totalFailed int64
+	for {
+		lastRowId, count, failed, err := uc.convertFieldTypeMigrationChunk(ctx, clientExec, migration, afterId, true)
+		if err != nil {
+			return 0, err
+		}
+		totalFailed += failed
+		if count < fieldTypeMigrationChunkSize {
+			break
+		}
+		afterId = lastRowId
+	}
+
+	if totalFailed > 0 {
+		if err := uc.migrationRepository.UpdateFieldTypeMigrationProgress(ctx, uc.executorFactory.NewExecutor(),
+			migration.Id, migration.LastRowId, 0, totalFailed); err != nil {
+			return 0, err
+		}
+	}
+	return totalFailed, nil
+}
+
+var errFieldTypeMigrationAborted = errors.New("field type migration aborted")
+
+// commitFieldTypeMigration swaps the shadow column with the column of the field while writes to the
+// table are blocked, after checking one last time that nothing breaks with the new type of the
+// field. It returns the reason why the migration failed, if it did.
+func (uc DataModelFieldTypeUsecase) commitFieldTypeMigration(ctx context.Context, migration models.FieldTypeMigration) (string, error) {
+	var failure string
+	abort := func(reason string) error {
+		failure = reason
+		return errFieldTypeMigrationAborted
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+	indexes := make([]models.ConcreteIndex, 0)
+
+	err := uc.transactionFactory.TransactionInOrgSchema(ctx, migration.OrganizationId, func(clientTx repositories.Transaction) error {
+		if err := uc.clientDbRepository.LockTableForFieldTypeMigration(ctx, clientTx, migration.TableName); err != nil {
+			return err
+		}
+
+		failed, err := uc.catchUpFieldTypeMigration(ctx, clientTx, migration)
+		if err != nil {
+			return err
+		}
+		migration.RowsFailed += failed
+
+		if migration.RowsFailed > 0 && !migration.NullOnFailure {
+			return abort(fmt.Sprintf("%d rows failed conversion", migration.RowsFailed))
+		}
+
+		field, err := uc.dataModelRepository.GetDataModelField(ctx, exec, migration.FieldId)
+		if err != nil {
+			return err
+		}
+		if field.Archived || field.Name != migration.FieldName || field.DataType != migration.SourceDataType {
+			return abort("the field was modified during the migration")
+		}
+		table, err := uc.dataModelRepository.GetDataModelTable(ctx, exec, field.TableId)
+		if err != nil {
+			return err
+		}
+		dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, migration.OrganizationId, false, false)
+		if err != nil {
+			return err
+		}
+		report, err := uc.fieldTypeMigrationConflicts(ctx, exec, dataModel, table, field, migration.TargetDataType)
+		if err != nil {
+			return err
+		}
+		if report.HasConflicts() {
+			return abort("the field started being used in a way that is not compatible with its new type")
+		}
+
+		// Indexes follow the column they were created on, those of the field are created again on the
+		// new column once it is committed.
+		allIndexes, err := uc.clientDbRepository.ListAllIndexes(ctx, clientTx)
+		if err != nil {
+			return err
+		}
+		for _, index := range allIndexes {
+			if index.TableName == migration.TableName && (slices.Contains(index.Indexed, migration.FieldName) ||
+				slices.Contains(index.Included, migration.FieldName)) {
+				indexes = append(indexes, models.ConcreteIndex{
+					TableName: index.TableName,
+					Indexed:   index.Indexed,
+					Included:  index.Included,
+					Type:      index.Type,
+				})
+			}
+		}
+
+		if err := uc.organizationSchemaRepository.RenameField(ctx, clientTx, migration.TableName,
+			migration.FieldName); err != nil {
+			return err
+		}
+		if err := uc.clientDbRepository.RenameFieldTypeMigrationColumn(ctx, clientTx, migration.TableName,
+			migration.ShadowColumn(), migration.FieldName); err != nil {
+			return err
+		}
+
+		return uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+			if err := uc.migrationRepository.UpdateDataModelFieldDataType(ctx, tx, migration.FieldId,
+				migration.TargetDataType); err != nil {
+				return err
+			}
+			if err := uc.migrationRepository.CompleteFieldTypeMigration(ctx, tx, migration.Id,
+				models.FieldTypeMigrationCompleted, nil); err != nil {
+				return err
+			}
+			return uc.taskQueueRepository.EnqueueCreateIndexTask(ctx, tx, migration.OrganizationId, indexes)
+		})
+	})
+	if errors.Is(err, errFieldTypeMigrationAborted) {
+		return failure, nil
+	}
+	return "", err
+}
+
+func (uc DataModelFieldTypeUsecase) failFieldTypeMigration(ctx context.Context, migration models.FieldTypeMigration, reason string) error {
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "field type migration failed",
+		"migration_id", migration.Id, "reason", reason)
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, migration.OrganizationId)
+	if err != nil {
+		return err
+	}
+	if err := uc.clientDbRepository.DropFieldTypeMigrationColumn(ctx, clientExec, migration.TableName,
+		migration.ShadowColumn()); err != nil {
+		return err
+	}
+
+	return uc.migrationRepository.CompleteFieldTypeMigration(ctx, uc.executorFactory.NewExecutor(),
+		migration.Id, models.FieldTypeMigrationFailed, &reason)
+}
+
+// FieldTypeMigrationWorker is a River worker that runs field type migrations, resuming them until
+// they are over.
+type FieldTypeMigrationWorker struct {
+	river.WorkerDefaults[models.FieldTypeMigrationArgs]
+	usecase DataModelFieldTypeUsecase
+}
+
+func NewFieldTypeMigrationWorker(usecase DataModelFieldTypeUsecase) *FieldTypeMigrationWorker {
+	return &FieldTypeMigrationWorker{usecase: usecase}
+}
+
+func (w *FieldTypeMigrationWorker) Timeout(job *river.Job[models.FieldTypeMigrationArgs]) time.Duration {
+	return 2 * fieldTypeMigrationTimeBudget
+}
+
+func (w *FieldTypeMigrationWorker) Work(ctx context.Context, job *river.Job[models.FieldTypeMigrationArgs]) error {
+	done, err := w.usecase.RunFieldTypeMigration(ctx, job.Args.MigrationId)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return river.JobSnooze(fieldTypeMigrationSnoozeDelay)
+	}
+	return nil
+}
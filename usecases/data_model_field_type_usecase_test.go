@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFieldTypeMigration(t *testing.T) {
+	table := models.TableMetadata{ID: "table_id", Name: "accounts"}
+	dataModel := models.DataModel{
+		Tables: map[string]models.Table{
+			"accounts": {
+				ID:   "table_id",
+				Name: "accounts",
+				Fields: map[string]models.Field{
+					"object_id":      {Name: "object_id", DataType: models.String},
+					"account_number": {Name: "account_number", DataType: models.Int, Nullable: true},
+					"opened_at":      {Name: "opened_at", DataType: models.String},
+				},
+			},
+		},
+	}
+	accountNumber := models.FieldMetadata{ID: "field_id", Name: "account_number", DataType: models.Int, Nullable: true}
+	openedAt := models.FieldMetadata{ID: "field_id_2", Name: "opened_at", DataType: models.String}
+
+	tests := []struct {
+		name     string
+		field    models.FieldMetadata
+		isUnique bool
+		input    models.CreateFieldTypeMigrationInput
+		valid    bool
+	}{
+		{
+			name:  "int to string",
+			field: accountNumber,
+			input: models.CreateFieldTypeMigrationInput{DataType: models.String, NullOnFailure: true},
+			valid: true,
+		},
+		{
+			name:  "string to timestamp with a format",
+			field: openedAt,
+			input: models.CreateFieldTypeMigrationInput{DataType: models.Timestamp, TimestampFormat: utils.Ptr("02/01/2006")},
+			valid: true,
+		},
+		{
+			name:  "internal field",
+			field: models.FieldMetadata{Name: "object_id", DataType: models.String},
+			input: models.CreateFieldTypeMigrationInput{DataType: models.Int},
+		},
+		{
+			name:  "unsupported conversion",
+			field: accountNumber,
+			input: models.CreateFieldTypeMigrationInput{DataType: models.Timestamp},
+		},
+		{
+			name:  "timestamp format on another type",
+			field: accountNumber,
+			input: models.CreateFieldTypeMigrationInput{DataType: models.String, TimestampFormat: utils.Ptr("2006")},
+		},
+		{
+			name:  "null on failure on a required field",
+			field: openedAt,
+			input: models.CreateFieldTypeMigrationInput{DataType: models.Timestamp, NullOnFailure: true},
+		},
+		{
+			name:     "unique field",
+			field:    accountNumber,
+			isUnique: true,
+			input:    models.CreateFieldTypeMigrationInput{DataType: models.String},
+		},
+		{
+			name: "constraints not applying to the new type",
+			field: models.FieldMetadata{
+				Name: "account_number", DataType: models.Int,
+				Constraints: &models.FieldConstraints{Min: utils.Ptr(0.0)},
+			},
+			input: models.CreateFieldTypeMigrationInput{DataType: models.String},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFieldTypeMigration(dataModel, table, tt.field, tt.isUnique, tt.input)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
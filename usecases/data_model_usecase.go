@@ -3,6 +3,7 @@ package usecases
 import (
 	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"regexp"
@@ -349,6 +350,7 @@ func (usecase *usecase) CreateDataModelTable(
 				FTMProperty:  f.FTMProperty,
 				Metadata:     f.Metadata,
 				SemanticType: f.SemanticType,
+				Constraints:  f.Constraints,
 			}
 
 			fieldId, err := usecase.createDataModelFieldWithExec(
@@ -1121,6 +1123,24 @@ func (usecase *usecase) createDataModelFieldWithExec(ctx context.Context,
 		return "", errors.Wrap(models.BadParameterError,
 			fmt.Sprintf("invalid field %q: %s", field.Name, err.Error()))
 	}
+	// Constraints are stored in the metadata of the field, where they may also have been given
+	constraints := field.Constraints
+	if constraints == nil {
+		var err error
+		if constraints, err = models.FieldConstraintsFromMetadata(field.Metadata); err != nil {
+			return "", err
+		}
+	}
+	if constraints != nil {
+		if err := constraints.Validate(field.DataType); err != nil {
+			return "", errors.Wrapf(err, "invalid constraints on field %q", field.Name)
+		}
+		metadata, err := models.SetFieldConstraintsInMetadata(field.Metadata, constraints)
+		if err != nil {
+			return "", err
+		}
+		field.Metadata = metadata
+	}
 
 	if err := usecase.dataModelRepository.CreateDataModelField(ctx, exec, table.OrganizationID, fieldId, field); err != nil {
 		return "", err
@@ -1227,6 +1247,10 @@ func (usecase *usecase) UpdateDataModelField(ctx context.Context, fieldID string
 			return err
 		}
 
+		if input.Metadata, err = fieldMetadataUpdate(field, input); err != nil {
+			return err
+		}
+
 		// update the field (data_model_field row)
 		if err := usecase.dataModelRepository.UpdateDataModelField(ctx, tx, fieldID, input); err != nil {
 			return err
@@ -1264,6 +1288,41 @@ func (usecase *usecase) UpdateDataModelField(ctx context.Context, fieldID string
 	return err
 }
 
+// fieldMetadataUpdate returns the metadata of the field after the update, nil if it is unchanged.
+// Constraints are stored in the metadata: updating the metadata alone keeps the constraints of the
+// field, unless the new metadata holds constraints of its own.
+func fieldMetadataUpdate(field models.FieldMetadata, input models.UpdateFieldInput) (*json.RawMessage, error) {
+	if input.Metadata == nil && !input.Constraints.Set {
+		return nil, nil
+	}
+
+	metadata, constraints := field.Metadata, field.Constraints
+	if input.Metadata != nil {
+		metadata = *input.Metadata
+		metadataConstraints, err := models.FieldConstraintsFromMetadata(metadata)
+		if err != nil {
+			return nil, err
+		}
+		if metadataConstraints != nil {
+			constraints = metadataConstraints
+		}
+	}
+	if input.Constraints.Set {
+		constraints = input.Constraints.Ptr()
+	}
+
+	if constraints != nil {
+		if err := constraints.Validate(field.DataType); err != nil {
+			return nil, errors.Wrapf(err, "invalid constraints on field %q", field.Name)
+		}
+	}
+	metadata, err := models.SetFieldConstraintsInMetadata(metadata, constraints)
+	if err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
+
 func validateFieldUpdateRules(
 	dataModel models.DataModel,
 	field models.FieldMetadata,
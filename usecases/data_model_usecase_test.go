@@ -1061,6 +1061,43 @@ func (suite *DatamodelUsecaseTestSuite) TestUpdateDataModelField_nominal_update_
 	suite.AssertExpectations()
 }
 
+func (suite *DatamodelUsecaseTestSuite) TestUpdateDataModelField_metadata_keeps_constraints() {
+	fieldId := "fieldId"
+	tableId := "tableId"
+	minValue := 0.0
+	newMetadata := json.RawMessage(`{"owner": "risk"}`)
+	input := models.UpdateFieldInput{Metadata: &newMetadata}
+	usecase := suite.makeUsecase()
+	suite.transactionFactory.On("Transaction", suite.ctx, mock.Anything).Return(nil)
+	suite.dataModelRepository.On("GetDataModelField", suite.ctx, suite.transaction, fieldId).
+		Return(models.FieldMetadata{
+			Name: "value", DataType: models.Float, ID: fieldId, TableId: tableId,
+			Metadata:    json.RawMessage(`{"constraints": {"min": 0}}`),
+			Constraints: &models.FieldConstraints{Min: &minValue},
+		}, nil)
+	suite.dataModelRepository.On("GetDataModelTable", suite.ctx, suite.transaction, tableId).
+		Return(models.TableMetadata{
+			Name:           "transactions",
+			OrganizationID: suite.organizationId,
+		}, nil)
+	suite.enforceSecurity.On("WriteDataModel", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel",
+		suite.ctx, suite.transaction, suite.organizationId, false, mock.Anything).
+		Return(suite.dataModel, nil)
+	suite.clientDbIndexEditor.On("ListAllUniqueIndexes", suite.ctx, suite.organizationId).
+		Return(suite.uniqueIndexes, nil)
+	suite.dataModelRepository.On("UpdateDataModelField", suite.ctx, suite.transaction, fieldId,
+		mock.MatchedBy(func(input models.UpdateFieldInput) bool {
+			return input.Metadata != nil && string(*input.Metadata) == `{"constraints":{"min":0},"owner":"risk"}`
+		})).
+		Return(nil)
+
+	err := usecase.UpdateDataModelField(suite.ctx, fieldId, input)
+	suite.Require().NoError(err, "no error expected")
+
+	suite.AssertExpectations()
+}
+
 func (suite *DatamodelUsecaseTestSuite) TestUpdateDataModelField_nominal_update_enum() {
 	fieldId := "fieldId"
 	tableId := "tableId"
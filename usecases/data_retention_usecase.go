@@ -0,0 +1,515 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/usecases/worker_jobs"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-set/v2"
+	"github.com/riverqueue/river"
+	"gocloud.dev/gcerrors"
+)
+
+const (
+	DATA_RETENTION_WORKER_INTERVAL = 6 * time.Hour
+
+	dataRetentionRowsBatchSize      = 1000
+	dataRetentionDecisionsBatchSize = 500
+	dataRetentionRunsListLimit      = 50
+	// A run stops deleting after that duration, the next run resumes where it stopped.
+	dataRetentionTimeBudget = 20 * time.Minute
+)
+
+type dataRetentionRepository interface {
+	GetOffloadedDecisionRuleKey(orgId uuid.UUID, decisionId, ruleId, outcome string, createdAt time.Time) string
+	GetOffloadedDecisionEvaluationKey(orgId uuid.UUID, decision models.Decision) string
+
+	CreateDataRetentionPolicy(ctx context.Context, exec repositories.Executor,
+		policy models.DataRetentionPolicy) (models.DataRetentionPolicy, error)
+	GetDataRetentionPolicy(ctx context.Context, exec repositories.Executor, id uuid.UUID) (models.DataRetentionPolicy, error)
+	ListDataRetentionPolicies(ctx context.Context, exec repositories.Executor, orgId uuid.UUID) ([]models.DataRetentionPolicy, error)
+	UpdateDataRetentionPolicy(ctx context.Context, exec repositories.Executor,
+		input models.UpdateDataRetentionPolicyInput) (models.DataRetentionPolicy, error)
+	DeleteDataRetentionPolicy(ctx context.Context, exec repositories.Executor, id uuid.UUID) error
+	CreateDataRetentionRun(ctx context.Context, exec repositories.Executor, run models.DataRetentionRun) error
+	ListDataRetentionRuns(ctx context.Context, exec repositories.Executor, policyId uuid.UUID,
+		limit int) ([]models.DataRetentionRun, error)
+	ListDataRetentionDecisions(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		before time.Time, limit int) ([]models.DataRetentionDecision, error)
+	ListDataSubjectErasureDecisionRules(ctx context.Context, exec repositories.Executor,
+		decisionIds []uuid.UUID) ([]models.DataSubjectErasureDecisionRule, error)
+	DeleteDataRetentionDecisions(ctx context.Context, exec repositories.Executor, decisionIds []uuid.UUID) (int64, error)
+}
+
+type dataRetentionClientDbRepository interface {
+	DeleteDataRetentionSupersededRows(ctx context.Context, exec repositories.Executor, tableName string,
+		before time.Time, limit int) (int64, error)
+	DeleteDataRetentionExpiredObjects(ctx context.Context, exec repositories.Executor, tableName string,
+		before time.Time, limit int) (int64, error)
+	GetDataRetentionIndexStatus(ctx context.Context, exec repositories.Executor, tableName string) (models.IndexStatus, error)
+	CreateDataRetentionIndexAsync(ctx context.Context, exec repositories.Executor, tableName string) error
+}
+
+type dataRetentionAggregatesLister interface {
+	GetRequiredIndices(ctx context.Context, organizationId uuid.UUID) ([]models.AggregateQueryFamily, error)
+}
+
+// DataRetentionUsecase manages the retention policies of an organization and enforces them from a
+// periodic job.
+type DataRetentionUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityOrganization
+
+	dataModelRepository repositories.DataModelRepository
+	retentionRepository dataRetentionRepository
+	clientDbRepository  dataRetentionClientDbRepository
+	aggregatesLister    dataRetentionAggregatesLister
+	blobRepository      repositories.BlobRepository
+
+	offloadingBucketUrl string
+}
+
+func NewDataRetentionUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityOrganization,
+	dataModelRepository repositories.DataModelRepository,
+	retentionRepository dataRetentionRepository,
+	clientDbRepository dataRetentionClientDbRepository,
+	aggregatesLister dataRetentionAggregatesLister,
+	blobRepository repositories.BlobRepository,
+	offloadingBucketUrl string,
+) DataRetentionUsecase {
+	return DataRetentionUsecase{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		enforceSecurity:     enforceSecurity,
+		dataModelRepository: dataModelRepository,
+		retentionRepository: retentionRepository,
+		clientDbRepository:  clientDbRepository,
+		aggregatesLister:    aggregatesLister,
+		blobRepository:      blobRepository,
+		offloadingBucketUrl: offloadingBucketUrl,
+	}
+}
+
+func (uc DataRetentionUsecase) CreateDataRetentionPolicy(
+	ctx context.Context,
+	input models.CreateDataRetentionPolicyInput,
+) (models.DataRetentionPolicy, error) {
+	if err := uc.enforceSecurity.WriteDataModel(input.OrganizationId); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	policy := models.DataRetentionPolicy{
+		Id:                     uuid.New(),
+		OrganizationId:         input.OrganizationId,
+		Target:                 input.Target,
+		TableId:                input.TableId,
+		SupersededVersionsDays: input.SupersededVersionsDays,
+		MaxAgeDays:             input.MaxAgeDays,
+		Enabled:                input.Enabled,
+	}
+	if err := policy.Validate(); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	exec := uc.executorFactory.NewExecutor()
+	if policy.TableId != nil {
+		dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, input.OrganizationId, false, true)
+		if err != nil {
+			return models.DataRetentionPolicy{}, err
+		}
+		if _, ok := dataRetentionTableById(dataModel, *policy.TableId); !ok {
+			return models.DataRetentionPolicy{}, errors.Wrap(models.NotFoundError, "table not found in the data model")
+		}
+	}
+
+	return uc.retentionRepository.CreateDataRetentionPolicy(ctx, exec, policy)
+}
+
+func (uc DataRetentionUsecase) ListDataRetentionPolicies(ctx context.Context, organizationId uuid.UUID) (
+	[]models.DataRetentionPolicy, error,
+) {
+	if err := uc.enforceSecurity.WriteDataModel(organizationId); err != nil {
+		return nil, err
+	}
+
+	return uc.retentionRepository.ListDataRetentionPolicies(ctx, uc.executorFactory.NewExecutor(), organizationId)
+}
+
+func (uc DataRetentionUsecase) GetDataRetentionPolicy(ctx context.Context, policyId uuid.UUID) (
+	models.DataRetentionPolicy, error,
+) {
+	policy, err := uc.retentionRepository.GetDataRetentionPolicy(ctx, uc.executorFactory.NewExecutor(), policyId)
+	if err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(policy.OrganizationId); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+func (uc DataRetentionUsecase) UpdateDataRetentionPolicy(
+	ctx context.Context,
+	input models.UpdateDataRetentionPolicyInput,
+) (models.DataRetentionPolicy, error) {
+	policy, err := uc.GetDataRetentionPolicy(ctx, input.Id)
+	if err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	policy.SupersededVersionsDays = input.SupersededVersionsDays
+	policy.MaxAgeDays = input.MaxAgeDays
+	policy.Enabled = input.Enabled
+	if err := policy.Validate(); err != nil {
+		return models.DataRetentionPolicy{}, err
+	}
+
+	return uc.retentionRepository.UpdateDataRetentionPolicy(ctx, uc.executorFactory.NewExecutor(), input)
+}
+
+func (uc DataRetentionUsecase) DeleteDataRetentionPolicy(ctx context.Context, policyId uuid.UUID) error {
+	if _, err := uc.GetDataRetentionPolicy(ctx, policyId); err != nil {
+		return err
+	}
+
+	return uc.retentionRepository.DeleteDataRetentionPolicy(ctx, uc.executorFactory.NewExecutor(), policyId)
+}
+
+// ListDataRetentionRuns reports what the latest runs of a policy reclaimed.
+func (uc DataRetentionUsecase) ListDataRetentionRuns(ctx context.Context, policyId uuid.UUID) (
+	[]models.DataRetentionRun, error,
+) {
+	if _, err := uc.GetDataRetentionPolicy(ctx, policyId); err != nil {
+		return nil, err
+	}
+
+	return uc.retentionRepository.ListDataRetentionRuns(ctx, uc.executorFactory.NewExecutor(),
+		policyId, dataRetentionRunsListLimit)
+}
+
+// RunDataRetention enforces the enabled policies of an organization, recording a run for each of
+// them. A failing policy is recorded and does not prevent the others from running.
+func (uc DataRetentionUsecase) RunDataRetention(ctx context.Context, organizationId uuid.UUID) error {
+	logger := utils.LoggerFromContext(ctx)
+	exec := uc.executorFactory.NewExecutor()
+	deadline := time.Now().Add(dataRetentionTimeBudget)
+
+	policies, err := uc.retentionRepository.ListDataRetentionPolicies(ctx, exec, organizationId)
+	if err != nil {
+		return err
+	}
+
+	var (
+		dataModel  *models.DataModel
+		aggregated *set.Set[string]
+	)
+
+	for _, policy := range policies {
+		if !policy.Enabled {
+			continue
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+
+		run := models.DataRetentionRun{
+			Id:             uuid.New(),
+			OrganizationId: organizationId,
+			PolicyId:       policy.Id,
+			StartedAt:      time.Now(),
+		}
+
+		switch policy.Target {
+		case models.DataRetentionTargetTable:
+			if dataModel == nil {
+				dm, err := uc.dataModelRepository.GetDataModel(ctx, exec, organizationId, false, true)
+				if err != nil {
+					return err
+				}
+				dataModel = &dm
+
+				aggregated, err = uc.aggregatedTables(ctx, organizationId)
+				if err != nil {
+					return err
+				}
+			}
+			err = uc.enforceTablePolicy(ctx, policy, *dataModel, aggregated, &run, deadline)
+		case models.DataRetentionTargetDecisions:
+			err = uc.enforceDecisionsPolicy(ctx, policy, &run, deadline)
+		}
+
+		if err != nil {
+			logger.WarnContext(ctx, "data retention policy failed",
+				"policy_id", policy.Id, "error", err.Error())
+			run.Error = utils.Ptr(err.Error())
+		}
+		run.FinishedAt = utils.Ptr(time.Now())
+
+		if err := uc.retentionRepository.CreateDataRetentionRun(ctx, exec, run); err != nil {
+			return err
+		}
+
+		logger.InfoContext(ctx, "data retention policy enforced", "policy_id", policy.Id,
+			"reclaimed", run.Reclaimed())
+	}
+
+	return nil
+}
+
+// aggregatedTables lists the tables that the live and canary iterations aggregate over. Aggregates
+// only read the live version of the objects, so superseded versions of those tables can still be
+// deleted, but deleting expired objects would change what the aggregates return.
+func (uc DataRetentionUsecase) aggregatedTables(ctx context.Context, organizationId uuid.UUID) (*set.Set[string], error) {
+	families, err := uc.aggregatesLister.GetRequiredIndices(ctx, organizationId)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not list the aggregates of live scenarios")
+	}
+
+	tables := set.New[string](len(families))
+	for _, family := range families {
+		tables.Insert(family.TableName)
+	}
+	return tables, nil
+}
+
+func (uc DataRetentionUsecase) enforceTablePolicy(
+	ctx context.Context,
+	policy models.DataRetentionPolicy,
+	dataModel models.DataModel,
+	aggregated *set.Set[string],
+	run *models.DataRetentionRun,
+	deadline time.Time,
+) error {
+	table, ok := dataRetentionTableById(dataModel, *policy.TableId)
+	if !ok {
+		return errors.Wrap(models.NotFoundError, "table not found in the data model")
+	}
+
+	db, err := uc.executorFactory.NewClientDbExecutor(ctx, policy.OrganizationId)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	if before := policy.SupersededBefore(now); before != nil {
+		ready, err := uc.ensureDataRetentionIndex(ctx, db, table.Name)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			run.SkippedReason = utils.Ptr(models.DataRetentionSkippedIndexPending)
+		}
+
+		for ready && time.Now().Before(deadline) {
+			deleted, err := uc.clientDbRepository.DeleteDataRetentionSupersededRows(ctx, db,
+				table.Name, *before, dataRetentionRowsBatchSize)
+			if err != nil {
+				return err
+			}
+			run.SupersededRowsDeleted += deleted
+			if deleted == 0 {
+				break
+			}
+		}
+	}
+
+	if before := policy.ExpiredBefore(now); before != nil {
+		if aggregated.Contains(table.Name) {
+			run.SkippedReason = utils.Ptr(models.DataRetentionSkippedLiveAggregates)
+			return nil
+		}
+
+		for time.Now().Before(deadline) {
+			deleted, err := uc.clientDbRepository.DeleteDataRetentionExpiredObjects(ctx, db,
+				table.Name, *before, dataRetentionRowsBatchSize)
+			if err != nil {
+				return err
+			}
+			run.ExpiredRowsDeleted += deleted
+			if deleted == 0 {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureDataRetentionIndex returns whether the index on the superseded versions of the table is
+// ready, and starts building it if it is missing. Without it, every batch of superseded versions
+// would be found with a scan of the whole table. Tables created before the retention policies were
+// introduced do not have it.
+func (uc DataRetentionUsecase) ensureDataRetentionIndex(ctx context.Context, db repositories.Executor, tableName string) (bool, error) {
+	status, err := uc.clientDbRepository.GetDataRetentionIndexStatus(ctx, db, tableName)
+	if err != nil {
+		return false, err
+	}
+
+	switch status {
+	case models.IndexStatusValid:
+		return true, nil
+	case models.IndexStatusPending:
+		return false, nil
+	default:
+		utils.LoggerFromContext(ctx).InfoContext(ctx, "creating the data retention index",
+			"table", tableName)
+		return false, uc.clientDbRepository.CreateDataRetentionIndexAsync(ctx, db, tableName)
+	}
+}
+
+// enforceDecisionsPolicy deletes the expired decisions, oldest first. The payloads offloaded for
+// them are deleted first, as their keys are derived from the decisions.
+func (uc DataRetentionUsecase) enforceDecisionsPolicy(
+	ctx context.Context,
+	policy models.DataRetentionPolicy,
+	run *models.DataRetentionRun,
+	deadline time.Time,
+) error {
+	exec := uc.executorFactory.NewExecutor()
+	before := policy.ExpiredBefore(time.Now())
+	if before == nil {
+		return nil
+	}
+
+	for time.Now().Before(deadline) {
+		decisions, err := uc.retentionRepository.ListDataRetentionDecisions(ctx, exec,
+			policy.OrganizationId, *before, dataRetentionDecisionsBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(decisions) == 0 {
+			return nil
+		}
+
+		decisionIds := make([]uuid.UUID, len(decisions))
+		for i, decision := range decisions {
+			decisionIds[i] = decision.Id
+		}
+
+		if err := uc.deleteOffloadedDecisions(ctx, policy.OrganizationId, decisions); err != nil {
+			return err
+		}
+
+		err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+			deleted, err := uc.retentionRepository.DeleteDataRetentionDecisions(ctx, tx, decisionIds)
+			if err != nil {
+				return err
+			}
+			run.DecisionsDeleted += deleted
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(decisions) < dataRetentionDecisionsBatchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (uc DataRetentionUsecase) deleteOffloadedDecisions(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	decisions []models.DataRetentionDecision,
+) error {
+	if uc.offloadingBucketUrl == "" {
+		return nil
+	}
+
+	decisionIds := make([]uuid.UUID, len(decisions))
+	decisionsById := make(map[string]models.DataRetentionDecision, len(decisions))
+	for i, decision := range decisions {
+		decisionIds[i] = decision.Id
+		decisionsById[decision.Id.String()] = decision
+
+		key := uc.retentionRepository.GetOffloadedDecisionEvaluationKey(organizationId, models.Decision{
+			DecisionId: decision.Id,
+			CreatedAt:  decision.CreatedAt,
+			Outcome:    models.OutcomeFrom(decision.Outcome),
+		})
+		if err := uc.deleteOffloadedBlob(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	rules, err := uc.retentionRepository.ListDataSubjectErasureDecisionRules(ctx,
+		uc.executorFactory.NewExecutor(), decisionIds)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		key := uc.retentionRepository.GetOffloadedDecisionRuleKey(organizationId, rule.DecisionId,
+			rule.RuleId, rule.Outcome, decisionsById[rule.DecisionId].CreatedAt)
+		if err := uc.deleteOffloadedBlob(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc DataRetentionUsecase) deleteOffloadedBlob(ctx context.Context, key string) error {
+	err := uc.blobRepository.DeleteFile(ctx, uc.offloadingBucketUrl, key)
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return errors.Wrapf(err, "could not delete blob %s", key)
+	}
+	return nil
+}
+
+func dataRetentionTableById(dataModel models.DataModel, tableId uuid.UUID) (models.Table, bool) {
+	for _, table := range dataModel.Tables {
+		if table.ID == tableId.String() {
+			return table, true
+		}
+	}
+	return models.Table{}, false
+}
+
+func NewDataRetentionPeriodicJob(orgId uuid.UUID) *river.PeriodicJob {
+	return worker_jobs.NewPeriodicJob(
+		river.PeriodicInterval(DATA_RETENTION_WORKER_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.DataRetentionArgs{
+					OrgId: orgId,
+				}, &river.InsertOpts{
+					Queue: orgId.String(),
+					UniqueOpts: river.UniqueOpts{
+						ByQueue:  true,
+						ByPeriod: DATA_RETENTION_WORKER_INTERVAL,
+					},
+				}
+		},
+	)
+}
+
+type DataRetentionWorker struct {
+	river.WorkerDefaults[models.DataRetentionArgs]
+	usecase DataRetentionUsecase
+}
+
+func NewDataRetentionWorker(usecase DataRetentionUsecase) *DataRetentionWorker {
+	return &DataRetentionWorker{usecase: usecase}
+}
+
+func (w *DataRetentionWorker) Timeout(job *river.Job[models.DataRetentionArgs]) time.Duration {
+	return 2 * dataRetentionTimeBudget
+}
+
+func (w *DataRetentionWorker) Work(ctx context.Context, job *river.Job[models.DataRetentionArgs]) error {
+	return w.usecase.RunDataRetention(ctx, job.Args.OrgId)
+}
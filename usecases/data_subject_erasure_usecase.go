@@ -0,0 +1,817 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"gocloud.dev/gcerrors"
+)
+
+const (
+	dataSubjectErasureChunkSize = 100
+	// The erasure gives the job back to the queue after that duration, to be resumed where it stopped
+	dataSubjectErasureTimeBudget  = 4 * time.Minute
+	dataSubjectErasureSnoozeDelay = 5 * time.Second
+)
+
+type dataSubjectErasureRepository interface {
+	GetOffloadedDecisionRuleKey(orgId uuid.UUID, decisionId, ruleId, outcome string, createdAt time.Time) string
+	GetOffloadedDecisionEvaluationKey(orgId uuid.UUID, decision models.Decision) string
+	GetOffloadedScreeningMatchKey(orgId uuid.UUID, screeningId, matchId string) string
+	GetOffloadedContinuousScreeningMatchKey(orgId, continuousScreeningId, matchId uuid.UUID) string
+	GetOffloadedContinuousScreeningEntityKey(orgId, continuousScreeningId uuid.UUID) string
+
+	CreateDataSubjectErasure(ctx context.Context, exec repositories.Executor,
+		erasure models.DataSubjectErasure) (models.DataSubjectErasure, error)
+	GetDataSubjectErasure(ctx context.Context, exec repositories.Executor, id uuid.UUID) (models.DataSubjectErasure, error)
+	ListDataSubjectErasures(ctx context.Context, exec repositories.Executor, orgId uuid.UUID) ([]models.DataSubjectErasure, error)
+	UpdateDataSubjectErasureProgress(ctx context.Context, exec repositories.Executor, erasure models.DataSubjectErasure) error
+	CompleteDataSubjectErasure(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		status models.DataSubjectErasureStatus, erasureError *string) error
+	ListDataSubjectErasureDecisions(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		targets models.DataSubjectErasureTargets, limit int) ([]models.DataSubjectErasureDecision, error)
+	CountDataSubjectErasureRecords(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		targets models.DataSubjectErasureTargets) (models.DataSubjectErasureCounts, error)
+	ListDataSubjectErasureDecisionRules(ctx context.Context, exec repositories.Executor,
+		decisionIds []uuid.UUID) ([]models.DataSubjectErasureDecisionRule, error)
+	ListDataSubjectErasureScreeningMatches(ctx context.Context, exec repositories.Executor,
+		decisionIds []uuid.UUID) ([]models.DataSubjectErasureScreeningMatch, error)
+	ListDataSubjectErasureScreeningFiles(ctx context.Context, exec repositories.Executor,
+		decisionIds []uuid.UUID) ([]models.ScreeningFile, error)
+	PseudonymizeDataSubjectErasureDecisions(ctx context.Context, exec repositories.Executor,
+		decisionIds []uuid.UUID, pseudonym string) (int64, int64, error)
+	ListDataSubjectErasureContinuousScreenings(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		targets models.DataSubjectErasureTargets, limit int) ([]models.DataSubjectErasureContinuousScreening, error)
+	ListDataSubjectErasureContinuousScreeningMatches(ctx context.Context, exec repositories.Executor,
+		targets models.DataSubjectErasureTargets, screenings []models.DataSubjectErasureContinuousScreening,
+	) ([]models.DataSubjectErasureContinuousScreeningMatch, error)
+	PseudonymizeDataSubjectErasureContinuousScreenings(ctx context.Context, exec repositories.Executor,
+		targets models.DataSubjectErasureTargets, screeningIds []uuid.UUID, matchIds []uuid.UUID, pseudonym string) error
+	ListDataSubjectErasureAnnotations(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		targets models.DataSubjectErasureTargets, limit int) ([]models.EntityAnnotation, error)
+	DeleteDataSubjectErasureAnnotations(ctx context.Context, exec repositories.Executor, ids []string) error
+	ListDataSubjectErasureCaseComments(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		identifiers []string, afterId string, limit int) ([]models.DataSubjectErasureCaseComment, error)
+	UpdateDataSubjectErasureCaseComment(ctx context.Context, exec repositories.Executor, id string, note string) error
+}
+
+type dataSubjectErasureClientDbRepository interface {
+	GetDataSubjectErasureFieldValues(ctx context.Context, exec repositories.Executor, tableName string,
+		objectId string, fields []string) (map[string]any, error)
+	ListDataSubjectErasureLinkedObjectIds(ctx context.Context, exec repositories.Executor, tableName string,
+		fieldName string, value any, limit int) ([]string, error)
+	CountDataSubjectErasureRows(ctx context.Context, exec repositories.Executor, tableName string, objectIds []string) (int64, error)
+	DeleteDataSubjectErasureRows(ctx context.Context, exec repositories.Executor, tableName string, objectIds []string) (int64, error)
+	DeleteDataSubjectErasureMonitoredObjects(ctx context.Context, exec repositories.Executor, objectType string,
+		objectIds []string, pseudonym string) (int64, error)
+	IsContinuousScreeningSetup(ctx context.Context, exec repositories.Executor) (bool, error)
+	ListMonitoredObjectsByObjectIds(ctx context.Context, exec repositories.Executor, objectType string,
+		objectIds []string) ([]models.ContinuousScreeningMonitoredObject, error)
+}
+
+// DataSubjectErasureUsecase erases the personal data of a data subject, on request of the data
+// subject. The erasure runs as a background job, going through the stores holding data about the
+// subject one stage at a time.
+type DataSubjectErasureUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityOrganization
+
+	dataModelRepository repositories.DataModelRepository
+	erasureRepository   dataSubjectErasureRepository
+	clientDbRepository  dataSubjectErasureClientDbRepository
+	taskQueueRepository repositories.TaskQueueRepository
+	blobRepository      repositories.BlobRepository
+
+	offloadingBucketUrl string
+}
+
+func NewDataSubjectErasureUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityOrganization,
+	dataModelRepository repositories.DataModelRepository,
+	erasureRepository dataSubjectErasureRepository,
+	clientDbRepository dataSubjectErasureClientDbRepository,
+	taskQueueRepository repositories.TaskQueueRepository,
+	blobRepository repositories.BlobRepository,
+	offloadingBucketUrl string,
+) DataSubjectErasureUsecase {
+	return DataSubjectErasureUsecase{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		enforceSecurity:     enforceSecurity,
+		dataModelRepository: dataModelRepository,
+		erasureRepository:   erasureRepository,
+		clientDbRepository:  clientDbRepository,
+		taskQueueRepository: taskQueueRepository,
+		blobRepository:      blobRepository,
+		offloadingBucketUrl: offloadingBucketUrl,
+	}
+}
+
+// CreateDataSubjectErasure reports what erasing the data subject would erase and, unless this is a
+// dry run, starts the erasure.
+func (uc DataSubjectErasureUsecase) CreateDataSubjectErasure(
+	ctx context.Context,
+	dryRun bool,
+	input models.CreateDataSubjectErasureInput,
+) (models.DataSubjectErasureReport, error) {
+	if err := uc.enforceSecurity.WriteDataModel(input.OrganizationId); err != nil {
+		return models.DataSubjectErasureReport{}, err
+	}
+	if input.ObjectId == "" {
+		return models.DataSubjectErasureReport{}, errors.Wrap(models.BadParameterError, "object_id is required")
+	}
+
+	targets, err := uc.collectDataSubjectErasureTargets(ctx, input.OrganizationId, input.ObjectType, input.ObjectId)
+	if err != nil {
+		return models.DataSubjectErasureReport{}, err
+	}
+	counts, err := uc.countDataSubjectErasure(ctx, input.OrganizationId, targets)
+	if err != nil {
+		return models.DataSubjectErasureReport{}, err
+	}
+	report := models.DataSubjectErasureReport{Targets: targets, Counts: counts}
+
+	if dryRun {
+		return report, nil
+	}
+
+	erasure := models.DataSubjectErasure{
+		Id:             uuid.New(),
+		OrganizationId: input.OrganizationId,
+		ObjectType:     input.ObjectType,
+		ObjectId:       &input.ObjectId,
+	}
+	if creds, ok := utils.CredentialsFromCtx(ctx); ok && creds.ActorIdentity.UserId != "" {
+		erasure.CreatedBy = utils.Ptr(string(creds.ActorIdentity.UserId))
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		created, err := uc.erasureRepository.CreateDataSubjectErasure(ctx, tx, erasure)
+		if err != nil {
+			return err
+		}
+		report.Erasure = &created
+
+		return uc.taskQueueRepository.EnqueueDataSubjectErasureTask(ctx, tx, created.OrganizationId, created.Id)
+	})
+	if err != nil {
+		return models.DataSubjectErasureReport{}, err
+	}
+
+	return report, nil
+}
+
+func (uc DataSubjectErasureUsecase) ListDataSubjectErasures(ctx context.Context, organizationId uuid.UUID) (
+	[]models.DataSubjectErasure, error,
+) {
+	if err := uc.enforceSecurity.WriteDataModel(organizationId); err != nil {
+		return nil, err
+	}
+
+	return uc.erasureRepository.ListDataSubjectErasures(ctx, uc.executorFactory.NewExecutor(), organizationId)
+}
+
+func (uc DataSubjectErasureUsecase) GetDataSubjectErasure(ctx context.Context, erasureId uuid.UUID) (
+	models.DataSubjectErasure, error,
+) {
+	erasure, err := uc.erasureRepository.GetDataSubjectErasure(ctx, uc.executorFactory.NewExecutor(), erasureId)
+	if err != nil {
+		return models.DataSubjectErasure{}, err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(erasure.OrganizationId); err != nil {
+		return models.DataSubjectErasure{}, err
+	}
+
+	return erasure, nil
+}
+
+// collectDataSubjectErasureTargets finds the objects referencing the data subject, following the
+// links of the data model from parent to child, and the pivot values identifying them.
+func (uc DataSubjectErasureUsecase) collectDataSubjectErasureTargets(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	objectType string,
+	objectId string,
+) (models.DataSubjectErasureTargets, error) {
+	exec := uc.executorFactory.NewExecutor()
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, organizationId, false, false)
+	if err != nil {
+		return models.DataSubjectErasureTargets{}, err
+	}
+	if _, ok := dataModel.Tables[objectType]; !ok {
+		return models.DataSubjectErasureTargets{}, errors.Wrapf(models.BadParameterError,
+			"table %s does not exist in the data model", objectType)
+	}
+
+	pivotsMeta, err := uc.dataModelRepository.ListPivots(ctx, exec, organizationId, nil, false, true)
+	if err != nil {
+		return models.DataSubjectErasureTargets{}, err
+	}
+	pivots := make([]models.Pivot, 0, len(pivotsMeta))
+	for _, pivot := range pivotsMeta {
+		pivots = append(pivots, pivot.Enrich(dataModel))
+	}
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, organizationId)
+	if err != nil {
+		return models.DataSubjectErasureTargets{}, err
+	}
+
+	targets := models.DataSubjectErasureTargets{}
+	seen := map[string]bool{}
+	seenPivotValues := map[string]bool{}
+	queue := []models.DataSubjectErasureObject{{ObjectType: objectType, ObjectId: objectId}}
+	seen[objectType+"/"+objectId] = true
+
+	for len(queue) > 0 {
+		object := queue[0]
+		queue = queue[1:]
+		targets.Objects = append(targets.Objects, object)
+
+		children := dataSubjectErasureChildLinks(dataModel, object.ObjectType)
+		identifiers := dataSubjectErasurePivotFields(dataModel, pivots, object.ObjectType)
+
+		fields := []string{"object_id"}
+		for _, link := range children {
+			fields = append(fields, link.ParentFieldName)
+		}
+		for _, field := range identifiers {
+			fields = append(fields, field)
+		}
+		slices.Sort(fields)
+		fields = slices.Compact(fields)
+
+		values, err := uc.clientDbRepository.GetDataSubjectErasureFieldValues(ctx, clientExec,
+			object.ObjectType, object.ObjectId, fields)
+		if err != nil {
+			return models.DataSubjectErasureTargets{}, err
+		}
+		if values == nil {
+			// Never ingested, or already erased: the object can still be found in decisions
+			values = map[string]any{"object_id": object.ObjectId}
+		}
+
+		for _, pivotId := range slices.SortedFunc(maps.Keys(identifiers), func(a, b uuid.UUID) int {
+			return slices.Compare(a[:], b[:])
+		}) {
+			value := values[identifiers[pivotId]]
+			if value == nil {
+				continue
+			}
+			pivotValue := models.DataSubjectErasurePivotValue{PivotId: pivotId, Value: fmt.Sprint(value)}
+			if key := pivotId.String() + "/" + pivotValue.Value; !seenPivotValues[key] {
+				seenPivotValues[key] = true
+				targets.PivotValues = append(targets.PivotValues, pivotValue)
+			}
+		}
+
+		if object.Depth >= models.DataSubjectErasureMaxDepth {
+			continue
+		}
+		for _, link := range children {
+			value := values[link.ParentFieldName]
+			if value == nil {
+				continue
+			}
+
+			childIds, err := uc.clientDbRepository.ListDataSubjectErasureLinkedObjectIds(ctx, clientExec,
+				link.ChildTableName, link.ChildFieldName, value, models.DataSubjectErasureMaxObjects+1)
+			if err != nil {
+				return models.DataSubjectErasureTargets{}, err
+			}
+			for _, childId := range childIds {
+				key := link.ChildTableName + "/" + childId
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				queue = append(queue, models.DataSubjectErasureObject{
+					ObjectType: link.ChildTableName,
+					ObjectId:   childId,
+					Depth:      object.Depth + 1,
+				})
+			}
+			if len(seen) > models.DataSubjectErasureMaxObjects {
+				return models.DataSubjectErasureTargets{}, errors.Wrapf(models.BadParameterError,
+					"the object is linked to more than %d objects, they cannot be erased at once",
+					models.DataSubjectErasureMaxObjects)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// dataSubjectErasureChildLinks returns the links from the objects of other tables to the objects
+// of the given table, sorted for the erasure to be deterministic.
+func dataSubjectErasureChildLinks(dataModel models.DataModel, tableName string) []models.LinkToSingle {
+	var links []models.LinkToSingle
+	for _, table := range dataModel.Tables {
+		for _, link := range table.LinksToSingle {
+			if link.ParentTableName == tableName && link.ChildTableName != tableName {
+				links = append(links, link)
+			}
+		}
+	}
+	slices.SortFunc(links, func(a, b models.LinkToSingle) int {
+		if c := cmp.Compare(a.ChildTableName, b.ChildTableName); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return links
+}
+
+// dataSubjectErasurePivotFields returns, by pivot, the field of the given table holding the pivot
+// value of the decisions. A pivot field referencing another table through a link identifies the
+// objects of that other table, by the field of the link.
+func dataSubjectErasurePivotFields(dataModel models.DataModel, pivots []models.Pivot, tableName string) map[uuid.UUID]string {
+	fields := make(map[uuid.UUID]string)
+	for _, pivot := range pivots {
+		if pivot.Field == "" {
+			continue
+		}
+
+		table, field := pivot.PivotTable, pivot.Field
+		for _, link := range dataModel.Tables[pivot.PivotTable].LinksToSingle {
+			if link.ChildFieldName == pivot.Field {
+				table, field = link.ParentTableName, link.ParentFieldName
+				break
+			}
+		}
+		if table == tableName {
+			fields[pivot.Id] = field
+		}
+	}
+	return fields
+}
+
+func (uc DataSubjectErasureUsecase) countDataSubjectErasure(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	targets models.DataSubjectErasureTargets,
+) (models.DataSubjectErasureCounts, error) {
+	exec := uc.executorFactory.NewExecutor()
+	counts, err := uc.erasureRepository.CountDataSubjectErasureRecords(ctx, exec, organizationId, targets)
+	if err != nil {
+		return models.DataSubjectErasureCounts{}, err
+	}
+	counts.Objects = int64(len(targets.Objects))
+
+	clientExec, err := uc.executorFactory.NewClientDbExecutor(ctx, organizationId)
+	if err != nil {
+		return models.DataSubjectErasureCounts{}, err
+	}
+	monitoring, err := uc.clientDbRepository.IsContinuousScreeningSetup(ctx, clientExec)
+	if err != nil {
+		return models.DataSubjectErasureCounts{}, err
+	}
+	objectIds := targets.ObjectIdsByType()
+	for _, objectType := range slices.Sorted(maps.Keys(objectIds)) {
+		rows, err := uc.clientDbRepository.CountDataSubjectErasureRows(ctx, clientExec, objectType, objectIds[objectType])
+		if err != nil {
+			return models.DataSubjectErasureCounts{}, err
+		}
+		counts.IngestedRows += rows
+
+		if monitoring {
+			monitored, err := uc.clientDbRepository.ListMonitoredObjectsByObjectIds(ctx, clientExec,
+				objectType, objectIds[objectType])
+			if err != nil {
+				return models.DataSubjectErasureCounts{}, err
+			}
+			counts.MonitoredObjects += int64(len(monitored))
+		}
+	}
+
+	identifiers := targets.Identifiers()
+	afterId := ""
+	for {
+		comments, err := uc.erasureRepository.ListDataSubjectErasureCaseComments(ctx, exec, organizationId,
+			identifiers, afterId, dataSubjectErasureChunkSize)
+		if err != nil {
+			return models.DataSubjectErasureCounts{}, err
+		}
+		for _, comment := range comments {
+			if _, n := models.ReplaceErasedIdentifiers(comment.Note, identifiers, ""); n > 0 {
+				counts.CaseComments += 1
+			}
+			afterId = comment.Id
+		}
+		if len(comments) < dataSubjectErasureChunkSize {
+			break
+		}
+	}
+
+	return counts, nil
+}
+
+// RunDataSubjectErasure runs the erasure from the stage it is at, until it is done or it runs out of
+// time. It returns whether the erasure is over.
+func (uc DataSubjectErasureUsecase) RunDataSubjectErasure(ctx context.Context, erasureId uuid.UUID) (bool, error) {
+	logger := utils.LoggerFromContext(ctx)
+	exec := uc.executorFactory.NewExecutor()
+
+	erasure, err := uc.erasureRepository.GetDataSubjectErasure(ctx, exec, erasureId)
+	if errors.Is(err, models.NotFoundError) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if erasure.Status.IsTerminal() {
+		return true, nil
+	}
+
+	if erasure.Stage == models.DataSubjectErasureStageCollect || erasure.Targets == nil {
+		if erasure.ObjectId == nil {
+			return true, uc.failDataSubjectErasure(ctx, erasure, "the erasure has no object")
+		}
+		targets, err := uc.collectDataSubjectErasureTargets(ctx, erasure.OrganizationId,
+			erasure.ObjectType, *erasure.ObjectId)
+		if errors.Is(err, models.BadParameterError) {
+			return true, uc.failDataSubjectErasure(ctx, erasure, err.Error())
+		}
+		if err != nil {
+			return false, err
+		}
+
+		erasure.Targets = &targets
+		erasure.Counts.Objects = int64(len(targets.Objects))
+		erasure.Stage = models.DataSubjectErasureStageDecisions
+		if err := uc.erasureRepository.UpdateDataSubjectErasureProgress(ctx, exec, erasure); err != nil {
+			return false, err
+		}
+	}
+
+	deadline := time.Now().Add(dataSubjectErasureTimeBudget)
+	for erasure.Stage != models.DataSubjectErasureStageDone {
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		var stageDone bool
+		switch erasure.Stage {
+		case models.DataSubjectErasureStageDecisions:
+			stageDone, err = uc.eraseDecisionsChunk(ctx, &erasure)
+		case models.DataSubjectErasureStageContinuousScreenings:
+			stageDone, err = uc.eraseContinuousScreeningsChunk(ctx, &erasure)
+		case models.DataSubjectErasureStageAnnotations:
+			stageDone, err = uc.eraseAnnotationsChunk(ctx, &erasure)
+		case models.DataSubjectErasureStageCaseComments:
+			stageDone, err = true, uc.eraseCaseComments(ctx, &erasure)
+		case models.DataSubjectErasureStageIngestedData:
+			stageDone, err = true, uc.eraseIngestedData(ctx, &erasure)
+		default:
+			return true, uc.failDataSubjectErasure(ctx, erasure, fmt.Sprintf("unknown stage %s", erasure.Stage))
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "could not erase data subject at stage %s", erasure.Stage)
+		}
+
+		if stageDone {
+			erasure.Stage = erasure.Stage.Next()
+			if err := uc.erasureRepository.UpdateDataSubjectErasureProgress(ctx, exec, erasure); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	if err := uc.erasureRepository.CompleteDataSubjectErasure(ctx, exec, erasure.Id,
+		models.DataSubjectErasureCompleted, nil); err != nil {
+		return false, err
+	}
+
+	logger.InfoContext(ctx, "data subject erasure completed", "erasure_id", erasure.Id,
+		"objects", erasure.Counts.Objects, "decisions", erasure.Counts.Decisions)
+	return true, nil
+}
+
+// eraseDecisionsChunk pseudonymizes the next decisions of the data subject. The blobs stored for
+// them are deleted first, as their keys are derived from data that is kept.
+func (uc DataSubjectErasureUsecase) eraseDecisionsChunk(ctx context.Context, erasure *models.DataSubjectErasure) (bool, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	decisions, err := uc.erasureRepository.ListDataSubjectErasureDecisions(ctx, exec,
+		erasure.OrganizationId, *erasure.Targets, dataSubjectErasureChunkSize)
+	if err != nil {
+		return false, err
+	}
+	if len(decisions) == 0 {
+		return true, nil
+	}
+
+	decisionIds := make([]uuid.UUID, len(decisions))
+	decisionsById := make(map[string]models.DataSubjectErasureDecision, len(decisions))
+	for i, decision := range decisions {
+		decisionIds[i] = decision.Id
+		decisionsById[decision.Id.String()] = decision
+	}
+
+	if uc.offloadingBucketUrl != "" {
+		for _, decision := range decisions {
+			key := uc.erasureRepository.GetOffloadedDecisionEvaluationKey(erasure.OrganizationId, models.Decision{
+				DecisionId: decision.Id,
+				CreatedAt:  decision.CreatedAt,
+				Outcome:    models.OutcomeFrom(decision.Outcome),
+			})
+			if err := uc.deleteDataSubjectErasureBlob(ctx, uc.offloadingBucketUrl, key); err != nil {
+				return false, err
+			}
+		}
+
+		rules, err := uc.erasureRepository.ListDataSubjectErasureDecisionRules(ctx, exec, decisionIds)
+		if err != nil {
+			return false, err
+		}
+		for _, rule := range rules {
+			key := uc.erasureRepository.GetOffloadedDecisionRuleKey(erasure.OrganizationId, rule.DecisionId,
+				rule.RuleId, rule.Outcome, decisionsById[rule.DecisionId].CreatedAt)
+			if err := uc.deleteDataSubjectErasureBlob(ctx, uc.offloadingBucketUrl, key); err != nil {
+				return false, err
+			}
+		}
+
+		matches, err := uc.erasureRepository.ListDataSubjectErasureScreeningMatches(ctx, exec, decisionIds)
+		if err != nil {
+			return false, err
+		}
+		for _, match := range matches {
+			key := uc.erasureRepository.GetOffloadedScreeningMatchKey(erasure.OrganizationId, match.ScreeningId, match.Id)
+			if err := uc.deleteDataSubjectErasureBlob(ctx, uc.offloadingBucketUrl, key); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	files, err := uc.erasureRepository.ListDataSubjectErasureScreeningFiles(ctx, exec, decisionIds)
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		if err := uc.deleteDataSubjectErasureBlob(ctx, file.BucketName, file.FileReference); err != nil {
+			return false, err
+		}
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		screenings, matches, err := uc.erasureRepository.PseudonymizeDataSubjectErasureDecisions(ctx, tx,
+			decisionIds, erasure.Pseudonym())
+		if err != nil {
+			return err
+		}
+
+		erasure.Counts.Decisions += int64(len(decisions))
+		erasure.Counts.Screenings += screenings
+		erasure.Counts.ScreeningMatches += matches
+		erasure.Counts.Files += int64(len(files))
+		return uc.erasureRepository.UpdateDataSubjectErasureProgress(ctx, tx, *erasure)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(decisions) < dataSubjectErasureChunkSize, nil
+}
+
+// eraseContinuousScreeningsChunk pseudonymizes the next continuous screenings of the data subject,
+// and the matches on it in the continuous screenings of sanctioned entities. The blobs offloaded for
+// them are deleted first.
+func (uc DataSubjectErasureUsecase) eraseContinuousScreeningsChunk(ctx context.Context, erasure *models.DataSubjectErasure) (bool, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	screenings, err := uc.erasureRepository.ListDataSubjectErasureContinuousScreenings(ctx, exec,
+		erasure.OrganizationId, *erasure.Targets, dataSubjectErasureChunkSize)
+	if err != nil {
+		return false, err
+	}
+	if len(screenings) == 0 {
+		return true, nil
+	}
+
+	matches, err := uc.erasureRepository.ListDataSubjectErasureContinuousScreeningMatches(ctx, exec,
+		*erasure.Targets, screenings)
+	if err != nil {
+		return false, err
+	}
+
+	screeningIds := make([]uuid.UUID, 0, len(screenings))
+	for _, screening := range screenings {
+		if !screening.OfTarget {
+			continue
+		}
+		screeningIds = append(screeningIds, screening.Id)
+
+		key := uc.erasureRepository.GetOffloadedContinuousScreeningEntityKey(erasure.OrganizationId, screening.Id)
+		if err := uc.deleteDataSubjectErasureBlob(ctx, uc.offloadingBucketUrl, key); err != nil {
+			return false, err
+		}
+	}
+	matchIds := make([]uuid.UUID, len(matches))
+	for i, match := range matches {
+		matchIds[i] = match.Id
+
+		key := uc.erasureRepository.GetOffloadedContinuousScreeningMatchKey(erasure.OrganizationId,
+			match.ContinuousScreeningId, match.Id)
+		if err := uc.deleteDataSubjectErasureBlob(ctx, uc.offloadingBucketUrl, key); err != nil {
+			return false, err
+		}
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if err := uc.erasureRepository.PseudonymizeDataSubjectErasureContinuousScreenings(ctx, tx,
+			*erasure.Targets, screeningIds, matchIds, erasure.Pseudonym()); err != nil {
+			return err
+		}
+
+		erasure.Counts.ContinuousScreenings += int64(len(screenings))
+		erasure.Counts.ContinuousScreeningMatches += int64(len(matches))
+		return uc.erasureRepository.UpdateDataSubjectErasureProgress(ctx, tx, *erasure)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(screenings) < dataSubjectErasureChunkSize, nil
+}
+
+func (uc DataSubjectErasureUsecase) eraseAnnotationsChunk(ctx context.Context, erasure *models.DataSubjectErasure) (bool, error) {
+	exec := uc.executorFactory.NewExecutor()
+
+	annotations, err := uc.erasureRepository.ListDataSubjectErasureAnnotations(ctx, exec,
+		erasure.OrganizationId, *erasure.Targets, dataSubjectErasureChunkSize)
+	if err != nil {
+		return false, err
+	}
+	if len(annotations) == 0 {
+		return true, nil
+	}
+
+	ids := make([]string, len(annotations))
+	files := 0
+	for i, annotation := range annotations {
+		ids[i] = annotation.Id
+
+		if annotation.AnnotationType != models.EntityAnnotationFile {
+			continue
+		}
+		var payload models.EntityAnnotationFilePayload
+		if err := json.Unmarshal(annotation.Payload, &payload); err != nil {
+			return false, errors.Wrapf(err, "could not read the files of annotation %s", annotation.Id)
+		}
+		for _, file := range payload.Files {
+			if err := uc.deleteDataSubjectErasureBlob(ctx, payload.Bucket, file.Key); err != nil {
+				return false, err
+			}
+			files += 1
+		}
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if err := uc.erasureRepository.DeleteDataSubjectErasureAnnotations(ctx, tx, ids); err != nil {
+			return err
+		}
+
+		erasure.Counts.Annotations += int64(len(annotations))
+		erasure.Counts.Files += int64(files)
+		return uc.erasureRepository.UpdateDataSubjectErasureProgress(ctx, tx, *erasure)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(annotations) < dataSubjectErasureChunkSize, nil
+}
+
+// eraseCaseComments replaces the identifiers of the data subject in case comments by its pseudonym.
+// Comments already pseudonymized no longer mention the identifiers, so the stage can be run again.
+func (uc DataSubjectErasureUsecase) eraseCaseComments(ctx context.Context, erasure *models.DataSubjectErasure) error {
+	exec := uc.executorFactory.NewExecutor()
+	identifiers := erasure.Targets.Identifiers()
+
+	afterId := ""
+	for {
+		comments, err := uc.erasureRepository.ListDataSubjectErasureCaseComments(ctx, exec,
+			erasure.OrganizationId, identifiers, afterId, dataSubjectErasureChunkSize)
+		if err != nil {
+			return err
+		}
+
+		for _, comment := range comments {
+			afterId = comment.Id
+
+			note, n := models.ReplaceErasedIdentifiers(comment.Note, identifiers, erasure.Pseudonym())
+			if n == 0 {
+				continue
+			}
+			if err := uc.erasureRepository.UpdateDataSubjectErasureCaseComment(ctx, exec, comment.Id, note); err != nil {
+				return err
+			}
+			erasure.Counts.CaseComments += 1
+		}
+
+		if len(comments) < dataSubjectErasureChunkSize {
+			return nil
+		}
+	}
+}
+
+// eraseIngestedData deletes every version of the objects from the client schema, and removes them
+// from continuous screening.
+func (uc DataSubjectErasureUsecase) eraseIngestedData(ctx context.Context, erasure *models.DataSubjectErasure) error {
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, uc.executorFactory.NewExecutor(),
+		erasure.OrganizationId, false, false)
+	if err != nil {
+		return err
+	}
+
+	return uc.transactionFactory.TransactionInOrgSchema(ctx, erasure.OrganizationId, func(tx repositories.Transaction) error {
+		monitoring, err := uc.clientDbRepository.IsContinuousScreeningSetup(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		objectIds := erasure.Targets.ObjectIdsByType()
+		for _, objectType := range slices.Sorted(maps.Keys(objectIds)) {
+			if monitoring {
+				monitored, err := uc.clientDbRepository.DeleteDataSubjectErasureMonitoredObjects(ctx, tx,
+					objectType, objectIds[objectType], erasure.Pseudonym())
+				if err != nil {
+					return err
+				}
+				erasure.Counts.MonitoredObjects += monitored
+			}
+
+			// The table may have been deleted since the objects were collected
+			if _, ok := dataModel.Tables[objectType]; !ok {
+				continue
+			}
+			rows, err := uc.clientDbRepository.DeleteDataSubjectErasureRows(ctx, tx, objectType, objectIds[objectType])
+			if err != nil {
+				return err
+			}
+			erasure.Counts.IngestedRows += rows
+		}
+
+		return nil
+	})
+}
+
+func (uc DataSubjectErasureUsecase) deleteDataSubjectErasureBlob(ctx context.Context, bucketUrl, key string) error {
+	if bucketUrl == "" || key == "" {
+		return nil
+	}
+	err := uc.blobRepository.DeleteFile(ctx, bucketUrl, key)
+	if err != nil && gcerrors.Code(err) != gcerrors.NotFound {
+		return errors.Wrapf(err, "could not delete blob %s", key)
+	}
+	return nil
+}
+
+func (uc DataSubjectErasureUsecase) failDataSubjectErasure(ctx context.Context, erasure models.DataSubjectErasure, reason string) error {
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "data subject erasure failed",
+		"erasure_id", erasure.Id, "reason", reason)
+
+	return uc.erasureRepository.CompleteDataSubjectErasure(ctx, uc.executorFactory.NewExecutor(),
+		erasure.Id, models.DataSubjectErasureFailed, &reason)
+}
+
+type DataSubjectErasureWorker struct {
+	river.WorkerDefaults[models.DataSubjectErasureArgs]
+	usecase DataSubjectErasureUsecase
+}
+
+func NewDataSubjectErasureWorker(usecase DataSubjectErasureUsecase) *DataSubjectErasureWorker {
+	return &DataSubjectErasureWorker{usecase: usecase}
+}
+
+func (w *DataSubjectErasureWorker) Timeout(job *river.Job[models.DataSubjectErasureArgs]) time.Duration {
+	return 2 * dataSubjectErasureTimeBudget
+}
+
+func (w *DataSubjectErasureWorker) Work(ctx context.Context, job *river.Job[models.DataSubjectErasureArgs]) error {
+	done, err := w.usecase.RunDataSubjectErasure(ctx, job.Args.ErasureId)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return river.JobSnooze(dataSubjectErasureSnoozeDelay)
+	}
+	return nil
+}
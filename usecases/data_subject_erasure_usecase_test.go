@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func dataSubjectErasureTestDataModel() models.DataModel {
+	return models.DataModel{
+		Tables: map[string]models.Table{
+			"users": {Name: "users"},
+			"accounts": {
+				Name: "accounts",
+				LinksToSingle: map[string]models.LinkToSingle{
+					"owner": {
+						Name: "owner", ParentTableName: "users", ParentFieldName: "object_id",
+						ChildTableName: "accounts", ChildFieldName: "user_id",
+					},
+				},
+			},
+			"transactions": {
+				Name: "transactions",
+				LinksToSingle: map[string]models.LinkToSingle{
+					"account": {
+						Name: "account", ParentTableName: "accounts", ParentFieldName: "object_id",
+						ChildTableName: "transactions", ChildFieldName: "account_id",
+					},
+					"beneficiary": {
+						Name: "beneficiary", ParentTableName: "users", ParentFieldName: "email",
+						ChildTableName: "transactions", ChildFieldName: "beneficiary_email",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDataSubjectErasureChildLinks(t *testing.T) {
+	dataModel := dataSubjectErasureTestDataModel()
+
+	links := dataSubjectErasureChildLinks(dataModel, "users")
+	assert.Len(t, links, 2)
+	assert.Equal(t, "owner", links[0].Name)
+	assert.Equal(t, "beneficiary", links[1].Name)
+
+	assert.Empty(t, dataSubjectErasureChildLinks(dataModel, "transactions"))
+}
+
+func TestDataSubjectErasurePivotFields(t *testing.T) {
+	dataModel := dataSubjectErasureTestDataModel()
+	onAccounts := models.Pivot{Id: uuid.New(), PivotTable: "accounts", Field: "object_id"}
+	throughLink := models.Pivot{Id: uuid.New(), PivotTable: "accounts", Field: "user_id"}
+	onTransactions := models.Pivot{Id: uuid.New(), PivotTable: "transactions", Field: "object_id"}
+	pivots := []models.Pivot{onAccounts, throughLink, onTransactions}
+
+	assert.Equal(t, map[uuid.UUID]string{throughLink.Id: "object_id"},
+		dataSubjectErasurePivotFields(dataModel, pivots, "users"))
+	assert.Equal(t, map[uuid.UUID]string{onAccounts.Id: "object_id"},
+		dataSubjectErasurePivotFields(dataModel, pivots, "accounts"))
+}
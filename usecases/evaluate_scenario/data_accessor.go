@@ -17,6 +17,7 @@ type DataAccessor struct {
 	executorFactory            executor_factory.ExecutorFactory
 	organizationId             uuid.UUID
 	ingestedDataReadRepository repositories.IngestedDataReadRepository
+	AsOf                       *time.Time
 }
 
 func (d *DataAccessor) GetDbField(ctx context.Context, triggerTableName string, path []string, fieldName string) (interface{}, error) {
@@ -33,6 +34,7 @@ func (d *DataAccessor) GetDbField(ctx context.Context, triggerTableName string,
 			FieldName:        fieldName,
 			DataModel:        d.DataModel,
 			ClientObject:     d.ClientObject,
+			AsOf:             d.AsOf,
 		})
 }
 
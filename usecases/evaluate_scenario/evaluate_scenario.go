@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"runtime/debug"
 	"slices"
 	"strings"
@@ -50,6 +51,12 @@ type ScenarioEvaluationParameters struct {
 	Pivots           []models.Pivot
 	CachedScreenings map[string]models.ScreeningWithMatches
 	ConcurrentRules  int
+	// AsOf, if set, evaluates database reads and aggregates against the data as it was at that
+	// time, so that a replayed evaluation does not see data ingested after the decision.
+	AsOf *time.Time
+	// TestRun, if set, is the test run EvalTestRunScenario evaluates instead of the running shadow
+	// test run of the scenario. Backtests use it to replay past decisions through a draft iteration.
+	TestRun *models.ScenarioTestRun
 }
 
 type EvalScreeningUsecase interface {
@@ -73,10 +80,20 @@ type SnoozesForDecisionReader interface {
 		ctx context.Context,
 		exec repositories.Executor,
 		snoozeGroupIds []string,
-		pivotValue string,
+		keys []models.RuleSnoozeKey,
 	) ([]models.RuleSnooze, error)
 }
 
+type ThrottlesForDecisionReader interface {
+	ListActiveRuleThrottlesForDecision(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId uuid.UUID,
+		stableRuleIds []string,
+		throttleKeys []string,
+	) ([]models.RuleThrottle, error)
+}
+
 type ScenarioEvaluatorFeatureAccessReader interface {
 	GetOrganizationFeatureAccess(
 		ctx context.Context,
@@ -93,6 +110,7 @@ type EvaluateAstExpression interface {
 		organizationId uuid.UUID,
 		payload models.ClientObject,
 		dataModel models.DataModel,
+		asOf *time.Time,
 	) (ast.NodeEvaluation, error)
 }
 
@@ -106,6 +124,7 @@ type ScenarioEvaluator struct {
 	ingestedDataReadRepository    repositories.IngestedDataReadRepository
 	evaluateAstExpression         EvaluateAstExpression
 	snoozeReader                  SnoozesForDecisionReader
+	throttleReader                ThrottlesForDecisionReader
 	featureAccessReader           ScenarioEvaluatorFeatureAccessReader
 	nameRecognizer                EvalNameRecognitionRepository
 }
@@ -120,6 +139,7 @@ func NewScenarioEvaluator(
 	ingestedDataReadRepository repositories.IngestedDataReadRepository,
 	evaluateAstExpression EvaluateAstExpression,
 	snoozeReader SnoozesForDecisionReader,
+	throttleReader ThrottlesForDecisionReader,
 	featureAccessReader ScenarioEvaluatorFeatureAccessReader,
 	nameRecognitionRepository repositories.NameRecognitionRepository,
 ) ScenarioEvaluator {
@@ -133,6 +153,7 @@ func NewScenarioEvaluator(
 		ingestedDataReadRepository:    ingestedDataReadRepository,
 		evaluateAstExpression:         evaluateAstExpression,
 		snoozeReader:                  snoozeReader,
+		throttleReader:                throttleReader,
 		featureAccessReader:           featureAccessReader,
 		nameRecognizer:                nameRecognitionRepository,
 	}
@@ -145,6 +166,7 @@ func (e ScenarioEvaluator) GetDataAccessor(params ScenarioEvaluationParameters)
 		executorFactory:            e.executorFactory,
 		organizationId:             params.Scenario.OrganizationId,
 		ingestedDataReadRepository: e.ingestedDataReadRepository,
+		AsOf:                       params.AsOf,
 	}
 }
 
@@ -165,6 +187,7 @@ func (e ScenarioEvaluator) processScenarioIteration(
 		executorFactory:            e.executorFactory,
 		organizationId:             params.Scenario.OrganizationId,
 		ingestedDataReadRepository: e.ingestedDataReadRepository,
+		AsOf:                       params.AsOf,
 	}
 
 	cache := ast_eval.NewEvaluationCache()
@@ -182,6 +205,7 @@ func (e ScenarioEvaluator) processScenarioIteration(
 			dataAccessor.organizationId,
 			dataAccessor.ClientObject,
 			params.DataModel,
+			dataAccessor.AsOf,
 		)
 		if err != nil {
 			return false, models.ScenarioExecution{}, errors.Wrap(err,
@@ -262,23 +286,45 @@ func (e ScenarioEvaluator) processScenarioIteration(
 		pivotValue = &eligible[0].value
 	}
 
-	snoozes := make([]models.RuleSnooze, 0)
-	var errSnooze error
-	if pivotValue != nil {
-		snoozeGroupIds := make([]string, 0, len(iteration.Rules))
-		for _, rule := range iteration.Rules {
-			if rule.SnoozeGroupId != nil {
-				snoozeGroupIds = append(snoozeGroupIds, *rule.SnoozeGroupId)
-			}
+	// Only the rules that were ever snoozed have a snooze group, so there is usually nothing to read here
+	snoozeGroupIds := make([]string, 0, len(iteration.Rules))
+	for _, rule := range iteration.Rules {
+		if rule.SnoozeGroupId != nil {
+			snoozeGroupIds = append(snoozeGroupIds, *rule.SnoozeGroupId)
 		}
-		snoozes, errSnooze = e.snoozeReader.ListActiveRuleSnoozesForDecision(ctx, exec, snoozeGroupIds, *pivotValue)
 	}
+	snoozes, errSnooze := e.snoozeReader.ListActiveRuleSnoozesForDecision(ctx, exec, snoozeGroupIds,
+		models.RuleSnoozeKeys(pivotValue, params.ClientObject))
 	if errSnooze != nil {
 		return false, models.ScenarioExecution{}, errors.Wrap(
 			errSnooze,
 			"error when listing active rule snozze")
 	}
 
+	// The throttle key of each throttled rule depends on its throttle scope
+	throttleKeys := make(map[string]string)
+	for _, rule := range iteration.Rules {
+		if rule.ThrottlePeriod > 0 {
+			throttleKeys[rule.StableRuleId] = models.RuleThrottleKey(rule.ThrottleScope,
+				pivotValue, params.ClientObject)
+		}
+	}
+	throttles := make([]models.RuleThrottle, 0)
+	if len(throttleKeys) > 0 {
+		activeThrottles, errThrottle := e.throttleReader.ListActiveRuleThrottlesForDecision(ctx, exec,
+			iteration.OrganizationId, slices.Collect(maps.Keys(throttleKeys)),
+			slices.Compact(slices.Sorted(maps.Values(throttleKeys))))
+		if errThrottle != nil {
+			return false, models.ScenarioExecution{}, errors.Wrap(errThrottle,
+				"error when listing active rule throttles")
+		}
+		for _, throttle := range activeThrottles {
+			if throttleKeys[throttle.StableRuleId] == throttle.ThrottleKey {
+				throttles = append(throttles, throttle)
+			}
+		}
+	}
+
 	beforeRules := time.Now()
 
 	var (
@@ -309,6 +355,7 @@ func (e ScenarioEvaluator) processScenarioIteration(
 			dataAccessor,
 			params.DataModel,
 			snoozes,
+			throttles,
 			params.ConcurrentRules)
 
 		score = inScore
@@ -460,23 +507,34 @@ func (e ScenarioEvaluator) EvalTestRunScenario(
 		),
 	)
 	defer span.End()
-	testruns, err := e.scenarioTestRunRepository.ListTestRunsByScenarioID(ctx, exec, params.Scenario.Id, models.Up)
-	if err != nil {
-		return false, se, err
-	}
-	if len(testruns) == 0 || testruns[0].Status != models.Up {
-		return false, se, nil
-	}
+	var testRun models.ScenarioTestRun
+	if params.TestRun != nil {
+		testRun = *params.TestRun
+	} else {
+		testruns, err := e.scenarioTestRunRepository.ListTestRunsByScenarioID(ctx, exec, params.Scenario.Id, models.Up)
+		if err != nil {
+			return false, se, err
+		}
+		if len(testruns) == 0 || testruns[0].Status != models.Up {
+			return false, se, nil
+		}
 
-	if params.Scenario.LiveVersionID == nil || *params.Scenario.LiveVersionID != testruns[0].ScenarioLiveIterationId {
-		logger.WarnContext(ctx, "the live version iteration associated to the current testrun does not match with the actual live scenario iteration")
-		return false, se, nil
+		if params.Scenario.LiveVersionID == nil || *params.Scenario.LiveVersionID != testruns[0].ScenarioLiveIterationId {
+			logger.WarnContext(ctx, "the live version iteration associated to the current testrun does not match with the actual live scenario iteration")
+			return false, se, nil
+		}
+		testRun = testruns[0]
 	}
 
-	testRunIteration, err := e.evalScenarioRepository.GetScenarioIteration(ctx, exec, testruns[0].ScenarioIterationId, true)
+	// A backtested draft can be edited while it runs, it must not be read from the cache.
+	testRunIteration, err := e.evalScenarioRepository.GetScenarioIteration(ctx, exec,
+		testRun.ScenarioIterationId, params.TestRun == nil)
 	if err != nil {
 		return false, se, err
 	}
+	if testRunIteration.Version == nil {
+		testRunIteration.Version = utils.Ptr(models.ScenarioBacktestDraftVersion)
+	}
 
 	// If the live version had a screening executed, and if it has the same configuration (except for the trigger rule),
 	// we just reuse the cached screening execution to avoid another (possibly paid) call to the screening service.
@@ -492,7 +550,7 @@ func (e ScenarioEvaluator) EvalTestRunScenario(
 
 	if len(sccs) > 0 {
 		liveVersionSccs, err := e.evalScreeningConfigRepository.ListScreeningConfigs(
-			ctx, exec, testruns[0].ScenarioLiveIterationId, true)
+			ctx, exec, testRun.ScenarioLiveIterationId, true)
 		if err != nil {
 			return false, se, err
 		}
@@ -521,7 +579,7 @@ func (e ScenarioEvaluator) EvalTestRunScenario(
 	if len(copiedScreening) > 0 {
 		se.ScreeningExecutions = append(se.ScreeningExecutions, copiedScreening...)
 	}
-	se.TestRunId = testruns[0].Id
+	se.TestRunId = testRun.Id
 	return triggerPassed, se, nil
 }
 
@@ -552,10 +610,17 @@ func (e ScenarioEvaluator) EvalScenario(
 	// It is important to keep this short-circuit. If one day, a draft **can** be executed,
 	// it might be stored in the iteration cache and provide stale data to subsequent queries.
 	var targetVersionId string
+	var rolloutArm models.RolloutArm
 	if params.TargetIterationId != nil {
 		targetVersionId = *params.TargetIterationId
 	} else if params.Scenario.LiveVersionID != nil {
 		targetVersionId = *params.Scenario.LiveVersionID
+		// Only decisions that follow the live iteration are split with a running canary
+		objectId, _ := params.ClientObject.Data["object_id"].(string)
+		rolloutArm = params.Scenario.PickRolloutArm(objectId)
+		if rolloutArm == models.RolloutArmCanary {
+			targetVersionId = *params.Scenario.CanaryIterationId
+		}
 	} else {
 		return false, models.ScenarioExecution{}, errors.Wrap(models.ErrScenarioHasNoLiveVersion,
 			"scenario has no live version in EvalScenario")
@@ -567,6 +632,7 @@ func (e ScenarioEvaluator) EvalScenario(
 			attribute.String("scenario_id", params.Scenario.Id),
 			attribute.String("organization_id", params.Scenario.OrganizationId.String()),
 			attribute.String("scenario_iteration_id", targetVersionId),
+			attribute.String("rollout_arm", string(rolloutArm)),
 			attribute.String("object_id", params.ClientObject.Data["object_id"].(string)),
 		),
 	)
@@ -600,6 +666,7 @@ func (e ScenarioEvaluator) EvalScenario(
 		return false, models.ScenarioExecution{}, errors.Wrap(errSe,
 			"error processing scenario iteration in EvalScenario")
 	}
+	se.RolloutArm = rolloutArm
 	return triggerPassed, se, nil
 }
 
@@ -610,6 +677,7 @@ func (e ScenarioEvaluator) evalScenarioRule(
 	dataAccessor DataAccessor,
 	dataModel models.DataModel,
 	snoozes []models.RuleSnooze,
+	throttles []models.RuleThrottle,
 ) (int, models.RuleExecution, error) {
 	start := time.Now()
 	ruleExecution := models.RuleExecution{}
@@ -633,8 +701,25 @@ func (e ScenarioEvaluator) evalScenarioRule(
 	}
 
 	for _, snooze := range snoozes {
-		if rule.SnoozeGroupId != nil && *rule.SnoozeGroupId == snooze.SnoozeGroupId {
-			return 0, models.RuleExecution{Outcome: "snoozed", Rule: rule, Result: false}, nil
+		if rule.SnoozeGroupId == nil || *rule.SnoozeGroupId != snooze.SnoozeGroupId {
+			continue
+		}
+		lifted, execErr := e.snoozeLifted(ctx, cache, snooze, dataAccessor, dataModel)
+		if execErr != ast.NoError {
+			logger.WarnContext(ctx, "could not evaluate the unless condition of a snooze, the snooze applies",
+				slog.String("ruleId", rule.Id),
+				slog.String("ruleSnoozeId", snooze.Id),
+				slog.String("error", execErr.String()),
+			)
+		}
+		if !lifted {
+			return 0, models.RuleExecution{
+				Outcome:        "snoozed",
+				Rule:           rule,
+				Result:         false,
+				RuleSnoozeId:   &snooze.Id,
+				ExecutionError: execErr,
+			}, nil
 		}
 	}
 
@@ -649,6 +734,7 @@ func (e ScenarioEvaluator) evalScenarioRule(
 		dataAccessor.organizationId,
 		dataAccessor.ClientObject,
 		dataModel,
+		dataAccessor.AsOf,
 	)
 	switch {
 	// special errors are handled first
@@ -692,8 +778,12 @@ func (e ScenarioEvaluator) evalScenarioRule(
 	ruleStats := ast.BuildEvaluationStats(ruleEvaluation, false)
 	functionStats := ruleStats.FunctionStats()
 
-	// Increment scenario score when rule result is true
-	if ruleExecution.Result {
+	// Increment scenario score when rule result is true, unless a previous hit of the rule throttles it
+	if ruleExecution.Result && slices.ContainsFunc(throttles, func(t models.RuleThrottle) bool {
+		return t.StableRuleId == rule.StableRuleId
+	}) {
+		ruleExecution.Outcome = "throttled"
+	} else if ruleExecution.Result {
 		ruleExecution.Outcome = "hit"
 		ruleExecution.ResultScoreModifier = rule.ScoreModifier
 
@@ -711,6 +801,43 @@ func (e ScenarioEvaluator) evalScenarioRule(
 	return ruleExecution.ResultScoreModifier, ruleExecution, nil
 }
 
+// snoozeLifted tells if the unless condition of a snooze is true for the decision, in which case the
+// snooze does not apply. A condition that cannot be evaluated leaves the snooze in place, and the
+// error is returned to be recorded on the rule execution.
+func (e ScenarioEvaluator) snoozeLifted(
+	ctx context.Context,
+	cache *ast_eval.EvaluationCache,
+	snooze models.RuleSnooze,
+	dataAccessor DataAccessor,
+	dataModel models.DataModel,
+) (bool, ast.ExecutionError) {
+	if snooze.UnlessCondition == nil {
+		return false, ast.NoError
+	}
+
+	evaluation, err := e.evaluateAstExpression.EvaluateAstExpression(
+		ctx,
+		cache,
+		*snooze.UnlessCondition,
+		dataAccessor.organizationId,
+		dataAccessor.ClientObject,
+		dataModel,
+		dataAccessor.AsOf,
+	)
+	switch {
+	case err != nil:
+		return false, ast.AdaptExecutionError(err)
+	case evaluation.ReturnValue == nil:
+		return false, ast.NullFieldRead
+	}
+
+	lifted, ok := evaluation.ReturnValue.(bool)
+	if !ok {
+		return false, ast.Unknown
+	}
+	return lifted, ast.NoError
+}
+
 func (e ScenarioEvaluator) evalScenarioTrigger(
 	ctx context.Context,
 	cache *ast_eval.EvaluationCache,
@@ -718,6 +845,7 @@ func (e ScenarioEvaluator) evalScenarioTrigger(
 	organizationId uuid.UUID,
 	payload models.ClientObject,
 	dataModel models.DataModel,
+	asOf *time.Time,
 ) (bool, error) {
 	tracer := utils.OpenTelemetryTracerFromContext(ctx)
 	ctx, span := tracer.Start(ctx, "evaluate_scenario.evalScenarioTrigger")
@@ -730,6 +858,7 @@ func (e ScenarioEvaluator) evalScenarioTrigger(
 		organizationId,
 		payload,
 		dataModel,
+		asOf,
 	)
 	switch {
 	case ast.IsAuthorizedError(err):
@@ -755,6 +884,7 @@ func (e ScenarioEvaluator) evalAllScenarioRules(
 	dataAccessor DataAccessor,
 	dataModel models.DataModel,
 	snoozes []models.RuleSnooze,
+	throttles []models.RuleThrottle,
 	concurrency int,
 ) (int, []models.RuleExecution, error) {
 	// Results
@@ -782,7 +912,7 @@ func (e ScenarioEvaluator) evalAllScenarioRules(
 			}
 
 			// Eval each rule
-			scoreModifier, ruleExecution, err := e.evalScenarioRule(ctx, cache, rule, dataAccessor, dataModel, snoozes)
+			scoreModifier, ruleExecution, err := e.evalScenarioRule(ctx, cache, rule, dataAccessor, dataModel, snoozes, throttles)
 			if err != nil {
 				return err // First err will cancel the ctx
 			}
@@ -858,6 +988,7 @@ func (e ScenarioEvaluator) EvalCaseName(
 		params.Scenario.OrganizationId,
 		params.ClientObject,
 		params.DataModel,
+		params.AsOf,
 	)
 	logger := utils.LoggerFromContext(ctx)
 	switch {
@@ -0,0 +1,142 @@
+package evaluate_scenario
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval"
+	"github.com/checkmarble/marble-backend/utils"
+)
+
+func TestEvalScenarioRule_snoozes(t *testing.T) {
+	evaluator := ScenarioEvaluator{
+		evaluateAstExpression: ast_eval.EvaluateAstExpression{
+			AstEvaluationEnvironmentFactory: func(
+				params ast_eval.EvaluationEnvironmentFactoryParams,
+			) ast_eval.AstEvaluationEnvironment {
+				return ast_eval.NewAstEvaluationEnvironment()
+			},
+		},
+	}
+	rule := models.Rule{
+		Id:                   "rule",
+		SnoozeGroupId:        utils.Ptr("snooze_group"),
+		ScoreModifier:        10,
+		FormulaAstExpression: &ast.Node{Constant: true},
+	}
+	snooze := models.RuleSnooze{Id: "snooze", SnoozeGroupId: "snooze_group"}
+
+	evalRule := func(snoozes []models.RuleSnooze) (int, models.RuleExecution) {
+		score, execution, err := evaluator.evalScenarioRule(context.Background(),
+			ast_eval.NewEvaluationCache(), rule, DataAccessor{}, models.DataModel{}, snoozes, nil)
+		assert.NoError(t, err)
+		return score, execution
+	}
+
+	t.Run("snooze of another rule", func(t *testing.T) {
+		otherSnooze := snooze
+		otherSnooze.SnoozeGroupId = "other_group"
+		score, execution := evalRule([]models.RuleSnooze{otherSnooze})
+		assert.Equal(t, 10, score)
+		assert.Equal(t, "hit", execution.Outcome)
+		assert.Nil(t, execution.RuleSnoozeId)
+	})
+
+	t.Run("snoozed", func(t *testing.T) {
+		score, execution := evalRule([]models.RuleSnooze{snooze})
+		assert.Equal(t, 0, score)
+		assert.Equal(t, "snoozed", execution.Outcome)
+		assert.Equal(t, utils.Ptr("snooze"), execution.RuleSnoozeId)
+	})
+
+	t.Run("unless condition false", func(t *testing.T) {
+		conditionalSnooze := snooze
+		conditionalSnooze.UnlessCondition = &ast.Node{Constant: false}
+		score, execution := evalRule([]models.RuleSnooze{conditionalSnooze})
+		assert.Equal(t, 0, score)
+		assert.Equal(t, "snoozed", execution.Outcome)
+	})
+
+	t.Run("unless condition true", func(t *testing.T) {
+		conditionalSnooze := snooze
+		conditionalSnooze.UnlessCondition = &ast.Node{Constant: true}
+		score, execution := evalRule([]models.RuleSnooze{conditionalSnooze})
+		assert.Equal(t, 10, score)
+		assert.Equal(t, "hit", execution.Outcome)
+		assert.Nil(t, execution.RuleSnoozeId)
+	})
+
+	t.Run("unless condition without a value", func(t *testing.T) {
+		conditionalSnooze := snooze
+		conditionalSnooze.UnlessCondition = &ast.Node{Constant: nil}
+		_, execution := evalRule([]models.RuleSnooze{conditionalSnooze})
+		assert.Equal(t, "snoozed", execution.Outcome)
+		assert.Equal(t, ast.NullFieldRead, execution.ExecutionError)
+	})
+
+	t.Run("unless condition failing", func(t *testing.T) {
+		conditionalSnooze := snooze
+		conditionalSnooze.UnlessCondition = &ast.Node{
+			Function: ast.FUNC_GREATER,
+			Children: []ast.Node{
+				{
+					Function: ast.FUNC_DIVIDE,
+					Children: []ast.Node{{Constant: 1}, {Constant: 0}},
+				},
+				{Constant: 1},
+			},
+		}
+		score, execution := evalRule([]models.RuleSnooze{conditionalSnooze})
+		assert.Equal(t, 0, score)
+		assert.Equal(t, "snoozed", execution.Outcome)
+		assert.Equal(t, utils.Ptr("snooze"), execution.RuleSnoozeId)
+		assert.Equal(t, ast.DivisionByZero, execution.ExecutionError)
+	})
+}
+
+func TestEvalScenarioRule_throttles(t *testing.T) {
+	evaluator := ScenarioEvaluator{
+		evaluateAstExpression: ast_eval.EvaluateAstExpression{
+			AstEvaluationEnvironmentFactory: func(
+				params ast_eval.EvaluationEnvironmentFactoryParams,
+			) ast_eval.AstEvaluationEnvironment {
+				return ast_eval.NewAstEvaluationEnvironment()
+			},
+		},
+	}
+	rule := models.Rule{
+		Id:                   "rule",
+		StableRuleId:         "stable_rule",
+		ScoreModifier:        10,
+		ThrottlePeriod:       time.Hour,
+		FormulaAstExpression: &ast.Node{Constant: true},
+	}
+	throttle := models.RuleThrottle{StableRuleId: "stable_rule", ThrottleKey: "customer-1"}
+
+	evalRule := func(throttles []models.RuleThrottle) (int, models.RuleExecution) {
+		score, execution, err := evaluator.evalScenarioRule(context.Background(),
+			ast_eval.NewEvaluationCache(), rule, DataAccessor{}, models.DataModel{}, nil, throttles)
+		assert.NoError(t, err)
+		return score, execution
+	}
+
+	t.Run("throttle of another rule", func(t *testing.T) {
+		otherThrottle := throttle
+		otherThrottle.StableRuleId = "other_rule"
+		score, execution := evalRule([]models.RuleThrottle{otherThrottle})
+		assert.Equal(t, 10, score)
+		assert.Equal(t, "hit", execution.Outcome)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		score, execution := evalRule([]models.RuleThrottle{throttle})
+		assert.Equal(t, 0, score)
+		assert.Equal(t, "throttled", execution.Outcome)
+		assert.Equal(t, 0, execution.ResultScoreModifier)
+	})
+}
@@ -89,6 +89,7 @@ func (e ScenarioEvaluator) evaluateScreening(
 					params.Scenario.OrganizationId,
 					dataAccessor.ClientObject,
 					params.DataModel,
+					dataAccessor.AsOf,
 				)
 				if err != nil {
 					addScreeningError(scc, errors.New("could not parse screening trigger condition AST expression"))
@@ -130,7 +131,7 @@ func (e ScenarioEvaluator) evaluateScreening(
 				for fieldName, fieldAst := range scc.Query {
 					inputAst, err := e.evaluateAstExpression.EvaluateAstExpression(ctx, nil,
 						fieldAst, iteration.OrganizationId,
-						dataAccessor.ClientObject, dataAccessor.DataModel)
+						dataAccessor.ClientObject, dataAccessor.DataModel, dataAccessor.AsOf)
 					if err != nil {
 						addScreeningError(scc, errors.New("could not parse screening counterparty name AST expression"))
 						return
@@ -199,6 +200,7 @@ func (e ScenarioEvaluator) evaluateScreening(
 					params.Scenario.OrganizationId,
 					dataAccessor.ClientObject,
 					params.DataModel,
+					dataAccessor.AsOf,
 				)
 				if err != nil {
 					addScreeningError(scc, errors.New("could not parse screening counterparty ID AST expression"))
@@ -230,6 +232,13 @@ func (e ScenarioEvaluator) evaluateScreening(
 						)
 						return
 					}
+					objectId, _ := dataAccessor.ClientObject.Data["object_id"].(string)
+					whitelistRecords = models.FilterApplicableScreeningWhitelists(
+						whitelistRecords,
+						models.NewScreeningWhitelistScope(scc.StableId,
+							dataAccessor.ClientObject.TableName, objectId),
+						time.Now(),
+					)
 					whitelistIds := pure_utils.Map(
 						whitelistRecords,
 						func(item models.ScreeningWhitelist) string {
@@ -301,6 +310,11 @@ func (e ScenarioEvaluator) preprocess(
 
 	out := deepcopy.Copy(queries).([]models.OpenSanctionsCheckQuery)
 
+	// Preprocessing steps work on names, identifiers are matched as they are.
+	if (models.OpenSanctionsQuery{Queries: out}).IsIdentifierOnly() {
+		return out, nil
+	}
+
 	steps := []ScreeningPreprocessor{
 		SkipIfUnder,
 		NameEntityRecognition,
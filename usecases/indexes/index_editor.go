@@ -43,6 +43,7 @@ type IngestedDataIndexesRepository interface {
 	ListIndicesPendingCreation(ctx context.Context, exec repositories.Executor) ([]string, error)
 	ListInvalidIndices(ctx context.Context, exec repositories.Executor) ([]string, error)
 	DeleteIndex(ctx context.Context, exec repositories.Executor, indexName string) error
+	CreateMissingHistoryIndexesAsync(ctx context.Context, exec repositories.Executor) ([]string, error)
 }
 
 type ScenarioFetcher interface {
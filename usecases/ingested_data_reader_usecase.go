@@ -33,6 +33,12 @@ type ingestedDataReaderClientDbRepository interface {
 	) ([]models.DataModelObject, error)
 	GatherFieldStatistics(ctx context.Context, exec repositories.Executor, table models.Table,
 		orgId uuid.UUID) ([]models.FieldStatistics, error)
+	QueryIngestedObjectVersions(
+		ctx context.Context,
+		exec repositories.Executor,
+		table models.Table,
+		objectId string,
+	) ([]models.DataModelObject, error)
 }
 
 type ingestedDataReaderRepository interface {
@@ -127,6 +133,85 @@ func (usecase IngestedDataReaderUsecase) GetIngestedObject(
 	return clientObjects, nil
 }
 
+// GetIngestedObjectAsOf returns the version of the object that was valid at the given time, if the
+// object existed then and that version was not deleted by a retention policy.
+func (usecase IngestedDataReaderUsecase) GetIngestedObjectAsOf(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	objectType string,
+	objectId string,
+	asOf time.Time,
+) ([]models.ClientObjectDetail, error) {
+	versions, err := usecase.readIngestedObjectVersions(ctx, organizationId, objectType, objectId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if version.IsValidAt(asOf) {
+			return []models.ClientObjectDetail{{
+				Data:     version.Data,
+				Metadata: models.ClientObjectMetadata{ValidFrom: &version.ValidFrom, ObjectType: objectType},
+			}}, nil
+		}
+	}
+	return []models.ClientObjectDetail{}, nil
+}
+
+// GetIngestedObjectHistory returns the versions of the object from the most recent to the oldest,
+// each with the fields that changed since the previous version.
+func (usecase IngestedDataReaderUsecase) GetIngestedObjectHistory(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	objectType string,
+	objectId string,
+) ([]models.ClientObjectVersion, error) {
+	versions, err := usecase.readIngestedObjectVersions(ctx, organizationId, objectType, objectId)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewClientObjectHistory(versions), nil
+}
+
+func (usecase IngestedDataReaderUsecase) readIngestedObjectVersions(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	objectType string,
+	objectId string,
+) ([]models.ClientObjectVersion, error) {
+	dataModel, err := usecase.dataModelUsecase.GetDataModel(ctx, organizationId, models.DataModelReadOptions{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	table, ok := dataModel.Tables[objectType]
+	if !ok {
+		return nil, errors.Wrapf(models.NotFoundError, "Table '%s' not found in readIngestedObjectVersions", objectType)
+	}
+
+	db, err := usecase.executorFactory.NewClientDbExecutor(ctx, organizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := usecase.clientDbRepository.QueryIngestedObjectVersions(ctx, db, table, objectId)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]models.ClientObjectVersion, len(objects))
+	for i, object := range objects {
+		validFrom, _ := object.Metadata["valid_from"].(time.Time)
+		version := models.ClientObjectVersion{ValidFrom: validFrom, Data: object.Data}
+		// The live version is valid until 'infinity', which is not read as a time.Time
+		if validUntil, ok := object.Metadata["valid_until"].(time.Time); ok {
+			version.ValidUntil = &validUntil
+		}
+		versions[i] = version
+	}
+	return versions, nil
+}
+
 func (usecase IngestedDataReaderUsecase) ReadPivotObjectsFromValues(
 	ctx context.Context,
 	orgId uuid.UUID,
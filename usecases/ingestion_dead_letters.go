@@ -0,0 +1,304 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/payload_parser"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const (
+	// Beyond this number of rejected rows, a file is most likely wrong as a whole and the rows are
+	// better fixed in the file itself.
+	maxDeadLettersPerUpload = 1000
+	// Dead letters replayed by a single bulk replay request
+	ingestionDeadLetterBulkReplayLimit = 500
+)
+
+type ingestionDeadLetterRepository interface {
+	CreateIngestionDeadLetters(ctx context.Context, exec repositories.Executor,
+		deadLetters []models.IngestionDeadLetter) error
+	GetIngestionDeadLetter(ctx context.Context, exec repositories.Executor,
+		id uuid.UUID) (models.IngestionDeadLetter, error)
+	ListIngestionDeadLetters(ctx context.Context, exec repositories.Executor, organizationId uuid.UUID,
+		filters models.IngestionDeadLetterFilters, pagination models.PaginationAndSorting) ([]models.IngestionDeadLetter, error)
+	ListIngestionDeadLettersToReplay(ctx context.Context, exec repositories.Executor, organizationId uuid.UUID,
+		filters models.IngestionDeadLetterFilters, limit int) ([]models.IngestionDeadLetter, error)
+	UpdateIngestionDeadLetterPayload(ctx context.Context, exec repositories.Executor,
+		deadLetter models.IngestionDeadLetter) error
+	RecordIngestionDeadLetterReplay(ctx context.Context, exec repositories.Executor,
+		deadLetter models.IngestionDeadLetter) error
+}
+
+// recordDeadLetters keeps rejected payloads so that they can be replayed later. It does not fail:
+// the caller still reports the rejection itself, whether the payloads could be kept or not.
+func (usecase *IngestionUseCase) recordDeadLetters(ctx context.Context, deadLetters []models.IngestionDeadLetter) {
+	if len(deadLetters) == 0 {
+		return
+	}
+
+	// The request may have been cancelled by the time the rejection is known
+	ctx = context.WithoutCancel(ctx)
+	if err := usecase.deadLetterRepository.CreateIngestionDeadLetters(ctx,
+		usecase.executorFactory.NewExecutor(), deadLetters); err != nil {
+		utils.LogAndReportSentryError(ctx, errors.Wrap(err, "could not record ingestion dead letters"))
+	}
+}
+
+func apiDeadLetter(
+	organizationId uuid.UUID,
+	table models.Table,
+	parser *payload_parser.Parser,
+	payload json.RawMessage,
+	rejection error,
+	ingestionOptions models.IngestionOptions,
+) models.IngestionDeadLetter {
+	deadLetter := models.NewIngestionDeadLetter(organizationId, table.Name, models.IngestionDeadLetterSourceApi,
+		models.IngestionDeadLetterFormatJson, payload, rejection, ingestionOptions)
+	deadLetter.IsPatch = parser.AllowsPatch()
+	return deadLetter
+}
+
+// csvDeadLetterPayload keeps a CSV row as an object mapping the columns to their raw values.
+func csvDeadLetterPayload(headers []string, row []string) json.RawMessage {
+	object := make(map[string]string, len(headers))
+	for i, header := range headers {
+		if i < len(row) {
+			object[header] = row[i]
+		}
+	}
+	payload, _ := json.Marshal(object)
+	return payload
+}
+
+func (usecase *IngestionUseCase) ListIngestionDeadLetters(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	filters models.IngestionDeadLetterFilters,
+	pagination models.PaginationAndSorting,
+) (models.Paginated[models.IngestionDeadLetter], error) {
+	if err := usecase.enforceSecurity.CanIngest(organizationId); err != nil {
+		return models.Paginated[models.IngestionDeadLetter]{}, err
+	}
+
+	page := pagination
+	page.Limit = pagination.Limit + 1
+
+	deadLetters, err := usecase.deadLetterRepository.ListIngestionDeadLetters(ctx,
+		usecase.executorFactory.NewExecutor(), organizationId, filters, page)
+	if err != nil {
+		return models.Paginated[models.IngestionDeadLetter]{}, err
+	}
+
+	return models.Paginated[models.IngestionDeadLetter]{
+		Items:       deadLetters[:min(len(deadLetters), pagination.Limit)],
+		HasNextPage: len(deadLetters) > pagination.Limit,
+	}, nil
+}
+
+func (usecase *IngestionUseCase) GetIngestionDeadLetter(ctx context.Context, id uuid.UUID) (models.IngestionDeadLetter, error) {
+	deadLetter, err := usecase.deadLetterRepository.GetIngestionDeadLetter(ctx,
+		usecase.executorFactory.NewExecutor(), id)
+	if err != nil {
+		return models.IngestionDeadLetter{}, err
+	}
+	if err := usecase.enforceSecurity.ReadIngestionDeadLetter(deadLetter); err != nil {
+		return models.IngestionDeadLetter{}, err
+	}
+	return deadLetter, nil
+}
+
+// ReplayIngestionDeadLetter ingests the payload of the dead letter again, replacing it first with
+// the fixed payload if one is given. The fixed payload has the same format as the original one. If
+// the payload is rejected again, the dead letter stays pending with the new rejection reason, which
+// is also returned.
+func (usecase *IngestionUseCase) ReplayIngestionDeadLetter(
+	ctx context.Context,
+	id uuid.UUID,
+	fixedPayload json.RawMessage,
+) (models.IngestionDeadLetter, error) {
+	deadLetter, err := usecase.GetIngestionDeadLetter(ctx, id)
+	if err != nil {
+		return models.IngestionDeadLetter{}, err
+	}
+	if err := usecase.enforceSecurity.CanIngest(deadLetter.OrganizationId); err != nil {
+		return models.IngestionDeadLetter{}, err
+	}
+	if deadLetter.Status == models.IngestionDeadLetterReplayed {
+		return models.IngestionDeadLetter{}, errors.Wrap(models.ConflictError,
+			"the dead letter was already replayed")
+	}
+
+	exec := usecase.executorFactory.NewExecutor()
+	if len(fixedPayload) > 0 {
+		deadLetter.SetPayload(fixedPayload)
+		if err := usecase.deadLetterRepository.UpdateIngestionDeadLetterPayload(ctx, exec, deadLetter); err != nil {
+			return models.IngestionDeadLetter{}, err
+		}
+	}
+
+	dataModel, err := usecase.dataModelRepository.GetDataModel(ctx, exec, deadLetter.OrganizationId, false, true)
+	if err != nil {
+		return models.IngestionDeadLetter{}, errors.Wrap(err, "error getting data model in ReplayIngestionDeadLetter")
+	}
+
+	rejection, err := usecase.replayDeadLetter(ctx, dataModel, deadLetter)
+	if err != nil {
+		return models.IngestionDeadLetter{}, err
+	}
+	if rejection != nil {
+		return models.IngestionDeadLetter{}, rejection
+	}
+
+	return usecase.deadLetterRepository.GetIngestionDeadLetter(ctx, exec, id)
+}
+
+// ReplayIngestionDeadLetters replays the pending dead letters of the organization matching the
+// filters, a batch at a time.
+func (usecase *IngestionUseCase) ReplayIngestionDeadLetters(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	filters models.IngestionDeadLetterFilters,
+) (models.IngestionDeadLetterReplayReport, error) {
+	if err := usecase.enforceSecurity.CanIngest(organizationId); err != nil {
+		return models.IngestionDeadLetterReplayReport{}, err
+	}
+
+	exec := usecase.executorFactory.NewExecutor()
+	deadLetters, err := usecase.deadLetterRepository.ListIngestionDeadLettersToReplay(ctx, exec,
+		organizationId, filters, ingestionDeadLetterBulkReplayLimit+1)
+	if err != nil {
+		return models.IngestionDeadLetterReplayReport{}, err
+	}
+
+	report := models.IngestionDeadLetterReplayReport{
+		HasMore: len(deadLetters) > ingestionDeadLetterBulkReplayLimit,
+	}
+	if len(deadLetters) == 0 {
+		return report, nil
+	}
+
+	dataModel, err := usecase.dataModelRepository.GetDataModel(ctx, exec, organizationId, false, true)
+	if err != nil {
+		return models.IngestionDeadLetterReplayReport{}, errors.Wrap(err,
+			"error getting data model in ReplayIngestionDeadLetters")
+	}
+
+	for _, deadLetter := range deadLetters[:min(len(deadLetters), ingestionDeadLetterBulkReplayLimit)] {
+		if err := usecase.enforceSecurity.ReadIngestionDeadLetter(deadLetter); err != nil {
+			return models.IngestionDeadLetterReplayReport{}, err
+		}
+
+		rejection, err := usecase.replayDeadLetter(ctx, dataModel, deadLetter)
+		if err != nil {
+			return report, err
+		}
+		if rejection != nil {
+			report.Failed++
+		} else {
+			report.Replayed++
+		}
+	}
+
+	return report, nil
+}
+
+// replayDeadLetter sends the payload of the dead letter through the ingestion path again and records
+// the outcome. It returns the rejection if the payload was rejected again, and an error only if the
+// replay could not be attempted.
+func (usecase *IngestionUseCase) replayDeadLetter(
+	ctx context.Context,
+	dataModel models.DataModel,
+	deadLetter models.IngestionDeadLetter,
+) (rejection error, err error) {
+	table, ok := dataModel.Tables[deadLetter.ObjectType]
+	if !ok {
+		rejection = errors.WithDetailf(models.NotFoundError,
+			"table %s not found in data model", deadLetter.ObjectType)
+	} else {
+		rejection, err = usecase.ingestDeadLetter(ctx, table, deadLetter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deadLetter.Status = models.IngestionDeadLetterReplayed
+	if rejection != nil {
+		deadLetter.Status = models.IngestionDeadLetterPending
+		deadLetter.SetRejection(rejection)
+	}
+	if err := usecase.deadLetterRepository.RecordIngestionDeadLetterReplay(ctx,
+		usecase.executorFactory.NewExecutor(), deadLetter); err != nil {
+		return nil, err
+	}
+
+	return rejection, nil
+}
+
+func (usecase *IngestionUseCase) ingestDeadLetter(
+	ctx context.Context,
+	table models.Table,
+	deadLetter models.IngestionDeadLetter,
+) (rejection error, err error) {
+	var object models.ClientObject
+	switch deadLetter.PayloadFormat {
+	case models.IngestionDeadLetterFormatCsv:
+		var row map[string]string
+		if err := json.Unmarshal(deadLetter.Payload, &row); err != nil {
+			return errors.Wrap(models.BadParameterError, "the payload is not an object of text values"), nil
+		}
+		headers := slices.Sorted(maps.Keys(row))
+		values := make([]string, len(headers))
+		for i, header := range headers {
+			values[i] = row[header]
+		}
+		data, err := parseStringValuesToMap(headers, values, table, usecase.payloadEnricher)
+		if err != nil {
+			return errors.Wrap(models.BadParameterError, err.Error()), nil
+		}
+		object = models.ClientObject{TableName: table.Name, Data: data}
+	default:
+		parser := payload_parser.NewParser(payload_parser.WithColumnEscape(),
+			payload_parser.WithAllowedPatch(deadLetter.IsPatch), payload_parser.WithoutDerivedFields(),
+			payload_parser.WithEnricher(usecase.payloadEnricher))
+		object, err = parser.ParsePayload(ctx, table, deadLetter.Payload)
+		if err != nil {
+			return err, nil
+		}
+	}
+
+	options := deadLetter.IngestionOptions
+	if options.ShouldMonitor {
+		exec := usecase.executorFactory.NewExecutor()
+		org, err := usecase.continuousScreeningRepository.GetOrganizationById(ctx, exec, deadLetter.OrganizationId)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting organization")
+		}
+		configs, err := usecase.continuousScreeningRepository.ListContinuousScreeningConfigByStableIds(ctx, exec,
+			deadLetter.OrganizationId, org.GetScreeningProviderFor(models.ScreeningFeatureContinuousMonitoring),
+			options.ContinuousScreeningIds)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateContinuousScreeningConfigs(configs, options.ContinuousScreeningIds, table.Name); err != nil {
+			return err, nil
+		}
+	}
+
+	err = retryIngestion(ctx, func() error {
+		_, err := usecase.insertEnumValuesAndIngest(ctx, deadLetter.OrganizationId,
+			[]models.ClientObject{object}, table, options)
+		return err
+	})
+	if errors.Is(err, models.BadParameterError) {
+		return err, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,375 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/payload_parser"
+	"github.com/cockroachdb/errors"
+)
+
+// ingestionFileReader reads the rows of an uploaded file one at a time, whatever its format, so that
+// all formats share the same batching, checkpointing and deadline handling.
+type ingestionFileReader interface {
+	// columns returns the columns of the file, checked against the required fields of the table
+	// before any row is read, or nil if the format has no header and rows are checked one by one.
+	columns() []string
+	// next moves to the next row of the file, returning io.EOF once all rows have been read.
+	next(ctx context.Context) error
+	// parse parses the current row into the data of a client object of the table.
+	parse(ctx context.Context, table models.Table) (map[string]any, error)
+	// checkpoint returns the position at which a later attempt should resume reading: the absolute
+	// byte offset of the next row for text formats, the index of the next row for Parquet.
+	checkpoint() int64
+	// position describes where the current row is located in the file, for error messages.
+	position() string
+	// rawRow returns the current row as it was read, to be kept as a dead letter if it is rejected.
+	rawRow() (json.RawMessage, models.IngestionDeadLetterFormat)
+}
+
+// csvFileReader reads a CSV file from a data row, its header having been read separately by
+// readCsvHeader so that the reader can start at an arbitrary offset when resuming. No BOM handling
+// either, since a BOM can only ever sit at byte 0 of the file.
+type csvFileReader struct {
+	reader      *csv.Reader
+	header      []string
+	startOffset int64
+	record      []string
+	enricher    payload_parser.PayloadEnrichementUsecase
+}
+
+func newCsvFileReader(fileReader io.Reader, header []string, startOffset int64,
+	enricher payload_parser.PayloadEnrichementUsecase,
+) *csvFileReader {
+	reader := csv.NewReader(fileReader)
+	// Normally established by the first row csv.Reader reads, which here is a data row rather than
+	// the header. Setting it explicitly keeps the column-count check identical on a first attempt
+	// and on a resume.
+	reader.FieldsPerRecord = len(header)
+
+	return &csvFileReader{
+		reader:      reader,
+		header:      header,
+		startOffset: startOffset,
+		enricher:    enricher,
+	}
+}
+
+func (r *csvFileReader) columns() []string {
+	return r.header
+}
+
+func (r *csvFileReader) next(ctx context.Context) error {
+	record, err := r.reader.Read()
+	r.record = record
+	return err
+}
+
+func (r *csvFileReader) parse(ctx context.Context, table models.Table) (map[string]any, error) {
+	return parseStringValuesToMap(r.header, r.record, table, r.enricher)
+}
+
+func (r *csvFileReader) checkpoint() int64 {
+	return r.startOffset + r.reader.InputOffset()
+}
+
+func (r *csvFileReader) position() string {
+	return fmt.Sprintf("byte offset %d", r.checkpoint())
+}
+
+func (r *csvFileReader) rawRow() (json.RawMessage, models.IngestionDeadLetterFormat) {
+	return csvDeadLetterPayload(r.header, r.record), models.IngestionDeadLetterFormatCsv
+}
+
+// ndjsonFileReader reads a file holding one JSON object per line, each parsed the same way as the
+// payloads of the ingestion API. Blank lines are skipped.
+type ndjsonFileReader struct {
+	reader *bufio.Reader
+	offset int64
+	line   []byte
+	parser *payload_parser.Parser
+}
+
+func newNdjsonFileReader(fileReader io.Reader, startOffset int64,
+	enricher payload_parser.PayloadEnrichementUsecase,
+) *ndjsonFileReader {
+	// As for CSV files, only a reader starting at byte 0 can see a BOM, and its length is counted so
+	// that checkpoints remain absolute offsets.
+	if startOffset == 0 {
+		var bomLen int64
+		fileReader, bomLen = pure_utils.TrimBom(fileReader)
+		startOffset += bomLen
+	}
+
+	return &ndjsonFileReader{
+		reader: bufio.NewReader(fileReader),
+		offset: startOffset,
+		parser: payload_parser.NewParser(
+			payload_parser.WithColumnEscape(),
+			payload_parser.WithoutDerivedFields(),
+			payload_parser.WithEnricher(enricher),
+		),
+	}
+}
+
+func (r *ndjsonFileReader) columns() []string {
+	return nil
+}
+
+func (r *ndjsonFileReader) next(ctx context.Context) error {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		r.offset += int64(len(line))
+		if err != nil && err != io.EOF { //nolint:errorlint
+			return err
+		}
+
+		// The last line may not end with a newline, in which case it comes along with io.EOF.
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			r.line = line
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *ndjsonFileReader) parse(ctx context.Context, table models.Table) (map[string]any, error) {
+	object, err := r.parser.ParsePayload(ctx, table, r.line)
+	if err != nil {
+		return nil, err
+	}
+	return object.Data, nil
+}
+
+func (r *ndjsonFileReader) checkpoint() int64 {
+	return r.offset
+}
+
+func (r *ndjsonFileReader) position() string {
+	return fmt.Sprintf("byte offset %d", r.offset)
+}
+
+func (r *ndjsonFileReader) rawRow() (json.RawMessage, models.IngestionDeadLetterFormat) {
+	// Not necessarily valid JSON, in which case it is kept as a JSON string
+	if !json.Valid(r.line) {
+		line, _ := json.Marshal(string(r.line))
+		return line, models.IngestionDeadLetterFormatJson
+	}
+	return slices.Clone(r.line), models.IngestionDeadLetterFormatJson
+}
+
+// parquetFileReader reads a Parquet file downloaded to a local temporary file, which it removes when
+// closed. Parquet files can only be read from their footer, so they are not read from a byte offset
+// but from a row index.
+type parquetFileReader struct {
+	file     *repositories.ParquetFile
+	tempPath string
+	nextRow  int64
+	batch    []map[string]any
+	row      map[string]any
+	enricher payload_parser.PayloadEnrichementUsecase
+}
+
+func newParquetFileReader(ctx context.Context, blob io.Reader, startRow int64,
+	enricher payload_parser.PayloadEnrichementUsecase,
+) (*parquetFileReader, error) {
+	tempFile, err := os.CreateTemp("", "marble-ingestion-*.parquet")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create temporary file for parquet file")
+	}
+	_, err = io.Copy(tempFile, blob)
+	if errClose := tempFile.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, errors.Wrap(err, "could not download parquet file")
+	}
+
+	file, err := repositories.OpenParquetFile(ctx, tempFile.Name())
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, err
+	}
+
+	return &parquetFileReader{
+		file:     file,
+		tempPath: tempFile.Name(),
+		nextRow:  startRow,
+		enricher: enricher,
+	}, nil
+}
+
+func (r *parquetFileReader) columns() []string {
+	return r.file.Columns()
+}
+
+func (r *parquetFileReader) next(ctx context.Context) error {
+	if len(r.batch) == 0 {
+		rows, err := r.file.ReadRows(ctx, r.nextRow, csvIngestionBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return io.EOF
+		}
+		r.batch = rows
+	}
+
+	r.row = r.batch[0]
+	r.batch = r.batch[1:]
+	r.nextRow++
+	return nil
+}
+
+func (r *parquetFileReader) parse(ctx context.Context, table models.Table) (map[string]any, error) {
+	return parseNativeValuesToMap(r.row, table, r.enricher)
+}
+
+func (r *parquetFileReader) checkpoint() int64 {
+	return r.nextRow
+}
+
+func (r *parquetFileReader) position() string {
+	return fmt.Sprintf("file row %d", r.nextRow-1)
+}
+
+func (r *parquetFileReader) rawRow() (json.RawMessage, models.IngestionDeadLetterFormat) {
+	// Typed values are encoded the way the ingestion API expects them, timestamps as RFC 3339 strings
+	row, err := json.Marshal(r.row)
+	if err != nil {
+		row, _ = json.Marshal(fmt.Sprintf("%v", r.row))
+	}
+	return row, models.IngestionDeadLetterFormatJson
+}
+
+func (r *parquetFileReader) numRows(ctx context.Context) (int64, error) {
+	return r.file.NumRows(ctx)
+}
+
+func (r *parquetFileReader) Close() error {
+	err := r.file.Close()
+	if errRemove := os.Remove(r.tempPath); err == nil {
+		err = errRemove
+	}
+	return err
+}
+
+func ingestionFileFormatLabel(fileFormat models.IngestionFileFormat) string {
+	switch fileFormat {
+	case models.IngestionFileFormatNdjson:
+		return "NDJSON"
+	case models.IngestionFileFormatParquet:
+		return "Parquet"
+	}
+	return "CSV"
+}
+
+// parseNativeValuesToMap parses a row read from a typed file format. Values whose type matches the
+// data type of their field are used as is, while text values are parsed the same way as CSV values.
+func parseNativeValuesToMap(values map[string]any, table models.Table,
+	enricher payload_parser.PayloadEnrichementUsecase,
+) (map[string]any, error) {
+	result := make(map[string]any)
+
+	for fieldName, value := range values {
+		field, ok := table.Fields[fieldName]
+		if !ok {
+			return nil, fmt.Errorf("field %s not found in table %s", fieldName, table.Name)
+		}
+
+		if value == nil {
+			if !field.Nullable {
+				return nil, fmt.Errorf("field %s is required but is null", fieldName)
+			}
+			result[fieldName] = nil
+			continue
+		}
+
+		if str, ok := value.(string); ok {
+			if err := parseStringValue(result, fieldName, field, str, enricher); err != nil {
+				return nil, err
+			}
+		} else {
+			val, err := parseNativeValue(fieldName, field, value)
+			if err != nil {
+				return nil, err
+			}
+			result[fieldName] = val
+		}
+
+		if err := checkFieldConstraints(fieldName, field, result[fieldName]); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func parseNativeValue(fieldName string, field models.Field, value any) (any, error) {
+	switch field.DataType {
+	case models.Timestamp:
+		if val, ok := value.(time.Time); ok {
+			return val.UTC(), nil
+		}
+	case models.Bool:
+		if val, ok := value.(bool); ok {
+			return val, nil
+		}
+	case models.Int:
+		if val, ok := nativeInt(value); ok {
+			return val, nil
+		}
+	case models.Float:
+		if val, ok := nativeInt(value); ok {
+			return float64(val), nil
+		}
+		switch val := value.(type) {
+		case float64:
+			return val, nil
+		case float32:
+			return float64(val), nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid value of type %T for field %s of type %s", value, fieldName, field.DataType)
+}
+
+func nativeInt(value any) (int, bool) {
+	switch val := value.(type) {
+	case int8:
+		return int(val), true
+	case int16:
+		return int(val), true
+	case int32:
+		return int(val), true
+	case int64:
+		return int(val), true
+	case uint8:
+		return int(val), true
+	case uint16:
+		return int(val), true
+	case uint32:
+		return int(val), true
+	case uint64:
+		if val > math.MaxInt64 {
+			return 0, false
+		}
+		return int(val), true
+	}
+	return 0, false
+}
@@ -0,0 +1,235 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases/payload_parser"
+	"github.com/duckdb/duckdb-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fileReadersTestTable() models.Table {
+	return models.Table{
+		Name: "transactions",
+		Fields: map[string]models.Field{
+			"object_id":  {Name: "object_id", DataType: models.String, Nullable: false},
+			"updated_at": {Name: "updated_at", DataType: models.Timestamp, Nullable: false},
+			"amount":     {Name: "amount", DataType: models.Float, Nullable: true},
+			"count":      {Name: "count", DataType: models.Int, Nullable: true},
+			"flagged":    {Name: "flagged", DataType: models.Bool, Nullable: true},
+		},
+	}
+}
+
+// readAllObjectIds reads the rest of the file, returning the object ids of the rows it contains.
+func readAllObjectIds(t *testing.T, reader ingestionFileReader, table models.Table) []string {
+	t.Helper()
+
+	var objectIds []string
+	for {
+		err := reader.next(context.Background())
+		if err == io.EOF { //nolint:errorlint
+			return objectIds
+		}
+		require.NoError(t, err)
+
+		object, err := reader.parse(context.Background(), table)
+		require.NoError(t, err)
+		objectIds = append(objectIds, object["object_id"].(string))
+	}
+}
+
+// TestNdjsonResumeFromCheckpointOffset is the NDJSON counterpart of TestCsvResumeFromCheckpointOffset:
+// reopening the file at a checkpoint must yield every remaining row exactly once.
+func TestNdjsonResumeFromCheckpointOffset(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "plain lines",
+			content: `{"object_id": "a", "updated_at": "2024-01-01T00:00:00Z"}
+{"object_id": "b", "updated_at": "2024-01-02T00:00:00Z"}
+{"object_id": "c", "updated_at": "2024-01-03T00:00:00Z"}
+{"object_id": "d", "updated_at": "2024-01-04T00:00:00Z"}
+`,
+		},
+		{
+			name: "blank lines, CRLF and no trailing newline",
+			content: "\ufeff" + `{"object_id": "a", "updated_at": "2024-01-01T00:00:00Z"}` + "\r\n\r\n" +
+				`{"object_id": "b", "updated_at": "2024-01-02T00:00:00Z"}` + "\n\n" +
+				`{"object_id": "c", "updated_at": "2024-01-03T00:00:00Z"}` + "\n" +
+				`{"object_id": "d", "updated_at": "2024-01-04T00:00:00Z"}`,
+		},
+	}
+
+	table := fileReadersTestTable()
+	enricher := payload_parser.NewPayloadEnrichmentUsecase(nil, nil)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			firstPass := newNdjsonFileReader(strings.NewReader(tc.content), 0, enricher)
+			for range 2 {
+				require.NoError(t, firstPass.next(context.Background()))
+			}
+			checkpoint := firstPass.checkpoint()
+
+			resumed := newNdjsonFileReader(strings.NewReader(tc.content[checkpoint:]), checkpoint, enricher)
+			assert.Equal(t, []string{"c", "d"}, readAllObjectIds(t, resumed, table))
+			assert.Equal(t, int64(len(tc.content)), resumed.checkpoint(),
+				"the checkpoint must land at the end of the file once it has been read entirely")
+		})
+	}
+}
+
+func TestNdjsonFileReaderParseError(t *testing.T) {
+	content := `{"object_id": "a", "updated_at": "2024-01-01T00:00:00Z", "amount": "not a number"}` + "\n"
+	reader := newNdjsonFileReader(strings.NewReader(content), 0, payload_parser.NewPayloadEnrichmentUsecase(nil, nil))
+
+	require.NoError(t, reader.next(context.Background()))
+	_, err := reader.parse(context.Background(), fileReadersTestTable())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, models.BadParameterError)
+	assert.Equal(t, fmt.Sprintf("byte offset %d", len(content)), reader.position())
+}
+
+func TestParseNativeValuesToMap(t *testing.T) {
+	table := fileReadersTestTable()
+	enricher := payload_parser.NewPayloadEnrichmentUsecase(nil, nil)
+	updatedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+
+	result, err := parseNativeValuesToMap(map[string]any{
+		"object_id":  "a",
+		"updated_at": updatedAt,
+		"amount":     int32(12),
+		"count":      int64(3),
+		"flagged":    true,
+	}, table, enricher)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"object_id":  "a",
+		"updated_at": updatedAt.UTC(),
+		"amount":     float64(12),
+		"count":      3,
+		"flagged":    true,
+	}, result)
+
+	t.Run("text values are parsed as in CSV files", func(t *testing.T) {
+		result, err := parseNativeValuesToMap(map[string]any{
+			"object_id":  "a",
+			"updated_at": "2024-01-01T10:00:00Z",
+			"amount":     "1.5",
+			"flagged":    "",
+		}, table, enricher)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), result["updated_at"])
+		assert.Equal(t, 1.5, result["amount"])
+		assert.Nil(t, result["flagged"])
+	})
+
+	errorCases := map[string]map[string]any{
+		"null required field": {"object_id": "a", "updated_at": nil},
+		"unknown field":       {"object_id": "a", "updated_at": updatedAt, "unknown": 1},
+		"mismatched type":     {"object_id": "a", "updated_at": updatedAt, "flagged": 1.5},
+		"float as int":        {"object_id": "a", "updated_at": updatedAt, "count": 1.5},
+	}
+	for name, values := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseNativeValuesToMap(values, table, enricher)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func writeTestParquetFile(t *testing.T, numRows int) string {
+	t.Helper()
+
+	connector, err := duckdb.NewConnector("", nil)
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	path := filepath.Join(t.TempDir(), "upload.parquet")
+	_, err = db.Exec(fmt.Sprintf(`COPY (
+		SELECT
+			'object_' || i AS object_id,
+			TIMESTAMPTZ '2024-01-01 00:00:00+00' + to_days(i::INT) AS updated_at,
+			i::DECIMAL(10, 2) AS amount,
+			i::INT AS count,
+			i %% 2 = 0 AS flagged
+		FROM range(%d) t(i)
+	) TO '%s' (FORMAT parquet, ROW_GROUP_SIZE 2)`, numRows, path))
+	require.NoError(t, err)
+
+	return path
+}
+
+func TestParquetFileReaderResumeFromCheckpoint(t *testing.T) {
+	path := writeTestParquetFile(t, 5)
+	table := fileReadersTestTable()
+	enricher := payload_parser.NewPayloadEnrichmentUsecase(nil, nil)
+
+	openFile := func(startRow int64) *parquetFileReader {
+		file, err := os.Open(path)
+		require.NoError(t, err)
+		defer file.Close()
+
+		reader, err := newParquetFileReader(context.Background(), file, startRow, enricher)
+		require.NoError(t, err)
+		t.Cleanup(func() { reader.Close() })
+		return reader
+	}
+
+	firstPass := openFile(0)
+	assert.ElementsMatch(t, []string{"object_id", "updated_at", "amount", "count", "flagged"}, firstPass.columns())
+	numRows, err := firstPass.numRows(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), numRows)
+
+	for range 3 {
+		require.NoError(t, firstPass.next(context.Background()))
+	}
+	object, err := firstPass.parse(context.Background(), table)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"object_id":  "object_2",
+		"updated_at": time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		"amount":     float64(2),
+		"count":      2,
+		"flagged":    true,
+	}, object)
+	assert.Equal(t, "file row 2", firstPass.position())
+
+	resumed := openFile(firstPass.checkpoint())
+	assert.Equal(t, []string{"object_3", "object_4"}, readAllObjectIds(t, resumed, table))
+	assert.Equal(t, numRows, resumed.checkpoint())
+}
+
+func TestParseNativeValuesToMapFieldConstraints(t *testing.T) {
+	table := fileReadersTestTable()
+	minAmount := 0.0
+	amount := table.Fields["amount"]
+	amount.Constraints = &models.FieldConstraints{Min: &minAmount}
+	table.Fields["amount"] = amount
+	enricher := payload_parser.NewPayloadEnrichmentUsecase(nil, nil)
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []any{-1.5, "-1.5"} {
+		_, err := parseNativeValuesToMap(map[string]any{
+			"object_id":  "a",
+			"updated_at": updatedAt,
+			"amount":     value,
+		}, table, enricher)
+		assert.ErrorContains(t, err, "must be greater than or equal to 0")
+	}
+}
@@ -0,0 +1,550 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/usecases/worker_jobs"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+	"gocloud.dev/blob"
+)
+
+const (
+	INGESTION_SOURCE_POLL_INTERVAL = 5 * time.Minute
+
+	// Files beyond that are picked up by the next poll.
+	maxIngestionSourceFilesPerPoll = 100
+	ingestionSourceFilesListLimit  = 100
+)
+
+// errIngestionSourceFileTracked aborts the ingestion of a file whose version was tracked by a
+// concurrent poll in the meantime.
+var errIngestionSourceFileTracked = errors.New("ingestion source file already tracked")
+
+type ingestionSourceRepository interface {
+	CreateIngestionSource(ctx context.Context, exec repositories.Executor,
+		source models.IngestionSource) (models.IngestionSource, error)
+	GetIngestionSource(ctx context.Context, exec repositories.Executor, id uuid.UUID) (models.IngestionSource, error)
+	ListIngestionSources(ctx context.Context, exec repositories.Executor, orgId uuid.UUID) ([]models.IngestionSource, error)
+	UpdateIngestionSource(ctx context.Context, exec repositories.Executor,
+		input models.UpdateIngestionSourceInput) (models.IngestionSource, error)
+	DeleteIngestionSource(ctx context.Context, exec repositories.Executor, id uuid.UUID) error
+	RecordIngestionSourcePoll(ctx context.Context, exec repositories.Executor, id uuid.UUID, pollError *string) error
+	ListIngestionSourceFileVersions(ctx context.Context, exec repositories.Executor,
+		sourceId uuid.UUID) (map[string][]string, error)
+	CreateIngestionSourceFile(ctx context.Context, exec repositories.Executor, file models.IngestionSourceFile) (bool, error)
+	ListIngestionSourceFiles(ctx context.Context, exec repositories.Executor, sourceId uuid.UUID,
+		limit int) ([]models.IngestionSourceFile, error)
+}
+
+type ingestionSourceTaskEnqueuer interface {
+	EnqueueCsvIngestionTask(
+		ctx context.Context,
+		tx repositories.Transaction,
+		organizationId uuid.UUID,
+		uploadLogId uuid.UUID,
+		ingestionOptions models.IngestionOptions,
+	) error
+}
+
+// IngestionSourceUsecase manages the blob storage prefixes an organization ingests files from, and
+// polls them from a periodic job.
+type IngestionSourceUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityOrganization
+
+	dataModelRepository repositories.DataModelRepository
+	sourceRepository    ingestionSourceRepository
+	uploadLogRepository repositories.UploadLogRepository
+	taskEnqueuer        ingestionSourceTaskEnqueuer
+	blobRepository      repositories.BlobRepository
+
+	ingestionBucketUrl string
+	// Sources may only read from these buckets, under the directory of their organization
+	allowedBucketUrls []string
+	// The buckets Marble stores its own files in, which sources may never read from
+	internalBucketUrls []string
+}
+
+func NewIngestionSourceUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityOrganization,
+	dataModelRepository repositories.DataModelRepository,
+	sourceRepository ingestionSourceRepository,
+	uploadLogRepository repositories.UploadLogRepository,
+	taskEnqueuer ingestionSourceTaskEnqueuer,
+	blobRepository repositories.BlobRepository,
+	ingestionBucketUrl string,
+	allowedBucketUrls string,
+	internalBucketUrls []string,
+) IngestionSourceUsecase {
+	allowed := make([]string, 0)
+	for _, bucketUrl := range strings.Split(allowedBucketUrls, ",") {
+		if bucketUrl = strings.TrimSpace(bucketUrl); bucketUrl != "" {
+			allowed = append(allowed, bucketUrl)
+		}
+	}
+	internal := make([]string, 0, len(internalBucketUrls)+1)
+	for _, bucketUrl := range append([]string{ingestionBucketUrl}, internalBucketUrls...) {
+		if bucketUrl != "" {
+			internal = append(internal, bucketUrl)
+		}
+	}
+
+	return IngestionSourceUsecase{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		enforceSecurity:     enforceSecurity,
+		dataModelRepository: dataModelRepository,
+		sourceRepository:    sourceRepository,
+		uploadLogRepository: uploadLogRepository,
+		taskEnqueuer:        taskEnqueuer,
+		blobRepository:      blobRepository,
+		ingestionBucketUrl:  ingestionBucketUrl,
+		allowedBucketUrls:   allowed,
+		internalBucketUrls:  internal,
+	}
+}
+
+func (uc IngestionSourceUsecase) CreateIngestionSource(
+	ctx context.Context,
+	input models.CreateIngestionSourceInput,
+) (models.IngestionSource, error) {
+	if err := uc.enforceSecurity.WriteDataModel(input.OrganizationId); err != nil {
+		return models.IngestionSource{}, err
+	}
+	configuredBy, err := uc.configuringUser()
+	if err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	source := models.IngestionSource{
+		Id:             pure_utils.NewId(),
+		OrganizationId: input.OrganizationId,
+		Name:           input.Name,
+		BucketUrl:      input.BucketUrl,
+		Prefix:         input.Prefix,
+		FileMappings:   input.FileMappings,
+		Enabled:        input.Enabled,
+		ConfiguredBy:   configuredBy,
+	}
+	exec := uc.executorFactory.NewExecutor()
+	if err := uc.validateIngestionSource(ctx, exec, source); err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	return uc.sourceRepository.CreateIngestionSource(ctx, exec, source)
+}
+
+func (uc IngestionSourceUsecase) ListIngestionSources(ctx context.Context, organizationId uuid.UUID) (
+	[]models.IngestionSource, error,
+) {
+	if err := uc.enforceSecurity.WriteDataModel(organizationId); err != nil {
+		return nil, err
+	}
+
+	return uc.sourceRepository.ListIngestionSources(ctx, uc.executorFactory.NewExecutor(), organizationId)
+}
+
+func (uc IngestionSourceUsecase) GetIngestionSource(ctx context.Context, sourceId uuid.UUID) (
+	models.IngestionSource, error,
+) {
+	source, err := uc.sourceRepository.GetIngestionSource(ctx, uc.executorFactory.NewExecutor(), sourceId)
+	if err != nil {
+		return models.IngestionSource{}, err
+	}
+	if err := uc.enforceSecurity.WriteDataModel(source.OrganizationId); err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	return source, nil
+}
+
+func (uc IngestionSourceUsecase) UpdateIngestionSource(
+	ctx context.Context,
+	input models.UpdateIngestionSourceInput,
+) (models.IngestionSource, error) {
+	source, err := uc.GetIngestionSource(ctx, input.Id)
+	if err != nil {
+		return models.IngestionSource{}, err
+	}
+	input.ConfiguredBy, err = uc.configuringUser()
+	if err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	source.Name = input.Name
+	source.BucketUrl = input.BucketUrl
+	source.Prefix = input.Prefix
+	source.FileMappings = input.FileMappings
+	source.Enabled = input.Enabled
+	source.ConfiguredBy = input.ConfiguredBy
+	exec := uc.executorFactory.NewExecutor()
+	if err := uc.validateIngestionSource(ctx, exec, source); err != nil {
+		return models.IngestionSource{}, err
+	}
+
+	return uc.sourceRepository.UpdateIngestionSource(ctx, exec, input)
+}
+
+func (uc IngestionSourceUsecase) DeleteIngestionSource(ctx context.Context, sourceId uuid.UUID) error {
+	if _, err := uc.GetIngestionSource(ctx, sourceId); err != nil {
+		return err
+	}
+
+	return uc.sourceRepository.DeleteIngestionSource(ctx, uc.executorFactory.NewExecutor(), sourceId)
+}
+
+// ListIngestionSourceFiles reports the latest files picked up from a source.
+func (uc IngestionSourceUsecase) ListIngestionSourceFiles(ctx context.Context, sourceId uuid.UUID) (
+	[]models.IngestionSourceFile, error,
+) {
+	if _, err := uc.GetIngestionSource(ctx, sourceId); err != nil {
+		return nil, err
+	}
+
+	return uc.sourceRepository.ListIngestionSourceFiles(ctx, uc.executorFactory.NewExecutor(),
+		sourceId, ingestionSourceFilesListLimit)
+}
+
+func (uc IngestionSourceUsecase) validateIngestionSource(
+	ctx context.Context,
+	exec repositories.Executor,
+	source models.IngestionSource,
+) error {
+	if err := source.Validate(); err != nil {
+		return err
+	}
+
+	if err := uc.checkBucketUrl(source.BucketUrl); err != nil {
+		return err
+	}
+
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, source.OrganizationId, false, true)
+	if err != nil {
+		return err
+	}
+	for _, mapping := range source.FileMappings {
+		if _, ok := dataModel.Tables[mapping.TableName]; !ok {
+			return errors.Wrapf(models.NotFoundError, "table %s not found in the data model", mapping.TableName)
+		}
+	}
+
+	return nil
+}
+
+// checkBucketUrl makes sure that a source reads from a bucket allowed on the instance, and not from
+// one of the buckets Marble stores its own files in. The url must be an allowed one as is: its query
+// parameters configure the driver, e.g. the endpoint requests are sent to with the credentials of
+// the instance.
+func (uc IngestionSourceUsecase) checkBucketUrl(bucketUrl string) error {
+	for _, internal := range uc.internalBucketUrls {
+		if models.BlobBucketWithin(bucketUrl, internal) || models.BlobBucketWithin(internal, bucketUrl) {
+			return errors.Wrap(models.BadParameterError,
+				"ingestion sources cannot read from the buckets Marble stores its files in")
+		}
+	}
+
+	if !slices.ContainsFunc(uc.allowedBucketUrls, func(allowed string) bool {
+		return models.SameBlobBucket(bucketUrl, allowed)
+	}) {
+		return errors.Wrapf(models.BadParameterError,
+			"bucket %s is not allowed for ingestion sources on this instance", bucketUrl)
+	}
+
+	return nil
+}
+
+// configuringUser returns the user configuring a source. Sources are configured by users and not by
+// API keys, so that the files picked up from them are uploaded on behalf of someone.
+func (uc IngestionSourceUsecase) configuringUser() (uuid.UUID, error) {
+	userId := uc.enforceSecurity.UserId()
+	if userId == nil {
+		return uuid.Nil, errors.Wrap(models.ForbiddenError, "ingestion sources must be configured by a user")
+	}
+	return uuid.Parse(*userId)
+}
+
+// PollIngestionSources picks up the new files of the enabled sources of an organization. A source
+// that cannot be polled is recorded as such and does not prevent the others from being polled.
+func (uc IngestionSourceUsecase) PollIngestionSources(ctx context.Context, organizationId uuid.UUID) error {
+	exec := uc.executorFactory.NewExecutor()
+
+	sources, err := uc.sourceRepository.ListIngestionSources(ctx, exec, organizationId)
+	if err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		if !source.Enabled {
+			continue
+		}
+
+		var pollError *string
+		if err := uc.PollIngestionSource(ctx, source); err != nil {
+			utils.LoggerFromContext(ctx).WarnContext(ctx, "could not poll ingestion source",
+				"org_id", organizationId,
+				"source_id", source.Id,
+				"error", err.Error())
+			pollError = utils.Ptr(err.Error())
+		}
+		if err := uc.sourceRepository.RecordIngestionSourcePoll(ctx, exec, source.Id, pollError); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type ingestionSourceCandidate struct {
+	key     string
+	modTime time.Time
+	mapping models.IngestionSourceFileMapping
+	format  models.IngestionFileFormat
+}
+
+// PollIngestionSource lists the files under the prefix of the source and ingests those it has not
+// picked up yet, oldest first. A file is picked up again if it is replaced, that is if its ETag
+// changes.
+func (uc IngestionSourceUsecase) PollIngestionSource(ctx context.Context, source models.IngestionSource) error {
+	exec := uc.executorFactory.NewExecutor()
+
+	// The allowed buckets may have changed since the source was configured
+	if err := uc.checkBucketUrl(source.BucketUrl); err != nil {
+		return err
+	}
+
+	bucket, err := uc.blobRepository.RawBucket(ctx, source.BucketUrl)
+	if err != nil {
+		return err
+	}
+
+	candidates := make([]ingestionSourceCandidate, 0)
+	iter := bucket.List(&blob.ListOptions{Prefix: source.BucketPrefix()})
+	for {
+		obj, err := iter.Next(ctx)
+		if err == io.EOF { //nolint:errorlint
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "error listing the files of the ingestion source")
+		}
+		if obj.IsDir {
+			continue
+		}
+		mapping, format, ok := source.MatchFile(obj.Key)
+		if !ok {
+			continue
+		}
+		candidates = append(candidates, ingestionSourceCandidate{
+			key:     obj.Key,
+			modTime: obj.ModTime,
+			mapping: mapping,
+			format:  format,
+		})
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(candidates, func(a, b ingestionSourceCandidate) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	versions, err := uc.sourceRepository.ListIngestionSourceFileVersions(ctx, exec, source.Id)
+	if err != nil {
+		return err
+	}
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, source.OrganizationId, false, true)
+	if err != nil {
+		return err
+	}
+
+	picked := 0
+	for _, candidate := range candidates {
+		if picked >= maxIngestionSourceFilesPerPoll {
+			break
+		}
+
+		attrs, err := bucket.Attributes(ctx, candidate.key)
+		if err != nil {
+			return errors.Wrapf(err, "error reading the attributes of file %s", candidate.key)
+		}
+		if slices.Contains(versions[candidate.key], attrs.ETag) {
+			continue
+		}
+
+		picked++
+		file := models.IngestionSourceFile{
+			Id:             pure_utils.NewId(),
+			OrganizationId: source.OrganizationId,
+			SourceId:       source.Id,
+			Key:            candidate.key,
+			ETag:           attrs.ETag,
+			Size:           attrs.Size,
+			TableName:      candidate.mapping.TableName,
+		}
+
+		if rejection := ingestionSourceFileRejection(dataModel, candidate, attrs.Size); rejection != "" {
+			file.Error = &rejection
+			if _, err := uc.sourceRepository.CreateIngestionSourceFile(ctx, exec, file); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := uc.ingestIngestionSourceFile(ctx, bucket, source, file, candidate.format); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ingestionSourceFileRejection returns why a file cannot be ingested, or an empty string if it can.
+func ingestionSourceFileRejection(
+	dataModel models.DataModel,
+	candidate ingestionSourceCandidate,
+	size int64,
+) string {
+	if _, ok := dataModel.Tables[candidate.mapping.TableName]; !ok {
+		return fmt.Sprintf("table %s not found in the data model", candidate.mapping.TableName)
+	}
+	if candidate.format == "" {
+		return "the file format could not be inferred from its extension"
+	}
+	if size > worker_jobs.ASYNC_UPLOAD_MAX_SIZE {
+		return fmt.Sprintf("maximum allowed file size is 10GB, file was %d bytes", size)
+	}
+	return ""
+}
+
+// ingestIngestionSourceFile copies the file into the ingestion bucket, then creates its upload log
+// and enqueues its ingestion, as for a file uploaded through the API by the user who configured the
+// source.
+func (uc IngestionSourceUsecase) ingestIngestionSourceFile(
+	ctx context.Context,
+	bucket *blob.Bucket,
+	source models.IngestionSource,
+	file models.IngestionSourceFile,
+	format models.IngestionFileFormat,
+) error {
+	key := fmt.Sprintf("ingestion-sources/%s/%s/%s", file.OrganizationId, file.SourceId, file.Id)
+	if err := uc.copyToIngestionBucket(ctx, bucket, file.Key, key, format); err != nil {
+		return err
+	}
+
+	uploadLogId := pure_utils.NewId()
+	file.UploadLogId = &uploadLogId
+
+	err := uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		if err := uc.uploadLogRepository.CreateUploadLog(ctx, tx, models.UploadLog{
+			Id:             uploadLogId,
+			UploadStatus:   models.UploadPending,
+			OrganizationId: file.OrganizationId,
+			FileName:       key,
+			TableName:      file.TableName,
+			FileFormat:     format,
+			UserId:         source.ConfiguredBy.String(),
+			StartedAt:      time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		created, err := uc.sourceRepository.CreateIngestionSourceFile(ctx, tx, file)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return errIngestionSourceFileTracked
+		}
+
+		return uc.taskEnqueuer.EnqueueCsvIngestionTask(ctx, tx, file.OrganizationId,
+			uploadLogId, models.IngestionOptions{})
+	})
+	if err != nil {
+		if deleteErr := uc.blobRepository.DeleteFile(ctx, uc.ingestionBucketUrl, key); deleteErr != nil {
+			utils.LogAndReportSentryError(ctx, deleteErr)
+		}
+		if errors.Is(err, errIngestionSourceFileTracked) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (uc IngestionSourceUsecase) copyToIngestionBucket(
+	ctx context.Context,
+	bucket *blob.Bucket,
+	sourceKey, key string,
+	format models.IngestionFileFormat,
+) error {
+	reader, err := bucket.NewReader(ctx, sourceKey, nil)
+	if err != nil {
+		return errors.Wrapf(err, "error opening file %s", sourceKey)
+	}
+	defer reader.Close()
+
+	writer, err := uc.blobRepository.OpenStreamWithOptions(ctx, uc.ingestionBucketUrl, key,
+		&blob.WriterOptions{ContentType: format.ContentType()})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, reader); err != nil {
+		_ = writer.Close()
+		return errors.Wrapf(err, "error copying file %s to the ingestion bucket", sourceKey)
+	}
+
+	return writer.Close()
+}
+
+func NewIngestionSourcePollPeriodicJob(orgId uuid.UUID) *river.PeriodicJob {
+	return worker_jobs.NewPeriodicJob(
+		river.PeriodicInterval(INGESTION_SOURCE_POLL_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.IngestionSourcePollArgs{
+					OrgId: orgId,
+				}, &river.InsertOpts{
+					Queue: orgId.String(),
+					UniqueOpts: river.UniqueOpts{
+						ByQueue:  true,
+						ByPeriod: INGESTION_SOURCE_POLL_INTERVAL,
+					},
+				}
+		},
+	)
+}
+
+type IngestionSourcePollWorker struct {
+	river.WorkerDefaults[models.IngestionSourcePollArgs]
+	usecase IngestionSourceUsecase
+}
+
+func NewIngestionSourcePollWorker(usecase IngestionSourceUsecase) *IngestionSourcePollWorker {
+	return &IngestionSourcePollWorker{usecase: usecase}
+}
+
+func (w *IngestionSourcePollWorker) Timeout(job *river.Job[models.IngestionSourcePollArgs]) time.Duration {
+	return 30 * time.Minute
+}
+
+func (w *IngestionSourcePollWorker) Work(ctx context.Context, job *river.Job[models.IngestionSourcePollArgs]) error {
+	return w.usecase.PollIngestionSources(ctx, job.Args.OrgId)
+}
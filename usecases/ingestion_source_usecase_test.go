@@ -0,0 +1,248 @@
+package usecases
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/checkmarble/marble-backend/infra"
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type ingestionSourceTestEnv struct {
+	uc                  IngestionSourceUsecase
+	enforceSecurity     *mocks.EnforceSecurity
+	sourceRepository    *mocks.IngestionSourceRepository
+	uploadLogRepository *mocks.UploadLogRepository
+	taskQueueRepository *mocks.TaskQueueRepository
+	blobRepository      repositories.BlobRepository
+	source              models.IngestionSource
+	sourceDir           string
+	ingestionBucketUrl  string
+}
+
+func newIngestionSourceTestEnv(t *testing.T) ingestionSourceTestEnv {
+	t.Helper()
+
+	executorFactory := executor_factory.NewExecutorFactoryStub()
+	dataModelRepository := new(mocks.DataModelRepository)
+	dataModelRepository.On("GetDataModel", mock.Anything, mock.Anything, mock.Anything, false, true).
+		Return(models.DataModel{Tables: map[string]models.Table{"accounts": {Name: "accounts"}}}, nil)
+
+	env := ingestionSourceTestEnv{
+		enforceSecurity:     new(mocks.EnforceSecurity),
+		sourceRepository:    new(mocks.IngestionSourceRepository),
+		uploadLogRepository: new(mocks.UploadLogRepository),
+		taskQueueRepository: new(mocks.TaskQueueRepository),
+		blobRepository:      repositories.NewBlobRepository(infra.GcpConfig{}),
+		sourceDir:           t.TempDir(),
+		ingestionBucketUrl:  "file://" + t.TempDir(),
+	}
+	env.source = models.IngestionSource{
+		Id:             uuid.New(),
+		OrganizationId: uuid.New(),
+		Name:           "core banking",
+		BucketUrl:      "file://" + env.sourceDir,
+		Prefix:         "extracts/",
+		FileMappings: []models.IngestionSourceFileMapping{
+			{Pattern: "accounts_*.csv", TableName: "accounts"},
+			{Pattern: "unknown_*", TableName: "accounts"},
+		},
+		Enabled:      true,
+		ConfiguredBy: uuid.New(),
+	}
+	env.uc = NewIngestionSourceUsecase(
+		executorFactory,
+		executor_factory.NewTransactionFactoryStub(executorFactory),
+		env.enforceSecurity,
+		dataModelRepository,
+		env.sourceRepository,
+		env.uploadLogRepository,
+		env.taskQueueRepository,
+		env.blobRepository,
+		env.ingestionBucketUrl,
+		"s3://other-extracts, file://"+env.sourceDir,
+		[]string{"s3://marble-offloading"},
+	)
+	return env
+}
+
+// writeFile writes a file under the directory of the organization in the bucket of the source
+func (env ingestionSourceTestEnv) writeFile(t *testing.T, key, content string) {
+	t.Helper()
+	path := filepath.Join(env.sourceDir, env.source.OrganizationId.String(), filepath.FromSlash(key))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func ingestionSourceTestContext() context.Context {
+	return utils.StoreLoggerInContext(context.Background(), utils.NewLogger("text"))
+}
+
+func TestPollIngestionSource_ingests_new_matching_files(t *testing.T) {
+	ctx := ingestionSourceTestContext()
+	env := newIngestionSourceTestEnv(t)
+
+	content := "object_id,updated_at\n1,2026-10-19T00:00:00Z\n"
+	env.writeFile(t, "extracts/accounts_20261019.csv", content)
+	env.writeFile(t, "extracts/transactions_20261019.csv", "ignored")
+	env.writeFile(t, "elsewhere/accounts_20261019.csv", "ignored")
+	otherOrg := filepath.Join(env.sourceDir, uuid.NewString(), "extracts")
+	require.NoError(t, os.MkdirAll(otherOrg, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(otherOrg, "accounts_20261019.csv"), []byte("ignored"), 0o644))
+
+	env.sourceRepository.On("ListIngestionSourceFileVersions", mock.Anything, mock.Anything, env.source.Id).
+		Return(map[string][]string{}, nil)
+
+	var uploadLog models.UploadLog
+	env.uploadLogRepository.On("CreateUploadLog", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { uploadLog = args.Get(2).(models.UploadLog) }).
+		Return(nil)
+
+	var trackedFile models.IngestionSourceFile
+	env.sourceRepository.On("CreateIngestionSourceFile", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { trackedFile = args.Get(2).(models.IngestionSourceFile) }).
+		Return(true, nil)
+	env.taskQueueRepository.On("EnqueueCsvIngestionTask", mock.Anything, mock.Anything,
+		env.source.OrganizationId, mock.Anything, models.IngestionOptions{}).
+		Return(nil)
+
+	require.NoError(t, env.uc.PollIngestionSource(ctx, env.source))
+
+	env.sourceRepository.AssertNumberOfCalls(t, "CreateIngestionSourceFile", 1)
+	env.taskQueueRepository.AssertNumberOfCalls(t, "EnqueueCsvIngestionTask", 1)
+
+	assert.Equal(t, env.source.OrganizationId.String()+"/extracts/accounts_20261019.csv", trackedFile.Key)
+	assert.NotEmpty(t, trackedFile.ETag)
+	assert.Equal(t, "accounts", trackedFile.TableName)
+	require.NotNil(t, trackedFile.UploadLogId)
+	assert.Equal(t, uploadLog.Id, *trackedFile.UploadLogId)
+
+	assert.Equal(t, "accounts", uploadLog.TableName)
+	assert.Equal(t, env.source.ConfiguredBy.String(), uploadLog.UserId)
+	assert.Equal(t, models.IngestionFileFormatCsv, uploadLog.FileFormat)
+	copied, err := env.blobRepository.GetBlob(ctx, env.ingestionBucketUrl, uploadLog.FileName)
+	require.NoError(t, err)
+	defer copied.ReadCloser.Close()
+	buf := make([]byte, len(content)+1)
+	n, _ := copied.ReadCloser.Read(buf)
+	assert.Equal(t, content, string(buf[:n]))
+}
+
+func TestPollIngestionSource_skips_tracked_versions(t *testing.T) {
+	ctx := ingestionSourceTestContext()
+	env := newIngestionSourceTestEnv(t)
+
+	env.writeFile(t, "extracts/accounts_20261019.csv", "object_id,updated_at\n")
+	key := env.source.BucketPrefix() + "accounts_20261019.csv"
+	bucket, err := env.blobRepository.RawBucket(ctx, env.source.BucketUrl)
+	require.NoError(t, err)
+	attrs, err := bucket.Attributes(ctx, key)
+	require.NoError(t, err)
+
+	env.sourceRepository.On("ListIngestionSourceFileVersions", mock.Anything, mock.Anything, env.source.Id).
+		Return(map[string][]string{key: {attrs.ETag}}, nil)
+
+	require.NoError(t, env.uc.PollIngestionSource(ctx, env.source))
+
+	env.sourceRepository.AssertNotCalled(t, "CreateIngestionSourceFile", mock.Anything, mock.Anything, mock.Anything)
+	env.uploadLogRepository.AssertNotCalled(t, "CreateUploadLog", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPollIngestionSource_tracks_files_it_cannot_ingest(t *testing.T) {
+	ctx := ingestionSourceTestContext()
+	env := newIngestionSourceTestEnv(t)
+
+	env.writeFile(t, "extracts/unknown_20261019.bin", "???")
+
+	env.sourceRepository.On("ListIngestionSourceFileVersions", mock.Anything, mock.Anything, env.source.Id).
+		Return(map[string][]string{}, nil)
+	env.sourceRepository.On("CreateIngestionSourceFile", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(file models.IngestionSourceFile) bool {
+			return file.Error != nil && file.UploadLogId == nil
+		})).
+		Return(true, nil)
+
+	require.NoError(t, env.uc.PollIngestionSource(ctx, env.source))
+
+	env.sourceRepository.AssertExpectations(t)
+	env.uploadLogRepository.AssertNotCalled(t, "CreateUploadLog", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPollIngestionSource_rejects_buckets_no_longer_allowed(t *testing.T) {
+	ctx := ingestionSourceTestContext()
+	env := newIngestionSourceTestEnv(t)
+	env.uc.allowedBucketUrls = []string{"s3://other-extracts"}
+
+	env.writeFile(t, "extracts/accounts_20261019.csv", "object_id,updated_at\n")
+
+	assert.ErrorIs(t, env.uc.PollIngestionSource(ctx, env.source), models.BadParameterError)
+	env.uploadLogRepository.AssertNotCalled(t, "CreateUploadLog", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateIngestionSource_checks_the_bucket(t *testing.T) {
+	ctx := ingestionSourceTestContext()
+	env := newIngestionSourceTestEnv(t)
+	userId := uuid.New()
+	env.enforceSecurity.On("WriteDataModel", env.source.OrganizationId).Return(nil)
+	env.enforceSecurity.On("UserId").Return(utils.Ptr(userId.String()))
+
+	input := models.CreateIngestionSourceInput{
+		OrganizationId: env.source.OrganizationId,
+		Name:           env.source.Name,
+		Prefix:         env.source.Prefix,
+		FileMappings:   env.source.FileMappings,
+		Enabled:        true,
+	}
+
+	rejected := map[string]string{
+		"not allowed":      "s3://extracts",
+		"ingestion bucket": env.ingestionBucketUrl,
+		"internal bucket":  "s3://marble-offloading?region=eu-west-3",
+		"parent directory": "file://" + filepath.Dir(env.sourceDir),
+		"sub directory":    "file://" + env.sourceDir + "/" + uuid.NewString(),
+		"other endpoint":   "s3://other-extracts?endpoint=https://attacker.example",
+		"other region":     "s3://other-extracts?region=eu-west-3",
+	}
+	for name, bucketUrl := range rejected {
+		t.Run(name, func(t *testing.T) {
+			input.BucketUrl = bucketUrl
+			_, err := env.uc.CreateIngestionSource(ctx, input)
+			assert.ErrorIs(t, err, models.BadParameterError)
+		})
+	}
+
+	input.BucketUrl = "s3://other-extracts"
+	env.sourceRepository.On("CreateIngestionSource", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(source models.IngestionSource) bool {
+			return source.ConfiguredBy == userId
+		})).
+		Return(models.IngestionSource{}, nil)
+	_, err := env.uc.CreateIngestionSource(ctx, input)
+	assert.NoError(t, err)
+	env.sourceRepository.AssertExpectations(t)
+}
+
+func TestCreateIngestionSource_requires_a_user(t *testing.T) {
+	ctx := ingestionSourceTestContext()
+	env := newIngestionSourceTestEnv(t)
+	env.enforceSecurity.On("WriteDataModel", env.source.OrganizationId).Return(nil)
+	env.enforceSecurity.On("UserId").Return((*string)(nil))
+
+	_, err := env.uc.CreateIngestionSource(ctx, models.CreateIngestionSourceInput{
+		OrganizationId: env.source.OrganizationId,
+		Name:           env.source.Name,
+		BucketUrl:      "s3://other-extracts",
+		FileMappings:   env.source.FileMappings,
+	})
+	assert.ErrorIs(t, err, models.ForbiddenError)
+}
@@ -22,7 +22,8 @@ func (usecase *IngestionUseCase) GenerateUploadLink(
 	ingestionOptions models.IngestionOptions,
 	headers http.Header,
 ) (string, error) {
-	if err := usecase.validateAsyncIngestionHeaders(headers); err != nil {
+	fileFormat, err := usecase.validateAsyncIngestionHeaders(headers)
+	if err != nil {
 		return "", err
 	}
 
@@ -65,37 +66,43 @@ func (usecase *IngestionUseCase) GenerateUploadLink(
 	}
 
 	return executor_factory.TransactionReturnValue(ctx, usecase.transactionFactory, func(tx repositories.Transaction) (string, error) {
-		if err := usecase.taskEnqueuer.EnqueueAsyncUploadTask(ctx, tx, orgId, recordType, key, ingestionOptions); err != nil {
+		if err := usecase.taskEnqueuer.EnqueueAsyncUploadTask(ctx, tx, orgId, recordType, key,
+			fileFormat, ingestionOptions); err != nil {
 			return "", err
 		}
 
 		hostOverride := infra.GetLocalCdnDomain()
 
-		return usecase.blobRepository.GenerateWriteSignedUrl(ctx, usecase.ingestionBucketUrl, key, worker_jobs.ASYNC_UPLOAD_START_TIMEOUT, hostOverride)
+		return usecase.blobRepository.GenerateWriteSignedUrl(ctx, usecase.ingestionBucketUrl, key,
+			fileFormat.ContentType(), worker_jobs.ASYNC_UPLOAD_START_TIMEOUT, hostOverride)
 	})
 }
 
-func (usecase *IngestionUseCase) validateAsyncIngestionHeaders(headers http.Header) error {
-	if headers.Get("content-type") != "text/csv" {
-		return errors.WithDetail(models.BadParameterError, "content-type header must be `text/csv`")
+// validateAsyncIngestionHeaders checks the headers the file will be uploaded with, and returns the
+// format of the file given by its content type.
+func (usecase *IngestionUseCase) validateAsyncIngestionHeaders(headers http.Header) (models.IngestionFileFormat, error) {
+	fileFormat, ok := models.IngestionFileFormatFromContentType(headers.Get("content-type"))
+	if !ok {
+		return "", errors.WithDetail(models.BadParameterError,
+			"content-type header must be one of `text/csv`, `application/x-ndjson` or `application/vnd.apache.parquet`")
 	}
 
 	switch {
 	case strings.HasPrefix(usecase.ingestionBucketUrl, "gs://"):
 		if headers.Get("x-goog-if-generation-match") != "0" {
-			return errors.WithDetail(models.BadParameterError, "x-goog-if-generation-match header must be set to `0`")
+			return "", errors.WithDetail(models.BadParameterError, "x-goog-if-generation-match header must be set to `0`")
 		}
 
 	case strings.HasPrefix(usecase.ingestionBucketUrl, "s3://"):
 		if headers.Get("if-none-match") != "*" {
-			return errors.WithDetail(models.BadParameterError, "if-none-match header must be set to `*`")
+			return "", errors.WithDetail(models.BadParameterError, "if-none-match header must be set to `*`")
 		}
 
 	case strings.HasPrefix(usecase.ingestionBucketUrl, "azblob://"):
 		if headers.Get("x-ms-blob-type") != "BlockBlob" {
-			return errors.WithDetail(models.BadParameterError, "x-ms-blob-type header must be set to `BlockBlob`")
+			return "", errors.WithDetail(models.BadParameterError, "x-ms-blob-type header must be set to `BlockBlob`")
 		}
 	}
 
-	return nil
+	return fileFormat, nil
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval"
 	"github.com/checkmarble/marble-backend/usecases/executor_factory"
 	"github.com/checkmarble/marble-backend/usecases/payload_parser"
 	"github.com/checkmarble/marble-backend/usecases/security"
@@ -122,10 +123,21 @@ type taskEnqueuer interface {
 		organizationId uuid.UUID,
 		objectType string,
 		key string,
+		fileFormat models.IngestionFileFormat,
 		ingestionOptions models.IngestionOptions,
 	) error
 }
 
+type ingestionScenarioRepository interface {
+	ListScenariosEvaluatingOnIngest(ctx context.Context, exec repositories.Executor,
+		organizationId uuid.UUID, objectType string) ([]models.Scenario, error)
+}
+
+type ingestionAsyncDecisionCreator interface {
+	CreateIngestionAsyncDecisionExecutions(ctx context.Context, orgId uuid.UUID,
+		inputs []models.AsyncDecisionExecutionCreate) error
+}
+
 type scoreComputationUsecase interface {
 	EnqueueComputationForIngestion(ctx context.Context, orgId uuid.UUID, recordType string, records models.IngestionResults) error
 }
@@ -139,12 +151,16 @@ type IngestionUseCase struct {
 	blobRepository                      repositories.BlobRepository
 	dataModelRepository                 repositories.DataModelRepository
 	uploadLogRepository                 repositories.UploadLogRepository
+	deadLetterRepository                ingestionDeadLetterRepository
 	payloadEnricher                     payload_parser.PayloadEnrichementUsecase
 	continuousScreeningRepository       continuousScreeningRepository
 	continuousScreeningClientRepository continuousScreeningClientDbRepository
+	scenarioRepository                  ingestionScenarioRepository
+	asyncDecisionCreator                ingestionAsyncDecisionCreator
 	ingestionBucketUrl                  string
 	batchIngestionMaxSize               int
 	taskEnqueuer                        taskEnqueuer
+	evaluateAstExpression               ast_eval.EvaluateAstExpression
 	isManagedMarble                     bool
 }
 
@@ -205,9 +221,12 @@ func (usecase *IngestionUseCase) IngestObject(
 	}
 
 	parser := payload_parser.NewParser(append(parserOpts, payload_parser.WithColumnEscape(),
-		payload_parser.WithEnricher(usecase.payloadEnricher))...)
+		payload_parser.WithoutDerivedFields(), payload_parser.WithEnricher(usecase.payloadEnricher))...)
 	payload, err := parser.ParsePayload(ctx, table, objectBody)
 	if err != nil {
+		usecase.recordDeadLetters(ctx, []models.IngestionDeadLetter{
+			apiDeadLetter(organizationId, table, parser, objectBody, err, ingestionOptions),
+		})
 		return 0, errors.WithDetail(err, "error parsing payload in decision usecase validate payload")
 	}
 
@@ -220,6 +239,9 @@ func (usecase *IngestionUseCase) IngestObject(
 	if err != nil {
 		var validationErrors models.IngestionValidationErrors
 		if errors.As(err, &validationErrors) {
+			usecase.recordDeadLetters(ctx, []models.IngestionDeadLetter{
+				apiDeadLetter(organizationId, table, parser, objectBody, validationErrors, ingestionOptions),
+			})
 			// if err is not nil, the call to the repository may return a models.IngestionValidationErrorsMultiple
 			// instance error, in which case it should have just one entry (with the input object_id as key)
 			// return 0, models.IngestionValidationErrorsSingle(
@@ -306,16 +328,23 @@ func (usecase *IngestionUseCase) IngestObjects(
 	clientObjects := make([]models.ClientObject, 0, len(rawMessages))
 	objectIds := make(map[string]struct{}, len(rawMessages))
 	parser := payload_parser.NewParser(append(parserOpts, payload_parser.WithColumnEscape(),
-		payload_parser.WithEnricher(usecase.payloadEnricher))...)
+		payload_parser.WithoutDerivedFields(), payload_parser.WithEnricher(usecase.payloadEnricher))...)
 	validationErrorsGroup := make(models.IngestionValidationErrors)
+	deadLetter := func(rawMsg json.RawMessage, rejection error) models.IngestionDeadLetter {
+		return apiDeadLetter(organizationId, table, parser, rawMsg, rejection, ingestionOptions)
+	}
+	var deadLetters []models.IngestionDeadLetter
+	rawMessagesByObjectId := make(map[string]json.RawMessage, len(rawMessages))
 	for _, rawMsg := range rawMessages {
 		payload, err := parser.ParsePayload(ctx, table, rawMsg)
 		var validationErrors models.IngestionValidationErrors
 		if errors.As(err, &validationErrors) {
 			objectId, errMap := validationErrors.GetSomeItem()
 			validationErrorsGroup[objectId] = errMap
+			deadLetters = append(deadLetters, deadLetter(rawMsg, validationErrors))
 			continue
 		} else if err != nil {
+			usecase.recordDeadLetters(ctx, append(deadLetters, deadLetter(rawMsg, err)))
 			return 0, errors.WithDetailf(
 				models.BadParameterError,
 				"Error while validating payload in IngestObjects: %v", err,
@@ -327,9 +356,11 @@ func (usecase *IngestionUseCase) IngestObjects(
 				"duplicate object_id %s in the batch", objectId)
 		}
 		objectIds[objectId] = struct{}{}
+		rawMessagesByObjectId[objectId] = rawMsg
 		clientObjects = append(clientObjects, payload)
 	}
 	if len(validationErrorsGroup) > 0 {
+		usecase.recordDeadLetters(ctx, deadLetters)
 		return 0, validationErrorsGroup
 	}
 
@@ -340,6 +371,15 @@ func (usecase *IngestionUseCase) IngestObjects(
 		return err
 	})
 	if err != nil {
+		var validationErrors models.IngestionValidationErrors
+		if errors.As(err, &validationErrors) {
+			for objectId, errMap := range validationErrors {
+				if rawMsg, ok := rawMessagesByObjectId[objectId]; ok {
+					deadLetters = append(deadLetters, deadLetter(rawMsg, errMap))
+				}
+			}
+			usecase.recordDeadLetters(ctx, deadLetters)
+		}
 		return 0, err
 	}
 	nbInsertedObjects := len(ingestionResults)
@@ -353,6 +393,111 @@ func (usecase *IngestionUseCase) IngestObjects(
 	return nbInsertedObjects, nil
 }
 
+// fieldsEvaluatedOnIngest lists the fields of the table whose changes trigger a decision of one of
+// the scenarios, so that the ingestion compares them with the previous version of the objects.
+func fieldsEvaluatedOnIngest(scenarios []models.Scenario, table models.Table) []string {
+	var fields []string
+	for _, scenario := range scenarios {
+		for _, field := range scenario.EvaluateOnIngestFields {
+			if _, ok := table.Fields[field]; ok && !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields
+}
+
+// createDecisionsOnIngest enqueues an async decision execution for every ingested object version that
+// one of the scenarios evaluates on ingestion. It runs once the ingestion is committed, so that the
+// decisions read the objects they are triggered by. The executions are deduplicated on the scenario
+// and the row of the object version, so that retrying the enqueuing does not create another decision.
+func (usecase *IngestionUseCase) createDecisionsOnIngest(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	table models.Table,
+	scenarios []models.Scenario,
+	clientObjects []models.ClientObject,
+	ingestionResults models.IngestionResults,
+) error {
+	if len(scenarios) == 0 {
+		return nil
+	}
+
+	var inputs []models.AsyncDecisionExecutionCreate
+	for _, object := range mostRecentObjectVersions(clientObjects) {
+		result, ok := ingestionResults[object.Data["object_id"].(string)]
+		if !ok {
+			// A more recent version of the object was already ingested
+			continue
+		}
+
+		var triggerObject json.RawMessage
+		for _, scenario := range scenarios {
+			if !scenario.EvaluatesIngestedObject(result) {
+				continue
+			}
+			if triggerObject == nil {
+				var err error
+				triggerObject, err = ingestedTriggerObject(table, object)
+				if err != nil {
+					return err
+				}
+			}
+			inputs = append(inputs, models.AsyncDecisionExecutionCreate{
+				Id:            pure_utils.NewId(),
+				OrgId:         organizationId,
+				ObjectType:    table.Name,
+				TriggerObject: triggerObject,
+				ScenarioId:    utils.Ptr(scenario.Id),
+				DeduplicationKey: utils.Ptr(models.IngestionDecisionDeduplicationKey(
+					scenario.Id, result.NewInternalId)),
+			})
+		}
+	}
+
+	return retry.Do(
+		func() error {
+			return usecase.asyncDecisionCreator.CreateIngestionAsyncDecisionExecutions(ctx, organizationId, inputs)
+		},
+		retry.Attempts(3),
+		retry.LastErrorOnly(true),
+		retry.Delay(100*time.Millisecond),
+		retry.DelayType(retry.BackOffDelay),
+		retry.Context(ctx),
+	)
+}
+
+// mostRecentObjectVersions keeps the most recent version of every object of the batch, which is the
+// only version the ingestion writes.
+func mostRecentObjectVersions(clientObjects []models.ClientObject) []models.ClientObject {
+	idxByObjectId := make(map[string]int, len(clientObjects))
+	mostRecent := make([]models.ClientObject, 0, len(clientObjects))
+	for _, object := range clientObjects {
+		objectId, updatedAt := object.Data["object_id"].(string), object.Data["updated_at"].(time.Time)
+		idx, ok := idxByObjectId[objectId]
+		if !ok {
+			idxByObjectId[objectId] = len(mostRecent)
+			mostRecent = append(mostRecent, object)
+		} else if updatedAt.After(mostRecent[idx].Data["updated_at"].(time.Time)) {
+			mostRecent[idx] = object
+		}
+	}
+	return mostRecent
+}
+
+// ingestedTriggerObject returns the payload a decision evaluates for an ingested object version: the
+// fields as they were written, including those a partial update read from the previous version of the
+// object and the derived fields.
+func ingestedTriggerObject(table models.Table, object models.ClientObject) (json.RawMessage, error) {
+	payload := make(map[string]any, len(table.Fields))
+	for name := range table.Fields {
+		if value, ok := object.Data[name]; ok {
+			payload[name] = value
+		}
+	}
+	return json.Marshal(payload)
+}
+
 func (usecase *IngestionUseCase) ListUploadLogs(ctx context.Context,
 	organizationId uuid.UUID, objectType string,
 ) ([]models.UploadLog, error) {
@@ -437,7 +582,7 @@ func (usecase *IngestionUseCase) ValidateAndUploadIngestionCsv(ctx context.Conte
 			errors.Wrap(models.BadParameterError, err.Error()))
 	}
 
-	fileName := computeFileName(organizationId.String(), table.Name)
+	fileName := computeFileName(organizationId.String(), table.Name, models.IngestionFileFormatCsv)
 	writer, err := usecase.blobRepository.OpenStream(ctx, usecase.ingestionBucketUrl, fileName, fileName)
 	if err != nil {
 		return models.UploadLog{}, err
@@ -468,7 +613,11 @@ func (usecase *IngestionUseCase) ValidateAndUploadIngestionCsv(ctx context.Conte
 		return models.UploadLog{}, err
 	}
 
-	var processedLinesCount int
+	var (
+		processedLinesCount int
+		rejectedRowErr      error
+		deadLetters         []models.IngestionDeadLetter
+	)
 	for processedLinesCount = 0; ; processedLinesCount++ {
 		// line number starts at 1, and we already read the first line as headers
 		lineNumber := processedLinesCount + 2
@@ -477,6 +626,7 @@ func (usecase *IngestionUseCase) ValidateAndUploadIngestionCsv(ctx context.Conte
 			break
 		}
 		if err != nil {
+			usecase.recordDeadLetters(ctx, deadLetters)
 			var parseError *csv.ParseError
 			if errors.As(err, &parseError) {
 				return models.UploadLog{}, fmt.Errorf("%w (%w)", err, models.BadParameterError)
@@ -487,8 +637,21 @@ func (usecase *IngestionUseCase) ValidateAndUploadIngestionCsv(ctx context.Conte
 
 		_, err = parseStringValuesToMap(headers, row, table, usecase.payloadEnricher)
 		if err != nil {
-			return models.UploadLog{}, fmt.Errorf("error found at line %d in CSV: %w (%w)",
-				lineNumber, err, models.BadParameterError)
+			// The file is rejected on its first invalid row, but the following ones are still checked
+			// so that all the rejected rows are kept.
+			if rejectedRowErr == nil {
+				rejectedRowErr = fmt.Errorf("error found at line %d in CSV: %w (%w)",
+					lineNumber, err, models.BadParameterError)
+			}
+			if len(deadLetters) < maxDeadLettersPerUpload {
+				deadLetters = append(deadLetters, models.NewIngestionDeadLetter(organizationId, table.Name,
+					models.IngestionDeadLetterSourceFile, models.IngestionDeadLetterFormatCsv,
+					csvDeadLetterPayload(headers, row), err, ingestionOptions))
+			}
+			continue
+		}
+		if rejectedRowErr != nil {
+			continue
 		}
 
 		if err := csvWriter.WriteAll([][]string{row}); err != nil {
@@ -496,10 +659,132 @@ func (usecase *IngestionUseCase) ValidateAndUploadIngestionCsv(ctx context.Conte
 		}
 	}
 
+	if rejectedRowErr != nil {
+		usecase.recordDeadLetters(ctx, deadLetters)
+		return models.UploadLog{}, rejectedRowErr
+	}
+
 	if err := writer.Close(); err != nil {
 		return models.UploadLog{}, err
 	}
 
+	return usecase.createPendingUploadLog(ctx, organizationId, userId, objectType, fileName,
+		models.IngestionFileFormatCsv, processedLinesCount, ingestionOptions)
+}
+
+// ValidateAndUploadIngestionFile is the counterpart of ValidateAndUploadIngestionCsv for all the
+// formats of batch file ingestion. NDJSON and Parquet rows are parsed against the table as they are
+// stored, and the file is rejected if any of them is invalid.
+func (usecase *IngestionUseCase) ValidateAndUploadIngestionFile(ctx context.Context,
+	organizationId uuid.UUID, userId, objectType string, fileFormat models.IngestionFileFormat,
+	file io.Reader, ingestionOptions models.IngestionOptions,
+) (models.UploadLog, error) {
+	if fileFormat == models.IngestionFileFormatCsv {
+		return usecase.ValidateAndUploadIngestionCsv(ctx, organizationId, userId, objectType,
+			csv.NewReader(pure_utils.NewReaderWithoutBom(file)), ingestionOptions)
+	}
+
+	if err := usecase.enforceSecurity.CanIngest(organizationId); err != nil {
+		return models.UploadLog{}, err
+	}
+	dataModel, err := usecase.dataModelRepository.GetDataModel(
+		ctx,
+		usecase.executorFactory.NewExecutor(),
+		organizationId,
+		false,
+		true,
+	)
+	if err != nil {
+		return models.UploadLog{}, err
+	}
+
+	table, ok := dataModel.Tables[objectType]
+	if !ok {
+		return models.UploadLog{}, fmt.Errorf("table %s not found on data model", objectType)
+	}
+
+	fileName := computeFileName(organizationId.String(), table.Name, fileFormat)
+	writer, err := usecase.blobRepository.OpenStream(ctx, usecase.ingestionBucketUrl, fileName, fileName)
+	if err != nil {
+		return models.UploadLog{}, err
+	}
+	defer writer.Close()
+
+	formatLabel := ingestionFileFormatLabel(fileFormat)
+
+	// The file is stored as it is read, so that it does not have to be kept in memory.
+	var fileReader ingestionFileReader
+	switch fileFormat {
+	case models.IngestionFileFormatNdjson:
+		fileReader = newNdjsonFileReader(io.TeeReader(file, writer), 0, usecase.payloadEnricher)
+	case models.IngestionFileFormatParquet:
+		parquetReader, err := newParquetFileReader(ctx, io.TeeReader(file, writer), 0, usecase.payloadEnricher)
+		if err != nil {
+			return models.UploadLog{}, fmt.Errorf("error reading Parquet file: %w (%w)", err, models.BadParameterError)
+		}
+		defer parquetReader.Close()
+		fileReader = parquetReader
+	default:
+		return models.UploadLog{}, errors.WithDetailf(models.BadParameterError,
+			"unsupported file format %s", fileFormat)
+	}
+
+	if columns := fileReader.columns(); columns != nil {
+		for name, field := range table.Fields {
+			if !field.Nullable && !slices.Contains(columns, name) {
+				return models.UploadLog{}, fmt.Errorf("missing required field %s in %s (%w)",
+					name, formatLabel, models.BadParameterError)
+			}
+		}
+	}
+
+	var (
+		processedRowsCount int
+		rejectedRowErr     error
+		deadLetters        []models.IngestionDeadLetter
+	)
+	for processedRowsCount = 0; ; processedRowsCount++ {
+		err := fileReader.next(ctx)
+		if err == io.EOF { //nolint:errorlint
+			break
+		}
+		if err != nil {
+			usecase.recordDeadLetters(ctx, deadLetters)
+			return models.UploadLog{}, fmt.Errorf("error reading %s at %s: %w (%w)",
+				formatLabel, fileReader.position(), err, models.BadParameterError)
+		}
+
+		if _, err := fileReader.parse(ctx, table); err != nil {
+			if rejectedRowErr == nil {
+				rejectedRowErr = fmt.Errorf("error found at %s in %s: %w (%w)",
+					fileReader.position(), formatLabel, err, models.BadParameterError)
+			}
+			if len(deadLetters) < maxDeadLettersPerUpload {
+				payload, payloadFormat := fileReader.rawRow()
+				deadLetters = append(deadLetters, models.NewIngestionDeadLetter(organizationId, table.Name,
+					models.IngestionDeadLetterSourceFile, payloadFormat, payload, err, ingestionOptions))
+			}
+		}
+	}
+
+	if rejectedRowErr != nil {
+		usecase.recordDeadLetters(ctx, deadLetters)
+		return models.UploadLog{}, rejectedRowErr
+	}
+
+	if err := writer.Close(); err != nil {
+		return models.UploadLog{}, err
+	}
+
+	return usecase.createPendingUploadLog(ctx, organizationId, userId, objectType, fileName,
+		fileFormat, processedRowsCount, ingestionOptions)
+}
+
+// createPendingUploadLog records a validated file and enqueues its ingestion.
+func (usecase *IngestionUseCase) createPendingUploadLog(ctx context.Context, organizationId uuid.UUID,
+	userId, objectType, fileName string, fileFormat models.IngestionFileFormat, linesProcessed int,
+	ingestionOptions models.IngestionOptions,
+) (models.UploadLog, error) {
 	return executor_factory.TransactionReturnValue(ctx,
 		usecase.transactionFactory, func(tx repositories.Transaction) (models.UploadLog, error) {
 			newUploadListId := pure_utils.NewId()
@@ -509,9 +794,10 @@ func (usecase *IngestionUseCase) ValidateAndUploadIngestionCsv(ctx context.Conte
 				OrganizationId: organizationId,
 				FileName:       fileName,
 				TableName:      objectType,
+				FileFormat:     fileFormat,
 				UserId:         userId,
 				StartedAt:      time.Now(),
-				LinesProcessed: processedLinesCount,
+				LinesProcessed: linesProcessed,
 			}
 			if err := usecase.uploadLogRepository.CreateUploadLog(ctx, tx, newUploadLoad); err != nil {
 				return models.UploadLog{}, err
@@ -642,41 +928,13 @@ func (usecase *IngestionUseCase) processUploadLog(ctx context.Context, uploadLog
 		return models.CsvIngestionCompleted, err
 	}
 
-	// The header is read from its own reader at the start of the file, so that the data reader can
-	// always be opened at an explicit offset and never has to deal with the header row nor with a
-	// leading BOM, whether this is a first attempt or a resume.
-	header, dataStart, err := usecase.readCsvHeader(ctx, uploadLog.FileName)
+	fileReader, fileSize, closeFile, err := usecase.openUploadedFile(ctx, uploadLog)
 	if err != nil {
 		return failAttempt(uploadLog.RowsIngested, nil, err)
 	}
+	defer closeFile()
 
-	startOffset := max(uploadLog.ByteOffset, dataStart)
-
-	attrs, err := usecase.blobRepository.GetBlobAttributes(ctx, usecase.ingestionBucketUrl, uploadLog.FileName)
-	if err != nil {
-		return failAttempt(uploadLog.RowsIngested, nil, err)
-	}
-
-	// A previous attempt may have checkpointed exactly at EOF and died before marking the log
-	// successful, and a header-only file has no data range at all. Requesting a range that starts at
-	// the file size is rejected by the storage backends, so feed an empty reader rather than skipping
-	// readFileIngestObjects: its validation (required fields, table exists, CanIngest) must still run,
-	// it simply has no rows left to read.
-	var dataReader io.Reader = strings.NewReader("")
-	if startOffset < attrs.Size {
-		file, err := usecase.blobRepository.GetBlob(ctx, usecase.ingestionBucketUrl, uploadLog.FileName,
-			repositories.WithBeginOffset(startOffset))
-		if file.ReadCloser != nil {
-			defer file.ReadCloser.Close()
-		}
-		if err != nil {
-			return failAttempt(uploadLog.RowsIngested, nil, err)
-		}
-		dataReader = file.ReadCloser
-	}
-
-	out := usecase.readFileIngestObjects(ctx, exec, uploadLog, header, startOffset, attrs.Size,
-		dataReader, ingestionOptions)
+	out := usecase.readFileIngestObjects(ctx, exec, uploadLog, fileReader, fileSize, ingestionOptions)
 	if out.inputErr != nil || out.err != nil {
 		return failAttempt(out.numRowsIngested, out.inputErr, out.err)
 	}
@@ -701,6 +959,96 @@ func (usecase *IngestionUseCase) processUploadLog(ctx context.Context, uploadLog
 	return models.CsvIngestionCompleted, nil
 }
 
+// openUploadedFile opens a reader on the file of the upload log, positioned on the first row that
+// has not been ingested yet. It also returns the size of the file, in the unit of the checkpoints of
+// its format, and a function releasing the resources held by the reader.
+func (usecase *IngestionUseCase) openUploadedFile(ctx context.Context, uploadLog models.UploadLog,
+) (ingestionFileReader, int64, func(), error) {
+	switch uploadLog.FileFormat {
+	case models.IngestionFileFormatNdjson:
+		attrs, err := usecase.blobRepository.GetBlobAttributes(ctx, usecase.ingestionBucketUrl, uploadLog.FileName)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		dataReader, closeBlob, err := usecase.openBlobFrom(ctx, uploadLog.FileName, uploadLog.ByteOffset, attrs.Size)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		return newNdjsonFileReader(dataReader, uploadLog.ByteOffset, usecase.payloadEnricher), attrs.Size, closeBlob, nil
+
+	case models.IngestionFileFormatParquet:
+		// Parquet metadata sits at the end of the file, so the whole file is needed even when resuming.
+		blob, err := usecase.blobRepository.GetBlob(ctx, usecase.ingestionBucketUrl, uploadLog.FileName)
+		if blob.ReadCloser != nil {
+			defer blob.ReadCloser.Close()
+		}
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		fileReader, err := newParquetFileReader(ctx, blob.ReadCloser, uploadLog.ByteOffset, usecase.payloadEnricher)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		closeFile := func() {
+			if err := fileReader.Close(); err != nil {
+				utils.LoggerFromContext(ctx).WarnContext(ctx, "could not clean up parquet file",
+					"upload_log_id", uploadLog.Id, "error", err.Error())
+			}
+		}
+		numRows, err := fileReader.numRows(ctx)
+		if err != nil {
+			closeFile()
+			return nil, 0, nil, err
+		}
+		return fileReader, numRows, closeFile, nil
+
+	default:
+		// The header is read from its own reader at the start of the file, so that the data reader can
+		// always be opened at an explicit offset and never has to deal with the header row nor with a
+		// leading BOM, whether this is a first attempt or a resume.
+		header, dataStart, err := usecase.readCsvHeader(ctx, uploadLog.FileName)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		startOffset := max(uploadLog.ByteOffset, dataStart)
+
+		attrs, err := usecase.blobRepository.GetBlobAttributes(ctx, usecase.ingestionBucketUrl, uploadLog.FileName)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		dataReader, closeBlob, err := usecase.openBlobFrom(ctx, uploadLog.FileName, startOffset, attrs.Size)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+		return newCsvFileReader(dataReader, header, startOffset, usecase.payloadEnricher), attrs.Size, closeBlob, nil
+	}
+}
+
+// openBlobFrom opens a reader on the file starting at the given byte offset.
+//
+// A previous attempt may have checkpointed exactly at EOF and died before marking the log
+// successful, and a header-only file has no data range at all. Requesting a range that starts at
+// the file size is rejected by the storage backends, so it returns an empty reader rather than
+// skipping readFileIngestObjects: its validation (required fields, table exists, CanIngest) must
+// still run, it simply has no rows left to read.
+func (usecase *IngestionUseCase) openBlobFrom(ctx context.Context, fileName string, offset, size int64,
+) (io.Reader, func(), error) {
+	if offset >= size {
+		return strings.NewReader(""), func() {}, nil
+	}
+
+	file, err := usecase.blobRepository.GetBlob(ctx, usecase.ingestionBucketUrl, fileName,
+		repositories.WithBeginOffset(offset))
+	if err != nil {
+		if file.ReadCloser != nil {
+			file.ReadCloser.Close()
+		}
+		return nil, nil, err
+	}
+	return file.ReadCloser, func() { file.ReadCloser.Close() }, nil
+}
+
 // readCsvHeader reads the header row from the start of the file and returns it along with the
 // absolute byte offset at which the first data row begins.
 //
@@ -762,12 +1110,12 @@ type ingestionResult struct {
 // This method uses a return value wrapping an error, because we still want to use the number of rows ingested even if
 // an error occurred.
 func (usecase *IngestionUseCase) readFileIngestObjects(ctx context.Context,
-	exec repositories.Executor, uploadLog models.UploadLog, header []string,
-	startOffset, fileSize int64, fileReader io.Reader, ingestionOptions models.IngestionOptions,
+	exec repositories.Executor, uploadLog models.UploadLog, fileReader ingestionFileReader,
+	fileSize int64, ingestionOptions models.IngestionOptions,
 ) ingestionResult {
 	logger := utils.LoggerFromContext(ctx)
 	fileName := uploadLog.FileName
-	logger.InfoContext(ctx, fmt.Sprintf("Ingesting data from CSV %s", fileName))
+	logger.InfoContext(ctx, fmt.Sprintf("Ingesting data from %s %s", ingestionFileFormatLabel(uploadLog.FileFormat), fileName))
 
 	var (
 		organizationIdStr string
@@ -821,18 +1169,16 @@ func (usecase *IngestionUseCase) readFileIngestObjects(ctx context.Context,
 		}
 	}
 
-	return usecase.ingestObjectsFromCSV(ctx, organizationId, uploadLog, header, startOffset, fileSize,
-		fileReader, table, ingestionOptions)
+	return usecase.ingestObjectsFromFile(ctx, organizationId, uploadLog, fileReader, fileSize,
+		table, ingestionOptions)
 }
 
-func (usecase *IngestionUseCase) ingestObjectsFromCSV(
+func (usecase *IngestionUseCase) ingestObjectsFromFile(
 	ctx context.Context,
 	organizationId uuid.UUID,
 	uploadLog models.UploadLog,
-	header []string,
-	startOffset int64,
+	fileReader ingestionFileReader,
 	fileSize int64,
-	fileReader io.Reader,
 	table models.Table,
 	ingestionOptions models.IngestionOptions,
 ) ingestionResult {
@@ -859,21 +1205,17 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(
 	}
 	defer printDuration()
 
-	// fileReader is positioned on a data row, not on the header: the header was read separately by
-	// readCsvHeader so that this reader can start at an arbitrary offset when resuming. No BOM
-	// handling either, since a BOM can only ever sit at byte 0 of the file.
-	r := csv.NewReader(fileReader)
-	// Normally established by the first row csv.Reader reads, which here is a data row rather than
-	// the header. Setting it explicitly keeps the column-count check identical on a first attempt
-	// and on a resume.
-	r.FieldsPerRecord = len(header)
-
-	// first, check presence of all required fields in the csv
-	for name, field := range table.Fields {
-		if !field.Nullable {
-			if !slices.Contains(header, name) {
-				return ingestionResult{
-					inputErr: errors.WithDetailf(models.BadParameterError, "missing required field %s in CSV", name),
+	formatLabel := ingestionFileFormatLabel(uploadLog.FileFormat)
+
+	// first, check presence of all required fields in the file, if its columns are known upfront
+	if columns := fileReader.columns(); columns != nil {
+		for name, field := range table.Fields {
+			if !field.Nullable {
+				if !slices.Contains(columns, name) {
+					return ingestionResult{
+						inputErr: errors.WithDetailf(models.BadParameterError,
+							"missing required field %s in %s", name, formatLabel),
+					}
 				}
 			}
 		}
@@ -902,16 +1244,16 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(
 	deadline, hasDeadline := ingestionDeadline(ctx)
 
 	// describeRow labels a row for user-facing error messages. The counter is relative to this pass,
-	// not an absolute CSV line number: after a resume the absolute number is unknowable, because
+	// not an absolute line number: after a resume the absolute number is unknowable, because
 	// num_rows_ingested counts objects inserted (IngestObjects dedupes by object_id and skips
-	// payloads older than the stored version) rather than rows read. The byte offset is reported
-	// either way so the offending row stays locatable.
+	// payloads older than the stored version) rather than rows read. The position of the row in the
+	// file is reported either way so the offending row stays locatable.
 	resumed := uploadLog.ByteOffset > 0
-	describeRow := func(idx int, offset int64) string {
+	describeRow := func(idx int) string {
 		if resumed {
-			return fmt.Sprintf("row %d of the resumed pass (byte offset %d)", idx, offset)
+			return fmt.Sprintf("row %d of the resumed pass (%s)", idx, fileReader.position())
 		}
-		return fmt.Sprintf("line %d (byte offset %d)", idx, offset)
+		return fmt.Sprintf("line %d (%s)", idx, fileReader.position())
 	}
 
 	keepParsingFile := true
@@ -922,7 +1264,7 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(
 		windowEnd := objectIdx + csvIngestionBatchSize
 		clientObjects := make([]models.ClientObject, 0, csvIngestionBatchSize)
 		for ; objectIdx < windowEnd; objectIdx++ {
-			record, err := r.Read()
+			err := fileReader.next(iterationCtx)
 			if err == io.EOF { //nolint:errorlint
 				keepParsingFile = false
 				break
@@ -930,19 +1272,23 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(
 				iterationCancel()
 				return ingestionResult{
 					numRowsIngested: previouslyIngested + total,
-					err: fmt.Errorf("error reading %s of CSV: %w",
-						describeRow(objectIdx, startOffset+r.InputOffset()), err),
+					err:             fmt.Errorf("error reading %s of %s: %w", describeRow(objectIdx), formatLabel, err),
 				}
 			}
 
-			object, err := parseStringValuesToMap(header, record, table, usecase.payloadEnricher)
+			object, err := fileReader.parse(iterationCtx, table)
 			if err != nil {
 				iterationCancel()
+				rawRow, format := fileReader.rawRow()
+				deadLetter := models.NewIngestionDeadLetter(organizationId, table.Name,
+					models.IngestionDeadLetterSourceFile, format, rawRow, err, ingestionOptions)
+				deadLetter.UploadLogId = &uploadLog.Id
+				usecase.recordDeadLetters(ctx, []models.IngestionDeadLetter{deadLetter})
 				return ingestionResult{
 					numRowsIngested: previouslyIngested + total,
 					inputErr: errors.WithDetailf(err,
-						"error parsing field value in CSV at %s: %v",
-						describeRow(objectIdx, startOffset+r.InputOffset()), err),
+						"error parsing field value in %s at %s: %v",
+						formatLabel, describeRow(objectIdx), err),
 				}
 			}
 			clientObject := models.ClientObject{TableName: table.Name, Data: object}
@@ -978,7 +1324,7 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(
 		// that window re-ingests this batch on the next attempt rather than skipping it, which is the
 		// safe direction: upload_logs and the client objects live in different databases, so the two
 		// writes cannot share a transaction.
-		checkpoint := startOffset + r.InputOffset()
+		checkpoint := fileReader.checkpoint()
 		if err := usecase.uploadLogRepository.SaveUploadLogCheckpoint(ctx, exec, uploadLog.Id,
 			checkpoint, previouslyIngested+total); err != nil {
 			return ingestionResult{
@@ -987,7 +1333,7 @@ func (usecase *IngestionUseCase) ingestObjectsFromCSV(
 			}
 		}
 
-		logger.DebugContext(ctx, "csv ingestion progress",
+		logger.DebugContext(ctx, "file ingestion progress",
 			"upload_log_id", uploadLog.Id,
 			"rows_ingested", previouslyIngested+total,
 			"byte_offset", checkpoint,
@@ -1120,97 +1466,119 @@ func parseStringValuesToMap(headers []string, values []string, table models.Tabl
 			return nil, fmt.Errorf("field %s not found in table %s", fieldName, table.Name)
 		}
 
-		// Handle the case of null values (except for strings, which can be empty strings)
-		if value == "" {
-			// Special case for object_id which is a string but must not be empty
-			if field.DataType == models.String && fieldName != "object_id" {
-				result[fieldName] = ""
-			} else if !field.Nullable {
-				return nil, fmt.Errorf("field %s is required but is empty", fieldName)
-			} else {
-				result[fieldName] = nil
-			}
-			// move on to next field
-			continue
+		if err := parseStringValue(result, fieldName, field, value, enricher); err != nil {
+			return nil, err
 		}
+		if err := checkFieldConstraints(fieldName, field, result[fieldName]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
 
-		switch field.DataType {
-		case models.String:
-			result[fieldName] = value
-		case models.Timestamp:
-			if val, err := time.Parse(time.RFC3339, value); err == nil {
-				result[fieldName] = val.UTC()
-			} else if val, err = time.Parse("2006-01-02 15:04:05.9", value); err == nil {
-				result[fieldName] = val.UTC()
-			} else if val, err = time.Parse("2006-01-02T15:04:05.9", value); err == nil {
-				result[fieldName] = val.UTC()
-			} else if val, err = time.Parse("2006-01-02", value); fieldName != "updated_at" && err == nil {
-				result[fieldName] = val.UTC()
-			} else {
-				return nil, fmt.Errorf("error parsing timestamp %s for field %s: %w", value, fieldName, err)
-			}
-		case models.Bool:
-			val, err := strconv.ParseBool(value)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing bool %s for field %s: %w", value, fieldName, err)
-			}
-			result[fieldName] = val
-		case models.Int:
-			val, err := strconv.Atoi(value)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing int %s for field %s: %w", value, fieldName, err)
-			}
-			result[fieldName] = val
-		case models.Float:
-			val, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing float %s for field %s: %w", value, fieldName, err)
-			}
-			result[fieldName] = val
-		case models.IpAddress:
-			val, err := netip.ParseAddr(value)
-			if err != nil {
-				return nil, fmt.Errorf("invalid IP address %s", value)
-			}
-			result[fieldName] = val.Unmap()
+func checkFieldConstraints(fieldName string, field models.Field, value any) error {
+	if field.Constraints == nil {
+		return nil
+	}
+	if err := field.Constraints.Check(field, value); err != nil {
+		return fmt.Errorf("invalid value %v for field %s: %w", value, fieldName, err)
+	}
+	return nil
+}
 
-			if metadata := enricher.EnrichIp(val.Unmap()); metadata != nil {
-				key := fmt.Sprintf(`"%s.metadata"`, field.Name)
+// parseStringValue parses the text representation of a value of the field into result, along with
+// the metadata of the field if it can be enriched.
+func parseStringValue(result map[string]any, fieldName string, field models.Field, value string,
+	enricher payload_parser.PayloadEnrichementUsecase,
+) error {
+	// Handle the case of null values (except for strings, which can be empty strings)
+	if value == "" {
+		// Special case for object_id which is a string but must not be empty
+		if field.DataType == models.String && fieldName != "object_id" {
+			result[fieldName] = ""
+		} else if !field.Nullable {
+			return fmt.Errorf("field %s is required but is empty", fieldName)
+		} else {
+			result[fieldName] = nil
+		}
+		return nil
+	}
 
-				result[key] = metadata
-			}
-		case models.Coords:
-			latS, lngS, ok := strings.Cut(value, ",")
-			if !ok {
-				return nil, fmt.Errorf("invalid coordinates (lat, lng)")
-			}
-			lat, errLat := strconv.ParseFloat(latS, 64)
-			lng, errLng := strconv.ParseFloat(lngS, 64)
-			if errLat != nil || errLng != nil {
-				return nil, fmt.Errorf("invalid coordinates (lat, lng)")
-			}
+	switch field.DataType {
+	case models.String:
+		result[fieldName] = value
+	case models.Timestamp:
+		if val, err := time.Parse(time.RFC3339, value); err == nil {
+			result[fieldName] = val.UTC()
+		} else if val, err = time.Parse("2006-01-02 15:04:05.9", value); err == nil {
+			result[fieldName] = val.UTC()
+		} else if val, err = time.Parse("2006-01-02T15:04:05.9", value); err == nil {
+			result[fieldName] = val.UTC()
+		} else if val, err = time.Parse("2006-01-02", value); fieldName != "updated_at" && err == nil {
+			result[fieldName] = val.UTC()
+		} else {
+			return fmt.Errorf("error parsing timestamp %s for field %s: %w", value, fieldName, err)
+		}
+	case models.Bool:
+		val, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("error parsing bool %s for field %s: %w", value, fieldName, err)
+		}
+		result[fieldName] = val
+	case models.Int:
+		val, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("error parsing int %s for field %s: %w", value, fieldName, err)
+		}
+		result[fieldName] = val
+	case models.Float:
+		val, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing float %s for field %s: %w", value, fieldName, err)
+		}
+		result[fieldName] = val
+	case models.IpAddress:
+		val, err := netip.ParseAddr(value)
+		if err != nil {
+			return fmt.Errorf("invalid IP address %s", value)
+		}
+		result[fieldName] = val.Unmap()
 
-			loc := models.Location{Point: geom.NewPointFlat(geom.XY, []float64{lng, lat}).SetSRID(4326)}
+		if metadata := enricher.EnrichIp(val.Unmap()); metadata != nil {
+			key := fmt.Sprintf(`"%s.metadata"`, field.Name)
 
-			result[fieldName] = loc
+			result[key] = metadata
+		}
+	case models.Coords:
+		latS, lngS, ok := strings.Cut(value, ",")
+		if !ok {
+			return fmt.Errorf("invalid coordinates (lat, lng)")
+		}
+		lat, errLat := strconv.ParseFloat(latS, 64)
+		lng, errLng := strconv.ParseFloat(lngS, 64)
+		if errLat != nil || errLng != nil {
+			return fmt.Errorf("invalid coordinates (lat, lng)")
+		}
 
-			if metadata := enricher.EnrichCoordinates(loc.X(), loc.Y()); metadata != nil {
-				key := fmt.Sprintf(`"%s.metadata"`, field.Name)
+		loc := models.Location{Point: geom.NewPointFlat(geom.XY, []float64{lng, lat}).SetSRID(4326)}
 
-				result[key] = map[string]string{
-					"country": metadata.CountryCode2,
-				}
+		result[fieldName] = loc
+
+		if metadata := enricher.EnrichCoordinates(loc.X(), loc.Y()); metadata != nil {
+			key := fmt.Sprintf(`"%s.metadata"`, field.Name)
+
+			result[key] = map[string]string{
+				"country": metadata.CountryCode2,
 			}
-		default:
-			return nil, fmt.Errorf("invalid data type %s for field %s", field.DataType, fieldName)
 		}
-
+	default:
+		return fmt.Errorf("invalid data type %s for field %s", field.DataType, fieldName)
 	}
-	return result, nil
+	return nil
 }
 
-func computeFileName(organizationId, tableName string) string {
-	return organizationId + "/" + tableName + "/" + strconv.FormatInt(time.Now().Unix(), 10) + ".csv"
+func computeFileName(organizationId, tableName string, fileFormat models.IngestionFileFormat) string {
+	return organizationId + "/" + tableName + "/" + strconv.FormatInt(time.Now().Unix(), 10) + "." + string(fileFormat)
 }
 
 func retryIngestion(ctx context.Context, f func() error) error {
@@ -1236,16 +1604,47 @@ func (usecase *IngestionUseCase) insertEnumValuesAndIngest(
 ) (models.IngestionResults, error) {
 	start := time.Now()
 
+	evaluatingScenarios, err := usecase.scenarioRepository.ListScenariosEvaluatingOnIngest(ctx,
+		usecase.executorFactory.NewExecutor(), organizationId, table.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the scenarios evaluating objects on ingestion")
+	}
+
+	// Derived fields are computed once partial updates are merged with the previous version of their
+	// object, so that their inputs do not have to be in the payload.
+	var completePayloads func(payloads []models.ClientObject) error
+	if len(table.DerivedFields()) > 0 {
+		dataModel, err := usecase.dataModelRepository.GetDataModel(ctx,
+			usecase.executorFactory.NewExecutor(), organizationId, false, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting data model to compute derived fields")
+		}
+		completePayloads = func(payloads []models.ClientObject) error {
+			return computeDerivedFields(ctx, usecase.evaluateAstExpression, organizationId,
+				dataModel, table, payloads)
+		}
+	}
+
 	var ingestionResults models.IngestionResults
-	var err error
 	err = usecase.transactionFactory.TransactionInOrgSchema(ctx, organizationId, func(tx repositories.Transaction) error {
-		ingestionResults, err = usecase.ingestionRepository.IngestObjects(ctx, tx, payloads, table)
+		ingestionResults, err = usecase.ingestionRepository.IngestObjects(ctx, tx, payloads, table,
+			fieldsEvaluatedOnIngest(evaluatingScenarios, table), completePayloads)
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// The decisions are enqueued once the objects are committed, for the workers not to evaluate
+	// objects that are not visible yet, or that were rolled back. If they cannot be enqueued, the
+	// ingestion fails although the objects are written: ingesting them again writes new versions,
+	// whose decisions are enqueued.
+	if err := usecase.createDecisionsOnIngest(ctx, organizationId, table, evaluatingScenarios,
+		payloads, ingestionResults); err != nil {
+		return nil, errors.Wrap(err, "the objects were ingested, but the decisions of the scenarios "+
+			"evaluating them on ingestion could not be enqueued")
+	}
+
 	err = usecase.enqueueObjectsNeedScreeningTaskIfNeeded(ctx, organizationId, table,
 		ingestionOptions, ingestionResults)
 	if err != nil {
@@ -1,15 +1,20 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/checkmarble/marble-backend/mocks"
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
 	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval/evaluate"
 	"github.com/checkmarble/marble-backend/usecases/executor_factory"
 	"github.com/checkmarble/marble-backend/usecases/payload_parser"
 	"github.com/checkmarble/marble-backend/utils"
@@ -127,8 +132,11 @@ type IngestionUsecaseTestSuite struct {
 	continuousScreeningRepository       *mocks.ContinuousScreeningRepository
 	continuousScreeningClientRepository *mocks.ContinuousScreeningClientDbRepository
 	taskQueueRepository                 *mocks.TaskQueueRepository
+	deadLetterRepository                *mocks.IngestionDeadLetterRepository
 	scoringRulesetsUsecase              *mocks.ScoringRulesetsUsecase
 	scoringScoreUsecase                 *mocks.ScoringScoreUsecase
+	scenarioRepository                  *mocks.IngestionScenarioRepository
+	asyncDecisionCreator                *mocks.IngestionAsyncDecisionCreator
 
 	organizationId uuid.UUID
 	dataModel      models.DataModel
@@ -147,7 +155,10 @@ func (suite *IngestionUsecaseTestSuite) makeUsecase() *IngestionUseCase {
 		continuousScreeningClientRepository: suite.continuousScreeningClientRepository,
 		batchIngestionMaxSize:               100,
 		taskEnqueuer:                        suite.taskQueueRepository,
+		deadLetterRepository:                suite.deadLetterRepository,
 		scoringScoreUsecase:                 suite.scoringScoreUsecase,
+		scenarioRepository:                  suite.scenarioRepository,
+		asyncDecisionCreator:                suite.asyncDecisionCreator,
 	}
 }
 
@@ -159,8 +170,11 @@ func (suite *IngestionUsecaseTestSuite) SetupTest() {
 	suite.continuousScreeningRepository = new(mocks.ContinuousScreeningRepository)
 	suite.continuousScreeningClientRepository = new(mocks.ContinuousScreeningClientDbRepository)
 	suite.taskQueueRepository = new(mocks.TaskQueueRepository)
+	suite.deadLetterRepository = new(mocks.IngestionDeadLetterRepository)
 	suite.scoringScoreUsecase = new(mocks.ScoringScoreUsecase)
 	suite.scoringRulesetsUsecase = new(mocks.ScoringRulesetsUsecase)
+	suite.scenarioRepository = new(mocks.IngestionScenarioRepository)
+	suite.asyncDecisionCreator = new(mocks.IngestionAsyncDecisionCreator)
 
 	suite.organizationId = uuid.MustParse("12345678-1234-5678-9012-345678901234")
 	suite.dataModel = models.DataModel{
@@ -200,11 +214,20 @@ func (suite *IngestionUsecaseTestSuite) AssertExpectations() {
 	suite.continuousScreeningRepository.AssertExpectations(t)
 	suite.continuousScreeningClientRepository.AssertExpectations(t)
 	suite.taskQueueRepository.AssertExpectations(t)
+	suite.scenarioRepository.AssertExpectations(t)
+	suite.asyncDecisionCreator.AssertExpectations(t)
+}
+
+func (suite *IngestionUsecaseTestSuite) noScenarioEvaluatingOnIngest() {
+	suite.scenarioRepository.On("ListScenariosEvaluatingOnIngest", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, "transactions").
+		Return([]models.Scenario{}, nil)
 }
 
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nominal_with_previous_version() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -263,6 +286,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nominal_no_previous_version() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -314,6 +338,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nominal_no_previous_version_and_enum() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	// update the basic data model to include an enum, and use this copy just in this test
 	dataModel := suite.dataModel.Copy()
@@ -375,6 +400,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nominal_with_more_recent_previous_version() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -426,6 +452,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 	// "status" is missing in the payload, but it can be read from a previous version of the object
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -481,10 +508,107 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_nomina
 	asserts.Equal(1, nb, "Number of rows affected")
 }
 
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_partial_insert_with_derived_field() {
+	// "value" is missing in the payload, the derived field "fee" is computed from the previous version of it
+	t := suite.T()
+	uc := suite.makeUsecase()
+	uc.evaluateAstExpression = ast_eval.EvaluateAstExpression{
+		AstEvaluationEnvironmentFactory: func(params ast_eval.EvaluationEnvironmentFactoryParams) ast_eval.AstEvaluationEnvironment {
+			environment := ast_eval.NewAstEvaluationEnvironment()
+			environment.AddEvaluator(ast.FUNC_PAYLOAD, evaluate.NewPayload(ast.FUNC_PAYLOAD, params.ClientObject))
+			return environment
+		},
+	}
+
+	table := suite.dataModel.Tables["transactions"]
+	table.Fields["fee"] = models.Field{
+		DataType: models.Float, Nullable: true, Name: "fee",
+		DerivedExpression: &ast.Node{
+			Function: ast.FUNC_MULTIPLY,
+			Children: []ast.Node{
+				{Function: ast.FUNC_PAYLOAD, Children: []ast.Node{ast.NewNodeConstant("value")}},
+				ast.NewNodeConstant(2.0),
+			},
+		},
+	}
+	suite.dataModel.Tables["transactions"] = table
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false, mock.Anything).
+		Return(suite.dataModel, nil)
+
+	suite.continuousScreeningRepository.On("GetOrganizationById",
+		mock.MatchedBy(matchContext), mock.Anything, suite.organizationId).
+		Return(models.Organization{}, nil)
+	suite.continuousScreeningRepository.On("ListContinuousScreeningConfigByObjectType",
+		mock.MatchedBy(matchContext), mock.MatchedBy(matchExec), mock.Anything, "transactions").
+		Return([]models.ContinuousScreeningConfig{}, nil)
+
+	rowIdStr := "17c5805e-eb8f-48f1-afd4-10ad5494954b"
+	rowId := utils.ByteUuid(rowIdStr)
+	updAt, _ := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	// there is a previous version for this object
+	suite.executorFactory.Mock.ExpectQuery(escapeSql(`SELECT object_id, updated_at, value, id FROM "test"."transactions" WHERE "test"."transactions".valid_until = $1 AND object_id IN ($2)`)).
+		WithArgs("Infinity", "1").
+		WillReturnRows(pgxmock.NewRows([]string{"object_id", "updated_at", "value", "id"}).
+			AddRow("1", updAt, 3.0, rowId))
+	// update the previous version
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`UPDATE "test"."transactions" SET valid_until = $1 WHERE id IN ($2)`)).
+		WithArgs("now()", rowIdStr).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	// insert the new version, with the derived field computed from the merged object
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`INSERT INTO "test"."transactions" (fee,object_id,status,updated_at,value,id) VALUES ($1,$2,$3,$4,$5,$6)`)).
+		WithArgs(6.0, "1", "KO", updAt, 3.0, anyUuid{}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	suite.dataModelRepository.On("BatchInsertEnumValues", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
+		Return(nil)
+
+	suite.continuousScreeningClientRepository.On("ListMonitoredObjectsByObjectIds",
+		mock.MatchedBy(matchContext), mock.Anything, "transactions", mock.Anything).
+		Return([]models.ContinuousScreeningMonitoredObject{}, nil)
+	suite.taskQueueRepository.On("EnqueueContinuousScreeningDoScreeningTaskMany",
+		mock.MatchedBy(matchContext), mock.Anything, suite.organizationId, "transactions", mock.Anything, mock.Anything).
+		Return(nil)
+	suite.continuousScreeningClientRepository.On("IsContinuousScreeningSetup",
+		mock.MatchedBy(matchContext), mock.Anything).Return(false, nil)
+
+	suite.scoringScoreUsecase.On("EnqueueComputationForIngestion", mock.Anything, suite.organizationId, "transactions", mock.Anything).
+		Return(nil)
+
+	scenario := models.Scenario{Id: "scenario", TriggerObjectType: "transactions", EvaluateOnIngest: true}
+	suite.scenarioRepository.On("ListScenariosEvaluatingOnIngest", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, "transactions").
+		Return([]models.Scenario{scenario}, nil)
+	var inputs []models.AsyncDecisionExecutionCreate
+	suite.asyncDecisionCreator.On("CreateIngestionAsyncDecisionExecutions", mock.MatchedBy(matchContext),
+		suite.organizationId, mock.Anything).
+		Run(func(args mock.Arguments) {
+			inputs = args.Get(2).([]models.AsyncDecisionExecutionCreate)
+		}).
+		Return(nil)
+
+	nb, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
+		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "status": "KO"}`), models.IngestionOptions{
+			ShouldScreen: true,
+		}, payload_parser.WithAllowPatch())
+	asserts := assert.New(t)
+	asserts.NoError(err, "Error ingesting object")
+	asserts.Equal(1, nb, "Number of rows affected")
+	// the decision evaluates the object as it was written, merged with its previous version
+	if asserts.Len(inputs, 1) {
+		asserts.JSONEq(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "status": "KO", "value": 3.0, "fee": 6.0}`,
+			string(inputs[0].TriggerObject))
+	}
+}
+
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_without_previous_version_and_partial_insert() {
 	// "status" is missing in the payload, and it can not be read from a previous version of the object
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -506,17 +630,27 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObject_withou
 	suite.scoringScoreUsecase.On("EnqueueComputationForIngestion", mock.Anything, suite.organizationId, "transactions", mock.Anything).
 		Return(nil)
 
+	// the rejected patch is kept as a patch, to be replayed as such
+	suite.deadLetterRepository.On("CreateIngestionDeadLetters", mock.Anything, mock.MatchedBy(matchExec),
+		mock.MatchedBy(func(deadLetters []models.IngestionDeadLetter) bool {
+			return len(deadLetters) == 1 && deadLetters[0].IsPatch &&
+				deadLetters[0].ObjectId != nil && *deadLetters[0].ObjectId == "1"
+		})).
+		Return(nil)
+
 	_, err := uc.IngestObject(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z"}`), models.IngestionOptions{
 			ShouldScreen: true,
 		}, payload_parser.WithAllowPatch())
 	asserts := assert.New(t)
 	asserts.ErrorAs(err, &models.IngestionValidationErrors{}, "Error ingesting object")
+	suite.deadLetterRepository.AssertExpectations(t)
 }
 
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_nominal() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -570,6 +704,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_nomin
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_previous_versions() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 
@@ -631,9 +766,94 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_
 	asserts.Equal(2, nb, "Number of rows affected")
 }
 
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_decisions_on_ingest() {
+	t := suite.T()
+	uc := suite.makeUsecase()
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false, mock.Anything).
+		Return(suite.dataModel, nil)
+
+	suite.continuousScreeningRepository.On("GetOrganizationById",
+		mock.MatchedBy(matchContext), mock.Anything, suite.organizationId).
+		Return(models.Organization{}, nil)
+
+	scenario := models.Scenario{
+		Id:                     "scenario",
+		TriggerObjectType:      "transactions",
+		EvaluateOnIngest:       true,
+		EvaluateOnIngestFields: []string{"status"},
+	}
+	suite.scenarioRepository.On("ListScenariosEvaluatingOnIngest", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, "transactions").
+		Return([]models.Scenario{scenario}, nil)
+
+	rowIdStr1 := "17c5805e-eb8f-48f1-afd4-10ad5494954b"
+	rowId1 := utils.ByteUuid(rowIdStr1)
+	rowIdStr2 := "27c5805e-eb8f-48f1-afd4-10ad5494954b"
+	rowId2 := utils.ByteUuid(rowIdStr2)
+	updAt, _ := time.Parse(time.RFC3339, "2020-01-01T00:00:00Z")
+	// the status watched by the scenario is loaded to be compared with the new versions
+	suite.executorFactory.Mock.ExpectQuery(escapeSql(`SELECT object_id, status, updated_at, id FROM "test"."transactions" WHERE "test"."transactions".valid_until = $1 AND object_id IN ($2,$3)`)).
+		WithArgs("Infinity", "1", "2").
+		WillReturnRows(pgxmock.NewRows([]string{"object_id", "status", "updated_at", "id"}).
+			AddRow("1", "OK", updAt, rowId1).
+			AddRow("2", "OK", updAt, rowId2))
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`UPDATE "test"."transactions" SET valid_until = $1 WHERE id IN ($2,$3)`)).
+		WithArgs("now()", rowIdStr1, rowIdStr2).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	suite.executorFactory.Mock.ExpectExec(escapeSql(`INSERT INTO "test"."transactions" (object_id,status,updated_at,value,id) VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10)`)).
+		WithArgs(
+			"1", "BLOCKED", updAt, 1.0, anyUuid{},
+			"2", "OK", updAt, 2.0, anyUuid{}).
+		WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+	suite.dataModelRepository.On("BatchInsertEnumValues", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), models.EnumValues{}, suite.dataModel.Tables["transactions"]).
+		Return(nil)
+	suite.continuousScreeningClientRepository.On("IsContinuousScreeningSetup",
+		mock.MatchedBy(matchContext), mock.Anything).Return(false, nil)
+	suite.scoringScoreUsecase.On("EnqueueComputationForIngestion", mock.Anything, suite.organizationId, "transactions", mock.Anything).
+		Return(nil)
+
+	// only the object whose status changed is evaluated
+	var inputs []models.AsyncDecisionExecutionCreate
+	suite.asyncDecisionCreator.On("CreateIngestionAsyncDecisionExecutions", mock.MatchedBy(matchContext),
+		suite.organizationId, mock.Anything).
+		Run(func(args mock.Arguments) {
+			inputs = args.Get(2).([]models.AsyncDecisionExecutionCreate)
+		}).
+		Return(nil)
+
+	nb, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
+		json.RawMessage(`[{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "BLOCKED"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`),
+		models.IngestionOptions{})
+	asserts := assert.New(t)
+	asserts.NoError(err, "Error ingesting objects")
+	asserts.Equal(2, nb, "Number of rows affected")
+	if asserts.Len(inputs, 1) {
+		asserts.Equal("transactions", inputs[0].ObjectType)
+		asserts.Equal(&scenario.Id, inputs[0].ScenarioId)
+		asserts.False(inputs[0].ShouldIngest)
+		asserts.JSONEq(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "BLOCKED"}`,
+			string(inputs[0].TriggerObject))
+		// the decision is deduplicated on the row of the new version of the object
+		rowId, ok := strings.CutPrefix(*inputs[0].DeduplicationKey, "ingestion:"+scenario.Id+":")
+		if asserts.True(ok) {
+			_, err := uuid.Parse(rowId)
+			asserts.NoError(err)
+			asserts.NotEqual(rowIdStr1, rowId)
+		}
+	}
+	suite.AssertExpectations()
+}
+
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_partial_insert() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
 	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
@@ -689,6 +909,7 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_
 func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_continuous_screening() {
 	t := suite.T()
 	uc := suite.makeUsecase()
+	suite.noScenarioEvaluatingOnIngest()
 
 	dataModel := suite.dataModel.Copy()
 	table := dataModel.Tables["transactions"]
@@ -853,12 +1074,149 @@ func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_IngestObjects_with_
 		mock.MatchedBy(matchContext), mock.MatchedBy(matchExec), mock.Anything, "transactions").
 		Return([]models.ContinuousScreeningConfig{}, nil)
 
+	var deadLetters []models.IngestionDeadLetter
+	suite.deadLetterRepository.On("CreateIngestionDeadLetters", mock.Anything,
+		mock.MatchedBy(matchExec), mock.Anything).
+		Run(func(args mock.Arguments) {
+			deadLetters = args.Get(2).([]models.IngestionDeadLetter)
+		}).
+		Return(nil)
+
 	_, err := uc.IngestObjects(suite.ctx, suite.organizationId, "transactions",
 		json.RawMessage(`[{"object_id": "", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}, {"object_id": "2", "updated_at": "2020-01-01T00:00:00Z", "value": 2.0, "status": "OK"}]`), models.IngestionOptions{
 			ShouldScreen: true,
 		})
 	asserts := assert.New(t)
 	asserts.ErrorAs(err, &models.IngestionValidationErrors{}, "Error ingesting objects")
+
+	// Only the rejected object is kept, with its payload as sent and the reason it was rejected
+	if asserts.Len(deadLetters, 1) {
+		asserts.Equal(suite.organizationId, deadLetters[0].OrganizationId)
+		asserts.Equal("transactions", deadLetters[0].ObjectType)
+		asserts.Equal(models.IngestionDeadLetterSourceApi, deadLetters[0].Source)
+		asserts.Equal(models.IngestionDeadLetterPending, deadLetters[0].Status)
+		asserts.Nil(deadLetters[0].ObjectId)
+		asserts.JSONEq(`{"object_id": "", "updated_at": "2020-01-01T00:00:00Z", "value": 1.0, "status": "OK"}`,
+			string(deadLetters[0].Payload))
+		asserts.Contains(deadLetters[0].FieldErrors, "object_id")
+		asserts.True(deadLetters[0].IngestionOptions.ShouldScreen)
+	}
+}
+
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_ReplayIngestionDeadLetter_rejected_again() {
+	t := suite.T()
+	uc := suite.makeUsecase()
+
+	deadLetter := models.NewIngestionDeadLetter(suite.organizationId, "transactions",
+		models.IngestionDeadLetterSourceFile, models.IngestionDeadLetterFormatCsv,
+		json.RawMessage(`{"object_id": "1", "updated_at": "not a date", "status": "OK"}`),
+		models.IngestionValidationErrorsSingle{"updated_at": "invalid timestamp"}, models.IngestionOptions{})
+	fixedPayload := json.RawMessage(`{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "value": "not a number", "status": "OK"}`)
+
+	suite.deadLetterRepository.On("GetIngestionDeadLetter", mock.Anything, mock.MatchedBy(matchExec), deadLetter.Id).
+		Return(deadLetter, nil)
+	suite.enforceSecurity.On("ReadIngestionDeadLetter", deadLetter).Return(nil)
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.deadLetterRepository.On("UpdateIngestionDeadLetterPayload", mock.Anything, mock.MatchedBy(matchExec),
+		mock.MatchedBy(func(d models.IngestionDeadLetter) bool {
+			return string(d.Payload) == string(fixedPayload)
+		})).
+		Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false, mock.Anything).
+		Return(suite.dataModel, nil)
+	suite.deadLetterRepository.On("RecordIngestionDeadLetterReplay", mock.Anything, mock.MatchedBy(matchExec),
+		mock.MatchedBy(func(d models.IngestionDeadLetter) bool {
+			return d.Status == models.IngestionDeadLetterPending && d.FieldErrors["updated_at"] == ""
+		})).
+		Return(nil)
+
+	_, err := uc.ReplayIngestionDeadLetter(suite.ctx, deadLetter.Id, fixedPayload)
+	assert.ErrorIs(t, err, models.BadParameterError)
+	suite.deadLetterRepository.AssertExpectations(t)
+	suite.AssertExpectations()
+}
+
+// bufferWriteCloser stands for a blob being written
+type bufferWriteCloser struct {
+	bytes.Buffer
+}
+
+func (*bufferWriteCloser) Close() error {
+	return nil
+}
+
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_ValidateAndUploadIngestionFile_ndjson() {
+	t := suite.T()
+	blobRepository := new(mocks.MockBlobRepository)
+	uploadLogRepository := new(mocks.UploadLogRepository)
+	uc := suite.makeUsecase()
+	uc.blobRepository = blobRepository
+	uc.uploadLogRepository = uploadLogRepository
+	uc.payloadEnricher = payload_parser.NewPayloadEnrichmentUsecase(nil, nil)
+
+	content := `{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "status": "OK", "value": 12.5}
+{"object_id": "2", "updated_at": "2020-01-02T00:00:00Z", "status": "KO"}
+`
+	blob := &bufferWriteCloser{}
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false, true).
+		Return(suite.dataModel, nil)
+	blobRepository.On("OpenStream", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(key string) bool { return strings.HasSuffix(key, ".ndjson") }), mock.Anything).
+		Return(blob, nil)
+	uploadLogRepository.On("CreateUploadLog", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(log models.UploadLog) bool {
+			return log.FileFormat == models.IngestionFileFormatNdjson && log.LinesProcessed == 2 &&
+				log.UploadStatus == models.UploadPending
+		})).
+		Return(nil)
+	suite.taskQueueRepository.On("EnqueueCsvIngestionTask", mock.Anything, mock.Anything,
+		suite.organizationId, mock.Anything, models.IngestionOptions{}).
+		Return(nil)
+	uploadLogRepository.On("UploadLogById", mock.Anything, mock.Anything, mock.Anything).
+		Return(models.UploadLog{FileFormat: models.IngestionFileFormatNdjson}, nil)
+
+	uploadLog, err := uc.ValidateAndUploadIngestionFile(suite.ctx, suite.organizationId, "user",
+		"transactions", models.IngestionFileFormatNdjson, strings.NewReader(content), models.IngestionOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, models.IngestionFileFormatNdjson, uploadLog.FileFormat)
+	assert.Equal(t, content, blob.String())
+	blobRepository.AssertExpectations(t)
+	uploadLogRepository.AssertExpectations(t)
+	suite.AssertExpectations()
+}
+
+func (suite *IngestionUsecaseTestSuite) TestIngestionUsecase_ValidateAndUploadIngestionFile_ndjson_rejected() {
+	t := suite.T()
+	blobRepository := new(mocks.MockBlobRepository)
+	uc := suite.makeUsecase()
+	uc.blobRepository = blobRepository
+	uc.payloadEnricher = payload_parser.NewPayloadEnrichmentUsecase(nil, nil)
+
+	content := `{"object_id": "1", "updated_at": "2020-01-01T00:00:00Z", "status": "OK"}
+{"object_id": "2", "updated_at": "not a date", "status": "KO"}
+`
+
+	suite.enforceSecurity.On("CanIngest", suite.organizationId).Return(nil)
+	suite.dataModelRepository.On("GetDataModel", mock.MatchedBy(matchContext),
+		mock.MatchedBy(matchExec), suite.organizationId, false, true).
+		Return(suite.dataModel, nil)
+	blobRepository.On("OpenStream", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&bufferWriteCloser{}, nil)
+	suite.deadLetterRepository.On("CreateIngestionDeadLetters", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(deadLetters []models.IngestionDeadLetter) bool {
+			return len(deadLetters) == 1 && deadLetters[0].PayloadFormat == models.IngestionDeadLetterFormatJson
+		})).
+		Return(nil)
+
+	_, err := uc.ValidateAndUploadIngestionFile(suite.ctx, suite.organizationId, "user",
+		"transactions", models.IngestionFileFormatNdjson, strings.NewReader(content), models.IngestionOptions{})
+	assert.ErrorIs(t, err, models.BadParameterError)
+	suite.deadLetterRepository.AssertExpectations(t)
+	suite.AssertExpectations()
 }
 
 func TestIngestionUsecase(t *testing.T) {
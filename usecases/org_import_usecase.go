@@ -306,9 +306,11 @@ func (uc *OrgImportUsecase) createOrganization(ctx context.Context, tx repositor
 	err = uc.orgRepository.UpdateOrganization(ctx, tx, orgId, models.UpdateOrganizationInput{
 		DefaultScenarioTimezone: spec.Org.UpdateOrganizationBodyDto.DefaultScenarioTimezone,
 		ScreeningConfig: models.OrganizationOpenSanctionsConfigUpdateInput{
-			MatchThreshold: spec.Org.SanctionsThreshold,
-			MatchLimit:     spec.Org.SanctionsLimit,
-			Providers:      spec.Org.ScreeningProviders,
+			MatchThreshold:    spec.Org.SanctionsThreshold,
+			MatchLimit:        spec.Org.SanctionsLimit,
+			Providers:         spec.Org.ScreeningProviders,
+			NameNormalization: spec.Org.NameNormalization,
+			NameStoplist:      spec.Org.NameStoplist,
 		},
 	})
 	if err != nil {
@@ -704,6 +706,7 @@ func buildCreateFieldInputs(table dto.Table) []models.CreateFieldInput {
 			IsEnum:       field.IsEnum,
 			FTMProperty:  ftmProperty,
 			Metadata:     field.Metadata,
+			Constraints:  dto.AdaptFieldConstraints(field.Constraints),
 		})
 	}
 	return fields
@@ -948,6 +951,15 @@ func (uc *OrgImportUsecase) createScenarios(ctx context.Context, tx repositories
 				}
 			}
 
+			throttlePeriod, err := dto.ParseThrottlePeriod(rule.ThrottlePeriod)
+			if err != nil {
+				return err
+			}
+			throttleScope, err := models.RuleThrottleScopeFrom(rule.ThrottleScope)
+			if err != nil {
+				return err
+			}
+
 			rules[idx] = models.CreateRuleInput{
 				StableRuleId:         stableId.String(),
 				OrganizationId:       orgId,
@@ -957,6 +969,8 @@ func (uc *OrgImportUsecase) createScenarios(ctx context.Context, tx repositories
 				FormulaAstExpression: ruleAst,
 				ScoreModifier:        rule.ScoreModifier,
 				RuleGroup:            rule.RuleGroup,
+				ThrottlePeriod:       throttlePeriod,
+				ThrottleScope:        throttleScope,
 			}
 		}
 
@@ -26,6 +26,8 @@ type Parser struct {
 	allFieldsNullable     bool
 	disallowUnknownFields bool
 	columnEscape          bool
+	skipConstraints       bool
+	skipDerivedFields     bool
 	enricher              PayloadEnrichementUsecase
 }
 
@@ -68,7 +70,7 @@ func (p *Parser) ParsePayload(ctx context.Context, table models.Table, json []by
 	}
 
 	for name, field := range table.Fields {
-		if field.Archived {
+		if field.Archived || (p.skipDerivedFields && field.IsDerived()) {
 			continue
 		}
 
@@ -101,6 +103,8 @@ func (p *Parser) ParsePayload(ctx context.Context, table models.Table, json []by
 		}
 		if val, err := parseField(name, value); err != nil {
 			addError(allErrors, objectId, name, err)
+		} else if err := p.checkConstraints(field, val); err != nil {
+			addError(allErrors, objectId, name, err)
 		} else {
 			// Enrich ingested data for fields supporting it
 			switch field.DataType {
@@ -177,11 +181,20 @@ func (p *Parser) ParsePayload(ctx context.Context, table models.Table, json []by
 	}, nil
 }
 
+func (p *Parser) checkConstraints(field models.Field, value any) error {
+	if p.skipConstraints || field.Constraints == nil {
+		return nil
+	}
+	return field.Constraints.Check(field, value)
+}
+
 type parserOpts struct {
 	allowPatch            bool
 	allFieldsNullable     bool
 	disallowUnknownFields bool
 	columnEscape          bool
+	skipConstraints       bool
+	skipDerivedFields     bool
 	enricher              PayloadEnrichementUsecase
 }
 
@@ -216,6 +229,22 @@ func DisallowUnknownFields() ParserOpt {
 	}
 }
 
+// WithoutFieldConstraints skips the value constraints declared on the fields, for the same reason as
+// WithAllFieldsNullable: they may have been added or tightened since the payload was validated.
+func WithoutFieldConstraints() ParserOpt {
+	return func(o *parserOpts) {
+		o.skipConstraints = true
+	}
+}
+
+// WithoutDerivedFields ignores the values sent for derived fields, which are computed at ingestion
+// from the other fields of the object.
+func WithoutDerivedFields() ParserOpt {
+	return func(o *parserOpts) {
+		o.skipDerivedFields = true
+	}
+}
+
 func WithEnricher(uc PayloadEnrichementUsecase) ParserOpt {
 	return func(o *parserOpts) {
 		o.enricher = uc
@@ -228,6 +257,11 @@ func WithColumnEscape() ParserOpt {
 	}
 }
 
+// AllowsPatch tells whether the parser accepts partial payloads updating an existing object.
+func (p *Parser) AllowsPatch() bool {
+	return p.allowPatch
+}
+
 func NewParser(opts ...ParserOpt) *Parser {
 	parsers := fieldParser{
 		models.Timestamp: func(fieldName string, result gjson.Result) (any, error) {
@@ -315,6 +349,8 @@ func NewParser(opts ...ParserOpt) *Parser {
 		allFieldsNullable:     options.allFieldsNullable,
 		disallowUnknownFields: options.disallowUnknownFields,
 		columnEscape:          options.columnEscape,
+		skipConstraints:       options.skipConstraints,
+		skipDerivedFields:     options.skipDerivedFields,
 		enricher:              options.enricher,
 	}
 }
@@ -325,7 +361,7 @@ func TypedClientObject(ctx context.Context, dataModel models.DataModel, o models
 		return models.ClientObject{}, err
 	}
 
-	parser := NewParser(WithAllFieldsNullable())
+	parser := NewParser(WithAllFieldsNullable(), WithoutFieldConstraints())
 
 	clientObject, err := parser.ParsePayload(ctx, dataModel.Tables[o.TableName], payloadJson)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
 )
 
 func TestParser_ParsePayload(t *testing.T) {
@@ -348,3 +349,72 @@ func TestTypedClientObject_BackwardCompatibility(t *testing.T) {
 	assert.Equal(t, "124", resultWithNull.Data["object_id"])
 	assert.Nil(t, resultWithNull.Data["new_required_field"])
 }
+
+func TestParser_ParsePayload_FieldConstraints(t *testing.T) {
+	minAmount := 0.0
+	currency := models.FieldConstraintFormatIsoCurrency
+	table := models.Table{
+		Name: "transactions",
+		Fields: map[string]models.Field{
+			"object_id":  {DataType: models.String},
+			"updated_at": {DataType: models.Timestamp},
+			"amount": {
+				DataType:    models.Float,
+				Constraints: &models.FieldConstraints{Min: &minAmount},
+			},
+			"currency": {
+				DataType:    models.String,
+				Nullable:    true,
+				Constraints: &models.FieldConstraints{Format: &currency},
+			},
+		},
+	}
+	input := []byte(`{
+		"object_id": "1",
+		"updated_at": "2023-10-19 17:33:22",
+		"amount": -10,
+		"currency": "EUX"
+	}`)
+
+	_, err := NewParser().ParsePayload(context.TODO(), table, input)
+	var validationErrors models.IngestionValidationErrors
+	assert.True(t, errors.As(err, &validationErrors), "expected validation errors")
+	assert.Equal(t, models.IngestionValidationErrors{"1": models.IngestionValidationErrorsSingle{
+		"amount":   "must be greater than or equal to 0",
+		"currency": "must be an ISO 4217 currency code",
+	}}, validationErrors)
+
+	out, err := NewParser(WithoutFieldConstraints()).ParsePayload(context.TODO(), table, input)
+	assert.NoError(t, err)
+	assert.Equal(t, -10.0, out.Data["amount"])
+}
+
+func TestParser_ParsePayload_DerivedFields(t *testing.T) {
+	table := models.Table{
+		Name: "transactions",
+		Fields: map[string]models.Field{
+			"object_id":  {DataType: models.String},
+			"updated_at": {DataType: models.Timestamp},
+			"amount":     {DataType: models.Float},
+			"total": {
+				DataType:          models.Float,
+				Nullable:          true,
+				DerivedExpression: &ast.Node{Function: ast.FUNC_PAYLOAD},
+			},
+		},
+	}
+	input := []byte(`{
+		"object_id": "1",
+		"updated_at": "2023-10-19 17:33:22",
+		"amount": 10,
+		"total": "not a float"
+	}`)
+
+	_, err := NewParser().ParsePayload(context.TODO(), table, input)
+	assert.Error(t, err)
+
+	out, err := NewParser(WithoutDerivedFields(), WithAllowPatch()).ParsePayload(context.TODO(), table, input)
+	assert.NoError(t, err)
+	assert.NotContains(t, out.Data, "total")
+	assert.Empty(t, out.MissingFieldsToLookup)
+}
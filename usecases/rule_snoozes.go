@@ -3,12 +3,17 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/models/ast"
 	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/ast_eval"
 	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/scenarios"
 	"github.com/checkmarble/marble-backend/utils"
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -30,8 +35,19 @@ type ruleSnoozeRepository interface {
 		ctx context.Context,
 		exec repositories.Executor,
 		snoozeGroupIds []string,
-		pivotValue string,
+		keys []models.RuleSnoozeKey,
 	) ([]models.RuleSnooze, error)
+	ListActiveRuleSnoozesOfSnoozeGroup(
+		ctx context.Context,
+		exec repositories.Executor,
+		snoozeGroupId string,
+	) ([]models.RuleSnooze, error)
+	EndRuleSnoozes(
+		ctx context.Context,
+		exec repositories.Executor,
+		snoozeGroupId string,
+		snoozeIds []string,
+	) error
 	AnySnoozesForIteration(
 		ctx context.Context,
 		exec repositories.Executor,
@@ -44,14 +60,24 @@ type ruleSnoozeRepository interface {
 	) error
 }
 
+type ruleSnoozeValidationRepository interface {
+	GetScenarioById(ctx context.Context, exec repositories.Executor, scenarioId string,
+		screeningProvider models.ScreeningProvider) (models.Scenario, error)
+	GetDataModel(ctx context.Context, exec repositories.Executor, organizationID uuid.UUID, fetchEnumValues bool,
+		useCache bool) (models.DataModel, error)
+}
+
 type enforceSecuritySnoozes interface {
 	ReadSnoozesOfDecision(ctx context.Context, decision models.Decision) error
 	CreateSnoozesOnDecision(ctx context.Context, decision models.Decision) error
 	ReadSnoozesOfIteration(ctx context.Context, iteration models.ScenarioIteration) error
 	ReadRuleSnooze(ctx context.Context, snooze models.RuleSnooze) error
+	ReadSnoozesOfRule(ctx context.Context, rule models.Rule) error
+	ManageSnoozesOfRule(ctx context.Context, rule models.Rule) error
 }
 
 type updateRuleRepository interface {
+	GetRuleById(ctx context.Context, exec repositories.Executor, ruleId string) (models.Rule, error)
 	UpdateRule(ctx context.Context, exec repositories.Executor, rule models.UpdateRuleInput) error
 }
 
@@ -70,6 +96,9 @@ type RuleSnoozeUsecase struct {
 	ruleRepository       updateRuleRepository
 	ruleSnoozeRepository ruleSnoozeRepository
 	enforceSecurity      enforceSecuritySnoozes
+
+	validationRepository            ruleSnoozeValidationRepository
+	astEvaluationEnvironmentFactory ast_eval.AstEvaluationEnvironmentFactory
 }
 
 func NewRuleSnoozeUsecase(
@@ -81,6 +110,8 @@ func NewRuleSnoozeUsecase(
 	r updateRuleRepository,
 	s ruleSnoozeRepository,
 	es enforceSecuritySnoozes,
+	vr ruleSnoozeValidationRepository,
+	astEvaluationEnvironmentFactory ast_eval.AstEvaluationEnvironmentFactory,
 ) RuleSnoozeUsecase {
 	return RuleSnoozeUsecase{
 		decisionGetter:       d,
@@ -91,6 +122,9 @@ func NewRuleSnoozeUsecase(
 		ruleRepository:       r,
 		ruleSnoozeRepository: s,
 		enforceSecurity:      es,
+
+		validationRepository:            vr,
+		astEvaluationEnvironmentFactory: astEvaluationEnvironmentFactory,
 	}
 }
 
@@ -115,15 +149,6 @@ func (usecase RuleSnoozeUsecase) ActiveSnoozesForDecision(ctx context.Context, d
 		return models.SnoozesOfDecision{}, err
 	}
 
-	if decision.PivotValue == nil {
-		// no snoozes possible if decision doesn't have a pivot value
-		return models.SnoozesOfDecision{
-			DecisionId:  decisionId,
-			RuleSnoozes: make([]models.RuleSnoozeWithRuleId, 0),
-			Iteration:   it,
-		}, nil
-	}
-
 	snoozeGroupIds := make([]string, 0, len(it.Rules))
 	for _, rule := range it.Rules {
 		if rule.SnoozeGroupId != nil {
@@ -132,7 +157,7 @@ func (usecase RuleSnoozeUsecase) ActiveSnoozesForDecision(ctx context.Context, d
 	}
 
 	snoozes, err := usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(
-		ctx, exec, snoozeGroupIds, *decision.PivotValue)
+		ctx, exec, snoozeGroupIds, models.RuleSnoozeKeys(decision.PivotValue, decision.ClientObject))
 	if err != nil {
 		return models.SnoozesOfDecision{}, err
 	}
@@ -145,14 +170,9 @@ func (usecase RuleSnoozeUsecase) SnoozeDecision(
 ) (models.SnoozesOfDecision, error) {
 	exec := usecase.executorFactory.NewExecutor()
 
-	duration, err := time.ParseDuration(input.Duration)
+	duration, err := parseSnoozeDuration(input.Duration)
 	if err != nil {
-		return models.SnoozesOfDecision{}, errors.WithDetail(models.BadParameterError, err.Error())
-	}
-	if duration < 0 || duration > 180*24*time.Hour {
-		return models.SnoozesOfDecision{}, errors.WithDetail(
-			models.BadParameterError,
-			"duration must be positive and below 180 days")
+		return models.SnoozesOfDecision{}, err
 	}
 
 	decisions, err := usecase.decisionGetter.DecisionsById(ctx, exec, []string{input.DecisionId})
@@ -177,16 +197,20 @@ func (usecase RuleSnoozeUsecase) SnoozeDecision(
 		return models.SnoozesOfDecision{}, err
 	}
 
-	if decision.PivotValue == nil || *decision.PivotValue == "" {
-		return models.SnoozesOfDecision{}, errors.WithDetail(
-			models.UnprocessableEntityError,
-			fmt.Sprintf("Decision %s has no pivot value and cannot be snoozed", decision.DecisionId))
+	snoozeKey, err := snoozeKeyOfDecision(decision, input.KeyField)
+	if err != nil {
+		return models.SnoozesOfDecision{}, err
 	}
 
 	if err := usecase.enforceSecurity.CreateSnoozesOnDecision(ctx, decision); err != nil {
 		return models.SnoozesOfDecision{}, err
 	}
 
+	if _, err := usecase.validateSnoozeOptions(ctx, exec, decision.ScenarioIterationId.String(),
+		input.KeyField, input.UnlessCondition); err != nil {
+		return models.SnoozesOfDecision{}, err
+	}
+
 	it, err := usecase.iterationGetter.GetScenarioIteration(ctx, exec,
 		decision.ScenarioIterationId.String(), false)
 	if err != nil {
@@ -219,7 +243,7 @@ func (usecase RuleSnoozeUsecase) SnoozeDecision(
 	if snoozeGroupId != nil {
 		snoozes, err := usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(ctx, exec, []string{
 			*snoozeGroupId,
-		}, *decision.PivotValue)
+		}, []models.RuleSnoozeKey{snoozeKey})
 		if err != nil {
 			return models.SnoozesOfDecision{}, err
 		}
@@ -259,10 +283,12 @@ func (usecase RuleSnoozeUsecase) SnoozeDecision(
 				Id:                    snoozeId,
 				CreatedByUserId:       input.UserId,
 				ExpiresAt:             time.Now().Add(duration),
-				CreatedFromDecisionId: input.DecisionId,
+				CreatedFromDecisionId: &input.DecisionId,
 				CreatedFromRuleId:     thisRule.Id,
-				PivotValue:            *decision.PivotValue,
+				PivotValue:            snoozeKey.Value,
+				KeyField:              snoozeKey.Field,
 				SnoozeGroupId:         *snoozeGroupId,
+				UnlessCondition:       input.UnlessCondition,
 			})
 			if err != nil {
 				return nil, err
@@ -285,7 +311,8 @@ func (usecase RuleSnoozeUsecase) SnoozeDecision(
 				return nil, err
 			}
 
-			return usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(ctx, tx, snoozeGroupIds, *decision.PivotValue)
+			return usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(ctx, tx, snoozeGroupIds,
+				models.RuleSnoozeKeys(decision.PivotValue, decision.ClientObject))
 		},
 	)
 	if err != nil {
@@ -300,14 +327,9 @@ func (usecase RuleSnoozeUsecase) SnoozeDecisionWithoutCase(
 ) (models.SnoozesOfDecision, error) {
 	exec := usecase.executorFactory.NewExecutor()
 
-	duration, err := time.ParseDuration(input.Duration)
+	duration, err := parseSnoozeDuration(input.Duration)
 	if err != nil {
-		return models.SnoozesOfDecision{}, errors.WithDetail(models.BadParameterError, err.Error())
-	}
-	if duration < 0 || duration > 180*24*time.Hour {
-		return models.SnoozesOfDecision{}, errors.WithDetail(
-			models.BadParameterError,
-			"duration must be positive and below 180 days")
+		return models.SnoozesOfDecision{}, err
 	}
 
 	decisions, err := usecase.decisionGetter.DecisionsById(ctx, exec, []string{input.DecisionId})
@@ -321,16 +343,20 @@ func (usecase RuleSnoozeUsecase) SnoozeDecisionWithoutCase(
 	}
 	decision := decisions[0]
 
-	if decision.PivotValue == nil || *decision.PivotValue == "" {
-		return models.SnoozesOfDecision{}, errors.WithDetail(
-			models.UnprocessableEntityError,
-			fmt.Sprintf("Decision %s has no pivot value and cannot be snoozed", decision.DecisionId))
+	snoozeKey, err := snoozeKeyOfDecision(decision, input.KeyField)
+	if err != nil {
+		return models.SnoozesOfDecision{}, err
 	}
 
 	if err := usecase.enforceSecurity.CreateSnoozesOnDecision(ctx, decision); err != nil {
 		return models.SnoozesOfDecision{}, err
 	}
 
+	if _, err := usecase.validateSnoozeOptions(ctx, exec, decision.ScenarioIterationId.String(),
+		input.KeyField, input.UnlessCondition); err != nil {
+		return models.SnoozesOfDecision{}, err
+	}
+
 	it, err := usecase.iterationGetter.GetScenarioIteration(ctx, exec,
 		decision.ScenarioIterationId.String(), false)
 	if err != nil {
@@ -363,7 +389,7 @@ func (usecase RuleSnoozeUsecase) SnoozeDecisionWithoutCase(
 	if snoozeGroupId != nil {
 		snoozes, err := usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(ctx, exec, []string{
 			*snoozeGroupId,
-		}, *decision.PivotValue)
+		}, []models.RuleSnoozeKey{snoozeKey})
 		if err != nil {
 			return models.SnoozesOfDecision{}, err
 		}
@@ -403,16 +429,19 @@ func (usecase RuleSnoozeUsecase) SnoozeDecisionWithoutCase(
 				Id:                    snoozeId,
 				CreatedByUserId:       input.UserId,
 				ExpiresAt:             time.Now().Add(duration),
-				CreatedFromDecisionId: input.DecisionId,
+				CreatedFromDecisionId: &input.DecisionId,
 				CreatedFromRuleId:     thisRule.Id,
-				PivotValue:            *decision.PivotValue,
+				PivotValue:            snoozeKey.Value,
+				KeyField:              snoozeKey.Field,
 				SnoozeGroupId:         *snoozeGroupId,
+				UnlessCondition:       input.UnlessCondition,
 			})
 			if err != nil {
 				return nil, err
 			}
 
-			return usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(ctx, tx, snoozeGroupIds, *decision.PivotValue)
+			return usecase.ruleSnoozeRepository.ListActiveRuleSnoozesForDecision(ctx, tx, snoozeGroupIds,
+				models.RuleSnoozeKeys(decision.PivotValue, decision.ClientObject))
 		},
 	)
 	if err != nil {
@@ -422,6 +451,99 @@ func (usecase RuleSnoozeUsecase) SnoozeDecisionWithoutCase(
 	return models.NewSnoozesOfDecision(decision.DecisionId.String(), snoozes, it), nil
 }
 
+func parseSnoozeDuration(input string) (time.Duration, error) {
+	duration, err := time.ParseDuration(input)
+	if err != nil {
+		return 0, errors.WithDetail(models.BadParameterError, err.Error())
+	}
+	if duration < 0 || duration > 180*24*time.Hour {
+		return 0, errors.WithDetail(
+			models.BadParameterError,
+			"duration must be positive and below 180 days")
+	}
+	return duration, nil
+}
+
+// validateSnoozeOptions checks the key field and the unless condition of new snoozes of a rule against
+// the trigger table of its scenario, as the rule formulas are. It returns the key field, if any.
+func (usecase RuleSnoozeUsecase) validateSnoozeOptions(
+	ctx context.Context,
+	exec repositories.Executor,
+	scenarioIterationId string,
+	keyField *string,
+	unlessCondition *ast.Node,
+) (*models.Field, error) {
+	if keyField == nil && unlessCondition == nil {
+		return nil, nil
+	}
+
+	iteration, err := usecase.iterationGetter.GetScenarioIteration(ctx, exec, scenarioIterationId, true)
+	if err != nil {
+		return nil, err
+	}
+	scenario, err := usecase.validationRepository.GetScenarioById(ctx, exec, iteration.ScenarioId, "")
+	if err != nil {
+		return nil, err
+	}
+	dataModel, err := usecase.validationRepository.GetDataModel(ctx, exec, scenario.OrganizationId, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var field *models.Field
+	if keyField != nil {
+		tableField, ok := dataModel.Tables[scenario.TriggerObjectType].Fields[*keyField]
+		if !ok || !models.IsRuleSnoozeKeyType(tableField.DataType) {
+			return nil, errors.WithDetail(
+				models.BadParameterError,
+				fmt.Sprintf("rules cannot be snoozed on field %s of table %s: it must be a string or number field",
+					*keyField, scenario.TriggerObjectType))
+		}
+		field = &tableField
+	}
+
+	if unlessCondition != nil {
+		env, validationErr := scenarios.MakeDryRunEnvironmentWithDataModel(
+			usecase.astEvaluationEnvironmentFactory, scenario, dataModel)
+		if validationErr != nil {
+			return nil, validationErr.Error
+		}
+		evaluation, _ := ast_eval.EvaluateAst(ctx, nil, env, *unlessCondition)
+		if errs := evaluation.FlattenErrors(); len(errs) > 0 {
+			return nil, errors.WithDetail(
+				errors.Wrap(models.BadParameterError, "the unless condition is invalid"),
+				errors.Join(errs...).Error())
+		}
+		if _, ok := evaluation.ReturnValue.(bool); !ok {
+			return nil, errors.WithDetail(models.BadParameterError, "the unless condition does not return a boolean")
+		}
+	}
+
+	return field, nil
+}
+
+// snoozeKeyOfDecision returns what a rule is snoozed on from a decision: its pivot value, or the value
+// of keyField on its trigger object.
+func snoozeKeyOfDecision(decision models.Decision, keyField *string) (models.RuleSnoozeKey, error) {
+	if keyField == nil {
+		if decision.PivotValue == nil || *decision.PivotValue == "" {
+			return models.RuleSnoozeKey{}, errors.WithDetail(
+				models.UnprocessableEntityError,
+				fmt.Sprintf("Decision %s has no pivot value and cannot be snoozed", decision.DecisionId))
+		}
+		return models.RuleSnoozeKey{Value: *decision.PivotValue}, nil
+	}
+
+	key, ok := models.RuleSnoozeKeyOfField(*keyField, decision.ClientObject)
+	if !ok {
+		return models.RuleSnoozeKey{}, errors.WithDetail(
+			models.UnprocessableEntityError,
+			fmt.Sprintf("Field %s of the trigger object of decision %s has no value to snooze on",
+				*keyField, decision.DecisionId))
+	}
+	return key, nil
+}
+
 func (usecase RuleSnoozeUsecase) ActiveSnoozesForScenarioIteration(ctx context.Context, iterationId string) (models.SnoozesOfIteration, error) {
 	exec := usecase.executorFactory.NewExecutor()
 	it, err := usecase.iterationGetter.GetScenarioIteration(ctx, exec, iterationId, true)
@@ -485,3 +607,157 @@ func (usecase RuleSnoozeUsecase) GetRuleSnoozeById(ctx context.Context, ruleSnoo
 
 	return s, nil
 }
+
+// maxSnoozedValuesPerRequest bounds the number of snoozes created at once by SnoozeRule.
+const maxSnoozedValuesPerRequest = 1000
+
+// ActiveSnoozesOfRule lists the active snoozes of a rule. Snoozes belong to the snooze group of the
+// rule, so they are shared with the other versions of the rule.
+func (usecase RuleSnoozeUsecase) ActiveSnoozesOfRule(ctx context.Context, ruleId string) ([]models.RuleSnooze, error) {
+	exec := usecase.executorFactory.NewExecutor()
+	rule, err := usecase.ruleRepository.GetRuleById(ctx, exec, ruleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.enforceSecurity.ReadSnoozesOfRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if rule.SnoozeGroupId == nil {
+		return make([]models.RuleSnooze, 0), nil
+	}
+	return usecase.ruleSnoozeRepository.ListActiveRuleSnoozesOfSnoozeGroup(ctx, exec, *rule.SnoozeGroupId)
+}
+
+// SnoozeRule snoozes a rule on a list of values, independently of any decision. The values the rule is
+// already snoozed on (with the same key field) are skipped. It returns all the active snoozes of the rule.
+func (usecase RuleSnoozeUsecase) SnoozeRule(ctx context.Context, input models.SnoozeRuleInput) ([]models.RuleSnooze, error) {
+	exec := usecase.executorFactory.NewExecutor()
+
+	duration, err := parseSnoozeDuration(input.Duration)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := usecase.ruleRepository.GetRuleById(ctx, exec, input.RuleId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := usecase.enforceSecurity.ManageSnoozesOfRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	keyField, err := usecase.validateSnoozeOptions(ctx, exec, rule.ScenarioIterationId,
+		input.KeyField, input.UnlessCondition)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]string, 0, len(input.Values))
+	for _, value := range input.Values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		// numbers are keyed on their normalized representation, whatever the way they are written
+		if keyField != nil {
+			normalized, ok := models.RuleSnoozeValueOfField(keyField.DataType, value)
+			if !ok {
+				return nil, errors.WithDetail(
+					models.BadParameterError,
+					fmt.Sprintf("%s is not a valid value of field %s", value, keyField.Name))
+			}
+			value = normalized
+		}
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 || len(values) > maxSnoozedValuesPerRequest {
+		return nil, errors.WithDetail(
+			models.BadParameterError,
+			fmt.Sprintf("between 1 and %d values must be snoozed", maxSnoozedValuesPerRequest))
+	}
+
+	return executor_factory.TransactionReturnValue(
+		ctx,
+		usecase.transactionFactory,
+		func(tx repositories.Transaction) ([]models.RuleSnooze, error) {
+			snoozeGroupId := rule.SnoozeGroupId
+			if snoozeGroupId == nil {
+				val := pure_utils.NewId().String()
+				snoozeGroupId = &val
+				err := usecase.ruleSnoozeRepository.CreateSnoozeGroup(ctx, tx, val, rule.OrganizationId)
+				if err != nil {
+					return nil, err
+				}
+				err = usecase.ruleRepository.UpdateRule(ctx, tx, models.UpdateRuleInput{
+					Id:            rule.Id,
+					SnoozeGroupId: snoozeGroupId,
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			activeSnoozes, err := usecase.ruleSnoozeRepository.ListActiveRuleSnoozesOfSnoozeGroup(ctx, tx, *snoozeGroupId)
+			if err != nil {
+				return nil, err
+			}
+			alreadySnoozed := make(map[[2]string]bool, len(activeSnoozes))
+			for _, snooze := range activeSnoozes {
+				alreadySnoozed[[2]string{utils.Or(snooze.KeyField, ""), snooze.PivotValue}] = true
+			}
+
+			expiresAt := time.Now().Add(duration)
+			for _, value := range values {
+				if alreadySnoozed[[2]string{utils.Or(input.KeyField, ""), value}] {
+					continue
+				}
+				err := usecase.ruleSnoozeRepository.CreateRuleSnooze(ctx, tx, models.RuleSnoozeCreateInput{
+					Id:                pure_utils.NewId().String(),
+					CreatedByUserId:   input.UserId,
+					ExpiresAt:         expiresAt,
+					CreatedFromRuleId: rule.Id,
+					PivotValue:        value,
+					KeyField:          input.KeyField,
+					SnoozeGroupId:     *snoozeGroupId,
+					UnlessCondition:   input.UnlessCondition,
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return usecase.ruleSnoozeRepository.ListActiveRuleSnoozesOfSnoozeGroup(ctx, tx, *snoozeGroupId)
+		},
+	)
+}
+
+// EndRuleSnoozes ends active snoozes of a rule before their expiration.
+func (usecase RuleSnoozeUsecase) EndRuleSnoozes(ctx context.Context, input models.EndRuleSnoozesInput) error {
+	if !input.All && len(input.SnoozeIds) == 0 {
+		return errors.WithDetail(models.BadParameterError, "either snooze ids or all must be given")
+	}
+
+	exec := usecase.executorFactory.NewExecutor()
+	rule, err := usecase.ruleRepository.GetRuleById(ctx, exec, input.RuleId)
+	if err != nil {
+		return err
+	}
+
+	if err := usecase.enforceSecurity.ManageSnoozesOfRule(ctx, rule); err != nil {
+		return err
+	}
+
+	if rule.SnoozeGroupId == nil {
+		return nil
+	}
+
+	snoozeIds := input.SnoozeIds
+	if input.All {
+		snoozeIds = nil
+	}
+	return usecase.ruleSnoozeRepository.EndRuleSnoozes(ctx, exec, *rule.SnoozeGroupId, snoozeIds)
+}
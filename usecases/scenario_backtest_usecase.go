@@ -0,0 +1,581 @@
+package usecases
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/evaluate_scenario"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/payload_parser"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const (
+	scenarioBacktestBatchSize = 200
+	// The backtest gives the job back to the queue after that duration, to be resumed where it stopped
+	scenarioBacktestTimeBudget  = 4 * time.Minute
+	scenarioBacktestSnoozeDelay = 5 * time.Second
+)
+
+var errScenarioBacktestOver = errors.New("the backtest is over")
+
+type scenarioBacktestRepository interface {
+	GetScenarioById(ctx context.Context, exec repositories.Executor, scenarioId string,
+		screeningProvider models.ScreeningProvider) (models.Scenario, error)
+	GetScenarioIteration(ctx context.Context, exec repositories.Executor, scenarioIterationId string,
+		useCache bool) (models.ScenarioIteration, error)
+	ListScreeningConfigs(ctx context.Context, exec repositories.Executor, scenarioIterationId string,
+		useCache bool) ([]models.ScreeningConfig, error)
+
+	CreateTestRun(ctx context.Context, tx repositories.Transaction, testrunId string,
+		input models.ScenarioTestRunCreateDbInput) error
+	GetTestRunByID(ctx context.Context, exec repositories.Executor, testrunID string) (models.ScenarioTestRun, error)
+	UpdateTestRunStatus(ctx context.Context, exec repositories.Executor, testRunId string, status models.TestrunStatus) error
+	StorePhantomDecision(ctx context.Context, exec repositories.Executor, decision models.PhantomDecision,
+		organizationId uuid.UUID, testRunId string, newPhantomDecisionId string, scenarioVersion int) error
+	SaveTestRunDecisionSummary(ctx context.Context, exec repositories.Executor, testRunId string,
+		stat models.DecisionsByVersionByOutcome, newWatermark time.Time) error
+	SaveTestRunSummary(ctx context.Context, exec repositories.Executor, testRunId string,
+		stat models.RuleExecutionStat, newWatermark time.Time) error
+
+	CreateScenarioBacktest(ctx context.Context, exec repositories.Executor,
+		backtest models.ScenarioBacktest) (models.ScenarioBacktest, error)
+	GetScenarioBacktest(ctx context.Context, exec repositories.Executor, id uuid.UUID) (models.ScenarioBacktest, error)
+	ListScenarioBacktests(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		scenarioId *uuid.UUID) ([]models.ScenarioBacktest, error)
+	UpdateScenarioBacktestProgress(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		cursorCreatedAt time.Time, cursorDecisionId uuid.UUID, evaluated, failed int64) (bool, error)
+	CompleteScenarioBacktest(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		status models.ScenarioBacktestStatus, backtestError *string) (bool, error)
+	SaveScenarioBacktestOutcomes(ctx context.Context, exec repositories.Executor, backtestId uuid.UUID,
+		counts []models.ScenarioBacktestOutcomeCount) error
+	ListScenarioBacktestOutcomes(ctx context.Context, exec repositories.Executor,
+		backtestId uuid.UUID) ([]models.ScenarioBacktestOutcomeCount, error)
+	ListScenarioBacktestDecisions(ctx context.Context, exec repositories.Executor,
+		backtest models.ScenarioBacktest, limit int) ([]models.DecisionWithRuleExecutions, error)
+}
+
+type scenarioBacktestEvaluator interface {
+	EvalTestRunScenario(ctx context.Context, params evaluate_scenario.ScenarioEvaluationParameters) (
+		triggerPassed bool, se models.ScenarioExecution, err error)
+}
+
+// ScenarioBacktestUsecase replays past decisions of a scenario through a draft iteration, to see how
+// it would have decided without waiting for a shadow test run to collect live traffic.
+type ScenarioBacktestUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityTestRun
+
+	repository          scenarioBacktestRepository
+	dataModelRepository repositories.DataModelRepository
+	taskQueueRepository repositories.TaskQueueRepository
+	evaluator           scenarioBacktestEvaluator
+}
+
+func NewScenarioBacktestUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityTestRun,
+	repository scenarioBacktestRepository,
+	dataModelRepository repositories.DataModelRepository,
+	taskQueueRepository repositories.TaskQueueRepository,
+	evaluator scenarioBacktestEvaluator,
+) ScenarioBacktestUsecase {
+	return ScenarioBacktestUsecase{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		enforceSecurity:     enforceSecurity,
+		repository:          repository,
+		dataModelRepository: dataModelRepository,
+		taskQueueRepository: taskQueueRepository,
+		evaluator:           evaluator,
+	}
+}
+
+// CreateScenarioBacktest checks that the draft can be backtested and starts the backtest in the
+// background. The summaries of its test run are available from the test run endpoints.
+func (uc ScenarioBacktestUsecase) CreateScenarioBacktest(
+	ctx context.Context,
+	input models.CreateScenarioBacktestInput,
+) (models.ScenarioBacktest, error) {
+	if err := uc.enforceSecurity.CreateTestRun(input.OrganizationId); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if err := input.Validate(time.Now()); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	exec := uc.executorFactory.NewExecutor()
+
+	scenario, err := uc.repository.GetScenarioById(ctx, exec, input.ScenarioId, "")
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if err := uc.enforceSecurity.ReadOrganization(scenario.OrganizationId); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	// The test run keeps track of the live version, past decisions may have been made by older ones
+	if scenario.LiveVersionID == nil {
+		return models.ScenarioBacktest{}, models.ErrScenarioHasNoLiveVersion
+	}
+
+	iteration, err := uc.repository.GetScenarioIteration(ctx, exec, input.ScenarioIterationId, false)
+	if errors.Is(err, models.NotFoundError) {
+		return models.ScenarioBacktest{}, models.ErrScenarioIterationNotValid
+	}
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if iteration.ScenarioId != scenario.Id || iteration.Archived {
+		return models.ScenarioBacktest{}, models.ErrScenarioIterationNotValid
+	}
+	if iteration.Version != nil {
+		return models.ScenarioBacktest{}, errors.Wrap(models.ErrScenarioIterationNotDraft,
+			"only drafts can be backtested, published versions can be compared with a test run")
+	}
+
+	sccs, err := uc.repository.ListScreeningConfigs(ctx, exec, iteration.Id, false)
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if len(sccs) > 0 {
+		return models.ScenarioBacktest{}, errors.Wrap(models.BadParameterError,
+			"drafts with screening configs cannot be backtested, it would run a screening for each past decision")
+	}
+
+	scenarioId, err := uuid.Parse(scenario.Id)
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	iterationId, err := uuid.Parse(iteration.Id)
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	testRunId := pure_utils.NewId()
+
+	backtest, err := executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ScenarioBacktest, error) {
+		if err := uc.repository.CreateTestRun(ctx, tx, testRunId.String(), models.ScenarioTestRunCreateDbInput{
+			Kind:               models.ScenarioTestRunBacktest,
+			ScenarioId:         scenario.Id,
+			PhantomIterationId: iteration.Id,
+			LiveScenarioId:     *scenario.LiveVersionID,
+			EndDate:            input.WindowEnd,
+		}); err != nil {
+			return models.ScenarioBacktest{}, err
+		}
+
+		backtest, err := uc.repository.CreateScenarioBacktest(ctx, tx, models.ScenarioBacktest{
+			Id:                  pure_utils.NewId(),
+			OrganizationId:      input.OrganizationId,
+			ScenarioId:          scenarioId,
+			ScenarioIterationId: iterationId,
+			TestRunId:           testRunId,
+			WindowStart:         input.WindowStart,
+			WindowEnd:           input.WindowEnd,
+		})
+		if err != nil {
+			return models.ScenarioBacktest{}, err
+		}
+
+		return backtest, uc.taskQueueRepository.EnqueueScenarioBacktestTask(ctx, tx,
+			input.OrganizationId, backtest.Id)
+	})
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+
+	return backtest, nil
+}
+
+func (uc ScenarioBacktestUsecase) ListScenarioBacktests(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	scenarioId *uuid.UUID,
+) ([]models.ScenarioBacktest, error) {
+	if err := uc.enforceSecurity.ListTestRuns(organizationId); err != nil {
+		return nil, err
+	}
+
+	return uc.repository.ListScenarioBacktests(ctx, uc.executorFactory.NewExecutor(), organizationId, scenarioId)
+}
+
+func (uc ScenarioBacktestUsecase) GetScenarioBacktest(ctx context.Context, id uuid.UUID) (models.ScenarioBacktest, error) {
+	backtest, err := uc.repository.GetScenarioBacktest(ctx, uc.executorFactory.NewExecutor(), id)
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if err := uc.enforceSecurity.ReadTestRun(backtest.OrganizationId); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+
+	return backtest, nil
+}
+
+// GetScenarioBacktestOutcomes returns the confusion matrix of the outcomes of the live decisions
+// against the outcomes the draft gave them.
+func (uc ScenarioBacktestUsecase) GetScenarioBacktestOutcomes(
+	ctx context.Context,
+	id uuid.UUID,
+) ([]models.ScenarioBacktestOutcomeCount, error) {
+	backtest, err := uc.GetScenarioBacktest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.repository.ListScenarioBacktestOutcomes(ctx, uc.executorFactory.NewExecutor(), backtest.Id)
+	if err != nil {
+		return nil, err
+	}
+	models.SortScenarioBacktestOutcomeCounts(counts)
+	return counts, nil
+}
+
+func (uc ScenarioBacktestUsecase) CancelScenarioBacktest(ctx context.Context, id uuid.UUID) (models.ScenarioBacktest, error) {
+	exec := uc.executorFactory.NewExecutor()
+	backtest, err := uc.repository.GetScenarioBacktest(ctx, exec, id)
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if err := uc.enforceSecurity.CreateTestRun(backtest.OrganizationId); err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+
+	ended, err := uc.endScenarioBacktest(ctx, backtest, models.ScenarioBacktestCancelled, nil)
+	if err != nil {
+		return models.ScenarioBacktest{}, err
+	}
+	if !ended {
+		return models.ScenarioBacktest{}, errors.Wrap(models.ConflictError, "the backtest is already over")
+	}
+
+	return uc.repository.GetScenarioBacktest(ctx, exec, id)
+}
+
+// RunScenarioBacktest replays the decisions of the backtest until the time budget runs out, or
+// until models.ScenarioBacktestMaxDecisions decisions were replayed. It returns whether the
+// backtest is over, successfully or not.
+//
+// The decisions are replayed as of their creation, which the partial indexes of the client tables
+// (on the current versions of the objects) do not serve: the aggregates of the draft scan the
+// aggregated tables, which is why the number of decisions replayed is bounded.
+func (uc ScenarioBacktestUsecase) RunScenarioBacktest(ctx context.Context, backtestId uuid.UUID) (bool, error) {
+	logger := utils.LoggerFromContext(ctx)
+	exec := uc.executorFactory.NewExecutor()
+
+	backtest, err := uc.repository.GetScenarioBacktest(ctx, exec, backtestId)
+	if errors.Is(err, models.NotFoundError) {
+		// The draft, and its backtests with it, was deleted
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if backtest.Status.IsTerminal() {
+		return true, nil
+	}
+
+	testRun, err := uc.repository.GetTestRunByID(ctx, exec, backtest.TestRunId.String())
+	if err != nil {
+		return false, err
+	}
+	scenario, err := uc.repository.GetScenarioById(ctx, exec, backtest.ScenarioId.String(), "")
+	if err != nil {
+		return false, err
+	}
+	sccs, err := uc.repository.ListScreeningConfigs(ctx, exec, backtest.ScenarioIterationId.String(), false)
+	if err != nil {
+		return false, err
+	}
+	if len(sccs) > 0 {
+		return true, uc.failScenarioBacktest(ctx, backtest, "a screening config was added to the draft during the backtest")
+	}
+
+	dataModel, err := uc.dataModelRepository.GetDataModel(ctx, exec, backtest.OrganizationId, false, true)
+	if err != nil {
+		return false, err
+	}
+	pivotsMeta, err := uc.dataModelRepository.ListPivots(ctx, exec, backtest.OrganizationId, nil, true, false)
+	if err != nil {
+		return false, err
+	}
+	pivots := models.FindPivotsForTable(pivotsMeta, scenario.TriggerObjectType, dataModel)
+
+	deadline := time.Now().Add(scenarioBacktestTimeBudget)
+	for {
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		limit := min(scenarioBacktestBatchSize,
+			models.ScenarioBacktestMaxDecisions-int(backtest.DecisionsEvaluated+backtest.DecisionsFailed))
+		if limit <= 0 {
+			break
+		}
+
+		decisions, err := uc.repository.ListScenarioBacktestDecisions(ctx, exec, backtest, limit)
+		if err != nil {
+			return false, err
+		}
+		if len(decisions) == 0 {
+			break
+		}
+
+		batch := newScenarioBacktestBatch()
+		phantomDecisions := make([]models.PhantomDecision, 0, len(decisions))
+		phantomVersions := make([]int, 0, len(decisions))
+		for _, decision := range decisions {
+			se, triggered, err := uc.replayDecision(ctx, scenario, testRun, dataModel, pivots, decision.Decision)
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return false, err
+			}
+			if err != nil {
+				logger.WarnContext(ctx, "could not replay decision in backtest", "backtest_id", backtest.Id,
+					"decision_id", decision.DecisionId, "error", err.Error())
+				batch.failed++
+				continue
+			}
+			// Only the decisions that could be replayed are counted, for the live and backtest
+			// summaries to cover the same decisions.
+			batch.addLiveDecision(decision)
+			if !triggered {
+				batch.addOutcome(decision.Outcome.String(), models.ScenarioBacktestOutcomeNotTriggered)
+				continue
+			}
+			batch.addBacktestExecution(se)
+			batch.addOutcome(decision.Outcome.String(), se.Outcome.String())
+
+			phantomDecision := models.AdaptScenarExecToPhantomDecision(se)
+			phantomDecision.CreatedAt = decision.CreatedAt
+			for i := range phantomDecision.RuleExecutions {
+				phantomDecision.RuleExecutions[i].Evaluation = nil
+			}
+			phantomDecisions = append(phantomDecisions, phantomDecision)
+			phantomVersions = append(phantomVersions, se.ScenarioVersion)
+		}
+		last := decisions[len(decisions)-1]
+
+		err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+			running, err := uc.repository.UpdateScenarioBacktestProgress(ctx, tx, backtest.Id,
+				last.CreatedAt, last.DecisionId, int64(len(decisions))-batch.failed, batch.failed)
+			if err != nil {
+				return err
+			}
+			if !running {
+				return errScenarioBacktestOver
+			}
+
+			for i, phantomDecision := range phantomDecisions {
+				if err := uc.repository.StorePhantomDecision(ctx, tx, phantomDecision, backtest.OrganizationId,
+					testRun.Id, phantomDecision.PhantomDecisionId, phantomVersions[i]); err != nil {
+					return err
+				}
+			}
+
+			watermark := time.Now()
+			for _, stat := range batch.decisionStats() {
+				if err := uc.repository.SaveTestRunDecisionSummary(ctx, tx, testRun.Id, stat, watermark); err != nil {
+					return err
+				}
+			}
+			for _, stat := range batch.ruleStats() {
+				if err := uc.repository.SaveTestRunSummary(ctx, tx, testRun.Id, stat, watermark); err != nil {
+					return err
+				}
+			}
+
+			return uc.repository.SaveScenarioBacktestOutcomes(ctx, tx, backtest.Id, batch.outcomeCounts())
+		})
+		if errors.Is(err, errScenarioBacktestOver) {
+			// The backtest was cancelled while the batch was replayed
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		backtest.CursorCreatedAt = &last.CreatedAt
+		backtest.CursorDecisionId = &last.DecisionId
+		backtest.DecisionsEvaluated += int64(len(decisions)) - batch.failed
+		backtest.DecisionsFailed += batch.failed
+
+		if len(decisions) < limit {
+			break
+		}
+	}
+
+	if _, err := uc.endScenarioBacktest(ctx, backtest, models.ScenarioBacktestCompleted, nil); err != nil {
+		return false, err
+	}
+
+	logger.InfoContext(ctx, "scenario backtest completed", "backtest_id", backtest.Id,
+		"decisions_evaluated", backtest.DecisionsEvaluated, "decisions_failed", backtest.DecisionsFailed)
+	return true, nil
+}
+
+// replayDecision evaluates the trigger object of the past decision with the draft, against the data
+// as it was when the decision was made.
+func (uc ScenarioBacktestUsecase) replayDecision(
+	ctx context.Context,
+	scenario models.Scenario,
+	testRun models.ScenarioTestRun,
+	dataModel models.DataModel,
+	pivots []models.Pivot,
+	decision models.Decision,
+) (models.ScenarioExecution, bool, error) {
+	clientObject, err := payload_parser.TypedClientObject(ctx, dataModel, decision.ClientObject)
+	if err != nil {
+		return models.ScenarioExecution{}, false, errors.Wrap(err, "could not type the trigger object of the decision")
+	}
+
+	triggered, se, err := uc.evaluator.EvalTestRunScenario(ctx, evaluate_scenario.ScenarioEvaluationParameters{
+		Scenario:     scenario,
+		ClientObject: clientObject,
+		DataModel:    dataModel,
+		Pivots:       pivots,
+		AsOf:         &decision.CreatedAt,
+		TestRun:      &testRun,
+	})
+	return se, triggered, err
+}
+
+func (uc ScenarioBacktestUsecase) failScenarioBacktest(ctx context.Context, backtest models.ScenarioBacktest, reason string) error {
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "scenario backtest failed",
+		"backtest_id", backtest.Id, "reason", reason)
+
+	_, err := uc.endScenarioBacktest(ctx, backtest, models.ScenarioBacktestFailed, &reason)
+	return err
+}
+
+// endScenarioBacktest ends the backtest and its test run. It returns false if the backtest was
+// already over.
+func (uc ScenarioBacktestUsecase) endScenarioBacktest(
+	ctx context.Context,
+	backtest models.ScenarioBacktest,
+	status models.ScenarioBacktestStatus,
+	reason *string,
+) (bool, error) {
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(tx repositories.Transaction) (bool, error) {
+		ended, err := uc.repository.CompleteScenarioBacktest(ctx, tx, backtest.Id, status, reason)
+		if err != nil || !ended {
+			return ended, err
+		}
+		return true, uc.repository.UpdateTestRunStatus(ctx, tx, backtest.TestRunId.String(), models.Down)
+	})
+}
+
+// scenarioBacktestBatch accumulates the results of a batch of replayed decisions, in the shape of
+// the test run summaries and of the confusion matrix.
+type scenarioBacktestBatch struct {
+	failed    int64
+	decisions map[[2]string]int
+	rules     map[scenarioBacktestRuleKey]int
+	outcomes  map[[2]string]int64
+}
+
+type scenarioBacktestRuleKey struct {
+	version      string
+	stableRuleId string
+	name         string
+	outcome      string
+}
+
+func newScenarioBacktestBatch() *scenarioBacktestBatch {
+	return &scenarioBacktestBatch{
+		decisions: make(map[[2]string]int),
+		rules:     make(map[scenarioBacktestRuleKey]int),
+		outcomes:  make(map[[2]string]int64),
+	}
+}
+
+func (b *scenarioBacktestBatch) addLiveDecision(decision models.DecisionWithRuleExecutions) {
+	version := strconv.Itoa(decision.ScenarioVersion)
+	b.decisions[[2]string{version, decision.Outcome.String()}]++
+	for _, rule := range decision.RuleExecutions {
+		b.rules[scenarioBacktestRuleKey{version, rule.Rule.StableRuleId, rule.Rule.Name, rule.Outcome}]++
+	}
+}
+
+// addBacktestExecution records the execution of the draft. Its results are kept under the draft
+// version even if the draft is published while the backtest runs.
+func (b *scenarioBacktestBatch) addBacktestExecution(se models.ScenarioExecution) {
+	version := strconv.Itoa(models.ScenarioBacktestDraftVersion)
+	b.decisions[[2]string{version, se.Outcome.String()}]++
+	for _, rule := range se.RuleExecutions {
+		b.rules[scenarioBacktestRuleKey{version, rule.Rule.StableRuleId, rule.Rule.Name, rule.Outcome}]++
+	}
+}
+
+func (b *scenarioBacktestBatch) addOutcome(liveOutcome, backtestOutcome string) {
+	b.outcomes[[2]string{liveOutcome, backtestOutcome}]++
+}
+
+func (b *scenarioBacktestBatch) decisionStats() []models.DecisionsByVersionByOutcome {
+	stats := make([]models.DecisionsByVersionByOutcome, 0, len(b.decisions))
+	for key, count := range b.decisions {
+		stats = append(stats, models.DecisionsByVersionByOutcome{Version: key[0], Outcome: key[1], Count: count})
+	}
+	return stats
+}
+
+func (b *scenarioBacktestBatch) ruleStats() []models.RuleExecutionStat {
+	stats := make([]models.RuleExecutionStat, 0, len(b.rules))
+	for key, count := range b.rules {
+		stats = append(stats, models.RuleExecutionStat{
+			Version:      key.version,
+			Name:         key.name,
+			Outcome:      key.outcome,
+			StableRuleId: utils.Ptr(key.stableRuleId),
+			Total:        count,
+		})
+	}
+	return stats
+}
+
+func (b *scenarioBacktestBatch) outcomeCounts() []models.ScenarioBacktestOutcomeCount {
+	counts := make([]models.ScenarioBacktestOutcomeCount, 0, len(b.outcomes))
+	for key, total := range b.outcomes {
+		counts = append(counts, models.ScenarioBacktestOutcomeCount{
+			LiveOutcome:     key[0],
+			BacktestOutcome: key[1],
+			Total:           total,
+		})
+	}
+	models.SortScenarioBacktestOutcomeCounts(counts)
+	return counts
+}
+
+// ScenarioBacktestWorker is a River worker that runs scenario backtests, resuming them until they
+// are over.
+type ScenarioBacktestWorker struct {
+	river.WorkerDefaults[models.ScenarioBacktestArgs]
+	usecase ScenarioBacktestUsecase
+}
+
+func NewScenarioBacktestWorker(usecase ScenarioBacktestUsecase) *ScenarioBacktestWorker {
+	return &ScenarioBacktestWorker{usecase: usecase}
+}
+
+func (w *ScenarioBacktestWorker) Timeout(job *river.Job[models.ScenarioBacktestArgs]) time.Duration {
+	return 2 * scenarioBacktestTimeBudget
+}
+
+func (w *ScenarioBacktestWorker) Work(ctx context.Context, job *river.Job[models.ScenarioBacktestArgs]) error {
+	done, err := w.usecase.RunScenarioBacktest(ctx, job.Args.BacktestId)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return river.JobSnooze(scenarioBacktestSnoozeDelay)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases/evaluate_scenario"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioBacktestEvaluatorStub func(params evaluate_scenario.ScenarioEvaluationParameters) (
+	bool, models.ScenarioExecution, error)
+
+func (f scenarioBacktestEvaluatorStub) EvalTestRunScenario(
+	ctx context.Context,
+	params evaluate_scenario.ScenarioEvaluationParameters,
+) (bool, models.ScenarioExecution, error) {
+	return f(params)
+}
+
+type scenarioBacktestTestEnv struct {
+	uc         ScenarioBacktestUsecase
+	security   *mocks.EnforceSecurity
+	repository *mocks.ScenarioBacktestRepository
+	taskQueue  *mocks.TaskQueueRepository
+	scenario   models.Scenario
+	iteration  models.ScenarioIteration
+}
+
+func newScenarioBacktestTestEnv(evaluator scenarioBacktestEvaluatorStub) scenarioBacktestTestEnv {
+	executorFactory := executor_factory.NewExecutorFactoryStub()
+	dataModelRepository := new(mocks.DataModelRepository)
+	dataModelRepository.On("GetDataModel", mock.Anything, mock.Anything, mock.Anything, false, true).
+		Return(models.DataModel{Tables: map[string]models.Table{"transactions": {
+			Name: "transactions",
+			Fields: map[string]models.Field{
+				"object_id": {Name: "object_id", DataType: models.String},
+			},
+		}}}, nil)
+	dataModelRepository.On("ListPivots", mock.Anything, mock.Anything, mock.Anything, mock.Anything, true, false).
+		Return(nil, nil)
+
+	scenario := models.Scenario{
+		Id:                uuid.NewString(),
+		OrganizationId:    uuid.New(),
+		TriggerObjectType: "transactions",
+		LiveVersionID:     utils.Ptr(uuid.NewString()),
+	}
+	env := scenarioBacktestTestEnv{
+		security:   new(mocks.EnforceSecurity),
+		repository: new(mocks.ScenarioBacktestRepository),
+		taskQueue:  new(mocks.TaskQueueRepository),
+		scenario:   scenario,
+		iteration: models.ScenarioIteration{
+			Id:         uuid.NewString(),
+			ScenarioId: scenario.Id,
+		},
+	}
+	env.security.On("CreateTestRun", mock.Anything).Return(nil)
+	env.security.On("ReadOrganization", mock.Anything).Return(nil)
+	env.uc = NewScenarioBacktestUsecase(
+		executorFactory,
+		executor_factory.NewTransactionFactoryStub(executorFactory),
+		env.security,
+		env.repository,
+		dataModelRepository,
+		env.taskQueue,
+		evaluator,
+	)
+	return env
+}
+
+func (env scenarioBacktestTestEnv) createInput() models.CreateScenarioBacktestInput {
+	return models.CreateScenarioBacktestInput{
+		OrganizationId:      env.scenario.OrganizationId,
+		ScenarioId:          env.scenario.Id,
+		ScenarioIterationId: env.iteration.Id,
+		WindowStart:         time.Now().Add(-30 * 24 * time.Hour),
+		WindowEnd:           time.Now().Add(-time.Hour),
+	}
+}
+
+func TestCreateScenarioBacktest_rejects_published_iterations(t *testing.T) {
+	env := newScenarioBacktestTestEnv(nil)
+	env.iteration.Version = utils.Ptr(3)
+	env.repository.On("GetScenarioById", mock.Anything, mock.Anything, env.scenario.Id, mock.Anything).
+		Return(env.scenario, nil)
+	env.repository.On("GetScenarioIteration", mock.Anything, mock.Anything, env.iteration.Id, false).
+		Return(env.iteration, nil)
+
+	_, err := env.uc.CreateScenarioBacktest(context.Background(), env.createInput())
+
+	assert.ErrorIs(t, err, models.ErrScenarioIterationNotDraft)
+	env.repository.AssertNotCalled(t, "CreateScenarioBacktest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateScenarioBacktest_rejects_scenarios_without_live_version(t *testing.T) {
+	env := newScenarioBacktestTestEnv(nil)
+	env.scenario.LiveVersionID = nil
+	env.repository.On("GetScenarioById", mock.Anything, mock.Anything, env.scenario.Id, mock.Anything).
+		Return(env.scenario, nil)
+
+	_, err := env.uc.CreateScenarioBacktest(context.Background(), env.createInput())
+
+	assert.ErrorIs(t, err, models.ErrScenarioHasNoLiveVersion)
+}
+
+func TestCreateScenarioBacktest_creates_backtest_test_run_and_enqueues_it(t *testing.T) {
+	env := newScenarioBacktestTestEnv(nil)
+	input := env.createInput()
+	env.repository.On("GetScenarioById", mock.Anything, mock.Anything, env.scenario.Id, mock.Anything).
+		Return(env.scenario, nil)
+	env.repository.On("GetScenarioIteration", mock.Anything, mock.Anything, env.iteration.Id, false).
+		Return(env.iteration, nil)
+	env.repository.On("ListScreeningConfigs", mock.Anything, mock.Anything, env.iteration.Id, false).
+		Return([]models.ScreeningConfig{}, nil)
+	env.repository.On("CreateTestRun", mock.Anything, mock.Anything, mock.Anything,
+		mock.MatchedBy(func(in models.ScenarioTestRunCreateDbInput) bool {
+			return in.Kind == models.ScenarioTestRunBacktest &&
+				in.PhantomIterationId == env.iteration.Id &&
+				in.LiveScenarioId == *env.scenario.LiveVersionID
+		})).Return(nil)
+	created := models.ScenarioBacktest{Id: uuid.New(), Status: models.ScenarioBacktestPending}
+	env.repository.On("CreateScenarioBacktest", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(b models.ScenarioBacktest) bool {
+			return b.WindowStart.Equal(input.WindowStart) && b.WindowEnd.Equal(input.WindowEnd) &&
+				b.ScenarioIterationId.String() == env.iteration.Id
+		})).Return(created, nil)
+	env.taskQueue.On("EnqueueScenarioBacktestTask", mock.Anything, mock.Anything,
+		input.OrganizationId, created.Id).Return(nil)
+
+	backtest, err := env.uc.CreateScenarioBacktest(context.Background(), input)
+
+	require.NoError(t, err)
+	assert.Equal(t, created, backtest)
+	env.repository.AssertExpectations(t)
+	env.taskQueue.AssertExpectations(t)
+}
+
+func TestRunScenarioBacktest_replays_decisions_and_completes(t *testing.T) {
+	evaluated := []time.Time{}
+	env := newScenarioBacktestTestEnv(func(params evaluate_scenario.ScenarioEvaluationParameters) (
+		bool, models.ScenarioExecution, error,
+	) {
+		evaluated = append(evaluated, *params.AsOf)
+		if params.ClientObject.Data["object_id"] == "not-triggered" {
+			return false, models.ScenarioExecution{}, nil
+		}
+		return true, models.ScenarioExecution{Outcome: models.Decline}, nil
+	})
+	ctx := utils.StoreLoggerInContext(context.Background(), utils.NewLogger("text"))
+
+	backtest := models.ScenarioBacktest{
+		Id:                  uuid.New(),
+		OrganizationId:      env.scenario.OrganizationId,
+		ScenarioId:          uuid.MustParse(env.scenario.Id),
+		ScenarioIterationId: uuid.MustParse(env.iteration.Id),
+		TestRunId:           uuid.New(),
+		Status:              models.ScenarioBacktestPending,
+	}
+	testRun := models.ScenarioTestRun{Id: backtest.TestRunId.String(), Kind: models.ScenarioTestRunBacktest}
+	decision := func(objectId string, createdAt time.Time) models.DecisionWithRuleExecutions {
+		return models.DecisionWithRuleExecutions{Decision: models.Decision{
+			DecisionId:      uuid.New(),
+			CreatedAt:       createdAt,
+			Outcome:         models.Approve,
+			ScenarioVersion: 2,
+			ClientObject: models.ClientObject{
+				TableName: "transactions",
+				Data:      map[string]any{"object_id": objectId},
+			},
+		}}
+	}
+	first := decision("triggered", time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC))
+	last := decision("not-triggered", time.Date(2026, 9, 2, 10, 0, 0, 0, time.UTC))
+
+	env.repository.On("GetScenarioBacktest", mock.Anything, mock.Anything, backtest.Id).Return(backtest, nil)
+	env.repository.On("GetTestRunByID", mock.Anything, mock.Anything, testRun.Id).Return(testRun, nil)
+	env.repository.On("GetScenarioById", mock.Anything, mock.Anything, env.scenario.Id, mock.Anything).
+		Return(env.scenario, nil)
+	env.repository.On("ListScreeningConfigs", mock.Anything, mock.Anything, env.iteration.Id, false).
+		Return([]models.ScreeningConfig{}, nil)
+	env.repository.On("ListScenarioBacktestDecisions", mock.Anything, mock.Anything, backtest,
+		scenarioBacktestBatchSize).Return([]models.DecisionWithRuleExecutions{first, last}, nil)
+	env.repository.On("UpdateScenarioBacktestProgress", mock.Anything, mock.Anything, backtest.Id,
+		last.CreatedAt, last.DecisionId, int64(2), int64(0)).Return(true, nil)
+	env.repository.On("StorePhantomDecision", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(d models.PhantomDecision) bool { return d.CreatedAt.Equal(first.CreatedAt) }),
+		backtest.OrganizationId, testRun.Id, mock.Anything, 0).Return(nil)
+	env.repository.On("SaveTestRunDecisionSummary", mock.Anything, mock.Anything, testRun.Id,
+		mock.Anything, mock.Anything).Return(nil)
+	env.repository.On("SaveScenarioBacktestOutcomes", mock.Anything, mock.Anything, backtest.Id,
+		[]models.ScenarioBacktestOutcomeCount{
+			{LiveOutcome: "approve", BacktestOutcome: models.ScenarioBacktestOutcomeNotTriggered, Total: 1},
+			{LiveOutcome: "approve", BacktestOutcome: "decline", Total: 1},
+		}).Return(nil)
+	env.repository.On("CompleteScenarioBacktest", mock.Anything, mock.Anything, backtest.Id,
+		models.ScenarioBacktestCompleted, (*string)(nil)).Return(true, nil)
+	env.repository.On("UpdateTestRunStatus", mock.Anything, mock.Anything, testRun.Id, models.Down).Return(nil)
+
+	done, err := env.uc.RunScenarioBacktest(ctx, backtest.Id)
+
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, []time.Time{first.CreatedAt, last.CreatedAt}, evaluated)
+	env.repository.AssertNumberOfCalls(t, "StorePhantomDecision", 1)
+	env.repository.AssertCalled(t, "SaveTestRunDecisionSummary", mock.Anything, mock.Anything, testRun.Id,
+		models.DecisionsByVersionByOutcome{Version: "2", Outcome: "approve", Count: 2}, mock.Anything)
+	env.repository.AssertCalled(t, "SaveTestRunDecisionSummary", mock.Anything, mock.Anything, testRun.Id,
+		models.DecisionsByVersionByOutcome{Version: "0", Outcome: "decline", Count: 1}, mock.Anything)
+	env.repository.AssertExpectations(t)
+}
+
+func TestRunScenarioBacktest_stops_when_cancelled(t *testing.T) {
+	env := newScenarioBacktestTestEnv(func(params evaluate_scenario.ScenarioEvaluationParameters) (
+		bool, models.ScenarioExecution, error,
+	) {
+		return false, models.ScenarioExecution{}, nil
+	})
+	ctx := utils.StoreLoggerInContext(context.Background(), utils.NewLogger("text"))
+
+	backtest := models.ScenarioBacktest{
+		Id:                  uuid.New(),
+		OrganizationId:      env.scenario.OrganizationId,
+		ScenarioId:          uuid.MustParse(env.scenario.Id),
+		ScenarioIterationId: uuid.MustParse(env.iteration.Id),
+		TestRunId:           uuid.New(),
+		Status:              models.ScenarioBacktestRunning,
+	}
+	decision := models.DecisionWithRuleExecutions{Decision: models.Decision{
+		DecisionId:   uuid.New(),
+		CreatedAt:    time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC),
+		ClientObject: models.ClientObject{TableName: "transactions", Data: map[string]any{"object_id": "1"}},
+	}}
+
+	env.repository.On("GetScenarioBacktest", mock.Anything, mock.Anything, backtest.Id).Return(backtest, nil)
+	env.repository.On("GetTestRunByID", mock.Anything, mock.Anything, backtest.TestRunId.String()).
+		Return(models.ScenarioTestRun{Id: backtest.TestRunId.String()}, nil)
+	env.repository.On("GetScenarioById", mock.Anything, mock.Anything, env.scenario.Id, mock.Anything).
+		Return(env.scenario, nil)
+	env.repository.On("ListScreeningConfigs", mock.Anything, mock.Anything, env.iteration.Id, false).
+		Return([]models.ScreeningConfig{}, nil)
+	env.repository.On("ListScenarioBacktestDecisions", mock.Anything, mock.Anything, backtest,
+		scenarioBacktestBatchSize).Return([]models.DecisionWithRuleExecutions{decision}, nil)
+	env.repository.On("UpdateScenarioBacktestProgress", mock.Anything, mock.Anything, backtest.Id,
+		decision.CreatedAt, decision.DecisionId, int64(1), int64(0)).Return(false, nil)
+
+	done, err := env.uc.RunScenarioBacktest(ctx, backtest.Id)
+
+	require.NoError(t, err)
+	assert.True(t, done)
+	env.repository.AssertNotCalled(t, "SaveScenarioBacktestOutcomes", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+	env.repository.AssertNotCalled(t, "CompleteScenarioBacktest", mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRunScenarioBacktest_stops_at_the_max_decisions(t *testing.T) {
+	env := newScenarioBacktestTestEnv(func(params evaluate_scenario.ScenarioEvaluationParameters) (
+		bool, models.ScenarioExecution, error,
+	) {
+		return false, models.ScenarioExecution{}, nil
+	})
+	ctx := utils.StoreLoggerInContext(context.Background(), utils.NewLogger("text"))
+
+	backtest := models.ScenarioBacktest{
+		Id:                  uuid.New(),
+		OrganizationId:      env.scenario.OrganizationId,
+		ScenarioId:          uuid.MustParse(env.scenario.Id),
+		ScenarioIterationId: uuid.MustParse(env.iteration.Id),
+		TestRunId:           uuid.New(),
+		Status:              models.ScenarioBacktestRunning,
+		DecisionsEvaluated:  models.ScenarioBacktestMaxDecisions - 2,
+		DecisionsFailed:     1,
+	}
+	testRun := models.ScenarioTestRun{Id: backtest.TestRunId.String(), Kind: models.ScenarioTestRunBacktest}
+	decision := models.DecisionWithRuleExecutions{Decision: models.Decision{
+		DecisionId:   uuid.New(),
+		CreatedAt:    time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC),
+		Outcome:      models.Approve,
+		ClientObject: models.ClientObject{TableName: "transactions", Data: map[string]any{"object_id": "1"}},
+	}}
+
+	env.repository.On("GetScenarioBacktest", mock.Anything, mock.Anything, backtest.Id).Return(backtest, nil)
+	env.repository.On("GetTestRunByID", mock.Anything, mock.Anything, testRun.Id).Return(testRun, nil)
+	env.repository.On("GetScenarioById", mock.Anything, mock.Anything, env.scenario.Id, mock.Anything).
+		Return(env.scenario, nil)
+	env.repository.On("ListScreeningConfigs", mock.Anything, mock.Anything, env.iteration.Id, false).
+		Return([]models.ScreeningConfig{}, nil)
+	env.repository.On("ListScenarioBacktestDecisions", mock.Anything, mock.Anything, backtest, 1).
+		Return([]models.DecisionWithRuleExecutions{decision}, nil).Once()
+	env.repository.On("UpdateScenarioBacktestProgress", mock.Anything, mock.Anything, backtest.Id,
+		decision.CreatedAt, decision.DecisionId, int64(1), int64(0)).Return(true, nil)
+	env.repository.On("SaveTestRunDecisionSummary", mock.Anything, mock.Anything, testRun.Id,
+		mock.Anything, mock.Anything).Return(nil)
+	env.repository.On("SaveScenarioBacktestOutcomes", mock.Anything, mock.Anything, backtest.Id,
+		mock.Anything).Return(nil)
+	env.repository.On("CompleteScenarioBacktest", mock.Anything, mock.Anything, backtest.Id,
+		models.ScenarioBacktestCompleted, (*string)(nil)).Return(true, nil)
+	env.repository.On("UpdateTestRunStatus", mock.Anything, mock.Anything, testRun.Id, models.Down).Return(nil)
+
+	done, err := env.uc.RunScenarioBacktest(ctx, backtest.Id)
+
+	require.NoError(t, err)
+	assert.True(t, done)
+	env.repository.AssertNumberOfCalls(t, "ListScenarioBacktestDecisions", 1)
+	env.repository.AssertExpectations(t)
+}
@@ -352,6 +352,8 @@ func (usecase *ScenarioIterationUsecase) CreateDraftFromScenarioIteration(
 					RuleGroup:            rule.RuleGroup,
 					SnoozeGroupId:        rule.SnoozeGroupId,
 					StableRuleId:         rule.StableRuleId,
+					ThrottlePeriod:       rule.ThrottlePeriod,
+					ThrottleScope:        rule.ThrottleScope,
 				}
 			}
 
@@ -404,6 +406,34 @@ func (usecase *ScenarioIterationUsecase) CreateDraftFromScenarioIteration(
 	return newScenarioIteration, nil
 }
 
+// DiffScenarioIterations compares two iterations of the same scenario, from the base to the target.
+func (usecase *ScenarioIterationUsecase) DiffScenarioIterations(ctx context.Context,
+	baseIterationId, targetIterationId string,
+) (models.ScenarioIterationDiff, error) {
+	exec := usecase.executorFactory.NewExecutor()
+
+	base, err := usecase.scenarioFetcher.FetchScenarioAndIteration(ctx, exec, baseIterationId)
+	if err != nil {
+		return models.ScenarioIterationDiff{}, err
+	}
+	target, err := usecase.scenarioFetcher.FetchScenarioAndIteration(ctx, exec, targetIterationId)
+	if err != nil {
+		return models.ScenarioIterationDiff{}, err
+	}
+
+	for _, si := range []models.ScenarioIteration{base.Iteration, target.Iteration} {
+		if err := usecase.enforceSecurity.ReadScenarioIteration(si.ToMetadata()); err != nil {
+			return models.ScenarioIterationDiff{}, err
+		}
+	}
+	if base.Iteration.ScenarioId != target.Iteration.ScenarioId {
+		return models.ScenarioIterationDiff{}, errors.Wrap(models.BadParameterError,
+			"cannot diff iterations of different scenarios")
+	}
+
+	return models.DiffScenarioIterations(base.Iteration, target.Iteration), nil
+}
+
 // Return a validation by running the scenario using fake data
 // If `triggerOrRuleToReplace` is provided, it is used during the validation.
 // If `replaceRuleId` is provided, the corresponding rule is replaced.
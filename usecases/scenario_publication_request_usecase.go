@@ -0,0 +1,365 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+// How long an approved publication request waits for the indexes of its iteration to be created
+const scenarioPublicationRequestSnoozeDelay = 1 * time.Minute
+
+type scenarioPublicationRequestRepository interface {
+	CreateScenarioPublicationRequest(ctx context.Context, exec repositories.Executor,
+		request models.ScenarioPublicationRequest) (models.ScenarioPublicationRequest, error)
+	GetScenarioPublicationRequest(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		forUpdate bool) (models.ScenarioPublicationRequest, error)
+	ListScenarioPublicationRequests(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		filters models.ListScenarioPublicationRequestsFilters) ([]models.ScenarioPublicationRequest, error)
+	UpdateScenarioPublicationRequest(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		input models.UpdateScenarioPublicationRequestInput) (models.ScenarioPublicationRequest, error)
+}
+
+// ScenarioPublicationRequestUsecase implements the four-eyes publication of scenario iterations: a
+// builder submits a publication request, and a publisher other than the requester reviews it. The
+// approved publication is executed in the background, once the indexes it needs are created.
+type ScenarioPublicationRequestUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityScenario
+
+	repository             scenarioPublicationRequestRepository
+	organizationRepository ScreeningOrganizationRepository
+	scenarioFetcher        ScenarioFetcher
+	publicationUsecase     *ScenarioPublicationUsecase
+	taskQueueRepository    repositories.TaskQueueRepository
+	webhookEventsSender    webhookEventsUsecase
+}
+
+func NewScenarioPublicationRequestUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityScenario,
+	repository scenarioPublicationRequestRepository,
+	organizationRepository ScreeningOrganizationRepository,
+	scenarioFetcher ScenarioFetcher,
+	publicationUsecase *ScenarioPublicationUsecase,
+	taskQueueRepository repositories.TaskQueueRepository,
+	webhookEventsSender webhookEventsUsecase,
+) ScenarioPublicationRequestUsecase {
+	return ScenarioPublicationRequestUsecase{
+		executorFactory:        executorFactory,
+		transactionFactory:     transactionFactory,
+		enforceSecurity:        enforceSecurity,
+		repository:             repository,
+		organizationRepository: organizationRepository,
+		scenarioFetcher:        scenarioFetcher,
+		publicationUsecase:     publicationUsecase,
+		taskQueueRepository:    taskQueueRepository,
+		webhookEventsSender:    webhookEventsSender,
+	}
+}
+
+func (uc ScenarioPublicationRequestUsecase) ListScenarioPublicationRequests(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	filters models.ListScenarioPublicationRequestsFilters,
+) ([]models.ScenarioPublicationRequest, error) {
+	if err := uc.enforceSecurity.ListScenarios(organizationId); err != nil {
+		return nil, err
+	}
+
+	return uc.repository.ListScenarioPublicationRequests(ctx,
+		uc.executorFactory.NewExecutor(), organizationId, filters)
+}
+
+func (uc ScenarioPublicationRequestUsecase) GetScenarioPublicationRequest(
+	ctx context.Context,
+	id uuid.UUID,
+) (models.ScenarioPublicationRequest, error) {
+	request, err := uc.repository.GetScenarioPublicationRequest(ctx, uc.executorFactory.NewExecutor(), id, false)
+	if err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+	if err := uc.enforceSecurity.ListScenarios(request.OrganizationId); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+	return request, nil
+}
+
+// CreateScenarioPublicationRequest submits a publication action for review, along with the diff it
+// makes against the live iteration of the scenario.
+func (uc ScenarioPublicationRequestUsecase) CreateScenarioPublicationRequest(
+	ctx context.Context,
+	input models.CreateScenarioPublicationRequestInput,
+) (models.ScenarioPublicationRequest, error) {
+	userId := uc.enforceSecurity.UserId()
+	if userId == nil {
+		return models.ScenarioPublicationRequest{}, errors.Wrap(models.ForbiddenError,
+			"a publication request can only be submitted by a user")
+	}
+	if err := input.Validate(); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	scenarioAndIteration, err := uc.scenarioFetcher.FetchScenarioAndIteration(ctx,
+		uc.executorFactory.NewExecutor(), input.ScenarioIterationId)
+	if err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+	if err := uc.enforceSecurity.UpdateScenario(scenarioAndIteration.Scenario); err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+	if scenarioAndIteration.Iteration.Version == nil {
+		return models.ScenarioPublicationRequest{}, models.ErrScenarioIterationIsDraft
+	}
+
+	diff, err := uc.publicationUsecase.GetPublicationDiff(ctx, input.ScenarioIterationId)
+	if err != nil {
+		return models.ScenarioPublicationRequest{}, err
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ScenarioPublicationRequest, error) {
+		request, err := uc.repository.CreateScenarioPublicationRequest(ctx, tx, models.ScenarioPublicationRequest{
+			Id:                  pure_utils.NewId(),
+			OrganizationId:      scenarioAndIteration.Scenario.OrganizationId,
+			ScenarioId:          scenarioAndIteration.Scenario.Id,
+			ScenarioIterationId: scenarioAndIteration.Iteration.Id,
+			PublicationAction:   input.PublicationAction,
+			CanaryPercentage:    input.CanaryPercentage,
+			Comment:             input.Comment,
+			Diff:                diff,
+			RequestedBy:         models.UserId(*userId),
+		})
+		if err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+
+		if err := uc.sendWebhookEvent(ctx, tx, request); err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		return request, nil
+	})
+}
+
+// ReviewScenarioPublicationRequest approves or rejects a pending request. The reviewer must be a
+// publisher, and not the requester. Approved publications are executed in the background, or by
+// their schedule for scheduled publications.
+func (uc ScenarioPublicationRequestUsecase) ReviewScenarioPublicationRequest(
+	ctx context.Context,
+	input models.ReviewScenarioPublicationRequestInput,
+) (models.ScenarioPublicationRequest, error) {
+	userId := uc.enforceSecurity.UserId()
+	if userId == nil {
+		return models.ScenarioPublicationRequest{}, errors.Wrap(models.ForbiddenError,
+			"a publication request can only be reviewed by a user")
+	}
+	reviewer := models.UserId(*userId)
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ScenarioPublicationRequest, error) {
+		request, err := uc.repository.GetScenarioPublicationRequest(ctx, tx, input.Id, true)
+		if err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		scenarioAndIteration, err := uc.scenarioFetcher.FetchScenarioAndIteration(ctx, tx, request.ScenarioIterationId)
+		if err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		if err := uc.enforceSecurity.PublishScenario(scenarioAndIteration.Scenario); err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		if err := request.CanBeReviewedBy(reviewer); err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+
+		status := models.ScenarioPublicationRequestRejected
+		if input.Approved {
+			status = models.ScenarioPublicationRequestApproved
+		}
+		request, err = uc.repository.UpdateScenarioPublicationRequest(ctx, tx, request.Id,
+			models.UpdateScenarioPublicationRequestInput{
+				Status:        status,
+				ReviewedBy:    &reviewer,
+				ReviewComment: input.Comment,
+			})
+		if err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+
+		// A scheduled publication is executed by its schedule when it is due
+		if input.Approved && request.ScheduledAt == nil {
+			if err := uc.taskQueueRepository.EnqueueScenarioPublicationRequestTask(ctx, tx,
+				request.OrganizationId, request.Id); err != nil {
+				return models.ScenarioPublicationRequest{}, err
+			}
+		}
+
+		if err := uc.sendWebhookEvent(ctx, tx, request); err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		return request, nil
+	})
+}
+
+// CancelScenarioPublicationRequest withdraws a pending request. Only its requester can cancel it.
+func (uc ScenarioPublicationRequestUsecase) CancelScenarioPublicationRequest(
+	ctx context.Context,
+	id uuid.UUID,
+) (models.ScenarioPublicationRequest, error) {
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ScenarioPublicationRequest, error) {
+		request, err := uc.repository.GetScenarioPublicationRequest(ctx, tx, id, true)
+		if err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		if err := uc.enforceSecurity.ReadOrganization(request.OrganizationId); err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		if userId := uc.enforceSecurity.UserId(); userId == nil || models.UserId(*userId) != request.RequestedBy {
+			return models.ScenarioPublicationRequest{}, errors.Wrap(models.ForbiddenError,
+				"a publication request can only be cancelled by its requester")
+		}
+		if request.Status != models.ScenarioPublicationRequestPending {
+			return models.ScenarioPublicationRequest{}, errors.Wrapf(
+				models.ErrScenarioPublicationRequestNotPending, "the request is %s", request.Status)
+		}
+
+		request, err = uc.repository.UpdateScenarioPublicationRequest(ctx, tx, request.Id,
+			models.UpdateScenarioPublicationRequestInput{Status: models.ScenarioPublicationRequestCancelled})
+		if err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+
+		if err := uc.sendWebhookEvent(ctx, tx, request); err != nil {
+			return models.ScenarioPublicationRequest{}, err
+		}
+		return request, nil
+	})
+}
+
+// ExecuteApprovedPublicationRequest prepares the indexes needed by the iteration of an approved
+// request, then executes its publication action. It returns false while the indexes are not ready.
+// A publication action that cannot be executed, for instance because the live iteration changed in
+// the meantime, fails the request.
+func (uc ScenarioPublicationRequestUsecase) ExecuteApprovedPublicationRequest(
+	ctx context.Context,
+	id uuid.UUID,
+) (bool, error) {
+	request, err := uc.repository.GetScenarioPublicationRequest(ctx, uc.executorFactory.NewExecutor(), id, false)
+	if err != nil {
+		return false, err
+	}
+	if request.Status != models.ScenarioPublicationRequestApproved || request.ScheduledAt != nil {
+		return true, nil
+	}
+
+	ready, err := uc.publicationUsecase.prepareIterationIndexes(ctx,
+		request.OrganizationId, request.ScenarioIterationId)
+	if err != nil {
+		return false, err
+	}
+	if !ready {
+		return false, nil
+	}
+
+	err = uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		request, err := uc.repository.GetScenarioPublicationRequest(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+		if request.Status != models.ScenarioPublicationRequestApproved {
+			return nil
+		}
+		org, err := uc.organizationRepository.GetOrganizationById(ctx, tx, request.OrganizationId)
+		if err != nil {
+			return err
+		}
+
+		if _, err := uc.publicationUsecase.executePublicationAction(ctx, tx, org,
+			models.PublishScenarioIterationInput{
+				ScenarioIterationId: request.ScenarioIterationId,
+				PublicationAction:   request.PublicationAction,
+				CanaryPercentage:    request.CanaryPercentage,
+			}); err != nil {
+			return err
+		}
+
+		request, err = uc.repository.UpdateScenarioPublicationRequest(ctx, tx, request.Id,
+			models.UpdateScenarioPublicationRequestInput{Status: models.ScenarioPublicationRequestPublished})
+		if err != nil {
+			return err
+		}
+		return uc.sendWebhookEvent(ctx, tx, request)
+	})
+	if err == nil {
+		return true, nil
+	}
+	if !isPermanentPublicationError(err) {
+		return false, err
+	}
+
+	requestError := err.Error()
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "approved publication request could not be executed",
+		"request_id", id.String(), "error", requestError)
+
+	return true, uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		request, err := uc.repository.UpdateScenarioPublicationRequest(ctx, tx, id,
+			models.UpdateScenarioPublicationRequestInput{
+				Status: models.ScenarioPublicationRequestFailed,
+				Error:  &requestError,
+			})
+		if err != nil {
+			return err
+		}
+		return uc.sendWebhookEvent(ctx, tx, request)
+	})
+}
+
+func (uc ScenarioPublicationRequestUsecase) sendWebhookEvent(
+	ctx context.Context,
+	tx repositories.Transaction,
+	request models.ScenarioPublicationRequest,
+) error {
+	return uc.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+		OrganizationId: request.OrganizationId,
+		EventContent:   models.NewWebhookEventPublicationRequest(request),
+	})
+}
+
+// ScenarioPublicationRequestWorker is a River worker that executes approved publication requests,
+// waiting for the indexes of their iteration to be created first.
+type ScenarioPublicationRequestWorker struct {
+	river.WorkerDefaults[models.ScenarioPublicationRequestArgs]
+	usecase ScenarioPublicationRequestUsecase
+}
+
+func NewScenarioPublicationRequestWorker(usecase ScenarioPublicationRequestUsecase) *ScenarioPublicationRequestWorker {
+	return &ScenarioPublicationRequestWorker{usecase: usecase}
+}
+
+func (w *ScenarioPublicationRequestWorker) Work(ctx context.Context,
+	job *river.Job[models.ScenarioPublicationRequestArgs],
+) error {
+	done, err := w.usecase.ExecuteApprovedPublicationRequest(ctx, job.Args.RequestId)
+	if err != nil {
+		return err
+	}
+	if !done {
+		return river.JobSnooze(scenarioPublicationRequestSnoozeDelay)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioPublicationRequestTestEnv struct {
+	uc          ScenarioPublicationRequestUsecase
+	security    *mocks.EnforceSecurity
+	repository  *mocks.ScenarioPublicationRequestRepository
+	fetcher     *mocks.ScenarioFetcher
+	taskQueue   *mocks.TaskQueueRepository
+	indexEditor *mocks.ClientDbIndexEditor
+	webhooks    *mocks.WebhookEventsUsecase
+	scenario    models.Scenario
+	request     models.ScenarioPublicationRequest
+}
+
+func newScenarioPublicationRequestTestEnv() scenarioPublicationRequestTestEnv {
+	executorFactory := executor_factory.NewExecutorFactoryStub()
+	transactionFactory := executor_factory.NewTransactionFactoryStub(executorFactory)
+
+	scenario := models.Scenario{Id: uuid.NewString(), OrganizationId: uuid.New()}
+	env := scenarioPublicationRequestTestEnv{
+		security:    new(mocks.EnforceSecurity),
+		repository:  new(mocks.ScenarioPublicationRequestRepository),
+		fetcher:     new(mocks.ScenarioFetcher),
+		taskQueue:   new(mocks.TaskQueueRepository),
+		indexEditor: new(mocks.ClientDbIndexEditor),
+		webhooks:    new(mocks.WebhookEventsUsecase),
+		scenario:    scenario,
+		request: models.ScenarioPublicationRequest{
+			Id:                  uuid.New(),
+			OrganizationId:      scenario.OrganizationId,
+			ScenarioId:          scenario.Id,
+			ScenarioIterationId: uuid.NewString(),
+			PublicationAction:   models.Publish,
+			Status:              models.ScenarioPublicationRequestPending,
+			RequestedBy:         "builder",
+		},
+	}
+	publicationUsecase := NewScenarioPublicationUsecase(transactionFactory, executorFactory, nil,
+		env.taskQueue, env.security, env.fetcher, nil, env.indexEditor, nil, nil, nil, nil, nil)
+	env.uc = NewScenarioPublicationRequestUsecase(
+		executorFactory,
+		transactionFactory,
+		env.security,
+		env.repository,
+		nil,
+		env.fetcher,
+		publicationUsecase,
+		env.taskQueue,
+		env.webhooks,
+	)
+	return env
+}
+
+func TestReviewScenarioPublicationRequest_refuses_the_requester(t *testing.T) {
+	env := newScenarioPublicationRequestTestEnv()
+	env.security.On("UserId").Return(utils.Ptr("builder"))
+	env.security.On("PublishScenario", env.scenario).Return(nil)
+	env.repository.On("GetScenarioPublicationRequest", mock.Anything, mock.Anything, env.request.Id, true).
+		Return(env.request, nil)
+	env.fetcher.On("FetchScenarioAndIteration", mock.Anything, mock.Anything, env.request.ScenarioIterationId).
+		Return(models.ScenarioAndIteration{Scenario: env.scenario}, nil)
+
+	_, err := env.uc.ReviewScenarioPublicationRequest(context.Background(),
+		models.ReviewScenarioPublicationRequestInput{Id: env.request.Id, Approved: true})
+
+	require.ErrorIs(t, err, models.ForbiddenError)
+	env.repository.AssertNotCalled(t, "UpdateScenarioPublicationRequest",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	env.taskQueue.AssertNotCalled(t, "EnqueueScenarioPublicationRequestTask",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReviewScenarioPublicationRequest_approve(t *testing.T) {
+	env := newScenarioPublicationRequestTestEnv()
+	approver := models.UserId("publisher")
+	approved := env.request
+	approved.Status = models.ScenarioPublicationRequestApproved
+	approved.ReviewedBy = &approver
+
+	env.security.On("UserId").Return(utils.Ptr(string(approver)))
+	env.security.On("PublishScenario", env.scenario).Return(nil)
+	env.repository.On("GetScenarioPublicationRequest", mock.Anything, mock.Anything, env.request.Id, true).
+		Return(env.request, nil)
+	env.fetcher.On("FetchScenarioAndIteration", mock.Anything, mock.Anything, env.request.ScenarioIterationId).
+		Return(models.ScenarioAndIteration{Scenario: env.scenario}, nil)
+	env.repository.On("UpdateScenarioPublicationRequest", mock.Anything, mock.Anything, env.request.Id,
+		models.UpdateScenarioPublicationRequestInput{
+			Status:     models.ScenarioPublicationRequestApproved,
+			ReviewedBy: &approver,
+		}).Return(approved, nil)
+	env.taskQueue.On("EnqueueScenarioPublicationRequestTask", mock.Anything, mock.Anything,
+		env.request.OrganizationId, env.request.Id).Return(nil)
+	env.webhooks.On("CreateWebhookEvent", mock.Anything, mock.Anything, mock.MatchedBy(
+		func(input models.WebhookEventCreate) bool {
+			return input.EventContent.Type == models.WebhookEventType_PublicationRequestApproved
+		})).Return(nil)
+
+	request, err := env.uc.ReviewScenarioPublicationRequest(context.Background(),
+		models.ReviewScenarioPublicationRequestInput{Id: env.request.Id, Approved: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.ScenarioPublicationRequestApproved, request.Status)
+	env.repository.AssertExpectations(t)
+	env.taskQueue.AssertExpectations(t)
+	env.webhooks.AssertExpectations(t)
+}
+
+func TestExecuteApprovedPublicationRequest_waits_for_indexes(t *testing.T) {
+	env := newScenarioPublicationRequestTestEnv()
+	env.request.Status = models.ScenarioPublicationRequestApproved
+	env.repository.On("GetScenarioPublicationRequest", mock.Anything, mock.Anything, env.request.Id, false).
+		Return(env.request, nil)
+	env.indexEditor.On("GetIndexesToCreate", mock.Anything, env.request.OrganizationId,
+		env.request.ScenarioIterationId).Return([]models.ConcreteIndex{{TableName: "transactions"}}, 1, nil)
+
+	done, err := env.uc.ExecuteApprovedPublicationRequest(context.Background(), env.request.Id)
+
+	require.NoError(t, err)
+	assert.False(t, done)
+	env.fetcher.AssertNotCalled(t, "FetchScenarioAndIteration", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteApprovedPublicationRequest_skips_closed_requests(t *testing.T) {
+	env := newScenarioPublicationRequestTestEnv()
+	env.request.Status = models.ScenarioPublicationRequestRejected
+	env.repository.On("GetScenarioPublicationRequest", mock.Anything, mock.Anything, env.request.Id, false).
+		Return(env.request, nil)
+
+	done, err := env.uc.ExecuteApprovedPublicationRequest(context.Background(), env.request.Id)
+
+	require.NoError(t, err)
+	assert.True(t, done)
+	env.indexEditor.AssertNotCalled(t, "GetIndexesToCreate", mock.Anything, mock.Anything, mock.Anything)
+}
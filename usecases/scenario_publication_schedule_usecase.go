@@ -0,0 +1,419 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/pure_utils"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/security"
+	"github.com/checkmarble/marble-backend/usecases/worker_jobs"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const SCENARIO_PUBLICATION_SCHEDULE_INTERVAL = 1 * time.Minute
+
+type ScenarioPublicationScheduleRepository interface {
+	CreateScenarioPublicationSchedule(ctx context.Context, exec repositories.Executor,
+		schedule models.ScenarioPublicationSchedule) (models.ScenarioPublicationSchedule, error)
+	GetScenarioPublicationSchedule(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		forUpdate bool) (models.ScenarioPublicationSchedule, error)
+	ListScenarioPublicationSchedules(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		filters models.ListScenarioPublicationsFilters, statuses []models.ScenarioPublicationScheduleStatus,
+	) ([]models.ScenarioPublicationSchedule, error)
+	UpdateScenarioPublicationSchedule(ctx context.Context, exec repositories.Executor, id uuid.UUID,
+		input models.UpdateScenarioPublicationScheduleInput) (models.ScenarioPublicationSchedule, error)
+}
+
+// ScenarioPublicationScheduleUsecase executes publish and unpublish actions at a given time, for
+// instance to run a dedicated iteration over a weekend. A periodic job prepares the indexes of the
+// scheduled publications ahead of time and executes the schedules when they are due. When the
+// organization requires approval, scheduling a publication submits a publication request, which
+// must be approved before the schedule is due.
+type ScenarioPublicationScheduleUsecase struct {
+	executorFactory    executor_factory.ExecutorFactory
+	transactionFactory executor_factory.TransactionFactory
+	enforceSecurity    security.EnforceSecurityScenario
+
+	repository             ScenarioPublicationScheduleRepository
+	requestRepository      scenarioPublicationRequestRepository
+	organizationRepository ScreeningOrganizationRepository
+	scenarioFetcher        ScenarioFetcher
+	publicationUsecase     *ScenarioPublicationUsecase
+	webhookEventsSender    webhookEventsUsecase
+}
+
+func NewScenarioPublicationScheduleUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	enforceSecurity security.EnforceSecurityScenario,
+	repository ScenarioPublicationScheduleRepository,
+	requestRepository scenarioPublicationRequestRepository,
+	organizationRepository ScreeningOrganizationRepository,
+	scenarioFetcher ScenarioFetcher,
+	publicationUsecase *ScenarioPublicationUsecase,
+	webhookEventsSender webhookEventsUsecase,
+) ScenarioPublicationScheduleUsecase {
+	return ScenarioPublicationScheduleUsecase{
+		executorFactory:        executorFactory,
+		transactionFactory:     transactionFactory,
+		enforceSecurity:        enforceSecurity,
+		repository:             repository,
+		requestRepository:      requestRepository,
+		organizationRepository: organizationRepository,
+		scenarioFetcher:        scenarioFetcher,
+		publicationUsecase:     publicationUsecase,
+		webhookEventsSender:    webhookEventsSender,
+	}
+}
+
+func (uc ScenarioPublicationScheduleUsecase) CreateScenarioPublicationSchedule(
+	ctx context.Context,
+	input models.CreateScenarioPublicationScheduleInput,
+) (models.ScenarioPublicationSchedule, error) {
+	if err := input.Validate(time.Now()); err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+	exec := uc.executorFactory.NewExecutor()
+
+	scenarioAndIteration, err := uc.scenarioFetcher.FetchScenarioAndIteration(ctx, exec, input.ScenarioIterationId)
+	if err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+	if err := uc.enforceSecurity.PublishScenario(scenarioAndIteration.Scenario); err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+	if scenarioAndIteration.Iteration.Version == nil {
+		return models.ScenarioPublicationSchedule{}, models.ErrScenarioIterationIsDraft
+	}
+
+	org, err := uc.organizationRepository.GetOrganizationById(ctx, exec, scenarioAndIteration.Scenario.OrganizationId)
+	if err != nil {
+		return models.ScenarioPublicationSchedule{}, err
+	}
+	requiresApproval := org.RequirePublicationApproval && input.PublicationAction.RequiresApproval()
+
+	var createdBy *models.UserId
+	if userId := uc.enforceSecurity.UserId(); userId != nil {
+		createdBy = utils.Ptr(models.UserId(*userId))
+	}
+	if requiresApproval && createdBy == nil {
+		return models.ScenarioPublicationSchedule{}, errors.Wrap(models.ForbiddenError,
+			"a publication that requires approval can only be scheduled by a user")
+	}
+
+	var diff models.ScenarioIterationDiff
+	if requiresApproval {
+		diff, err = uc.publicationUsecase.GetPublicationDiff(ctx, input.ScenarioIterationId)
+		if err != nil {
+			return models.ScenarioPublicationSchedule{}, err
+		}
+	}
+
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ScenarioPublicationSchedule, error) {
+		schedule := models.ScenarioPublicationSchedule{
+			Id:                  pure_utils.NewId(),
+			OrganizationId:      scenarioAndIteration.Scenario.OrganizationId,
+			ScenarioId:          scenarioAndIteration.Scenario.Id,
+			ScenarioIterationId: scenarioAndIteration.Iteration.Id,
+			PublicationAction:   input.PublicationAction,
+			ScheduledAt:         input.ScheduledAt,
+			CreatedBy:           createdBy,
+		}
+
+		if requiresApproval {
+			request, err := uc.requestRepository.CreateScenarioPublicationRequest(ctx, tx,
+				models.ScenarioPublicationRequest{
+					Id:                  pure_utils.NewId(),
+					OrganizationId:      schedule.OrganizationId,
+					ScenarioId:          schedule.ScenarioId,
+					ScenarioIterationId: schedule.ScenarioIterationId,
+					PublicationAction:   schedule.PublicationAction,
+					Comment:             input.Comment,
+					Diff:                diff,
+					RequestedBy:         *createdBy,
+					ScheduledAt:         &schedule.ScheduledAt,
+				})
+			if err != nil {
+				return models.ScenarioPublicationSchedule{}, err
+			}
+			if err := uc.sendPublicationRequestWebhookEvent(ctx, tx, request); err != nil {
+				return models.ScenarioPublicationSchedule{}, err
+			}
+			schedule.PublicationRequestId = &request.Id
+		}
+
+		return uc.repository.CreateScenarioPublicationSchedule(ctx, tx, schedule)
+	})
+}
+
+func (uc ScenarioPublicationScheduleUsecase) CancelScenarioPublicationSchedule(
+	ctx context.Context,
+	id uuid.UUID,
+) (models.ScenarioPublicationSchedule, error) {
+	return executor_factory.TransactionReturnValue(ctx, uc.transactionFactory, func(
+		tx repositories.Transaction,
+	) (models.ScenarioPublicationSchedule, error) {
+		schedule, err := uc.repository.GetScenarioPublicationSchedule(ctx, tx, id, true)
+		if err != nil {
+			return models.ScenarioPublicationSchedule{}, err
+		}
+		scenarioAndIteration, err := uc.scenarioFetcher.FetchScenarioAndIteration(ctx, tx, schedule.ScenarioIterationId)
+		if err != nil {
+			return models.ScenarioPublicationSchedule{}, err
+		}
+		if err := uc.enforceSecurity.PublishScenario(scenarioAndIteration.Scenario); err != nil {
+			return models.ScenarioPublicationSchedule{}, err
+		}
+		if schedule.Status != models.ScenarioPublicationScheduleScheduled {
+			return models.ScenarioPublicationSchedule{}, errors.Wrapf(models.ConflictError,
+				"the publication schedule is %s", schedule.Status)
+		}
+
+		schedule, err = uc.repository.UpdateScenarioPublicationSchedule(ctx, tx, schedule.Id,
+			models.UpdateScenarioPublicationScheduleInput{
+				Status: utils.Ptr(models.ScenarioPublicationScheduleCancelled),
+			})
+		if err != nil {
+			return models.ScenarioPublicationSchedule{}, err
+		}
+		if err := uc.closePublicationRequest(ctx, tx, schedule,
+			models.UpdateScenarioPublicationRequestInput{
+				Status: models.ScenarioPublicationRequestCancelled,
+			}); err != nil {
+			return models.ScenarioPublicationSchedule{}, err
+		}
+		return schedule, nil
+	})
+}
+
+// ExecuteScenarioPublicationSchedules prepares the indexes of the upcoming scheduled publications of
+// the organization, and executes the schedules that are due, in the order they are due.
+func (uc ScenarioPublicationScheduleUsecase) ExecuteScenarioPublicationSchedules(
+	ctx context.Context,
+	organizationId uuid.UUID,
+) error {
+	schedules, err := uc.repository.ListScenarioPublicationSchedules(ctx, uc.executorFactory.NewExecutor(),
+		organizationId, models.ListScenarioPublicationsFilters{},
+		[]models.ScenarioPublicationScheduleStatus{models.ScenarioPublicationScheduleScheduled})
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range schedules {
+		if err := uc.processSchedule(ctx, schedule, time.Now()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc ScenarioPublicationScheduleUsecase) processSchedule(
+	ctx context.Context,
+	schedule models.ScenarioPublicationSchedule,
+	now time.Time,
+) error {
+	if schedule.PublicationAction == models.Publish {
+		ready, err := uc.publicationUsecase.prepareIterationIndexes(ctx,
+			schedule.OrganizationId, schedule.ScenarioIterationId)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			switch {
+			case schedule.IsDue(now):
+				return uc.failSchedule(ctx, schedule.Id, models.ErrScenarioIterationRequiresPreparation)
+			case schedule.ShouldAlertPreparation(now):
+				return uc.alertPreparation(ctx, schedule)
+			}
+			return nil
+		}
+	}
+	if !schedule.IsDue(now) {
+		return nil
+	}
+
+	err := uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		schedule, err := uc.repository.GetScenarioPublicationSchedule(ctx, tx, schedule.Id, true)
+		if err != nil {
+			return err
+		}
+		if schedule.Status != models.ScenarioPublicationScheduleScheduled {
+			return nil
+		}
+		org, err := uc.organizationRepository.GetOrganizationById(ctx, tx, schedule.OrganizationId)
+		if err != nil {
+			return err
+		}
+
+		// A schedule created while the organization required approval executes its publication
+		// request, which must be approved by now. A schedule created before approval was required
+		// cannot publish anymore.
+		var request *models.ScenarioPublicationRequest
+		if schedule.PublicationRequestId != nil {
+			linkedRequest, err := uc.requestRepository.GetScenarioPublicationRequest(ctx, tx,
+				*schedule.PublicationRequestId, true)
+			if err != nil {
+				return err
+			}
+			if linkedRequest.Status != models.ScenarioPublicationRequestApproved {
+				return errors.Wrapf(models.ErrScenarioPublicationRequiresApproval,
+					"the publication request is %s", linkedRequest.Status)
+			}
+			request = &linkedRequest
+		} else if org.RequirePublicationApproval && schedule.PublicationAction.RequiresApproval() {
+			return models.ErrScenarioPublicationRequiresApproval
+		}
+
+		if _, err := uc.publicationUsecase.executePublicationAction(ctx, tx, org,
+			models.PublishScenarioIterationInput{
+				ScenarioIterationId: schedule.ScenarioIterationId,
+				PublicationAction:   schedule.PublicationAction,
+			}); err != nil {
+			return err
+		}
+
+		_, err = uc.repository.UpdateScenarioPublicationSchedule(ctx, tx, schedule.Id,
+			models.UpdateScenarioPublicationScheduleInput{
+				Status: utils.Ptr(models.ScenarioPublicationScheduleExecuted),
+			})
+		if err != nil {
+			return err
+		}
+		if request == nil {
+			return nil
+		}
+		published, err := uc.requestRepository.UpdateScenarioPublicationRequest(ctx, tx, request.Id,
+			models.UpdateScenarioPublicationRequestInput{Status: models.ScenarioPublicationRequestPublished})
+		if err != nil {
+			return err
+		}
+		return uc.sendPublicationRequestWebhookEvent(ctx, tx, published)
+	})
+	if err != nil && isPermanentPublicationError(err) {
+		return uc.failSchedule(ctx, schedule.Id, err)
+	}
+	return err
+}
+
+func (uc ScenarioPublicationScheduleUsecase) alertPreparation(
+	ctx context.Context,
+	schedule models.ScenarioPublicationSchedule,
+) error {
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "the indexes of a scheduled publication are not ready",
+		"schedule_id", schedule.Id.String(), "scheduled_at", schedule.ScheduledAt)
+
+	return uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		schedule, err := uc.repository.UpdateScenarioPublicationSchedule(ctx, tx, schedule.Id,
+			models.UpdateScenarioPublicationScheduleInput{PreparationAlerted: true})
+		if err != nil {
+			return err
+		}
+		return uc.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			OrganizationId: schedule.OrganizationId,
+			EventContent:   models.NewWebhookEventPublicationSchedulePreparationNotReady(schedule),
+		})
+	})
+}
+
+func (uc ScenarioPublicationScheduleUsecase) failSchedule(ctx context.Context, id uuid.UUID, cause error) error {
+	scheduleError := cause.Error()
+	utils.LoggerFromContext(ctx).WarnContext(ctx, "scheduled publication could not be executed",
+		"schedule_id", id.String(), "error", scheduleError)
+
+	return uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+		schedule, err := uc.repository.UpdateScenarioPublicationSchedule(ctx, tx, id,
+			models.UpdateScenarioPublicationScheduleInput{
+				Status: utils.Ptr(models.ScenarioPublicationScheduleFailed),
+				Error:  &scheduleError,
+			})
+		if err != nil {
+			return err
+		}
+		if err := uc.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+			OrganizationId: schedule.OrganizationId,
+			EventContent:   models.NewWebhookEventPublicationScheduleFailed(schedule),
+		}); err != nil {
+			return err
+		}
+		return uc.closePublicationRequest(ctx, tx, schedule, models.UpdateScenarioPublicationRequestInput{
+			Status: models.ScenarioPublicationRequestFailed,
+			Error:  &scheduleError,
+		})
+	})
+}
+
+// closePublicationRequest closes the publication request of a schedule that will not be executed, so
+// that it does not stay open on the scenario.
+func (uc ScenarioPublicationScheduleUsecase) closePublicationRequest(
+	ctx context.Context,
+	tx repositories.Transaction,
+	schedule models.ScenarioPublicationSchedule,
+	input models.UpdateScenarioPublicationRequestInput,
+) error {
+	if schedule.PublicationRequestId == nil {
+		return nil
+	}
+	request, err := uc.requestRepository.GetScenarioPublicationRequest(ctx, tx, *schedule.PublicationRequestId, true)
+	if err != nil {
+		return err
+	}
+	if !request.Status.IsOpen() {
+		return nil
+	}
+	request, err = uc.requestRepository.UpdateScenarioPublicationRequest(ctx, tx, request.Id, input)
+	if err != nil {
+		return err
+	}
+	return uc.sendPublicationRequestWebhookEvent(ctx, tx, request)
+}
+
+func (uc ScenarioPublicationScheduleUsecase) sendPublicationRequestWebhookEvent(
+	ctx context.Context,
+	tx repositories.Transaction,
+	request models.ScenarioPublicationRequest,
+) error {
+	return uc.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+		OrganizationId: request.OrganizationId,
+		EventContent:   models.NewWebhookEventPublicationRequest(request),
+	})
+}
+
+func NewScenarioPublicationSchedulePeriodicJob(orgId uuid.UUID) *river.PeriodicJob {
+	return worker_jobs.NewPeriodicJob(
+		river.PeriodicInterval(SCENARIO_PUBLICATION_SCHEDULE_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.ScenarioPublicationScheduleArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId.String(),
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: SCENARIO_PUBLICATION_SCHEDULE_INTERVAL,
+				},
+			}
+		},
+	)
+}
+
+type ScenarioPublicationScheduleWorker struct {
+	river.WorkerDefaults[models.ScenarioPublicationScheduleArgs]
+	usecase ScenarioPublicationScheduleUsecase
+}
+
+func NewScenarioPublicationScheduleWorker(usecase ScenarioPublicationScheduleUsecase) *ScenarioPublicationScheduleWorker {
+	return &ScenarioPublicationScheduleWorker{usecase: usecase}
+}
+
+func (w *ScenarioPublicationScheduleWorker) Work(ctx context.Context,
+	job *river.Job[models.ScenarioPublicationScheduleArgs],
+) error {
+	return w.usecase.ExecuteScenarioPublicationSchedules(ctx, job.Args.OrgId)
+}
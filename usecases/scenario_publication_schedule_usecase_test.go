@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type scenarioPublicationScheduleTestEnv struct {
+	uc          ScenarioPublicationScheduleUsecase
+	repository  *mocks.ScenarioPublicationScheduleRepository
+	requests    *mocks.ScenarioPublicationRequestRepository
+	orgs        *mocks.OrganizationRepository
+	fetcher     *mocks.ScenarioFetcher
+	indexEditor *mocks.ClientDbIndexEditor
+	webhooks    *mocks.WebhookEventsUsecase
+	schedule    models.ScenarioPublicationSchedule
+}
+
+func newScenarioPublicationScheduleTestEnv() scenarioPublicationScheduleTestEnv {
+	executorFactory := executor_factory.NewExecutorFactoryStub()
+	transactionFactory := executor_factory.NewTransactionFactoryStub(executorFactory)
+
+	env := scenarioPublicationScheduleTestEnv{
+		repository:  new(mocks.ScenarioPublicationScheduleRepository),
+		requests:    new(mocks.ScenarioPublicationRequestRepository),
+		orgs:        new(mocks.OrganizationRepository),
+		fetcher:     new(mocks.ScenarioFetcher),
+		indexEditor: new(mocks.ClientDbIndexEditor),
+		webhooks:    new(mocks.WebhookEventsUsecase),
+		schedule: models.ScenarioPublicationSchedule{
+			Id:                  uuid.New(),
+			OrganizationId:      uuid.New(),
+			ScenarioId:          uuid.NewString(),
+			ScenarioIterationId: uuid.NewString(),
+			PublicationAction:   models.Publish,
+			Status:              models.ScenarioPublicationScheduleScheduled,
+		},
+	}
+	publicationUsecase := NewScenarioPublicationUsecase(transactionFactory, executorFactory, nil,
+		nil, nil, env.fetcher, nil, env.indexEditor, nil, nil, nil, nil, env.repository)
+	env.uc = NewScenarioPublicationScheduleUsecase(
+		executorFactory,
+		transactionFactory,
+		nil,
+		env.repository,
+		env.requests,
+		env.orgs,
+		env.fetcher,
+		publicationUsecase,
+		env.webhooks,
+	)
+	return env
+}
+
+func (env scenarioPublicationScheduleTestEnv) listSchedules() {
+	env.repository.On("ListScenarioPublicationSchedules", mock.Anything, mock.Anything,
+		env.schedule.OrganizationId, models.ListScenarioPublicationsFilters{},
+		[]models.ScenarioPublicationScheduleStatus{models.ScenarioPublicationScheduleScheduled}).
+		Return([]models.ScenarioPublicationSchedule{env.schedule}, nil)
+}
+
+func TestExecuteScenarioPublicationSchedules_fails_due_publication_without_indexes(t *testing.T) {
+	env := newScenarioPublicationScheduleTestEnv()
+	env.schedule.ScheduledAt = time.Now().Add(-time.Minute)
+	failed := env.schedule
+	failed.Status = models.ScenarioPublicationScheduleFailed
+
+	env.listSchedules()
+	env.indexEditor.On("GetIndexesToCreate", mock.Anything, env.schedule.OrganizationId,
+		env.schedule.ScenarioIterationId).Return([]models.ConcreteIndex{{TableName: "transactions"}}, 1, nil)
+	env.repository.On("UpdateScenarioPublicationSchedule", mock.Anything, mock.Anything, env.schedule.Id,
+		mock.MatchedBy(func(input models.UpdateScenarioPublicationScheduleInput) bool {
+			return input.Status != nil && *input.Status == models.ScenarioPublicationScheduleFailed &&
+				input.Error != nil
+		})).Return(failed, nil)
+	env.webhooks.On("CreateWebhookEvent", mock.Anything, mock.Anything, mock.MatchedBy(
+		func(input models.WebhookEventCreate) bool {
+			return input.EventContent.Type == models.WebhookEventType_PublicationScheduleFailed
+		})).Return(nil)
+
+	err := env.uc.ExecuteScenarioPublicationSchedules(context.Background(), env.schedule.OrganizationId)
+
+	require.NoError(t, err)
+	env.repository.AssertExpectations(t)
+	env.webhooks.AssertExpectations(t)
+	env.fetcher.AssertNotCalled(t, "FetchScenarioAndIteration", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteScenarioPublicationSchedules_alerts_before_the_publication(t *testing.T) {
+	env := newScenarioPublicationScheduleTestEnv()
+	env.schedule.ScheduledAt = time.Now().Add(30 * time.Minute)
+
+	env.listSchedules()
+	env.indexEditor.On("GetIndexesToCreate", mock.Anything, env.schedule.OrganizationId,
+		env.schedule.ScenarioIterationId).Return([]models.ConcreteIndex{{TableName: "transactions"}}, 1, nil)
+	env.repository.On("UpdateScenarioPublicationSchedule", mock.Anything, mock.Anything, env.schedule.Id,
+		models.UpdateScenarioPublicationScheduleInput{PreparationAlerted: true}).Return(env.schedule, nil)
+	env.webhooks.On("CreateWebhookEvent", mock.Anything, mock.Anything, mock.MatchedBy(
+		func(input models.WebhookEventCreate) bool {
+			return input.EventContent.Type == models.WebhookEventType_PublicationSchedulePreparation
+		})).Return(nil)
+
+	err := env.uc.ExecuteScenarioPublicationSchedules(context.Background(), env.schedule.OrganizationId)
+
+	require.NoError(t, err)
+	env.repository.AssertExpectations(t)
+	env.webhooks.AssertExpectations(t)
+}
+
+func TestExecuteScenarioPublicationSchedules_waits_for_upcoming_unpublication(t *testing.T) {
+	env := newScenarioPublicationScheduleTestEnv()
+	env.schedule.PublicationAction = models.Unpublish
+	env.schedule.ScheduledAt = time.Now().Add(10 * time.Minute)
+
+	env.listSchedules()
+
+	err := env.uc.ExecuteScenarioPublicationSchedules(context.Background(), env.schedule.OrganizationId)
+
+	require.NoError(t, err)
+	env.indexEditor.AssertNotCalled(t, "GetIndexesToCreate", mock.Anything, mock.Anything, mock.Anything)
+	env.repository.AssertNotCalled(t, "UpdateScenarioPublicationSchedule",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestExecuteScenarioPublicationSchedules_fails_publication_request_not_approved(t *testing.T) {
+	env := newScenarioPublicationScheduleTestEnv()
+	env.schedule.ScheduledAt = time.Now().Add(-time.Minute)
+	env.schedule.PublicationRequestId = utils.Ptr(uuid.New())
+	request := models.ScenarioPublicationRequest{
+		Id:             *env.schedule.PublicationRequestId,
+		OrganizationId: env.schedule.OrganizationId,
+		Status:         models.ScenarioPublicationRequestPending,
+		ScheduledAt:    &env.schedule.ScheduledAt,
+	}
+	failed := env.schedule
+	failed.Status = models.ScenarioPublicationScheduleFailed
+	failedRequest := request
+	failedRequest.Status = models.ScenarioPublicationRequestFailed
+
+	env.listSchedules()
+	env.indexEditor.On("GetIndexesToCreate", mock.Anything, env.schedule.OrganizationId,
+		env.schedule.ScenarioIterationId).Return([]models.ConcreteIndex{}, 0, nil)
+	env.repository.On("GetScenarioPublicationSchedule", mock.Anything, mock.Anything, env.schedule.Id, true).
+		Return(env.schedule, nil)
+	env.orgs.On("GetOrganizationById", mock.Anything, mock.Anything, env.schedule.OrganizationId).
+		Return(models.Organization{Id: env.schedule.OrganizationId, RequirePublicationApproval: true}, nil)
+	env.requests.On("GetScenarioPublicationRequest", mock.Anything, mock.Anything, request.Id, true).
+		Return(request, nil)
+	env.repository.On("UpdateScenarioPublicationSchedule", mock.Anything, mock.Anything, env.schedule.Id,
+		mock.MatchedBy(func(input models.UpdateScenarioPublicationScheduleInput) bool {
+			return input.Status != nil && *input.Status == models.ScenarioPublicationScheduleFailed
+		})).Return(failed, nil)
+	env.requests.On("UpdateScenarioPublicationRequest", mock.Anything, mock.Anything, request.Id,
+		mock.MatchedBy(func(input models.UpdateScenarioPublicationRequestInput) bool {
+			return input.Status == models.ScenarioPublicationRequestFailed && input.Error != nil
+		})).Return(failedRequest, nil)
+	env.webhooks.On("CreateWebhookEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := env.uc.ExecuteScenarioPublicationSchedules(context.Background(), env.schedule.OrganizationId)
+
+	require.NoError(t, err)
+	env.repository.AssertExpectations(t)
+	env.requests.AssertExpectations(t)
+	env.webhooks.AssertNumberOfCalls(t, "CreateWebhookEvent", 2)
+	env.fetcher.AssertNotCalled(t, "FetchScenarioAndIteration", mock.Anything, mock.Anything, mock.Anything)
+}
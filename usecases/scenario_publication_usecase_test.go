@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -31,6 +32,7 @@ type ScenarioPublicationUsecaseTestSuite struct {
 	featureAccessReader            *mocks.FeatureAccessReader
 	taskQueueRepository            *mocks.TaskQueueRepository
 	organizationRepository         *mocks.OrganizationRepository
+	scheduleRepository             *mocks.ScenarioPublicationScheduleRepository
 
 	organizationId                uuid.UUID
 	scenarioId                    string
@@ -61,6 +63,7 @@ func (suite *ScenarioPublicationUsecaseTestSuite) SetupTest() {
 	suite.featureAccessReader = new(mocks.FeatureAccessReader)
 	suite.taskQueueRepository = new(mocks.TaskQueueRepository)
 	suite.organizationRepository = new(mocks.OrganizationRepository)
+	suite.scheduleRepository = new(mocks.ScenarioPublicationScheduleRepository)
 
 	suite.organizationId = uuid.MustParse("12345678-1234-5678-9012-345678901234")
 	suite.scenarioId = "scenarioId"
@@ -163,6 +166,8 @@ func (suite *ScenarioPublicationUsecaseTestSuite) makeUsecase() *ScenarioPublica
 		suite.featureAccessReader,
 		nil,
 		suite.organizationRepository,
+		nil,
+		suite.scheduleRepository,
 	)
 }
 
@@ -176,6 +181,7 @@ func (suite *ScenarioPublicationUsecaseTestSuite) AssertExpectations() {
 	suite.exec.AssertExpectations(t)
 	suite.transaction.AssertExpectations(t)
 	suite.transactionFactory.AssertExpectations(t)
+	suite.scheduleRepository.AssertExpectations(t)
 }
 
 // GetScenarioPublication
@@ -247,6 +253,26 @@ func (suite *ScenarioPublicationUsecaseTestSuite) Test_ListScenarioPublications_
 	suite.organizationRepository.On("GetOrganizationById", suite.ctx, suite.transaction, suite.organizationId).Return(models.Organization{
 		OpenSanctionsConfig: models.OrganizationOpenSanctionsConfig{Providers: map[models.ScreeningFeature]models.ScreeningProvider{}},
 	}, nil)
+	scheduledAt := time.Now().Add(24 * time.Hour)
+	suite.scheduleRepository.On(
+		"ListScenarioPublicationSchedules",
+		suite.ctx,
+		suite.transaction,
+		suite.organizationId,
+		models.ListScenarioPublicationsFilters{},
+		[]models.ScenarioPublicationScheduleStatus{
+			models.ScenarioPublicationScheduleScheduled,
+			models.ScenarioPublicationScheduleFailed,
+		},
+	).Return([]models.ScenarioPublicationSchedule{{
+		Id:                  uuid.New(),
+		OrganizationId:      suite.organizationId,
+		ScenarioId:          suite.scenarioId,
+		ScenarioIterationId: suite.iterationId,
+		PublicationAction:   models.Unpublish,
+		ScheduledAt:         scheduledAt,
+		Status:              models.ScenarioPublicationScheduleScheduled,
+	}}, nil)
 
 	publications, err := suite.makeUsecase().ListScenarioPublications(
 		suite.ctx,
@@ -254,8 +280,11 @@ func (suite *ScenarioPublicationUsecaseTestSuite) Test_ListScenarioPublications_
 		models.ListScenarioPublicationsFilters{})
 
 	suite.NoError(err)
-	suite.Assert().NotEmpty(publications)
+	suite.Require().Len(publications, 2)
 	suite.Equal(suite.scenarioPublication, publications[0])
+	suite.Equal(models.ScenarioPublicationStatusScheduled, publications[1].Status)
+	suite.Equal(models.Unpublish, publications[1].PublicationAction)
+	suite.Equal(&scheduledAt, publications[1].ScheduledAt)
 
 	suite.AssertExpectations()
 }
@@ -324,6 +353,55 @@ func (suite *ScenarioPublicationUsecaseTestSuite) Test_ExecuteScenarioPublicatio
 	suite.AssertExpectations()
 }
 
+func (suite *ScenarioPublicationUsecaseTestSuite) Test_ExecuteScenarioPublicationAction_requires_approval() {
+	suite.clientDbIndexEditor.On("GetIndexesToCreate", suite.ctx, suite.organizationId, suite.iterationId).Return(
+		[]models.ConcreteIndex{}, 0, nil)
+	suite.transactionFactory.On("Transaction", suite.ctx, mock.Anything)
+	suite.organizationRepository.On("GetOrganizationById", suite.ctx, suite.transaction, suite.organizationId).Return(models.Organization{
+		RequirePublicationApproval: true,
+	}, nil)
+
+	publications, err := suite.makeUsecase().ExecuteScenarioPublicationAction(suite.ctx,
+		suite.organizationId,
+		models.PublishScenarioIterationInput{
+			ScenarioIterationId: suite.iterationId,
+			PublicationAction:   models.Publish,
+		})
+
+	suite.ErrorIs(err, models.ErrScenarioPublicationRequiresApproval)
+	suite.Assert().Empty(publications)
+	suite.scenarioPublisher.AssertNotCalled(suite.T(), "PublishOrUnpublishIteration",
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	suite.AssertExpectations()
+}
+
+func (suite *ScenarioPublicationUsecaseTestSuite) Test_ExecuteScenarioPublicationAction_unpublish_without_approval() {
+	suite.clientDbIndexEditor.On("GetIndexesToCreate", suite.ctx, suite.organizationId, suite.iterationId).Return(
+		[]models.ConcreteIndex{}, 0, nil)
+	suite.transactionFactory.On("Transaction", suite.ctx, mock.Anything).Return(nil)
+	suite.scenarioFetcher.On("FetchScenarioAndIteration", suite.ctx, suite.transaction, suite.iterationId).
+		Return(suite.scenarioAndIteration, nil)
+	suite.enforceSecurity.On("PublishScenario", suite.scenario).Return(nil)
+	suite.scenarioPublisher.On("PublishOrUnpublishIteration", suite.ctx, suite.transaction, mock.Anything, models.Unpublish).
+		Return([]models.ScenarioPublication{suite.scenarioPublication}, nil)
+	suite.organizationRepository.On("GetOrganizationById", suite.ctx, suite.transaction, suite.organizationId).Return(models.Organization{
+		RequirePublicationApproval: true,
+	}, nil)
+
+	publications, err := suite.makeUsecase().ExecuteScenarioPublicationAction(suite.ctx,
+		suite.organizationId,
+		models.PublishScenarioIterationInput{
+			ScenarioIterationId: suite.iterationId,
+			PublicationAction:   models.Unpublish,
+		})
+
+	suite.NoError(err)
+	suite.Assert().NotEmpty(publications)
+
+	suite.AssertExpectations()
+}
+
 func (suite *ScenarioPublicationUsecaseTestSuite) Test_ExecuteScenarioPublicationAction_fetch_error() {
 	suite.clientDbIndexEditor.On("GetIndexesToCreate", suite.ctx, suite.organizationId, suite.iterationId).Return(
 		suite.existingIndexes, 0, nil)
@@ -533,6 +611,43 @@ func (suite *ScenarioPublicationUsecaseTestSuite) Test_StartPublicationPreparati
 	suite.AssertExpectations()
 }
 
+func (suite *ScenarioPublicationUsecaseTestSuite) Test_GetPublicationDiff_against_live() {
+	liveIteration := suite.scenarioIteration
+	liveIteration.Id = "liveIterationId"
+	liveIteration.ScoreReviewThreshold = utils.Ptr(10)
+	candidate := suite.scenarioAndIteration
+	candidate.Scenario.LiveVersionID = utils.Ptr(liveIteration.Id)
+	candidate.Iteration.ScoreReviewThreshold = utils.Ptr(20)
+
+	suite.executorFactory.On("NewExecutor").Return(suite.transaction)
+	suite.scenarioFetcher.On("FetchScenarioAndIteration", suite.ctx, suite.transaction, suite.iterationId).
+		Return(candidate, nil)
+	suite.scenarioFetcher.On("FetchScenarioAndIteration", suite.ctx, suite.transaction, liveIteration.Id).
+		Return(models.ScenarioAndIteration{Scenario: candidate.Scenario, Iteration: liveIteration}, nil)
+	suite.enforceSecurity.On("ReadScenario", candidate.Scenario).Return(nil)
+
+	diff, err := suite.makeUsecase().GetPublicationDiff(suite.ctx, suite.iterationId)
+
+	suite.NoError(err)
+	suite.Equal(liveIteration.Id, diff.BaseIterationId)
+	suite.Equal([]models.FieldChange{{Field: "score_review_threshold", Before: "10", After: "20"}}, diff.Changes)
+
+	suite.AssertExpectations()
+}
+
+func (suite *ScenarioPublicationUsecaseTestSuite) Test_GetPublicationDiff_security_error() {
+	suite.executorFactory.On("NewExecutor").Return(suite.transaction)
+	suite.scenarioFetcher.On("FetchScenarioAndIteration", suite.ctx, suite.transaction, suite.iterationId).
+		Return(suite.scenarioAndIteration, nil)
+	suite.enforceSecurity.On("ReadScenario", suite.scenario).Return(suite.securityError)
+
+	_, err := suite.makeUsecase().GetPublicationDiff(suite.ctx, suite.iterationId)
+
+	suite.ErrorIs(err, suite.securityError)
+
+	suite.AssertExpectations()
+}
+
 func TestScenarioPublicationUsecase(t *testing.T) {
 	suite.Run(t, new(ScenarioPublicationUsecaseTestSuite))
 }
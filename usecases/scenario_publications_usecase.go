@@ -3,6 +3,8 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/repositories"
@@ -28,6 +30,12 @@ type ScenarioPublisher interface {
 		scenarioAndIteration models.ScenarioAndIteration,
 		publicationAction models.PublicationAction,
 	) ([]models.ScenarioPublication, error)
+	StartCanary(
+		ctx context.Context,
+		exec repositories.Transaction,
+		scenarioAndIteration models.ScenarioAndIteration,
+		percentage int,
+	) ([]models.ScenarioPublication, error)
 	SaveScenarioPreparationAction(ctx context.Context, exec repositories.Executor,
 		orgId uuid.UUID,
 		scenarioId, iterationId string) error
@@ -59,6 +67,19 @@ type PublicationUsecaseFeatureAccessReader interface {
 	) (models.OrganizationFeatureAccess, error)
 }
 
+type ScenarioCanaryRepository interface {
+	GetScenarioById(ctx context.Context, exec repositories.Executor, scenarioId string,
+		screeningProvider models.ScreeningProvider) (models.Scenario, error)
+	CountDecisionsByRolloutArm(ctx context.Context, exec repositories.Executor,
+		organizationId uuid.UUID, scenarioId string, since time.Time) ([]models.RolloutArmOutcomeCount, error)
+}
+
+type ScenarioPublicationScheduleLister interface {
+	ListScenarioPublicationSchedules(ctx context.Context, exec repositories.Executor, orgId uuid.UUID,
+		filters models.ListScenarioPublicationsFilters, statuses []models.ScenarioPublicationScheduleStatus,
+	) ([]models.ScenarioPublicationSchedule, error)
+}
+
 type ScreeningRequirementChecker interface {
 	IsConfigured(context.Context, models.ScreeningProvider) (bool, error)
 }
@@ -75,6 +96,8 @@ type ScenarioPublicationUsecase struct {
 	featureAccessReader            PublicationUsecaseFeatureAccessReader
 	screeningRequirements          ScreeningRequirementChecker
 	organizationRepository         ScreeningOrganizationRepository
+	canaryRepository               ScenarioCanaryRepository
+	scheduleRepository             ScenarioPublicationScheduleLister
 }
 
 func NewScenarioPublicationUsecase(
@@ -89,6 +112,8 @@ func NewScenarioPublicationUsecase(
 	featureAccessReader PublicationUsecaseFeatureAccessReader,
 	screeningRequirements ScreeningRequirementChecker,
 	organizationRepository ScreeningOrganizationRepository,
+	canaryRepository ScenarioCanaryRepository,
+	scheduleRepository ScenarioPublicationScheduleLister,
 ) *ScenarioPublicationUsecase {
 	return &ScenarioPublicationUsecase{
 		transactionFactory:             transactionFactory,
@@ -102,6 +127,8 @@ func NewScenarioPublicationUsecase(
 		featureAccessReader:            featureAccessReader,
 		screeningRequirements:          screeningRequirements,
 		organizationRepository:         organizationRepository,
+		canaryRepository:               canaryRepository,
+		scheduleRepository:             scheduleRepository,
 	}
 }
 
@@ -132,8 +159,26 @@ func (usecase *ScenarioPublicationUsecase) ListScenarioPublications(
 		return nil, err
 	}
 
-	return usecase.scenarioPublicationsRepository.ListScenarioPublicationsOfOrganization(ctx,
-		usecase.executorFactory.NewExecutor(), organizationId, filters)
+	exec := usecase.executorFactory.NewExecutor()
+	publications, err := usecase.scenarioPublicationsRepository.ListScenarioPublicationsOfOrganization(ctx,
+		exec, organizationId, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// The publications that are scheduled, or whose schedule failed, come after the executed ones
+	schedules, err := usecase.scheduleRepository.ListScenarioPublicationSchedules(ctx, exec,
+		organizationId, filters, []models.ScenarioPublicationScheduleStatus{
+			models.ScenarioPublicationScheduleScheduled,
+			models.ScenarioPublicationScheduleFailed,
+		})
+	if err != nil {
+		return nil, err
+	}
+	for _, schedule := range schedules {
+		publications = append(publications, schedule.AsScenarioPublication())
+	}
+	return publications, nil
 }
 
 func (usecase *ScenarioPublicationUsecase) ExecuteScenarioPublicationAction(
@@ -149,7 +194,10 @@ func (usecase *ScenarioPublicationUsecase) ExecuteScenarioPublicationAction(
 	if err != nil {
 		return nil, errors.Wrap(err, "Error while fetching indexes to create in ExecuteScenarioPublicationAction")
 	}
-	if len(indexesToCreate) > 0 && input.PublicationAction == models.Publish {
+	runsIteration := slices.Contains([]models.PublicationAction{
+		models.Publish, models.StartCanary, models.PromoteCanary,
+	}, input.PublicationAction)
+	if len(indexesToCreate) > 0 && runsIteration {
 		return nil, errors.Wrap(
 			models.ErrScenarioIterationRequiresPreparation,
 			fmt.Sprintf("Cannot publish the scenario iteration: it requires data preparation to be run first for %d indexes", len(indexesToCreate)),
@@ -165,46 +213,133 @@ func (usecase *ScenarioPublicationUsecase) ExecuteScenarioPublicationAction(
 			if err != nil {
 				return nil, err
 			}
-
-			scenarioAndIteration, err := usecase.scenarioFetcher.FetchScenarioAndIteration(ctx, tx, input.ScenarioIterationId)
-			if err != nil {
-				return nil, err
-			}
-			if len(scenarioAndIteration.Iteration.ScreeningConfigs) > 0 {
-				featureAccess, err := usecase.featureAccessReader.GetOrganizationFeatureAccess(ctx, organizationId, nil)
-				if err != nil {
-					return nil, err
-				}
-				if !featureAccess.Sanctions.IsAllowed() {
-					return nil, errors.Wrapf(models.ForbiddenError,
-						"screening feature access is missing: status is %s", featureAccess.Sanctions)
-				}
-
-				screeningProvider := org.GetScreeningProviderFor(models.ScreeningFeatureTransactionMonitoring)
-
-				isConfigured, err := usecase.screeningRequirements.IsConfigured(ctx, screeningProvider)
-				if err != nil {
-					return nil, errors.Wrapf(err,
-						"could not check whether screening was provided configured for %s", screeningProvider)
-				}
-				if !isConfigured {
-					return nil, errors.New("screening is not configured, cannot publish scenario")
-				}
-			}
-
-			if err := usecase.enforceSecurity.PublishScenario(scenarioAndIteration.Scenario); err != nil {
-				return nil, err
+			if org.RequirePublicationApproval && input.PublicationAction.RequiresApproval() {
+				return nil, models.ErrScenarioPublicationRequiresApproval
 			}
 
-			return usecase.scenarioPublisher.PublishOrUnpublishIteration(
-				ctx,
-				tx,
-				scenarioAndIteration,
-				input.PublicationAction,
-			)
+			return usecase.executePublicationAction(ctx, tx, org, input)
 		})
 }
 
+// executePublicationAction runs the publication action once it is allowed, either directly or
+// through an approved publication request.
+func (usecase *ScenarioPublicationUsecase) executePublicationAction(
+	ctx context.Context,
+	tx repositories.Transaction,
+	org models.Organization,
+	input models.PublishScenarioIterationInput,
+) ([]models.ScenarioPublication, error) {
+	scenarioAndIteration, err := usecase.scenarioFetcher.FetchScenarioAndIteration(ctx, tx, input.ScenarioIterationId)
+	if err != nil {
+		return nil, err
+	}
+	if len(scenarioAndIteration.Iteration.ScreeningConfigs) > 0 {
+		featureAccess, err := usecase.featureAccessReader.GetOrganizationFeatureAccess(ctx, org.Id, nil)
+		if err != nil {
+			return nil, err
+		}
+		if !featureAccess.Sanctions.IsAllowed() {
+			return nil, errors.Wrapf(models.ForbiddenError,
+				"screening feature access is missing: status is %s", featureAccess.Sanctions)
+		}
+
+		screeningProvider := org.GetScreeningProviderFor(models.ScreeningFeatureTransactionMonitoring)
+
+		isConfigured, err := usecase.screeningRequirements.IsConfigured(ctx, screeningProvider)
+		if err != nil {
+			return nil, errors.Wrapf(err,
+				"could not check whether screening was provided configured for %s", screeningProvider)
+		}
+		if !isConfigured {
+			return nil, errors.New("screening is not configured, cannot publish scenario")
+		}
+	}
+
+	if err := usecase.enforceSecurity.PublishScenario(scenarioAndIteration.Scenario); err != nil {
+		return nil, err
+	}
+
+	if input.PublicationAction == models.StartCanary {
+		return usecase.scenarioPublisher.StartCanary(
+			ctx,
+			tx,
+			scenarioAndIteration,
+			input.CanaryPercentage,
+		)
+	}
+	return usecase.scenarioPublisher.PublishOrUnpublishIteration(
+		ctx,
+		tx,
+		scenarioAndIteration,
+		input.PublicationAction,
+	)
+}
+
+// GetScenarioCanaryStats compares the outcomes of the decisions taken on each arm since the current
+// canary of the scenario started.
+func (usecase *ScenarioPublicationUsecase) GetScenarioCanaryStats(
+	ctx context.Context,
+	scenarioId string,
+) (models.ScenarioCanaryStats, error) {
+	exec := usecase.executorFactory.NewExecutor()
+
+	scenario, err := usecase.canaryRepository.GetScenarioById(ctx, exec, scenarioId, "")
+	if err != nil {
+		return models.ScenarioCanaryStats{}, err
+	}
+	if err := usecase.enforceSecurity.ReadScenario(scenario); err != nil {
+		return models.ScenarioCanaryStats{}, err
+	}
+
+	stats := models.ScenarioCanaryStats{
+		ScenarioId:        scenario.Id,
+		LiveIterationId:   scenario.LiveVersionID,
+		CanaryIterationId: scenario.CanaryIterationId,
+		CanaryPercentage:  scenario.CanaryPercentage,
+		CanaryStartedAt:   scenario.CanaryStartedAt,
+	}
+	if !scenario.HasCanary() || scenario.CanaryStartedAt == nil {
+		stats.Arms = models.NewRolloutArmStats(nil)
+		return stats, nil
+	}
+
+	counts, err := usecase.canaryRepository.CountDecisionsByRolloutArm(ctx, exec,
+		scenario.OrganizationId, scenario.Id, *scenario.CanaryStartedAt)
+	if err != nil {
+		return models.ScenarioCanaryStats{}, err
+	}
+	stats.Arms = models.NewRolloutArmStats(counts)
+	return stats, nil
+}
+
+// GetPublicationDiff shows what publishing an iteration changes compared to the live iteration of its
+// scenario. Everything in the iteration shows up as added if the scenario has no live iteration.
+func (usecase *ScenarioPublicationUsecase) GetPublicationDiff(
+	ctx context.Context,
+	scenarioIterationId string,
+) (models.ScenarioIterationDiff, error) {
+	exec := usecase.executorFactory.NewExecutor()
+
+	scenarioAndIteration, err := usecase.scenarioFetcher.FetchScenarioAndIteration(ctx, exec, scenarioIterationId)
+	if err != nil {
+		return models.ScenarioIterationDiff{}, err
+	}
+	if err := usecase.enforceSecurity.ReadScenario(scenarioAndIteration.Scenario); err != nil {
+		return models.ScenarioIterationDiff{}, err
+	}
+
+	var live models.ScenarioIteration
+	if liveVersionId := scenarioAndIteration.Scenario.LiveVersionID; liveVersionId != nil {
+		liveScenarioAndIteration, err := usecase.scenarioFetcher.FetchScenarioAndIteration(ctx, exec, *liveVersionId)
+		if err != nil {
+			return models.ScenarioIterationDiff{}, err
+		}
+		live = liveScenarioAndIteration.Iteration
+	}
+
+	return models.DiffScenarioIterations(live, scenarioAndIteration.Iteration), nil
+}
+
 func (usecase *ScenarioPublicationUsecase) GetPublicationPreparationStatus(
 	ctx context.Context,
 	organizationId uuid.UUID,
@@ -275,3 +410,39 @@ func (usecase *ScenarioPublicationUsecase) StartPublicationPreparation(
 		return nil
 	})
 }
+
+// prepareIterationIndexes starts the creation of the indexes the iteration needs, like the
+// publication preparation does. It returns true once there is no index left to create.
+func (usecase *ScenarioPublicationUsecase) prepareIterationIndexes(
+	ctx context.Context,
+	organizationId uuid.UUID,
+	scenarioIterationId string,
+) (bool, error) {
+	indexesToCreate, numPending, err := usecase.clientDbIndexEditor.GetIndexesToCreate(ctx,
+		organizationId, scenarioIterationId)
+	if err != nil {
+		return false, err
+	}
+	if len(indexesToCreate) == 0 {
+		return true, nil
+	}
+	if numPending > 0 {
+		return false, nil
+	}
+
+	err = usecase.StartPublicationPreparation(ctx, organizationId, scenarioIterationId)
+	if errors.Is(err, models.ErrDataPreparationServiceUnavailable) {
+		return false, nil
+	}
+	return false, err
+}
+
+// The errors that will not go away by retrying a publication executed in the background
+func isPermanentPublicationError(err error) bool {
+	return errors.IsAny(err,
+		models.BadParameterError,
+		models.ForbiddenError,
+		models.NotFoundError,
+		models.ConflictError,
+	)
+}
@@ -75,6 +75,11 @@ func (usecase *ScenarioUsecase) UpdateScenario(
 	ctx context.Context,
 	scenarioInput models.UpdateScenarioInput,
 ) (models.Scenario, error) {
+	if scenarioInput.FullRefreshIntervalDays != nil && *scenarioInput.FullRefreshIntervalDays < 0 {
+		return models.Scenario{}, errors.Wrap(models.BadParameterError,
+			"full_refresh_interval_days must not be negative")
+	}
+
 	return executor_factory.TransactionReturnValue(
 		ctx,
 		usecase.transactionFactory,
@@ -311,6 +316,8 @@ func (usecase *ScenarioUsecase) CopyScenario(
 					FormulaAstExpression: rule.FormulaAstExpression,
 					ScoreModifier:        rule.ScoreModifier,
 					RuleGroup:            rule.RuleGroup,
+					ThrottlePeriod:       rule.ThrottlePeriod,
+					ThrottleScope:        rule.ThrottleScope,
 				}
 			}
 
@@ -3,6 +3,7 @@ package scenarios
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -22,6 +23,8 @@ type ScenarioPublisherRepository interface {
 	ListAllRulesAndScreenings(ctx context.Context, exec repositories.Executor,
 		organizationId uuid.UUID) ([]models.RulesAndScreenings, error)
 	ArchiveScenarioIteration(ctx context.Context, exec repositories.Executor, scenarioIterationId string) error
+	UpdateScenarioCanary(ctx context.Context, exec repositories.Executor, scenarioId string,
+		input models.UpdateScenarioCanaryInput) error
 }
 
 type ScenarioPublisher struct {
@@ -51,48 +54,35 @@ func (publisher ScenarioPublisher) PublishOrUnpublishIteration(
 				return nil, fmt.Errorf("unable to unpublish: scenario iteration %s is not currently live %w", iterationId, models.BadParameterError)
 			}
 
-			if sps, err := publisher.unpublishOldIteration(ctx, tx, organizationId,
-				scenariosId, &iterationId); err != nil {
+			// A canary is only compared to the live iteration, it cannot outlive it
+			if sps, err := publisher.endCanary(ctx, tx, scenarioAndIteration.Scenario, ""); err != nil {
 				return nil, err
 			} else {
 				scenarioPublications = append(scenarioPublications, sps...)
 			}
-		}
-	case models.Publish:
-		{
-			if scenarioAndIteration.Iteration.Version == nil {
-				return nil, errors.Wrap(models.ErrScenarioIterationIsDraft,
-					"input scenario iteration is a draft in PublishOrUnpublishIteration")
-			}
-
-			if liveVersionId != nil && *liveVersionId == iterationId {
-				return []models.ScenarioPublication{}, nil
-			}
 
-			if err := ScenarioValidationToError(publisher.ValidateScenarioIteration.Validate(
-				ctx, scenarioAndIteration)); err != nil {
-				return nil, errors.Wrap(
-					models.ErrScenarioIterationNotValid,
-					fmt.Sprintf("Error validating scenario iteration %s: %s", iterationId, err.Error()),
-				)
-			}
 			if sps, err := publisher.unpublishOldIteration(ctx, tx, organizationId,
-				scenariosId, liveVersionId); err != nil {
+				scenariosId, &iterationId); err != nil {
 				return nil, err
 			} else {
 				scenarioPublications = append(scenarioPublications, sps...)
 			}
-
-			if sp, err := publisher.publishNewIteration(ctx, tx, organizationId,
-				scenariosId, iterationId); err != nil {
-				return nil, err
-			} else {
-				scenarioPublications = append(scenarioPublications, sp)
+		}
+	case models.Publish:
+		return publisher.publishIteration(ctx, tx, scenarioAndIteration)
+	case models.PromoteCanary:
+		{
+			if !isCanaryOf(scenarioAndIteration) {
+				return nil, fmt.Errorf("unable to promote: scenario iteration %s is not the current canary %w", iterationId, models.BadParameterError)
 			}
-
-			tracking.TrackEvent(ctx, models.AnalyticsScenarioIterationPublished, map[string]interface{}{
-				"scenario_iteration_id": iterationId,
-			})
+			return publisher.publishIteration(ctx, tx, scenarioAndIteration)
+		}
+	case models.RollbackCanary:
+		{
+			if !isCanaryOf(scenarioAndIteration) {
+				return nil, fmt.Errorf("unable to roll back: scenario iteration %s is not the current canary %w", iterationId, models.BadParameterError)
+			}
+			return publisher.endCanary(ctx, tx, scenarioAndIteration.Scenario, "")
 		}
 	default:
 		return nil, errors.Wrap(
@@ -104,6 +94,177 @@ func (publisher ScenarioPublisher) PublishOrUnpublishIteration(
 	return scenarioPublications, nil
 }
 
+func (publisher ScenarioPublisher) publishIteration(
+	ctx context.Context,
+	tx repositories.Transaction,
+	scenarioAndIteration models.ScenarioAndIteration,
+) ([]models.ScenarioPublication, error) {
+	var scenarioPublications []models.ScenarioPublication
+
+	organizationId := scenarioAndIteration.Scenario.OrganizationId
+	scenariosId := scenarioAndIteration.Scenario.Id
+	iterationId := scenarioAndIteration.Iteration.Id
+	liveVersionId := scenarioAndIteration.Scenario.LiveVersionID
+
+	if scenarioAndIteration.Iteration.Version == nil {
+		return nil, errors.Wrap(models.ErrScenarioIterationIsDraft,
+			"input scenario iteration is a draft in PublishOrUnpublishIteration")
+	}
+
+	if liveVersionId != nil && *liveVersionId == iterationId {
+		return []models.ScenarioPublication{}, nil
+	}
+
+	if err := publisher.validate(ctx, scenarioAndIteration); err != nil {
+		return nil, err
+	}
+
+	// Publishing the canary promotes it, publishing any other iteration rolls it back
+	if sps, err := publisher.endCanary(ctx, tx, scenarioAndIteration.Scenario, iterationId); err != nil {
+		return nil, err
+	} else {
+		scenarioPublications = append(scenarioPublications, sps...)
+	}
+
+	if sps, err := publisher.unpublishOldIteration(ctx, tx, organizationId,
+		scenariosId, liveVersionId); err != nil {
+		return nil, err
+	} else {
+		scenarioPublications = append(scenarioPublications, sps...)
+	}
+
+	if sp, err := publisher.publishNewIteration(ctx, tx, organizationId,
+		scenariosId, iterationId); err != nil {
+		return nil, err
+	} else {
+		scenarioPublications = append(scenarioPublications, sp)
+	}
+
+	tracking.TrackEvent(ctx, models.AnalyticsScenarioIterationPublished, map[string]interface{}{
+		"scenario_iteration_id": iterationId,
+	})
+
+	return scenarioPublications, nil
+}
+
+// StartCanary runs a published iteration on a deterministic share of the trigger objects of a
+// scenario, next to its live iteration. Starting it again for the current canary only changes the
+// percentage, starting it for another iteration replaces the current canary.
+func (publisher ScenarioPublisher) StartCanary(
+	ctx context.Context,
+	tx repositories.Transaction,
+	scenarioAndIteration models.ScenarioAndIteration,
+	percentage int,
+) ([]models.ScenarioPublication, error) {
+	scenario := scenarioAndIteration.Scenario
+	iterationId := scenarioAndIteration.Iteration.Id
+
+	if percentage < models.MinCanaryPercentage || percentage > models.MaxCanaryPercentage {
+		return nil, errors.Wrapf(models.BadParameterError,
+			"canary percentage must be between %d and %d", models.MinCanaryPercentage, models.MaxCanaryPercentage)
+	}
+	if scenarioAndIteration.Iteration.Version == nil {
+		return nil, errors.Wrap(models.ErrScenarioIterationIsDraft,
+			"input scenario iteration is a draft in StartCanary")
+	}
+	if scenario.LiveVersionID == nil {
+		return nil, errors.Wrap(models.BadParameterError,
+			"unable to start a canary: the scenario has no live iteration to compare it with")
+	}
+	if *scenario.LiveVersionID == iterationId {
+		return nil, errors.Wrapf(models.BadParameterError,
+			"unable to start a canary: scenario iteration %s is already live", iterationId)
+	}
+
+	if err := publisher.validate(ctx, scenarioAndIteration); err != nil {
+		return nil, err
+	}
+
+	var scenarioPublications []models.ScenarioPublication
+	startedAt := time.Now()
+	if isCanaryOf(scenarioAndIteration) && scenario.CanaryStartedAt != nil {
+		startedAt = *scenario.CanaryStartedAt
+	} else {
+		sps, err := publisher.endCanary(ctx, tx, scenario, "")
+		if err != nil {
+			return nil, err
+		}
+		scenarioPublications = append(scenarioPublications, sps...)
+	}
+
+	sp, err := publisher.recordPublication(ctx, tx, scenario, iterationId, models.StartCanary)
+	if err != nil {
+		return nil, err
+	}
+	scenarioPublications = append(scenarioPublications, sp)
+
+	if err := publisher.Repository.UpdateScenarioCanary(ctx, tx, scenario.Id, models.UpdateScenarioCanaryInput{
+		CanaryIterationId: &iterationId,
+		CanaryPercentage:  percentage,
+		CanaryStartedAt:   &startedAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return scenarioPublications, nil
+}
+
+// endCanary stops the canary of the scenario, if any. It is recorded as a rollback unless the canary
+// is the iteration being published.
+func (publisher ScenarioPublisher) endCanary(ctx context.Context, tx repositories.Transaction,
+	scenario models.Scenario, publishedIterationId string,
+) ([]models.ScenarioPublication, error) {
+	if scenario.CanaryIterationId == nil {
+		return []models.ScenarioPublication{}, nil
+	}
+
+	scenarioPublications := []models.ScenarioPublication{}
+	if *scenario.CanaryIterationId != publishedIterationId {
+		sp, err := publisher.recordPublication(ctx, tx, scenario, *scenario.CanaryIterationId, models.RollbackCanary)
+		if err != nil {
+			return nil, err
+		}
+		scenarioPublications = append(scenarioPublications, sp)
+	}
+
+	if err := publisher.Repository.UpdateScenarioCanary(ctx, tx, scenario.Id,
+		models.UpdateScenarioCanaryInput{}); err != nil {
+		return nil, err
+	}
+	return scenarioPublications, nil
+}
+
+func isCanaryOf(scenarioAndIteration models.ScenarioAndIteration) bool {
+	canaryId := scenarioAndIteration.Scenario.CanaryIterationId
+	return canaryId != nil && *canaryId == scenarioAndIteration.Iteration.Id
+}
+
+func (publisher ScenarioPublisher) validate(ctx context.Context, scenarioAndIteration models.ScenarioAndIteration) error {
+	if err := ScenarioValidationToError(publisher.ValidateScenarioIteration.Validate(
+		ctx, scenarioAndIteration)); err != nil {
+		return errors.Wrap(
+			models.ErrScenarioIterationNotValid,
+			fmt.Sprintf("Error validating scenario iteration %s: %s", scenarioAndIteration.Iteration.Id, err.Error()),
+		)
+	}
+	return nil
+}
+
+func (publisher ScenarioPublisher) recordPublication(ctx context.Context, tx repositories.Transaction,
+	scenario models.Scenario, scenarioIterationId string, action models.PublicationAction,
+) (models.ScenarioPublication, error) {
+	newScenarioPublicationId := pure_utils.NewId().String()
+	if err := publisher.ScenarioPublicationsRepository.CreateScenarioPublication(ctx, tx, models.CreateScenarioPublicationInput{
+		OrganizationId:      scenario.OrganizationId,
+		ScenarioIterationId: scenarioIterationId,
+		ScenarioId:          scenario.Id,
+		PublicationAction:   action,
+	}, newScenarioPublicationId); err != nil {
+		return models.ScenarioPublication{}, err
+	}
+	return publisher.ScenarioPublicationsRepository.GetScenarioPublicationById(ctx, tx, newScenarioPublicationId)
+}
+
 func (publisher ScenarioPublisher) shutDownTestRunIfNeeded(ctx context.Context, tx repositories.Transaction, liveVersionId string) error {
 	testrun, err := publisher.ScenarioTestRunRepository.GetTestRunByLiveVersionID(ctx, tx, liveVersionId)
 	if err != nil {
@@ -130,3 +130,216 @@ func TestScenarioPublisher_PublishOrUnpublishIteration_unpublish_CreateScenarioP
 	spr.AssertExpectations(t)
 	swr.AssertExpectations(t)
 }
+
+type validateScenarioIterationStub struct{}
+
+func (validateScenarioIterationStub) Validate(ctx context.Context, si models.ScenarioAndIteration) models.ScenarioValidation {
+	return models.NewScenarioValidation()
+}
+
+func canaryScenarioAndIteration() models.ScenarioAndIteration {
+	return models.ScenarioAndIteration{
+		Scenario: models.Scenario{
+			OrganizationId: pure_utils.NewId(),
+			Id:             pure_utils.NewId().String(),
+			LiveVersionID:  utils.Ptr(pure_utils.NewId().String()),
+		},
+		Iteration: models.ScenarioIteration{
+			Id:      pure_utils.NewId().String(),
+			Version: utils.Ptr(2),
+		},
+	}
+}
+
+func isPublicationId(id string) bool {
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+func TestScenarioPublisher_StartCanary_nominal(t *testing.T) {
+	scenarioAndIteration := canaryScenarioAndIteration()
+	scenario := scenarioAndIteration.Scenario
+	iterationId := scenarioAndIteration.Iteration.Id
+	publication := models.ScenarioPublication{Id: pure_utils.NewId().String(), PublicationAction: models.StartCanary}
+
+	transaction := new(mocks.Transaction)
+	ctx := context.Background()
+
+	spr := new(mocks.ScenarioPublicationRepository)
+	spr.On("CreateScenarioPublication", ctx, transaction, models.CreateScenarioPublicationInput{
+		OrganizationId:      scenario.OrganizationId,
+		ScenarioId:          scenario.Id,
+		ScenarioIterationId: iterationId,
+		PublicationAction:   models.StartCanary,
+	}, mock.MatchedBy(isPublicationId)).Return(nil)
+	spr.On("GetScenarioPublicationById", ctx, transaction, mock.MatchedBy(isPublicationId)).Return(publication, nil)
+
+	repo := new(mocks.ScenarioPublisherRepository)
+	repo.On("UpdateScenarioCanary", ctx, transaction, scenario.Id, mock.MatchedBy(
+		func(input models.UpdateScenarioCanaryInput) bool {
+			return *input.CanaryIterationId == iterationId &&
+				input.CanaryPercentage == 10 && input.CanaryStartedAt != nil
+		})).Return(nil)
+
+	publisher := ScenarioPublisher{
+		Repository:                     repo,
+		ValidateScenarioIteration:      validateScenarioIterationStub{},
+		ScenarioPublicationsRepository: spr,
+	}
+
+	publications, err := publisher.StartCanary(ctx, transaction, scenarioAndIteration, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ScenarioPublication{publication}, publications)
+
+	spr.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestScenarioPublisher_StartCanary_updates_percentage(t *testing.T) {
+	scenarioAndIteration := canaryScenarioAndIteration()
+	startedAt := time.Now().Add(-time.Hour)
+	scenarioAndIteration.Scenario.CanaryIterationId = utils.Ptr(scenarioAndIteration.Iteration.Id)
+	scenarioAndIteration.Scenario.CanaryPercentage = 10
+	scenarioAndIteration.Scenario.CanaryStartedAt = &startedAt
+	scenario := scenarioAndIteration.Scenario
+
+	transaction := new(mocks.Transaction)
+	ctx := context.Background()
+
+	spr := new(mocks.ScenarioPublicationRepository)
+	spr.On("CreateScenarioPublication", ctx, transaction, mock.MatchedBy(
+		func(input models.CreateScenarioPublicationInput) bool {
+			return input.PublicationAction == models.StartCanary
+		}), mock.MatchedBy(isPublicationId)).Return(nil).Once()
+	spr.On("GetScenarioPublicationById", ctx, transaction, mock.MatchedBy(isPublicationId)).
+		Return(models.ScenarioPublication{}, nil)
+
+	repo := new(mocks.ScenarioPublisherRepository)
+	repo.On("UpdateScenarioCanary", ctx, transaction, scenario.Id, models.UpdateScenarioCanaryInput{
+		CanaryIterationId: scenario.CanaryIterationId,
+		CanaryPercentage:  50,
+		CanaryStartedAt:   &startedAt,
+	}).Return(nil).Once()
+
+	publisher := ScenarioPublisher{
+		Repository:                     repo,
+		ValidateScenarioIteration:      validateScenarioIterationStub{},
+		ScenarioPublicationsRepository: spr,
+	}
+
+	_, err := publisher.StartCanary(ctx, transaction, scenarioAndIteration, 50)
+	assert.NoError(t, err)
+
+	spr.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestScenarioPublisher_StartCanary_invalid(t *testing.T) {
+	ctx := context.Background()
+	publisher := ScenarioPublisher{ValidateScenarioIteration: validateScenarioIterationStub{}}
+
+	_, err := publisher.StartCanary(ctx, nil, canaryScenarioAndIteration(), 100)
+	assert.ErrorIs(t, err, models.BadParameterError)
+
+	live := canaryScenarioAndIteration()
+	live.Scenario.LiveVersionID = utils.Ptr(live.Iteration.Id)
+	_, err = publisher.StartCanary(ctx, nil, live, 10)
+	assert.ErrorIs(t, err, models.BadParameterError)
+
+	noLive := canaryScenarioAndIteration()
+	noLive.Scenario.LiveVersionID = nil
+	_, err = publisher.StartCanary(ctx, nil, noLive, 10)
+	assert.ErrorIs(t, err, models.BadParameterError)
+
+	draft := canaryScenarioAndIteration()
+	draft.Iteration.Version = nil
+	_, err = publisher.StartCanary(ctx, nil, draft, 10)
+	assert.ErrorIs(t, err, models.ErrScenarioIterationIsDraft)
+}
+
+func TestScenarioPublisher_PublishOrUnpublishIteration_rollback_canary(t *testing.T) {
+	scenarioAndIteration := canaryScenarioAndIteration()
+	scenarioAndIteration.Scenario.CanaryIterationId = utils.Ptr(scenarioAndIteration.Iteration.Id)
+	scenarioAndIteration.Scenario.CanaryPercentage = 10
+	scenario := scenarioAndIteration.Scenario
+	publication := models.ScenarioPublication{Id: pure_utils.NewId().String(), PublicationAction: models.RollbackCanary}
+
+	transaction := new(mocks.Transaction)
+	ctx := context.Background()
+
+	spr := new(mocks.ScenarioPublicationRepository)
+	spr.On("CreateScenarioPublication", ctx, transaction, models.CreateScenarioPublicationInput{
+		OrganizationId:      scenario.OrganizationId,
+		ScenarioId:          scenario.Id,
+		ScenarioIterationId: scenarioAndIteration.Iteration.Id,
+		PublicationAction:   models.RollbackCanary,
+	}, mock.MatchedBy(isPublicationId)).Return(nil)
+	spr.On("GetScenarioPublicationById", ctx, transaction, mock.MatchedBy(isPublicationId)).Return(publication, nil)
+
+	repo := new(mocks.ScenarioPublisherRepository)
+	repo.On("UpdateScenarioCanary", ctx, transaction, scenario.Id, models.UpdateScenarioCanaryInput{}).Return(nil)
+
+	publisher := ScenarioPublisher{
+		Repository:                     repo,
+		ScenarioPublicationsRepository: spr,
+	}
+
+	publications, err := publisher.PublishOrUnpublishIteration(ctx, transaction,
+		scenarioAndIteration, models.RollbackCanary)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ScenarioPublication{publication}, publications)
+
+	other := scenarioAndIteration
+	other.Iteration.Id = pure_utils.NewId().String()
+	_, err = publisher.PublishOrUnpublishIteration(ctx, transaction, other, models.RollbackCanary)
+	assert.ErrorIs(t, err, models.BadParameterError)
+
+	spr.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
+
+func TestScenarioPublisher_PublishOrUnpublishIteration_promote_canary(t *testing.T) {
+	scenarioAndIteration := canaryScenarioAndIteration()
+	scenarioAndIteration.Scenario.CanaryIterationId = utils.Ptr(scenarioAndIteration.Iteration.Id)
+	scenarioAndIteration.Scenario.CanaryPercentage = 10
+	scenario := scenarioAndIteration.Scenario
+	iterationId := scenarioAndIteration.Iteration.Id
+
+	transaction := new(mocks.Transaction)
+	ctx := context.Background()
+
+	spr := new(mocks.ScenarioPublicationRepository)
+	spr.On("CreateScenarioPublication", ctx, transaction, mock.MatchedBy(
+		func(input models.CreateScenarioPublicationInput) bool {
+			return input.PublicationAction == models.Unpublish && input.ScenarioIterationId == *scenario.LiveVersionID
+		}), mock.MatchedBy(isPublicationId)).Return(nil).Once()
+	spr.On("CreateScenarioPublication", ctx, transaction, mock.MatchedBy(
+		func(input models.CreateScenarioPublicationInput) bool {
+			return input.PublicationAction == models.Publish && input.ScenarioIterationId == iterationId
+		}), mock.MatchedBy(isPublicationId)).Return(nil).Once()
+	spr.On("GetScenarioPublicationById", ctx, transaction, mock.MatchedBy(isPublicationId)).
+		Return(models.ScenarioPublication{}, nil)
+
+	repo := new(mocks.ScenarioPublisherRepository)
+	repo.On("UpdateScenarioCanary", ctx, transaction, scenario.Id, models.UpdateScenarioCanaryInput{}).Return(nil).Once()
+	repo.On("UpdateScenarioLiveIterationId", ctx, transaction, scenario.Id, (*string)(nil)).Return(nil).Once()
+	repo.On("UpdateScenarioLiveIterationId", ctx, transaction, scenario.Id, &iterationId).Return(nil).Once()
+
+	str := new(mocks.ScenarioTestrunRepository)
+	str.On("GetTestRunByLiveVersionID", ctx, transaction, mock.Anything).Return(nil, nil)
+
+	publisher := ScenarioPublisher{
+		Repository:                     repo,
+		ValidateScenarioIteration:      validateScenarioIterationStub{},
+		ScenarioPublicationsRepository: spr,
+		ScenarioTestRunRepository:      str,
+	}
+
+	publications, err := publisher.PublishOrUnpublishIteration(ctx, transaction,
+		scenarioAndIteration, models.PromoteCanary)
+	assert.NoError(t, err)
+	assert.Len(t, publications, 2, "promoting does not record a rollback")
+
+	spr.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
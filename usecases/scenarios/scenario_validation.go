@@ -318,6 +318,16 @@ func (validator *AstValidatorImpl) MakeDryRunEnvironment(ctx context.Context,
 		}
 	}
 
+	return MakeDryRunEnvironmentWithDataModel(validator.AstEvaluationEnvironmentFactory, scenario, dataModel)
+}
+
+// MakeDryRunEnvironmentWithDataModel builds the dry run environment of a scenario against a given data
+// model, which may differ from the current one, to check how formulas would evaluate once it is changed.
+func MakeDryRunEnvironmentWithDataModel(
+	factory ast_eval.AstEvaluationEnvironmentFactory,
+	scenario models.Scenario,
+	dataModel models.DataModel,
+) (ast_eval.AstEvaluationEnvironment, *models.ScenarioValidationError) {
 	table, ok := dataModel.Tables[scenario.TriggerObjectType]
 	if !ok {
 		return ast_eval.AstEvaluationEnvironment{}, &models.ScenarioValidationError{
@@ -332,8 +342,8 @@ func (validator *AstValidatorImpl) MakeDryRunEnvironment(ctx context.Context,
 		Data:      evaluate.DryRunPayload(table),
 	}
 
-	env := validator.AstEvaluationEnvironmentFactory(ast_eval.EvaluationEnvironmentFactoryParams{
-		OrganizationId:                organizationId,
+	env := factory(ast_eval.EvaluationEnvironmentFactoryParams{
+		OrganizationId:                scenario.OrganizationId,
 		ClientObject:                  clientObject,
 		DataModel:                     dataModel,
 		DatabaseAccessReturnFakeValue: true,
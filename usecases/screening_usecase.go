@@ -12,6 +12,7 @@ import (
 	"mime/multipart"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/checkmarble/marble-backend/dto"
 	"github.com/checkmarble/marble-backend/models"
@@ -98,11 +99,13 @@ type ScreeningRepository interface {
 	CopyScreeningFiles(ctx context.Context, exec repositories.Executor,
 		screeningId, newScreeningId string) error
 	AddScreeningMatchWhitelist(ctx context.Context, exec repositories.Executor,
-		orgId uuid.UUID, counterpartyId string, entityId string, reviewerId *models.UserId) error
+		input models.ScreeningWhitelistCreateInput) error
 	DeleteScreeningMatchWhitelist(ctx context.Context, exec repositories.Executor,
 		orgId uuid.UUID, counterpartyId *string, entityId string, reviewerId *models.UserId) error
 	SearchScreeningMatchWhitelist(ctx context.Context, exec repositories.Executor,
 		orgId uuid.UUID, counterpartyId, entityId *string) ([]models.ScreeningWhitelist, error)
+	ListScreeningMatchWhitelists(ctx context.Context, exec repositories.Executor,
+		orgId uuid.UUID, filters models.ScreeningWhitelistSearchFilters) ([]models.ScreeningWhitelist, error)
 	UpdateScreeningMatchPayload(ctx context.Context, exec repositories.Executor,
 		match models.ScreeningMatch, newPayload []byte) (models.ScreeningMatch, error)
 	SetScreeningMatchEnriched(ctx context.Context, exec repositories.Executor,
@@ -435,7 +438,7 @@ func (uc ScreeningUsecase) Refine(ctx context.Context, refine models.ScreeningRe
 
 	counterpartyIdentifier := getScreeningCounterpartyIdentifier(sc)
 	whitelist, err := uc.getWhitelistFromScreening(ctx, uc.executorFactory.NewExecutor(),
-		decision.OrganizationId, counterpartyIdentifier)
+		decision, sc.Screening)
 	if err != nil {
 		return models.ScreeningWithMatches{}, err
 	}
@@ -522,7 +525,7 @@ func (uc ScreeningUsecase) Search(ctx context.Context, refine models.ScreeningRe
 	}
 
 	whitelist, err := uc.getWhitelistFromScreening(ctx, uc.executorFactory.NewExecutor(),
-		decision.OrganizationId, getScreeningCounterpartyIdentifier(sc))
+		decision, sc.Screening)
 	if err != nil {
 		return models.ScreeningWithMatches{}, err
 	}
@@ -877,10 +880,15 @@ func (uc ScreeningUsecase) UpdateMatchStatus(
 
 			if update.Status == models.ScreeningMatchStatusNoHit && update.Whitelist &&
 				data.sanction.UniqueCounterpartyIdentifier != nil {
-				if err := uc.CreateWhitelist(ctx, tx,
-					data.decision.OrganizationId,
-					*data.sanction.UniqueCounterpartyIdentifier,
-					data.match.EntityId, update.ReviewerId); err != nil {
+				if err := uc.CreateWhitelist(ctx, tx, models.ScreeningWhitelistCreateInput{
+					OrgId:          data.decision.OrganizationId,
+					CounterpartyId: *data.sanction.UniqueCounterpartyIdentifier,
+					EntityId:       data.match.EntityId,
+					Scope: update.WhitelistOptions.Scope(
+						screeningWhitelistScope(data.decision, data.sanction)),
+					ExpiresAt:     update.WhitelistOptions.ExpiresAt,
+					WhitelistedBy: update.ReviewerId,
+				}); err != nil {
 					return errors.Wrap(err, "could not whitelist match")
 				}
 			}
@@ -901,17 +909,25 @@ func (uc ScreeningUsecase) UpdateMatchStatus(
 }
 
 func (uc ScreeningUsecase) CreateWhitelist(ctx context.Context, exec repositories.Executor,
-	orgId uuid.UUID, counterpartyId, entityId string, reviewerId *models.UserId,
+	input models.ScreeningWhitelistCreateInput,
 ) error {
 	if err := uc.enforceSecurity.WriteWhitelist(ctx); err != nil {
 		return err
 	}
 
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return errors.WithDetail(models.BadParameterError, "whitelist expiry date must be in the future")
+	}
+	if (input.Scope.ObjectType == nil) != (input.Scope.ObjectId == nil) {
+		return errors.WithDetail(models.BadParameterError,
+			"whitelist object scope requires both an object type and an object id")
+	}
+
 	if exec == nil {
 		exec = uc.executorFactory.NewExecutor()
 	}
 
-	if err := uc.repository.AddScreeningMatchWhitelist(ctx, exec, orgId, counterpartyId, entityId, reviewerId); err != nil {
+	if err := uc.repository.AddScreeningMatchWhitelist(ctx, exec, input); err != nil {
 		return err
 	}
 
@@ -955,6 +971,19 @@ func (uc ScreeningUsecase) SearchWhitelist(ctx context.Context, exec repositorie
 	return whitelists, nil
 }
 
+// ListWhitelists lists the whitelist entries of the organization. Expired entries, which no
+// longer apply, are only returned on demand so they can be reviewed and renewed.
+func (uc ScreeningUsecase) ListWhitelists(ctx context.Context, orgId uuid.UUID,
+	filters models.ScreeningWhitelistSearchFilters,
+) ([]models.ScreeningWhitelist, error) {
+	if err := uc.enforceSecurity.ReadWhitelist(ctx); err != nil {
+		return nil, err
+	}
+
+	return uc.repository.ListScreeningMatchWhitelists(ctx,
+		uc.executorFactory.NewExecutor(), orgId, filters)
+}
+
 func (uc ScreeningUsecase) MatchAddComment(ctx context.Context, matchId string,
 	comment models.ScreeningMatchComment,
 ) (models.ScreeningMatchComment, error) {
@@ -1309,24 +1338,27 @@ func (uc ScreeningUsecase) enforceCanReadOrUpdateScreeningMatch(
 func (uc ScreeningUsecase) getWhitelistFromScreening(
 	ctx context.Context,
 	exec repositories.Executor,
-	orgId uuid.UUID,
-	counterpartyIdentifier *string,
+	decision models.Decision,
+	screening models.Screening,
 ) ([]string, error) {
-	if counterpartyIdentifier == nil {
+	if screening.UniqueCounterpartyIdentifier == nil {
 		return nil, nil
 	}
 
 	whitelistEntries, err := uc.repository.SearchScreeningMatchWhitelist(
 		ctx,
 		exec,
-		orgId,
-		counterpartyIdentifier,
+		decision.OrganizationId,
+		screening.UniqueCounterpartyIdentifier,
 		nil,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	whitelistEntries = models.FilterApplicableScreeningWhitelists(whitelistEntries,
+		screeningWhitelistScope(decision, screening), time.Now())
+
 	whitelist := pure_utils.Map(whitelistEntries, func(entry models.ScreeningWhitelist) string {
 		return entry.EntityId
 	})
@@ -1354,6 +1386,15 @@ func mergePayloads(originalRaw, newRaw []byte) ([]byte, error) {
 	return out, nil
 }
 
+// screeningWhitelistScope is the context of a scenario screening: its config and the object
+// that triggered the decision.
+func screeningWhitelistScope(decision models.Decision, screening models.Screening) models.ScreeningWhitelistScope {
+	objectId, _ := decision.ClientObject.Data["object_id"].(string)
+
+	return models.NewScreeningWhitelistScope(screening.Config.StableId,
+		decision.ClientObject.TableName, objectId)
+}
+
 func getScreeningCounterpartyIdentifier(screening models.ScreeningWithMatches) *string {
 	return screening.UniqueCounterpartyIdentifier
 }
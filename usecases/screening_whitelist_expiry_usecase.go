@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/usecases/worker_jobs"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/riverqueue/river"
+)
+
+const (
+	SCREENING_WHITELIST_EXPIRY_INTERVAL = 1 * time.Hour
+	screeningWhitelistExpiryBatchSize   = 1000
+)
+
+type ScreeningWhitelistExpiryRepository interface {
+	ListScreeningWhitelistsToNotifyOfExpiry(ctx context.Context, exec repositories.Executor,
+		orgId uuid.UUID, expiresBefore time.Time, limit int) ([]models.ScreeningWhitelist, error)
+	MarkScreeningWhitelistExpiryNotified(ctx context.Context, exec repositories.Executor, id string) error
+}
+
+// ScreeningWhitelistExpiryUsecase reports the whitelist entries nearing their expiry, so that they
+// are re-reviewed and renewed if they still hold. Each entry is reported once with a webhook event,
+// until it is renewed.
+type ScreeningWhitelistExpiryUsecase struct {
+	executorFactory     executor_factory.ExecutorFactory
+	transactionFactory  executor_factory.TransactionFactory
+	repository          ScreeningWhitelistExpiryRepository
+	webhookEventsSender webhookEventsUsecase
+}
+
+func NewScreeningWhitelistExpiryUsecase(
+	executorFactory executor_factory.ExecutorFactory,
+	transactionFactory executor_factory.TransactionFactory,
+	repository ScreeningWhitelistExpiryRepository,
+	webhookEventsSender webhookEventsUsecase,
+) ScreeningWhitelistExpiryUsecase {
+	return ScreeningWhitelistExpiryUsecase{
+		executorFactory:     executorFactory,
+		transactionFactory:  transactionFactory,
+		repository:          repository,
+		webhookEventsSender: webhookEventsSender,
+	}
+}
+
+func (uc ScreeningWhitelistExpiryUsecase) NotifyExpiringScreeningWhitelists(ctx context.Context, orgId uuid.UUID) error {
+	logger := utils.LoggerFromContext(ctx)
+
+	entries, err := uc.repository.ListScreeningWhitelistsToNotifyOfExpiry(ctx, uc.executorFactory.NewExecutor(),
+		orgId, time.Now().Add(models.SCREENING_WHITELIST_EXPIRY_NOTICE), screeningWhitelistExpiryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err := uc.transactionFactory.Transaction(ctx, func(tx repositories.Transaction) error {
+			if err := uc.repository.MarkScreeningWhitelistExpiryNotified(ctx, tx, entry.Id); err != nil {
+				return err
+			}
+			return uc.webhookEventsSender.CreateWebhookEvent(ctx, tx, models.WebhookEventCreate{
+				OrganizationId: orgId,
+				EventContent:   models.NewWebhookEventScreeningWhitelistExpiring(entry),
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(entries) > 0 {
+		logger.InfoContext(ctx, "reported screening whitelist entries nearing their expiry",
+			"org_id", orgId, "count", len(entries))
+	}
+
+	return nil
+}
+
+func NewScreeningWhitelistExpiryPeriodicJob(orgId uuid.UUID) *river.PeriodicJob {
+	return worker_jobs.NewPeriodicJob(
+		river.PeriodicInterval(SCREENING_WHITELIST_EXPIRY_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.ScreeningWhitelistExpiryArgs{
+				OrgId: orgId,
+			}, &river.InsertOpts{
+				Queue: orgId.String(),
+				UniqueOpts: river.UniqueOpts{
+					ByQueue:  true,
+					ByPeriod: SCREENING_WHITELIST_EXPIRY_INTERVAL,
+				},
+			}
+		},
+	)
+}
+
+type ScreeningWhitelistExpiryWorker struct {
+	river.WorkerDefaults[models.ScreeningWhitelistExpiryArgs]
+	usecase ScreeningWhitelistExpiryUsecase
+}
+
+func NewScreeningWhitelistExpiryWorker(usecase ScreeningWhitelistExpiryUsecase) *ScreeningWhitelistExpiryWorker {
+	return &ScreeningWhitelistExpiryWorker{usecase: usecase}
+}
+
+func (w *ScreeningWhitelistExpiryWorker) Work(ctx context.Context,
+	job *river.Job[models.ScreeningWhitelistExpiryArgs],
+) error {
+	return w.usecase.NotifyExpiringScreeningWhitelists(ctx, job.Args.OrgId)
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyExpiringScreeningWhitelists(t *testing.T) {
+	executorFactory := executor_factory.NewExecutorFactoryStub()
+	transactionFactory := executor_factory.NewTransactionFactoryStub(executorFactory)
+	repository := new(mocks.ScreeningWhitelistExpiryRepository)
+	webhooks := new(mocks.WebhookEventsUsecase)
+	uc := NewScreeningWhitelistExpiryUsecase(executorFactory, transactionFactory, repository, webhooks)
+
+	orgId := uuid.New()
+	entry := models.ScreeningWhitelist{
+		Id:             uuid.NewString(),
+		OrgId:          orgId,
+		CounterpartyId: "counterparty",
+		EntityId:       "entity",
+		ExpiresAt:      utils.Ptr(time.Now().Add(24 * time.Hour)),
+	}
+
+	repository.On("ListScreeningWhitelistsToNotifyOfExpiry", mock.Anything, mock.Anything, orgId,
+		mock.MatchedBy(func(expiresBefore time.Time) bool {
+			return expiresBefore.After(time.Now().Add(models.SCREENING_WHITELIST_EXPIRY_NOTICE - time.Minute))
+		}), screeningWhitelistExpiryBatchSize).
+		Return([]models.ScreeningWhitelist{entry}, nil)
+	repository.On("MarkScreeningWhitelistExpiryNotified", mock.Anything, mock.Anything, entry.Id).Return(nil)
+	webhooks.On("CreateWebhookEvent", mock.Anything, mock.Anything,
+		mock.MatchedBy(func(input models.WebhookEventCreate) bool {
+			return input.OrganizationId == orgId &&
+				input.EventContent.Type == models.WebhookEventType_ScreeningWhitelistExpiring &&
+				input.EventContent.Data.Content.ScreeningWhitelist.Id == entry.Id
+		})).Return(nil)
+
+	err := uc.NotifyExpiringScreeningWhitelists(context.Background(), orgId)
+
+	require.NoError(t, err)
+	repository.AssertExpectations(t)
+	webhooks.AssertExpectations(t)
+}
@@ -10,6 +10,7 @@ import (
 type EnforceSecurityIngestion interface {
 	EnforceSecurity
 	CanIngest(organizationId uuid.UUID) error
+	ReadIngestionDeadLetter(deadLetter models.IngestionDeadLetter) error
 }
 
 type EnforceSecurityIngestionImpl struct {
@@ -23,3 +24,12 @@ func (e *EnforceSecurityIngestionImpl) CanIngest(organizationId uuid.UUID) error
 		e.ReadOrganization(organizationId),
 	)
 }
+
+// ReadIngestionDeadLetter checks access to a rejected payload, which holds data as sensitive as the
+// ingested objects, so it requires the same permission as ingesting it.
+func (e *EnforceSecurityIngestionImpl) ReadIngestionDeadLetter(deadLetter models.IngestionDeadLetter) error {
+	return errors.Join(
+		e.Permission(models.INGESTION),
+		e.ReadOrganization(deadLetter.OrganizationId),
+	)
+}
@@ -36,3 +36,17 @@ func (e *EnforceSecurityImpl) ReadRuleSnooze(ctx context.Context, snooze models.
 		utils.EnforceOrganizationAccess(e.Credentials, snooze.OrganizationId),
 	)
 }
+
+func (e *EnforceSecurityImpl) ReadSnoozesOfRule(ctx context.Context, rule models.Rule) error {
+	return errors.Join(
+		e.Permission(models.READ_SNOOZES),
+		utils.EnforceOrganizationAccess(e.Credentials, rule.OrganizationId),
+	)
+}
+
+func (e *EnforceSecurityImpl) ManageSnoozesOfRule(ctx context.Context, rule models.Rule) error {
+	return errors.Join(
+		e.Permission(models.CREATE_SNOOZE),
+		utils.EnforceOrganizationAccess(e.Credentials, rule.OrganizationId),
+	)
+}
@@ -197,7 +197,13 @@ func listOrgPeriodics(
 			// TODO: Configurable per Org
 			csCreateFullDatasetInterval,
 		),
+		continuous_screening.NewContinuousScreeningAttestationReportPeriodicJob(org.Id),
+		continuous_screening.NewContinuousScreeningCoverageAnalysisPeriodicJob(org.Id),
 		worker_jobs.NewScheduledScenarioPeriodicJob(org.Id),
+		NewDataRetentionPeriodicJob(org.Id),
+		NewIngestionSourcePollPeriodicJob(org.Id),
+		NewScenarioPublicationSchedulePeriodicJob(org.Id),
+		NewScreeningWhitelistExpiryPeriodicJob(org.Id),
 	}
 	if offloadingConfig.Enabled {
 		// Undocumented debug setting to only enable offloading for a specific organization
@@ -288,6 +294,14 @@ func QueueWebhookCleanup() map[string]river.QueueConfig {
 	return queues
 }
 
+func QueueIngestionDeadLetterCleanup() map[string]river.QueueConfig {
+	queues := make(map[string]river.QueueConfig, 1)
+	queues[worker_jobs.INGESTION_DEAD_LETTER_CLEANUP_QUEUE] = river.QueueConfig{
+		MaxWorkers: 1,
+	}
+	return queues
+}
+
 func QueueAsyncDecisionCleanup() map[string]river.QueueConfig {
 	queues := make(map[string]river.QueueConfig, 1)
 	queues[worker_jobs.ASYNC_DECISION_CLEANUP_QUEUE] = river.QueueConfig{
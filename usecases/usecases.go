@@ -1,6 +1,7 @@
 package usecases
 
 import (
+	"context"
 	"io/fs"
 	"time"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/checkmarble/marble-backend/infra"
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/models/ast"
+	"github.com/checkmarble/marble-backend/pure_utils"
 	"github.com/checkmarble/marble-backend/repositories"
 	"github.com/checkmarble/marble-backend/repositories/idp"
 	"github.com/checkmarble/marble-backend/usecases/ast_eval"
@@ -53,6 +55,7 @@ type Usecases struct {
 	csCreateFullDatasetInterval  time.Duration
 	allowInsecureWebhookURLs     bool   // Allow HTTP webhook URLs (dev only)
 	webhookIPWhitelist           string // Comma-separated CIDR ranges to whitelist for webhooks
+	ingestionSourceBucketUrls    string // Comma-separated bucket urls ingestion sources may read from
 	screeningOffloadingEnabled   bool
 	aiPromptsServingDir          string
 	aiPromptsFS                  fs.FS
@@ -209,6 +212,16 @@ func WithWebhookIPWhitelist(whitelist string) Option {
 	}
 }
 
+// WithIngestionSourceBucketUrls sets a comma-separated list of the bucket urls that ingestion
+// sources may read from, e.g. "s3://client-extracts,gs://core-banking-exports". Sources use them as
+// is, and each organization reads under its own directory, named after its id. Sources cannot be
+// configured on an instance that allows none.
+func WithIngestionSourceBucketUrls(bucketUrls string) Option {
+	return func(o *options) {
+		o.ingestionSourceBucketUrls = bucketUrls
+	}
+}
+
 func WithIpEnrichmentDatabase(db *maxminddb.Reader) Option {
 	return func(o *options) {
 		o.ipEnricher = db
@@ -263,6 +276,7 @@ type options struct {
 	allowInsecureWebhookURLs     bool
 	screeningOffloadingEnabled   bool
 	webhookIPWhitelist           string
+	ingestionSourceBucketUrls    string
 	ipEnricher                   *maxminddb.Reader
 	aiPromptsServingDir          string
 	aiPromptsFS                  fs.FS
@@ -303,6 +317,7 @@ func newUsecasesWithOptions(repositories repositories.Repositories, o *options)
 		csCreateFullDatasetInterval:  o.csCreateFullDatasetInterval,
 		allowInsecureWebhookURLs:     o.allowInsecureWebhookURLs,
 		webhookIPWhitelist:           o.webhookIPWhitelist,
+		ingestionSourceBucketUrls:    o.ingestionSourceBucketUrls,
 		screeningOffloadingEnabled:   o.screeningOffloadingEnabled,
 		aiPromptsServingDir:          o.aiPromptsServingDir,
 		aiPromptsFS:                  o.aiPromptsFS,
@@ -449,12 +464,25 @@ func (usecases *Usecases) AstEvaluationEnvironmentFactory(params ast_eval.Evalua
 			ExecutorFactory:            usecases.NewExecutorFactory(),
 			IngestedDataReadRepository: usecases.Repositories.IngestedDataReadRepository,
 			ReturnFakeValue:            params.DatabaseAccessReturnFakeValue,
+			AsOf:                       params.DatabaseAccessAsOf,
 		},
 	)
 
 	environment.AddEvaluator(ast.FUNC_PAYLOAD,
 		evaluate.NewPayload(ast.FUNC_PAYLOAD, params.ClientObject))
 
+	nameNormalizer := evaluate.NewCachedNameNormalizerLoader(
+		func(ctx context.Context) (pure_utils.NameNormalizer, error) {
+			org, err := usecases.Repositories.MarbleDbRepository.GetOrganizationById(ctx,
+				usecases.NewExecutorFactory().NewExecutor(), params.OrganizationId)
+			if err != nil {
+				return pure_utils.NameNormalizer{}, err
+			}
+			return org.OpenSanctionsConfig.NameNormalizer(), nil
+		})
+	environment.ReplaceEvaluator(ast.FUNC_FUZZY_MATCH, evaluate.FuzzyMatch{NameNormalizer: nameNormalizer})
+	environment.ReplaceEvaluator(ast.FUNC_FUZZY_MATCH_ANY_OF, evaluate.FuzzyMatchAnyOf{NameNormalizer: nameNormalizer})
+
 	environment.AddEvaluator(ast.FUNC_AGGREGATOR, evaluate.AggregatorEvaluator{
 		OrganizationId:             params.OrganizationId,
 		DataModel:                  params.DataModel,
@@ -462,6 +490,7 @@ func (usecases *Usecases) AstEvaluationEnvironmentFactory(params ast_eval.Evalua
 		ExecutorFactory:            usecases.NewExecutorFactory(),
 		IngestedDataReadRepository: usecases.Repositories.IngestedDataReadRepository,
 		ReturnFakeValue:            params.DatabaseAccessReturnFakeValue,
+		AsOf:                       params.DatabaseAccessAsOf,
 	})
 
 	environment.AddEvaluator(ast.FUNC_FILTER, evaluate.FilterEvaluator{
@@ -673,6 +702,25 @@ func (usecases *Usecases) NewContinuousScreeningCreateFullDatasetWorker() *conti
 	)
 }
 
+func (usecases *Usecases) NewContinuousScreeningAttestationReportWorker() *continuous_screening.AttestationReportWorker {
+	return continuous_screening.NewAttestationReportWorker(
+		usecases.NewExecutorFactory(),
+		usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+		usecases.Repositories.BlobRepository,
+		usecases.continuousScreeningBucketUrl,
+	)
+}
+
+func (usecases *Usecases) NewContinuousScreeningCoverageAnalysisWorker() *continuous_screening.CoverageAnalysisWorker {
+	return continuous_screening.NewCoverageAnalysisWorker(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+	)
+}
+
 func (usecases *Usecases) NewPayloadEnrichmentUsecase() payload_parser.PayloadEnrichementUsecase {
 	return payload_parser.NewPayloadEnrichmentUsecase(
 		usecases.coordsEnricher,
@@ -226,6 +226,7 @@ func (usecases *UsecasesWithCreds) NewScenarioEvaluator() evaluate_scenario.Scen
 		usecases.Repositories.IngestedDataReadRepository,
 		usecases.NewEvaluateAstExpression(),
 		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
 		usecases.NewFeatureAccessReader(),
 		usecases.Repositories.NameRecognitionRepository,
 	)
@@ -341,9 +342,60 @@ func (usecases *UsecasesWithCreds) NewScenarioPublicationUsecase() *ScenarioPubl
 		usecases.NewFeatureAccessReader(),
 		usecases.Repositories.OpenSanctionsRepository,
 		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+	)
+}
+
+func (usecases *UsecasesWithCreds) NewScenarioPublicationRequestUsecase() ScenarioPublicationRequestUsecase {
+	return NewScenarioPublicationRequestUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceScenarioSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.NewScenarioFetcher(),
+		usecases.NewScenarioPublicationUsecase(),
+		usecases.Repositories.TaskQueueRepository,
+		usecases.NewWebhookEventsUsecase(),
+	)
+}
+
+func (usecases UsecasesWithCreds) NewScenarioPublicationRequestWorker() *ScenarioPublicationRequestWorker {
+	return NewScenarioPublicationRequestWorker(usecases.NewScenarioPublicationRequestUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewScenarioPublicationScheduleUsecase() ScenarioPublicationScheduleUsecase {
+	return NewScenarioPublicationScheduleUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceScenarioSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.NewScenarioFetcher(),
+		usecases.NewScenarioPublicationUsecase(),
+		usecases.NewWebhookEventsUsecase(),
+	)
+}
+
+func (usecases UsecasesWithCreds) NewScenarioPublicationScheduleWorker() *ScenarioPublicationScheduleWorker {
+	return NewScenarioPublicationScheduleWorker(usecases.NewScenarioPublicationScheduleUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewScreeningWhitelistExpiryUsecase() ScreeningWhitelistExpiryUsecase {
+	return NewScreeningWhitelistExpiryUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.NewWebhookEventsUsecase(),
 	)
 }
 
+func (usecases UsecasesWithCreds) NewScreeningWhitelistExpiryWorker() *ScreeningWhitelistExpiryWorker {
+	return NewScreeningWhitelistExpiryWorker(usecases.NewScreeningWhitelistExpiryUsecase())
+}
+
 func (usecases *UsecasesWithCreds) NewClientDbIndexEditor() indexes.ClientDbIndexEditor {
 	return indexes.NewClientDbIndexEditor(
 		usecases.NewExecutorFactory(),
@@ -394,6 +446,7 @@ func (usecases *UsecasesWithCreds) NewAnalyticsSettingsUsecase() AnalyticsSettin
 }
 
 func (usecases *UsecasesWithCreds) NewIngestionUseCase() IngestionUseCase {
+	asyncDecisionUsecase := usecases.NewAsyncDecisionExecutionUsecase()
 	return IngestionUseCase{
 		enforceSecurity:                     usecases.NewEnforceIngestionSecurity(),
 		transactionFactory:                  usecases.NewTransactionFactory(),
@@ -403,12 +456,16 @@ func (usecases *UsecasesWithCreds) NewIngestionUseCase() IngestionUseCase {
 		blobRepository:                      usecases.Repositories.BlobRepository,
 		dataModelRepository:                 usecases.Repositories.MarbleDbRepository,
 		uploadLogRepository:                 usecases.Repositories.UploadLogRepository,
+		deadLetterRepository:                usecases.Repositories.MarbleDbRepository,
 		payloadEnricher:                     usecases.Usecases.NewPayloadEnrichmentUsecase(),
 		ingestionBucketUrl:                  usecases.ingestionBucketUrl,
 		continuousScreeningRepository:       usecases.Repositories.MarbleDbRepository,
 		continuousScreeningClientRepository: &usecases.Repositories.ClientDbRepository,
+		scenarioRepository:                  usecases.Repositories.MarbleDbRepository,
+		asyncDecisionCreator:                &asyncDecisionUsecase,
 		batchIngestionMaxSize:               usecases.Usecases.batchIngestionMaxSize,
 		taskEnqueuer:                        usecases.Repositories.TaskQueueRepository,
+		evaluateAstExpression:               usecases.NewEvaluateAstExpression(),
 		isManagedMarble:                     usecases.license.IsManagedMarble,
 	}
 }
@@ -622,6 +679,8 @@ func (usecases *UsecasesWithCreds) NewRuleSnoozeUsecase() RuleSnoozeUsecase {
 		usecases.Repositories.MarbleDbRepository,
 		usecases.Repositories.MarbleDbRepository,
 		security.NewEnforceSecurity(usecases.Credentials),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.AstEvaluationEnvironmentFactory,
 	)
 }
 
@@ -936,6 +995,28 @@ func (usecases *UsecasesWithCreds) NewContinuousScreeningUsecase() *continuous_s
 	)
 }
 
+func (usecases *UsecasesWithCreds) NewContinuousScreeningAttestationReportUsecase() *continuous_screening.ContinuousScreeningAttestationReportUsecase {
+	return continuous_screening.NewContinuousScreeningAttestationReportUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewEnforceSecurityContinuousScreening(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.NewFeatureAccessReader(),
+		usecases.Repositories.BlobRepository,
+		usecases.continuousScreeningBucketUrl,
+	)
+}
+
+func (usecases *UsecasesWithCreds) NewContinuousScreeningCoverageAnalysisUsecase() *continuous_screening.ContinuousScreeningCoverageAnalysisUsecase {
+	return continuous_screening.NewContinuousScreeningCoverageAnalysisUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceSecurityContinuousScreening(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.NewFeatureAccessReader(),
+	)
+}
+
 func (usecases *UsecasesWithCreds) NewContinuousScreeningDoScreeningWorker() *continuous_screening.DoScreeningWorker {
 	return continuous_screening.NewDoScreeningWorker(
 		usecases.NewExecutorFactory(),
@@ -1071,6 +1152,13 @@ func (usecases UsecasesWithCreds) NewWebhookCleanupWorker() *worker_jobs.Webhook
 	)
 }
 
+func (usecases UsecasesWithCreds) NewIngestionDeadLetterCleanupWorker() *worker_jobs.IngestionDeadLetterCleanupWorker {
+	return worker_jobs.NewIngestionDeadLetterCleanupWorker(
+		usecases.Repositories.MarbleDbRepository,
+		usecases.NewExecutorFactory(),
+	)
+}
+
 func (usecases UsecasesWithCreds) NewScoreComputationWorker() *scoring_jobs.ScoreComputationWorker {
 	return scoring_jobs.NewScoreComputationWorker(
 		usecases.NewExecutorFactory(),
@@ -1159,6 +1247,123 @@ func (usecases *UsecasesWithCreds) NewDataModelDestroyUsecase() DataModelDestroy
 	)
 }
 
+func (usecases *UsecasesWithCreds) NewDataModelFieldTypeUsecase() DataModelFieldTypeUsecase {
+	return NewDataModelFieldTypeUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceOrganizationSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+		usecases.Repositories.OrganizationSchemaRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.AstEvaluationEnvironmentFactory,
+	)
+}
+
+func (usecases UsecasesWithCreds) NewFieldTypeMigrationWorker() *FieldTypeMigrationWorker {
+	return NewFieldTypeMigrationWorker(usecases.NewDataModelFieldTypeUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewDerivedFieldUsecase() DerivedFieldUsecase {
+	return NewDerivedFieldUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceOrganizationSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.NewEvaluateAstExpression(),
+		usecases.AstEvaluationEnvironmentFactory,
+	)
+}
+
+func (usecases UsecasesWithCreds) NewDerivedFieldBackfillWorker() *DerivedFieldBackfillWorker {
+	return NewDerivedFieldBackfillWorker(usecases.NewDerivedFieldUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewDataSubjectErasureUsecase() DataSubjectErasureUsecase {
+	return NewDataSubjectErasureUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceOrganizationSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.Repositories.BlobRepository,
+		usecases.offloadingBucketUrl,
+	)
+}
+
+func (usecases UsecasesWithCreds) NewDataSubjectErasureWorker() *DataSubjectErasureWorker {
+	return NewDataSubjectErasureWorker(usecases.NewDataSubjectErasureUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewDataRetentionUsecase() DataRetentionUsecase {
+	return NewDataRetentionUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceOrganizationSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		&usecases.Repositories.ClientDbRepository,
+		usecases.NewClientDbIndexEditor(),
+		usecases.Repositories.BlobRepository,
+		usecases.offloadingBucketUrl,
+	)
+}
+
+func (usecases UsecasesWithCreds) NewDataRetentionWorker() *DataRetentionWorker {
+	return NewDataRetentionWorker(usecases.NewDataRetentionUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewIngestionSourceUsecase() IngestionSourceUsecase {
+	return NewIngestionSourceUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceOrganizationSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.UploadLogRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.Repositories.BlobRepository,
+		usecases.ingestionBucketUrl,
+		usecases.ingestionSourceBucketUrls,
+		[]string{
+			usecases.offloadingBucketUrl,
+			usecases.caseManagerBucketUrl,
+			usecases.continuousScreeningBucketUrl,
+			usecases.analyticsConfig.BucketUrl,
+		},
+	)
+}
+
+func (usecases UsecasesWithCreds) NewIngestionSourcePollWorker() *IngestionSourcePollWorker {
+	return NewIngestionSourcePollWorker(usecases.NewIngestionSourceUsecase())
+}
+
+func (usecases *UsecasesWithCreds) NewScenarioBacktestUsecase() ScenarioBacktestUsecase {
+	return NewScenarioBacktestUsecase(
+		usecases.NewExecutorFactory(),
+		usecases.NewTransactionFactory(),
+		usecases.NewEnforceTestRunScenarioSecurity(),
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.MarbleDbRepository,
+		usecases.Repositories.TaskQueueRepository,
+		usecases.NewScenarioEvaluator(),
+	)
+}
+
+func (usecases UsecasesWithCreds) NewScenarioBacktestWorker() *ScenarioBacktestWorker {
+	return NewScenarioBacktestWorker(usecases.NewScenarioBacktestUsecase())
+}
+
 func (usecases *UsecasesWithCreds) NewPublicApiAdapterUsecase() PublicApiAdapterUsecase {
 	return PublicApiAdapterUsecase{
 		enforceSecurity: usecases.NewEnforceOrganizationSecurity(),
@@ -228,6 +228,24 @@ RepeatLoop:
 
 					return err
 				})
+
+				wg.Go(func() error {
+					req := repositories.AnalyticsCopyRequest{
+						OrgId:         job.Args.OrgId,
+						Table:         w.analyticsFactory.BuildTablePrefix("decision_case_outcomes"),
+						TriggerObject: table.Name,
+						EndTime:       job.CreatedAt,
+						Limit:         w.config.ExportBatchSize,
+					}
+
+					nRows, err := w.exportDecisionCaseOutcomes(ctx, exec, req)
+
+					if nRows > 0 {
+						insertedRows = true
+					}
+
+					return err
+				})
 			}
 
 			if err := wg.Wait(); err != nil {
@@ -382,3 +400,32 @@ func (w AnalyticsExportWorker) exportCaseEvents(
 
 	return nRows, nil
 }
+
+func (w AnalyticsExportWorker) exportDecisionCaseOutcomes(
+	ctx context.Context,
+	exec repositories.AnalyticsExecutor,
+	req repositories.AnalyticsCopyRequest,
+) (nRows int, err error) {
+	start := time.Now()
+	var startWatermark time.Time
+	defer func() {
+		logExportResult(ctx, "decision case outcomes", start, nRows, startWatermark, err)
+	}()
+
+	var id uuid.UUID
+	id, startWatermark, err = repositories.AnalyticsGetLatestRow(ctx, exec,
+		req.OrgId, req.TriggerObject,
+		w.analyticsFactory.BuildTarget("decision_case_outcomes", req.OrgId, req.TriggerObject))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get latest exported row")
+	}
+
+	req.Watermark = &models.Watermark{WatermarkId: utils.Ptr(id.String()), WatermarkTime: startWatermark}
+
+	nRows, err = repositories.AnalyticsCopyDecisionCaseOutcomes(ctx, exec, req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to copy decision case outcomes")
+	}
+
+	return nRows, nil
+}
@@ -69,6 +69,7 @@ type asyncDecisionWorkerRepository interface {
 	) (err error)
 	ListWorkflowsForScenario(ctx context.Context, exec repositories.Executor, scenarioId uuid.UUID) ([]models.Workflow, error)
 	GetAnalyticsSettings(ctx context.Context, exec repositories.Executor, orgId uuid.UUID) (map[string]analytics.Settings, error)
+	scheduledExecutionWatermarkRepository
 }
 
 type ScenarioEvaluator interface {
@@ -121,5 +121,8 @@ func (w *AsyncScheduledExecWorker) handleScheduledExecStatusRefres(
 			Status:                     finalStatus,
 		},
 	)
-	return err
+	if err != nil || finalStatus != models.ScheduledExecutionSuccess {
+		return err
+	}
+	return saveScheduledExecutionWatermark(ctx, w.repository, tx, scheduledExec)
 }
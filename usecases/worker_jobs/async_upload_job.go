@@ -97,6 +97,7 @@ func (w AsyncUploadWorker) Work(ctx context.Context, job *river.Job[models.Async
 			OrganizationId: job.Args.OrgId,
 			FileName:       job.Args.Key,
 			TableName:      job.Args.ObjectType,
+			FileFormat:     models.IngestionFileFormatFrom(string(job.Args.FileFormat)),
 			UserId:         uuid.Max.String(),
 			StartedAt:      time.Now(),
 			LinesProcessed: 0,
@@ -121,6 +122,7 @@ func (w AsyncUploadWorker) createUploadError(ctx context.Context, job *river.Job
 			OrganizationId: job.Args.OrgId,
 			FileName:       job.Args.Key,
 			TableName:      job.Args.ObjectType,
+			FileFormat:     models.IngestionFileFormatFrom(string(job.Args.FileFormat)),
 			UserId:         uuid.Max.String(),
 			StartedAt:      time.Now(),
 			LinesProcessed: 0,
@@ -80,6 +80,7 @@ type batchCoordinatorRepository interface {
 		scenarioId string,
 		objectIds []string,
 	) ([]string, error)
+	scheduledExecutionWatermarkRepository
 }
 
 type BatchExecutionCoordinator struct {
@@ -219,7 +220,7 @@ func (c *BatchExecutionCoordinator) Run(ctx context.Context, scheduledExecutionI
 	// A context timeout during the job execution leads to a snooze and retry, the next execution can mark it as failed if the deadline is passed
 	if time.Now().After(*se.Deadline) {
 		if se.ManifestRowsProcessed >= int64(*se.NumberOfPlannedDecisions) {
-			return c.repository.UpdateScheduledExecutionStatus(ctx, exec, models.UpdateScheduledExecutionStatusInput{Id: se.Id, Status: models.ScheduledExecutionSuccess})
+			return c.markSucceeded(ctx, exec, se)
 		} else {
 			logger.InfoContext(ctx, "Scheduled execution not completed after the deadline, marking as failed")
 			return c.markFailed(ctx, se.Id, errors.New("Scheduled execution not completed after the deadline"))
@@ -294,7 +295,7 @@ func (c *BatchExecutionCoordinator) Run(ctx context.Context, scheduledExecutionI
 
 		if rows >= planned {
 			logger.InfoContext(ctx, fmt.Sprintf("batch execution complete: %d decisions created, %d evaluated", created, evaluated))
-			return c.repository.UpdateScheduledExecutionStatus(ctx, exec, models.UpdateScheduledExecutionStatusInput{Id: se.Id, Status: models.ScheduledExecutionSuccess})
+			return c.markSucceeded(ctx, exec, se)
 		}
 
 		if manifestReader == nil {
@@ -315,7 +316,7 @@ func (c *BatchExecutionCoordinator) Run(ctx context.Context, scheduledExecutionI
 			// loop forever; log because counts should have matched.
 			logger.WarnContext(ctx, fmt.Sprintf(
 				"manifest exhausted at %d rows but %d were planned; finalizing", rows, planned))
-			return c.repository.UpdateScheduledExecutionStatus(ctx, exec, models.UpdateScheduledExecutionStatusInput{Id: se.Id, Status: models.ScheduledExecutionSuccess})
+			return c.markSucceeded(ctx, exec, se)
 		}
 		newOffset := offset + consumed
 
@@ -730,6 +731,21 @@ func (c *BatchExecutionCoordinator) testRunCallback(
 	}
 }
 
+// markSucceeded completes the execution and moves the scenario's scheduled execution watermark.
+func (c *BatchExecutionCoordinator) markSucceeded(
+	ctx context.Context,
+	exec repositories.Executor,
+	se models.ScheduledExecution,
+) error {
+	if err := c.repository.UpdateScheduledExecutionStatus(ctx, exec, models.UpdateScheduledExecutionStatusInput{
+		Id:     se.Id,
+		Status: models.ScheduledExecutionSuccess,
+	}); err != nil {
+		return err
+	}
+	return saveScheduledExecutionWatermark(ctx, c.repository, exec, se)
+}
+
 func (c *BatchExecutionCoordinator) markFailed(
 	ctx context.Context,
 	id string,
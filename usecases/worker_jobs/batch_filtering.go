@@ -3,6 +3,7 @@ package worker_jobs
 import (
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/checkmarble/marble-backend/models"
 	"github.com/checkmarble/marble-backend/models/ast"
@@ -27,6 +28,15 @@ func selectFiltersFromTriggerAstRootAnd(node ast.Node, table models.TableIdentif
 	return filters
 }
 
+// writtenAfterFilter selects the objects whose current version was written after the given time.
+func writtenAfterFilter(table models.TableIdentifier, since time.Time) models.Filter {
+	return models.Filter{
+		LeftSql:    pgx.Identifier.Sanitize([]string{table.Schema, table.Table, "valid_from"}),
+		Operator:   ast.FUNC_GREATER,
+		RightValue: since,
+	}
+}
+
 // We ignore filters that only use constant values. Indeed, they are not most useful for filtering the data, and
 // they generate errors once translated to SQL (we pass the values as 'any' to pgx, it needs typehints to compare params
 // if it has no hint from columns or SQL expressions with which we compare them).
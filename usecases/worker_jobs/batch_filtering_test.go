@@ -3,6 +3,7 @@ package worker_jobs
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/checkmarble/marble-backend/dto"
 	"github.com/checkmarble/marble-backend/models"
@@ -199,3 +200,12 @@ func TestFilterFromComparisonNode(t *testing.T) {
 		})
 	}
 }
+
+func TestWrittenAfterFilter(t *testing.T) {
+	since := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+	filter := writtenAfterFilter(models.TableIdentifier{Schema: "schema", Table: "table"}, since)
+
+	sql, args := filter.ToSql()
+	assert.Equal(t, `"schema"."table"."valid_from" > ?`, sql)
+	assert.Equal(t, []any{since}, args)
+}
@@ -82,6 +82,16 @@ func (w *IndexCleanupWorker) Work(ctx context.Context, job *river.Job[models.Ind
 		logger.DebugContext(ctx, "deleted invalid indices", "count", len(deletedIndices), "indices", deletedIndices)
 	}
 
+	// Reads as of a past time need the history index, which a failed build leaves missing once its
+	// invalid index is deleted above.
+	historyTables, err := w.indexEditor.CreateMissingHistoryIndexesAsync(ctx, db)
+	if err != nil {
+		return err
+	}
+	if len(historyTables) > 0 {
+		logger.InfoContext(ctx, "creating history indices", "org", job.Args.OrgId, "tables", historyTables)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,97 @@
+package worker_jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+	"github.com/checkmarble/marble-backend/usecases/executor_factory"
+	"github.com/checkmarble/marble-backend/utils"
+	"github.com/cockroachdb/errors"
+	"github.com/riverqueue/river"
+)
+
+const (
+	INGESTION_DEAD_LETTER_CLEANUP_INTERVAL = 1 * time.Hour
+	INGESTION_DEAD_LETTER_CLEANUP_TIMEOUT  = 5 * time.Minute
+	INGESTION_DEAD_LETTER_RETENTION_PERIOD = 30 * 24 * time.Hour // 30 days
+	INGESTION_DEAD_LETTER_CLEANUP_QUEUE    = "ingestion_dead_letter_cleanup"
+	INGESTION_DEAD_LETTER_CLEANUP_BATCH    = 1000
+)
+
+func NewIngestionDeadLetterCleanupPeriodicJob() *river.PeriodicJob {
+	return NewPeriodicJob(
+		river.PeriodicInterval(INGESTION_DEAD_LETTER_CLEANUP_INTERVAL),
+		func() (river.JobArgs, *river.InsertOpts) {
+			return models.IngestionDeadLetterCleanupArgs{},
+				&river.InsertOpts{
+					Queue:    INGESTION_DEAD_LETTER_CLEANUP_QUEUE,
+					Priority: 4, // Low priority
+					UniqueOpts: river.UniqueOpts{
+						ByQueue:  true,
+						ByPeriod: INGESTION_DEAD_LETTER_CLEANUP_INTERVAL,
+					},
+				}
+		},
+	)
+}
+
+type ingestionDeadLetterCleanupRepository interface {
+	DeleteOldIngestionDeadLettersBatch(ctx context.Context, exec repositories.Executor,
+		olderThan time.Time, limit int) (int64, error)
+}
+
+// IngestionDeadLetterCleanupWorker deletes the rejected ingestion payloads past their retention
+// period, whether they were replayed or not.
+type IngestionDeadLetterCleanupWorker struct {
+	river.WorkerDefaults[models.IngestionDeadLetterCleanupArgs]
+
+	repository      ingestionDeadLetterCleanupRepository
+	executorFactory executor_factory.ExecutorFactory
+	retentionPeriod time.Duration
+	batchSize       int
+}
+
+func NewIngestionDeadLetterCleanupWorker(
+	repository ingestionDeadLetterCleanupRepository,
+	executorFactory executor_factory.ExecutorFactory,
+) *IngestionDeadLetterCleanupWorker {
+	return &IngestionDeadLetterCleanupWorker{
+		repository:      repository,
+		executorFactory: executorFactory,
+		retentionPeriod: INGESTION_DEAD_LETTER_RETENTION_PERIOD,
+		batchSize:       INGESTION_DEAD_LETTER_CLEANUP_BATCH,
+	}
+}
+
+func (w *IngestionDeadLetterCleanupWorker) Timeout(job *river.Job[models.IngestionDeadLetterCleanupArgs]) time.Duration {
+	return INGESTION_DEAD_LETTER_CLEANUP_TIMEOUT
+}
+
+func (w *IngestionDeadLetterCleanupWorker) Work(ctx context.Context, job *river.Job[models.IngestionDeadLetterCleanupArgs]) error {
+	logger := utils.LoggerFromContext(ctx)
+	exec := w.executorFactory.NewExecutor()
+
+	cutoff := time.Now().Add(-w.retentionPeriod)
+
+	var totalDeleted int64
+	for {
+		deleted, err := w.repository.DeleteOldIngestionDeadLettersBatch(ctx, exec, cutoff, w.batchSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete old ingestion dead letters")
+		}
+		totalDeleted += deleted
+		if deleted < int64(w.batchSize) {
+			break
+		}
+	}
+
+	if totalDeleted > 0 {
+		logger.InfoContext(ctx, "Ingestion dead letter cleanup completed",
+			"deleted_dead_letters", totalDeleted,
+			"retention_days", int(w.retentionPeriod.Hours()/24))
+	}
+
+	return nil
+}
@@ -52,6 +52,7 @@ type RunScheduledExecutionRepository interface {
 		input models.UpdateScheduledExecutionInput,
 	) error
 	GetScheduledExecution(ctx context.Context, exec repositories.Executor, id string) (models.ScheduledExecution, error)
+	scheduledExecutionWatermarkRepository
 }
 
 type taskQueueRepository interface {
@@ -153,12 +154,23 @@ func (usecase *RunScheduledExecution) ExecuteScheduledExecutionById(
 	if err != nil {
 		return err
 	}
+	table := models.TableIdentifier{Table: scenario.TriggerObjectType, Schema: db.DatabaseSchema().Schema}
 	var filters []models.Filter
 	if liveVersion.TriggerConditionAstExpression != nil {
-		filters = selectFiltersFromTriggerAstRootAnd(
-			*liveVersion.TriggerConditionAstExpression,
-			models.TableIdentifier{Table: scenario.TriggerObjectType, Schema: db.DatabaseSchema().Schema},
-		)
+		filters = selectFiltersFromTriggerAstRootAnd(*liveVersion.TriggerConditionAstExpression, table)
+	}
+
+	// In incremental mode, only the objects written since the previous successful execution are
+	// evaluated, unless a full refresh is due.
+	scheduledExecution.IncrementalSince, err = incrementalExecutionSince(ctx, usecase.repository, exec,
+		scheduledExecution, time.Now())
+	if err != nil {
+		return err
+	}
+	if scheduledExecution.IncrementalSince != nil {
+		logger.InfoContext(ctx, fmt.Sprintf("Incremental execution over the objects written since %s",
+			scheduledExecution.IncrementalSince.Format(time.RFC3339)))
+		filters = append(filters, writtenAfterFilter(table, *scheduledExecution.IncrementalSince))
 	}
 
 	// When enabled for the org, process the execution by streaming a manifest of object ids to
@@ -188,6 +200,7 @@ func (usecase *RunScheduledExecution) ExecuteScheduledExecutionById(
 	err = usecase.repository.UpdateScheduledExecution(ctx, exec, models.UpdateScheduledExecutionInput{
 		Id:                       scheduledExecutionId,
 		NumberOfPlannedDecisions: &nbPlannedDecisions,
+		IncrementalSince:         scheduledExecution.IncrementalSince,
 	})
 	if err != nil {
 		return err
@@ -293,6 +306,7 @@ func (usecase *RunScheduledExecution) executeViaManifest(
 			NumberOfPlannedDecisions: &nbPlanned,
 			ManifestBlobKey:          &manifestKey,
 			Deadline:                 &deadline,
+			IncrementalSince:         scheduledExecution.IncrementalSince,
 		})
 		if err != nil {
 			return err
@@ -0,0 +1,123 @@
+package worker_jobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"github.com/checkmarble/marble-backend/models"
+	"github.com/checkmarble/marble-backend/repositories"
+)
+
+// The ingestion writes valid_from with the start time of its transaction, so an object can become
+// visible with a valid_from slightly older than the watermark it should be newer than. Incremental
+// executions re-read this margin before the watermark rather than miss those objects.
+const incrementalExecutionOverlap = 10 * time.Minute
+
+type scheduledExecutionWatermarkRepository interface {
+	GetWatermark(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId *uuid.UUID,
+		watermarkType models.WatermarkType,
+	) (*models.Watermark, error)
+	SaveWatermark(
+		ctx context.Context,
+		exec repositories.Executor,
+		orgId *uuid.UUID,
+		watermarkType models.WatermarkType,
+		watermarkId *string,
+		watermarkTime time.Time,
+		params json.RawMessage,
+	) error
+}
+
+func getScheduledExecutionWatermark(
+	ctx context.Context,
+	repository scheduledExecutionWatermarkRepository,
+	exec repositories.Executor,
+	orgId uuid.UUID,
+	scenarioId string,
+) (*models.Watermark, models.ScheduledExecutionWatermarkParams, error) {
+	var params models.ScheduledExecutionWatermarkParams
+
+	watermark, err := repository.GetWatermark(ctx, exec, &orgId, models.ScheduledExecutionWatermarkType(scenarioId))
+	if err != nil {
+		return nil, params, errors.Wrap(err, "could not get scheduled execution watermark")
+	}
+	if watermark == nil || len(watermark.Params) == 0 {
+		return watermark, params, nil
+	}
+	if err := json.Unmarshal(watermark.Params, &params); err != nil {
+		return nil, params, errors.Wrap(err, "could not parse scheduled execution watermark params")
+	}
+	return watermark, params, nil
+}
+
+// incrementalExecutionSince returns the time after which objects must have been written to be
+// evaluated by the scheduled execution, or nil if the execution must evaluate every object. Manual
+// executions are always full.
+func incrementalExecutionSince(
+	ctx context.Context,
+	repository scheduledExecutionWatermarkRepository,
+	exec repositories.Executor,
+	scheduledExecution models.ScheduledExecution,
+	now time.Time,
+) (*time.Time, error) {
+	scenario := scheduledExecution.Scenario
+	if scheduledExecution.Manual || !scenario.IncrementalScheduledExecution {
+		return nil, nil
+	}
+
+	watermark, params, err := getScheduledExecutionWatermark(ctx, repository, exec,
+		scheduledExecution.OrganizationId, scheduledExecution.ScenarioId)
+	if err != nil {
+		return nil, err
+	}
+	if watermark == nil || params.FullRefreshDue(scenario, scheduledExecution.ScenarioIterationId, now) {
+		return nil, nil
+	}
+
+	since := watermark.WatermarkTime.Add(-incrementalExecutionOverlap)
+	return &since, nil
+}
+
+// saveScheduledExecutionWatermark moves the scenario's watermark to the start of a successful
+// execution. Objects written after that time were possibly not listed by the execution, so they
+// are left to the next one. The watermark never moves back, in case executions overlapped.
+func saveScheduledExecutionWatermark(
+	ctx context.Context,
+	repository scheduledExecutionWatermarkRepository,
+	exec repositories.Executor,
+	scheduledExecution models.ScheduledExecution,
+) error {
+	watermark, previous, err := getScheduledExecutionWatermark(ctx, repository, exec,
+		scheduledExecution.OrganizationId, scheduledExecution.ScenarioId)
+	if err != nil {
+		return err
+	}
+	if watermark != nil && watermark.WatermarkTime.After(scheduledExecution.StartedAt) {
+		return nil
+	}
+
+	params := models.ScheduledExecutionWatermarkParams{
+		ScenarioIterationId: scheduledExecution.ScenarioIterationId,
+		LastFullRefreshAt:   scheduledExecution.StartedAt,
+	}
+	if scheduledExecution.IncrementalSince != nil {
+		params.LastFullRefreshAt = previous.LastFullRefreshAt
+	}
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return errors.Wrap(err, "could not serialize scheduled execution watermark params")
+	}
+
+	return errors.Wrap(
+		repository.SaveWatermark(ctx, exec, &scheduledExecution.OrganizationId,
+			models.ScheduledExecutionWatermarkType(scheduledExecution.ScenarioId),
+			&scheduledExecution.Id, scheduledExecution.StartedAt, rawParams),
+		"could not save scheduled execution watermark")
+}
@@ -0,0 +1,163 @@
+package worker_jobs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/checkmarble/marble-backend/mocks"
+	"github.com/checkmarble/marble-backend/models"
+)
+
+func scheduledExecutionWatermark(t *testing.T, watermarkTime time.Time, params models.ScheduledExecutionWatermarkParams) *models.Watermark {
+	rawParams, err := json.Marshal(params)
+	assert.NoError(t, err)
+	return &models.Watermark{WatermarkTime: watermarkTime, Params: rawParams}
+}
+
+func TestIncrementalExecutionSince(t *testing.T) {
+	ctx := context.Background()
+	orgId := uuid.New()
+	now := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+	lastStart := now.AddDate(0, 0, -1)
+	watermarkType := models.ScheduledExecutionWatermarkType("scenario")
+
+	execution := models.ScheduledExecution{
+		Id:                  "execution",
+		OrganizationId:      orgId,
+		ScenarioId:          "scenario",
+		ScenarioIterationId: "iteration",
+		Scenario: models.Scenario{
+			IncrementalScheduledExecution: true,
+			FullRefreshIntervalDays:       7,
+		},
+	}
+
+	t.Run("not incremental", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		scenarioExecution := execution
+		scenarioExecution.Scenario.IncrementalScheduledExecution = false
+
+		since, err := incrementalExecutionSince(ctx, repository, nil, scenarioExecution, now)
+		assert.NoError(t, err)
+		assert.Nil(t, since)
+
+		manualExecution := execution
+		manualExecution.Manual = true
+
+		since, err = incrementalExecutionSince(ctx, repository, nil, manualExecution, now)
+		assert.NoError(t, err)
+		assert.Nil(t, since)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("no previous successful execution", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		repository.On("GetWatermark", ctx, nil, &orgId, watermarkType).Return((*models.Watermark)(nil), nil)
+
+		since, err := incrementalExecutionSince(ctx, repository, nil, execution, now)
+		assert.NoError(t, err)
+		assert.Nil(t, since)
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("incremental", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		repository.On("GetWatermark", ctx, nil, &orgId, watermarkType).Return(
+			scheduledExecutionWatermark(t, lastStart, models.ScheduledExecutionWatermarkParams{
+				ScenarioIterationId: "iteration",
+				LastFullRefreshAt:   now.AddDate(0, 0, -3),
+			}), nil)
+
+		since, err := incrementalExecutionSince(ctx, repository, nil, execution, now)
+		assert.NoError(t, err)
+		if assert.NotNil(t, since) {
+			assert.Equal(t, lastStart.Add(-incrementalExecutionOverlap), *since)
+		}
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("full refresh due", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		repository.On("GetWatermark", ctx, nil, &orgId, watermarkType).Return(
+			scheduledExecutionWatermark(t, lastStart, models.ScheduledExecutionWatermarkParams{
+				ScenarioIterationId: "iteration",
+				LastFullRefreshAt:   now.AddDate(0, 0, -7),
+			}), nil)
+
+		since, err := incrementalExecutionSince(ctx, repository, nil, execution, now)
+		assert.NoError(t, err)
+		assert.Nil(t, since)
+		repository.AssertExpectations(t)
+	})
+}
+
+func TestSaveScheduledExecutionWatermark(t *testing.T) {
+	ctx := context.Background()
+	orgId := uuid.New()
+	startedAt := time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC)
+	lastFullRefresh := startedAt.AddDate(0, 0, -3)
+	watermarkType := models.ScheduledExecutionWatermarkType("scenario")
+	previousWatermark := scheduledExecutionWatermark(t, startedAt.AddDate(0, 0, -1),
+		models.ScheduledExecutionWatermarkParams{
+			ScenarioIterationId: "iteration",
+			LastFullRefreshAt:   lastFullRefresh,
+		})
+
+	execution := models.ScheduledExecution{
+		Id:                  "execution",
+		OrganizationId:      orgId,
+		ScenarioId:          "scenario",
+		ScenarioIterationId: "iteration",
+		StartedAt:           startedAt,
+	}
+
+	expectSave := func(repository *mocks.WatermarkRepository, params models.ScheduledExecutionWatermarkParams) {
+		rawParams, err := json.Marshal(params)
+		assert.NoError(t, err)
+		repository.On("SaveWatermark", ctx, nil, &orgId, watermarkType, &execution.Id, startedAt,
+			json.RawMessage(rawParams)).Return(nil)
+	}
+
+	t.Run("full execution", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		repository.On("GetWatermark", ctx, nil, &orgId, watermarkType).Return(previousWatermark, nil)
+		expectSave(repository, models.ScheduledExecutionWatermarkParams{
+			ScenarioIterationId: "iteration",
+			LastFullRefreshAt:   startedAt,
+		})
+
+		assert.NoError(t, saveScheduledExecutionWatermark(ctx, repository, nil, execution))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("incremental execution", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		repository.On("GetWatermark", ctx, nil, &orgId, watermarkType).Return(previousWatermark, nil)
+		expectSave(repository, models.ScheduledExecutionWatermarkParams{
+			ScenarioIterationId: "iteration",
+			LastFullRefreshAt:   lastFullRefresh,
+		})
+
+		incrementalExecution := execution
+		incrementalExecution.IncrementalSince = &previousWatermark.WatermarkTime
+
+		assert.NoError(t, saveScheduledExecutionWatermark(ctx, repository, nil, incrementalExecution))
+		repository.AssertExpectations(t)
+	})
+
+	t.Run("watermark does not move back", func(t *testing.T) {
+		repository := new(mocks.WatermarkRepository)
+		repository.On("GetWatermark", ctx, nil, &orgId, watermarkType).Return(
+			scheduledExecutionWatermark(t, startedAt.Add(time.Hour), models.ScheduledExecutionWatermarkParams{}), nil)
+
+		assert.NoError(t, saveScheduledExecutionWatermark(ctx, repository, nil, execution))
+		repository.AssertNotCalled(t, "SaveWatermark", mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}